@@ -0,0 +1,89 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bigquery
+
+import (
+	"errors"
+
+	"github.com/apache/arrow/go/v12/arrow"
+)
+
+// ArrowIterator provides access to the result of a BigQuery lookup as Arrow
+// record batches read with the Storage Read API.
+//
+// Records returned by Next are retained for the caller, who must call Release
+// on each of them once done.
+type ArrowIterator struct {
+	it      *arrowIterator
+	records []arrow.Record
+}
+
+// ArrowIterator returns an iterator over the rows of it as Arrow records.
+// It is only available when the RowIterator is accelerated by the Storage
+// Read API; see Client.EnableStorageReadClient and IsAccelerated.
+//
+// The ArrowIterator consumes the same underlying streams as Next, so only one
+// of them should be used for a given RowIterator.
+func (it *RowIterator) ArrowIterator() (*ArrowIterator, error) {
+	if !it.IsAccelerated() {
+		return nil, errors.New("bigquery: ArrowIterator requires a RowIterator accelerated by the Storage Read API")
+	}
+	return &ArrowIterator{it: it.arrowIterator}, nil
+}
+
+// Next returns the next Arrow record. Its second return value is
+// iterator.Done if there are no more records. The caller must call Release on
+// the returned record.
+func (ai *ArrowIterator) Next() (arrow.Record, error) {
+	for len(ai.records) == 0 {
+		batch, err := ai.it.next()
+		if err != nil {
+			return nil, err
+		}
+		records, err := ai.it.decoder.decodeRetainedArrowRecords(batch)
+		if err != nil {
+			return nil, err
+		}
+		ai.records = records
+	}
+	rec := ai.records[0]
+	ai.records = ai.records[1:]
+	return rec, nil
+}
+
+// Schema returns the BigQuery schema of the records.
+func (ai *ArrowIterator) Schema() (Schema, error) {
+	if err := ai.it.init(); err != nil {
+		return nil, err
+	}
+	return ai.it.schema, nil
+}
+
+// ArrowSchema returns the Arrow schema of the records.
+func (ai *ArrowIterator) ArrowSchema() (*arrow.Schema, error) {
+	if err := ai.it.init(); err != nil {
+		return nil, err
+	}
+	return ai.it.decoder.arrowSchema, nil
+}
+
+// Release releases the records decoded but not yet returned by Next. It
+// should be called when the caller stops iterating before the end.
+func (ai *ArrowIterator) Release() {
+	for _, rec := range ai.records {
+		rec.Release()
+	}
+	ai.records = nil
+}
@@ -0,0 +1,128 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bigquery
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"cloud.google.com/go/bigquery/storage/apiv1/storagepb"
+	"github.com/apache/arrow/go/v12/arrow"
+	"github.com/apache/arrow/go/v12/arrow/array"
+	"github.com/apache/arrow/go/v12/arrow/ipc"
+	"github.com/apache/arrow/go/v12/arrow/memory"
+	"google.golang.org/api/iterator"
+)
+
+// serializeArrowRecord returns the serialized schema and record batch of rec,
+// as they are sent by the Storage Read API.
+func serializeArrowRecord(t *testing.T, rec arrow.Record) (schema, batch []byte) {
+	var sbuf bytes.Buffer
+	w := ipc.NewWriter(&sbuf, ipc.WithSchema(rec.Schema()))
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	// Drop the end-of-stream marker written by Close.
+	schema = sbuf.Bytes()[:sbuf.Len()-8]
+
+	var buf bytes.Buffer
+	w = ipc.NewWriter(&buf, ipc.WithSchema(rec.Schema()))
+	if err := w.Write(rec); err != nil {
+		t.Fatal(err)
+	}
+	return schema, buf.Bytes()[len(schema):]
+}
+
+func TestArrowIterator(t *testing.T) {
+	arrowSchema := arrow.NewSchema([]arrow.Field{
+		{Name: "name", Type: arrow.BinaryTypes.String},
+		{Name: "num", Type: arrow.PrimitiveTypes.Int64},
+	}, nil)
+	b := array.NewRecordBuilder(memory.NewGoAllocator(), arrowSchema)
+	defer b.Release()
+	b.Field(0).(*array.StringBuilder).AppendValues([]string{"a", "b"}, nil)
+	b.Field(1).(*array.Int64Builder).AppendValues([]int64{1, 2}, nil)
+	rec := b.NewRecord()
+	defer rec.Release()
+	rawSchema, batch := serializeArrowRecord(t, rec)
+
+	schema := Schema{
+		{Name: "name", Type: StringFieldType},
+		{Name: "num", Type: IntegerFieldType},
+	}
+	session := &readSession{
+		bqSession: &storagepb.ReadSession{
+			Schema: &storagepb.ReadSession_ArrowSchema{
+				ArrowSchema: &storagepb.ArrowSchema{SerializedSchema: rawSchema},
+			},
+		},
+	}
+	decoder, err := newArrowDecoderFromSession(session, schema)
+	if err != nil {
+		t.Fatal(err)
+	}
+	records := make(chan arrowRecordBatch, 2)
+	records <- batch
+	records <- batch
+	close(records)
+	rit := &RowIterator{
+		arrowIterator: &arrowIterator{
+			ctx:     context.Background(),
+			errs:    make(chan error),
+			schema:  schema,
+			decoder: decoder,
+			records: records,
+			session: session,
+		},
+	}
+
+	it, err := rit.ArrowIterator()
+	if err != nil {
+		t.Fatal(err)
+	}
+	gotSchema, err := it.ArrowSchema()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !gotSchema.Equal(arrowSchema) {
+		t.Errorf("got schema %v, want %v", gotSchema, arrowSchema)
+	}
+	var n int
+	for {
+		got, err := it.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !array.RecordEqual(got, rec) {
+			t.Errorf("record %d: got %v, want %v", n, got, rec)
+		}
+		got.Release()
+		n++
+	}
+	if n != 2 {
+		t.Errorf("got %d records, want 2", n)
+	}
+}
+
+func TestArrowIteratorNotAccelerated(t *testing.T) {
+	it := &RowIterator{}
+	if _, err := it.ArrowIterator(); err == nil {
+		t.Error("got nil error, want error")
+	}
+}
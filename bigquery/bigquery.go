@@ -103,6 +103,7 @@ func NewClient(ctx context.Context, projectID string, opts ...option.ClientOptio
 // large datasets from tables, jobs or queries.
 // Currently out of pagination methods like PageInfo().Token and RowIterator.StartIndex
 // are not supported when the Storage API is enabled.
+// Accelerated results can also be read as Arrow records with RowIterator.ArrowIterator.
 // Calling this method twice will return an error.
 func (c *Client) EnableStorageReadClient(ctx context.Context, opts ...option.ClientOption) error {
 	if c.isStorageReadAvailable() {
@@ -139,16 +140,41 @@ func (c *Client) Close() error {
 	return nil
 }
 
-// Calls the Jobs.Insert RPC and returns a Job.
-func (c *Client) insertJob(ctx context.Context, job *bq.Job, media io.Reader) (*Job, error) {
+// mediaUpload is data uploaded with a job, along with the options that
+// control the upload.
+type mediaUpload struct {
+	r        io.Reader
+	opts     []googleapi.MediaOption
+	progress googleapi.ProgressUpdater
+}
+
+// Calls the Jobs.Insert RPC and returns a Job. The ID and retries of the job
+// are controlled by idc, which may be nil.
+func (c *Client) insertJob(ctx context.Context, job *bq.Job, media *mediaUpload, idc *JobIDConfig) (*Job, error) {
+	var policy *JobRetryPolicy
+	if idc != nil {
+		if idc.DeterministicJobID && job.JobReference != nil {
+			id, err := idc.deterministicJobID(job)
+			if err != nil {
+				return nil, err
+			}
+			job.JobReference.JobId = id
+		}
+		policy = idc.RetryPolicy
+	}
 	call := c.bqs.Jobs.Insert(c.projectID, job).Context(ctx)
 	setClientHeader(call.Header())
 	if media != nil {
-		call.Media(media)
+		call.Media(media.r, media.opts...)
+		if media.progress != nil {
+			call.ProgressUpdater(media.progress)
+		}
 	}
 	var res *bq.Job
 	var err error
+	attempts := 0
 	invoke := func() error {
+		attempts++
 		sCtx := trace.StartSpan(ctx, "bigquery.jobs.insert")
 		res, err = call.Do()
 		trace.EndSpan(sCtx, err)
@@ -161,20 +187,38 @@ func (c *Client) insertJob(ctx context.Context, job *bq.Job, media io.Reader) (*
 	// TODO(jba): Look into retrying if media != nil.
 	if job.JobReference != nil && media == nil {
 		// We deviate from default retries due to BigQuery wanting to retry structured internal job errors.
-		err = runWithRetryExplicit(ctx, invoke, jobRetryReasons)
+		err = runWithRetryPolicy(ctx, invoke, jobRetryReasons, policy)
 	} else {
 		err = invoke()
 	}
+	// A conflict after a retry means an earlier attempt created the job. With
+	// a deterministic ID, it means the same job was submitted before.
+	if isConflict(err) && (attempts > 1 || (idc != nil && idc.DeterministicJobID)) {
+		ref := job.JobReference
+		res, err = c.getJobInternal(ctx, ref.JobId, ref.Location, ref.ProjectId)
+	}
 	if err != nil {
 		return nil, err
 	}
-	return bqToJob(res, c)
+	j, err := bqToJob(res, c)
+	if err != nil {
+		return nil, err
+	}
+	j.insertAttempts = attempts
+	return j, nil
+}
+
+// isConflict reports whether err is an HTTP 409 (Conflict) error, which
+// jobs.insert returns for an existing job ID.
+func isConflict(err error) bool {
+	var e *googleapi.Error
+	return errors.As(err, &e) && e.Code == http.StatusConflict
 }
 
 // runQuery invokes the optimized query path.
 // Due to differences in options it supports, it cannot be used for all existing
 // jobs.insert requests that are query jobs.
-func (c *Client) runQuery(ctx context.Context, queryRequest *bq.QueryRequest) (*bq.QueryResponse, error) {
+func (c *Client) runQuery(ctx context.Context, queryRequest *bq.QueryRequest, policy *JobRetryPolicy) (*bq.QueryResponse, error) {
 	call := c.bqs.Jobs.Query(c.projectID, queryRequest).Context(ctx)
 	setClientHeader(call.Header())
 
@@ -188,7 +232,7 @@ func (c *Client) runQuery(ctx context.Context, queryRequest *bq.QueryRequest) (*
 	}
 
 	// We control request ID, so we can always runWithRetry.
-	err = runWithRetryExplicit(ctx, invoke, jobRetryReasons)
+	err = runWithRetryPolicy(ctx, invoke, jobRetryReasons, policy)
 	if err != nil {
 		return nil, err
 	}
@@ -214,17 +258,35 @@ func runWithRetry(ctx context.Context, call func() error) error {
 }
 
 func runWithRetryExplicit(ctx context.Context, call func() error, allowedReasons []string) error {
+	return runWithRetryPolicy(ctx, call, allowedReasons, nil)
+}
+
+// runWithRetryPolicy is like runWithRetryExplicit, with the backoff and
+// maximum number of attempts taken from policy, if it is non-nil.
+func runWithRetryPolicy(ctx context.Context, call func() error, allowedReasons []string, policy *JobRetryPolicy) error {
 	// These parameters match the suggestions in https://cloud.google.com/bigquery/sla.
 	backoff := gax.Backoff{
 		Initial:    1 * time.Second,
 		Max:        32 * time.Second,
 		Multiplier: 2,
 	}
+	maxAttempts := 0
+	if policy != nil {
+		if policy.Backoff.Initial != 0 {
+			backoff = policy.Backoff
+		}
+		maxAttempts = policy.MaxAttempts
+	}
+	attempts := 0
 	return cloudinternal.Retry(ctx, backoff, func() (stop bool, err error) {
+		attempts++
 		err = call()
 		if err == nil {
 			return true, nil
 		}
+		if maxAttempts > 0 && attempts >= maxAttempts {
+			return true, err
+		}
 		return !retryableError(err, allowedReasons), err
 	})
 }
@@ -15,14 +15,24 @@
 package bigquery
 
 import (
+	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
 	"io"
 	"net/http"
+	"net/http/httptest"
 	"net/url"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 
+	gax "github.com/googleapis/gax-go/v2"
 	"golang.org/x/xerrors"
+	bq "google.golang.org/api/bigquery/v2"
 	"google.golang.org/api/googleapi"
+	"google.golang.org/api/option"
 )
 
 func TestRetryableErrors(t *testing.T) {
@@ -125,3 +135,125 @@ func TestRetryableErrors(t *testing.T) {
 		}
 	}
 }
+
+// jobsServer serves jobs.insert with a scripted sequence of status codes,
+// and jobs.get for any job.
+type jobsServer struct {
+	mu      sync.Mutex
+	codes   []int // status of each insert; 200 once exhausted
+	inserts []string
+	gets    int
+}
+
+func (s *jobsServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	w.Header().Set("Content-Type", "application/json")
+	if r.Method == http.MethodGet {
+		s.gets++
+		id := r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:]
+		json.NewEncoder(w).Encode(&bq.Job{JobReference: &bq.JobReference{ProjectId: "p", JobId: id}})
+		return
+	}
+	var job bq.Job
+	if err := json.NewDecoder(r.Body).Decode(&job); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	s.inserts = append(s.inserts, job.JobReference.JobId)
+	code := http.StatusOK
+	if len(s.inserts) <= len(s.codes) {
+		code = s.codes[len(s.inserts)-1]
+	}
+	w.WriteHeader(code)
+	if code != http.StatusOK {
+		fmt.Fprintf(w, `{"error": {"code": %d, "message": "status %[1]d"}}`, code)
+		return
+	}
+	json.NewEncoder(w).Encode(&job)
+}
+
+func TestInsertJobRetries(t *testing.T) {
+	ctx := context.Background()
+	fastRetries := &JobRetryPolicy{Backoff: gax.Backoff{Initial: time.Millisecond, Max: time.Millisecond}}
+	newClient := func(s *jobsServer) *Client {
+		srv := httptest.NewServer(s)
+		t.Cleanup(srv.Close)
+		c, err := NewClient(ctx, "p", option.WithEndpoint(srv.URL), option.WithHTTPClient(srv.Client()))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return c
+	}
+	copier := func(c *Client) *Copier {
+		cp := c.Dataset("d").Table("dst").CopierFrom(c.Dataset("d").Table("src"))
+		cp.RetryPolicy = fastRetries
+		return cp
+	}
+
+	// A conflict after a retry returns the job created by the first attempt.
+	s := &jobsServer{codes: []int{http.StatusServiceUnavailable, http.StatusConflict}}
+	job, err := copier(newClient(s)).Run(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := job.InsertAttempts(), 2; got != want {
+		t.Errorf("InsertAttempts: got %d, want %d", got, want)
+	}
+	if s.gets != 1 || job.ID() != s.inserts[0] || s.inserts[0] != s.inserts[1] {
+		t.Errorf("got job %q after inserts %v and %d gets", job.ID(), s.inserts, s.gets)
+	}
+
+	// A conflict on the first attempt is an error, unless the ID is deterministic.
+	s = &jobsServer{codes: []int{http.StatusConflict, http.StatusConflict}}
+	c := newClient(s)
+	if _, err := copier(c).Run(ctx); !isConflict(err) {
+		t.Errorf("got %v, want conflict", err)
+	}
+	cp := copier(c)
+	cp.DeterministicJobID = true
+	if job, err = cp.Run(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if job.InsertAttempts() != 1 || s.gets != 1 {
+		t.Errorf("deterministic ID: got %d attempts, %d gets", job.InsertAttempts(), s.gets)
+	}
+
+	// MaxAttempts bounds retries.
+	s = &jobsServer{codes: []int{http.StatusServiceUnavailable, http.StatusServiceUnavailable}}
+	cp = copier(newClient(s))
+	cp.RetryPolicy = &JobRetryPolicy{Backoff: fastRetries.Backoff, MaxAttempts: 1}
+	if _, err := cp.Run(ctx); err == nil || len(s.inserts) != 1 {
+		t.Errorf("MaxAttempts 1: got error %v after %d inserts, want error after 1", err, len(s.inserts))
+	}
+}
+
+func TestDeterministicJobID(t *testing.T) {
+	c := &Client{projectID: "p"}
+	newJob := func(idc JobIDConfig, dst string) *bq.Job {
+		cp := c.Dataset("d").Table(dst).CopierFrom(c.Dataset("d").Table("src"))
+		cp.JobIDConfig = idc
+		job := cp.newJob()
+		id, err := idc.deterministicJobID(job)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return &bq.Job{JobReference: &bq.JobReference{JobId: id}}
+	}
+	idc := JobIDConfig{DeterministicJobID: true}
+	a, b := newJob(idc, "t1"), newJob(idc, "t1")
+	if a.JobReference.JobId != b.JobReference.JobId {
+		t.Errorf("identical jobs: got IDs %q and %q", a.JobReference.JobId, b.JobReference.JobId)
+	}
+	if other := newJob(idc, "t2"); other.JobReference.JobId == a.JobReference.JobId {
+		t.Errorf("different jobs: both got ID %q", a.JobReference.JobId)
+	}
+	idc.JobID, idc.AddJobIDSuffix = "daily", true
+	if got := newJob(idc, "t1").JobReference.JobId; got != "daily-"+a.JobReference.JobId {
+		t.Errorf("with suffix: got %q, want %q", got, "daily-"+a.JobReference.JobId)
+	}
+	idc.AddJobIDSuffix = false
+	if got := newJob(idc, "t1").JobReference.JobId; got != "daily" {
+		t.Errorf("without suffix: got %q, want %q", got, "daily")
+	}
+}
@@ -0,0 +1,59 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bqtest_test
+
+import (
+	"context"
+	"fmt"
+
+	"cloud.google.com/go/bigquery"
+	"cloud.google.com/go/bigquery/bqtest"
+	"google.golang.org/api/iterator"
+)
+
+func ExampleServer() {
+	ctx := context.Background()
+	srv := bqtest.NewServer()
+	defer srv.Close()
+
+	// Register the result of the query under test.
+	schema := bigquery.Schema{{Name: "total", Type: bigquery.IntegerFieldType}}
+	if err := srv.AddQueryResult("SELECT SUM(n) AS total FROM ds.t", schema, [][]bigquery.Value{{int64(42)}}); err != nil {
+		// TODO: Handle error.
+	}
+
+	client, err := bigquery.NewClient(ctx, "project", srv.ClientOptions()...)
+	if err != nil {
+		// TODO: Handle error.
+	}
+	defer client.Close()
+
+	it, err := client.Query("SELECT SUM(n) AS total FROM ds.t").Read(ctx)
+	if err != nil {
+		// TODO: Handle error.
+	}
+	for {
+		var row []bigquery.Value
+		err := it.Next(&row)
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			// TODO: Handle error.
+		}
+		fmt.Println(row[0])
+	}
+	// Output: 42
+}
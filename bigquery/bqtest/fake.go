@@ -0,0 +1,959 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package bqtest provides a fake BigQuery service for testing. It implements
+// a simplified, in-memory form of the REST API used by
+// cloud.google.com/go/bigquery, suitable for unit tests:
+//
+//   - datasets and tables can be created, read, updated, listed and deleted;
+//   - rows can be streamed with an Inserter, loaded from a ReaderSource in
+//     CSV or newline-delimited JSON format, copied between tables, and read
+//     back;
+//   - queries return results registered with AddQueryResult, or are evaluated
+//     if they have the form "SELECT <columns or *> FROM <table> [LIMIT <n>]".
+//
+// The fake does not evaluate any other SQL, nor does it support loads from
+// Cloud Storage, extract jobs, routines, models or the Storage APIs.
+//
+// This package is EXPERIMENTAL and is subject to change without notice.
+//
+// See the example for usage.
+package bqtest
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"cloud.google.com/go/bigquery"
+	bq "google.golang.org/api/bigquery/v2"
+	"google.golang.org/api/option"
+)
+
+const (
+	basePath   = "/bigquery/v2/"
+	uploadPath = "/upload/bigquery/v2/"
+)
+
+// Server is a fake BigQuery server.
+type Server struct {
+	srv *httptest.Server
+
+	// URL is the base URL of the server.
+	URL string
+
+	mu       sync.Mutex
+	datasets map[string]*dataset // keyed by "project:dataset"
+	jobs     map[string]*job     // keyed by "project:job"
+	results  map[string]*result  // keyed by normalized query text
+	queries  []string
+	nextID   int
+}
+
+type dataset struct {
+	meta   *bq.Dataset
+	tables map[string]*table
+}
+
+type table struct {
+	meta *bq.Table
+	rows [][]interface{}
+}
+
+type job struct {
+	job    *bq.Job
+	err    error
+	schema *bq.TableSchema
+	rows   [][]interface{}
+}
+
+// result holds the schema and rows of a query, in the REST representation.
+type result struct {
+	schema *bq.TableSchema
+	rows   [][]interface{}
+}
+
+// NewServer creates a new fake server running in the current process.
+func NewServer() *Server {
+	s := &Server{
+		datasets: map[string]*dataset{},
+		jobs:     map[string]*job{},
+		results:  map[string]*result{},
+	}
+	s.srv = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	s.URL = s.srv.URL
+	return s
+}
+
+// Close shuts down the server.
+func (s *Server) Close() {
+	s.srv.Close()
+}
+
+// ClientOptions returns the options that connect a client created with
+// bigquery.NewClient to the server.
+func (s *Server) ClientOptions() []option.ClientOption {
+	return []option.ClientOption{
+		option.WithEndpoint(s.URL + basePath),
+		option.WithHTTPClient(s.srv.Client()),
+	}
+}
+
+// AddQueryResult registers the result of a query. Queries whose text matches
+// query, ignoring differences in whitespace, return schema and rows regardless
+// of their parameters. Rows hold the values of schema's fields in order, with
+// the same Go types as returned by bigquery.RowIterator; nested records are
+// []bigquery.Value.
+func (s *Server) AddQueryResult(query string, schema bigquery.Schema, rows [][]bigquery.Value) error {
+	ts, err := toBQSchema(schema)
+	if err != nil {
+		return err
+	}
+	cells, err := valuesToRows(rows, ts)
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.results[normalizeQuery(query)] = &result{schema: ts, rows: cells}
+	return nil
+}
+
+// Queries returns the text of the queries the server has run, in order,
+// including dry runs.
+func (s *Server) Queries() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.queries...)
+}
+
+// apiError is an error returned in the format of the REST API.
+type apiError struct {
+	code   int
+	reason string
+	msg    string
+}
+
+func (e *apiError) Error() string { return e.msg }
+
+func notFound(format string, args ...interface{}) error {
+	return &apiError{code: http.StatusNotFound, reason: "notFound", msg: fmt.Sprintf(format, args...)}
+}
+
+func invalid(format string, args ...interface{}) error {
+	return &apiError{code: http.StatusBadRequest, reason: "invalid", msg: fmt.Sprintf(format, args...)}
+}
+
+func invalidQuery(format string, args ...interface{}) error {
+	return &apiError{code: http.StatusBadRequest, reason: "invalidQuery", msg: fmt.Sprintf(format, args...)}
+}
+
+func duplicate(format string, args ...interface{}) error {
+	return &apiError{code: http.StatusConflict, reason: "duplicate", msg: fmt.Sprintf(format, args...)}
+}
+
+func errorProto(err error) *bq.ErrorProto {
+	reason := "invalid"
+	if e, ok := err.(*apiError); ok {
+		reason = e.reason
+	}
+	return &bq.ErrorProto{Reason: reason, Message: err.Error()}
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	resp, err := s.handle(r)
+	if err != nil {
+		e, ok := err.(*apiError)
+		if !ok {
+			e = &apiError{code: http.StatusBadRequest, reason: "invalid", msg: err.Error()}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(e.code)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"error": map[string]interface{}{
+				"code":    e.code,
+				"message": e.msg,
+				"errors":  []interface{}{map[string]string{"reason": e.reason, "message": e.msg}},
+			},
+		})
+		return
+	}
+	if resp == nil {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+func (s *Server) handle(r *http.Request) (interface{}, error) {
+	path := r.URL.EscapedPath()
+	upload := strings.HasPrefix(path, uploadPath)
+	switch {
+	case upload:
+		path = strings.TrimPrefix(path, uploadPath)
+	case strings.HasPrefix(path, basePath):
+		path = strings.TrimPrefix(path, basePath)
+	default:
+		return nil, notFound("bqtest: unknown path %q", r.URL.Path)
+	}
+	var parts []string
+	for _, p := range strings.Split(strings.Trim(path, "/"), "/") {
+		u, err := url.PathUnescape(p)
+		if err != nil {
+			return nil, invalid("bqtest: bad path %q", r.URL.Path)
+		}
+		parts = append(parts, u)
+	}
+	if len(parts) < 3 || parts[0] != "projects" {
+		return nil, notFound("bqtest: unknown path %q", r.URL.Path)
+	}
+	project, rest := parts[1], parts[2:]
+	q := r.URL.Query()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	route := r.Method + " " + rest[0]
+	switch {
+	case route == "POST datasets" && len(rest) == 1:
+		var d bq.Dataset
+		if err := decode(r.Body, &d); err != nil {
+			return nil, err
+		}
+		return s.insertDataset(project, &d)
+	case route == "GET datasets" && len(rest) == 1:
+		return s.listDatasets(project), nil
+	case route == "GET datasets" && len(rest) == 2:
+		d, err := s.dataset(project, rest[1])
+		if err != nil {
+			return nil, err
+		}
+		return d.meta, nil
+	case route == "PATCH datasets" && len(rest) == 2:
+		var d bq.Dataset
+		if err := decode(r.Body, &d); err != nil {
+			return nil, err
+		}
+		return s.patchDataset(project, rest[1], &d)
+	case route == "DELETE datasets" && len(rest) == 2:
+		return nil, s.deleteDataset(project, rest[1], q.Get("deleteContents") == "true")
+	case route == "POST datasets" && len(rest) == 3 && rest[2] == "tables":
+		var t bq.Table
+		if err := decode(r.Body, &t); err != nil {
+			return nil, err
+		}
+		return s.insertTable(project, rest[1], &t)
+	case route == "GET datasets" && len(rest) == 3 && rest[2] == "tables":
+		return s.listTables(project, rest[1])
+	case route == "GET datasets" && len(rest) == 4 && rest[2] == "tables":
+		t, err := s.table(project, rest[1], rest[3])
+		if err != nil {
+			return nil, err
+		}
+		return tableMetadata(t), nil
+	case route == "PATCH datasets" && len(rest) == 4 && rest[2] == "tables":
+		var t bq.Table
+		if err := decode(r.Body, &t); err != nil {
+			return nil, err
+		}
+		return s.patchTable(project, rest[1], rest[3], &t)
+	case route == "DELETE datasets" && len(rest) == 4 && rest[2] == "tables":
+		return nil, s.deleteTable(project, rest[1], rest[3])
+	case route == "GET datasets" && len(rest) == 5 && rest[2] == "tables" && rest[4] == "data":
+		return s.listRows(project, rest[1], rest[3], q)
+	case route == "POST datasets" && len(rest) == 5 && rest[2] == "tables" && rest[4] == "insertAll":
+		var req bq.TableDataInsertAllRequest
+		if err := decode(r.Body, &req); err != nil {
+			return nil, err
+		}
+		return s.insertAll(project, rest[1], rest[3], &req)
+	case route == "POST jobs" && len(rest) == 1:
+		j, media, err := readJobRequest(r, upload)
+		if err != nil {
+			return nil, err
+		}
+		return s.insertJob(project, j, media)
+	case route == "GET jobs" && len(rest) == 2:
+		j, err := s.job(project, rest[1])
+		if err != nil {
+			return nil, err
+		}
+		return j.job, nil
+	case route == "POST jobs" && len(rest) == 3 && rest[2] == "cancel":
+		j, err := s.job(project, rest[1])
+		if err != nil {
+			return nil, err
+		}
+		return &bq.JobCancelResponse{Job: j.job}, nil
+	case route == "DELETE jobs" && len(rest) == 3 && rest[2] == "delete":
+		if _, err := s.job(project, rest[1]); err != nil {
+			return nil, err
+		}
+		delete(s.jobs, project+":"+rest[1])
+		return nil, nil
+	case route == "POST queries" && len(rest) == 1:
+		var req bq.QueryRequest
+		if err := decode(r.Body, &req); err != nil {
+			return nil, err
+		}
+		return s.query(project, &req)
+	case route == "GET queries" && len(rest) == 2:
+		return s.getQueryResults(project, rest[1], q)
+	}
+	return nil, notFound("bqtest: unsupported method %s %s", r.Method, r.URL.Path)
+}
+
+// decode decodes a JSON request body, keeping numbers as json.Number so that
+// large integers are not rounded.
+func decode(r io.Reader, v interface{}) error {
+	dec := json.NewDecoder(r)
+	dec.UseNumber()
+	if err := dec.Decode(v); err != nil {
+		return invalid("bqtest: bad request body: %v", err)
+	}
+	return nil
+}
+
+// readJobRequest reads a jobs.insert request, along with the uploaded data of
+// a multipart upload.
+func readJobRequest(r *http.Request, upload bool) (*bq.Job, []byte, error) {
+	var j bq.Job
+	if !upload {
+		if err := decode(r.Body, &j); err != nil {
+			return nil, nil, err
+		}
+		return &j, nil, nil
+	}
+	if t := r.URL.Query().Get("uploadType"); t != "multipart" {
+		return nil, nil, invalid("bqtest: unsupported upload type %q", t)
+	}
+	_, params, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if err != nil {
+		return nil, nil, invalid("bqtest: bad upload: %v", err)
+	}
+	mr := multipart.NewReader(r.Body, params["boundary"])
+	p, err := mr.NextPart()
+	if err != nil {
+		return nil, nil, invalid("bqtest: bad upload: %v", err)
+	}
+	if err := decode(p, &j); err != nil {
+		return nil, nil, err
+	}
+	p, err = mr.NextPart()
+	if err != nil {
+		return nil, nil, invalid("bqtest: bad upload: %v", err)
+	}
+	media, err := io.ReadAll(p)
+	if err != nil {
+		return nil, nil, invalid("bqtest: bad upload: %v", err)
+	}
+	return &j, media, nil
+}
+
+func nowMillis() int64 {
+	return time.Now().UnixMilli()
+}
+
+func (s *Server) newID(prefix string) string {
+	s.nextID++
+	return fmt.Sprintf("%s_%d", prefix, s.nextID)
+}
+
+func (s *Server) dataset(project, id string) (*dataset, error) {
+	d, ok := s.datasets[project+":"+id]
+	if !ok {
+		return nil, notFound("Not found: Dataset %s:%s", project, id)
+	}
+	return d, nil
+}
+
+func (s *Server) table(project, datasetID, id string) (*table, error) {
+	d, err := s.dataset(project, datasetID)
+	if err != nil {
+		return nil, err
+	}
+	t, ok := d.tables[id]
+	if !ok {
+		return nil, notFound("Not found: Table %s:%s.%s", project, datasetID, id)
+	}
+	return t, nil
+}
+
+func (s *Server) insertDataset(project string, d *bq.Dataset) (*bq.Dataset, error) {
+	if d.DatasetReference == nil || d.DatasetReference.DatasetId == "" {
+		return nil, invalid("bqtest: missing dataset ID")
+	}
+	if d.DatasetReference.ProjectId == "" {
+		d.DatasetReference.ProjectId = project
+	}
+	ref := d.DatasetReference
+	key := ref.ProjectId + ":" + ref.DatasetId
+	if _, ok := s.datasets[key]; ok {
+		return nil, duplicate("Already Exists: Dataset %s", key)
+	}
+	now := nowMillis()
+	d.Id = key
+	d.Kind = "bigquery#dataset"
+	d.CreationTime = now
+	d.LastModifiedTime = now
+	d.Etag = strconv.FormatInt(now, 10)
+	if d.Location == "" {
+		d.Location = "US"
+	}
+	s.datasets[key] = &dataset{meta: d, tables: map[string]*table{}}
+	return d, nil
+}
+
+func (s *Server) listDatasets(project string) *bq.DatasetList {
+	res := &bq.DatasetList{Kind: "bigquery#datasetList"}
+	for _, d := range s.datasets {
+		if d.meta.DatasetReference.ProjectId != project {
+			continue
+		}
+		res.Datasets = append(res.Datasets, &bq.DatasetListDatasets{
+			DatasetReference: d.meta.DatasetReference,
+			FriendlyName:     d.meta.FriendlyName,
+			Id:               d.meta.Id,
+			Labels:           d.meta.Labels,
+			Location:         d.meta.Location,
+		})
+	}
+	sort.Slice(res.Datasets, func(i, j int) bool { return res.Datasets[i].Id < res.Datasets[j].Id })
+	return res
+}
+
+func (s *Server) patchDataset(project, id string, patch *bq.Dataset) (*bq.Dataset, error) {
+	d, err := s.dataset(project, id)
+	if err != nil {
+		return nil, err
+	}
+	m := d.meta
+	if patch.Description != "" {
+		m.Description = patch.Description
+	}
+	if patch.FriendlyName != "" {
+		m.FriendlyName = patch.FriendlyName
+	}
+	if patch.DefaultTableExpirationMs != 0 {
+		m.DefaultTableExpirationMs = patch.DefaultTableExpirationMs
+	}
+	for k, v := range patch.Labels {
+		if m.Labels == nil {
+			m.Labels = map[string]string{}
+		}
+		m.Labels[k] = v
+	}
+	m.LastModifiedTime = nowMillis()
+	m.Etag = strconv.FormatInt(m.LastModifiedTime, 10)
+	return m, nil
+}
+
+func (s *Server) deleteDataset(project, id string, deleteContents bool) error {
+	d, err := s.dataset(project, id)
+	if err != nil {
+		return err
+	}
+	if len(d.tables) > 0 && !deleteContents {
+		return &apiError{code: http.StatusBadRequest, reason: "resourceInUse", msg: fmt.Sprintf("Dataset %s:%s is still in use", project, id)}
+	}
+	delete(s.datasets, project+":"+id)
+	return nil
+}
+
+func (s *Server) insertTable(project, datasetID string, t *bq.Table) (*bq.Table, error) {
+	d, err := s.dataset(project, datasetID)
+	if err != nil {
+		return nil, err
+	}
+	if t.TableReference == nil || t.TableReference.TableId == "" {
+		return nil, invalid("bqtest: missing table ID")
+	}
+	t.TableReference.ProjectId = project
+	t.TableReference.DatasetId = datasetID
+	id := t.TableReference.TableId
+	if _, ok := d.tables[id]; ok {
+		return nil, duplicate("Already Exists: Table %s:%s.%s", project, datasetID, id)
+	}
+	now := nowMillis()
+	t.Id = fmt.Sprintf("%s:%s.%s", project, datasetID, id)
+	t.Kind = "bigquery#table"
+	t.CreationTime = now
+	t.LastModifiedTime = uint64(now)
+	t.Etag = strconv.FormatInt(now, 10)
+	t.Location = d.meta.Location
+	t.Type = "TABLE"
+	if t.View != nil {
+		t.Type = "VIEW"
+	}
+	nt := &table{meta: t}
+	d.tables[id] = nt
+	return tableMetadata(nt), nil
+}
+
+// tableMetadata returns the metadata of t, with its current row count.
+func tableMetadata(t *table) *bq.Table {
+	m := *t.meta
+	m.NumRows = uint64(len(t.rows))
+	m.ForceSendFields = append(m.ForceSendFields, "NumRows")
+	return &m
+}
+
+func (s *Server) listTables(project, datasetID string) (*bq.TableList, error) {
+	d, err := s.dataset(project, datasetID)
+	if err != nil {
+		return nil, err
+	}
+	res := &bq.TableList{Kind: "bigquery#tableList", TotalItems: int64(len(d.tables))}
+	for _, t := range d.tables {
+		res.Tables = append(res.Tables, &bq.TableListTables{
+			Id:             t.meta.Id,
+			Kind:           "bigquery#table",
+			Labels:         t.meta.Labels,
+			TableReference: t.meta.TableReference,
+			Type:           t.meta.Type,
+		})
+	}
+	sort.Slice(res.Tables, func(i, j int) bool { return res.Tables[i].Id < res.Tables[j].Id })
+	return res, nil
+}
+
+func (s *Server) patchTable(project, datasetID, id string, patch *bq.Table) (*bq.Table, error) {
+	t, err := s.table(project, datasetID, id)
+	if err != nil {
+		return nil, err
+	}
+	m := t.meta
+	if patch.Schema != nil {
+		if t.rows != nil {
+			t.rows = alignRows(t.rows, m.Schema, patch.Schema)
+		}
+		m.Schema = patch.Schema
+	}
+	if patch.Description != "" {
+		m.Description = patch.Description
+	}
+	if patch.FriendlyName != "" {
+		m.FriendlyName = patch.FriendlyName
+	}
+	if patch.ExpirationTime != 0 {
+		m.ExpirationTime = patch.ExpirationTime
+	}
+	for k, v := range patch.Labels {
+		if m.Labels == nil {
+			m.Labels = map[string]string{}
+		}
+		m.Labels[k] = v
+	}
+	m.LastModifiedTime = uint64(nowMillis())
+	m.Etag = strconv.FormatUint(m.LastModifiedTime, 10)
+	return tableMetadata(t), nil
+}
+
+func (s *Server) deleteTable(project, datasetID, id string) error {
+	d, err := s.dataset(project, datasetID)
+	if err != nil {
+		return err
+	}
+	if _, ok := d.tables[id]; !ok {
+		return notFound("Not found: Table %s:%s.%s", project, datasetID, id)
+	}
+	delete(d.tables, id)
+	return nil
+}
+
+func (s *Server) listRows(project, datasetID, id string, q url.Values) (*bq.TableDataList, error) {
+	t, err := s.table(project, datasetID, id)
+	if err != nil {
+		return nil, err
+	}
+	rows, token, err := page(t.rows, q)
+	if err != nil {
+		return nil, err
+	}
+	return &bq.TableDataList{
+		Kind:      "bigquery#tableDataList",
+		Rows:      rows,
+		TotalRows: int64(len(t.rows)),
+		PageToken: token,
+	}, nil
+}
+
+// page returns the page of rows selected by the startIndex, maxResults and
+// pageToken parameters of a list request, and the token of the next page.
+// Page tokens are row offsets.
+func page(rows [][]interface{}, q url.Values) ([]*bq.TableRow, string, error) {
+	start := 0
+	var err error
+	if tok := q.Get("pageToken"); tok != "" {
+		start, err = strconv.Atoi(tok)
+	} else if si := q.Get("startIndex"); si != "" {
+		start, err = strconv.Atoi(si)
+	}
+	if err != nil || start < 0 {
+		return nil, "", invalid("bqtest: bad page token or start index")
+	}
+	end := len(rows)
+	if mr := q.Get("maxResults"); mr != "" {
+		n, err := strconv.Atoi(mr)
+		if err != nil || n < 0 {
+			return nil, "", invalid("bqtest: bad maxResults %q", mr)
+		}
+		if start+n < end {
+			end = start + n
+		}
+	}
+	if start > end {
+		start = end
+	}
+	var res []*bq.TableRow
+	for _, r := range rows[start:end] {
+		res = append(res, toTableRow(r))
+	}
+	var token string
+	if end < len(rows) && end > start {
+		token = strconv.Itoa(end)
+	}
+	return res, token, nil
+}
+
+func toTableRow(cells []interface{}) *bq.TableRow {
+	r := &bq.TableRow{}
+	for _, c := range cells {
+		r.F = append(r.F, &bq.TableCell{V: c})
+	}
+	return r
+}
+
+func (s *Server) insertAll(project, datasetID, id string, req *bq.TableDataInsertAllRequest) (*bq.TableDataInsertAllResponse, error) {
+	t, err := s.table(project, datasetID, id)
+	if err != nil {
+		return nil, err
+	}
+	if t.meta.Schema == nil {
+		return nil, invalid("bqtest: table %s has no schema", t.meta.Id)
+	}
+	res := &bq.TableDataInsertAllResponse{Kind: "bigquery#tableDataInsertAllResponse"}
+	var rows [][]interface{}
+	for i, r := range req.Rows {
+		m := map[string]interface{}{}
+		for k, v := range r.Json {
+			m[k] = v
+		}
+		row, err := jsonToRow(m, t.meta.Schema.Fields, req.IgnoreUnknownValues)
+		if err != nil {
+			res.InsertErrors = append(res.InsertErrors, &bq.TableDataInsertAllResponseInsertErrors{
+				Index:  int64(i),
+				Errors: []*bq.ErrorProto{errorProto(err)},
+			})
+			continue
+		}
+		rows = append(rows, row)
+	}
+	if len(res.InsertErrors) > 0 && !req.SkipInvalidRows {
+		return res, nil
+	}
+	t.rows = append(t.rows, rows...)
+	return res, nil
+}
+
+func (s *Server) job(project, id string) (*job, error) {
+	j, ok := s.jobs[project+":"+id]
+	if !ok {
+		return nil, notFound("Not found: Job %s:%s", project, id)
+	}
+	return j, nil
+}
+
+// insertJob runs j to completion. Failures of the job itself are reported
+// in its status, as the service does.
+func (s *Server) insertJob(project string, j *bq.Job, media []byte) (*bq.Job, error) {
+	if j.JobReference == nil {
+		j.JobReference = &bq.JobReference{}
+	}
+	ref := j.JobReference
+	if ref.ProjectId == "" {
+		ref.ProjectId = project
+	}
+	if ref.JobId == "" {
+		ref.JobId = s.newID("job")
+	}
+	key := ref.ProjectId + ":" + ref.JobId
+	if _, ok := s.jobs[key]; ok {
+		return nil, duplicate("Already Exists: Job %s", key)
+	}
+	cfg := j.Configuration
+	if cfg == nil {
+		return nil, invalid("bqtest: missing job configuration")
+	}
+	now := nowMillis()
+	j.Id = key
+	j.Kind = "bigquery#job"
+	j.Statistics = &bq.JobStatistics{CreationTime: now, StartTime: now, EndTime: now}
+	j.Status = &bq.JobStatus{State: "DONE"}
+	fj := &job{job: j}
+	switch {
+	case cfg.Query != nil:
+		s.queries = append(s.queries, cfg.Query.Query)
+		res, err := s.runQuery(ref.ProjectId, cfg.Query)
+		j.Statistics.Query = &bq.JobStatistics2{StatementType: "SELECT", TotalBytesProcessedAccuracy: "PRECISE"}
+		if err == nil && cfg.DryRun {
+			j.Statistics.Query.Schema = res.schema
+			return j, nil
+		}
+		if err == nil && cfg.Query.DestinationTable != nil {
+			_, err = s.writeRows(cfg.Query.DestinationTable, res.schema, res.rows,
+				cfg.Query.CreateDisposition, orDefault(cfg.Query.WriteDisposition, "WRITE_EMPTY"))
+		}
+		fj.err = err
+		if err == nil {
+			fj.schema, fj.rows = res.schema, res.rows
+		}
+	case cfg.Load != nil:
+		var n int64
+		n, fj.err = s.load(ref.ProjectId, cfg.Load, media)
+		j.Statistics.Load = &bq.JobStatistics3{InputFiles: 1, OutputRows: n}
+	case cfg.Copy != nil:
+		fj.err = s.copyTables(ref.ProjectId, cfg.Copy)
+	default:
+		fj.err = invalid("bqtest: unsupported job type")
+	}
+	if fj.err != nil {
+		ep := errorProto(fj.err)
+		j.Status.ErrorResult = ep
+		j.Status.Errors = []*bq.ErrorProto{ep}
+	}
+	s.jobs[key] = fj
+	return j, nil
+}
+
+func orDefault(s, def string) string {
+	if s == "" {
+		return def
+	}
+	return s
+}
+
+// writeRows writes rows with the given schema to the table dst, creating it
+// if needed.
+func (s *Server) writeRows(dst *bq.TableReference, schema *bq.TableSchema, rows [][]interface{}, createDisposition, writeDisposition string) (*table, error) {
+	d, err := s.dataset(dst.ProjectId, dst.DatasetId)
+	if err != nil {
+		return nil, err
+	}
+	t, ok := d.tables[dst.TableId]
+	if !ok {
+		if createDisposition == "CREATE_NEVER" {
+			return nil, notFound("Not found: Table %s:%s.%s", dst.ProjectId, dst.DatasetId, dst.TableId)
+		}
+		if schema == nil {
+			return nil, invalid("bqtest: no schema for new table %s:%s.%s", dst.ProjectId, dst.DatasetId, dst.TableId)
+		}
+		ref := *dst
+		if _, err := s.insertTable(dst.ProjectId, dst.DatasetId, &bq.Table{TableReference: &ref, Schema: schema}); err != nil {
+			return nil, err
+		}
+		t = d.tables[dst.TableId]
+	}
+	switch writeDisposition {
+	case "WRITE_TRUNCATE":
+		if schema != nil {
+			t.meta.Schema = schema
+		}
+		t.rows = nil
+	case "WRITE_EMPTY":
+		if len(t.rows) > 0 {
+			return nil, duplicate("Already Exists: Table %s", t.meta.Id)
+		}
+	}
+	if t.meta.Schema == nil {
+		t.meta.Schema = schema
+	}
+	if schema != nil {
+		rows = alignRows(rows, schema, t.meta.Schema)
+	}
+	t.rows = append(t.rows, rows...)
+	return t, nil
+}
+
+// alignRows reorders the cells of rows from the fields of schema from to the
+// fields of schema to, matching fields by name. Fields missing from from are
+// NULL.
+func alignRows(rows [][]interface{}, from, to *bq.TableSchema) [][]interface{} {
+	idx := make([]int, len(to.Fields))
+	for i, f := range to.Fields {
+		idx[i] = -1
+		for j, g := range from.Fields {
+			if strings.EqualFold(f.Name, g.Name) {
+				idx[i] = j
+			}
+		}
+	}
+	res := make([][]interface{}, len(rows))
+	for i, r := range rows {
+		row := make([]interface{}, len(idx))
+		for k, j := range idx {
+			if j >= 0 && j < len(r) {
+				row[k] = r[j]
+			}
+		}
+		res[i] = row
+	}
+	return res
+}
+
+func (s *Server) load(project string, cfg *bq.JobConfigurationLoad, media []byte) (int64, error) {
+	if media == nil {
+		return 0, invalid("bqtest: loads from Cloud Storage are not supported")
+	}
+	dst := cfg.DestinationTable
+	if dst == nil {
+		return 0, invalid("bqtest: missing destination table")
+	}
+	if dst.ProjectId == "" {
+		dst.ProjectId = project
+	}
+	schema := cfg.Schema
+	if schema == nil {
+		t, err := s.table(dst.ProjectId, dst.DatasetId, dst.TableId)
+		if err != nil {
+			return 0, invalid("bqtest: no schema for load into %s:%s.%s, and schema autodetection is not supported", dst.ProjectId, dst.DatasetId, dst.TableId)
+		}
+		schema = t.meta.Schema
+	}
+	var rows [][]interface{}
+	var err error
+	switch cfg.SourceFormat {
+	case "", "CSV":
+		rows, err = csvToRows(media, schema.Fields, cfg)
+	case "NEWLINE_DELIMITED_JSON":
+		rows, err = ndjsonToRows(media, schema.Fields, cfg.IgnoreUnknownValues)
+	default:
+		err = invalid("bqtest: unsupported source format %q", cfg.SourceFormat)
+	}
+	if err != nil {
+		return 0, err
+	}
+	if _, err := s.writeRows(dst, schema, rows, cfg.CreateDisposition, orDefault(cfg.WriteDisposition, "WRITE_APPEND")); err != nil {
+		return 0, err
+	}
+	return int64(len(rows)), nil
+}
+
+func (s *Server) copyTables(project string, cfg *bq.JobConfigurationTableCopy) error {
+	srcs := cfg.SourceTables
+	if cfg.SourceTable != nil {
+		srcs = append(srcs, cfg.SourceTable)
+	}
+	if len(srcs) == 0 || cfg.DestinationTable == nil {
+		return invalid("bqtest: copy jobs need source and destination tables")
+	}
+	var schema *bq.TableSchema
+	var rows [][]interface{}
+	for _, ref := range srcs {
+		t, err := s.table(orDefault(ref.ProjectId, project), ref.DatasetId, ref.TableId)
+		if err != nil {
+			return err
+		}
+		if schema == nil {
+			schema = t.meta.Schema
+		}
+		rows = append(rows, alignRows(t.rows, t.meta.Schema, schema)...)
+	}
+	dst := *cfg.DestinationTable
+	dst.ProjectId = orDefault(dst.ProjectId, project)
+	_, err := s.writeRows(&dst, schema, rows, cfg.CreateDisposition, orDefault(cfg.WriteDisposition, "WRITE_EMPTY"))
+	return err
+}
+
+// query implements jobs.query. Unlike jobs.insert, it reports failed queries
+// as errors.
+func (s *Server) query(project string, req *bq.QueryRequest) (*bq.QueryResponse, error) {
+	j := &bq.Job{
+		JobReference: &bq.JobReference{ProjectId: project, Location: req.Location},
+		Configuration: &bq.JobConfiguration{
+			DryRun: req.DryRun,
+			Labels: req.Labels,
+			Query: &bq.JobConfigurationQuery{
+				Query:           req.Query,
+				DefaultDataset:  req.DefaultDataset,
+				QueryParameters: req.QueryParameters,
+				UseLegacySql:    req.UseLegacySql,
+			},
+		},
+	}
+	j, err := s.insertJob(project, j, nil)
+	if err != nil {
+		return nil, err
+	}
+	if req.DryRun {
+		return &bq.QueryResponse{JobComplete: true, JobReference: j.JobReference, Schema: j.Statistics.Query.Schema}, nil
+	}
+	fj := s.jobs[j.Id]
+	if fj.err != nil {
+		return nil, fj.err
+	}
+	q := url.Values{}
+	if req.MaxResults > 0 {
+		q.Set("maxResults", strconv.FormatInt(req.MaxResults, 10))
+	}
+	rows, token, err := page(fj.rows, q)
+	if err != nil {
+		return nil, err
+	}
+	return &bq.QueryResponse{
+		Kind:         "bigquery#queryResponse",
+		JobComplete:  true,
+		JobReference: j.JobReference,
+		Schema:       fj.schema,
+		Rows:         rows,
+		TotalRows:    uint64(len(fj.rows)),
+		PageToken:    token,
+	}, nil
+}
+
+func (s *Server) getQueryResults(project, id string, q url.Values) (*bq.GetQueryResultsResponse, error) {
+	j, err := s.job(project, id)
+	if err != nil {
+		return nil, err
+	}
+	if j.job.Configuration.Query == nil {
+		return nil, invalid("bqtest: job %s is not a query", id)
+	}
+	if j.err != nil {
+		return nil, j.err
+	}
+	rows, token, err := page(j.rows, q)
+	if err != nil {
+		return nil, err
+	}
+	return &bq.GetQueryResultsResponse{
+		Kind:         "bigquery#getQueryResultsResponse",
+		JobComplete:  true,
+		JobReference: j.job.JobReference,
+		Schema:       j.schema,
+		Rows:         rows,
+		TotalRows:    uint64(len(j.rows)),
+		PageToken:    token,
+	}, nil
+}
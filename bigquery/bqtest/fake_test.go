@@ -0,0 +1,305 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bqtest
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/bigquery"
+	"cloud.google.com/go/civil"
+	"cloud.google.com/go/internal/testutil"
+	"google.golang.org/api/googleapi"
+	"google.golang.org/api/iterator"
+)
+
+func newFake(t *testing.T) (*Server, *bigquery.Client) {
+	t.Helper()
+	srv := NewServer()
+	t.Cleanup(srv.Close)
+	client, err := bigquery.NewClient(context.Background(), "proj", srv.ClientOptions()...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { client.Close() })
+	return srv, client
+}
+
+type item struct {
+	Name    string
+	Count   int64
+	Created time.Time
+	Day     civil.Date
+	Tags    []string
+}
+
+var itemSchema = bigquery.Schema{
+	{Name: "Name", Type: bigquery.StringFieldType, Required: true},
+	{Name: "Count", Type: bigquery.IntegerFieldType},
+	{Name: "Created", Type: bigquery.TimestampFieldType},
+	{Name: "Day", Type: bigquery.DateFieldType},
+	{Name: "Tags", Type: bigquery.StringFieldType, Repeated: true},
+}
+
+func readAll[T any](t *testing.T, it *bigquery.RowIterator) []T {
+	t.Helper()
+	rows, err := bigquery.AllRows[T](it)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return rows
+}
+
+func TestDatasetsAndTables(t *testing.T) {
+	ctx := context.Background()
+	_, client := newFake(t)
+	ds := client.Dataset("ds")
+	if err := ds.Create(ctx, nil); err != nil {
+		t.Fatal(err)
+	}
+	var e *googleapi.Error
+	if err := ds.Create(ctx, nil); !errors.As(err, &e) || e.Code != 409 {
+		t.Errorf("creating duplicate dataset: got %v, want 409", err)
+	}
+	tbl := ds.Table("t")
+	if err := tbl.Create(ctx, &bigquery.TableMetadata{Schema: itemSchema, Description: "d"}); err != nil {
+		t.Fatal(err)
+	}
+	md, err := tbl.Metadata(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if md.Description != "d" || len(md.Schema) != len(itemSchema) {
+		t.Errorf("got metadata %+v", md)
+	}
+	if _, err := tbl.Update(ctx, bigquery.TableMetadataToUpdate{Description: "new"}, ""); err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	tit := ds.Tables(ctx)
+	for {
+		tb, err := tit.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		names = append(names, tb.TableID)
+	}
+	if want := []string{"t"}; !testutil.Equal(names, want) {
+		t.Errorf("tables: got %v, want %v", names, want)
+	}
+	if err := ds.Delete(ctx); err == nil {
+		t.Error("deleting non-empty dataset: got nil error")
+	}
+	if err := tbl.Delete(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := tbl.Metadata(ctx); !errors.As(err, &e) || e.Code != 404 {
+		t.Errorf("deleted table: got %v, want 404", err)
+	}
+	if err := ds.Delete(ctx); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestInsertAndQuery(t *testing.T) {
+	ctx := context.Background()
+	srv, client := newFake(t)
+	ds := client.Dataset("ds")
+	if err := ds.Create(ctx, nil); err != nil {
+		t.Fatal(err)
+	}
+	tbl := ds.Table("items")
+	if err := tbl.Create(ctx, &bigquery.TableMetadata{Schema: itemSchema}); err != nil {
+		t.Fatal(err)
+	}
+	created := time.Date(2023, 8, 3, 10, 11, 12, 345678000, time.UTC)
+	items := []*item{
+		{Name: "a", Count: 1, Created: created, Day: civil.DateOf(created), Tags: []string{"x", "y"}},
+		{Name: "b", Count: 2, Created: created, Day: civil.DateOf(created)},
+	}
+	if err := tbl.Inserter().Put(ctx, items); err != nil {
+		t.Fatal(err)
+	}
+
+	got := readAll[item](t, tbl.Read(ctx))
+	want := []item{*items[0], *items[1]}
+	if diff := testutil.Diff(got, want); diff != "" {
+		t.Errorf("table rows: -got +want:\n%s", diff)
+	}
+
+	// Both the jobs.query and jobs.insert paths.
+	q := client.Query("SELECT Name, Count AS n FROM `proj.ds.items` LIMIT 1")
+	type nameCount struct {
+		Name string
+		N    int64
+	}
+	it, err := q.Read(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := readAll[nameCount](t, it), []nameCount{{"a", 1}}; !testutil.Equal(got, want) {
+		t.Errorf("query: got %v, want %v", got, want)
+	}
+	q.JobID = "myjob"
+	job, err := q.Run(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := job.Wait(ctx); err != nil {
+		t.Fatal(err)
+	}
+	it, err = job.Read(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := readAll[nameCount](t, it), []nameCount{{"a", 1}}; !testutil.Equal(got, want) {
+		t.Errorf("query job: got %v, want %v", got, want)
+	}
+
+	if n := len(srv.Queries()); n != 2 {
+		t.Errorf("got %d queries, want 2", n)
+	}
+
+	// Invalid rows are rejected.
+	err = tbl.Inserter().Put(ctx, &bigquery.ValuesSaver{
+		Schema: bigquery.Schema{{Name: "Count", Type: bigquery.IntegerFieldType}},
+		Row:    []bigquery.Value{3},
+	})
+	var pme bigquery.PutMultiError
+	if !errors.As(err, &pme) {
+		t.Errorf("inserting row without required field: got %v, want PutMultiError", err)
+	}
+}
+
+func TestAddQueryResult(t *testing.T) {
+	ctx := context.Background()
+	srv, client := newFake(t)
+	schema := bigquery.Schema{
+		{Name: "word", Type: bigquery.StringFieldType},
+		{Name: "n", Type: bigquery.IntegerFieldType},
+		{Name: "rec", Type: bigquery.RecordFieldType, Schema: bigquery.Schema{
+			{Name: "when", Type: bigquery.DateTimeFieldType},
+		}},
+	}
+	dt := civil.DateTime{Date: civil.Date{Year: 2023, Month: 8, Day: 3}, Time: civil.Time{Hour: 1, Minute: 2, Second: 3}}
+	err := srv.AddQueryResult("SELECT word, COUNT(*) AS n, STRUCT(CURRENT_DATETIME() AS when) AS rec FROM corpus GROUP BY word",
+		schema, [][]bigquery.Value{
+			{"hello", int64(3), []bigquery.Value{dt}},
+			{"world", int64(1), nil},
+		})
+	if err != nil {
+		t.Fatal(err)
+	}
+	q := client.Query(`
+		SELECT word, COUNT(*) AS n, STRUCT(CURRENT_DATETIME() AS when) AS rec
+		FROM corpus
+		GROUP BY word;`)
+	q.Parameters = []bigquery.QueryParameter{{Name: "ignored", Value: 1}}
+	it, err := q.Read(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := readAll[[]bigquery.Value](t, it)
+	want := [][]bigquery.Value{
+		{"hello", int64(3), []bigquery.Value{dt}},
+		{"world", int64(1), nil},
+	}
+	if diff := testutil.Diff(got, want); diff != "" {
+		t.Errorf("-got +want:\n%s", diff)
+	}
+
+	est, err := q.Estimate(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(est.Schema) != 3 {
+		t.Errorf("dry run: got schema %v", est.Schema)
+	}
+
+	_, err = client.Query("SELECT 1").Read(ctx)
+	if err == nil || !strings.Contains(err.Error(), "AddQueryResult") {
+		t.Errorf("unregistered query: got %v, want error mentioning AddQueryResult", err)
+	}
+}
+
+func TestLoadAndCopy(t *testing.T) {
+	ctx := context.Background()
+	_, client := newFake(t)
+	ds := client.Dataset("ds")
+	if err := ds.Create(ctx, nil); err != nil {
+		t.Fatal(err)
+	}
+	schema := bigquery.Schema{
+		{Name: "name", Type: bigquery.StringFieldType},
+		{Name: "n", Type: bigquery.IntegerFieldType},
+	}
+	load := func(tableID string, src *bigquery.ReaderSource) {
+		t.Helper()
+		src.Schema = schema
+		job, err := ds.Table(tableID).LoaderFrom(src).Run(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		status, err := job.Wait(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := status.Err(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	csvSrc := bigquery.NewReaderSource(strings.NewReader("name,n\na,1\nb,\n"))
+	csvSrc.SkipLeadingRows = 1
+	load("t", csvSrc)
+	jsonSrc := bigquery.NewReaderSource(strings.NewReader(`{"name": "c", "n": 3}` + "\n"))
+	jsonSrc.SourceFormat = bigquery.JSON
+	load("t", jsonSrc)
+
+	want := [][]bigquery.Value{{"a", int64(1)}, {"b", nil}, {"c", int64(3)}}
+	if got := readAll[[]bigquery.Value](t, ds.Table("t").Read(ctx)); !testutil.Equal(got, want) {
+		t.Errorf("loaded rows: got %v, want %v", got, want)
+	}
+
+	job, err := ds.Table("t").Snapshot(ds.Table("snap")).Run(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if status, err := job.Wait(ctx); err != nil || status.Err() != nil {
+		t.Fatalf("copy: %v, %v", err, status.Err())
+	}
+	if got := readAll[[]bigquery.Value](t, ds.Table("snap").Read(ctx)); !testutil.Equal(got, want) {
+		t.Errorf("copied rows: got %v, want %v", got, want)
+	}
+
+	bad := bigquery.NewReaderSource(strings.NewReader("x,notanumber\n"))
+	bad.Schema = schema
+	job, err = ds.Table("t").LoaderFrom(bad).Run(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	status, err := job.Wait(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if status.Err() == nil {
+		t.Error("loading bad CSV: got nil job error")
+	}
+}
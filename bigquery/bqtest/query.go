@@ -0,0 +1,137 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bqtest
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	bq "google.golang.org/api/bigquery/v2"
+)
+
+var (
+	// selectRE matches the queries the server can evaluate:
+	// SELECT <columns> FROM <table> [LIMIT <n>].
+	selectRE = regexp.MustCompile("(?i)^SELECT (.+?) FROM `?([\\w.:-]+)`?(?: LIMIT (\\d+))?$")
+
+	// columnRE matches a column of a SELECT list, with an optional alias.
+	columnRE = regexp.MustCompile(`(?i)^(\w+)(?: AS (\w+))?$`)
+)
+
+// normalizeQuery collapses the whitespace of q and removes a trailing
+// semicolon, so that registered results match regardless of formatting.
+func normalizeQuery(q string) string {
+	q = strings.Join(strings.Fields(q), " ")
+	return strings.TrimSpace(strings.TrimSuffix(q, ";"))
+}
+
+// runQuery returns the result of the query in cfg, run in project.
+func (s *Server) runQuery(project string, cfg *bq.JobConfigurationQuery) (*result, error) {
+	q := normalizeQuery(cfg.Query)
+	if res, ok := s.results[q]; ok {
+		return res, nil
+	}
+	m := selectRE.FindStringSubmatch(q)
+	if m == nil {
+		return nil, invalidQuery("bqtest: unsupported query %q; register its result with AddQueryResult", cfg.Query)
+	}
+	t, err := s.resolveTable(project, cfg.DefaultDataset, m[2])
+	if err != nil {
+		return nil, err
+	}
+	if t.meta.Schema == nil {
+		return nil, invalid("bqtest: table %s has no schema", t.meta.Id)
+	}
+	schema := t.meta.Schema
+	rows := t.rows
+	if m[1] != "*" {
+		fields := &bq.TableSchema{}
+		var idx []int
+		for _, col := range strings.Split(m[1], ",") {
+			cm := columnRE.FindStringSubmatch(strings.TrimSpace(col))
+			if cm == nil {
+				return nil, invalidQuery("bqtest: unsupported column expression %q", col)
+			}
+			i := fieldIndex(schema, cm[1])
+			if i < 0 {
+				return nil, invalidQuery("Unrecognized name: %s", cm[1])
+			}
+			f := *schema.Fields[i]
+			if cm[2] != "" {
+				f.Name = cm[2]
+			}
+			fields.Fields = append(fields.Fields, &f)
+			idx = append(idx, i)
+		}
+		projected := make([][]interface{}, len(rows))
+		for r, row := range rows {
+			pr := make([]interface{}, len(idx))
+			for k, i := range idx {
+				pr[k] = row[i]
+			}
+			projected[r] = pr
+		}
+		schema, rows = fields, projected
+	}
+	if m[3] != "" {
+		n, err := strconv.Atoi(m[3])
+		if err != nil {
+			return nil, invalid("bqtest: bad LIMIT %s", m[3])
+		}
+		if n < len(rows) {
+			rows = rows[:n]
+		}
+	}
+	return &result{schema: schema, rows: rows}, nil
+}
+
+// resolveTable finds the table named by ref, which is of the form
+// "project.dataset.table", "dataset.table" or "table".
+func (s *Server) resolveTable(project string, defaultDataset *bq.DatasetReference, ref string) (*table, error) {
+	var datasetID string
+	parts := strings.Split(ref, ".")
+	jobProject := project
+	if defaultDataset != nil {
+		datasetID = defaultDataset.DatasetId
+		if defaultDataset.ProjectId != "" {
+			project = defaultDataset.ProjectId
+		}
+	}
+	switch len(parts) {
+	case 1:
+		if datasetID == "" {
+			return nil, invalid("Table name %q missing dataset while no default dataset is set in the request.", ref)
+		}
+	case 2:
+		project, datasetID = jobProject, parts[0]
+	case 3:
+		project, datasetID = parts[0], parts[1]
+	default:
+		return nil, invalid("bqtest: invalid table name %q", ref)
+	}
+	return s.table(project, datasetID, parts[len(parts)-1])
+}
+
+// fieldIndex returns the index of the field of schema named name, ignoring
+// case, or -1.
+func fieldIndex(schema *bq.TableSchema, name string) int {
+	for i, f := range schema.Fields {
+		if strings.EqualFold(f.Name, name) {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,362 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bqtest
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"strconv"
+	"strings"
+	"time"
+
+	"cloud.google.com/go/bigquery"
+	"cloud.google.com/go/civil"
+	bq "google.golang.org/api/bigquery/v2"
+)
+
+// The server stores rows in the representation of the REST API: a row is a
+// list of cells, scalars are strings, repeated fields are lists of
+// {"v": value} and records are {"f": [{"v": value}, ...]}.
+
+// toBQSchema converts schema to its REST representation.
+func toBQSchema(schema bigquery.Schema) (*bq.TableSchema, error) {
+	b, err := schema.ToJSONFields()
+	if err != nil {
+		return nil, err
+	}
+	ts := &bq.TableSchema{}
+	if err := json.Unmarshal(b, &ts.Fields); err != nil {
+		return nil, err
+	}
+	return ts, nil
+}
+
+func isRecord(f *bq.TableFieldSchema) bool {
+	return f.Type == "RECORD" || f.Type == "STRUCT"
+}
+
+func wrapCells(cells []interface{}) []interface{} {
+	res := make([]interface{}, len(cells))
+	for i, c := range cells {
+		res[i] = map[string]interface{}{"v": c}
+	}
+	return res
+}
+
+// valuesToRows converts rows of bigquery.Values to cells.
+func valuesToRows(rows [][]bigquery.Value, schema *bq.TableSchema) ([][]interface{}, error) {
+	res := make([][]interface{}, len(rows))
+	for i, r := range rows {
+		row, err := valuesToCells(r, schema.Fields)
+		if err != nil {
+			return nil, fmt.Errorf("bqtest: row %d: %v", i, err)
+		}
+		res[i] = row
+	}
+	return res, nil
+}
+
+func valuesToCells(vals []bigquery.Value, fields []*bq.TableFieldSchema) ([]interface{}, error) {
+	if len(vals) != len(fields) {
+		return nil, fmt.Errorf("got %d values, want %d", len(vals), len(fields))
+	}
+	cells := make([]interface{}, len(vals))
+	for i, v := range vals {
+		c, err := valueToCell(v, fields[i])
+		if err != nil {
+			return nil, fmt.Errorf("field %s: %v", fields[i].Name, err)
+		}
+		cells[i] = c
+	}
+	return cells, nil
+}
+
+func valueToCell(v bigquery.Value, f *bq.TableFieldSchema) (interface{}, error) {
+	if v == nil {
+		// The API represents a NULL repeated field as an empty list.
+		if f.Mode == "REPEATED" {
+			return []interface{}{}, nil
+		}
+		return nil, nil
+	}
+	if f.Mode == "REPEATED" {
+		vs, ok := v.([]bigquery.Value)
+		if !ok {
+			return nil, fmt.Errorf("got %T for repeated field, want []bigquery.Value", v)
+		}
+		elem := *f
+		elem.Mode = ""
+		cells := make([]interface{}, len(vs))
+		for i, e := range vs {
+			c, err := valueToCell(e, &elem)
+			if err != nil {
+				return nil, err
+			}
+			cells[i] = c
+		}
+		return wrapCells(cells), nil
+	}
+	if isRecord(f) {
+		vs, ok := v.([]bigquery.Value)
+		if !ok {
+			return nil, fmt.Errorf("got %T for record field, want []bigquery.Value", v)
+		}
+		cells, err := valuesToCells(vs, f.Fields)
+		if err != nil {
+			return nil, err
+		}
+		return map[string]interface{}{"f": wrapCells(cells)}, nil
+	}
+	switch v := v.(type) {
+	case string:
+		return scalarCell(v, f.Type)
+	case []byte:
+		return base64.StdEncoding.EncodeToString(v), nil
+	case int, int8, int16, int32, int64, uint8, uint16, uint32, float32, float64, bool:
+		return scalarCell(fmt.Sprint(v), f.Type)
+	case time.Time:
+		return timestampCell(v), nil
+	case civil.Date:
+		return v.String(), nil
+	case civil.Time:
+		return bigquery.CivilTimeString(v), nil
+	case civil.DateTime:
+		return v.Date.String() + "T" + bigquery.CivilTimeString(v.Time), nil
+	case *big.Rat:
+		if f.Type == "BIGNUMERIC" {
+			return bigquery.BigNumericString(v), nil
+		}
+		return bigquery.NumericString(v), nil
+	case *bigquery.IntervalValue:
+		return bigquery.IntervalString(v), nil
+	}
+	return nil, fmt.Errorf("unsupported value type %T", v)
+}
+
+// timestampCell returns t in the representation of the REST API, as
+// fractional seconds since the epoch.
+func timestampCell(t time.Time) string {
+	return strconv.FormatFloat(float64(t.UnixMicro())/1e6, 'f', 6, 64)
+}
+
+var timestampLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02 15:04:05.999999999Z07:00",
+	"2006-01-02 15:04:05.999999999 Z07:00",
+	"2006-01-02 15:04:05.999999999 MST",
+	"2006-01-02T15:04:05.999999999",
+	"2006-01-02 15:04:05.999999999",
+}
+
+// scalarCell validates the textual value s of a field of type typ, and returns
+// it in the representation of the REST API.
+func scalarCell(s, typ string) (interface{}, error) {
+	var err error
+	switch typ {
+	case "INTEGER", "INT64":
+		_, err = strconv.ParseInt(s, 10, 64)
+	case "FLOAT", "FLOAT64":
+		_, err = strconv.ParseFloat(s, 64)
+	case "BOOLEAN", "BOOL":
+		var b bool
+		b, err = strconv.ParseBool(s)
+		s = strconv.FormatBool(b)
+	case "NUMERIC", "BIGNUMERIC":
+		if _, ok := new(big.Rat).SetString(s); !ok {
+			err = fmt.Errorf("invalid %s value", typ)
+		}
+	case "DATE":
+		_, err = civil.ParseDate(s)
+	case "TIME":
+		_, err = civil.ParseTime(s)
+	case "DATETIME":
+		var dt civil.DateTime
+		dt, err = civil.ParseDateTime(strings.Replace(s, " ", "T", 1))
+		s = dt.Date.String() + "T" + bigquery.CivilTimeString(dt.Time)
+	case "TIMESTAMP":
+		if f, ferr := strconv.ParseFloat(s, 64); ferr == nil {
+			return strconv.FormatFloat(f, 'f', 6, 64), nil
+		}
+		for _, layout := range timestampLayouts {
+			var t time.Time
+			if t, err = time.Parse(layout, s); err == nil {
+				return timestampCell(t), nil
+			}
+		}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s value %q", typ, s)
+	}
+	return s, nil
+}
+
+// jsonToRow converts a JSON object, as sent to insertAll or loaded from
+// newline-delimited JSON, to cells.
+func jsonToRow(m map[string]interface{}, fields []*bq.TableFieldSchema, ignoreUnknown bool) ([]interface{}, error) {
+	cells := make([]interface{}, len(fields))
+	used := map[string]bool{}
+	for i, f := range fields {
+		var v interface{}
+		for k, kv := range m {
+			if strings.EqualFold(k, f.Name) {
+				v = kv
+				used[k] = true
+			}
+		}
+		c, err := jsonToCell(v, f, ignoreUnknown)
+		if err != nil {
+			return nil, fmt.Errorf("field %s: %v", f.Name, err)
+		}
+		cells[i] = c
+	}
+	if !ignoreUnknown {
+		for k := range m {
+			if !used[k] {
+				return nil, fmt.Errorf("no such field: %s", k)
+			}
+		}
+	}
+	return cells, nil
+}
+
+func jsonToCell(v interface{}, f *bq.TableFieldSchema, ignoreUnknown bool) (interface{}, error) {
+	if v == nil {
+		switch f.Mode {
+		case "REQUIRED":
+			return nil, fmt.Errorf("missing required field")
+		case "REPEATED":
+			return []interface{}{}, nil
+		}
+		return nil, nil
+	}
+	if f.Mode == "REPEATED" {
+		vs, ok := v.([]interface{})
+		if !ok {
+			return nil, fmt.Errorf("got %T for repeated field, want array", v)
+		}
+		elem := *f
+		elem.Mode = ""
+		cells := make([]interface{}, len(vs))
+		for i, e := range vs {
+			c, err := jsonToCell(e, &elem, ignoreUnknown)
+			if err != nil {
+				return nil, err
+			}
+			cells[i] = c
+		}
+		return wrapCells(cells), nil
+	}
+	if isRecord(f) {
+		m, ok := v.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("got %T for record field, want object", v)
+		}
+		cells, err := jsonToRow(m, f.Fields, ignoreUnknown)
+		if err != nil {
+			return nil, err
+		}
+		return map[string]interface{}{"f": wrapCells(cells)}, nil
+	}
+	switch v := v.(type) {
+	case string:
+		return scalarCell(v, f.Type)
+	case json.Number:
+		return scalarCell(v.String(), f.Type)
+	case float64:
+		return scalarCell(strconv.FormatFloat(v, 'f', -1, 64), f.Type)
+	case bool:
+		return scalarCell(strconv.FormatBool(v), f.Type)
+	case map[string]interface{}, []interface{}:
+		if f.Type == "JSON" {
+			b, err := json.Marshal(v)
+			return string(b), err
+		}
+	}
+	return nil, fmt.Errorf("unsupported value %v for type %s", v, f.Type)
+}
+
+// csvToRows parses CSV data according to the options of cfg.
+func csvToRows(data []byte, fields []*bq.TableFieldSchema, cfg *bq.JobConfigurationLoad) ([][]interface{}, error) {
+	r := csv.NewReader(bytes.NewReader(data))
+	r.FieldsPerRecord = -1
+	switch d := cfg.FieldDelimiter; d {
+	case "":
+	case `\t`, "tab":
+		r.Comma = '\t'
+	default:
+		r.Comma = []rune(d)[0]
+	}
+	for _, f := range fields {
+		if f.Mode == "REPEATED" || isRecord(f) {
+			return nil, invalid("bqtest: CSV loads do not support nested or repeated field %s", f.Name)
+		}
+	}
+	var rows [][]interface{}
+	for line := 0; ; line++ {
+		rec, err := r.Read()
+		if err == io.EOF {
+			return rows, nil
+		}
+		if err != nil {
+			return nil, invalid("bqtest: reading CSV: %v", err)
+		}
+		if int64(line) < cfg.SkipLeadingRows {
+			continue
+		}
+		if len(rec) > len(fields) || (len(rec) < len(fields) && !cfg.AllowJaggedRows) {
+			return nil, invalid("bqtest: CSV line %d has %d columns, want %d", line+1, len(rec), len(fields))
+		}
+		row := make([]interface{}, len(fields))
+		for i, f := range fields {
+			if i >= len(rec) || rec[i] == "" || (cfg.NullMarker != "" && rec[i] == cfg.NullMarker) {
+				if f.Mode == "REQUIRED" {
+					return nil, invalid("bqtest: CSV line %d: missing required field %s", line+1, f.Name)
+				}
+				continue
+			}
+			c, err := scalarCell(rec[i], f.Type)
+			if err != nil {
+				return nil, invalid("bqtest: CSV line %d: field %s: %v", line+1, f.Name, err)
+			}
+			row[i] = c
+		}
+		rows = append(rows, row)
+	}
+}
+
+// ndjsonToRows parses newline-delimited JSON data.
+func ndjsonToRows(data []byte, fields []*bq.TableFieldSchema, ignoreUnknown bool) ([][]interface{}, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var rows [][]interface{}
+	for line := 1; ; line++ {
+		var m map[string]interface{}
+		if err := dec.Decode(&m); err == io.EOF {
+			return rows, nil
+		} else if err != nil {
+			return nil, invalid("bqtest: reading JSON: %v", err)
+		}
+		row, err := jsonToRow(m, fields, ignoreUnknown)
+		if err != nil {
+			return nil, invalid("bqtest: JSON row %d: %v", line, err)
+		}
+		rows = append(rows, row)
+	}
+}
@@ -64,6 +64,11 @@ type CopyConfig struct {
 	// copies tables, but can also be set to perform snapshot or restore operations.
 	OperationType TableCopyOperationType
 
+	// DestinationExpirationTime is the time when the destination table expires.
+	// It is typically set for snapshots and clones. If zero, the destination
+	// table uses the dataset's default expiration, if any.
+	DestinationExpirationTime time.Time
+
 	// Sets a best-effort deadline on a specific job.  If job execution exceeds this
 	// timeout, BigQuery may attempt to cancel this work automatically.
 	//
@@ -80,6 +85,10 @@ func (c *CopyConfig) toBQ() *bq.JobConfiguration {
 	for _, t := range c.Srcs {
 		ts = append(ts, t.toBQ())
 	}
+	var expiration interface{}
+	if !c.DestinationExpirationTime.IsZero() {
+		expiration = c.DestinationExpirationTime.UTC().Format(time.RFC3339Nano)
+	}
 	return &bq.JobConfiguration{
 		Labels: c.Labels,
 		Copy: &bq.JobConfigurationTableCopy{
@@ -89,6 +98,7 @@ func (c *CopyConfig) toBQ() *bq.JobConfiguration {
 			DestinationEncryptionConfiguration: c.DestinationEncryptionConfig.toBQ(),
 			SourceTables:                       ts,
 			OperationType:                      string(c.OperationType),
+			DestinationExpirationTime:          expiration,
 		},
 		JobTimeoutMs: c.JobTimeout.Milliseconds(),
 	}
@@ -104,6 +114,11 @@ func bqToCopyConfig(q *bq.JobConfiguration, c *Client) *CopyConfig {
 		OperationType:               TableCopyOperationType(q.Copy.OperationType),
 		JobTimeout:                  time.Duration(q.JobTimeoutMs) * time.Millisecond,
 	}
+	if s, ok := q.Copy.DestinationExpirationTime.(string); ok {
+		if t, err := time.Parse(time.RFC3339Nano, s); err == nil {
+			cc.DestinationExpirationTime = t
+		}
+	}
 	for _, t := range q.Copy.SourceTables {
 		cc.Srcs = append(cc.Srcs, bqToTable(t, c))
 	}
@@ -130,9 +145,38 @@ func (t *Table) CopierFrom(srcs ...*Table) *Copier {
 	}
 }
 
+// Snapshot returns a Copier that creates dst as a snapshot of t: a read-only
+// copy that is billed only for the data that later differs from t. Set the
+// Copier's DestinationExpirationTime to delete the snapshot automatically.
+// To snapshot t as of an earlier time, use a table decorator such as
+// "mytable@1691078400000" as the TableID of t.
+func (t *Table) Snapshot(dst *Table) *Copier {
+	return t.copierWithOperation(dst, SnapshotOperation)
+}
+
+// Clone returns a Copier that creates dst as a clone of t: a writable copy
+// that is billed only for the data that differs from t.
+func (t *Table) Clone(dst *Table) *Copier {
+	return t.copierWithOperation(dst, CloneOperation)
+}
+
+// Restore returns a Copier that restores the snapshot t into dst. To replace
+// the contents of an existing table, set the Copier's WriteDisposition to
+// WriteTruncate.
+func (t *Table) Restore(dst *Table) *Copier {
+	return t.copierWithOperation(dst, RestoreOperation)
+}
+
+func (t *Table) copierWithOperation(dst *Table, op TableCopyOperationType) *Copier {
+	c := dst.CopierFrom(t)
+	c.c = t.c
+	c.OperationType = op
+	return c
+}
+
 // Run initiates a copy job.
 func (c *Copier) Run(ctx context.Context) (*Job, error) {
-	return c.c.insertJob(ctx, c.newJob(), nil)
+	return c.c.insertJob(ctx, c.newJob(), nil, &c.JobIDConfig)
 }
 
 func (c *Copier) newJob() *bq.Job {
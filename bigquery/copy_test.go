@@ -162,6 +162,30 @@ func TestCopy(t *testing.T) {
 				return j
 			}(),
 		},
+		{
+			dst: &Table{
+				ProjectID: "d-project-id",
+				DatasetID: "d-dataset-id",
+				TableID:   "d-table-id",
+			},
+			srcs: []*Table{
+				{
+					ProjectID: "s-project-id",
+					DatasetID: "s-dataset-id",
+					TableID:   "s-table-id",
+				},
+			},
+			config: CopyConfig{
+				OperationType:             CloneOperation,
+				DestinationExpirationTime: time.Date(2023, 8, 3, 16, 0, 0, 0, time.UTC),
+			},
+			want: func() *bq.Job {
+				j := defaultCopyJob()
+				j.Configuration.Copy.OperationType = "CLONE"
+				j.Configuration.Copy.DestinationExpirationTime = "2023-08-03T16:00:00Z"
+				return j
+			}(),
+		},
 	}
 	c := &Client{projectID: "client-project-id"}
 	for i, tc := range testCases {
@@ -186,3 +210,22 @@ func TestCopy(t *testing.T) {
 		}
 	}
 }
+
+func TestTableCopyOperations(t *testing.T) {
+	defer fixRandomID("RANDOM")()
+	c := &Client{projectID: "client-project-id"}
+	src := c.DatasetInProject("s-project-id", "s-dataset-id").Table("s-table-id")
+	dst := c.DatasetInProject("d-project-id", "d-dataset-id").Table("d-table-id")
+	for _, tc := range []struct {
+		copier *Copier
+		want   string
+	}{
+		{src.Snapshot(dst), "SNAPSHOT"},
+		{src.Clone(dst), "CLONE"},
+		{src.Restore(dst), "RESTORE"},
+	} {
+		want := defaultCopyJob()
+		want.Configuration.Copy.OperationType = tc.want
+		checkJob(t, 0, tc.copier.newJob(), want)
+	}
+}
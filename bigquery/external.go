@@ -16,6 +16,10 @@ package bigquery
 
 import (
 	"encoding/base64"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
 	"unicode/utf8"
 
 	bq "google.golang.org/api/bigquery/v2"
@@ -114,6 +118,103 @@ type ExternalDataConfig struct {
 	// When creating an external table, the user can provide a reference file with the table schema.
 	// This is enabled for the following formats: AVRO, PARQUET, ORC.
 	ReferenceFileSchemaURI string
+
+	// MetadataCacheMode enables caching of the metadata of the source files,
+	// which speeds up queries over many files. It requires ConnectionID, as it
+	// is only supported for BigLake tables. Use TableMetadata.MaxStaleness to
+	// bound how stale the cached metadata may be.
+	MetadataCacheMode MetadataCacheMode
+}
+
+// MetadataCacheMode controls how the metadata cache of a BigLake table is
+// refreshed.
+type MetadataCacheMode string
+
+const (
+	// AutomaticMetadataCacheMode refreshes the metadata cache at a
+	// system-defined interval.
+	AutomaticMetadataCacheMode MetadataCacheMode = "AUTOMATIC"
+	// ManualMetadataCacheMode refreshes the metadata cache only when the
+	// BQ.REFRESH_EXTERNAL_METADATA_CACHE system procedure is called.
+	ManualMetadataCacheMode MetadataCacheMode = "MANUAL"
+)
+
+// connectionIDRE matches the forms accepted for ExternalDataConfig.ConnectionID:
+// "project.location.connection", "location.connection" and
+// "projects/project/locations/location/connections/connection".
+var connectionIDRE = regexp.MustCompile(`^(?:(?:[\w.:-]+\.)?[\w-]+\.[\w-]+|projects/[^/]+/locations/[^/]+/connections/[^/]+)$`)
+
+// Validate reports problems with the configuration that would otherwise only
+// be detected by the service, when a table using it is created or a query
+// using it is run. It checks that required fields are set, that Options and
+// the other format-specific fields match SourceFormat, and that Hive
+// partitioning and metadata caching are configured consistently.
+func (e *ExternalDataConfig) Validate() error {
+	if e.SourceFormat == "" {
+		return errors.New("bigquery: ExternalDataConfig requires SourceFormat")
+	}
+	if len(e.SourceURIs) == 0 {
+		return errors.New("bigquery: ExternalDataConfig requires SourceURIs")
+	}
+	switch e.SourceFormat {
+	case Bigtable, DatastoreBackup:
+		if len(e.SourceURIs) != 1 {
+			return fmt.Errorf("bigquery: %s external data requires exactly one source URI, got %d", e.SourceFormat, len(e.SourceURIs))
+		}
+	}
+	if e.Options != nil {
+		var want DataFormat
+		switch e.Options.(type) {
+		case *CSVOptions:
+			want = CSV
+		case *GoogleSheetsOptions:
+			want = GoogleSheets
+		case *BigtableOptions:
+			want = Bigtable
+		case *ParquetOptions:
+			want = Parquet
+		case *AvroOptions:
+			want = Avro
+		}
+		if want != "" && want != e.SourceFormat {
+			return fmt.Errorf("bigquery: %T cannot be used with source format %s", e.Options, e.SourceFormat)
+		}
+	}
+	if e.ReferenceFileSchemaURI != "" {
+		switch e.SourceFormat {
+		case Avro, Parquet, ORC:
+		default:
+			return fmt.Errorf("bigquery: ReferenceFileSchemaURI is not supported for source format %s", e.SourceFormat)
+		}
+	}
+	for _, t := range e.DecimalTargetTypes {
+		switch t {
+		case NumericTargetType, BigNumericTargetType, StringTargetType:
+		default:
+			return fmt.Errorf("bigquery: invalid DecimalTargetType %q", t)
+		}
+	}
+	if e.ConnectionID != "" && !connectionIDRE.MatchString(e.ConnectionID) {
+		return fmt.Errorf("bigquery: invalid ConnectionID %q; want \"project.location.connection\" or \"projects/project/locations/location/connections/connection\"", e.ConnectionID)
+	}
+	switch e.MetadataCacheMode {
+	case "":
+	case AutomaticMetadataCacheMode, ManualMetadataCacheMode:
+		if e.ConnectionID == "" {
+			return errors.New("bigquery: MetadataCacheMode requires ConnectionID")
+		}
+	default:
+		return fmt.Errorf("bigquery: invalid MetadataCacheMode %q", e.MetadataCacheMode)
+	}
+	if e.SourceFormat == Iceberg && e.ConnectionID == "" {
+		return errors.New("bigquery: Iceberg external data requires ConnectionID")
+	}
+	if e.HivePartitioningOptions != nil {
+		if err := e.HivePartitioningOptions.validate(e); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 func (e *ExternalDataConfig) toBQ() bq.ExternalDataConfiguration {
@@ -127,6 +228,7 @@ func (e *ExternalDataConfig) toBQ() bq.ExternalDataConfiguration {
 		HivePartitioningOptions: e.HivePartitioningOptions.toBQ(),
 		ConnectionId:            e.ConnectionID,
 		ReferenceFileSchemaUri:  e.ReferenceFileSchemaURI,
+		MetadataCacheMode:       string(e.MetadataCacheMode),
 	}
 	if e.Schema != nil {
 		q.Schema = e.Schema.toBQ()
@@ -152,6 +254,7 @@ func bqToExternalDataConfig(q *bq.ExternalDataConfiguration) (*ExternalDataConfi
 		HivePartitioningOptions: bqToHivePartitioningOptions(q.HivePartitioningOptions),
 		ConnectionID:            q.ConnectionId,
 		ReferenceFileSchemaURI:  q.ReferenceFileSchemaUri,
+		MetadataCacheMode:       MetadataCacheMode(q.MetadataCacheMode),
 	}
 	for _, v := range q.DecimalTargetTypes {
 		e.DecimalTargetTypes = append(e.DecimalTargetTypes, DecimalTargetType(v))
@@ -553,6 +656,40 @@ type HivePartitioningOptions struct {
 	RequirePartitionFilter bool
 }
 
+// validate checks o against the external data configuration e it belongs to.
+func (o *HivePartitioningOptions) validate(e *ExternalDataConfig) error {
+	switch e.SourceFormat {
+	case Avro, CSV, JSON, ORC, Parquet:
+	default:
+		return fmt.Errorf("bigquery: Hive partitioning is not supported for source format %s", e.SourceFormat)
+	}
+	switch o.Mode {
+	case AutoHivePartitioningMode, StringHivePartitioningMode, CustomHivePartitioningMode:
+	default:
+		return fmt.Errorf("bigquery: invalid HivePartitioningMode %q", o.Mode)
+	}
+	if o.SourceURIPrefix == "" {
+		return errors.New("bigquery: HivePartitioningOptions requires SourceURIPrefix")
+	}
+	// A CUSTOM prefix ends with the partition key schema, as in
+	// "gs://bucket/path/{dt:DATE}/{country:STRING}".
+	prefix := o.SourceURIPrefix
+	if i := strings.Index(prefix, "{"); i >= 0 {
+		if o.Mode != CustomHivePartitioningMode {
+			return fmt.Errorf("bigquery: SourceURIPrefix %q encodes partition keys, which requires CustomHivePartitioningMode", prefix)
+		}
+		prefix = prefix[:i]
+	} else if o.Mode == CustomHivePartitioningMode {
+		return fmt.Errorf("bigquery: SourceURIPrefix %q must encode the partition keys in CustomHivePartitioningMode", prefix)
+	}
+	for _, uri := range e.SourceURIs {
+		if !strings.HasPrefix(uri, prefix) {
+			return fmt.Errorf("bigquery: source URI %q does not start with SourceURIPrefix %q", uri, prefix)
+		}
+	}
+	return nil
+}
+
 func (o *HivePartitioningOptions) toBQ() *bq.HivePartitioningOptions {
 	if o == nil {
 		return nil
@@ -99,6 +99,12 @@ func TestExternalDataConfig(t *testing.T) {
 				UseAvroLogicalTypes: true,
 			},
 		},
+		{
+			SourceFormat:      Parquet,
+			SourceURIs:        []string{"gs://bucket/*.parquet"},
+			ConnectionID:      "proj.us.conn",
+			MetadataCacheMode: AutomaticMetadataCacheMode,
+		},
 	} {
 		q := want.toBQ()
 		got, err := bqToExternalDataConfig(&q)
@@ -111,6 +117,75 @@ func TestExternalDataConfig(t *testing.T) {
 	}
 }
 
+func TestExternalDataConfigValidate(t *testing.T) {
+	valid := []*ExternalDataConfig{
+		{SourceFormat: CSV, SourceURIs: []string{"gs://b/*.csv"}, Options: &CSVOptions{SkipLeadingRows: 1}},
+		{SourceFormat: Bigtable, SourceURIs: []string{"https://googleapis.com/bigtable/projects/p/instances/i/tables/t"}},
+		{
+			SourceFormat:           Parquet,
+			SourceURIs:             []string{"gs://b/t/dt=2023-01-01/*", "gs://b/t/dt=2023-01-02/*"},
+			ReferenceFileSchemaURI: "gs://b/schema.parquet",
+			HivePartitioningOptions: &HivePartitioningOptions{
+				Mode:            AutoHivePartitioningMode,
+				SourceURIPrefix: "gs://b/t/",
+			},
+		},
+		{
+			SourceFormat: Avro,
+			SourceURIs:   []string{"gs://b/t/dt=2023-01-01/*"},
+			HivePartitioningOptions: &HivePartitioningOptions{
+				Mode:            CustomHivePartitioningMode,
+				SourceURIPrefix: "gs://b/t/{dt:DATE}",
+			},
+		},
+		{
+			SourceFormat:      Iceberg,
+			SourceURIs:        []string{"gs://b/t/metadata/v1.metadata.json"},
+			ConnectionID:      "projects/p/locations/us/connections/c",
+			MetadataCacheMode: ManualMetadataCacheMode,
+		},
+		{SourceFormat: ORC, SourceURIs: []string{"gs://b/*"}, ConnectionID: "us.c"},
+	}
+	for i, e := range valid {
+		if err := e.Validate(); err != nil {
+			t.Errorf("valid #%d: %v", i, err)
+		}
+	}
+
+	uris := []string{"gs://b/t/*"}
+	for _, test := range []struct {
+		desc string
+		e    *ExternalDataConfig
+	}{
+		{"no format", &ExternalDataConfig{SourceURIs: uris}},
+		{"no URIs", &ExternalDataConfig{SourceFormat: CSV}},
+		{"two Bigtable URIs", &ExternalDataConfig{SourceFormat: Bigtable, SourceURIs: []string{"a", "b"}}},
+		{"options mismatch", &ExternalDataConfig{SourceFormat: Parquet, SourceURIs: uris, Options: &CSVOptions{}}},
+		{"reference schema for CSV", &ExternalDataConfig{SourceFormat: CSV, SourceURIs: uris, ReferenceFileSchemaURI: "gs://b/s"}},
+		{"bad decimal type", &ExternalDataConfig{SourceFormat: Parquet, SourceURIs: uris, DecimalTargetTypes: []DecimalTargetType{"INT"}}},
+		{"bad connection", &ExternalDataConfig{SourceFormat: Parquet, SourceURIs: uris, ConnectionID: "conn"}},
+		{"cache without connection", &ExternalDataConfig{SourceFormat: Parquet, SourceURIs: uris, MetadataCacheMode: AutomaticMetadataCacheMode}},
+		{"bad cache mode", &ExternalDataConfig{SourceFormat: Parquet, SourceURIs: uris, ConnectionID: "p.us.c", MetadataCacheMode: "ALWAYS"}},
+		{"Iceberg without connection", &ExternalDataConfig{SourceFormat: Iceberg, SourceURIs: uris}},
+		{"Hive for Sheets", &ExternalDataConfig{SourceFormat: GoogleSheets, SourceURIs: uris,
+			HivePartitioningOptions: &HivePartitioningOptions{Mode: AutoHivePartitioningMode, SourceURIPrefix: "gs://b/t/"}}},
+		{"Hive without mode", &ExternalDataConfig{SourceFormat: Parquet, SourceURIs: uris,
+			HivePartitioningOptions: &HivePartitioningOptions{SourceURIPrefix: "gs://b/t/"}}},
+		{"Hive without prefix", &ExternalDataConfig{SourceFormat: Parquet, SourceURIs: uris,
+			HivePartitioningOptions: &HivePartitioningOptions{Mode: AutoHivePartitioningMode}}},
+		{"URI outside prefix", &ExternalDataConfig{SourceFormat: Parquet, SourceURIs: []string{"gs://other/*"},
+			HivePartitioningOptions: &HivePartitioningOptions{Mode: AutoHivePartitioningMode, SourceURIPrefix: "gs://b/t/"}}},
+		{"custom keys in AUTO mode", &ExternalDataConfig{SourceFormat: Parquet, SourceURIs: uris,
+			HivePartitioningOptions: &HivePartitioningOptions{Mode: AutoHivePartitioningMode, SourceURIPrefix: "gs://b/t/{dt:DATE}"}}},
+		{"CUSTOM mode without keys", &ExternalDataConfig{SourceFormat: Parquet, SourceURIs: uris,
+			HivePartitioningOptions: &HivePartitioningOptions{Mode: CustomHivePartitioningMode, SourceURIPrefix: "gs://b/t/"}}},
+	} {
+		if err := test.e.Validate(); err == nil {
+			t.Errorf("%s: got nil, want error", test.desc)
+		}
+	}
+}
+
 func TestQuote(t *testing.T) {
 	ptr := func(s string) *string { return &s }
 
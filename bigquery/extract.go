@@ -147,7 +147,7 @@ func (e *Extractor) Run(ctx context.Context) (j *Job, err error) {
 	ctx = trace.StartSpan(ctx, "cloud.google.com/go/bigquery.Extractor.Run")
 	defer func() { trace.EndSpan(ctx, err) }()
 
-	return e.c.insertJob(ctx, e.newJob(), nil)
+	return e.c.insertJob(ctx, e.newJob(), nil, &e.JobIDConfig)
 }
 
 func (e *Extractor) newJob() *bq.Job {
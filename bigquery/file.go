@@ -16,8 +16,10 @@ package bigquery
 
 import (
 	"io"
+	"time"
 
 	bq "google.golang.org/api/bigquery/v2"
+	"google.golang.org/api/googleapi"
 )
 
 // A ReaderSource is a source for a load operation that gets
@@ -26,9 +28,31 @@ import (
 // When a ReaderSource is part of a LoadConfig obtained via Job.Config,
 // its internal io.Reader will be nil, so it cannot be used for a
 // subsequent load operation.
+//
+// Data is uploaded in chunks, so that a chunk that fails with a transient
+// error is retried on its own rather than restarting the whole upload. The
+// fields below tune this for large loads or unreliable networks.
 type ReaderSource struct {
 	r io.Reader
 	FileConfig
+
+	// ChunkSize is the maximum number of bytes sent in each upload request.
+	// Each chunk is buffered in memory so that it can be resent, so larger
+	// chunks use more memory but need fewer requests. ChunkSize is rounded up
+	// to a multiple of googleapi.MinUploadChunkSize. If zero,
+	// googleapi.DefaultUploadChunkSize is used. Data that fits in a single
+	// chunk is sent in one request, which is not retried.
+	ChunkSize int
+
+	// ChunkRetryDeadline bounds how long a failed chunk is retried before the
+	// upload, and so the Loader.Run call, fails. If zero, a default of 32
+	// seconds is used.
+	ChunkRetryDeadline time.Duration
+
+	// ProgressFunc, if set, is called after each chunk is uploaded with the
+	// total number of bytes uploaded so far. It is not called for data that
+	// fits in a single chunk.
+	ProgressFunc func(int64)
 }
 
 // NewReaderSource creates a ReaderSource from an io.Reader. You may
@@ -38,9 +62,22 @@ func NewReaderSource(r io.Reader) *ReaderSource {
 	return &ReaderSource{r: r}
 }
 
-func (r *ReaderSource) populateLoadConfig(lc *bq.JobConfigurationLoad) io.Reader {
+func (r *ReaderSource) populateLoadConfig(lc *bq.JobConfigurationLoad) *mediaUpload {
 	r.FileConfig.populateLoadConfig(lc)
-	return r.r
+	if r.r == nil {
+		return nil
+	}
+	media := &mediaUpload{r: r.r}
+	if r.ChunkSize != 0 {
+		media.opts = append(media.opts, googleapi.ChunkSize(r.ChunkSize))
+	}
+	if r.ChunkRetryDeadline != 0 {
+		media.opts = append(media.opts, googleapi.ChunkRetryDeadline(r.ChunkRetryDeadline))
+	}
+	if f := r.ProgressFunc; f != nil {
+		media.progress = func(current, _ int64) { f(current) }
+	}
+	return media
 }
 
 // FileConfig contains configuration options that pertain to files, typically
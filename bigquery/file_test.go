@@ -15,10 +15,13 @@
 package bigquery
 
 import (
+	"strings"
 	"testing"
+	"time"
 
 	"cloud.google.com/go/internal/testutil"
 	bq "google.golang.org/api/bigquery/v2"
+	"google.golang.org/api/googleapi"
 )
 
 var (
@@ -190,3 +193,34 @@ func TestFileConfigPopulateExternalDataConfig(t *testing.T) {
 	}
 
 }
+
+func TestReaderSourceUpload(t *testing.T) {
+	if got := NewReaderSource(nil).populateLoadConfig(&bq.JobConfigurationLoad{}); got != nil {
+		t.Errorf("nil reader: got %+v, want nil upload", got)
+	}
+
+	media := NewReaderSource(strings.NewReader("a,b")).populateLoadConfig(&bq.JobConfigurationLoad{})
+	if len(media.opts) != 0 || media.progress != nil {
+		t.Errorf("default options: got %+v", media)
+	}
+
+	var progress []int64
+	rs := NewReaderSource(strings.NewReader("a,b"))
+	rs.ChunkSize = 2 * googleapi.MinUploadChunkSize
+	rs.ChunkRetryDeadline = time.Minute
+	rs.ProgressFunc = func(n int64) { progress = append(progress, n) }
+	media = rs.populateLoadConfig(&bq.JobConfigurationLoad{})
+	got := googleapi.ProcessMediaOptions(media.opts)
+	want := &googleapi.MediaOptions{
+		ChunkSize:          2 * googleapi.MinUploadChunkSize,
+		ChunkRetryDeadline: time.Minute,
+	}
+	if diff := testutil.Diff(got, want); diff != "" {
+		t.Errorf("media options: got=-, want=+:\n%s", diff)
+	}
+	media.progress(10, 100)
+	media.progress(20, 100)
+	if want := []int64{10, 20}; !testutil.Equal(progress, want) {
+		t.Errorf("progress: got %v, want %v", progress, want)
+	}
+}
@@ -0,0 +1,142 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bigquery
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Pseudo-columns returned by the query from Table.Appends, in addition to the
+// columns of the table.
+const (
+	// ChangeTypeColumn holds the type of the change that produced a row. For
+	// appends, it is always "INSERT".
+	ChangeTypeColumn = "_CHANGE_TYPE"
+
+	// ChangeTimestampColumn holds the commit time of the change that produced
+	// a row, as a TIMESTAMP.
+	ChangeTimestampColumn = "_CHANGE_TIMESTAMP"
+)
+
+// AppendsOptions configures the query returned by Table.Appends.
+type AppendsOptions struct {
+	// Columns are the columns of the table to return. If empty, all columns
+	// are returned. The ChangeTypeColumn and ChangeTimestampColumn
+	// pseudo-columns are always returned.
+	Columns []string
+
+	// Start is the earliest time, inclusive, of the changes to return. If
+	// zero, changes are returned from the creation of the table, or the start
+	// of the time travel window if that is later.
+	Start time.Time
+
+	// End is the latest time, exclusive, of the changes to return. If zero,
+	// changes are returned up to the time the query runs.
+	End time.Time
+}
+
+// Appends returns a query for the rows appended to the table between
+// opts.Start and opts.End, using the APPENDS table-valued function. Each row
+// includes the ChangeTimestampColumn, so a consumer of changes can resume
+// from the latest timestamp it has processed by using it as the next Start.
+// The times are passed as query parameters, so their precision and time zone
+// are preserved. opts may be nil.
+//
+// See https://cloud.google.com/bigquery/docs/reference/standard-sql/table-functions-built-in#appends
+// for details, including which changes are visible.
+func (t *Table) Appends(opts *AppendsOptions) (*Query, error) {
+	if opts == nil {
+		opts = &AppendsOptions{}
+	}
+	if !opts.Start.IsZero() && !opts.End.IsZero() && !opts.Start.Before(opts.End) {
+		return nil, fmt.Errorf("bigquery: Appends start %v is not before end %v", opts.Start, opts.End)
+	}
+	cols, err := selectColumns(opts.Columns)
+	if err != nil {
+		return nil, err
+	}
+	if len(opts.Columns) > 0 {
+		cols += ", " + ChangeTypeColumn + ", " + ChangeTimestampColumn
+	}
+	id, err := quotedTableID(t)
+	if err != nil {
+		return nil, err
+	}
+	q := t.c.Query(fmt.Sprintf("SELECT %s FROM APPENDS(TABLE %s, @appends_start, @appends_end)", cols, id))
+	q.Parameters = []QueryParameter{
+		{Name: "appends_start", Value: timestampParam(opts.Start)},
+		{Name: "appends_end", Value: timestampParam(opts.End)},
+	}
+	return q, nil
+}
+
+// AsOf returns a query for the contents of the table as they were at time
+// asOf, using FOR SYSTEM_TIME AS OF. asOf must be within the time travel
+// window of the table's dataset. If columns is empty, all columns are
+// returned.
+//
+// See https://cloud.google.com/bigquery/docs/time-travel for details.
+func (t *Table) AsOf(asOf time.Time, columns ...string) (*Query, error) {
+	if asOf.IsZero() {
+		return nil, errors.New("bigquery: AsOf requires a time")
+	}
+	cols, err := selectColumns(columns)
+	if err != nil {
+		return nil, err
+	}
+	id, err := quotedTableID(t)
+	if err != nil {
+		return nil, err
+	}
+	q := t.c.Query(fmt.Sprintf("SELECT %s FROM %s FOR SYSTEM_TIME AS OF @as_of", cols, id))
+	q.Parameters = []QueryParameter{{Name: "as_of", Value: asOf}}
+	return q, nil
+}
+
+// timestampParam returns the value of a TIMESTAMP parameter that is NULL if t
+// is zero.
+func timestampParam(t time.Time) NullTimestamp {
+	return NullTimestamp{Timestamp: t, Valid: !t.IsZero()}
+}
+
+// selectColumns returns the SELECT list for columns, quoting each name.
+func selectColumns(columns []string) (string, error) {
+	if len(columns) == 0 {
+		return "*", nil
+	}
+	quoted := make([]string, len(columns))
+	for i, c := range columns {
+		if c == "" || strings.ContainsAny(c, "`\\") {
+			return "", fmt.Errorf("bigquery: invalid column name %q", c)
+		}
+		quoted[i] = "`" + c + "`"
+	}
+	return strings.Join(quoted, ", "), nil
+}
+
+// quotedTableID returns the quoted Standard SQL identifier of t.
+func quotedTableID(t *Table) (string, error) {
+	id, err := t.Identifier(StandardSQLID)
+	if err != nil {
+		return "", err
+	}
+	if strings.ContainsAny(id, "`\\") {
+		return "", fmt.Errorf("bigquery: invalid table identifier %q", id)
+	}
+	return "`" + id + "`", nil
+}
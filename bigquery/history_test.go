@@ -0,0 +1,127 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bigquery
+
+import (
+	"testing"
+	"time"
+
+	"cloud.google.com/go/internal/testutil"
+)
+
+func TestAppends(t *testing.T) {
+	c := &Client{projectID: "p"}
+	tbl := c.DatasetInProject("p", "d").Table("t")
+	start := time.Date(2023, 5, 1, 12, 30, 0, 123456000, time.FixedZone("X", 3600))
+	end := start.Add(time.Hour)
+
+	for _, test := range []struct {
+		opts       *AppendsOptions
+		wantQ      string
+		wantParams []QueryParameter
+	}{
+		{
+			opts:  nil,
+			wantQ: "SELECT * FROM APPENDS(TABLE `p.d.t`, @appends_start, @appends_end)",
+			wantParams: []QueryParameter{
+				{Name: "appends_start", Value: NullTimestamp{}},
+				{Name: "appends_end", Value: NullTimestamp{}},
+			},
+		},
+		{
+			opts:  &AppendsOptions{Columns: []string{"a", "b c"}, Start: start},
+			wantQ: "SELECT `a`, `b c`, _CHANGE_TYPE, _CHANGE_TIMESTAMP FROM APPENDS(TABLE `p.d.t`, @appends_start, @appends_end)",
+			wantParams: []QueryParameter{
+				{Name: "appends_start", Value: NullTimestamp{Timestamp: start, Valid: true}},
+				{Name: "appends_end", Value: NullTimestamp{}},
+			},
+		},
+		{
+			opts:  &AppendsOptions{Start: start, End: end},
+			wantQ: "SELECT * FROM APPENDS(TABLE `p.d.t`, @appends_start, @appends_end)",
+			wantParams: []QueryParameter{
+				{Name: "appends_start", Value: NullTimestamp{Timestamp: start, Valid: true}},
+				{Name: "appends_end", Value: NullTimestamp{Timestamp: end, Valid: true}},
+			},
+		},
+	} {
+		q, err := tbl.Appends(test.opts)
+		if err != nil {
+			t.Fatalf("%+v: %v", test.opts, err)
+		}
+		if q.Q != test.wantQ {
+			t.Errorf("%+v: got query %q, want %q", test.opts, q.Q, test.wantQ)
+		}
+		if diff := testutil.Diff(q.Parameters, test.wantParams); diff != "" {
+			t.Errorf("%+v: parameters: -got +want:\n%s", test.opts, diff)
+		}
+	}
+
+	// The timestamp parameter preserves the precision of the time.
+	q, err := tbl.Appends(&AppendsOptions{Start: start})
+	if err != nil {
+		t.Fatal(err)
+	}
+	qp, err := q.Parameters[0].toBQ()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := qp.ParameterValue.Value, "2023-05-01 12:30:00.123456+01:00"; got != want {
+		t.Errorf("start parameter: got %q, want %q", got, want)
+	}
+
+	for _, opts := range []*AppendsOptions{
+		{Start: end, End: start},
+		{Start: start, End: start},
+		{Columns: []string{"a`b"}},
+		{Columns: []string{""}},
+	} {
+		if _, err := tbl.Appends(opts); err == nil {
+			t.Errorf("%+v: got nil, want error", opts)
+		}
+	}
+}
+
+func TestAsOf(t *testing.T) {
+	c := &Client{projectID: "p"}
+	tbl := c.DatasetInProject("other", "d").Table("t")
+	ts := time.Date(2023, 5, 1, 12, 30, 0, 0, time.UTC)
+
+	q, err := tbl.AsOf(ts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := q.Q, "SELECT * FROM `other.d.t` FOR SYSTEM_TIME AS OF @as_of"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+	if diff := testutil.Diff(q.Parameters, []QueryParameter{{Name: "as_of", Value: ts}}); diff != "" {
+		t.Errorf("parameters: -got +want:\n%s", diff)
+	}
+
+	q, err = tbl.AsOf(ts, "x", "y")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := q.Q, "SELECT `x`, `y` FROM `other.d.t` FOR SYSTEM_TIME AS OF @as_of"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+
+	if _, err := tbl.AsOf(time.Time{}); err == nil {
+		t.Error("zero time: got nil, want error")
+	}
+	if _, err := tbl.AsOf(ts, "a`b"); err == nil {
+		t.Error("bad column: got nil, want error")
+	}
+}
@@ -16,6 +16,9 @@ package bigquery
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"reflect"
@@ -58,6 +61,39 @@ type Inserter struct {
 	// More information is available at
 	// https://cloud.google.com/bigquery/streaming-data-into-bigquery#template-tables
 	TableTemplateSuffix string
+
+	// InsertIDFunc, if set, generates the insert ID of each row whose
+	// ValueSaver returns an empty insert ID, in place of a random one. It is
+	// called with the row returned by the ValueSaver. As with ValueSavers, a
+	// result of NoDedupeID sends no insert ID, and an empty result a random
+	// one.
+	//
+	// Insert IDs govern best-effort deduplication: a random ID only
+	// deduplicates retries within a single call to Put, while
+	// ContentHashInsertID also deduplicates identical rows sent by separate
+	// calls. NoInsertID disables deduplication, which allows higher
+	// streaming throughput.
+	InsertIDFunc func(row map[string]Value) (string, error)
+}
+
+// NoInsertID is an InsertIDFunc that sends rows without insert IDs, opting
+// out of best-effort deduplication.
+func NoInsertID(map[string]Value) (string, error) {
+	return NoDedupeID, nil
+}
+
+// ContentHashInsertID is an InsertIDFunc that uses a hash of the contents of
+// the row as its insert ID, so that identical rows are deduplicated even if
+// they are inserted by different calls to Put, or by different processes.
+// Use it only for tables in which identical rows are never valid.
+func ContentHashInsertID(row map[string]Value) (string, error) {
+	// Map keys are sorted when encoded, so the encoding is deterministic.
+	b, err := json.Marshal(row)
+	if err != nil {
+		return "", fmt.Errorf("bigquery: hashing row for insert ID: %v", err)
+	}
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:]), nil
 }
 
 // Inserter returns an Inserter that can be used to append rows to t.
@@ -89,7 +125,7 @@ func (t *Table) Uploader() *Inserter { return t.Inserter() }
 //
 // Put will retry on temporary errors (see
 // https://cloud.google.com/bigquery/troubleshooting-errors). This can result
-// in duplicate rows if you do not use insert IDs. Also, if the error persists,
+// in duplicate rows if you do not use insert IDs; see InsertIDFunc. Also, if the error persists,
 // the call will run indefinitely. Pass a context with a timeout to prevent
 // hanging calls.
 func (u *Inserter) Put(ctx context.Context, src interface{}) (err error) {
@@ -206,6 +242,11 @@ func (u *Inserter) newInsertRequest(savers []ValueSaver) (*bq.TableDataInsertAll
 		if err != nil {
 			return nil, err
 		}
+		if insertID == "" && u.InsertIDFunc != nil {
+			if insertID, err = u.InsertIDFunc(row); err != nil {
+				return nil, err
+			}
+		}
 		if insertID == NoDedupeID {
 			// User wants to opt-out of sending deduplication ID.
 			insertID = ""
@@ -100,6 +100,54 @@ func TestNewInsertRequest(t *testing.T) {
 	}
 }
 
+func TestInsertIDFunc(t *testing.T) {
+	defer fixRandomID("random")()
+	savers := []ValueSaver{
+		testSaver{row: map[string]Value{"a": 1}},
+		testSaver{insertID: "explicit", row: map[string]Value{"a": 1}},
+		testSaver{row: map[string]Value{"a": 2}},
+	}
+	ids := func(u *Inserter) []string {
+		t.Helper()
+		req, err := u.newInsertRequest(savers)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var ids []string
+		for _, r := range req.Rows {
+			ids = append(ids, r.InsertId)
+		}
+		return ids
+	}
+
+	if got, want := ids(&Inserter{InsertIDFunc: NoInsertID}), []string{"", "explicit", ""}; !testutil.Equal(got, want) {
+		t.Errorf("NoInsertID: got %q, want %q", got, want)
+	}
+
+	got := ids(&Inserter{InsertIDFunc: ContentHashInsertID})
+	if got[1] != "explicit" || got[0] == "" || got[0] == got[2] || got[0] == "random" {
+		t.Errorf("ContentHashInsertID: got %q", got)
+	}
+	if again := ids(&Inserter{InsertIDFunc: ContentHashInsertID}); !testutil.Equal(again, got) {
+		t.Errorf("ContentHashInsertID is not deterministic: got %q, then %q", got, again)
+	}
+
+	perRow := func(row map[string]Value) (string, error) {
+		if row["a"] == 2 {
+			return "", nil
+		}
+		return fmt.Sprintf("row-%v", row["a"]), nil
+	}
+	if got, want := ids(&Inserter{InsertIDFunc: perRow}), []string{"row-1", "explicit", "random"}; !testutil.Equal(got, want) {
+		t.Errorf("per-row func: got %q, want %q", got, want)
+	}
+
+	u := &Inserter{InsertIDFunc: func(map[string]Value) (string, error) { return "", errors.New("bang") }}
+	if _, err := u.newInsertRequest(savers); err == nil {
+		t.Error("failing InsertIDFunc: got nil, want error")
+	}
+}
+
 func TestNewInsertRequestErrors(t *testing.T) {
 	var u Uploader
 	_, err := u.newInsertRequest([]ValueSaver{testSaver{err: errors.New("bang")}})
@@ -2068,6 +2068,46 @@ func TestIntegration_QuerySessionSupport(t *testing.T) {
 
 }
 
+func TestIntegration_SessionTransaction(t *testing.T) {
+	if client == nil {
+		t.Skip("Integration tests skipped")
+	}
+	ctx := context.Background()
+
+	s, err := client.CreateSession(ctx)
+	if err != nil {
+		t.Fatalf("CreateSession: %v", err)
+	}
+	defer s.Close(ctx)
+
+	if _, err := s.Exec(ctx, "CREATE TEMPORARY TABLE txtable (n INT64)"); err != nil {
+		t.Fatalf("CREATE TEMPORARY TABLE: %v", err)
+	}
+	err = s.RunInTransaction(ctx, func(ctx context.Context, s *Session) error {
+		_, err := s.Exec(ctx, "INSERT INTO txtable (n) VALUES (@n)", QueryParameter{Name: "n", Value: 1})
+		return err
+	})
+	if err != nil {
+		t.Fatalf("RunInTransaction: %v", err)
+	}
+	wantErr := errors.New("abort")
+	err = s.RunInTransaction(ctx, func(ctx context.Context, s *Session) error {
+		if _, err := s.Exec(ctx, "INSERT INTO txtable (n) VALUES (2)"); err != nil {
+			return err
+		}
+		return wantErr
+	})
+	if err != wantErr {
+		t.Fatalf("RunInTransaction: got %v, want %v", err, wantErr)
+	}
+
+	it, err := s.Query("SELECT n FROM txtable").Read(ctx)
+	if err != nil {
+		t.Fatalf("Read: %v", err)
+	}
+	checkReadAndTotalRows(t, "SessionTransaction", it, [][]Value{{int64(1)}})
+}
+
 var (
 	queryParameterTestCases = []struct {
 		query      string
@@ -2462,7 +2502,7 @@ func TestIntegration_TimestampFormat(t *testing.T) {
 		}
 		bqJob.Configuration.Query.QueryParameters = c.parameters
 
-		job, err := q.client.insertJob(ctx, bqJob, nil)
+		job, err := q.client.insertJob(ctx, bqJob, nil, nil)
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -126,20 +126,26 @@ type pageFetcher func(ctx context.Context, _ *rowSource, _ Schema, startIndex ui
 //	DATETIME    civil.DateTime
 //	NUMERIC     *big.Rat
 //	BIGNUMERIC  *big.Rat
+//	JSON        string, json.RawMessage, map[string]T
 //
 // The big.Rat type supports numbers of arbitrary size and precision.
 // See https://cloud.google.com/bigquery/docs/reference/standard-sql/data-types#numeric-type
 // for more on NUMERIC.
 //
+// A JSON value is decoded with encoding/json when read into a json.RawMessage
+// or a map. When read into a []Value or map[string]Value, it is a string.
+//
 // A repeated field corresponds to a slice or array of the element type. A STRUCT
 // type (RECORD or nested schema) corresponds to a nested struct or struct pointer.
 // All calls to Next on the same iterator must use the same struct type.
 //
 // It is an error to attempt to read a BigQuery NULL value into a struct field,
-// unless the field is of type []byte or is one of the special Null types: NullInt64,
-// NullFloat64, NullBool, NullString, NullTimestamp, NullDate, NullTime or
-// NullDateTime. You can also use a *[]Value or *map[string]Value to read from a
+// unless the field is of type []byte, json.RawMessage or map, or is one of the
+// special Null types: NullInt64, NullFloat64, NullBool, NullString,
+// NullTimestamp, NullDate, NullTime, NullDateTime or NullJSON. You can also use a *[]Value or *map[string]Value to read from a
 // table with NULLs.
+//
+// NextRow and AllRows provide the same conversions with a type parameter.
 func (it *RowIterator) Next(dst interface{}) error {
 	var vl ValueLoader
 	switch dst := dst.(type) {
@@ -171,6 +177,57 @@ func (it *RowIterator) Next(dst interface{}) error {
 	return vl.Load(row, it.Schema)
 }
 
+// NextRow loads the next row of it into a new value of type T and returns it.
+// Its second return value is iterator.Done if there are no more rows.
+//
+// T may be a struct, a pointer to a struct, []Value, map[string]Value, or a
+// type whose pointer implements ValueLoader. Struct fields are matched to
+// columns as described for RowIterator.Next.
+//
+// For example:
+//
+//	for {
+//		row, err := bigquery.NextRow[Item](it)
+//		if err == iterator.Done {
+//			break
+//		}
+//		if err != nil {
+//			// TODO: Handle error.
+//		}
+//		fmt.Println(row.Name)
+//	}
+func NextRow[T any](it *RowIterator) (T, error) {
+	var row T
+	dst := interface{}(&row)
+	if t := reflect.TypeOf(row); t != nil && t.Kind() == reflect.Ptr && t.Elem().Kind() == reflect.Struct {
+		// Load into a newly allocated struct rather than a pointer to a nil pointer.
+		p := reflect.New(t.Elem())
+		reflect.ValueOf(&row).Elem().Set(p)
+		dst = p.Interface()
+	}
+	if err := it.Next(dst); err != nil {
+		var zero T
+		return zero, err
+	}
+	return row, nil
+}
+
+// AllRows reads the remaining rows of it into a slice of T. See NextRow for
+// the supported types.
+func AllRows[T any](it *RowIterator) ([]T, error) {
+	var rows []T
+	for {
+		row, err := NextRow[T](it)
+		if err == iterator.Done {
+			return rows, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		rows = append(rows, row)
+	}
+}
+
 func isStructPtr(x interface{}) bool {
 	t := reflect.TypeOf(x)
 	return t.Kind() == reflect.Ptr && t.Elem().Kind() == reflect.Struct
@@ -510,3 +510,63 @@ func TestIteratorSourceJob(t *testing.T) {
 		}
 	}
 }
+
+func TestNextRowTypes(t *testing.T) {
+	type item struct {
+		Name string
+		Num  int64
+	}
+	newIt := func() *RowIterator {
+		pf := &pageFetcherStub{
+			fetchResponses: map[string]fetchResponse{
+				"": {
+					result: &fetchPageResult{
+						rows:   [][]Value{{"a", int64(1)}, {"b", int64(2)}},
+						schema: Schema{{Name: "name", Type: StringFieldType}, {Name: "num", Type: IntegerFieldType}},
+					},
+				},
+			},
+		}
+		return newRowIterator(context.Background(), nil, pf.fetchPage)
+	}
+
+	structs, err := AllRows[item](newIt())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []item{{"a", 1}, {"b", 2}}; !testutil.Equal(structs, want) {
+		t.Errorf("structs: got %v, want %v", structs, want)
+	}
+
+	ptrs, err := AllRows[*item](newIt())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []*item{{"a", 1}, {"b", 2}}; !testutil.Equal(ptrs, want) {
+		t.Errorf("pointers: got %v, want %v", ptrs, want)
+	}
+
+	values, err := AllRows[[]Value](newIt())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := [][]Value{{"a", int64(1)}, {"b", int64(2)}}; !testutil.Equal(values, want) {
+		t.Errorf("values: got %v, want %v", values, want)
+	}
+
+	it := newIt()
+	m, err := NextRow[map[string]Value](it)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := map[string]Value{"name": "a", "num": int64(1)}; !testutil.Equal(m, want) {
+		t.Errorf("map: got %v, want %v", m, want)
+	}
+
+	if _, err := NextRow[int](newIt()); err == nil {
+		t.Error("NextRow[int]: got nil error, want error")
+	}
+	if _, err := AllRows[int](newIt()); err == nil {
+		t.Error("AllRows[int]: got nil error, want error")
+	}
+}
@@ -16,6 +16,9 @@ package bigquery
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"time"
@@ -37,6 +40,16 @@ type Job struct {
 	email      string
 	config     *bq.JobConfiguration
 	lastStatus *JobStatus
+
+	insertAttempts int
+}
+
+// InsertAttempts reports the number of requests made to create the job,
+// including retries after transient errors. It can help to explain slow
+// calls to Run. It is zero for a Job that was not returned by Run, such as
+// one from JobFromID.
+func (j *Job) InsertAttempts() int {
+	return j.insertAttempts
 }
 
 // JobFromID creates a Job which refers to an existing BigQuery job. The job
@@ -160,7 +173,8 @@ func bqToJobConfig(q *bq.JobConfiguration, c *Client) (JobConfig, error) {
 	}
 }
 
-// JobIDConfig  describes how to create an ID for a job.
+// JobIDConfig  describes how to create an ID for a job, and how the request
+// that creates the job is retried.
 type JobIDConfig struct {
 	// JobID is the ID to use for the job. If empty, a random job ID will be generated.
 	JobID string
@@ -173,6 +187,37 @@ type JobIDConfig struct {
 
 	// ProjectID is the Google Cloud project associated with the job.
 	ProjectID string
+
+	// If DeterministicJobID is true, the generated part of the job ID (all of
+	// it if JobID is empty, or the suffix if AddJobIDSuffix is set) is a hash
+	// of the job's project, location and configuration instead of a random
+	// string, and an existing job with that ID is returned instead of an
+	// error. Submitting an identical job again, for example after a crash,
+	// then returns the original job rather than running it twice. The data
+	// of a ReaderSource is not part of the hash; to run identical jobs more
+	// than once, make them differ, for example with a label.
+	DeterministicJobID bool
+
+	// RetryPolicy controls how the request that creates the job is retried
+	// after transient errors. If nil, the request is retried with the default
+	// backoff until the context is done. Requests that upload data from a
+	// ReaderSource are never retried as a whole; see ReaderSource.ChunkSize.
+	RetryPolicy *JobRetryPolicy
+}
+
+// JobRetryPolicy configures the retries of the request that creates a job.
+// The job ID is the same for every attempt, so a retry never creates a second
+// job; if an earlier attempt did create the job, it is returned.
+type JobRetryPolicy struct {
+	// Backoff controls the delay between attempts. If its Initial delay is
+	// zero, the delays recommended by the BigQuery SLA are used: one second,
+	// doubling up to 32 seconds.
+	Backoff gax.Backoff
+
+	// MaxAttempts is the maximum number of attempts, including the first. If
+	// zero, attempts continue until the context is done. Set it to 1 to
+	// disable retries.
+	MaxAttempts int
 }
 
 // createJobRef creates a JobReference.
@@ -196,6 +241,28 @@ func (j *JobIDConfig) createJobRef(c *Client) *bq.JobReference {
 	return jr
 }
 
+// deterministicJobID returns the ID for job when DeterministicJobID is set.
+func (j *JobIDConfig) deterministicJobID(job *bq.Job) (string, error) {
+	b, err := json.Marshal(struct {
+		ProjectID string
+		Location  string
+		Config    *bq.JobConfiguration
+	}{job.JobReference.ProjectId, job.JobReference.Location, job.Configuration})
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(b)
+	hash := hex.EncodeToString(sum[:16])
+	switch {
+	case j.JobID == "":
+		return hash, nil
+	case j.AddJobIDSuffix:
+		return j.JobID + "-" + hash, nil
+	default:
+		return j.JobID, nil
+	}
+}
+
 // Done reports whether the job has completed.
 // After Done returns true, the Err method will return an error if the job completed unsuccessfully.
 func (s *JobStatus) Done() bool {
@@ -16,7 +16,6 @@ package bigquery
 
 import (
 	"context"
-	"io"
 	"time"
 
 	"cloud.google.com/go/internal/trace"
@@ -103,7 +102,7 @@ type LoadConfig struct {
 	ConnectionProperties []*ConnectionProperty
 }
 
-func (l *LoadConfig) toBQ() (*bq.JobConfiguration, io.Reader) {
+func (l *LoadConfig) toBQ() (*bq.JobConfiguration, *mediaUpload) {
 	config := &bq.JobConfiguration{
 		Labels: l.Labels,
 		Load: &bq.JobConfigurationLoad{
@@ -187,7 +186,7 @@ type Loader struct {
 // objects, and ReaderSource, for data read from an io.Reader.
 type LoadSource interface {
 	// populates config, returns media
-	populateLoadConfig(*bq.JobConfigurationLoad) io.Reader
+	populateLoadConfig(*bq.JobConfigurationLoad) *mediaUpload
 }
 
 // LoaderFrom returns a Loader which can be used to load data into a BigQuery table.
@@ -210,10 +209,10 @@ func (l *Loader) Run(ctx context.Context) (j *Job, err error) {
 	defer func() { trace.EndSpan(ctx, err) }()
 
 	job, media := l.newJob()
-	return l.c.insertJob(ctx, job, media)
+	return l.c.insertJob(ctx, job, media, &l.JobIDConfig)
 }
 
-func (l *Loader) newJob() (*bq.Job, io.Reader) {
+func (l *Loader) newJob() (*bq.Job, *mediaUpload) {
 	config, media := l.LoadConfig.toBQ()
 	return &bq.Job{
 		JobReference:  l.JobIDConfig.createJobRef(l.c),
@@ -16,6 +16,7 @@ package bigquery
 
 import (
 	"encoding/base64"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"math/big"
@@ -90,6 +91,7 @@ var (
 	typeOfRat                 = reflect.TypeOf(&big.Rat{})
 	typeOfIntervalValue       = reflect.TypeOf(&IntervalValue{})
 	typeOfQueryParameterValue = reflect.TypeOf(&QueryParameterValue{})
+	typeOfJSONRawMessage      = reflect.TypeOf(json.RawMessage{})
 )
 
 // A QueryParameter is a parameter to a query.
@@ -112,8 +114,14 @@ type QueryParameter struct {
 	// time.Time: TIMESTAMP
 	// *big.Rat: NUMERIC
 	// *IntervalValue: INTERVAL
+	// json.RawMessage, NullJSON, maps with string keys: JSON
 	// Arrays and slices of the above.
 	// Structs of the above. Only the exported fields are used.
+	// Arrays and slices of structs, which may themselves contain arrays.
+	//
+	// BigQuery does not support arrays of arrays; wrap the inner array in a
+	// struct instead. JSON values must be valid JSON; maps are encoded with
+	// encoding/json. A nil json.RawMessage or map is sent as NULL.
 	//
 	// For scalar values, you can supply the Null types within this library
 	// to send the appropriate NULL values (e.g. NullInt64, NullString, etc).
@@ -336,9 +344,12 @@ func paramType(t reflect.Type, v reflect.Value) (*bq.QueryParameterType, error)
 		return stringParamType, nil
 	case typeOfNullGeography:
 		return geographyParamType, nil
-	case typeOfNullJSON:
+	case typeOfNullJSON, typeOfJSONRawMessage:
 		return jsonParamType, nil
 	case typeOfQueryParameterValue:
+		if !v.IsValid() || v.IsNil() {
+			return nil, errors.New("bigquery: cannot infer the type of a nil *QueryParameterValue, or of an empty array of them")
+		}
 		return v.Interface().(*QueryParameterValue).toBQParamType(), nil
 	}
 	switch t.Kind() {
@@ -354,6 +365,11 @@ func paramType(t reflect.Type, v reflect.Value) (*bq.QueryParameterType, error)
 	case reflect.String:
 		return stringParamType, nil
 
+	case reflect.Map:
+		if t.Key().Kind() == reflect.String {
+			return jsonParamType, nil
+		}
+
 	case reflect.Slice:
 		if t.Elem().Kind() == reflect.Uint8 {
 			return bytesParamType, nil
@@ -361,7 +377,15 @@ func paramType(t reflect.Type, v reflect.Value) (*bq.QueryParameterType, error)
 		fallthrough
 
 	case reflect.Array:
-		et, err := paramType(t.Elem(), v)
+		if isParamArrayType(t.Elem()) {
+			return nil, fmt.Errorf("bigquery: Go type %s cannot be represented as a parameter type: arrays of arrays are not supported, wrap the inner array in a struct", t)
+		}
+		// The element type of a *QueryParameterValue is taken from the first element.
+		var ev reflect.Value
+		if v.IsValid() && v.Len() > 0 {
+			ev = v.Index(0)
+		}
+		et, err := paramType(t.Elem(), ev)
 		if err != nil {
 			return nil, err
 		}
@@ -372,6 +396,9 @@ func paramType(t reflect.Type, v reflect.Value) (*bq.QueryParameterType, error)
 			break
 		}
 		t = t.Elem()
+		if v.IsValid() {
+			v = v.Elem()
+		}
 		fallthrough
 
 	case reflect.Struct:
@@ -387,9 +414,13 @@ func paramType(t reflect.Type, v reflect.Value) (*bq.QueryParameterType, error)
 					return nil, fmt.Errorf("bigquery: Go type %s cannot be represented as a parameter due to an attribute cycle/recursion detected", t)
 				}
 			}
-			pt, err := paramType(f.Type, v)
+			var fv reflect.Value
+			if v.IsValid() {
+				fv = v.FieldByIndex(f.Index)
+			}
+			pt, err := paramType(f.Type, fv)
 			if err != nil {
-				return nil, err
+				return nil, structFieldParamError(f.Name, err)
 			}
 			fts = append(fts, &bq.QueryParameterTypeStructTypes{
 				Name: f.Name,
@@ -401,6 +432,23 @@ func paramType(t reflect.Type, v reflect.Value) (*bq.QueryParameterType, error)
 	return nil, fmt.Errorf("bigquery: Go type %s cannot be represented as a parameter type", t)
 }
 
+// isParamArrayType reports whether t is sent as an ARRAY parameter.
+func isParamArrayType(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Slice:
+		return t != typeOfJSONRawMessage && t.Elem().Kind() != reflect.Uint8
+	case reflect.Array:
+		return true
+	}
+	return false
+}
+
+// structFieldParamError annotates an error converting the struct field name,
+// so that errors in nested parameters report the path to the failing field.
+func structFieldParamError(name string, err error) error {
+	return fmt.Errorf("bigquery: struct field %s: %s", name, strings.TrimPrefix(err.Error(), "bigquery: "))
+}
+
 func paramValue(v reflect.Value) (*bq.QueryParameterValue, error) {
 	res := &bq.QueryParameterValue{}
 	if !v.IsValid() {
@@ -439,6 +487,9 @@ func paramValue(v reflect.Value) (*bq.QueryParameterValue, error) {
 			res.Value = fmt.Sprint(v.FieldByName("GeographyVal").Interface())
 		case typeOfNullJSON:
 			res.Value = fmt.Sprint(v.FieldByName("JSONVal").Interface())
+			if !json.Valid([]byte(res.Value)) {
+				return nil, fmt.Errorf("bigquery: NullJSON parameter holds invalid JSON %q", res.Value)
+			}
 		case typeOfNullFloat64:
 			res.Value = fmt.Sprint(v.FieldByName("Float64").Interface())
 		case typeOfNullBool:
@@ -486,10 +537,34 @@ func paramValue(v reflect.Value) (*bq.QueryParameterValue, error) {
 	case typeOfIntervalValue:
 		res.Value = IntervalString(v.Interface().(*IntervalValue))
 		return res, nil
+	case typeOfJSONRawMessage:
+		raw := v.Interface().(json.RawMessage)
+		if raw == nil {
+			res.NullFields = append(res.NullFields, "Value")
+			return res, nil
+		}
+		if !json.Valid(raw) {
+			return nil, fmt.Errorf("bigquery: json.RawMessage parameter holds invalid JSON %q", raw)
+		}
+		res.Value = string(raw)
+		return res, nil
 	case typeOfQueryParameterValue:
 		return v.Interface().(*QueryParameterValue).toBQParamValue()
 	}
 	switch t.Kind() {
+	case reflect.Map:
+		// Maps are sent as JSON.
+		if v.IsNil() {
+			res.NullFields = append(res.NullFields, "Value")
+			return res, nil
+		}
+		b, err := json.Marshal(v.Interface())
+		if err != nil {
+			return nil, fmt.Errorf("bigquery: encoding map parameter as JSON: %v", err)
+		}
+		res.Value = string(b)
+		return res, nil
+
 	case reflect.Slice:
 		if t.Elem().Kind() == reflect.Uint8 {
 			res.Value = base64.StdEncoding.EncodeToString(v.Interface().([]byte))
@@ -530,7 +605,7 @@ func paramValue(v reflect.Value) (*bq.QueryParameterValue, error) {
 			fv := v.FieldByIndex(f.Index)
 			fp, err := paramValue(fv)
 			if err != nil {
-				return nil, err
+				return nil, structFieldParamError(f.Name, err)
 			}
 			res.StructValues[f.Name] = *fp
 		}
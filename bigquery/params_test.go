@@ -16,10 +16,12 @@ package bigquery
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"math"
 	"math/big"
 	"reflect"
+	"strings"
 	"testing"
 	"time"
 
@@ -242,6 +244,23 @@ func TestParamValueStruct(t *testing.T) {
 	}
 }
 
+func TestParamValueMap(t *testing.T) {
+	got, err := paramValue(reflect.ValueOf(map[string]interface{}{"a": []int{1, 2}}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `{"a":[1,2]}`; got.Value != want {
+		t.Errorf("got %q, want %q", got.Value, want)
+	}
+	got, err = paramValue(reflect.ValueOf(map[string]int(nil)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := (&bq.QueryParameterValue{NullFields: []string{"Value"}}); !testutil.Equal(got, want) {
+		t.Errorf("nil map: got %+v, want %+v", got, want)
+	}
+}
+
 func TestParamValueErrors(t *testing.T) {
 	// paramValue lets a few invalid types through, but paramType catches them.
 	// Since we never call one without the other that's fine.
@@ -272,6 +291,7 @@ func TestParamType(t *testing.T) {
 		{[]int{}, &bq.QueryParameterType{Type: "ARRAY", ArrayType: int64ParamType}},
 		{[3]bool{}, &bq.QueryParameterType{Type: "ARRAY", ArrayType: boolParamType}},
 		{S1{}, s1ParamType},
+		{map[string]interface{}{}, jsonParamType},
 	} {
 		got, err := paramType(reflect.TypeOf(test.val), reflect.ValueOf(test.val))
 		if err != nil {
@@ -284,7 +304,7 @@ func TestParamType(t *testing.T) {
 }
 func TestParamTypeErrors(t *testing.T) {
 	for _, val := range []interface{}{
-		nil, uint(0), new([]int), make(chan int), map[string]interface{}{},
+		nil, uint(0), new([]int), make(chan int), map[int]interface{}{},
 	} {
 		_, err := paramType(reflect.TypeOf(val), reflect.ValueOf(val))
 		if err == nil {
@@ -296,7 +316,7 @@ func TestParamTypeErrors(t *testing.T) {
 		RecArr []recArr
 	}
 	type recMap struct {
-		RecMap map[string]recMap
+		RecMap map[int]recMap
 	}
 	queryParam := QueryParameterValue{
 		StructValue: map[string]QueryParameterValue{
@@ -317,7 +337,7 @@ func TestParamTypeErrors(t *testing.T) {
 		RecArr: []recArr{},
 	}
 	recursiveMap := recMap{
-		RecMap: map[string]recMap{},
+		RecMap: map[int]recMap{},
 	}
 	// Recursive structs
 	for _, val := range []interface{}{
@@ -330,6 +350,110 @@ func TestParamTypeErrors(t *testing.T) {
 	}
 }
 
+func TestParamArrayOfStructs(t *testing.T) {
+	type item struct {
+		Name string
+		Tags []string
+	}
+	type order struct {
+		ID    int
+		Items []*item
+		Meta  json.RawMessage
+	}
+	val := []order{
+		{ID: 1, Items: []*item{{Name: "a", Tags: []string{"x"}}}, Meta: json.RawMessage(`{"k":1}`)},
+		{ID: 2},
+	}
+	gotType, err := paramType(reflect.TypeOf(val), reflect.ValueOf(val))
+	if err != nil {
+		t.Fatal(err)
+	}
+	itemType := &bq.QueryParameterType{Type: "STRUCT", StructTypes: []*bq.QueryParameterTypeStructTypes{
+		{Name: "Name", Type: stringParamType},
+		{Name: "Tags", Type: &bq.QueryParameterType{Type: "ARRAY", ArrayType: stringParamType}},
+	}}
+	wantType := &bq.QueryParameterType{Type: "ARRAY", ArrayType: &bq.QueryParameterType{Type: "STRUCT", StructTypes: []*bq.QueryParameterTypeStructTypes{
+		{Name: "ID", Type: int64ParamType},
+		{Name: "Items", Type: &bq.QueryParameterType{Type: "ARRAY", ArrayType: itemType}},
+		{Name: "Meta", Type: jsonParamType},
+	}}}
+	if diff := testutil.Diff(gotType, wantType); diff != "" {
+		t.Errorf("type: -got, +want:\n%s", diff)
+	}
+
+	gotVal, err := paramValue(reflect.ValueOf(val))
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantVal := &bq.QueryParameterValue{ArrayValues: []*bq.QueryParameterValue{
+		{StructValues: map[string]bq.QueryParameterValue{
+			"ID": {Value: "1"},
+			"Items": {ArrayValues: []*bq.QueryParameterValue{
+				{StructValues: map[string]bq.QueryParameterValue{
+					"Name": {Value: "a"},
+					"Tags": {ArrayValues: []*bq.QueryParameterValue{{Value: "x"}}},
+				}},
+			}},
+			"Meta": {Value: `{"k":1}`},
+		}},
+		{StructValues: map[string]bq.QueryParameterValue{
+			"ID":    {Value: "2"},
+			"Items": {},
+			"Meta":  {NullFields: []string{"Value"}},
+		}},
+	}}
+	if diff := testutil.Diff(gotVal, wantVal); diff != "" {
+		t.Errorf("value: -got, +want:\n%s", diff)
+	}
+}
+
+func TestParamNestedQueryParameterValue(t *testing.T) {
+	type s struct {
+		N *QueryParameterValue
+	}
+	val := []s{{N: &QueryParameterValue{Type: StandardSQLDataType{TypeKind: "BIGNUMERIC"}, Value: "1.5"}}}
+	got, err := paramType(reflect.TypeOf(val), reflect.ValueOf(val))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := &bq.QueryParameterType{Type: "ARRAY", ArrayType: &bq.QueryParameterType{Type: "STRUCT", StructTypes: []*bq.QueryParameterTypeStructTypes{
+		{Name: "N", Type: bigNumericParamType},
+	}}}
+	if diff := testutil.Diff(got, want); diff != "" {
+		t.Errorf("-got, +want:\n%s", diff)
+	}
+	if _, err := paramType(reflect.TypeOf([]s{}), reflect.ValueOf([]s{})); err == nil {
+		t.Error("empty array of *QueryParameterValue: got nil error, want error")
+	}
+}
+
+func TestComplexParamErrors(t *testing.T) {
+	type bad struct {
+		Inner struct {
+			U uint
+		}
+	}
+	for _, test := range []struct {
+		desc    string
+		val     interface{}
+		wantErr string
+	}{
+		{"nested array", [][]int{{1}}, "arrays of arrays are not supported"},
+		{"nested field", bad{}, "struct field Inner: struct field U: Go type uint"},
+		{"invalid JSON", json.RawMessage(`{`), "invalid JSON"},
+		{"invalid NullJSON", NullJSON{JSONVal: "{", Valid: true}, "invalid JSON"},
+	} {
+		_, err := QueryParameter{Value: test.val}.toBQ()
+		if err == nil {
+			t.Errorf("%s: got nil error, want error", test.desc)
+			continue
+		}
+		if !strings.Contains(err.Error(), test.wantErr) {
+			t.Errorf("%s: got error %q, want it to contain %q", test.desc, err, test.wantErr)
+		}
+	}
+}
+
 func TestConvertParamValue(t *testing.T) {
 	// Scalars.
 	for _, test := range scalarTests {
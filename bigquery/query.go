@@ -124,7 +124,8 @@ type QueryConfig struct {
 	//
 	// Query.Read will fail with dry-run queries. Call Query.Run instead, and then
 	// call LastStatus on the returned job to get statistics. Calling Status on a
-	// dry-run job will fail.
+	// dry-run job will fail. Query.Estimate returns the most useful statistics
+	// directly.
 	DryRun bool
 
 	// Custom encryption configuration (e.g., Cloud KMS keys).
@@ -135,9 +136,11 @@ type QueryConfig struct {
 	SchemaUpdateOptions []string
 
 	// CreateSession will trigger creation of a new session when true.
+	// Client.CreateSession and Session.Query provide a higher level API.
 	CreateSession bool
 
 	// ConnectionProperties are optional key-values settings.
+	// Use the SessionIDProperty key to run the query in an existing session.
 	ConnectionProperties []*ConnectionProperty
 
 	// Sets a best-effort deadline on a specific job.  If job execution exceeds this
@@ -348,7 +351,7 @@ func (q *Query) Run(ctx context.Context) (j *Job, err error) {
 	if err != nil {
 		return nil, err
 	}
-	j, err = q.client.insertJob(ctx, job, nil)
+	j, err = q.client.insertJob(ctx, job, nil, &q.JobIDConfig)
 	if err != nil {
 		return nil, err
 	}
@@ -387,7 +390,7 @@ func (q *Query) Read(ctx context.Context) (it *RowIterator, err error) {
 	}
 
 	// we have a config, run on fastPath.
-	resp, err := q.client.runQuery(ctx, queryRequest)
+	resp, err := q.client.runQuery(ctx, queryRequest, q.RetryPolicy)
 	if err != nil {
 		return nil, err
 	}
@@ -430,6 +433,73 @@ func (q *Query) Read(ctx context.Context) (it *RowIterator, err error) {
 	return minimalJob.Read(ctx)
 }
 
+// DryRunResult describes a query that was validated, but not run, by
+// Query.Estimate.
+type DryRunResult struct {
+	// TotalBytesProcessed is the estimated number of bytes the query would
+	// process.
+	TotalBytesProcessed int64
+
+	// TotalBytesProcessedAccuracy indicates how accurate the estimate is. See
+	// QueryStatistics.TotalBytesProcessedAccuracy for the values.
+	TotalBytesProcessedAccuracy string
+
+	// StatementType is the type of the query statement.
+	StatementType string
+
+	// ReferencedTables are the tables the query reads. Queries that reference
+	// more than 50 tables will not have a complete list.
+	ReferencedTables []*Table
+
+	// Schema is the schema of the query results. It is only present for
+	// GoogleSQL queries.
+	Schema Schema
+
+	// UndeclaredQueryParameterNames lists the query parameters used in the
+	// query but missing from QueryConfig.Parameters.
+	UndeclaredQueryParameterNames []string
+}
+
+// Estimate validates the query with a dry run, and returns an estimate of the
+// bytes it would process along with the tables it references and the schema
+// of its results. An invalid query returns the same error as it would if it
+// were run.
+//
+// Estimate does not modify q; q.DryRun need not be set.
+func (q *Query) Estimate(ctx context.Context) (res *DryRunResult, err error) {
+	ctx = trace.StartSpan(ctx, "cloud.google.com/go/bigquery.Query.Estimate")
+	defer func() { trace.EndSpan(ctx, err) }()
+
+	dq := *q
+	dq.QueryConfig.DryRun = true
+	job, err := dq.Run(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return dryRunResultFromStatus(job.LastStatus())
+}
+
+func dryRunResultFromStatus(js *JobStatus) (*DryRunResult, error) {
+	if js == nil || js.Statistics == nil {
+		return nil, errors.New("bigquery: dry run returned no statistics")
+	}
+	if err := js.Err(); err != nil {
+		return nil, err
+	}
+	qs, ok := js.Statistics.Details.(*QueryStatistics)
+	if !ok {
+		return nil, errors.New("bigquery: dry run returned no query statistics")
+	}
+	return &DryRunResult{
+		TotalBytesProcessed:           qs.TotalBytesProcessed,
+		TotalBytesProcessedAccuracy:   qs.TotalBytesProcessedAccuracy,
+		StatementType:                 qs.StatementType,
+		ReferencedTables:              qs.ReferencedTables,
+		Schema:                        qs.Schema,
+		UndeclaredQueryParameterNames: qs.UndeclaredQueryParameterNames,
+	}, nil
+}
+
 // probeFastPath is used to attempt configuring a jobs.Query request based on a
 // user's Query configuration.  If all the options set on the job are supported on the
 // faster query path, this method returns a QueryRequest suitable for execution.
@@ -454,7 +524,8 @@ func (q *Query) probeFastPath() (*bq.QueryRequest, error) {
 		q.QueryConfig.SchemaUpdateOptions != nil ||
 		q.QueryConfig.JobTimeout != 0 ||
 		// User has defined the jobID generation behavior
-		q.JobIDConfig.JobID != "" {
+		q.JobIDConfig.JobID != "" ||
+		q.JobIDConfig.DeterministicJobID {
 		return nil, fmt.Errorf("QueryConfig incompatible with fastPath")
 	}
 	pfalse := false
@@ -470,6 +541,9 @@ func (q *Query) probeFastPath() (*bq.QueryRequest, error) {
 	if q.QueryConfig.DisableQueryCache {
 		qRequest.UseQueryCache = &pfalse
 	}
+	for _, cp := range q.QueryConfig.ConnectionProperties {
+		qRequest.ConnectionProperties = append(qRequest.ConnectionProperties, cp.toBQ())
+	}
 	// Convert query parameters
 	for _, p := range q.QueryConfig.Parameters {
 		qp, err := p.toBQ()
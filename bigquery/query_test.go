@@ -585,3 +585,44 @@ func TestQueryLegacySQL(t *testing.T) {
 		t.Error("Parameters and UseLegacySQL: got nil, want error")
 	}
 }
+
+func TestDryRunResultFromStatus(t *testing.T) {
+	c := &Client{projectID: "client-project-id"}
+	job, err := bqToJob(&bq.Job{
+		JobReference: &bq.JobReference{ProjectId: "client-project-id", JobId: "dry"},
+		Status:       &bq.JobStatus{State: "DONE"},
+		Statistics: &bq.JobStatistics{
+			TotalBytesProcessed: 1024,
+			Query: &bq.JobStatistics2{
+				TotalBytesProcessed:         1024,
+				TotalBytesProcessedAccuracy: "PRECISE",
+				StatementType:               "SELECT",
+				ReferencedTables:            []*bq.TableReference{{ProjectId: "p", DatasetId: "d", TableId: "t"}},
+				Schema:                      &bq.TableSchema{Fields: []*bq.TableFieldSchema{{Name: "n", Type: "INTEGER"}}},
+				UndeclaredQueryParameters:   []*bq.QueryParameter{{Name: "x"}},
+			},
+		},
+	}, c)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := dryRunResultFromStatus(job.LastStatus())
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := &DryRunResult{
+		TotalBytesProcessed:           1024,
+		TotalBytesProcessedAccuracy:   "PRECISE",
+		StatementType:                 "SELECT",
+		ReferencedTables:              []*Table{{ProjectID: "p", DatasetID: "d", TableID: "t"}},
+		Schema:                        Schema{{Name: "n", Type: IntegerFieldType}},
+		UndeclaredQueryParameterNames: []string{"x"},
+	}
+	if diff := testutil.Diff(got, want, cmpopts.IgnoreUnexported(Table{})); diff != "" {
+		t.Errorf("-got +want:\n%s", diff)
+	}
+
+	if _, err := dryRunResultFromStatus(&JobStatus{State: Done}); err == nil {
+		t.Error("no statistics: got nil error, want error")
+	}
+}
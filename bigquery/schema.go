@@ -340,6 +340,10 @@ var typeOfByteSlice = reflect.TypeOf([]byte{})
 // Due to lack of unique native Go type for GEOGRAPHY, there is no schema
 // inference to GEOGRAPHY at this time.
 //
+// Fields of type json.RawMessage or map with string keys are inferred to be
+// nullable JSON fields; values of these types are encoded with encoding/json
+// when uploaded.
+//
 // Nullable fields are inferred from the NullXXX types, declared in this package:
 //
 //	STRING      NullString
@@ -351,6 +355,7 @@ var typeOfByteSlice = reflect.TypeOf([]byte{})
 //	TIME        NullTime
 //	DATETIME    NullDateTime
 //	GEOGRAPHY   NullGeography
+//	JSON        NullJSON
 //
 // For a nullable BYTES field, use the type []byte and tag the field "nullable" (see below).
 // For a nullable NUMERIC field, use the type *big.Rat and tag the field "nullable".
@@ -361,8 +366,9 @@ var typeOfByteSlice = reflect.TypeOf([]byte{})
 // field, use the "nullable" tag (see below).
 //
 // InferSchema returns an error if any of the examined fields is of type uint,
-// uint64, uintptr, map, interface, complex64, complex128, func, or chan. Future
-// versions may handle these cases without error.
+// uint64, uintptr, interface, complex64, complex128, func, or chan, or is a map
+// whose keys are not strings. Future versions may handle these cases without
+// error.
 //
 // Recursively defined structs are also disallowed.
 //
@@ -435,8 +441,8 @@ func inferStruct(t reflect.Type) (Schema, error) {
 
 // inferFieldSchema infers the FieldSchema for a Go type
 func inferFieldSchema(fieldName string, rt reflect.Type, nullable bool) (*FieldSchema, error) {
-	// Only []byte and struct pointers can be tagged nullable.
-	if nullable && !(rt == typeOfByteSlice || rt.Kind() == reflect.Ptr && rt.Elem().Kind() == reflect.Struct) {
+	// Only []byte, JSON types and struct pointers can be tagged nullable.
+	if nullable && !(rt == typeOfByteSlice || isJSONType(rt) || rt.Kind() == reflect.Ptr && rt.Elem().Kind() == reflect.Struct) {
 		return nil, badNullableError{fieldName, rt}
 	}
 	switch rt {
@@ -460,13 +466,17 @@ func inferFieldSchema(fieldName string, rt reflect.Type, nullable bool) (*FieldS
 	if ft := nullableFieldType(rt); ft != "" {
 		return &FieldSchema{Required: false, Type: ft}, nil
 	}
+	if isJSONType(rt) {
+		// A nil json.RawMessage or map is stored as NULL.
+		return &FieldSchema{Required: false, Type: JSONFieldType}, nil
+	}
 	if isSupportedIntType(rt) || isSupportedUintType(rt) {
 		return &FieldSchema{Required: true, Type: IntegerFieldType}, nil
 	}
 	switch rt.Kind() {
 	case reflect.Slice, reflect.Array:
 		et := rt.Elem()
-		if et != typeOfByteSlice && (et.Kind() == reflect.Slice || et.Kind() == reflect.Array) {
+		if et != typeOfByteSlice && et != typeOfJSONRawMessage && (et.Kind() == reflect.Slice || et.Kind() == reflect.Array) {
 			// Multi dimensional slices/arrays are not supported by BigQuery
 			return nil, unsupportedFieldTypeError{fieldName, rt}
 		}
@@ -528,6 +538,12 @@ func inferFields(rt reflect.Type) (Schema, error) {
 	return s, nil
 }
 
+// isJSONType reports whether t is stored in a JSON column: t is either
+// json.RawMessage or a map with string keys.
+func isJSONType(t reflect.Type) bool {
+	return t == typeOfJSONRawMessage || t.Kind() == reflect.Map && t.Key().Kind() == reflect.String
+}
+
 // isSupportedIntType reports whether t is an int type that can be properly
 // represented by the BigQuery INTEGER/INT64 type.
 func isSupportedIntType(t reflect.Type) bool {
@@ -769,6 +769,30 @@ func TestNullInference(t *testing.T) {
 	}
 }
 
+func TestJSONInference(t *testing.T) {
+	type jsonFields struct {
+		Raw      json.RawMessage
+		Map      map[string]interface{}
+		Nullable json.RawMessage `bigquery:",nullable"`
+		Raws     []json.RawMessage
+		Maps     []map[string]int
+	}
+	got, err := InferSchema(jsonFields{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := Schema{
+		optField("Raw", "JSON"),
+		optField("Map", "JSON"),
+		optField("Nullable", "JSON"),
+		{Name: "Raws", Type: JSONFieldType, Repeated: true},
+		{Name: "Maps", Type: JSONFieldType, Repeated: true},
+	}
+	if diff := testutil.Diff(got, want); diff != "" {
+		t.Error(diff)
+	}
+}
+
 type Embedded struct {
 	Embedded int
 }
@@ -988,7 +1012,7 @@ func TestSchemaErrors(t *testing.T) {
 			want: unsupportedFieldTypeError{},
 		},
 		{
-			in:   struct{ Map map[string]int }{},
+			in:   struct{ Map map[int]int }{},
 			want: unsupportedFieldTypeError{},
 		},
 		{
@@ -0,0 +1,153 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bigquery
+
+import (
+	"context"
+	"fmt"
+
+	"cloud.google.com/go/internal/trace"
+)
+
+// SessionIDProperty is the ConnectionProperty key that runs a query or load
+// job in an existing session.
+const SessionIDProperty = "session_id"
+
+// A Session runs queries in a BigQuery session. Temporary tables, variables
+// and transactions persist across the queries of a session.
+// See https://cloud.google.com/bigquery/docs/sessions-intro.
+//
+// Create a session with Client.CreateSession, or attach to an existing one
+// with Client.Session.
+type Session struct {
+	c *Client
+
+	// ID identifies the session.
+	ID string
+
+	// Location is the location of the session. Queries in the session run in
+	// this location.
+	Location string
+}
+
+// CreateSession starts a new session.
+func (c *Client) CreateSession(ctx context.Context) (s *Session, err error) {
+	ctx = trace.StartSpan(ctx, "cloud.google.com/go/bigquery.Client.CreateSession")
+	defer func() { trace.EndSpan(ctx, err) }()
+
+	q := c.Query("SELECT 1")
+	q.CreateSession = true
+	status, job, err := runAndWait(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+	if status.Statistics == nil || status.Statistics.SessionInfo == nil || status.Statistics.SessionInfo.SessionID == "" {
+		return nil, fmt.Errorf("bigquery: job %q did not create a session", job.ID())
+	}
+	return &Session{
+		c:        c,
+		ID:       status.Statistics.SessionInfo.SessionID,
+		Location: job.Location(),
+	}, nil
+}
+
+// Session returns a handle to the existing session with the given ID, in the
+// given location. The location may be empty to use the client's default.
+func (c *Client) Session(id, location string) *Session {
+	return &Session{c: c, ID: id, Location: location}
+}
+
+// Query creates a query with string q that runs in the session.
+// The returned Query may optionally be further configured before its Run or
+// Read methods are called, but its session connection property should be kept.
+func (s *Session) Query(q string) *Query {
+	query := s.c.Query(q)
+	query.Location = s.Location
+	query.ConnectionProperties = []*ConnectionProperty{
+		{Key: SessionIDProperty, Value: s.ID},
+	}
+	return query
+}
+
+// Exec runs the statements in q in the session and waits for them to complete.
+// It is intended for DDL, DML and scripts whose results are not read.
+func (s *Session) Exec(ctx context.Context, q string, params ...QueryParameter) (*JobStatus, error) {
+	query := s.Query(q)
+	query.Parameters = params
+	status, _, err := runAndWait(ctx, query)
+	return status, err
+}
+
+// Begin starts a multi-statement transaction in the session. Statements run
+// in the session are part of the transaction until Commit or Rollback is
+// called.
+func (s *Session) Begin(ctx context.Context) error {
+	_, err := s.Exec(ctx, "BEGIN TRANSACTION")
+	return err
+}
+
+// Commit commits the transaction started by Begin.
+func (s *Session) Commit(ctx context.Context) error {
+	_, err := s.Exec(ctx, "COMMIT TRANSACTION")
+	return err
+}
+
+// Rollback rolls back the transaction started by Begin.
+func (s *Session) Rollback(ctx context.Context) error {
+	_, err := s.Exec(ctx, "ROLLBACK TRANSACTION")
+	return err
+}
+
+// RunInTransaction runs f in a transaction in the session. If f returns nil,
+// the transaction is committed; otherwise it is rolled back and the error
+// from f is returned.
+func (s *Session) RunInTransaction(ctx context.Context, f func(ctx context.Context, s *Session) error) (err error) {
+	ctx = trace.StartSpan(ctx, "cloud.google.com/go/bigquery.Session.RunInTransaction")
+	defer func() { trace.EndSpan(ctx, err) }()
+
+	if err := s.Begin(ctx); err != nil {
+		return err
+	}
+	if err := f(ctx, s); err != nil {
+		if rerr := s.Rollback(ctx); rerr != nil {
+			return fmt.Errorf("bigquery: %v; rollback failed: %v", err, rerr)
+		}
+		return err
+	}
+	return s.Commit(ctx)
+}
+
+// Close terminates the session. Any open transaction is rolled back.
+func (s *Session) Close(ctx context.Context) error {
+	_, err := s.Exec(ctx, "CALL BQ.ABORT_SESSION()")
+	return err
+}
+
+// runAndWait runs q and waits for it to finish. It returns an error if the
+// job fails.
+func runAndWait(ctx context.Context, q *Query) (*JobStatus, *Job, error) {
+	job, err := q.Run(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	status, err := job.Wait(ctx)
+	if err != nil {
+		return nil, job, err
+	}
+	if err := status.Err(); err != nil {
+		return status, job, err
+	}
+	return status, job, nil
+}
@@ -0,0 +1,51 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bigquery
+
+import (
+	"testing"
+
+	"cloud.google.com/go/internal/testutil"
+	bq "google.golang.org/api/bigquery/v2"
+)
+
+func TestSessionQuery(t *testing.T) {
+	c := &Client{projectID: "project-id"}
+	s := c.Session("sess", "EU")
+	q := s.Query("SELECT * FROM temptable")
+	if q.Location != "EU" {
+		t.Errorf("got location %q, want EU", q.Location)
+	}
+
+	job, err := q.newJob()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []*bq.ConnectionProperty{{Key: "session_id", Value: "sess"}}
+	if diff := testutil.Diff(job.Configuration.Query.ConnectionProperties, want); diff != "" {
+		t.Errorf("jobs.insert: -got +want:\n%s", diff)
+	}
+
+	req, err := q.probeFastPath()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := testutil.Diff(req.ConnectionProperties, want); diff != "" {
+		t.Errorf("jobs.query: -got +want:\n%s", diff)
+	}
+	if req.Location != "EU" {
+		t.Errorf("jobs.query: got location %q, want EU", req.Location)
+	}
+}
@@ -168,6 +168,24 @@ have been finalized, meaning they'll no longer allow further data writes.
 	// table atomically.
 	resp, err := client.BatchCommitWriteStreams(ctx, req)
 
+# Writing Go Structs
+
+StructWriter handles the details above for rows described by Go structs, in the
+same way as the Inserter in cloud.google.com/go/bigquery. It derives the protocol
+buffer schema from the struct type, tracks offsets, and enables retries:
+
+	writer, err := client.NewStructWriter(ctx, tableName, MyRow{}, managedwriter.WithType(managedwriter.PendingStream))
+	if err != nil {
+		// TODO: Handle error.
+	}
+	if _, err := writer.Append(ctx, []*MyRow{row1, row2}); err != nil {
+		// TODO: Handle error.
+	}
+	// Wait for the appends, then finalize and commit the pending stream.
+	if _, err := writer.Finalize(ctx); err != nil {
+		// TODO: Handle error.
+	}
+
 # Error Handling and Automatic Retries
 
 Like other Google Cloud services, this API relies on common components that can provide an
@@ -0,0 +1,437 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package managedwriter
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"reflect"
+	"sync"
+	"time"
+
+	"cloud.google.com/go/bigquery"
+	"cloud.google.com/go/bigquery/storage/apiv1/storagepb"
+	"cloud.google.com/go/bigquery/storage/managedwriter/adapt"
+	"cloud.google.com/go/civil"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+// StructWriter appends Go values to a table using a ManagedStream. Unlike
+// ManagedStream, it does not require a hand-maintained protocol buffer
+// message: the message descriptor is derived from a BigQuery schema, and rows
+// are converted with the same rules as bigquery.Inserter.
+//
+// Rows may be structs, pointers to structs, or implementations of
+// bigquery.ValueSaver, or slices of these. Struct fields are matched to
+// columns as described in bigquery.InferSchema.
+//
+// A StructWriter tracks the offsets of the rows it appends to explicitly
+// created streams, and enables write retries, so each row is written at most
+// once. It is safe for concurrent use, though appends are sent one at a time
+// to preserve their order.
+type StructWriter struct {
+	ms     *ManagedStream
+	schema bigquery.Schema
+	md     protoreflect.MessageDescriptor
+
+	mu      sync.Mutex
+	offset  int64           // offset of the next appended row, for explicit streams
+	pending []*AppendResult // results of appends not yet observed by Flush
+}
+
+// NewStructWriter returns a StructWriter that appends rows to destTable,
+// using the schema inferred from st with bigquery.InferSchema. The table
+// must be of the form projects/{project}/datasets/{dataset}/tables/{table},
+// as returned by TableParentFromParts.
+//
+// By default rows are appended to the table's default stream. Use
+// WithType to write to an explicitly created stream instead.
+func (c *Client) NewStructWriter(ctx context.Context, destTable string, st interface{}, opts ...WriterOption) (*StructWriter, error) {
+	schema, err := bigquery.InferSchema(st)
+	if err != nil {
+		return nil, err
+	}
+	return c.NewStructWriterWithSchema(ctx, destTable, schema, opts...)
+}
+
+// NewStructWriterWithSchema is like NewStructWriter, but uses the given schema
+// instead of inferring it. The schema may describe a subset of the table's
+// columns.
+func (c *Client) NewStructWriterWithSchema(ctx context.Context, destTable string, schema bigquery.Schema, opts ...WriterOption) (*StructWriter, error) {
+	md, dp, err := structWriterDescriptor(schema)
+	if err != nil {
+		return nil, err
+	}
+	allOpts := []WriterOption{WithDestinationTable(destTable), EnableWriteRetries(true)}
+	allOpts = append(allOpts, opts...)
+	allOpts = append(allOpts, WithSchemaDescriptor(dp))
+	ms, err := c.NewManagedStream(ctx, allOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return newStructWriter(ms, schema, md), nil
+}
+
+func newStructWriter(ms *ManagedStream, schema bigquery.Schema, md protoreflect.MessageDescriptor) *StructWriter {
+	return &StructWriter{ms: ms, schema: schema, md: md}
+}
+
+// Stream returns the ManagedStream the writer appends to.
+func (w *StructWriter) Stream() *ManagedStream {
+	return w.ms
+}
+
+// Append converts rows and appends them to the stream. The returned
+// AppendResult reports the outcome of the append; the same outcome is also
+// reported by the next call to Flush.
+func (w *StructWriter) Append(ctx context.Context, rows interface{}) (*AppendResult, error) {
+	maps, err := w.rowMaps(rows)
+	if err != nil {
+		return nil, err
+	}
+	data := make([][]byte, len(maps))
+	for i, row := range maps {
+		msg, err := encodeRow(w.md, w.schema, row)
+		if err != nil {
+			return nil, fmt.Errorf("row %d: %w", i, err)
+		}
+		if data[i], err = proto.Marshal(msg); err != nil {
+			return nil, fmt.Errorf("row %d: %w", i, err)
+		}
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	var opts []AppendOption
+	if w.ms.StreamType() != DefaultStream {
+		opts = append(opts, WithOffset(w.offset))
+	}
+	res, err := w.ms.AppendRows(ctx, data, opts...)
+	if err != nil {
+		return nil, err
+	}
+	w.offset += int64(len(data))
+	w.pending = append(w.pending, res)
+	return res, nil
+}
+
+// Flush waits for all appends made since the previous call to Flush to
+// complete, and returns the first error among them. For a BufferedStream, it
+// then makes all the appended rows visible.
+func (w *StructWriter) Flush(ctx context.Context) error {
+	w.mu.Lock()
+	pending := w.pending
+	w.pending = nil
+	offset := w.offset
+	w.mu.Unlock()
+
+	var firstErr error
+	for _, res := range pending {
+		if _, err := res.GetResult(ctx); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	if firstErr != nil {
+		return firstErr
+	}
+	if w.ms.StreamType() == BufferedStream && offset > 0 {
+		if _, err := w.ms.FlushRows(ctx, offset-1); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Finalize flushes the writer, then finalizes its stream so no more rows can
+// be appended, and returns the number of rows in the stream. For a
+// PendingStream, Finalize also commits the stream, making its rows visible.
+//
+// The default stream cannot be finalized; call Flush and Close instead.
+func (w *StructWriter) Finalize(ctx context.Context) (int64, error) {
+	if w.ms.StreamType() == DefaultStream {
+		return 0, fmt.Errorf("cannot finalize the default stream")
+	}
+	if err := w.Flush(ctx); err != nil {
+		return 0, err
+	}
+	rowCount, err := w.ms.Finalize(ctx)
+	if err != nil {
+		return 0, err
+	}
+	if w.ms.StreamType() != PendingStream {
+		return rowCount, nil
+	}
+	name := w.ms.StreamName()
+	resp, err := w.ms.c.BatchCommitWriteStreams(ctx, &storagepb.BatchCommitWriteStreamsRequest{
+		Parent:       TableParentFromStreamName(name),
+		WriteStreams: []string{name},
+	})
+	if err != nil {
+		return 0, err
+	}
+	if errs := resp.GetStreamErrors(); len(errs) > 0 {
+		return 0, fmt.Errorf("committing stream %q: %s", name, errs[0].GetErrorMessage())
+	}
+	return rowCount, nil
+}
+
+// Close closes the underlying ManagedStream. Appends that have not been
+// flushed may not have completed.
+func (w *StructWriter) Close() error {
+	return w.ms.Close()
+}
+
+// rowMaps converts the argument of Append into rows keyed by column name.
+func (w *StructWriter) rowMaps(rows interface{}) ([]map[string]bigquery.Value, error) {
+	if row, ok, err := w.rowMap(rows); ok || err != nil {
+		if err != nil {
+			return nil, err
+		}
+		return []map[string]bigquery.Value{row}, nil
+	}
+	v := reflect.ValueOf(rows)
+	if v.Kind() != reflect.Slice {
+		return nil, fmt.Errorf("%T is not a ValueSaver, struct, struct pointer, or slice", rows)
+	}
+	out := make([]map[string]bigquery.Value, 0, v.Len())
+	for i := 0; i < v.Len(); i++ {
+		x := v.Index(i).Interface()
+		row, ok, err := w.rowMap(x)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			return nil, fmt.Errorf("rows[%d] has type %T, which is not a ValueSaver, struct or struct pointer", i, x)
+		}
+		out = append(out, row)
+	}
+	return out, nil
+}
+
+// rowMap converts x to a row if it is a ValueSaver, struct or struct pointer.
+func (w *StructWriter) rowMap(x interface{}) (map[string]bigquery.Value, bool, error) {
+	if ss, ok := x.(*bigquery.StructSaver); ok && ss.Schema == nil {
+		x = ss.Struct
+	}
+	saver, ok := x.(bigquery.ValueSaver)
+	if !ok {
+		v := reflect.ValueOf(x)
+		if v.Kind() == reflect.Ptr {
+			v = v.Elem()
+		}
+		if v.Kind() != reflect.Struct {
+			return nil, false, nil
+		}
+		saver = &bigquery.StructSaver{Schema: w.schema, Struct: x}
+	}
+	row, _, err := saver.Save()
+	return row, true, err
+}
+
+// structWriterDescriptor derives the message used to encode rows for the given
+// schema. TIME, DATETIME, NUMERIC and BIGNUMERIC columns are encoded as
+// strings, which the backend accepts for those types, so values can be written
+// in the canonical formats produced by the bigquery package.
+func structWriterDescriptor(schema bigquery.Schema) (protoreflect.MessageDescriptor, *descriptorpb.DescriptorProto, error) {
+	ts, err := adapt.BQSchemaToStorageTableSchema(schema)
+	if err != nil {
+		return nil, nil, err
+	}
+	useStringEncoding(ts.GetFields())
+	d, err := adapt.StorageSchemaToProto2Descriptor(ts, "root")
+	if err != nil {
+		return nil, nil, err
+	}
+	md, ok := d.(protoreflect.MessageDescriptor)
+	if !ok {
+		return nil, nil, fmt.Errorf("adapted descriptor is not a message descriptor")
+	}
+	dp, err := adapt.NormalizeDescriptor(md)
+	if err != nil {
+		return nil, nil, err
+	}
+	return md, dp, nil
+}
+
+func useStringEncoding(fields []*storagepb.TableFieldSchema) {
+	for _, f := range fields {
+		switch f.GetType() {
+		case storagepb.TableFieldSchema_TIME, storagepb.TableFieldSchema_DATETIME,
+			storagepb.TableFieldSchema_NUMERIC, storagepb.TableFieldSchema_BIGNUMERIC:
+			f.Type = storagepb.TableFieldSchema_STRING
+		case storagepb.TableFieldSchema_STRUCT:
+			useStringEncoding(f.GetFields())
+		}
+	}
+}
+
+// encodeRow builds a message of type md from a row. The fields of md are
+// numbered in schema order, starting at 1.
+func encodeRow(md protoreflect.MessageDescriptor, schema bigquery.Schema, row map[string]bigquery.Value) (*dynamicpb.Message, error) {
+	msg := dynamicpb.NewMessage(md)
+	for i, fs := range schema {
+		fd := md.Fields().ByNumber(protoreflect.FieldNumber(i + 1))
+		if fd == nil {
+			return nil, fmt.Errorf("no field for column %q", fs.Name)
+		}
+		v := row[fs.Name]
+		if v == nil {
+			if fs.Required {
+				return nil, fmt.Errorf("required column %q is missing", fs.Name)
+			}
+			continue
+		}
+		if !fs.Repeated {
+			pv, ok, err := encodeValue(fd, fs, v)
+			if err != nil {
+				return nil, err
+			}
+			if ok {
+				msg.Set(fd, pv)
+			} else if fs.Required {
+				return nil, fmt.Errorf("required column %q is null", fs.Name)
+			}
+			continue
+		}
+		rv := reflect.ValueOf(v)
+		if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+			return nil, fmt.Errorf("repeated column %q requires a slice or array, but value has type %T", fs.Name, v)
+		}
+		list := msg.Mutable(fd).List()
+		for j := 0; j < rv.Len(); j++ {
+			pv, ok, err := encodeValue(fd, fs, rv.Index(j).Interface())
+			if err != nil {
+				return nil, err
+			}
+			if !ok {
+				return nil, fmt.Errorf("repeated column %q has a null element at index %d", fs.Name, j)
+			}
+			list.Append(pv)
+		}
+	}
+	return msg, nil
+}
+
+var unixEpochDate = civil.Date{Year: 1970, Month: time.January, Day: 1}
+
+// encodeValue converts a single (non-repeated) value of the column fs. It
+// returns false if the value is null.
+func encodeValue(fd protoreflect.FieldDescriptor, fs *bigquery.FieldSchema, v bigquery.Value) (protoreflect.Value, bool, error) {
+	badType := func() (protoreflect.Value, bool, error) {
+		return protoreflect.Value{}, false, fmt.Errorf("cannot write value of type %T to column %q of type %s", v, fs.Name, fs.Type)
+	}
+	switch fs.Type {
+	case bigquery.StringFieldType, bigquery.GeographyFieldType:
+		switch x := v.(type) {
+		case string:
+			return protoreflect.ValueOfString(x), true, nil
+		case bigquery.NullString:
+			return protoreflect.ValueOfString(x.StringVal), x.Valid, nil
+		case bigquery.NullGeography:
+			return protoreflect.ValueOfString(x.GeographyVal), x.Valid, nil
+		}
+	case bigquery.BytesFieldType:
+		if x, ok := v.([]byte); ok {
+			return protoreflect.ValueOfBytes(x), x != nil, nil
+		}
+	case bigquery.IntegerFieldType:
+		if x, ok := v.(bigquery.NullInt64); ok {
+			return protoreflect.ValueOfInt64(x.Int64), x.Valid, nil
+		}
+		rv := reflect.ValueOf(v)
+		switch rv.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			return protoreflect.ValueOfInt64(rv.Int()), true, nil
+		case reflect.Uint8, reflect.Uint16, reflect.Uint32:
+			return protoreflect.ValueOfInt64(int64(rv.Uint())), true, nil
+		}
+	case bigquery.FloatFieldType:
+		if x, ok := v.(bigquery.NullFloat64); ok {
+			return protoreflect.ValueOfFloat64(x.Float64), x.Valid, nil
+		}
+		rv := reflect.ValueOf(v)
+		if rv.Kind() == reflect.Float32 || rv.Kind() == reflect.Float64 {
+			return protoreflect.ValueOfFloat64(rv.Float()), true, nil
+		}
+	case bigquery.BooleanFieldType:
+		switch x := v.(type) {
+		case bool:
+			return protoreflect.ValueOfBool(x), true, nil
+		case bigquery.NullBool:
+			return protoreflect.ValueOfBool(x.Bool), x.Valid, nil
+		}
+	case bigquery.TimestampFieldType:
+		switch x := v.(type) {
+		case time.Time:
+			return protoreflect.ValueOfInt64(x.UnixMicro()), true, nil
+		case bigquery.NullTimestamp:
+			return protoreflect.ValueOfInt64(x.Timestamp.UnixMicro()), x.Valid, nil
+		}
+	case bigquery.DateFieldType:
+		switch x := v.(type) {
+		case civil.Date:
+			return protoreflect.ValueOfInt32(int32(x.DaysSince(unixEpochDate))), true, nil
+		case bigquery.NullDate:
+			return protoreflect.ValueOfInt32(int32(x.Date.DaysSince(unixEpochDate))), x.Valid, nil
+		}
+	case bigquery.TimeFieldType:
+		switch x := v.(type) {
+		case string:
+			return protoreflect.ValueOfString(x), true, nil
+		case civil.Time:
+			return protoreflect.ValueOfString(bigquery.CivilTimeString(x)), true, nil
+		case bigquery.NullTime:
+			return protoreflect.ValueOfString(bigquery.CivilTimeString(x.Time)), x.Valid, nil
+		}
+	case bigquery.DateTimeFieldType:
+		switch x := v.(type) {
+		case string:
+			return protoreflect.ValueOfString(x), true, nil
+		case civil.DateTime:
+			return protoreflect.ValueOfString(bigquery.CivilDateTimeString(x)), true, nil
+		case bigquery.NullDateTime:
+			return protoreflect.ValueOfString(bigquery.CivilDateTimeString(x.DateTime)), x.Valid, nil
+		}
+	case bigquery.NumericFieldType, bigquery.BigNumericFieldType:
+		switch x := v.(type) {
+		case string:
+			return protoreflect.ValueOfString(x), true, nil
+		case *big.Rat:
+			if x == nil {
+				return protoreflect.Value{}, false, nil
+			}
+			if fs.Type == bigquery.NumericFieldType {
+				return protoreflect.ValueOfString(bigquery.NumericString(x)), true, nil
+			}
+			return protoreflect.ValueOfString(bigquery.BigNumericString(x)), true, nil
+		}
+	case bigquery.RecordFieldType:
+		if x, ok := v.(map[string]bigquery.Value); ok {
+			if x == nil {
+				return protoreflect.Value{}, false, nil
+			}
+			sub, err := encodeRow(fd.Message(), fs.Schema, x)
+			if err != nil {
+				return protoreflect.Value{}, false, fmt.Errorf("column %q: %w", fs.Name, err)
+			}
+			return protoreflect.ValueOfMessage(sub), true, nil
+		}
+	}
+	return badType()
+}
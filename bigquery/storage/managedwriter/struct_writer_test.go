@@ -0,0 +1,205 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package managedwriter
+
+import (
+	"context"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/bigquery"
+	"cloud.google.com/go/civil"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+type structWriterInner struct {
+	Label string
+}
+
+type structWriterRow struct {
+	Name     string
+	Count    int
+	Score    bigquery.NullFloat64
+	OK       bool
+	Created  time.Time
+	Day      civil.Date
+	Clock    civil.Time
+	When     civil.DateTime
+	Price    *big.Rat `bigquery:"price"`
+	Tags     []string
+	Inner    structWriterInner
+	Children []structWriterInner
+}
+
+func TestStructWriterEncodeRow(t *testing.T) {
+	schema, err := bigquery.InferSchema(structWriterRow{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	md, dp, err := structWriterDescriptor(schema)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if dp == nil {
+		t.Fatal("no descriptor proto")
+	}
+	created := time.Date(2023, 4, 5, 6, 7, 8, 9000, time.UTC)
+	w := newStructWriter(nil, schema, md)
+	rows, err := w.rowMaps([]*structWriterRow{{
+		Name:     "a",
+		Count:    3,
+		OK:       true,
+		Created:  created,
+		Day:      civil.Date{Year: 1970, Month: 1, Day: 11},
+		Clock:    civil.Time{Hour: 1, Minute: 2, Second: 3},
+		When:     civil.DateTime{Date: civil.Date{Year: 2023, Month: 1, Day: 2}, Time: civil.Time{Hour: 3}},
+		Price:    big.NewRat(3, 2),
+		Tags:     []string{"x", "y"},
+		Inner:    structWriterInner{Label: "in"},
+		Children: []structWriterInner{{Label: "c1"}, {Label: "c2"}},
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) != 1 {
+		t.Fatalf("got %d rows, want 1", len(rows))
+	}
+	msg, err := encodeRow(md, schema, rows[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Round trip through the wire format, as the backend would see it.
+	b, err := proto.Marshal(msg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := dynamicpb.NewMessage(md)
+	if err := proto.Unmarshal(b, got); err != nil {
+		t.Fatal(err)
+	}
+	field := func(name string) protoreflect.Value {
+		return got.Get(md.Fields().ByName(protoreflect.Name(strings.ToLower(name))))
+	}
+	for _, tc := range []struct {
+		name string
+		got  interface{}
+		want interface{}
+	}{
+		{"Name", field("Name").String(), "a"},
+		{"Count", field("Count").Int(), int64(3)},
+		{"OK", field("OK").Bool(), true},
+		{"Created", field("Created").Int(), created.UnixMicro()},
+		{"Day", field("Day").Int(), int64(10)},
+		{"Clock", field("Clock").String(), "01:02:03"},
+		{"When", field("When").String(), "2023-01-02 03:00:00"},
+		{"price", field("price").String(), "1.500000000"},
+		{"Tags", field("Tags").List().Len(), 2},
+		{"Inner", field("Inner").Message().Get(md.Fields().ByName("inner").Message().Fields().ByNumber(1)).String(), "in"},
+		{"Children", field("Children").List().Len(), 2},
+	} {
+		if tc.got != tc.want {
+			t.Errorf("%s: got %v, want %v", tc.name, tc.got, tc.want)
+		}
+	}
+	if got.Has(md.Fields().ByName("score")) {
+		t.Error("null Score was set")
+	}
+}
+
+func TestStructWriterEncodeRowErrors(t *testing.T) {
+	schema := bigquery.Schema{
+		{Name: "req", Type: bigquery.IntegerFieldType, Required: true},
+		{Name: "rep", Type: bigquery.StringFieldType, Repeated: true},
+	}
+	md, _, err := structWriterDescriptor(schema)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, row := range []map[string]bigquery.Value{
+		{},
+		{"req": "not an int"},
+		{"req": bigquery.NullInt64{}},
+		{"req": 1, "rep": "not a slice"},
+	} {
+		if _, err := encodeRow(md, schema, row); err == nil {
+			t.Errorf("%v: got nil error, want error", row)
+		}
+	}
+	w := newStructWriter(nil, schema, md)
+	if _, err := w.rowMaps(42); err == nil {
+		t.Error("rowMaps(42): got nil error, want error")
+	}
+}
+
+func TestStructWriterAppendOffsets(t *testing.T) {
+	ctx := context.Background()
+	testARC := &testAppendRowsClient{}
+	pool := &connectionPool{
+		ctx:                ctx,
+		open:               openTestArc(testARC, nil, nil),
+		baseFlowController: newFlowController(0, 0),
+	}
+	if err := pool.activateRouter(newSimpleRouter("")); err != nil {
+		t.Fatalf("activateRouter: %v", err)
+	}
+	ms := &ManagedStream{
+		id:             "foo",
+		ctx:            ctx,
+		streamSettings: defaultStreamSettings(),
+	}
+	ms.streamSettings.streamType = CommittedStream
+	if err := pool.addWriter(ms); err != nil {
+		t.Fatalf("addWriter: %v", err)
+	}
+	ms.streamSettings.streamID = "FOO"
+
+	type row struct{ Name string }
+	schema, err := bigquery.InferSchema(row{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	md, dp, err := structWriterDescriptor(schema)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ms.curDescVersion = newDescriptorVersion(dp)
+	w := newStructWriter(ms, schema, md)
+
+	if _, err := w.Append(ctx, []row{{"a"}, {"b"}}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := w.Append(ctx, &row{"c"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Flush(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if len(testARC.requests) != 2 {
+		t.Fatalf("got %d requests, want 2", len(testARC.requests))
+	}
+	for i, want := range []int64{0, 2} {
+		req := testARC.requests[i]
+		if got := req.GetOffset().GetValue(); got != want {
+			t.Errorf("request %d: got offset %d, want %d", i, got, want)
+		}
+	}
+	if got := len(testARC.requests[0].GetProtoRows().GetRows().GetSerializedRows()); got != 2 {
+		t.Errorf("got %d rows in first request, want 2", got)
+	}
+}
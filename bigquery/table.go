@@ -151,6 +151,11 @@ type TableMetadata struct {
 	// TableConstraints contains table primary and foreign keys constraints.
 	// Present only if the table has primary or foreign keys.
 	TableConstraints *TableConstraints
+
+	// MaxStaleness is the maximum staleness of data that may be returned
+	// when the table is queried. For BigLake tables it bounds the age of the
+	// metadata cache; see ExternalDataConfig.MetadataCacheMode.
+	MaxStaleness *IntervalValue
 }
 
 // TableConstraints defines the primary key and foreign key of a table.
@@ -302,47 +307,100 @@ const (
 )
 
 // MaterializedViewDefinition contains information for materialized views.
+//
+// To create a materialized view, set TableMetadata.MaterializedView with a
+// Query. To change the refresh settings of an existing one, set
+// TableMetadataToUpdate.MaterializedView; the query of a materialized view
+// cannot be changed.
 type MaterializedViewDefinition struct {
 	// EnableRefresh governs whether the derived view is updated to reflect
 	// changes in the base table.
 	EnableRefresh bool
 
 	// LastRefreshTime reports the time, in millisecond precision, that the
-	// materialized view was last updated.
+	// materialized view was last updated. It is output only.
 	LastRefreshTime time.Time
 
 	// Query contains the SQL query used to define the materialized view.
 	Query string
 
 	// RefreshInterval defines the maximum frequency, in millisecond precision,
-	// at which this this materialized view will be refreshed.
+	// at which this this materialized view will be refreshed. If set, it must
+	// be between one minute and seven days.
 	RefreshInterval time.Duration
+
+	// AllowNonIncrementalDefinition allows the view to be defined by a query
+	// that cannot be maintained incrementally, such as one with outer joins or
+	// analytic functions. Such views are fully recomputed on refresh, and
+	// require MaxStaleness to be set. It can only be set when the view is
+	// created, and is ignored by updates.
+	AllowNonIncrementalDefinition bool
+
+	// MaxStaleness is the maximum staleness of data that may be returned when
+	// the materialized view is queried. If nil, the service default is used.
+	MaxStaleness *IntervalValue
+}
+
+const (
+	minMaterializedViewRefresh = time.Minute
+	maxMaterializedViewRefresh = 7 * 24 * time.Hour
+)
+
+// validate checks the settable fields of mvd. The query is required only on
+// create.
+func (mvd *MaterializedViewDefinition) validate(create bool) error {
+	if mvd == nil {
+		return nil
+	}
+	if create && mvd.Query == "" {
+		return errors.New("bigquery: MaterializedView requires Query")
+	}
+	if ri := mvd.RefreshInterval; ri != 0 && (ri < minMaterializedViewRefresh || ri > maxMaterializedViewRefresh) {
+		return fmt.Errorf("bigquery: MaterializedView RefreshInterval %v is not between %v and %v", ri, minMaterializedViewRefresh, maxMaterializedViewRefresh)
+	}
+	return nil
 }
 
 func (mvd *MaterializedViewDefinition) toBQ() *bq.MaterializedViewDefinition {
 	if mvd == nil {
 		return nil
 	}
-	return &bq.MaterializedViewDefinition{
-		EnableRefresh:     mvd.EnableRefresh,
-		Query:             mvd.Query,
-		LastRefreshTime:   mvd.LastRefreshTime.UnixNano() / 1e6,
-		RefreshIntervalMs: int64(mvd.RefreshInterval) / 1e6,
+	res := &bq.MaterializedViewDefinition{
+		EnableRefresh:                 mvd.EnableRefresh,
+		Query:                         mvd.Query,
+		RefreshIntervalMs:             int64(mvd.RefreshInterval) / 1e6,
+		AllowNonIncrementalDefinition: mvd.AllowNonIncrementalDefinition,
 		// force sending the bool in all cases due to how Go handles false.
 		ForceSendFields: []string{"EnableRefresh"},
 	}
+	if !mvd.LastRefreshTime.IsZero() {
+		res.LastRefreshTime = mvd.LastRefreshTime.UnixNano() / 1e6
+	}
+	if mvd.MaxStaleness != nil {
+		res.MaxStaleness = IntervalString(mvd.MaxStaleness)
+	}
+	return res
 }
 
-func bqToMaterializedViewDefinition(q *bq.MaterializedViewDefinition) *MaterializedViewDefinition {
+func bqToMaterializedViewDefinition(q *bq.MaterializedViewDefinition) (*MaterializedViewDefinition, error) {
 	if q == nil {
-		return nil
+		return nil, nil
 	}
-	return &MaterializedViewDefinition{
-		EnableRefresh:   q.EnableRefresh,
-		Query:           q.Query,
-		LastRefreshTime: unixMillisToTime(q.LastRefreshTime),
-		RefreshInterval: time.Duration(q.RefreshIntervalMs) * time.Millisecond,
+	mvd := &MaterializedViewDefinition{
+		EnableRefresh:                 q.EnableRefresh,
+		Query:                         q.Query,
+		LastRefreshTime:               unixMillisToTime(q.LastRefreshTime),
+		RefreshInterval:               time.Duration(q.RefreshIntervalMs) * time.Millisecond,
+		AllowNonIncrementalDefinition: q.AllowNonIncrementalDefinition,
 	}
+	if q.MaxStaleness != "" {
+		iv, err := ParseInterval(q.MaxStaleness)
+		if err != nil {
+			return nil, fmt.Errorf("bigquery: parsing MaterializedView MaxStaleness %q: %w", q.MaxStaleness, err)
+		}
+		mvd.MaxStaleness = iv
+	}
+	return mvd, nil
 }
 
 // SnapshotDefinition provides metadata related to the origin of a snapshot.
@@ -727,6 +785,17 @@ func (tm *TableMetadata) toBQ() (*bq.Table, error) {
 	} else if tm.UseLegacySQL || tm.UseStandardSQL {
 		return nil, errors.New("bigquery: UseLegacy/StandardSQL requires ViewQuery")
 	}
+	if tm.MaterializedView != nil {
+		if tm.ViewQuery != "" {
+			return nil, errors.New("bigquery: provide MaterializedView or ViewQuery, not both")
+		}
+		if tm.Schema != nil {
+			return nil, errors.New("bigquery: provide Schema or MaterializedView, not both")
+		}
+		if err := tm.MaterializedView.validate(true); err != nil {
+			return nil, err
+		}
+	}
 	t.MaterializedView = tm.MaterializedView.toBQ()
 	t.TimePartitioning = tm.TimePartitioning.toBQ()
 	t.RangePartitioning = tm.RangePartitioning.toBQ()
@@ -775,6 +844,9 @@ func (tm *TableMetadata) toBQ() (*bq.Table, error) {
 		return nil, errors.New("cannot set ETag on create")
 	}
 	t.DefaultCollation = string(tm.DefaultCollation)
+	if tm.MaxStaleness != nil {
+		t.MaxStaleness = IntervalString(tm.MaxStaleness)
+	}
 
 	if tm.TableConstraints != nil {
 		t.TableConstraints = &bq.TableConstraints{}
@@ -875,7 +947,18 @@ func bqToTableMetadata(t *bq.Table, c *Client) (*TableMetadata, error) {
 		CloneDefinition:        bqToCloneDefinition(t.CloneDefinition, c),
 	}
 	if t.MaterializedView != nil {
-		md.MaterializedView = bqToMaterializedViewDefinition(t.MaterializedView)
+		mvd, err := bqToMaterializedViewDefinition(t.MaterializedView)
+		if err != nil {
+			return nil, err
+		}
+		md.MaterializedView = mvd
+	}
+	if t.MaxStaleness != "" {
+		iv, err := ParseInterval(t.MaxStaleness)
+		if err != nil {
+			return nil, fmt.Errorf("bigquery: parsing MaxStaleness %q: %w", t.MaxStaleness, err)
+		}
+		md.MaxStaleness = iv
 	}
 	if t.Schema != nil {
 		md.Schema = bqToSchema(t.Schema)
@@ -1010,7 +1093,12 @@ func (tm *TableMetadataToUpdate) toBQ() (*bq.Table, error) {
 		forceSend("FriendlyName")
 	}
 	if tm.MaterializedView != nil {
+		if err := tm.MaterializedView.validate(false); err != nil {
+			return nil, err
+		}
 		t.MaterializedView = tm.MaterializedView.toBQ()
+		// The definition of a materialized view is immutable.
+		t.MaterializedView.AllowNonIncrementalDefinition = false
 		forceSend("MaterializedView")
 	}
 	if tm.Schema != nil {
@@ -1066,6 +1154,10 @@ func (tm *TableMetadataToUpdate) toBQ() (*bq.Table, error) {
 		t.DefaultCollation = optional.ToString(tm.DefaultCollation)
 		forceSend("DefaultCollation")
 	}
+	if tm.MaxStaleness != nil {
+		t.MaxStaleness = IntervalString(tm.MaxStaleness)
+		forceSend("MaxStaleness")
+	}
 	if tm.TableConstraints != nil {
 		t.TableConstraints = &bq.TableConstraints{}
 		if tm.TableConstraints.PrimaryKey != nil {
@@ -1162,6 +1254,10 @@ type TableMetadataToUpdate struct {
 	// such as primary and foreign keys.
 	TableConstraints *TableConstraints
 
+	// MaxStaleness is the maximum staleness of data that may be returned
+	// when the table is queried.
+	MaxStaleness *IntervalValue
+
 	labelUpdater
 }
 
@@ -34,6 +34,23 @@ func TestBQToTableMetadata(t *testing.T) {
 		want *TableMetadata
 	}{
 		{&bq.Table{}, &TableMetadata{}}, // test minimal case
+		// MaxStaleness as returned by the service.
+		{
+			&bq.Table{
+				MaxStaleness: "0-0 0 0:30:0",
+				MaterializedView: &bq.MaterializedViewDefinition{
+					Query:        "SELECT 1",
+					MaxStaleness: "0-0 1 4:5:6.5",
+				},
+			},
+			&TableMetadata{
+				MaxStaleness: &IntervalValue{Minutes: 30},
+				MaterializedView: &MaterializedViewDefinition{
+					Query:        "SELECT 1",
+					MaxStaleness: &IntervalValue{Days: 1, Hours: 4, Minutes: 5, Seconds: 6, SubSecondNanos: 500000000},
+				},
+			},
+		},
 		{
 			&bq.Table{
 				CreationTime:     aTimeMillis,
@@ -53,10 +70,12 @@ func TestBQToTableMetadata(t *testing.T) {
 					OldestEntryTime: uint64(aTimeMillis),
 				},
 				MaterializedView: &bq.MaterializedViewDefinition{
-					EnableRefresh:     true,
-					Query:             "mat view query",
-					LastRefreshTime:   aTimeMillis,
-					RefreshIntervalMs: aDurationMillis,
+					EnableRefresh:                 true,
+					Query:                         "mat view query",
+					LastRefreshTime:               aTimeMillis,
+					RefreshIntervalMs:             aDurationMillis,
+					AllowNonIncrementalDefinition: true,
+					MaxStaleness:                  "0-0 0 4:0:0",
 				},
 				TimePartitioning: &bq.TimePartitioning{
 					ExpirationMs: 7890,
@@ -112,10 +131,12 @@ func TestBQToTableMetadata(t *testing.T) {
 				NumLongTermBytes:   23,
 				NumRows:            7,
 				MaterializedView: &MaterializedViewDefinition{
-					EnableRefresh:   true,
-					Query:           "mat view query",
-					LastRefreshTime: aTime,
-					RefreshInterval: aDuration,
+					EnableRefresh:                 true,
+					Query:                         "mat view query",
+					LastRefreshTime:               aTime,
+					RefreshInterval:               aDuration,
+					AllowNonIncrementalDefinition: true,
+					MaxStaleness:                  &IntervalValue{Hours: 4},
 				},
 				TimePartitioning: &TimePartitioning{
 					Type:       DayPartitioningType,
@@ -166,6 +187,15 @@ func TestBQToTableMetadata(t *testing.T) {
 			t.Errorf("%+v:\n, -got, +want:\n%s", test.in, diff)
 		}
 	}
+
+	for _, in := range []*bq.Table{
+		{MaxStaleness: "bogus"},
+		{MaterializedView: &bq.MaterializedViewDefinition{MaxStaleness: "bogus"}},
+	} {
+		if _, err := bqToTableMetadata(in, bqClient); err == nil {
+			t.Errorf("%+v: got nil, want error for an invalid MaxStaleness", in)
+		}
+	}
 }
 
 func TestTableMetadataToBQ(t *testing.T) {
@@ -295,6 +325,31 @@ func TestTableMetadataToBQ(t *testing.T) {
 			&TableMetadata{ExpirationTime: NeverExpire},
 			&bq.Table{ExpirationTime: 0},
 		},
+		{
+			&TableMetadata{
+				MaterializedView: &MaterializedViewDefinition{
+					Query:                         "mv query",
+					EnableRefresh:                 true,
+					RefreshInterval:               time.Hour,
+					AllowNonIncrementalDefinition: true,
+					MaxStaleness:                  &IntervalValue{Hours: 4},
+				},
+			},
+			&bq.Table{
+				MaterializedView: &bq.MaterializedViewDefinition{
+					Query:                         "mv query",
+					EnableRefresh:                 true,
+					RefreshIntervalMs:             3600000,
+					AllowNonIncrementalDefinition: true,
+					MaxStaleness:                  "0-0 0 4:0:0",
+					ForceSendFields:               []string{"EnableRefresh"},
+				},
+			},
+		},
+		{
+			&TableMetadata{MaxStaleness: &IntervalValue{Minutes: 30}},
+			&bq.Table{MaxStaleness: "0-0 0 0:30:0"},
+		},
 	} {
 		got, err := test.in.toBQ()
 		if err != nil {
@@ -320,6 +375,12 @@ func TestTableMetadataToBQ(t *testing.T) {
 		{NumRows: 1},
 		{StreamingBuffer: &StreamingBuffer{}},
 		{ETag: "x"},
+		// invalid materialized views
+		{MaterializedView: &MaterializedViewDefinition{}},
+		{MaterializedView: &MaterializedViewDefinition{Query: "q"}, ViewQuery: "q"},
+		{MaterializedView: &MaterializedViewDefinition{Query: "q"}, Schema: sc},
+		{MaterializedView: &MaterializedViewDefinition{Query: "q", RefreshInterval: time.Second}},
+		{MaterializedView: &MaterializedViewDefinition{Query: "q", RefreshInterval: 8 * 24 * time.Hour}},
 		// expiration time outside allowable range is invalid
 		// See https://godoc.org/time#Time.UnixNano
 		{ExpirationTime: time.Date(1677, 9, 21, 0, 12, 43, 145224192, time.UTC).Add(-1)},
@@ -437,6 +498,25 @@ func TestTableMetadataToUpdateToBQ(t *testing.T) {
 				ForceSendFields:        []string{"RequirePartitionFilter"},
 			},
 		},
+		{
+			tm: TableMetadataToUpdate{
+				MaterializedView: &MaterializedViewDefinition{RefreshInterval: 30 * time.Minute, AllowNonIncrementalDefinition: true},
+			},
+			want: &bq.Table{
+				MaterializedView: &bq.MaterializedViewDefinition{
+					RefreshIntervalMs: 1800000,
+					ForceSendFields:   []string{"EnableRefresh"},
+				},
+				ForceSendFields: []string{"MaterializedView"},
+			},
+		},
+		{
+			tm: TableMetadataToUpdate{MaxStaleness: &IntervalValue{Hours: 1}},
+			want: &bq.Table{
+				MaxStaleness:    "0-0 0 1:0:0",
+				ForceSendFields: []string{"MaxStaleness"},
+			},
+		},
 		{
 			tm: TableMetadataToUpdate{RequirePartitionFilter: true},
 			want: &bq.Table{
@@ -520,6 +600,11 @@ func TestTableMetadataToUpdateToBQ(t *testing.T) {
 }
 
 func TestTableMetadataToUpdateToBQErrors(t *testing.T) {
+	tm := &TableMetadataToUpdate{MaterializedView: &MaterializedViewDefinition{RefreshInterval: -time.Minute}}
+	if _, err := tm.toBQ(); err == nil {
+		t.Error("negative MaterializedView.RefreshInterval: got nil, want error")
+	}
+
 	// See https://godoc.org/time#Time.UnixNano
 	start := time.Date(1677, 9, 21, 0, 12, 43, 145224192, time.UTC)
 	end := time.Date(2262, 04, 11, 23, 47, 16, 854775807, time.UTC)
@@ -16,6 +16,7 @@ package bigquery
 
 import (
 	"encoding/base64"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"math"
@@ -186,6 +187,21 @@ func setJSON(v reflect.Value, x interface{}) error {
 	return nil
 }
 
+// setJSONValue decodes the value of a JSON column into a json.RawMessage or
+// a map.
+func setJSONValue(v reflect.Value, x interface{}) error {
+	if x == nil {
+		v.Set(reflect.Zero(v.Type()))
+		return nil
+	}
+	p := reflect.New(v.Type())
+	if err := json.Unmarshal([]byte(x.(string)), p.Interface()); err != nil {
+		return fmt.Errorf("bigquery: decoding JSON value into %s: %v", v.Type(), err)
+	}
+	v.Set(p.Elem())
+	return nil
+}
+
 func setBytes(v reflect.Value, x interface{}) error {
 	if x == nil {
 		v.SetBytes(nil)
@@ -328,6 +344,9 @@ func determineSetFunc(ftype reflect.Type, stype FieldType) setFunc {
 				})
 			}
 		}
+		if isJSONType(ftype) {
+			return setJSONValue
+		}
 
 	case BytesFieldType:
 		if ftype == typeOfByteSlice {
@@ -575,6 +594,14 @@ func valuesToMap(vs []Value, schema Schema) (map[string]Value, error) {
 			m[fieldSchema.Name] = nil
 			continue
 		}
+		if fieldSchema.Type == JSONFieldType {
+			v, err := jsonUploadValue(reflect.ValueOf(vs[i]), fieldSchema)
+			if err != nil {
+				return nil, err
+			}
+			m[fieldSchema.Name] = v
+			continue
+		}
 		if fieldSchema.Type != RecordFieldType {
 			m[fieldSchema.Name] = toUploadValue(vs[i], fieldSchema)
 			continue
@@ -691,6 +718,9 @@ func structFieldToUploadValue(vfield reflect.Value, schemaField *FieldSchema) (i
 			schemaField.Name, vfield.Type())
 	}
 
+	if schemaField.Type == JSONFieldType {
+		return jsonUploadValue(vfield, schemaField)
+	}
 	// A non-nested field can be represented by its Go value, except for some types.
 	if schemaField.Type != RecordFieldType {
 		return toUploadValueReflect(vfield, schemaField), nil
@@ -776,6 +806,43 @@ func toUploadValueReflect(v reflect.Value, fs *FieldSchema) interface{} {
 	}
 }
 
+// jsonUploadValue converts the value of a JSON field for upload. A
+// json.RawMessage or map is encoded as a JSON string; other values, such as
+// strings and NullJSON, are handled like those of any other field.
+func jsonUploadValue(v reflect.Value, fs *FieldSchema) (interface{}, error) {
+	t := v.Type()
+	if fs.Repeated && (t.Kind() == reflect.Slice || t.Kind() == reflect.Array) {
+		t = t.Elem()
+	}
+	if !isJSONType(t) {
+		return toUploadValueReflect(v, fs), nil
+	}
+	encode := func(v reflect.Value) (string, error) {
+		b, err := json.Marshal(v.Interface())
+		if err != nil {
+			return "", fmt.Errorf("bigquery: encoding JSON field %s: %v", fs.Name, err)
+		}
+		return string(b), nil
+	}
+	if !fs.Repeated {
+		if v.IsNil() {
+			return nil, nil
+		}
+		return encode(v)
+	}
+	if v.Len() == 0 {
+		return nil, nil
+	}
+	s := make([]string, v.Len())
+	for i := range s {
+		var err error
+		if s[i], err = encode(v.Index(i)); err != nil {
+			return nil, err
+		}
+	}
+	return s, nil
+}
+
 func formatUploadValue(v reflect.Value, fs *FieldSchema, cvt func(reflect.Value) string) interface{} {
 	if !fs.Repeated {
 		return cvt(v)
@@ -16,6 +16,7 @@ package bigquery
 
 import (
 	"encoding/base64"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"math"
@@ -720,6 +721,85 @@ func TestStructSaver(t *testing.T) {
 		})
 }
 
+func TestJSONFields(t *testing.T) {
+	type row struct {
+		Raw  json.RawMessage
+		Map  map[string]interface{}
+		Str  string
+		Raws []json.RawMessage
+	}
+	schema := Schema{
+		{Name: "Raw", Type: JSONFieldType},
+		{Name: "Map", Type: JSONFieldType},
+		{Name: "Str", Type: JSONFieldType},
+		{Name: "Raws", Type: JSONFieldType, Repeated: true},
+	}
+
+	// Saving encodes json.RawMessage and map values as strings.
+	in := &row{
+		Raw:  json.RawMessage(`{"a": 1}`),
+		Map:  map[string]interface{}{"b": true},
+		Str:  `"s"`,
+		Raws: []json.RawMessage{json.RawMessage(`[1, 2]`)},
+	}
+	got, _, err := (&StructSaver{Struct: in, Schema: schema}).Save()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]Value{
+		"Raw":  `{"a":1}`,
+		"Map":  `{"b":true}`,
+		"Str":  `"s"`,
+		"Raws": []string{`[1,2]`},
+	}
+	if diff := testutil.Diff(got, want); diff != "" {
+		t.Errorf("Save: -got, +want:\n%s", diff)
+	}
+	got, _, err = (&StructSaver{Struct: &row{}, Schema: schema}).Save()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := (map[string]Value{"Str": ""}); !testutil.Equal(got, want) {
+		t.Errorf("Save of zero row: got %v, want %v", got, want)
+	}
+	vs := &ValuesSaver{Schema: schema[:2], Row: []Value{json.RawMessage(`1`), map[string]int{"c": 2}}}
+	got, _, err = vs.Save()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := (map[string]Value{"Raw": "1", "Map": `{"c":2}`}); !testutil.Equal(got, want) {
+		t.Errorf("ValuesSaver: got %v, want %v", got, want)
+	}
+	if _, _, err := (&StructSaver{Struct: &row{Raw: json.RawMessage("{")}, Schema: schema}).Save(); err == nil {
+		t.Error("saving invalid JSON: got nil, want error")
+	}
+
+	// Loading decodes JSON values into json.RawMessage and map fields.
+	var out row
+	if err := load(&out, schema, []Value{`{"a":1}`, `{"b":true}`, `"s"`, []Value{`[1,2]`}}); err != nil {
+		t.Fatal(err)
+	}
+	wantRow := row{
+		Raw:  json.RawMessage(`{"a":1}`),
+		Map:  map[string]interface{}{"b": true},
+		Str:  `"s"`,
+		Raws: []json.RawMessage{json.RawMessage(`[1,2]`)},
+	}
+	if diff := testutil.Diff(out, wantRow); diff != "" {
+		t.Errorf("load: -got, +want:\n%s", diff)
+	}
+	out = row{}
+	if err := load(&out, schema, []Value{nil, nil, "", []Value{}}); err != nil {
+		t.Fatal(err)
+	}
+	if diff := testutil.Diff(out, row{}); diff != "" {
+		t.Errorf("load of NULLs: -got, +want:\n%s", diff)
+	}
+	if err := load(&out, schema, []Value{nil, "[1]", "", []Value{}}); err == nil {
+		t.Error("loading a JSON array into a map: got nil, want error")
+	}
+}
+
 func TestStructSaverErrors(t *testing.T) {
 	type (
 		badField struct {
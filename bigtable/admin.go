@@ -237,6 +237,9 @@ type TableConf struct {
 	SplitKeys []string
 	// Families is a map from family name to GCPolicy
 	Families map[string]GCPolicy
+	// ColumnFamilies is a map from family name to the configuration of the
+	// family. It is used instead of Families if it is set.
+	ColumnFamilies map[string]Family
 	// DeletionProtection can be none, protected or unprotected
 	// set to protected to make the table protected against data loss
 	DeletionProtection    DeletionProtection
@@ -280,7 +283,12 @@ func (ac *AdminClient) CreateTableFromConf(ctx context.Context, conf *TableConf)
 		tbl.ChangeStreamConfig = &btapb.ChangeStreamConfig{}
 		tbl.ChangeStreamConfig.RetentionPeriod = durationpb.New(conf.ChangeStreamRetention.(time.Duration))
 	}
-	if conf.Families != nil {
+	if conf.ColumnFamilies != nil {
+		tbl.ColumnFamilies = make(map[string]*btapb.ColumnFamily)
+		for fam, config := range conf.ColumnFamilies {
+			tbl.ColumnFamilies[fam] = config.proto()
+		}
+	} else if conf.Families != nil {
 		tbl.ColumnFamilies = make(map[string]*btapb.ColumnFamily)
 		for fam, policy := range conf.Families {
 			tbl.ColumnFamilies[fam] = &btapb.ColumnFamily{GcRule: policy.proto()}
@@ -297,6 +305,72 @@ func (ac *AdminClient) CreateTableFromConf(ctx context.Context, conf *TableConf)
 	return err
 }
 
+// Aggregate is the aggregation function of an aggregate column family. The
+// cells of an aggregate family are written with Mutation.AddIntToCell, and
+// each accumulates the inputs with the same timestamp. They are read as
+// int64s, with ReadItem.Int64.
+type Aggregate int
+
+const (
+	// NoAggregate is the Aggregate of an ordinary column family, whose cells
+	// are written with Mutation.Set.
+	NoAggregate Aggregate = iota
+	// SumAggregate sums the inputs of each cell.
+	SumAggregate
+)
+
+// Family is the configuration of a column family.
+type Family struct {
+	// GCPolicy is the garbage collection policy of the family, or nil for
+	// none.
+	GCPolicy GCPolicy
+	// Aggregate makes the family an aggregate family. It cannot be changed
+	// once the family is created.
+	Aggregate Aggregate
+}
+
+func (f Family) proto() *btapb.ColumnFamily {
+	cf := &btapb.ColumnFamily{}
+	if f.GCPolicy != nil {
+		cf.GcRule = f.GCPolicy.proto()
+	}
+	if f.Aggregate == SumAggregate {
+		cf.ValueType = &btapb.Type{Kind: &btapb.Type_AggregateType{AggregateType: &btapb.Type_Aggregate{
+			InputType: &btapb.Type{Kind: &btapb.Type_Int64Type{Int64Type: &btapb.Type_Int64{
+				Encoding: &btapb.Type_Int64_Encoding{Encoding: &btapb.Type_Int64_Encoding_BigEndianBytes_{
+					BigEndianBytes: &btapb.Type_Int64_Encoding_BigEndianBytes{},
+				}},
+			}}},
+			Aggregator: &btapb.Type_Aggregate_Sum_{Sum: &btapb.Type_Aggregate_Sum{}},
+		}}}
+	}
+	return cf
+}
+
+// aggregateOf returns the Aggregate of a column family with value type t.
+func aggregateOf(t *btapb.Type) Aggregate {
+	if t.GetAggregateType().GetSum() != nil {
+		return SumAggregate
+	}
+	return NoAggregate
+}
+
+// CreateColumnFamilyWithConfig creates a new column family in a table, with
+// the given configuration.
+func (ac *AdminClient) CreateColumnFamilyWithConfig(ctx context.Context, table, family string, config Family) error {
+	ctx = mergeOutgoingMetadata(ctx, ac.md)
+	prefix := ac.instancePrefix()
+	req := &btapb.ModifyColumnFamiliesRequest{
+		Name: prefix + "/tables/" + table,
+		Modifications: []*btapb.ModifyColumnFamiliesRequest_Modification{{
+			Id:  family,
+			Mod: &btapb.ModifyColumnFamiliesRequest_Modification_Create{Create: config.proto()},
+		}},
+	}
+	_, err := ac.tClient.ModifyColumnFamilies(ctx, req)
+	return err
+}
+
 // CreateColumnFamily creates a new column family in a table.
 func (ac *AdminClient) CreateColumnFamily(ctx context.Context, table, family string) error {
 	// TODO(dsymonds): Permit specifying gcexpr and any other family settings.
@@ -429,6 +503,7 @@ type FamilyInfo struct {
 	Name         string
 	GCPolicy     string
 	FullGCPolicy GCPolicy
+	Aggregate    Aggregate
 }
 
 func (ac *AdminClient) getTable(ctx context.Context, table string, view btapb.Table_View) (*btapb.Table, error) {
@@ -468,6 +543,7 @@ func (ac *AdminClient) TableInfo(ctx context.Context, table string) (*TableInfo,
 			Name:         name,
 			GCPolicy:     GCRuleToString(fam.GcRule),
 			FullGCPolicy: gcRuleToPolicy(fam.GcRule),
+			Aggregate:    aggregateOf(fam.ValueType),
 		})
 	}
 	// we expect DeletionProtection to be in the response because Table_SCHEMA_VIEW is being used in this function
@@ -0,0 +1,193 @@
+/*
+Copyright 2023 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bigtable
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"google.golang.org/api/support/bundler"
+	btpb "google.golang.org/genproto/googleapis/bigtable/v2"
+)
+
+const (
+	// DefaultBatchRowCount is the default MutationBatcherConfig.RowCountThreshold.
+	DefaultBatchRowCount = 100
+
+	// DefaultBatchByteSize is the default MutationBatcherConfig.ByteThreshold.
+	DefaultBatchByteSize = 1 << 20 // 1MiB
+
+	// DefaultBatchDelay is the default MutationBatcherConfig.DelayThreshold.
+	DefaultBatchDelay = 10 * time.Millisecond
+
+	// DefaultBatchBufferedByteLimit is the default
+	// MutationBatcherConfig.BufferedByteLimit.
+	DefaultBatchBufferedByteLimit = 100 << 20 // 100MiB
+)
+
+// errBatcherClosed is returned by MutationBatcher.Add after Close.
+var errBatcherClosed = errors.New("bigtable: MutationBatcher is closed")
+
+// MutationBatcherConfig configures a MutationBatcher. The zero value of each
+// field selects its default.
+type MutationBatcherConfig struct {
+	// RowCountThreshold is the number of rows that triggers a flush.
+	// The default is DefaultBatchRowCount.
+	RowCountThreshold int
+
+	// ByteThreshold is the size in bytes of the mutations that triggers a
+	// flush. The default is DefaultBatchByteSize.
+	ByteThreshold int
+
+	// DelayThreshold is the longest time that a row waits before it is
+	// flushed. The default is DefaultBatchDelay.
+	DelayThreshold time.Duration
+
+	// BufferedByteLimit is the largest size in bytes of the mutations held in
+	// memory, waiting to be flushed or being flushed. When it is reached,
+	// Add blocks until there is room. The default is
+	// DefaultBatchBufferedByteLimit.
+	BufferedByteLimit int
+
+	// ConcurrentFlushes is the number of batches that may be flushed at the
+	// same time. The default is 1.
+	ConcurrentFlushes int
+}
+
+// A MutationBatcher applies mutations in the background, in batches, with
+// ApplyBulk. A batch is flushed when it holds enough rows or bytes, or when
+// its oldest row has waited long enough, according to the
+// MutationBatcherConfig. It is suited to streaming ingestion, where rows
+// arrive one by one but are best written many at a time.
+//
+// A MutationBatcher is safe to use concurrently. Call Close when you are done
+// with it, to flush the remaining rows.
+type MutationBatcher struct {
+	t       *Table
+	ctx     context.Context
+	bundler *bundler.Bundler
+	closed  chan struct{}
+}
+
+// batchEntry is a row added to a MutationBatcher.
+type batchEntry struct {
+	row      string
+	mut      *Mutation
+	callback func(error)
+}
+
+// NewMutationBatcher returns a MutationBatcher that applies mutations to the
+// table. ctx is used for the ApplyBulk calls of all the batches, so it should
+// outlive the MutationBatcher.
+func (t *Table) NewMutationBatcher(ctx context.Context, config MutationBatcherConfig) *MutationBatcher {
+	b := &MutationBatcher{
+		t:      t,
+		ctx:    ctx,
+		closed: make(chan struct{}),
+	}
+	b.bundler = bundler.NewBundler(&batchEntry{}, func(entries interface{}) {
+		b.flush(entries.([]*batchEntry))
+	})
+	b.bundler.BundleCountThreshold = DefaultBatchRowCount
+	if config.RowCountThreshold > 0 {
+		b.bundler.BundleCountThreshold = config.RowCountThreshold
+	}
+	b.bundler.BundleByteThreshold = DefaultBatchByteSize
+	if config.ByteThreshold > 0 {
+		b.bundler.BundleByteThreshold = config.ByteThreshold
+	}
+	b.bundler.DelayThreshold = DefaultBatchDelay
+	if config.DelayThreshold > 0 {
+		b.bundler.DelayThreshold = config.DelayThreshold
+	}
+	b.bundler.BufferedByteLimit = DefaultBatchBufferedByteLimit
+	if config.BufferedByteLimit > 0 {
+		b.bundler.BufferedByteLimit = config.BufferedByteLimit
+	}
+	if config.ConcurrentFlushes > 0 {
+		b.bundler.HandlerLimit = config.ConcurrentFlushes
+	}
+	return b
+}
+
+// Add adds a mutation of a row to the batcher. If callback is not nil, it is
+// called with the result of applying the mutation once its batch has been
+// flushed: nil if the mutation was applied, and its error otherwise. Callbacks
+// are called from the goroutines that flush batches, so they should return
+// quickly.
+//
+// Add blocks while the batcher holds BufferedByteLimit bytes of mutations,
+// until there is room or ctx is done. Add returns an error, and callback is
+// not called, if the mutation is conditional, if it is larger than
+// BufferedByteLimit, if ctx is done or if the batcher is closed.
+func (b *MutationBatcher) Add(ctx context.Context, row string, m *Mutation, callback func(error)) error {
+	if m.cond != nil {
+		return errors.New("bigtable: conditional mutations cannot be batched")
+	}
+	select {
+	case <-b.closed:
+		return errBatcherClosed
+	default:
+	}
+	size := proto.Size(&btpb.MutateRowsRequest_Entry{RowKey: []byte(row), Mutations: m.ops})
+	return b.bundler.AddWait(ctx, &batchEntry{row: row, mut: m, callback: callback}, size)
+}
+
+// Flush flushes all the rows added so far, and waits until their callbacks
+// have been called.
+func (b *MutationBatcher) Flush() {
+	b.bundler.Flush()
+}
+
+// Close flushes all the rows added so far, waits until their callbacks have
+// been called, and makes later calls to Add fail. Close must not be called
+// concurrently with Add.
+func (b *MutationBatcher) Close() {
+	select {
+	case <-b.closed:
+		return
+	default:
+	}
+	close(b.closed)
+	b.bundler.Flush()
+}
+
+// flush applies a batch of entries and reports their results.
+func (b *MutationBatcher) flush(entries []*batchEntry) {
+	rowKeys := make([]string, len(entries))
+	muts := make([]*Mutation, len(entries))
+	for i, e := range entries {
+		rowKeys[i] = e.row
+		muts[i] = e.mut
+	}
+	errs, err := b.t.ApplyBulk(b.ctx, rowKeys, muts)
+	for i, e := range entries {
+		if e.callback == nil {
+			continue
+		}
+		switch {
+		case err != nil:
+			e.callback(err)
+		case errs != nil:
+			e.callback(errs[i])
+		default:
+			e.callback(nil)
+		}
+	}
+}
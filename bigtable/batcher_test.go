@@ -0,0 +1,172 @@
+/*
+Copyright 2023 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bigtable
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	btpb "google.golang.org/genproto/googleapis/bigtable/v2"
+	"google.golang.org/grpc"
+)
+
+func TestMutationBatcherRowCount(t *testing.T) {
+	ctx := context.Background()
+
+	var (
+		mu        sync.Mutex
+		batchSize []int
+	)
+	interceptor := func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if strings.HasSuffix(info.FullMethod, "MutateRows") {
+			ss = &recordingServerStream{ServerStream: ss, recv: func(m interface{}) {
+				mu.Lock()
+				defer mu.Unlock()
+				batchSize = append(batchSize, len(m.(*btpb.MutateRowsRequest).Entries))
+			}}
+		}
+		return handler(srv, ss)
+	}
+	tbl, cleanup, err := setupFakeServer(grpc.StreamInterceptor(interceptor))
+	if err != nil {
+		t.Fatalf("fake server setup: %v", err)
+	}
+	defer cleanup()
+
+	b := tbl.NewMutationBatcher(ctx, MutationBatcherConfig{RowCountThreshold: 2, DelayThreshold: time.Hour})
+	var results []error
+	for i := 0; i < 5; i++ {
+		m := NewMutation()
+		m.Set("cf", "col", 1000, []byte(fmt.Sprint(i)))
+		if err := b.Add(ctx, fmt.Sprintf("row%d", i), m, func(err error) {
+			mu.Lock()
+			defer mu.Unlock()
+			results = append(results, err)
+		}); err != nil {
+			t.Fatalf("Add: %v", err)
+		}
+	}
+	b.Close()
+
+	if want := []int{2, 2, 1}; !cmp.Equal(batchSize, want) {
+		t.Errorf("batch sizes: got %v, want %v", batchSize, want)
+	}
+	if want := make([]error, 5); !cmp.Equal(results, want) {
+		t.Errorf("results: got %v, want %v", results, want)
+	}
+	var rows []string
+	if err := tbl.ReadRows(ctx, RowRange{}, func(r Row) bool {
+		rows = append(rows, r.Key())
+		return true
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"row0", "row1", "row2", "row3", "row4"}; !cmp.Equal(rows, want) {
+		t.Errorf("rows: got %v, want %v", rows, want)
+	}
+
+	m := NewMutation()
+	m.Set("cf", "col", 1000, []byte("v"))
+	if err := b.Add(ctx, "row", m, nil); err != errBatcherClosed {
+		t.Errorf("Add after Close: got %v, want %v", err, errBatcherClosed)
+	}
+}
+
+func TestMutationBatcherDelay(t *testing.T) {
+	ctx := context.Background()
+	tbl, cleanup, err := setupFakeServer()
+	if err != nil {
+		t.Fatalf("fake server setup: %v", err)
+	}
+	defer cleanup()
+
+	b := tbl.NewMutationBatcher(ctx, MutationBatcherConfig{RowCountThreshold: 1000, DelayThreshold: 10 * time.Millisecond})
+	defer b.Close()
+	done := make(chan error, 1)
+	m := NewMutation()
+	m.Set("cf", "col", 1000, []byte("v"))
+	if err := b.Add(ctx, "row", m, func(err error) { done <- err }); err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Errorf("got %v, want nil", err)
+		}
+	case <-time.After(10 * time.Second):
+		t.Fatal("row was not flushed after the delay threshold")
+	}
+}
+
+func TestMutationBatcherErrors(t *testing.T) {
+	ctx := context.Background()
+	tbl, cleanup, err := setupFakeServer()
+	if err != nil {
+		t.Fatalf("fake server setup: %v", err)
+	}
+	defer cleanup()
+
+	b := tbl.NewMutationBatcher(ctx, MutationBatcherConfig{})
+	results := map[string]error{}
+	var mu sync.Mutex
+	add := func(row, family string) {
+		m := NewMutation()
+		m.Set(family, "col", 1000, []byte("v"))
+		if err := b.Add(ctx, row, m, func(err error) {
+			mu.Lock()
+			defer mu.Unlock()
+			results[row] = err
+		}); err != nil {
+			t.Fatalf("Add: %v", err)
+		}
+	}
+	add("good", "cf")
+	add("bad", "nosuchfamily")
+	b.Flush()
+
+	if err := results["good"]; err != nil {
+		t.Errorf("good row: got %v, want nil", err)
+	}
+	if err := results["bad"]; err == nil {
+		t.Error("row with unknown family: got nil, want error")
+	}
+
+	cond := NewCondMutation(PassAllFilter(), NewMutation(), nil)
+	if err := b.Add(ctx, "row", cond, nil); err == nil {
+		t.Error("conditional mutation: got nil, want error")
+	}
+	b.Close()
+}
+
+// recordingServerStream calls recv with each message that it receives.
+type recordingServerStream struct {
+	grpc.ServerStream
+	recv func(interface{})
+}
+
+func (s *recordingServerStream) RecvMsg(m interface{}) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+	s.recv(m)
+	return nil
+}
@@ -29,6 +29,7 @@ import (
 	"cloud.google.com/go/internal/trace"
 	"github.com/golang/protobuf/proto"
 	gax "github.com/googleapis/gax-go/v2"
+	"go.opentelemetry.io/otel/metric"
 	"google.golang.org/api/option"
 	"google.golang.org/api/option/internaloption"
 	gtransport "google.golang.org/api/transport/grpc"
@@ -55,6 +56,7 @@ type Client struct {
 	client            btpb.BigtableClient
 	project, instance string
 	appProfile        string
+	metrics           *builtinMetrics // nil if client-side metrics are disabled
 }
 
 // ClientConfig has configurations for the client.
@@ -62,6 +64,13 @@ type ClientConfig struct {
 	// The id of the app profile to associate with all data operations sent from this client.
 	// If unspecified, the default app profile for the instance will be used.
 	AppProfile string
+
+	// MeterProvider, if set, is used to record client-side metrics for data
+	// operations: the latencies of operations and of their attempts, the
+	// number of retries, the latencies measured by the server and the number
+	// of attempts that failed to reach it. See the package documentation for
+	// the list of metrics. If unspecified, no metrics are recorded.
+	MeterProvider metric.MeterProvider
 }
 
 // NewClient creates a new Client for a given project and instance.
@@ -89,6 +98,13 @@ func NewClientWithConfig(ctx context.Context, project, instance string, config C
 	// whether the attempt is allowed is totally controlled by service owner.
 	o = append(o, internaloption.EnableDirectPath(true))
 	o = append(o, opts...)
+	var metrics *builtinMetrics
+	if config.MeterProvider != nil {
+		metrics, err = newBuiltinMetrics(config.MeterProvider, project, instance, config.AppProfile)
+		if err != nil {
+			return nil, fmt.Errorf("creating metrics: %w", err)
+		}
+	}
 	connPool, err := gtransport.DialPool(ctx, o...)
 	if err != nil {
 		return nil, fmt.Errorf("dialing: %w", err)
@@ -100,6 +116,7 @@ func NewClientWithConfig(ctx context.Context, project, instance string, config C
 		project:    project,
 		instance:   instance,
 		appProfile: config.AppProfile,
+		metrics:    metrics,
 	}, nil
 }
 
@@ -175,21 +192,29 @@ func (c *Client) Open(table string) *Table {
 // f owns its argument, and f is called serially in order by row key.
 //
 // By default, the yielded rows will contain all values in all cells.
-// Use RowFilter to limit the cells returned.
+// Use RowFilter to limit the cells returned. Use ReverseScan to read the
+// rows in descending order of row key instead.
 func (t *Table) ReadRows(ctx context.Context, arg RowSet, f func(Row) bool, opts ...ReadOption) (err error) {
 	ctx = mergeOutgoingMetadata(ctx, t.md)
 	ctx = trace.StartSpan(ctx, "cloud.google.com/go/bigtable.ReadRows")
 	defer func() { trace.EndSpan(ctx, err) }()
 
+	mt := t.c.metrics.newOpTracer(ctx, t.table, "ReadRows", true)
+	defer func() { mt.done(err) }()
+
 	var prevRowKey string
+	var rowsRead int64
 	attrMap := make(map[string]interface{})
-	err = gax.Invoke(ctx, func(ctx context.Context, _ gax.CallSettings) error {
+	err = gax.Invoke(ctx, func(ctx context.Context, _ gax.CallSettings) (err error) {
 		if !arg.valid() {
 			// Empty row set, no need to make an API call.
 			// NOTE: we must return early if arg == RowList{} because reading
 			// an empty RowList from bigtable returns all rows from that table.
 			return nil
 		}
+		mt.attemptStarted()
+		defer func() { mt.attemptDone(err) }()
+
 		req := &btpb.ReadRowsRequest{
 			TableName:    t.c.fullTableName(t.table),
 			AppProfileId: t.c.appProfile,
@@ -199,15 +224,23 @@ func (t *Table) ReadRows(ctx context.Context, arg RowSet, f func(Row) bool, opts
 		for _, opt := range opts {
 			opt.set(&settings)
 		}
+		if req.RowsLimit > 0 {
+			// Don't read more rows than the limit over all attempts.
+			req.RowsLimit -= rowsRead
+			if req.RowsLimit <= 0 {
+				return nil
+			}
+		}
 		ctx, cancel := context.WithCancel(ctx) // for aborting the stream
 		defer cancel()
 
 		startTime := time.Now()
-		stream, err := t.c.client.ReadRows(ctx, req)
+		stream, err := t.c.client.ReadRows(ctx, req, mt.callOptions()...)
 		if err != nil {
 			return err
 		}
 		cr := newChunkReader()
+		cr.reversed = req.Reversed
 		for {
 			res, err := stream.Recv()
 			if err == io.EOF {
@@ -215,13 +248,18 @@ func (t *Table) ReadRows(ctx context.Context, arg RowSet, f func(Row) bool, opts
 			}
 			if err != nil {
 				// Reset arg for next Invoke call.
-				arg = arg.retainRowsAfter(prevRowKey)
+				if req.Reversed {
+					arg = arg.retainRowsBefore(prevRowKey)
+				} else {
+					arg = arg.retainRowsAfter(prevRowKey)
+				}
 				attrMap["rowKey"] = prevRowKey
 				attrMap["error"] = err.Error()
 				attrMap["time_secs"] = time.Since(startTime).Seconds()
 				trace.TracePrintf(ctx, attrMap, "Retry details in ReadRows")
 				return err
 			}
+			mt.responseReceived()
 			attrMap["time_secs"] = time.Since(startTime).Seconds()
 			attrMap["rowCount"] = len(res.Chunks)
 			trace.TracePrintf(ctx, attrMap, "Details in ReadRows")
@@ -236,7 +274,11 @@ func (t *Table) ReadRows(ctx context.Context, arg RowSet, f func(Row) bool, opts
 					continue
 				}
 				prevRowKey = row.Key()
-				if !f(row) {
+				rowsRead++
+				callStart := time.Now()
+				more := f(row)
+				mt.applicationBlocked(time.Since(callStart))
+				if !more {
 					// Cancel and drain stream.
 					cancel()
 					for {
@@ -306,6 +348,11 @@ type RowSet interface {
 	// given row key or any row key lexicographically less than it.
 	retainRowsAfter(lastRowKey string) RowSet
 
+	// retainRowsBefore returns a new RowSet that does not include the
+	// given row key or any row key lexicographically greater than it.
+	// It is used to resume reverse scans.
+	retainRowsBefore(lastRowKey string) RowSet
+
 	// Valid reports whether this set can cover at least one row.
 	valid() bool
 }
@@ -331,6 +378,19 @@ func (r RowList) retainRowsAfter(lastRowKey string) RowSet {
 	return retryKeys
 }
 
+func (r RowList) retainRowsBefore(lastRowKey string) RowSet {
+	if lastRowKey == "" {
+		return r
+	}
+	var retryKeys RowList
+	for _, key := range r {
+		if key < lastRowKey {
+			retryKeys = append(retryKeys, key)
+		}
+	}
+	return retryKeys
+}
+
 func (r RowList) valid() bool {
 	return len(r) > 0
 }
@@ -393,6 +453,14 @@ func (r RowRange) retainRowsAfter(lastRowKey string) RowSet {
 	return NewRange(start, r.limit)
 }
 
+func (r RowRange) retainRowsBefore(lastRowKey string) RowSet {
+	if lastRowKey == "" || (!r.Unbounded() && r.limit <= lastRowKey) {
+		return r
+	}
+	// Set the end of the range to the last row scanned, which is excluded.
+	return NewRange(r.start, lastRowKey)
+}
+
 func (r RowRange) valid() bool {
 	return r.Unbounded() || r.start < r.limit
 }
@@ -424,6 +492,21 @@ func (r RowRangeList) retainRowsAfter(lastRowKey string) RowSet {
 	return ranges
 }
 
+func (r RowRangeList) retainRowsBefore(lastRowKey string) RowSet {
+	if lastRowKey == "" {
+		return r
+	}
+	// Return a list of any range that has not yet been completely processed
+	var ranges RowRangeList
+	for _, rr := range r {
+		retained := rr.retainRowsBefore(lastRowKey)
+		if retained.valid() {
+			ranges = append(ranges, retained.(RowRange))
+		}
+	}
+	return ranges
+}
+
 func (r RowRangeList) valid() bool {
 	for _, rr := range r {
 		if rr.valid() {
@@ -564,6 +647,16 @@ type limitRows struct{ limit int64 }
 
 func (lr limitRows) set(settings *readSettings) { settings.req.RowsLimit = lr.limit }
 
+// ReverseScan returns a ReadOption that makes ReadRows return rows in
+// descending order of row key. Combined with LimitRows, it reads the last
+// rows of a range efficiently, for example the latest entries of a time
+// series whose row keys end with a timestamp.
+func ReverseScan() ReadOption { return reverseScan{} }
+
+type reverseScan struct{}
+
+func (reverseScan) set(settings *readSettings) { settings.req.Reversed = true }
+
 // WithFullReadStats returns a ReadOption that will request FullReadStats
 // and invoke the given callback on the resulting FullReadStats.
 func WithFullReadStats(f FullReadStatsFunc) ReadOption { return withFullReadStats{f} }
@@ -606,6 +699,13 @@ func (t *Table) Apply(ctx context.Context, row string, m *Mutation, opts ...Appl
 		}
 	}
 
+	method := "CheckAndMutateRow"
+	if m.cond == nil {
+		method = "MutateRow"
+	}
+	mt := t.c.metrics.newOpTracer(ctx, t.table, method, false)
+	defer func() { mt.done(err) }()
+
 	var callOptions []gax.CallOption
 	if m.cond == nil {
 		req := &btpb.MutateRowRequest{
@@ -618,9 +718,10 @@ func (t *Table) Apply(ctx context.Context, row string, m *Mutation, opts ...Appl
 			callOptions = retryOptions
 		}
 		var res *btpb.MutateRowResponse
-		err := gax.Invoke(ctx, func(ctx context.Context, _ gax.CallSettings) error {
-			var err error
-			res, err = t.c.client.MutateRow(ctx, req)
+		err := gax.Invoke(ctx, func(ctx context.Context, _ gax.CallSettings) (err error) {
+			mt.attemptStarted()
+			defer func() { mt.attemptDone(err) }()
+			res, err = t.c.client.MutateRow(ctx, req, mt.callOptions()...)
 			return err
 		}, callOptions...)
 		if err == nil {
@@ -651,9 +752,10 @@ func (t *Table) Apply(ctx context.Context, row string, m *Mutation, opts ...Appl
 		callOptions = retryOptions
 	}
 	var cmRes *btpb.CheckAndMutateRowResponse
-	err = gax.Invoke(ctx, func(ctx context.Context, _ gax.CallSettings) error {
-		var err error
-		cmRes, err = t.c.client.CheckAndMutateRow(ctx, req)
+	err = gax.Invoke(ctx, func(ctx context.Context, _ gax.CallSettings) (err error) {
+		mt.attemptStarted()
+		defer func() { mt.attemptDone(err) }()
+		cmRes, err = t.c.client.CheckAndMutateRow(ctx, req, mt.callOptions()...)
 		return err
 	}, callOptions...)
 	if err == nil {
@@ -719,6 +821,20 @@ func (m *Mutation) Set(family, column string, ts Timestamp, value []byte) {
 	}}})
 }
 
+// AddIntToCell adds value to the cell with the given timestamp in a specified
+// column of an aggregate family, which accumulates the inputs with the
+// family's aggregation function, such as SumAggregate. The timestamp will be
+// truncated to millisecond granularity. Unlike ReadModifyWrite.Increment,
+// AddIntToCell does not read the row, so it can be applied in bulk.
+func (m *Mutation) AddIntToCell(family, column string, ts Timestamp, value int64) {
+	m.ops = append(m.ops, &btpb.Mutation{Mutation: &btpb.Mutation_AddToCell_{AddToCell: &btpb.Mutation_AddToCell{
+		FamilyName:      family,
+		ColumnQualifier: &btpb.Value{Kind: &btpb.Value_RawValue{RawValue: []byte(column)}},
+		Timestamp:       &btpb.Value{Kind: &btpb.Value_RawTimestampMicros{RawTimestampMicros: int64(ts.TruncateToMilliseconds())}},
+		Input:           &btpb.Value{Kind: &btpb.Value_IntValue{IntValue: value}},
+	}}})
+}
+
 // DeleteCellsInColumn will delete all the cells whose columns are family:column.
 func (m *Mutation) DeleteCellsInColumn(family, column string) {
 	m.ops = append(m.ops, &btpb.Mutation{Mutation: &btpb.Mutation_DeleteFromColumn_{DeleteFromColumn: &btpb.Mutation_DeleteFromColumn{
@@ -791,10 +907,13 @@ func (t *Table) ApplyBulk(ctx context.Context, rowKeys []string, muts []*Mutatio
 
 	for _, group := range groupEntries(origEntries, maxMutations) {
 		attrMap := make(map[string]interface{})
-		err = gax.Invoke(ctx, func(ctx context.Context, _ gax.CallSettings) error {
+		mt := t.c.metrics.newOpTracer(ctx, t.table, "MutateRows", true)
+		err = gax.Invoke(ctx, func(ctx context.Context, _ gax.CallSettings) (err error) {
+			mt.attemptStarted()
+			defer func() { mt.attemptDone(err) }()
 			attrMap["rowCount"] = len(group)
 			trace.TracePrintf(ctx, attrMap, "Row count in ApplyBulk")
-			err := t.doApplyBulk(ctx, group, opts...)
+			err = t.doApplyBulk(ctx, mt, group, opts...)
 			if err != nil {
 				// We want to retry the entire request with the current group
 				return err
@@ -807,6 +926,7 @@ func (t *Table) ApplyBulk(ctx context.Context, rowKeys []string, muts []*Mutatio
 			}
 			return nil
 		}, retryOptions...)
+		mt.done(err)
 		if err != nil {
 			return nil, err
 		}
@@ -841,7 +961,7 @@ func (t *Table) getApplyBulkRetries(entries []*entryErr) []*entryErr {
 }
 
 // doApplyBulk does the work of a single ApplyBulk invocation
-func (t *Table) doApplyBulk(ctx context.Context, entryErrs []*entryErr, opts ...ApplyOption) error {
+func (t *Table) doApplyBulk(ctx context.Context, mt *opTracer, entryErrs []*entryErr, opts ...ApplyOption) error {
 	after := func(res proto.Message) {
 		for _, o := range opts {
 			o.after(res)
@@ -857,7 +977,7 @@ func (t *Table) doApplyBulk(ctx context.Context, entryErrs []*entryErr, opts ...
 		AppProfileId: t.c.appProfile,
 		Entries:      entries,
 	}
-	stream, err := t.c.client.MutateRows(ctx, req)
+	stream, err := t.c.client.MutateRows(ctx, req, mt.callOptions()...)
 	if err != nil {
 		return err
 	}
@@ -944,7 +1064,11 @@ func (t *Table) ApplyReadModifyWrite(ctx context.Context, row string, m *ReadMod
 		RowKey:       []byte(row),
 		Rules:        m.ops,
 	}
-	res, err := t.c.client.ReadModifyWriteRow(ctx, req)
+	mt := t.c.metrics.newOpTracer(ctx, t.table, "ReadModifyWriteRow", false)
+	mt.attemptStarted()
+	res, err := t.c.client.ReadModifyWriteRow(ctx, req, mt.callOptions()...)
+	mt.attemptDone(err)
+	mt.done(err)
 	if err != nil {
 		return nil, err
 	}
@@ -999,8 +1123,11 @@ func (m *ReadModifyWrite) Increment(family, column string, delta int64) {
 // the table of approximately equal size, which can be used to break up the data for distributed tasks like mapreduces.
 func (t *Table) SampleRowKeys(ctx context.Context) ([]string, error) {
 	ctx = mergeOutgoingMetadata(ctx, t.md)
+	mt := t.c.metrics.newOpTracer(ctx, t.table, "SampleRowKeys", true)
 	var sampledRowKeys []string
-	err := gax.Invoke(ctx, func(ctx context.Context, _ gax.CallSettings) error {
+	err := gax.Invoke(ctx, func(ctx context.Context, _ gax.CallSettings) (err error) {
+		mt.attemptStarted()
+		defer func() { mt.attemptDone(err) }()
 		sampledRowKeys = nil
 		req := &btpb.SampleRowKeysRequest{
 			TableName:    t.c.fullTableName(t.table),
@@ -1009,7 +1136,7 @@ func (t *Table) SampleRowKeys(ctx context.Context) ([]string, error) {
 		ctx, cancel := context.WithCancel(ctx) // for aborting the stream
 		defer cancel()
 
-		stream, err := t.c.client.SampleRowKeys(ctx, req)
+		stream, err := t.c.client.SampleRowKeys(ctx, req, mt.callOptions()...)
 		if err != nil {
 			return err
 		}
@@ -1031,5 +1158,6 @@ func (t *Table) SampleRowKeys(ctx context.Context) ([]string, error) {
 		}
 		return nil
 	}, retryOptions...)
+	mt.done(err)
 	return sampledRowKeys, err
 }
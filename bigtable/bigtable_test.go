@@ -330,6 +330,47 @@ func TestReadRowsRequestStats(t *testing.T) {
 	}
 }
 
+func TestReadRowsReverseScan(t *testing.T) {
+	ctx := context.Background()
+	tbl, cleanup, err := setupFakeServer()
+	if err != nil {
+		t.Fatalf("fake server setup: %v", err)
+	}
+	defer cleanup()
+
+	for _, key := range []string{"a", "b", "c", "d", "e"} {
+		m := NewMutation()
+		m.Set("cf", "col", 1000, []byte(key))
+		if err := tbl.Apply(ctx, key, m); err != nil {
+			t.Fatalf("Apply(%q): %v", key, err)
+		}
+	}
+
+	for _, test := range []struct {
+		desc string
+		rows RowSet
+		opts []ReadOption
+		want []string
+	}{
+		{"all rows", RowRange{}, nil, []string{"e", "d", "c", "b", "a"}},
+		{"range", NewRange("b", "e"), nil, []string{"d", "c", "b"}},
+		{"limit", InfiniteRange("b"), []ReadOption{LimitRows(2)}, []string{"e", "d"}},
+		{"list", RowList{"a", "c", "x"}, nil, []string{"c", "a"}},
+	} {
+		var got []string
+		opts := append([]ReadOption{ReverseScan()}, test.opts...)
+		if err := tbl.ReadRows(ctx, test.rows, func(r Row) bool {
+			got = append(got, r.Key())
+			return true
+		}, opts...); err != nil {
+			t.Fatalf("%s: ReadRows: %v", test.desc, err)
+		}
+		if !cmp.Equal(got, test.want) {
+			t.Errorf("%s: got %v, want %v", test.desc, got, test.want)
+		}
+	}
+}
+
 // TestHeaderPopulatedWithAppProfile verifies that request params header is populated with table name and app profile
 func TestHeaderPopulatedWithAppProfile(t *testing.T) {
 	testEnv, err := NewEmulatedEnv(IntegrationTestConfig{})
@@ -27,6 +27,11 @@ To use a Server, create it, and then connect to it with no security:
 	client, err := bigtable.NewClient(ctx, proj, instance,
 	        option.WithGRPCConn(conn))
 	...
+
+The Server supports every kind of row filter, including sink filters. A read
+with a filter that it does not recognize fails with InvalidArgument rather
+than ignoring the filter. Of the aggregate column families, only those that
+sum int64 inputs are supported.
 */
 package bttest // import "cloud.google.com/go/bigtable/bttest"
 
@@ -299,10 +304,14 @@ func (s *server) ModifyColumnFamilies(ctx context.Context, req *btapb.ModifyColu
 			if _, ok := tbl.families[mod.Id]; ok {
 				return nil, status.Errorf(codes.AlreadyExists, "family %q already exists", mod.Id)
 			}
+			if err := validateValueType(create.ValueType); err != nil {
+				return nil, err
+			}
 			newcf := &columnFamily{
-				name:   req.Name + "/columnFamilies/" + mod.Id,
-				order:  tbl.counter,
-				gcRule: create.GcRule,
+				name:      req.Name + "/columnFamilies/" + mod.Id,
+				order:     tbl.counter,
+				gcRule:    create.GcRule,
+				valueType: create.ValueType,
 			}
 			tbl.counter++
 			tbl.families[mod.Id] = newcf
@@ -321,12 +330,14 @@ func (s *server) ModifyColumnFamilies(ctx context.Context, req *btapb.ModifyColu
 				return true
 			})
 		} else if modify := mod.GetUpdate(); modify != nil {
-			if _, ok := tbl.families[mod.Id]; !ok {
+			cf, ok := tbl.families[mod.Id]
+			if !ok {
 				return nil, fmt.Errorf("no such family %q", mod.Id)
 			}
 			newcf := &columnFamily{
-				name:   req.Name + "/columnFamilies/" + mod.Id,
-				gcRule: modify.GcRule,
+				name:      req.Name + "/columnFamilies/" + mod.Id,
+				gcRule:    modify.GcRule,
+				valueType: cf.valueType,
 			}
 			// assume that we ALWAYS want to replace by the new setting
 			// we may need partial update through
@@ -640,6 +651,27 @@ func streamRow(stream btpb.Bigtable_ReadRowsServer, r *row, f *btpb.RowFilter, s
 // filterRow modifies a row with the given filter. Returns true if at least one cell from the row matches,
 // false otherwise. If a filter is invalid, filterRow returns false and an error.
 func filterRow(f *btpb.RowFilter, r *row) (bool, error) {
+	sunk := newRow(r.key)
+	match, err := applyFilter(f, r, sunk)
+	if err != nil {
+		return false, err
+	}
+	if sunk.isEmpty() {
+		return match, nil
+	}
+	// Cells that reached a sink filter are part of the result, whether or not
+	// the rest of the filter matched.
+	if !match {
+		r.families = make(map[string]*family)
+	}
+	r.mergeCells(sunk)
+	return true, nil
+}
+
+// applyFilter modifies a row with the given filter, like filterRow, and adds
+// the cells that reach a sink filter to sunk. sunk is nil where sink filters
+// are not allowed.
+func applyFilter(f *btpb.RowFilter, r *row, sunk *row) (bool, error) {
 	if f == nil {
 		return true, nil
 	}
@@ -660,7 +692,7 @@ func filterRow(f *btpb.RowFilter, r *row) (bool, error) {
 			return false, status.Errorf(codes.InvalidArgument, "Chain must contain at least two RowFilters")
 		}
 		for _, sub := range f.Chain.Filters {
-			match, err := filterRow(sub, r)
+			match, err := applyFilter(sub, r, sunk)
 			if err != nil {
 				return false, err
 			}
@@ -676,7 +708,7 @@ func filterRow(f *btpb.RowFilter, r *row) (bool, error) {
 		srs := make([]*row, 0, len(f.Interleave.Filters))
 		for _, sub := range f.Interleave.Filters {
 			sr := r.copy()
-			match, err := filterRow(sub, sr)
+			match, err := applyFilter(sub, sr, sunk)
 			if err != nil {
 				return false, err
 			}
@@ -688,21 +720,9 @@ func filterRow(f *btpb.RowFilter, r *row) (bool, error) {
 		// TODO(dsymonds): is this correct?
 		r.families = make(map[string]*family)
 		for _, sr := range srs {
-			for _, fam := range sr.families {
-				f := r.getOrCreateFamily(fam.name, fam.order)
-				for colName, cs := range fam.cells {
-					f.cells[colName] = append(f.cellsByColumn(colName), cs...)
-				}
-			}
-		}
-		var count int
-		for _, fam := range r.families {
-			for _, cs := range fam.cells {
-				sort.Sort(byDescTS(cs))
-				count += len(cs)
-			}
+			r.mergeCells(sr)
 		}
-		return count > 0, nil
+		return !r.isEmpty(), nil
 	case *btpb.RowFilter_CellsPerColumnLimitFilter:
 		lim := int(f.CellsPerColumnLimitFilter)
 		if lim <= 0 {
@@ -717,7 +737,8 @@ func filterRow(f *btpb.RowFilter, r *row) (bool, error) {
 		}
 		return true, nil
 	case *btpb.RowFilter_Condition_:
-		match, err := filterRow(f.Condition.PredicateFilter, r.copy())
+		// Sink filters are not allowed anywhere in a condition.
+		match, err := applyFilter(f.Condition.PredicateFilter, r.copy(), nil)
 		if err != nil {
 			return false, err
 		}
@@ -725,12 +746,23 @@ func filterRow(f *btpb.RowFilter, r *row) (bool, error) {
 			if f.Condition.TrueFilter == nil {
 				return false, nil
 			}
-			return filterRow(f.Condition.TrueFilter, r)
+			return applyFilter(f.Condition.TrueFilter, r, nil)
 		}
 		if f.Condition.FalseFilter == nil {
 			return false, nil
 		}
-		return filterRow(f.Condition.FalseFilter, r)
+		return applyFilter(f.Condition.FalseFilter, r, nil)
+	case *btpb.RowFilter_Sink:
+		if !f.Sink {
+			return false, status.Errorf(codes.InvalidArgument, "sink must be true if set")
+		}
+		if sunk == nil {
+			return false, status.Errorf(codes.InvalidArgument, "sink is not allowed in a condition")
+		}
+		// Send the cells to the output of the read, and none to the parent filter.
+		sunk.mergeCells(r)
+		r.families = make(map[string]*family)
+		return false, nil
 	case *btpb.RowFilter_RowKeyRegexFilter:
 		if len(f.RowKeyRegexFilter) == 0 {
 			return false, status.Errorf(codes.InvalidArgument, "Error in field 'row_key_regex_filter' : argument must not be empty")
@@ -748,7 +780,7 @@ func filterRow(f *btpb.RowFilter, r *row) (bool, error) {
 		if lim <= 0 {
 			return false, status.Errorf(codes.InvalidArgument, "Error in field 'cells_per_row_limit_filter' : argument must be > 0")
 		}
-		for _, fam := range r.families {
+		for _, fam := range r.sortedFamilies() {
 			for _, col := range fam.colNames {
 				cs := fam.cells[col]
 				if len(cs) > lim {
@@ -763,7 +795,10 @@ func filterRow(f *btpb.RowFilter, r *row) (bool, error) {
 	case *btpb.RowFilter_CellsPerRowOffsetFilter:
 		// Skip the first n cells in the row.
 		offset := int(f.CellsPerRowOffsetFilter)
-		for _, fam := range r.families {
+		if offset < 0 {
+			return false, status.Errorf(codes.InvalidArgument, "Error in field 'cells_per_row_offset_filter' : argument must be >= 0")
+		}
+		for _, fam := range r.sortedFamilies() {
 			for _, col := range fam.colNames {
 				cs := fam.cells[col]
 				if len(cs) > offset {
@@ -850,7 +885,6 @@ func includeCell(f *btpb.RowFilter, fam, col string, cell cell) (bool, error) {
 	if f == nil {
 		return true, nil
 	}
-	// TODO(dsymonds): Implement many more filters.
 	switch f := f.Filter.(type) {
 	case *btpb.RowFilter_CellsPerColumnLimitFilter:
 		// Don't log, row-level filter
@@ -865,8 +899,9 @@ func includeCell(f *btpb.RowFilter, fam, col string, cell cell) (bool, error) {
 		// Don't log, cell-modifying filter
 		return true, nil
 	default:
-		log.Printf("WARNING: don't know how to handle filter of type %T (ignoring it)", f)
-		return true, nil
+		// Fail rather than ignore the filter, so that tests don't pass with
+		// results that the service would not return.
+		return false, status.Errorf(codes.InvalidArgument, "unsupported filter of type %T", f)
 	case *btpb.RowFilter_FamilyNameRegexFilter:
 		rx, err := newRegexp([]byte(f.FamilyNameRegexFilter))
 		if err != nil {
@@ -1157,6 +1192,38 @@ func applyMutations(tbl *table, r *row, muts []*btpb.Mutation, fs map[string]*co
 					r.families[fam].cells[col] = cs
 				}
 			}
+		case *btpb.Mutation_AddToCell_:
+			add := mut.AddToCell
+			cf, ok := fs[add.FamilyName]
+			if !ok {
+				return fmt.Errorf("unknown family %q", add.FamilyName)
+			}
+			if cf.valueType == nil {
+				return fmt.Errorf("family %q is not an aggregate family", add.FamilyName)
+			}
+			ts := add.GetTimestamp().GetRawTimestampMicros()
+			if !tbl.validTimestamp(ts) {
+				return fmt.Errorf("invalid timestamp %d", ts)
+			}
+			input, ok := add.GetInput().GetKind().(*btpb.Value_IntValue)
+			if !ok {
+				return fmt.Errorf("AddToCell input must be an int64")
+			}
+			fam := add.FamilyName
+			col := string(add.GetColumnQualifier().GetRawValue())
+
+			f := r.getOrCreateFamily(fam, cf.order)
+			cs := f.cellsByColumn(col)
+			sum := input.IntValue
+			for _, c := range cs {
+				if c.ts == ts {
+					sum += int64(binary.BigEndian.Uint64(c.value))
+					break
+				}
+			}
+			value := make([]byte, 8)
+			binary.BigEndian.PutUint64(value, uint64(sum))
+			f.cells[col] = appendOrReplaceCell(cs, cell{ts: ts, value: value})
 		case *btpb.Mutation_DeleteFromRow_:
 			r.families = make(map[string]*family)
 		case *btpb.Mutation_DeleteFromFamily_:
@@ -1383,9 +1450,10 @@ func newTable(ctr *btapb.CreateTableRequest) *table {
 	if ctr.Table != nil {
 		for id, cf := range ctr.Table.ColumnFamilies {
 			fams[id] = &columnFamily{
-				name:   ctr.Parent + "/columnFamilies/" + id,
-				order:  c,
-				gcRule: cf.GcRule,
+				name:      ctr.Parent + "/columnFamilies/" + id,
+				order:     c,
+				gcRule:    cf.GcRule,
+				valueType: cf.ValueType,
 			}
 			c++
 		}
@@ -1493,7 +1561,7 @@ func (r *row) copy() *row {
 		nr.families[fam.name] = &family{
 			name:     fam.name,
 			order:    fam.order,
-			colNames: fam.colNames,
+			colNames: append([]string(nil), fam.colNames...),
 			cells:    make(map[string][]cell),
 		}
 		for col, cs := range fam.cells {
@@ -1518,6 +1586,22 @@ func (r *row) isEmpty() bool {
 
 // sortedFamilies returns a column family set
 // sorted in ascending creation order in a row.
+// mergeCells adds the cells of src to r, keeping the cells of each column in
+// descending timestamp order. Cell values are aliased.
+func (r *row) mergeCells(src *row) {
+	for _, fam := range src.families {
+		f := r.getOrCreateFamily(fam.name, fam.order)
+		for colName, cs := range fam.cells {
+			if len(cs) == 0 {
+				continue
+			}
+			merged := append(f.cellsByColumn(colName), cs...)
+			sort.Sort(byDescTS(merged))
+			f.cells[colName] = merged
+		}
+	}
+}
+
 func (r *row) sortedFamilies() []*family {
 	var families []*family
 	for _, fam := range r.families {
@@ -1650,17 +1734,32 @@ func (b byDescTS) Swap(i, j int)      { b[i], b[j] = b[j], b[i] }
 func (b byDescTS) Less(i, j int) bool { return b[i].ts > b[j].ts }
 
 type columnFamily struct {
-	name   string
-	order  uint64 // Creation order of column family
-	gcRule *btapb.GcRule
+	name      string
+	order     uint64 // Creation order of column family
+	gcRule    *btapb.GcRule
+	valueType *btapb.Type // nil for an ordinary family
 }
 
 func (c *columnFamily) proto() *btapb.ColumnFamily {
 	return &btapb.ColumnFamily{
-		GcRule: c.gcRule,
+		GcRule:    c.gcRule,
+		ValueType: c.valueType,
 	}
 }
 
+// validateValueType reports whether the Server supports a column family
+// with value type t.
+func validateValueType(t *btapb.Type) error {
+	if t == nil {
+		return nil
+	}
+	agg := t.GetAggregateType()
+	if agg.GetSum() == nil || agg.GetInputType().GetInt64Type().GetEncoding().GetBigEndianBytes() == nil {
+		return status.Errorf(codes.Unimplemented, "unsupported column family value type %v", t)
+	}
+	return nil
+}
+
 func toColumnFamilies(families map[string]*columnFamily) map[string]*btapb.ColumnFamily {
 	fs := make(map[string]*btapb.ColumnFamily)
 	for k, v := range families {
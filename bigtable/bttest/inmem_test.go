@@ -17,6 +17,7 @@ package bttest
 import (
 	"bytes"
 	"context"
+	"encoding/binary"
 	"fmt"
 	"math"
 	"math/rand"
@@ -667,6 +668,79 @@ func TestCheckTimestampMaxValue(t *testing.T) {
 	}
 }
 
+func TestAddToCell(t *testing.T) {
+	ctx := context.Background()
+	s := &server{
+		tables: make(map[string]*table),
+	}
+	int64Type := &btapb.Type{Kind: &btapb.Type_Int64Type{Int64Type: &btapb.Type_Int64{
+		Encoding: &btapb.Type_Int64_Encoding{Encoding: &btapb.Type_Int64_Encoding_BigEndianBytes_{
+			BigEndianBytes: &btapb.Type_Int64_Encoding_BigEndianBytes{},
+		}},
+	}}}
+	sumType := &btapb.Type{Kind: &btapb.Type_AggregateType{AggregateType: &btapb.Type_Aggregate{
+		InputType:  int64Type,
+		Aggregator: &btapb.Type_Aggregate_Sum_{Sum: &btapb.Type_Aggregate_Sum{}},
+	}}}
+	newTbl := btapb.Table{
+		ColumnFamilies: map[string]*btapb.ColumnFamily{
+			"cf0": {},
+			"sum": {ValueType: sumType},
+		},
+	}
+	tblInfo, err := s.CreateTable(ctx, &btapb.CreateTableRequest{Parent: "cluster", TableId: "t", Table: &newTbl})
+	if err != nil {
+		t.Fatalf("Creating table: %v", err)
+	}
+	if got := tblInfo.ColumnFamilies["sum"].GetValueType(); !proto.Equal(got, sumType) {
+		t.Errorf("got value type %v, want %v", got, sumType)
+	}
+
+	addToCell := func(fam string, ts, n int64) error {
+		_, err := s.MutateRow(ctx, &btpb.MutateRowRequest{
+			TableName: tblInfo.Name,
+			RowKey:    []byte("row"),
+			Mutations: []*btpb.Mutation{{
+				Mutation: &btpb.Mutation_AddToCell_{AddToCell: &btpb.Mutation_AddToCell{
+					FamilyName:      fam,
+					ColumnQualifier: &btpb.Value{Kind: &btpb.Value_RawValue{RawValue: []byte("col")}},
+					Timestamp:       &btpb.Value{Kind: &btpb.Value_RawTimestampMicros{RawTimestampMicros: ts}},
+					Input:           &btpb.Value{Kind: &btpb.Value_IntValue{IntValue: n}},
+				}},
+			}},
+		})
+		return err
+	}
+	for _, n := range []int64{1, 2, 39} {
+		if err := addToCell("sum", 1000, n); err != nil {
+			t.Fatalf("AddToCell(%d): %v", n, err)
+		}
+	}
+	if err := addToCell("sum", 2000, -7); err != nil {
+		t.Fatalf("AddToCell: %v", err)
+	}
+	if err := addToCell("cf0", 1000, 1); err == nil {
+		t.Error("AddToCell to an ordinary family: got nil, want error")
+	}
+	if err := addToCell("sum", 1001, 1); err == nil {
+		t.Error("AddToCell with an invalid timestamp: got nil, want error")
+	}
+
+	mock := &MockReadRowsServer{}
+	if err := s.ReadRows(&btpb.ReadRowsRequest{TableName: tblInfo.Name}, mock); err != nil {
+		t.Fatalf("ReadRows: %v", err)
+	}
+	var got []int64
+	for _, resp := range mock.responses {
+		for _, c := range resp.Chunks {
+			got = append(got, int64(binary.BigEndian.Uint64(c.Value)))
+		}
+	}
+	if want := []int64{-7, 42}; !testutil.Equal(got, want) {
+		t.Errorf("got sums %v, want %v", got, want)
+	}
+}
+
 func TestReadRows(t *testing.T) {
 	ctx := context.Background()
 	s := &server{
@@ -1676,8 +1750,8 @@ func TestFilters(t *testing.T) {
 		code codes.Code
 		out  int
 	}{
-		{in: &btpb.RowFilter{Filter: &btpb.RowFilter_BlockAllFilter{true}}, out: 0},
-		{in: &btpb.RowFilter{Filter: &btpb.RowFilter_BlockAllFilter{false}}, code: codes.InvalidArgument},
+		{in: &btpb.RowFilter{Filter: &btpb.RowFilter_BlockAllFilter{BlockAllFilter: true}}, out: 0},
+		{in: &btpb.RowFilter{Filter: &btpb.RowFilter_BlockAllFilter{BlockAllFilter: false}}, code: codes.InvalidArgument},
 		{in: &btpb.RowFilter{Filter: &btpb.RowFilter_PassAllFilter{true}}, out: 1},
 		{in: &btpb.RowFilter{Filter: &btpb.RowFilter_PassAllFilter{false}}, code: codes.InvalidArgument},
 	}
@@ -2303,3 +2377,144 @@ func TestFilterRowCellsPerRowLimitFilterTruthiness(t *testing.T) {
 		}
 	}
 }
+
+// rowCells returns the cells of a row as "family:column@ts[labels]" strings,
+// sorted.
+func rowCells(r *row) []string {
+	var cells []string
+	for _, fam := range r.families {
+		for col, cs := range fam.cells {
+			for _, c := range cs {
+				cells = append(cells, fmt.Sprintf("%s:%s@%d%v", fam.name, col, c.ts, c.labels))
+			}
+		}
+	}
+	sort.Strings(cells)
+	return cells
+}
+
+func TestFilterRowSink(t *testing.T) {
+	row := &row{
+		key: "row",
+		families: map[string]*family{
+			"A": {
+				name:  "A",
+				order: 1,
+				cells: map[string][]cell{
+					"A": {{ts: 1000, value: []byte("w")}},
+					"B": {{ts: 2000, value: []byte("x")}},
+				},
+				colNames: []string{"A", "B"},
+			},
+			"B": {
+				name:  "B",
+				order: 2,
+				cells: map[string][]cell{
+					"B": {{ts: 4000, value: []byte("z")}},
+				},
+				colNames: []string{"B"},
+			},
+		},
+	}
+	chain := func(fs ...*btpb.RowFilter) *btpb.RowFilter {
+		return &btpb.RowFilter{Filter: &btpb.RowFilter_Chain_{Chain: &btpb.RowFilter_Chain{Filters: fs}}}
+	}
+	sink := &btpb.RowFilter{Filter: &btpb.RowFilter_Sink{Sink: true}}
+	label := &btpb.RowFilter{Filter: &btpb.RowFilter_ApplyLabelTransformer{ApplyLabelTransformer: "foo"}}
+
+	// The example from the documentation of RowFilter.sink.
+	f := chain(
+		&btpb.RowFilter{Filter: &btpb.RowFilter_FamilyNameRegexFilter{FamilyNameRegexFilter: "A"}},
+		&btpb.RowFilter{Filter: &btpb.RowFilter_Interleave_{Interleave: &btpb.RowFilter_Interleave{Filters: []*btpb.RowFilter{
+			{Filter: &btpb.RowFilter_PassAllFilter{PassAllFilter: true}},
+			chain(label, sink),
+		}}}},
+		&btpb.RowFilter{Filter: &btpb.RowFilter_ColumnQualifierRegexFilter{ColumnQualifierRegexFilter: []byte("B")}},
+	)
+	r := row.copy()
+	match, err := filterRow(f, r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"A:A@1000[foo]", "A:B@2000[]", "A:B@2000[foo]"}
+	if got := rowCells(r); !match || !cmp.Equal(got, want) {
+		t.Errorf("got %t, %v; want true, %v", match, got, want)
+	}
+
+	// Cells that reach a sink are returned even if the rest of the chain
+	// blocks them.
+	r = row.copy()
+	match, err = filterRow(chain(sink, &btpb.RowFilter{Filter: &btpb.RowFilter_BlockAllFilter{BlockAllFilter: true}}), r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want = []string{"A:A@1000[]", "A:B@2000[]", "B:B@4000[]"}
+	if got := rowCells(r); !match || !cmp.Equal(got, want) {
+		t.Errorf("sink then block: got %t, %v; want true, %v", match, got, want)
+	}
+
+	cond := &btpb.RowFilter{Filter: &btpb.RowFilter_Condition_{Condition: &btpb.RowFilter_Condition{
+		PredicateFilter: &btpb.RowFilter{Filter: &btpb.RowFilter_PassAllFilter{PassAllFilter: true}},
+		TrueFilter:      chain(label, sink),
+	}}}
+	if _, err := filterRow(cond, row.copy()); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("sink in condition: got error %v, want InvalidArgument", err)
+	}
+}
+
+func TestFilterRowUnsupportedFilter(t *testing.T) {
+	row := &row{
+		key: "row",
+		families: map[string]*family{
+			"fam": {
+				name:     "fam",
+				cells:    map[string][]cell{"col": {{ts: 1000, value: []byte("val")}}},
+				colNames: []string{"col"},
+			},
+		},
+	}
+	if _, err := filterRow(&btpb.RowFilter{}, row.copy()); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("got error %v, want InvalidArgument", err)
+	}
+}
+
+func TestFilterRowCellsPerRowInFamilyOrder(t *testing.T) {
+	row := &row{
+		key: "row",
+		families: map[string]*family{
+			"b": {
+				name:     "b",
+				order:    1,
+				cells:    map[string][]cell{"col": {{ts: 1000, value: []byte("b")}}},
+				colNames: []string{"col"},
+			},
+			"a": {
+				name:     "a",
+				order:    2,
+				cells:    map[string][]cell{"col": {{ts: 1000, value: []byte("a")}}},
+				colNames: []string{"col"},
+			},
+		},
+	}
+	for _, test := range []struct {
+		filter *btpb.RowFilter
+		want   []string
+	}{
+		{&btpb.RowFilter{Filter: &btpb.RowFilter_CellsPerRowLimitFilter{CellsPerRowLimitFilter: 1}}, []string{"b:col@1000[]"}},
+		{&btpb.RowFilter{Filter: &btpb.RowFilter_CellsPerRowOffsetFilter{CellsPerRowOffsetFilter: 1}}, []string{"a:col@1000[]"}},
+	} {
+		// Map iteration order is random, so try several times.
+		for i := 0; i < 20; i++ {
+			r := row.copy()
+			if _, err := filterRow(test.filter, r); err != nil {
+				t.Fatal(err)
+			}
+			if got := rowCells(r); !cmp.Equal(got, test.want) {
+				t.Fatalf("%s: got %v, want %v", proto.CompactTextString(test.filter), got, test.want)
+			}
+		}
+	}
+	if _, err := filterRow(&btpb.RowFilter{Filter: &btpb.RowFilter_CellsPerRowOffsetFilter{CellsPerRowOffsetFilter: -1}}, row.copy()); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("negative offset: got error %v, want InvalidArgument", err)
+	}
+}
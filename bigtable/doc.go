@@ -75,6 +75,30 @@ For instance, to set a couple of cells in a table:
 		// TODO: handle err.
 	}
 
+To write the fields of a struct, tag them with their columns and use SetStruct.
+Row.ToStruct reads them back:
+
+	type User struct {
+		Name   string `bigtable:"profile:name"`
+		Visits int64  `bigtable:"stats:visits"`
+	}
+	mut = bigtable.NewMutation()
+	if err := mut.SetStruct(bigtable.Now(), User{Name: "alice", Visits: 1}); err != nil {
+		// TODO: handle err.
+	}
+
+To write a stream of rows, add them to a MutationBatcher, which applies them
+in batches in the background:
+
+	b := tbl.NewMutationBatcher(ctx, bigtable.MutationBatcherConfig{})
+	defer b.Close()
+	err = b.Add(ctx, "com.google.cloud", mut, func(err error) {
+		// TODO: handle err, which is nil if the row was written.
+	})
+	if err != nil {
+		// TODO: handle err.
+	}
+
 To increment an encoded value in one cell:
 
 	tbl := client.Open("mytable")
@@ -93,6 +117,27 @@ until a successful response, an unretryable error or the context deadline is
 reached. Non-idempotent writes (where the timestamp is set to ServerTime) will
 not be retried. In the case of ReadRows, retried calls will not re-scan rows
 that have already been processed.
+
+# Client-side metrics
+
+To see how long operations take and how often they are retried, set the
+MeterProvider of the ClientConfig to an OpenTelemetry MeterProvider. The
+client then records these metrics, under the meter
+"cloud.google.com/go/bigtable", for each data operation:
+
+  - operation_latencies: the total latency of an operation, in milliseconds
+  - attempt_latencies: the latency of each attempt
+  - server_latencies: the latency of each attempt measured by the Google front end
+  - first_response_latencies: the latency of the first response of ReadRows
+  - application_blocking_latencies: the time ReadRows waits for the function
+    that processes rows
+  - retry_count: the number of attempts of an operation after the first
+  - connectivity_error_count: the number of failed attempts that did not
+    reach the Google front end
+
+Measurements have the attributes project_id, instance, app_profile, table,
+method, streaming and client_name and, except for
+application_blocking_latencies, status, cluster and zone.
 */
 package bigtable // import "cloud.google.com/go/bigtable"
 
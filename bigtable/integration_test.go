@@ -370,6 +370,69 @@ func TestIntegration_ReadModifyWrite(t *testing.T) {
 	}
 }
 
+func TestIntegration_AggregateFamily(t *testing.T) {
+	ctx := context.Background()
+	testEnv, _, adminClient, table, tableName, cleanup, err := setupIntegration(ctx, t)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer cleanup()
+
+	if err := adminClient.CreateColumnFamilyWithConfig(ctx, tableName, "sum", Family{Aggregate: SumAggregate}); err != nil {
+		t.Fatalf("Creating column family: %v", err)
+	}
+	ti, err := adminClient.TableInfo(ctx, tableName)
+	if err != nil {
+		t.Fatalf("Getting table info: %v", err)
+	}
+	for _, fi := range ti.FamilyInfos {
+		want := NoAggregate
+		if fi.Name == "sum" {
+			want = SumAggregate
+		}
+		if fi.Aggregate != want {
+			t.Errorf("family %q: got aggregate %v, want %v", fi.Name, fi.Aggregate, want)
+		}
+	}
+
+	for _, n := range []int64{5, -2, 14} {
+		mut := NewMutation()
+		mut.AddIntToCell("sum", "likes", 1000, n)
+		if err := table.Apply(ctx, "gwashington", mut); err != nil {
+			t.Fatalf("Apply AddIntToCell(%d): %v", n, err)
+		}
+		verifyDirectPathRemoteAddress(testEnv, t)
+	}
+	mut := NewMutation()
+	mut.AddIntToCell("sum", "likes", 2000, 3)
+	if err := table.Apply(ctx, "gwashington", mut); err != nil {
+		t.Fatalf("Apply AddIntToCell: %v", err)
+	}
+
+	row, err := table.ReadRow(ctx, "gwashington")
+	if err != nil {
+		t.Fatalf("Reading row: %v", err)
+	}
+	var got []int64
+	for _, ri := range row["sum"] {
+		n, err := ri.Int64()
+		if err != nil {
+			t.Fatal(err)
+		}
+		got = append(got, n)
+	}
+	if want := []int64{3, 17}; !testutil.Equal(got, want) {
+		t.Errorf("got sums %v, want %v", got, want)
+	}
+
+	// AddIntToCell is rejected by ordinary families.
+	mut = NewMutation()
+	mut.AddIntToCell("follows", "likes", 1000, 1)
+	if err := table.Apply(ctx, "gwashington", mut); err == nil {
+		t.Error("AddIntToCell to an ordinary family: got nil, want error")
+	}
+}
+
 func TestIntegration_ArbitraryTimestamps(t *testing.T) {
 	ctx := context.Background()
 	_, _, adminClient, table, tableName, cleanup, err := setupIntegration(ctx, t)
@@ -0,0 +1,287 @@
+/*
+Copyright 2023 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bigtable
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+
+	"cloud.google.com/go/bigtable/internal"
+	"github.com/golang/protobuf/proto"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	btpb "google.golang.org/genproto/googleapis/bigtable/v2"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// The client-side metrics recorded when ClientConfig.MeterProvider is set.
+// Their names, units and attributes match the built-in metrics of the Java
+// client.
+const (
+	metricsMeterName = "cloud.google.com/go/bigtable"
+
+	metricOperationLatencies           = "operation_latencies"
+	metricAttemptLatencies             = "attempt_latencies"
+	metricServerLatencies              = "server_latencies"
+	metricFirstResponseLatencies       = "first_response_latencies"
+	metricApplicationBlockingLatencies = "application_blocking_latencies"
+	metricRetryCount                   = "retry_count"
+	metricConnectivityErrorCount       = "connectivity_error_count"
+)
+
+const (
+	// serverTimingHeader holds the latency measured by the Google front end,
+	// as "gfet4t7; dur=<milliseconds>".
+	serverTimingHeader = "server-timing"
+
+	// responseParamsHeader holds a serialized ResponseParams with the cluster
+	// and zone that served the request.
+	responseParamsHeader = "x-goog-ext-425905942-bin"
+
+	// The cluster and zone attributes used when the response does not say.
+	defaultCluster = "unspecified"
+	defaultZone    = "global"
+)
+
+// builtinMetrics holds the instruments that client-side metrics are recorded
+// with. A nil *builtinMetrics records nothing.
+type builtinMetrics struct {
+	attrs []attribute.KeyValue // attributes common to all measurements
+
+	operationLatencies           metric.Float64Histogram
+	attemptLatencies             metric.Float64Histogram
+	serverLatencies              metric.Float64Histogram
+	firstResponseLatencies       metric.Float64Histogram
+	applicationBlockingLatencies metric.Float64Histogram
+	retryCount                   metric.Int64Counter
+	connectivityErrorCount       metric.Int64Counter
+}
+
+func newBuiltinMetrics(mp metric.MeterProvider, project, instance, appProfile string) (*builtinMetrics, error) {
+	meter := mp.Meter(metricsMeterName, metric.WithInstrumentationVersion(internal.Version))
+	m := &builtinMetrics{
+		attrs: []attribute.KeyValue{
+			attribute.String("project_id", project),
+			attribute.String("instance", instance),
+			attribute.String("app_profile", appProfile),
+			attribute.String("client_name", clientUserAgent),
+		},
+	}
+	var err error
+	histogram := func(name, desc string) metric.Float64Histogram {
+		if err != nil {
+			return nil
+		}
+		var h metric.Float64Histogram
+		h, err = meter.Float64Histogram(name, metric.WithDescription(desc), metric.WithUnit("ms"))
+		return h
+	}
+	counter := func(name, desc string) metric.Int64Counter {
+		if err != nil {
+			return nil
+		}
+		var c metric.Int64Counter
+		c, err = meter.Int64Counter(name, metric.WithDescription(desc), metric.WithUnit("1"))
+		return c
+	}
+	m.operationLatencies = histogram(metricOperationLatencies,
+		"The total latency of an operation, including all of its attempts.")
+	m.attemptLatencies = histogram(metricAttemptLatencies,
+		"The latency of each attempt of an operation.")
+	m.serverLatencies = histogram(metricServerLatencies,
+		"The latency of each attempt measured by the Google front end.")
+	m.firstResponseLatencies = histogram(metricFirstResponseLatencies,
+		"The latency from the start of a ReadRows operation to its first response.")
+	m.applicationBlockingLatencies = histogram(metricApplicationBlockingLatencies,
+		"The time that a ReadRows operation spends waiting for the application to process rows.")
+	m.retryCount = counter(metricRetryCount,
+		"The number of attempts of an operation after the first.")
+	m.connectivityErrorCount = counter(metricConnectivityErrorCount,
+		"The number of failed attempts that did not reach the Google front end.")
+	if err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// newOpTracer returns an opTracer for an operation of the given method on a
+// table, or nil if metrics are disabled.
+func (m *builtinMetrics) newOpTracer(ctx context.Context, table, method string, streaming bool) *opTracer {
+	if m == nil {
+		return nil
+	}
+	return &opTracer{
+		m:     m,
+		ctx:   ctx,
+		start: time.Now(),
+		attrs: append(m.attrs[:len(m.attrs):len(m.attrs)],
+			attribute.String("table", table),
+			attribute.String("method", "Bigtable."+method),
+			attribute.Bool("streaming", streaming),
+		),
+		cluster: defaultCluster,
+		zone:    defaultZone,
+	}
+}
+
+// opTracer records the metrics of one operation, which is made of one or more
+// attempts. All of its methods may be called on a nil *opTracer, and then do
+// nothing.
+type opTracer struct {
+	m     *builtinMetrics
+	ctx   context.Context
+	attrs []attribute.KeyValue
+	start time.Time
+
+	attempts      int
+	attemptStart  time.Time
+	header        metadata.MD // of the current attempt
+	trailer       metadata.MD // of the current attempt
+	firstResponse time.Duration
+	blocking      time.Duration
+
+	// The cluster and zone that served the last attempt.
+	cluster, zone string
+}
+
+// callOptions returns the options to pass to each RPC of an attempt, so that
+// the tracer can read its response metadata.
+func (t *opTracer) callOptions() []grpc.CallOption {
+	if t == nil {
+		return nil
+	}
+	return []grpc.CallOption{grpc.Header(&t.header), grpc.Trailer(&t.trailer)}
+}
+
+// attemptStarted is called at the start of each attempt.
+func (t *opTracer) attemptStarted() {
+	if t == nil {
+		return
+	}
+	t.attempts++
+	t.attemptStart = time.Now()
+	t.header, t.trailer = nil, nil
+}
+
+// responseReceived is called when a response of a streaming operation is
+// received.
+func (t *opTracer) responseReceived() {
+	if t == nil || t.firstResponse != 0 {
+		return
+	}
+	t.firstResponse = time.Since(t.start)
+}
+
+// applicationBlocked is called with the time spent in a callback of the
+// application.
+func (t *opTracer) applicationBlocked(d time.Duration) {
+	if t == nil {
+		return
+	}
+	t.blocking += d
+}
+
+// attemptDone is called at the end of each attempt, with its error.
+func (t *opTracer) attemptDone(err error) {
+	if t == nil {
+		return
+	}
+	if params, ok := responseParams(t.header, t.trailer); ok {
+		if params.GetClusterId() != "" {
+			t.cluster = params.GetClusterId()
+		}
+		if params.GetZoneId() != "" {
+			t.zone = params.GetZoneId()
+		}
+	}
+	opt := metric.WithAttributes(t.resultAttrs(err)...)
+	t.m.attemptLatencies.Record(t.ctx, millis(time.Since(t.attemptStart)), opt)
+	if d, ok := serverLatency(t.header); ok {
+		t.m.serverLatencies.Record(t.ctx, millis(d), opt)
+	} else if err != nil {
+		// Without a server-timing header, the attempt failed before it got to
+		// the Google front end.
+		t.m.connectivityErrorCount.Add(t.ctx, 1, opt)
+	}
+}
+
+// done is called once the operation ends, with its error. Operations that
+// end before their first attempt are not recorded.
+func (t *opTracer) done(err error) {
+	if t == nil || t.attempts == 0 {
+		return
+	}
+	opt := metric.WithAttributes(t.resultAttrs(err)...)
+	t.m.operationLatencies.Record(t.ctx, millis(time.Since(t.start)), opt)
+	t.m.retryCount.Add(t.ctx, int64(t.attempts-1), opt)
+	if t.firstResponse != 0 {
+		t.m.firstResponseLatencies.Record(t.ctx, millis(t.firstResponse), opt)
+	}
+	if t.blocking != 0 {
+		t.m.applicationBlockingLatencies.Record(t.ctx, millis(t.blocking), metric.WithAttributes(t.attrs...))
+	}
+}
+
+// resultAttrs returns the attributes of the outcome of an attempt or
+// operation.
+func (t *opTracer) resultAttrs(err error) []attribute.KeyValue {
+	return append(t.attrs[:len(t.attrs):len(t.attrs)],
+		attribute.String("status", status.Code(err).String()),
+		attribute.String("cluster", t.cluster),
+		attribute.String("zone", t.zone),
+	)
+}
+
+// serverLatency returns the latency in the server-timing header, if any.
+func serverLatency(header metadata.MD) (time.Duration, bool) {
+	for _, v := range header.Get(serverTimingHeader) {
+		_, dur, ok := strings.Cut(v, "dur=")
+		if !ok {
+			continue
+		}
+		if end := strings.IndexAny(dur, ",;"); end >= 0 {
+			dur = dur[:end]
+		}
+		ms, err := strconv.ParseFloat(strings.TrimSpace(dur), 64)
+		if err != nil {
+			continue
+		}
+		return time.Duration(ms * float64(time.Millisecond)), true
+	}
+	return 0, false
+}
+
+// responseParams returns the ResponseParams in the response metadata, if any.
+func responseParams(mds ...metadata.MD) (*btpb.ResponseParams, bool) {
+	for _, md := range mds {
+		for _, v := range md.Get(responseParamsHeader) {
+			var params btpb.ResponseParams
+			if err := proto.Unmarshal([]byte(v), &params); err == nil {
+				return &params, true
+			}
+		}
+	}
+	return nil, false
+}
+
+func millis(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
@@ -0,0 +1,233 @@
+/*
+Copyright 2023 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bigtable
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/bigtable/bttest"
+	"github.com/golang/protobuf/proto"
+	"go.opentelemetry.io/otel/attribute"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"google.golang.org/api/option"
+	btpb "google.golang.org/genproto/googleapis/bigtable/v2"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestBuiltinMetrics(t *testing.T) {
+	ctx := context.Background()
+
+	// Fail the first two MutateRow attempts before they reach the "front end",
+	// then succeed with the headers that the front end sets.
+	mutateRowCalls := 0
+	interceptor := func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if !strings.HasSuffix(info.FullMethod, "MutateRow") {
+			return handler(ctx, req)
+		}
+		mutateRowCalls++
+		if mutateRowCalls < 3 {
+			return nil, status.Error(codes.Unavailable, "")
+		}
+		params, err := proto.Marshal(&btpb.ResponseParams{ClusterId: proto.String("c1"), ZoneId: proto.String("z1")})
+		if err != nil {
+			return nil, err
+		}
+		if err := grpc.SetHeader(ctx, metadata.Pairs(
+			serverTimingHeader, "gfet4t7; dur=12.5",
+			responseParamsHeader, string(params),
+		)); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+	srv, err := bttest.NewServer("localhost:0", grpc.UnaryInterceptor(interceptor))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer srv.Close()
+	conn, err := grpc.Dial(srv.Addr, grpc.WithInsecure(), grpc.WithBlock())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	adminClient, err := NewAdminClient(ctx, "proj", "inst", option.WithGRPCConn(conn))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer adminClient.Close()
+	if err := adminClient.CreateTable(ctx, "table"); err != nil {
+		t.Fatal(err)
+	}
+	if err := adminClient.CreateColumnFamily(ctx, "table", "cf"); err != nil {
+		t.Fatal(err)
+	}
+
+	reader := sdkmetric.NewManualReader()
+	config := ClientConfig{
+		AppProfile:    "profile",
+		MeterProvider: sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader)),
+	}
+	client, err := NewClientWithConfig(ctx, "proj", "inst", config, option.WithGRPCConn(conn))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+	tbl := client.Open("table")
+
+	mut := NewMutation()
+	mut.Set("cf", "col", 1000, []byte("val"))
+	if err := tbl.Apply(ctx, "row", mut); err != nil {
+		t.Fatalf("Apply: %v", err)
+	}
+	if err := tbl.ReadRows(ctx, RowRange{}, func(Row) bool {
+		time.Sleep(time.Millisecond)
+		return true
+	}); err != nil {
+		t.Fatalf("ReadRows: %v", err)
+	}
+
+	var rm metricdata.ResourceMetrics
+	if err := reader.Collect(ctx, &rm); err != nil {
+		t.Fatal(err)
+	}
+	metrics := map[string]metricdata.Aggregation{}
+	for _, sm := range rm.ScopeMetrics {
+		if sm.Scope.Name != metricsMeterName {
+			t.Errorf("got scope %q, want %q", sm.Scope.Name, metricsMeterName)
+		}
+		for _, m := range sm.Metrics {
+			metrics[m.Name] = m.Data
+		}
+	}
+
+	// matches reports whether attributes are those of the method and status.
+	// An empty status matches points without one.
+	matches := func(attrs attribute.Set, method, code string) bool {
+		m, _ := attrs.Value("method")
+		s, ok := attrs.Value("status")
+		return m.AsString() == method && (code == "" && !ok || s.AsString() == code)
+	}
+	// counts returns the total count, or sum for counters, of the points of a
+	// metric for the method and status.
+	counts := func(name, method, code string) int64 {
+		var n int64
+		switch data := metrics[name].(type) {
+		case metricdata.Histogram[float64]:
+			for _, dp := range data.DataPoints {
+				if matches(dp.Attributes, method, code) {
+					n += int64(dp.Count)
+				}
+			}
+		case metricdata.Sum[int64]:
+			for _, dp := range data.DataPoints {
+				if matches(dp.Attributes, method, code) {
+					n += dp.Value
+				}
+			}
+		default:
+			t.Fatalf("metric %s: got %T", name, data)
+		}
+		return n
+	}
+	for _, test := range []struct {
+		name, method, code string
+		want               int64
+	}{
+		{metricOperationLatencies, "Bigtable.MutateRow", "OK", 1},
+		{metricAttemptLatencies, "Bigtable.MutateRow", "Unavailable", 2},
+		{metricAttemptLatencies, "Bigtable.MutateRow", "OK", 1},
+		{metricConnectivityErrorCount, "Bigtable.MutateRow", "Unavailable", 2},
+		{metricServerLatencies, "Bigtable.MutateRow", "OK", 1},
+		{metricRetryCount, "Bigtable.MutateRow", "OK", 2},
+		{metricOperationLatencies, "Bigtable.ReadRows", "OK", 1},
+		{metricFirstResponseLatencies, "Bigtable.ReadRows", "OK", 1},
+		{metricApplicationBlockingLatencies, "Bigtable.ReadRows", "", 1},
+	} {
+		if got := counts(test.name, test.method, test.code); got != test.want {
+			t.Errorf("%s for %s with status %q: got %d, want %d", test.name, test.method, test.code, got, test.want)
+		}
+	}
+
+	// The successful MutateRow attempt has the cluster and zone of the
+	// response, and common attributes.
+	for _, dp := range metrics[metricServerLatencies].(metricdata.Histogram[float64]).DataPoints {
+		for k, want := range map[attribute.Key]string{
+			"cluster":     "c1",
+			"zone":        "z1",
+			"app_profile": "profile",
+			"table":       "table",
+			"project_id":  "proj",
+			"instance":    "inst",
+			"streaming":   "false",
+		} {
+			if got, _ := dp.Attributes.Value(k); got.Emit() != want {
+				t.Errorf("server_latencies attribute %s: got %q, want %q", k, got.Emit(), want)
+			}
+		}
+		if dp.Sum != 12.5 {
+			t.Errorf("server_latencies: got sum %v, want 12.5", dp.Sum)
+		}
+	}
+}
+
+func TestBuiltinMetricsDisabled(t *testing.T) {
+	var m *builtinMetrics
+	mt := m.newOpTracer(context.Background(), "table", "ReadRows", true)
+	if mt != nil {
+		t.Fatalf("got %v, want nil", mt)
+	}
+	// None of these should panic.
+	mt.attemptStarted()
+	mt.responseReceived()
+	mt.applicationBlocked(time.Second)
+	mt.attemptDone(nil)
+	mt.done(nil)
+	if opts := mt.callOptions(); opts != nil {
+		t.Errorf("callOptions: got %v, want nil", opts)
+	}
+}
+
+func TestServerLatency(t *testing.T) {
+	for _, test := range []struct {
+		header []string
+		want   time.Duration
+		ok     bool
+	}{
+		{nil, 0, false},
+		{[]string{"gfet4t7; dur=12"}, 12 * time.Millisecond, true},
+		{[]string{"gfet4t7; dur=0.5"}, 500 * time.Microsecond, true},
+		{[]string{"gfet4t7; dur=7, other; dur=1"}, 7 * time.Millisecond, true},
+		{[]string{"gfet4t7; dur=x"}, 0, false},
+	} {
+		md := metadata.MD{}
+		if test.header != nil {
+			md.Set(serverTimingHeader, test.header...)
+		}
+		got, ok := serverLatency(md)
+		if got != test.want || ok != test.ok {
+			t.Errorf("%q: got %v, %t, want %v, %t", test.header, got, ok, test.want, test.ok)
+		}
+	}
+}
@@ -18,6 +18,7 @@ package bigtable
 
 import (
 	"bytes"
+	"encoding/binary"
 	"fmt"
 
 	btpb "google.golang.org/genproto/googleapis/bigtable/v2"
@@ -46,6 +47,15 @@ type ReadItem struct {
 	Labels      []string
 }
 
+// Int64 returns the value of a cell of an aggregate family, which holds an
+// int64 as 8 big-endian bytes.
+func (ri ReadItem) Int64() (int64, error) {
+	if len(ri.Value) != 8 {
+		return 0, fmt.Errorf("bigtable: cell %s of row %q has %d bytes, want 8 for an int64", ri.Column, ri.Row, len(ri.Value))
+	}
+	return int64(binary.BigEndian.Uint64(ri.Value)), nil
+}
+
 // The current state of the read rows state machine.
 type rrState int64
 
@@ -67,6 +77,7 @@ type chunkReader struct {
 	curVal    []byte
 	curRow    Row
 	lastKey   string
+	reversed  bool // rows are in descending order of key
 }
 
 // newChunkReader returns a new chunkReader for handling read rows responses.
@@ -200,7 +211,7 @@ func (cr *chunkReader) validateNewRow(cc *btpb.ReadRowsResponse_CellChunk) error
 	if cc.RowKey == nil || cc.FamilyName == nil || cc.Qualifier == nil {
 		return fmt.Errorf("missing key field for new row %v", cc)
 	}
-	if cr.lastKey != "" && cr.lastKey >= string(cc.RowKey) {
+	if cr.lastKey != "" && (!cr.reversed && cr.lastKey >= string(cc.RowKey) || cr.reversed && cr.lastKey <= string(cc.RowKey)) {
 		return fmt.Errorf("out of order row key: %q, %q", cr.lastKey, string(cc.RowKey))
 	}
 	return nil
@@ -0,0 +1,269 @@
+/*
+Copyright 2023 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bigtable
+
+import (
+	"encoding"
+	"encoding/binary"
+	"fmt"
+	"math"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+)
+
+// structField is a field of a struct that is mapped to a column with a
+// `bigtable:"family:qualifier"` tag.
+type structField struct {
+	name      string
+	index     []int
+	family    string
+	qualifier string
+}
+
+// column returns the column name of the field, as in ReadItem.Column.
+func (f structField) column() string { return f.family + ":" + f.qualifier }
+
+var structFieldsCache sync.Map // map[reflect.Type][]structField
+
+// structFields returns the tagged fields of the struct type t.
+func structFields(t reflect.Type) ([]structField, error) {
+	if fs, ok := structFieldsCache.Load(t); ok {
+		return fs.([]structField), nil
+	}
+	var fs []structField
+	for _, f := range reflect.VisibleFields(t) {
+		tag, ok := f.Tag.Lookup("bigtable")
+		if !ok || tag == "-" {
+			continue
+		}
+		if !f.IsExported() {
+			return nil, fmt.Errorf("bigtable: tagged field %s of %s is not exported", f.Name, t)
+		}
+		family, qualifier, ok := strings.Cut(tag, ":")
+		if !ok || family == "" || qualifier == "" {
+			return nil, fmt.Errorf("bigtable: tag of field %s of %s is %q, want \"family:qualifier\"", f.Name, t, tag)
+		}
+		if !canEncodeType(f.Type) {
+			return nil, fmt.Errorf("bigtable: field %s of %s has unsupported type %s", f.Name, t, f.Type)
+		}
+		fs = append(fs, structField{name: f.Name, index: f.Index, family: family, qualifier: qualifier})
+	}
+	structFieldsCache.Store(t, fs)
+	return fs, nil
+}
+
+var (
+	timeType              = reflect.TypeOf(time.Time{})
+	binaryMarshalerType   = reflect.TypeOf((*encoding.BinaryMarshaler)(nil)).Elem()
+	binaryUnmarshalerType = reflect.TypeOf((*encoding.BinaryUnmarshaler)(nil)).Elem()
+)
+
+func canEncodeType(t reflect.Type) bool {
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t == timeType || t.Implements(binaryMarshalerType) && reflect.PointerTo(t).Implements(binaryUnmarshalerType) {
+		return true
+	}
+	switch t.Kind() {
+	case reflect.String, reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	case reflect.Slice:
+		return t.Elem().Kind() == reflect.Uint8
+	}
+	return false
+}
+
+// SetStruct adds a Set operation, at timestamp ts, for each field of the struct
+// v (or pointer to struct) that has a `bigtable:"family:qualifier"` tag. Fields
+// with nil pointer values are skipped. Values are encoded as follows:
+//
+//   - strings and byte slices as their bytes
+//   - integers as 64-bit big-endian two's complement, the encoding used by
+//     ReadModifyWrite.Increment
+//   - floats as 64-bit big-endian IEEE 754
+//   - bools as a single byte, 0 or 1
+//   - time.Time as a 64-bit big-endian count of microseconds since the Unix
+//     epoch, like a Timestamp
+//   - other types that implement encoding.BinaryMarshaler with MarshalBinary
+func (m *Mutation) SetStruct(ts Timestamp, v interface{}) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() == reflect.Pointer {
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return fmt.Errorf("bigtable: SetStruct needs a struct or pointer to struct, got %T", v)
+	}
+	fs, err := structFields(rv.Type())
+	if err != nil {
+		return err
+	}
+	for _, f := range fs {
+		fv := rv.FieldByIndex(f.index)
+		if fv.Kind() == reflect.Pointer {
+			if fv.IsNil() {
+				continue
+			}
+			fv = fv.Elem()
+		}
+		b, err := encodeValue(fv)
+		if err != nil {
+			return fmt.Errorf("bigtable: encoding field %s: %w", f.name, err)
+		}
+		m.Set(f.family, f.qualifier, ts, b)
+	}
+	return nil
+}
+
+// ToStruct sets each field of the struct pointed to by p that has a
+// `bigtable:"family:qualifier"` tag from the value of the latest cell of that
+// column in the row. Fields whose column is not in the row are left unchanged.
+// Values are decoded as described for Mutation.SetStruct.
+func (r Row) ToStruct(p interface{}) error {
+	rv := reflect.ValueOf(p)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("bigtable: ToStruct needs a non-nil pointer to struct, got %T", p)
+	}
+	rv = rv.Elem()
+	fs, err := structFields(rv.Type())
+	if err != nil {
+		return err
+	}
+	for _, f := range fs {
+		item, ok := r.latest(f.family, f.column())
+		if !ok {
+			continue
+		}
+		fv := rv.FieldByIndex(f.index)
+		if fv.Kind() == reflect.Pointer {
+			if fv.IsNil() {
+				fv.Set(reflect.New(fv.Type().Elem()))
+			}
+			fv = fv.Elem()
+		}
+		if err := decodeValue(item.Value, fv); err != nil {
+			return fmt.Errorf("bigtable: decoding column %s into field %s: %w", f.column(), f.name, err)
+		}
+	}
+	return nil
+}
+
+// latest returns the cell of the column with the latest timestamp.
+func (r Row) latest(family, column string) (ReadItem, bool) {
+	var (
+		latest ReadItem
+		found  bool
+	)
+	for _, item := range r[family] {
+		if item.Column == column && (!found || item.Timestamp > latest.Timestamp) {
+			latest, found = item, true
+		}
+	}
+	return latest, found
+}
+
+func encodeValue(v reflect.Value) ([]byte, error) {
+	if v.Type() == timeType {
+		return encodeInt64(v.Interface().(time.Time).UnixMicro()), nil
+	}
+	if m, ok := v.Interface().(encoding.BinaryMarshaler); ok {
+		return m.MarshalBinary()
+	}
+	switch v.Kind() {
+	case reflect.String:
+		return []byte(v.String()), nil
+	case reflect.Slice:
+		return v.Bytes(), nil
+	case reflect.Bool:
+		if v.Bool() {
+			return []byte{1}, nil
+		}
+		return []byte{0}, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return encodeInt64(v.Int()), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return binary.BigEndian.AppendUint64(nil, v.Uint()), nil
+	case reflect.Float32, reflect.Float64:
+		return binary.BigEndian.AppendUint64(nil, math.Float64bits(v.Float())), nil
+	}
+	return nil, fmt.Errorf("unsupported type %s", v.Type())
+}
+
+func encodeInt64(n int64) []byte {
+	return binary.BigEndian.AppendUint64(nil, uint64(n))
+}
+
+func decodeValue(b []byte, v reflect.Value) error {
+	if v.Type() == timeType {
+		n, err := decodeUint64(b)
+		if err != nil {
+			return err
+		}
+		v.Set(reflect.ValueOf(time.UnixMicro(int64(n))))
+		return nil
+	}
+	if u, ok := v.Addr().Interface().(encoding.BinaryUnmarshaler); ok {
+		return u.UnmarshalBinary(b)
+	}
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(string(b))
+		return nil
+	case reflect.Slice:
+		v.SetBytes(append([]byte(nil), b...))
+		return nil
+	case reflect.Bool:
+		if len(b) != 1 {
+			return fmt.Errorf("got %d bytes for bool, want 1", len(b))
+		}
+		v.SetBool(b[0] != 0)
+		return nil
+	}
+	n, err := decodeUint64(b)
+	if err != nil {
+		return err
+	}
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if v.OverflowInt(int64(n)) {
+			return fmt.Errorf("value %d overflows %s", int64(n), v.Type())
+		}
+		v.SetInt(int64(n))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if v.OverflowUint(n) {
+			return fmt.Errorf("value %d overflows %s", n, v.Type())
+		}
+		v.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		v.SetFloat(math.Float64frombits(n))
+	default:
+		return fmt.Errorf("unsupported type %s", v.Type())
+	}
+	return nil
+}
+
+func decodeUint64(b []byte) (uint64, error) {
+	if len(b) != 8 {
+		return 0, fmt.Errorf("got %d bytes, want 8", len(b))
+	}
+	return binary.BigEndian.Uint64(b), nil
+}
@@ -0,0 +1,137 @@
+/*
+Copyright 2023 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bigtable
+
+import (
+	"bytes"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+type testUser struct {
+	Name     string    `bigtable:"profile:name"`
+	Age      int32     `bigtable:"profile:age"`
+	Balance  float64   `bigtable:"account:balance"`
+	Visits   uint64    `bigtable:"stats:visits"`
+	Active   bool      `bigtable:"profile:active"`
+	Avatar   []byte    `bigtable:"profile:avatar"`
+	Joined   time.Time `bigtable:"profile:joined"`
+	Nickname *string   `bigtable:"profile:nickname"`
+	IP       net.IP    `bigtable:"profile:ip"`
+	Ignored  string    `bigtable:"-"`
+	Untagged string
+}
+
+// mutationRow returns the row that applying m to an empty row would produce.
+func mutationRow(key string, m *Mutation) Row {
+	r := Row{}
+	for _, op := range m.ops {
+		sc := op.GetSetCell()
+		r[sc.FamilyName] = append(r[sc.FamilyName], ReadItem{
+			Row:       key,
+			Column:    sc.FamilyName + ":" + string(sc.ColumnQualifier),
+			Timestamp: Timestamp(sc.TimestampMicros),
+			Value:     sc.Value,
+		})
+	}
+	return r
+}
+
+func TestStructRoundTrip(t *testing.T) {
+	nick := "al"
+	in := testUser{
+		Name:     "alice",
+		Age:      -30,
+		Balance:  12.5,
+		Visits:   7,
+		Active:   true,
+		Avatar:   []byte{1, 2, 3},
+		Joined:   time.Date(2023, 4, 5, 6, 7, 8, 9000, time.UTC),
+		Nickname: &nick,
+		IP:       net.IPv4(10, 0, 0, 1),
+		Ignored:  "x",
+		Untagged: "y",
+	}
+	m := NewMutation()
+	if err := m.SetStruct(Timestamp(1000), &in); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := len(m.ops), 9; got != want {
+		t.Fatalf("got %d operations, want %d", got, want)
+	}
+	r := mutationRow("alice", m)
+	// Integers use the encoding of ReadModifyWrite.Increment.
+	if got, want := r["profile"][1].Value, []byte{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xe2}; !bytes.Equal(got, want) {
+		t.Errorf("got age %v, want %v", got, want)
+	}
+
+	var out testUser
+	if err := r.ToStruct(&out); err != nil {
+		t.Fatal(err)
+	}
+	in.Ignored, in.Untagged = "", ""
+	if diff := cmp.Diff(in, out); diff != "" {
+		t.Errorf("-want +got:\n%s", diff)
+	}
+}
+
+func TestToStructLatestCell(t *testing.T) {
+	r := Row{"profile": {
+		{Column: "profile:name", Timestamp: 1000, Value: []byte("old")},
+		{Column: "profile:name", Timestamp: 3000, Value: []byte("new")},
+		{Column: "profile:name", Timestamp: 2000, Value: []byte("middle")},
+	}}
+	out := testUser{Age: 5}
+	if err := r.ToStruct(&out); err != nil {
+		t.Fatal(err)
+	}
+	if out.Name != "new" || out.Age != 5 || out.Nickname != nil {
+		t.Errorf("got %+v, want name from latest cell and other fields unchanged", out)
+	}
+}
+
+func TestStructErrors(t *testing.T) {
+	type badTag struct {
+		A string `bigtable:"family"`
+	}
+	type badType struct {
+		A []string `bigtable:"f:a"`
+	}
+	type small struct {
+		A int8 `bigtable:"f:a"`
+	}
+	m := NewMutation()
+	for _, v := range []interface{}{badTag{}, badType{}, 3, nil} {
+		if err := m.SetStruct(0, v); err == nil {
+			t.Errorf("SetStruct(%T): got nil, want error", v)
+		}
+	}
+
+	r := Row{"f": {{Column: "f:a", Value: encodeInt64(300)}}}
+	for _, p := range []interface{}{&small{}, small{}, (*small)(nil), &badTag{}} {
+		if err := r.ToStruct(p); err == nil {
+			t.Errorf("ToStruct(%T): got nil, want error", p)
+		}
+	}
+	r = Row{"f": {{Column: "f:a", Value: []byte("short")}}}
+	if err := r.ToStruct(&small{}); err == nil {
+		t.Error("ToStruct with short value: got nil, want error")
+	}
+}
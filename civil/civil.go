@@ -22,6 +22,7 @@
 package civil
 
 import (
+	"database/sql/driver"
 	"fmt"
 	"time"
 )
@@ -52,6 +53,18 @@ func ParseDate(s string) (Date, error) {
 	return DateOf(t), nil
 }
 
+// ParseDateLayout parses a string in the given layout and returns the date
+// value it represents. The layout is interpreted as by time.Parse, for
+// example "01/02/2006" or "Jan 2, 2006". Any time of day or time zone in the
+// string is ignored.
+func ParseDateLayout(layout, s string) (Date, error) {
+	t, err := time.Parse(layout, s)
+	if err != nil {
+		return Date{}, err
+	}
+	return DateOf(t), nil
+}
+
 // String returns the date in RFC3339 full-date format.
 func (d Date) String() string {
 	return fmt.Sprintf("%04d-%02d-%02d", d.Year, d.Month, d.Day)
@@ -86,6 +99,60 @@ func (d Date) AddDays(n int) Date {
 	return DateOf(d.In(time.UTC).AddDate(0, 0, n))
 }
 
+// An OverflowPolicy determines what AddMonths and AddYears do when the day of
+// the month does not exist in the resulting month, as when adding one month to
+// January 31.
+type OverflowPolicy int
+
+const (
+	// OverflowClamp moves the day back to the last day of the resulting month.
+	// For example, January 31 plus one month is February 28, or February 29
+	// in a leap year.
+	OverflowClamp OverflowPolicy = iota
+
+	// OverflowNormalize carries the extra days over into the following month,
+	// as time.Time.AddDate does. For example, January 31 plus one month is
+	// March 3, or March 2 in a leap year.
+	OverflowNormalize
+)
+
+// AddMonths returns the date that is n months in the future.
+// n can also be negative to go into the past. The policy determines the
+// result when the day of the month of d does not exist in the resulting month.
+func (d Date) AddMonths(n int, policy OverflowPolicy) Date {
+	if policy == OverflowNormalize {
+		return DateOf(d.In(time.UTC).AddDate(0, n, 0))
+	}
+	// Find the first day of the resulting month, then the closest day to d.Day
+	// in it.
+	first := DateOf(time.Date(d.Year, d.Month+time.Month(n), 1, 0, 0, 0, 0, time.UTC))
+	last := DateOf(time.Date(first.Year, first.Month+1, 0, 0, 0, 0, 0, time.UTC))
+	if d.Day > last.Day {
+		return last
+	}
+	return Date{Year: first.Year, Month: first.Month, Day: d.Day}
+}
+
+// AddYears returns the date that is n years in the future.
+// n can also be negative to go into the past. The policy determines the
+// result when d is February 29 and the resulting year is not a leap year.
+func (d Date) AddYears(n int, policy OverflowPolicy) Date {
+	return d.AddMonths(12*n, policy)
+}
+
+// Weekday returns the day of the week of the date.
+func (d Date) Weekday() time.Weekday {
+	return d.In(time.UTC).Weekday()
+}
+
+// ISOWeek returns the ISO 8601 year and week number in which the date occurs.
+// Week ranges from 1 to 53. Jan 01 to Jan 03 of year n might belong to week
+// 52 or 53 of year n-1, and Dec 29 to Dec 31 might belong to week 1 of year
+// n+1.
+func (d Date) ISOWeek() (year, week int) {
+	return d.In(time.UTC).ISOWeek()
+}
+
 // DaysSince returns the signed number of days between the date and s, not including the end day.
 // This is the inverse operation to AddDays.
 func (d Date) DaysSince(s Date) (days int) {
@@ -111,6 +178,18 @@ func (d Date) After(d2 Date) bool {
 	return d2.Before(d)
 }
 
+// Compare compares d and d2. If d is before d2, it returns -1;
+// if d is after d2, it returns +1; if they're the same, it returns 0.
+func (d Date) Compare(d2 Date) int {
+	switch {
+	case d.Before(d2):
+		return -1
+	case d.After(d2):
+		return +1
+	}
+	return 0
+}
+
 // IsZero reports whether date fields are set to their default value.
 func (d Date) IsZero() bool {
 	return (d.Year == 0) && (int(d.Month) == 0) && (d.Day == 0)
@@ -130,6 +209,28 @@ func (d *Date) UnmarshalText(data []byte) error {
 	return err
 }
 
+// Scan implements the sql.Scanner interface. It accepts a time.Time, whose
+// date in its location is used, or a string or []byte in a format accepted
+// by ParseDate.
+func (d *Date) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case time.Time:
+		*d = DateOf(v)
+		return nil
+	case string:
+		return d.UnmarshalText([]byte(v))
+	case []byte:
+		return d.UnmarshalText(v)
+	}
+	return fmt.Errorf("civil: cannot scan %T into Date", src)
+}
+
+// Value implements the driver.Valuer interface. The value is the result of
+// d.String().
+func (d Date) Value() (driver.Value, error) {
+	return d.String(), nil
+}
+
 // A Time represents a time with nanosecond precision.
 //
 // This type does not include location information, and therefore does not
@@ -166,6 +267,17 @@ func ParseTime(s string) (Time, error) {
 	return TimeOf(t), nil
 }
 
+// ParseTimeLayout parses a string in the given layout and returns the time
+// value it represents. The layout is interpreted as by time.Parse, for
+// example "3:04PM". Any date or time zone in the string is ignored.
+func ParseTimeLayout(layout, s string) (Time, error) {
+	t, err := time.Parse(layout, s)
+	if err != nil {
+		return Time{}, err
+	}
+	return TimeOf(t), nil
+}
+
 // String returns the date in the format described in ParseTime. If Nanoseconds
 // is zero, no fractional part will be generated. Otherwise, the result will
 // end with a fractional part consisting of a decimal point and nine digits.
@@ -209,6 +321,18 @@ func (t Time) After(t2 Time) bool {
 	return t2.Before(t)
 }
 
+// Compare compares t and t2. If t is before t2, it returns -1;
+// if t is after t2, it returns +1; if they're the same, it returns 0.
+func (t Time) Compare(t2 Time) int {
+	switch {
+	case t.Before(t2):
+		return -1
+	case t.After(t2):
+		return +1
+	}
+	return 0
+}
+
 // MarshalText implements the encoding.TextMarshaler interface.
 // The output is the result of t.String().
 func (t Time) MarshalText() ([]byte, error) {
@@ -223,6 +347,28 @@ func (t *Time) UnmarshalText(data []byte) error {
 	return err
 }
 
+// Scan implements the sql.Scanner interface. It accepts a time.Time, whose
+// time of day in its location is used, or a string or []byte in a format
+// accepted by ParseTime.
+func (t *Time) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case time.Time:
+		*t = TimeOf(v)
+		return nil
+	case string:
+		return t.UnmarshalText([]byte(v))
+	case []byte:
+		return t.UnmarshalText(v)
+	}
+	return fmt.Errorf("civil: cannot scan %T into Time", src)
+}
+
+// Value implements the driver.Valuer interface. The value is the result of
+// t.String().
+func (t Time) Value() (driver.Value, error) {
+	return t.String(), nil
+}
+
 // A DateTime represents a date and time.
 //
 // This type does not include location information, and therefore does not
@@ -261,6 +407,18 @@ func ParseDateTime(s string) (DateTime, error) {
 	return DateTimeOf(t), nil
 }
 
+// ParseDateTimeLayout parses a string in the given layout and returns the
+// DateTime it represents. The layout is interpreted as by time.Parse, for
+// example "2006-01-02 15:04:05" or time.RFC1123. Any time zone in the string
+// is ignored.
+func ParseDateTimeLayout(layout, s string) (DateTime, error) {
+	t, err := time.Parse(layout, s)
+	if err != nil {
+		return DateTime{}, err
+	}
+	return DateTimeOf(t), nil
+}
+
 // String returns the date in the format described in ParseDate.
 func (dt DateTime) String() string {
 	return dt.Date.String() + "T" + dt.Time.String()
@@ -302,6 +460,15 @@ func (dt DateTime) After(dt2 DateTime) bool {
 	return dt2.Before(dt)
 }
 
+// Compare compares dt and dt2. If dt is before dt2, it returns -1;
+// if dt is after dt2, it returns +1; if they're the same, it returns 0.
+func (dt DateTime) Compare(dt2 DateTime) int {
+	if c := dt.Date.Compare(dt2.Date); c != 0 {
+		return c
+	}
+	return dt.Time.Compare(dt2.Time)
+}
+
 // IsZero reports whether datetime fields are set to their default value.
 func (dt DateTime) IsZero() bool {
 	return dt.Date.IsZero() && dt.Time.IsZero()
@@ -320,3 +487,41 @@ func (dt *DateTime) UnmarshalText(data []byte) error {
 	*dt, err = ParseDateTime(string(data))
 	return err
 }
+
+// sqlDateTimeLayout is the layout in which SQL databases commonly represent
+// datetimes as text.
+const sqlDateTimeLayout = "2006-01-02 15:04:05.999999999"
+
+// Scan implements the sql.Scanner interface. It accepts a time.Time, whose
+// date and time in its location are used, or a string or []byte in a format
+// accepted by ParseDateTime or in which the date and time are separated by a
+// space, as in "2016-03-22 13:26:33".
+func (dt *DateTime) Scan(src interface{}) error {
+	var s string
+	switch v := src.(type) {
+	case time.Time:
+		*dt = DateTimeOf(v)
+		return nil
+	case string:
+		s = v
+	case []byte:
+		s = string(v)
+	default:
+		return fmt.Errorf("civil: cannot scan %T into DateTime", src)
+	}
+	d, err := ParseDateTime(s)
+	if err != nil {
+		var err2 error
+		if d, err2 = ParseDateTimeLayout(sqlDateTimeLayout, s); err2 != nil {
+			return err
+		}
+	}
+	*dt = d
+	return nil
+}
+
+// Value implements the driver.Valuer interface. The value is the result of
+// dt.String().
+func (dt DateTime) Value() (driver.Value, error) {
+	return dt.String(), nil
+}
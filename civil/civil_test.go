@@ -15,6 +15,7 @@
 package civil
 
 import (
+	"database/sql/driver"
 	"encoding/json"
 	"testing"
 	"time"
@@ -532,3 +533,178 @@ func TestUnmarshalJSON(t *testing.T) {
 		}
 	}
 }
+
+func TestParseLayout(t *testing.T) {
+	if got, err := ParseDateLayout("01/02/2006", "04/15/1987"); err != nil || got != (Date{1987, 4, 15}) {
+		t.Errorf("ParseDateLayout: got %v, %v, want 1987-04-15, nil", got, err)
+	}
+	if got, err := ParseTimeLayout("3:04PM", "6:54PM"); err != nil || got != (Time{18, 54, 0, 0}) {
+		t.Errorf("ParseTimeLayout: got %v, %v, want 18:54:00, nil", got, err)
+	}
+	want := DateTime{Date{1987, 4, 15}, Time{18, 54, 2, 0}}
+	if got, err := ParseDateTimeLayout(time.RFC1123, "Wed, 15 Apr 1987 18:54:02 PST"); err != nil || got != want {
+		t.Errorf("ParseDateTimeLayout: got %v, %v, want %v, nil", got, err, want)
+	}
+	if _, err := ParseDateLayout("01/02/2006", "1987-04-15"); err == nil {
+		t.Error("ParseDateLayout with wrong layout: got nil, want error")
+	}
+}
+
+func TestDateAddMonths(t *testing.T) {
+	for _, test := range []struct {
+		start  Date
+		months int
+		policy OverflowPolicy
+		want   Date
+	}{
+		{Date{2014, 5, 9}, 0, OverflowClamp, Date{2014, 5, 9}},
+		{Date{2014, 5, 9}, 1, OverflowClamp, Date{2014, 6, 9}},
+		{Date{2014, 11, 9}, 3, OverflowClamp, Date{2015, 2, 9}},
+		{Date{2014, 2, 9}, -3, OverflowClamp, Date{2013, 11, 9}},
+		{Date{2015, 1, 31}, 1, OverflowClamp, Date{2015, 2, 28}},
+		{Date{2016, 1, 31}, 1, OverflowClamp, Date{2016, 2, 29}},
+		{Date{2016, 3, 31}, -1, OverflowClamp, Date{2016, 2, 29}},
+		{Date{2016, 5, 31}, 1, OverflowClamp, Date{2016, 6, 30}},
+		{Date{2015, 1, 31}, 1, OverflowNormalize, Date{2015, 3, 3}},
+		{Date{2016, 1, 31}, 1, OverflowNormalize, Date{2016, 3, 2}},
+		{Date{2016, 5, 31}, 1, OverflowNormalize, Date{2016, 7, 1}},
+	} {
+		if got := test.start.AddMonths(test.months, test.policy); got != test.want {
+			t.Errorf("%v.AddMonths(%d, %d) = %v, want %v", test.start, test.months, test.policy, got, test.want)
+		}
+	}
+}
+
+func TestDateAddYears(t *testing.T) {
+	for _, test := range []struct {
+		start  Date
+		years  int
+		policy OverflowPolicy
+		want   Date
+	}{
+		{Date{2014, 5, 9}, 2, OverflowClamp, Date{2016, 5, 9}},
+		{Date{2014, 5, 9}, -2, OverflowNormalize, Date{2012, 5, 9}},
+		{Date{2016, 2, 29}, 4, OverflowClamp, Date{2020, 2, 29}},
+		{Date{2016, 2, 29}, 1, OverflowClamp, Date{2017, 2, 28}},
+		{Date{2016, 2, 29}, 1, OverflowNormalize, Date{2017, 3, 1}},
+	} {
+		if got := test.start.AddYears(test.years, test.policy); got != test.want {
+			t.Errorf("%v.AddYears(%d, %d) = %v, want %v", test.start, test.years, test.policy, got, test.want)
+		}
+	}
+}
+
+func TestDateWeekday(t *testing.T) {
+	for _, test := range []struct {
+		date       Date
+		weekday    time.Weekday
+		year, week int
+	}{
+		{Date{1987, 4, 15}, time.Wednesday, 1987, 16},
+		{Date{2016, 1, 1}, time.Friday, 2015, 53},
+		{Date{2014, 12, 29}, time.Monday, 2015, 1},
+	} {
+		if got := test.date.Weekday(); got != test.weekday {
+			t.Errorf("%v.Weekday() = %v, want %v", test.date, got, test.weekday)
+		}
+		if year, week := test.date.ISOWeek(); year != test.year || week != test.week {
+			t.Errorf("%v.ISOWeek() = %d, %d, want %d, %d", test.date, year, week, test.year, test.week)
+		}
+	}
+}
+
+func TestCompare(t *testing.T) {
+	for _, test := range []struct {
+		d1, d2 Date
+		want   int
+	}{
+		{Date{2016, 12, 31}, Date{2017, 1, 1}, -1},
+		{Date{2016, 1, 1}, Date{2016, 1, 1}, 0},
+		{Date{2016, 12, 31}, Date{2016, 12, 30}, +1},
+	} {
+		if got := test.d1.Compare(test.d2); got != test.want {
+			t.Errorf("%v.Compare(%v): got %d, want %d", test.d1, test.d2, got, test.want)
+		}
+	}
+	for _, test := range []struct {
+		t1, t2 Time
+		want   int
+	}{
+		{Time{12, 0, 0, 0}, Time{14, 0, 0, 0}, -1},
+		{Time{12, 20, 0, 0}, Time{12, 20, 0, 0}, 0},
+		{Time{12, 20, 30, 6}, Time{12, 20, 30, 5}, +1},
+	} {
+		if got := test.t1.Compare(test.t2); got != test.want {
+			t.Errorf("%v.Compare(%v): got %d, want %d", test.t1, test.t2, got, test.want)
+		}
+	}
+	for _, test := range []struct {
+		dt1, dt2 DateTime
+		want     int
+	}{
+		{DateTime{Date{2016, 12, 31}, Time{23, 0, 0, 0}}, DateTime{Date{2017, 1, 1}, Time{1, 0, 0, 0}}, -1},
+		{DateTime{Date{2016, 12, 31}, Time{1, 0, 0, 0}}, DateTime{Date{2016, 12, 31}, Time{1, 0, 0, 0}}, 0},
+		{DateTime{Date{2016, 12, 31}, Time{1, 0, 0, 1}}, DateTime{Date{2016, 12, 31}, Time{1, 0, 0, 0}}, +1},
+	} {
+		if got := test.dt1.Compare(test.dt2); got != test.want {
+			t.Errorf("%v.Compare(%v): got %d, want %d", test.dt1, test.dt2, got, test.want)
+		}
+	}
+}
+
+func TestScan(t *testing.T) {
+	tm := time.Date(1987, 4, 15, 18, 54, 2, 0, time.FixedZone("", -8*60*60))
+	for _, test := range []struct {
+		src  interface{}
+		ptr  interface{ Scan(interface{}) error }
+		want interface{}
+	}{
+		{tm, new(Date), &Date{1987, 4, 15}},
+		{"1987-04-15", new(Date), &Date{1987, 4, 15}},
+		{[]byte("1987-04-15"), new(Date), &Date{1987, 4, 15}},
+		{tm, new(Time), &Time{18, 54, 2, 0}},
+		{"18:54:02.5", new(Time), &Time{18, 54, 2, 500000000}},
+		{[]byte("18:54:02"), new(Time), &Time{18, 54, 2, 0}},
+		{tm, new(DateTime), &DateTime{Date{1987, 4, 15}, Time{18, 54, 2, 0}}},
+		{"1987-04-15T18:54:02", new(DateTime), &DateTime{Date{1987, 4, 15}, Time{18, 54, 2, 0}}},
+		{[]byte("1987-04-15 18:54:02.5"), new(DateTime), &DateTime{Date{1987, 4, 15}, Time{18, 54, 2, 500000000}}},
+	} {
+		if err := test.ptr.Scan(test.src); err != nil {
+			t.Fatalf("%T.Scan(%v): %v", test.ptr, test.src, err)
+		}
+		if !cmp.Equal(test.ptr, test.want) {
+			t.Errorf("%T.Scan(%v): got %v, want %v", test.ptr, test.src, test.ptr, test.want)
+		}
+	}
+
+	for _, bad := range []interface{}{nil, 42, "bad", []byte("1987-04-15x")} {
+		if (&Date{}).Scan(bad) == nil {
+			t.Errorf("%v, Date: got nil, want error", bad)
+		}
+		if (&Time{}).Scan(bad) == nil {
+			t.Errorf("%v, Time: got nil, want error", bad)
+		}
+		if (&DateTime{}).Scan(bad) == nil {
+			t.Errorf("%v, DateTime: got nil, want error", bad)
+		}
+	}
+}
+
+func TestValue(t *testing.T) {
+	for _, test := range []struct {
+		value driver.Valuer
+		want  driver.Value
+	}{
+		{Date{1987, 4, 15}, "1987-04-15"},
+		{Time{18, 54, 2, 0}, "18:54:02"},
+		{DateTime{Date{1987, 4, 15}, Time{18, 54, 2, 0}}, "1987-04-15T18:54:02"},
+	} {
+		got, err := test.value.Value()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != test.want {
+			t.Errorf("%#v: got %v, want %v", test.value, got, test.want)
+		}
+	}
+}
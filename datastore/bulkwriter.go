@@ -0,0 +1,494 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datastore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	gax "github.com/googleapis/gax-go/v2"
+	"golang.org/x/time/rate"
+	"google.golang.org/api/support/bundler"
+	pb "google.golang.org/genproto/googleapis/datastore/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	// bulkWriterMaxAttempts is the max number of times a batch is committed.
+	bulkWriterMaxAttempts = 10
+	// bulkWriterConcurrentBatches is the default number of batches committed
+	// at a time.
+	bulkWriterConcurrentBatches = 20
+	// bulkWriterOpsPerSecond is the default limit of writes per second.
+	bulkWriterOpsPerSecond = 10000
+	// bulkWriterBatchBytes is the max size of the mutations of a batch,
+	// below the 10 MiB limit of a request.
+	bulkWriterBatchBytes = 9 << 20
+	// bulkWriterBufferedBytes is the max size of the mutations held by a
+	// BulkWriter; Put and Delete block beyond it.
+	bulkWriterBufferedBytes = 256 << 20
+	// rampUpInterval is how often a throttled BulkWriter increases its rate.
+	rampUpInterval = 5 * time.Minute
+	// rampUpFactor is how much a throttled BulkWriter increases its rate by.
+	rampUpFactor = 1.5
+)
+
+// A BulkWriterOption is an option passed to Client.BulkWriter.
+type BulkWriterOption interface {
+	config(bw *BulkWriter)
+}
+
+// BulkWriterThrottling is a BulkWriterOption that limits the number of writes
+// the BulkWriter sends per second. The limit starts at initialOpsPerSecond and
+// increases by 50% every 5 minutes, up to maxOpsPerSecond. This follows the
+// "500/50/5" rule for ramping up traffic to Datastore, which avoids hotspots
+// when writing to a new or cold range of keys.
+//
+// Without this option, a BulkWriter sends up to 10,000 writes per second from
+// the start.
+func BulkWriterThrottling(initialOpsPerSecond, maxOpsPerSecond int) BulkWriterOption {
+	return bulkWriterThrottling{initial: initialOpsPerSecond, max: maxOpsPerSecond}
+}
+
+type bulkWriterThrottling struct{ initial, max int }
+
+func (t bulkWriterThrottling) config(bw *BulkWriter) {
+	initial := t.initial
+	if initial < 1 {
+		initial = 1
+	}
+	max := t.max
+	if max < initial {
+		max = initial
+	}
+	bw.rampUpInitial, bw.rampUpMax = initial, max
+}
+
+// BulkWriterNoThrottling is a BulkWriterOption that removes the limit on the
+// number of writes the BulkWriter sends per second.
+var BulkWriterNoThrottling = bulkWriterNoThrottling{}
+
+type bulkWriterNoThrottling struct{}
+
+func (bulkWriterNoThrottling) config(bw *BulkWriter) { bw.unthrottled = true }
+
+// BulkWriterOnSuccess is a BulkWriterOption that calls f with each write that
+// succeeds and the key of its entity, which is complete. f is called from
+// the goroutine that commits the writes, so it should return quickly.
+func BulkWriterOnSuccess(f func(*BulkWriterJob, *Key)) BulkWriterOption {
+	return bulkWriterOnSuccess(f)
+}
+
+type bulkWriterOnSuccess func(*BulkWriterJob, *Key)
+
+func (f bulkWriterOnSuccess) config(bw *BulkWriter) { bw.onSuccess = f }
+
+// BulkWriterOnError is a BulkWriterOption that calls f with each write that
+// fails, after any retries, and its error. f is called from the goroutine that
+// commits the writes, so it should return quickly.
+func BulkWriterOnError(f func(*BulkWriterJob, error)) BulkWriterOption {
+	return bulkWriterOnError(f)
+}
+
+type bulkWriterOnError func(*BulkWriterJob, error)
+
+func (f bulkWriterOnError) config(bw *BulkWriter) { bw.onError = f }
+
+// BulkWriterProgress is a snapshot of the progress of a BulkWriter.
+type BulkWriterProgress struct {
+	// Enqueued is the number of writes added to the BulkWriter.
+	Enqueued int64
+	// Succeeded is the number of writes that succeeded.
+	Succeeded int64
+	// Failed is the number of writes that failed after any retries.
+	Failed int64
+	// Pending is the number of writes that have neither succeeded nor failed.
+	Pending int64
+	// Retries is the number of times writes were retried.
+	Retries int64
+	// OpsPerSecond is the current limit on writes per second, or +Inf if the
+	// BulkWriter is not throttled.
+	OpsPerSecond float64
+	// Elapsed is the time since the BulkWriter was created.
+	Elapsed time.Duration
+}
+
+// BulkWriterJob is a write added to a BulkWriter. Its Results method waits
+// for the outcome of the write.
+type BulkWriterJob struct {
+	key      *Key
+	op       string
+	mutation *pb.Mutation
+	attempts int32 // accessed atomically
+	ctx      context.Context
+
+	done   chan struct{} // closed when result and err are set
+	result *Key
+	err    error
+}
+
+// Results waits until the write of the job succeeds or fails, and returns the
+// key of its entity, which is complete, or its error. It returns early with
+// the error of the context of the BulkWriter if it is done.
+func (j *BulkWriterJob) Results() (*Key, error) {
+	select {
+	case <-j.ctx.Done():
+		return nil, j.ctx.Err()
+	case <-j.done:
+		return j.result, j.err
+	}
+}
+
+// Key returns the key of the entity written by the job, as it was given to
+// BulkWriter.Put or BulkWriter.Delete.
+func (j *BulkWriterJob) Key() *Key {
+	return j.key
+}
+
+// Operation returns the kind of write of the job: "put" or "delete".
+func (j *BulkWriterJob) Operation() string {
+	return j.op
+}
+
+// Attempts returns the number of times the write of the job has been
+// attempted and failed.
+func (j *BulkWriterJob) Attempts() int {
+	return int(atomic.LoadInt32(&j.attempts))
+}
+
+// A BulkWriter writes many entities with non-transactional commits. Put and
+// Delete add writes to it, which it commits in batches, several at a time, in
+// the background; they return a BulkWriterJob, whose Results are those of the
+// write. Commits that fail with contention or quota errors are retried with
+// exponential backoff.
+//
+// The BulkWriter applies flow control: it limits the number of writes it sends
+// per second (see BulkWriterThrottling), and Put and Delete block while it
+// holds too many writes which have not been committed.
+//
+// BulkWriter cannot promise atomicity: individual writes can fail or succeed
+// independent of each other, and are not applied in any set order. When a
+// commit fails because of some of its writes, for example with an
+// InvalidArgument error for an indexed string that is too long, the batch is
+// split and its parts are committed separately, so that only the writes that
+// cause the error fail. Other errors, such as those of the context or of
+// permissions, fail the whole batch. An entity can have only one pending
+// write: a write to the key of another write which has not completed is
+// rejected.
+//
+// The batches are of at most MaxBatchSize writes, and MaxConcurrentBatches of
+// them are committed at a time, as given to Client.WithWriteOptions; by
+// default, 500 writes and 20 batches.
+type BulkWriter struct {
+	client  *Client
+	ctx     context.Context // context for canceling all BulkWriter operations
+	start   time.Time       // when this BulkWriter was started; used to calculate rate increases
+	limiter *rate.Limiter
+	bundler *bundler.Bundler
+	backoff gax.Backoff // backoff of the retries of a batch
+
+	mu      sync.Mutex      // guards isOpen and pending
+	isOpen  bool            // whether writes can be added
+	pending map[string]bool // the keys of the pending writes
+
+	// Throttling and callbacks, set by BulkWriterOptions.
+	rampUpInitial int  // if non-zero, the limit of writes per second ramps up from this value
+	rampUpMax     int  // the value the limit of writes per second ramps up to
+	unthrottled   bool // no limit on writes per second
+	onSuccess     func(*BulkWriterJob, *Key)
+	onError       func(*BulkWriterJob, error)
+
+	// Progress counters, accessed atomically.
+	enqueued, succeeded, failed, retries int64
+}
+
+// BulkWriter returns a BulkWriter that writes with the client. The writes of
+// the BulkWriter end when ctx is done. Call End when done with it.
+func (c *Client) BulkWriter(ctx context.Context, opts ...BulkWriterOption) *BulkWriter {
+	bw := &BulkWriter{
+		client:  c,
+		ctx:     ctx,
+		start:   time.Now(),
+		limiter: rate.NewLimiter(rate.Limit(bulkWriterOpsPerSecond), 1),
+		backoff: gax.Backoff{Initial: time.Second, Max: time.Minute, Multiplier: 1.5},
+		isOpen:  true,
+		pending: make(map[string]bool),
+	}
+	for _, opt := range opts {
+		opt.config(bw)
+	}
+	switch {
+	case bw.unthrottled:
+		bw.limiter.SetLimit(rate.Inf)
+	case bw.rampUpInitial > 0:
+		bw.limiter.SetLimit(rate.Limit(bw.rampUpInitial))
+	}
+
+	size, concurrency := c.writeSettings.batching()
+	if c.writeSettings == nil || c.writeSettings.maxConcurrentBatches <= 0 {
+		concurrency = bulkWriterConcurrentBatches
+	}
+	bw.bundler = bundler.NewBundler(&BulkWriterJob{}, bw.send)
+	bw.bundler.BundleCountThreshold = size
+	bw.bundler.BundleByteThreshold = bulkWriterBatchBytes
+	bw.bundler.BundleByteLimit = bulkWriterBatchBytes
+	bw.bundler.BufferedByteLimit = bulkWriterBufferedBytes
+	bw.bundler.HandlerLimit = concurrency
+	return bw
+}
+
+// Put adds a write of src to the entity of key, as by Client.Put. If key is
+// incomplete, the key of the job's Results is the one allocated to the entity.
+func (bw *BulkWriter) Put(key *Key, src interface{}) (*BulkWriterJob, error) {
+	if !key.valid() {
+		return nil, ErrInvalidKey
+	}
+	e, err := saveEntity(key, src)
+	if err != nil {
+		return nil, err
+	}
+	mut := &pb.Mutation{Operation: &pb.Mutation_Upsert{Upsert: e}}
+	if key.Incomplete() {
+		mut = &pb.Mutation{Operation: &pb.Mutation_Insert{Insert: e}}
+	}
+	return bw.write(key, "put", mut)
+}
+
+// Delete adds a deletion of the entity of key, as by Client.Delete.
+func (bw *BulkWriter) Delete(key *Key) (*BulkWriterJob, error) {
+	if !key.valid() {
+		return nil, ErrInvalidKey
+	}
+	if key.Incomplete() {
+		return nil, fmt.Errorf("datastore: can't delete the incomplete key: %v", key)
+	}
+	return bw.write(key, "delete", &pb.Mutation{Operation: &pb.Mutation_Delete{Delete: keyToProto(key)}})
+}
+
+// Flush commits all writes that have been added up to this point, and waits
+// until they succeed or fail.
+func (bw *BulkWriter) Flush() {
+	bw.bundler.Flush()
+}
+
+// End commits all writes that have been added, waits until they succeed or
+// fail, and closes the BulkWriter to new writes. After End, Put and Delete
+// return an error.
+func (bw *BulkWriter) End() {
+	bw.mu.Lock()
+	bw.isOpen = false
+	bw.mu.Unlock()
+	bw.Flush()
+}
+
+// Progress returns a snapshot of the progress of the BulkWriter.
+func (bw *BulkWriter) Progress() BulkWriterProgress {
+	p := BulkWriterProgress{
+		Enqueued:     atomic.LoadInt64(&bw.enqueued),
+		Succeeded:    atomic.LoadInt64(&bw.succeeded),
+		Failed:       atomic.LoadInt64(&bw.failed),
+		Retries:      atomic.LoadInt64(&bw.retries),
+		OpsPerSecond: float64(bw.limiter.Limit()),
+		Elapsed:      time.Since(bw.start),
+	}
+	if bw.limiter.Limit() == rate.Inf {
+		p.OpsPerSecond = math.Inf(1)
+	}
+	p.Pending = p.Enqueued - p.Succeeded - p.Failed
+	return p
+}
+
+// write adds a job for the mutation mut of key to the bundler, waiting for
+// the limiter and for the bundler to have room for it.
+func (bw *BulkWriter) write(key *Key, op string, mut *pb.Mutation) (*BulkWriterJob, error) {
+	ks := ""
+	if !key.Incomplete() {
+		ks = key.String()
+	}
+	bw.mu.Lock()
+	if !bw.isOpen {
+		bw.mu.Unlock()
+		return nil, errors.New("datastore: BulkWriter has been closed")
+	}
+	if ks != "" {
+		if bw.pending[ks] {
+			bw.mu.Unlock()
+			return nil, fmt.Errorf("datastore: BulkWriter has a pending write for key %v", key)
+		}
+		bw.pending[ks] = true
+	}
+	bw.mu.Unlock()
+
+	j := &BulkWriterJob{
+		key:      key,
+		op:       op,
+		mutation: mut,
+		ctx:      bw.ctx,
+		done:     make(chan struct{}),
+	}
+	if bw.rampUpInitial > 0 && !bw.unthrottled {
+		if l := rate.Limit(rampedOpsPerSecond(bw.rampUpInitial, bw.rampUpMax, time.Since(bw.start))); l != bw.limiter.Limit() {
+			bw.limiter.SetLimit(l)
+		}
+	}
+	err := bw.limiter.Wait(bw.ctx)
+	if err == nil {
+		err = bw.bundler.AddWait(bw.ctx, j, proto.Size(mut))
+	}
+	if err != nil {
+		bw.release(j)
+		return nil, err
+	}
+	atomic.AddInt64(&bw.enqueued, 1)
+	return j, nil
+}
+
+// rampedOpsPerSecond returns the limit of writes per second after elapsed
+// time, starting at initial and increasing by rampUpFactor every
+// rampUpInterval, up to max.
+func rampedOpsPerSecond(initial, max int, elapsed time.Duration) int {
+	n := float64(initial) * math.Pow(rampUpFactor, float64(elapsed/rampUpInterval))
+	if n > float64(max) {
+		return max
+	}
+	return int(n)
+}
+
+// release removes the key of j from the pending writes.
+func (bw *BulkWriter) release(j *BulkWriterJob) {
+	if j.key.Incomplete() {
+		return
+	}
+	bw.mu.Lock()
+	delete(bw.pending, j.key.String())
+	bw.mu.Unlock()
+}
+
+// finish delivers the result or the error of the write of j.
+func (bw *BulkWriter) finish(j *BulkWriterJob, key *Key, err error) {
+	bw.release(j)
+	if err != nil {
+		atomic.AddInt64(&bw.failed, 1)
+		if bw.onError != nil {
+			bw.onError(j, err)
+		}
+	} else {
+		atomic.AddInt64(&bw.succeeded, 1)
+		if bw.onSuccess != nil {
+			bw.onSuccess(j, key)
+		}
+	}
+	j.result, j.err = key, err
+	close(j.done)
+}
+
+// send commits a batch of jobs and delivers the results to the jobs.
+func (bw *BulkWriter) send(i interface{}) {
+	jobs := i.([]*BulkWriterJob)
+	if len(jobs) == 0 {
+		return
+	}
+	bw.commit(jobs)
+}
+
+// commit commits jobs and delivers the results to them. If the commit fails
+// with an error caused by some of the writes, jobs are split in halves, which
+// are committed separately.
+func (bw *BulkWriter) commit(jobs []*BulkWriterJob) {
+	resp, err := bw.commitWithRetry(jobs)
+	if err != nil && len(jobs) > 1 && bulkWriterShouldSplit(err) {
+		bw.commit(jobs[:len(jobs)/2])
+		bw.commit(jobs[len(jobs)/2:])
+		return
+	}
+	for k, j := range jobs {
+		if err != nil {
+			bw.finish(j, nil, err)
+			continue
+		}
+		key := j.key
+		if key.Incomplete() {
+			var kerr error
+			if key, kerr = protoToKey(resp.MutationResults[k].Key); kerr != nil {
+				bw.finish(j, nil, errors.New("datastore: internal error: server returned an invalid key"))
+				continue
+			}
+		}
+		bw.finish(j, key, nil)
+	}
+}
+
+// commitWithRetry commits the mutations of jobs, retrying with backoff while
+// the commit fails with a retryable error.
+func (bw *BulkWriter) commitWithRetry(jobs []*BulkWriterJob) (*pb.CommitResponse, error) {
+	req := &pb.CommitRequest{
+		ProjectId:  bw.client.dataset,
+		DatabaseId: bw.client.databaseID,
+		Mode:       pb.CommitRequest_NON_TRANSACTIONAL,
+	}
+	for _, j := range jobs {
+		req.Mutations = append(req.Mutations, j.mutation)
+	}
+
+	bo := bw.backoff
+	for attempts := 1; ; attempts++ {
+		resp, err := bw.client.client.Commit(bw.ctx, req)
+		if err == nil {
+			return resp, nil
+		}
+		for _, j := range jobs {
+			atomic.AddInt32(&j.attempts, 1)
+		}
+		if !bulkWriterShouldRetry(err) {
+			return nil, err
+		}
+		if attempts == bulkWriterMaxAttempts {
+			return nil, fmt.Errorf("datastore: BulkWriter commit failed after %d attempts: %w", attempts, err)
+		}
+		atomic.AddInt64(&bw.retries, int64(len(jobs)))
+		if err := gax.Sleep(bw.ctx, bo.Pause()); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// bulkWriterShouldSplit reports whether a commit of a BulkWriter which failed
+// with err may have failed because of some of its writes only, such as an
+// entity that is too large or a property value that cannot be indexed.
+func bulkWriterShouldSplit(err error) bool {
+	switch status.Code(err) {
+	case codes.InvalidArgument, codes.FailedPrecondition:
+		return true
+	}
+	return false
+}
+
+// bulkWriterShouldRetry reports whether a commit of a BulkWriter which failed
+// with err is retried: on contention or when a quota is exhausted.
+// Unavailable errors are retried by every request.
+func bulkWriterShouldRetry(err error) bool {
+	switch status.Code(err) {
+	case codes.Aborted, codes.ResourceExhausted:
+		return true
+	}
+	return false
+}
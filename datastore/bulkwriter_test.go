@@ -0,0 +1,277 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datastore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	gax "github.com/googleapis/gax-go/v2"
+	pb "google.golang.org/genproto/googleapis/datastore/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// newBulkClient returns a client whose commits allocate the ID N+1 to an
+// inserted entity with the property N, after failing with the errors of
+// fail, if it returns any, and records the number of mutations of each
+// commit.
+func newBulkClient(fail func(*pb.CommitRequest) error) (*Client, func() []int) {
+	var (
+		mu      sync.Mutex
+		commits []int
+	)
+	client := &Client{
+		client: &fakeDatastoreClient{
+			commit: func(req *pb.CommitRequest) (*pb.CommitResponse, error) {
+				mu.Lock()
+				commits = append(commits, len(req.Mutations))
+				mu.Unlock()
+				if fail != nil {
+					if err := fail(req); err != nil {
+						return nil, err
+					}
+				}
+				resp := &pb.CommitResponse{}
+				for _, m := range req.Mutations {
+					var k *pb.Key
+					if ins := m.GetInsert(); ins != nil {
+						id := ins.Properties["N"].GetIntegerValue()
+						k = keyToProto(IDKey(ins.Key.Path[0].Kind, id+1, nil))
+					}
+					resp.MutationResults = append(resp.MutationResults, &pb.MutationResult{Key: k})
+				}
+				return resp, nil
+			},
+		},
+		writeSettings: &writeSettings{},
+	}
+	return client, func() []int {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]int(nil), commits...)
+	}
+}
+
+func newTestBulkWriter(client *Client, opts ...BulkWriterOption) *BulkWriter {
+	bw := client.BulkWriter(context.Background(), append([]BulkWriterOption{BulkWriterNoThrottling}, opts...)...)
+	bw.backoff = gax.Backoff{Initial: time.Millisecond, Max: time.Millisecond}
+	return bw
+}
+
+func TestBulkWriter(t *testing.T) {
+	type ent struct{ N int }
+	client, commits := newBulkClient(nil)
+	client.WithWriteOptions(MaxBatchSize(100), MaxConcurrentBatches(4))
+	var (
+		mu        sync.Mutex
+		succeeded = map[*BulkWriterJob]*Key{}
+	)
+	bw := newTestBulkWriter(client, BulkWriterOnSuccess(func(j *BulkWriterJob, k *Key) {
+		mu.Lock()
+		succeeded[j] = k
+		mu.Unlock()
+	}))
+
+	const n = 1050
+	jobs := make([]*BulkWriterJob, n)
+	want := make([]*Key, n)
+	for i := range jobs {
+		var (
+			j   *BulkWriterJob
+			err error
+		)
+		switch {
+		case i%3 == 0:
+			j, err = bw.Put(IncompleteKey("Ent", nil), &ent{N: i})
+			want[i] = IDKey("Ent", int64(i+1), nil)
+		case i%3 == 1:
+			want[i] = NameKey("Ent", fmt.Sprint(i), nil)
+			j, err = bw.Put(want[i], &ent{N: i})
+		default:
+			want[i] = NameKey("Ent", fmt.Sprint(i), nil)
+			j, err = bw.Delete(want[i])
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		jobs[i] = j
+	}
+	bw.End()
+
+	for i, j := range jobs {
+		k, err := j.Results()
+		if err != nil || !k.Equal(want[i]) {
+			t.Errorf("%d: got %v, %v, want %v", i, k, err, want[i])
+		}
+		if op := j.Operation(); (op == "delete") != (i%3 == 2) {
+			t.Errorf("%d: got operation %q", i, op)
+		}
+		if !succeeded[j].Equal(k) {
+			t.Errorf("%d: OnSuccess got %v, want %v", i, succeeded[j], k)
+		}
+	}
+	var total int
+	for _, c := range commits() {
+		if c > 100 {
+			t.Errorf("got a commit of %d mutations, want at most 100", c)
+		}
+		total += c
+	}
+	if total != n {
+		t.Errorf("got %d mutations, want %d", total, n)
+	}
+	if p := bw.Progress(); p.Enqueued != n || p.Succeeded != n || p.Failed != 0 || p.Pending != 0 {
+		t.Errorf("got %+v", p)
+	}
+	if _, err := bw.Put(NameKey("Ent", "x", nil), &ent{}); err == nil {
+		t.Error("Put after End: got nil, want error")
+	}
+}
+
+func TestBulkWriterRetry(t *testing.T) {
+	type ent struct{ N int }
+	var (
+		mu    sync.Mutex
+		tries = map[string]int{}
+	)
+	// Commits of the entity "contended" fail with Aborted twice, and those
+	// of the entity "bad" fail.
+	client, _ := newBulkClient(func(req *pb.CommitRequest) error {
+		mu.Lock()
+		defer mu.Unlock()
+		for _, m := range req.Mutations {
+			switch name := m.GetUpsert().Key.Path[0].GetName(); name {
+			case "contended":
+				if tries[name]++; tries[name] <= 2 {
+					return status.Error(codes.Aborted, "too much contention")
+				}
+			case "bad":
+				return errors.New("bad entity")
+			}
+		}
+		return nil
+	})
+	var failed []*BulkWriterJob
+	bw := newTestBulkWriter(client, BulkWriterOnError(func(j *BulkWriterJob, err error) {
+		failed = append(failed, j)
+	}))
+
+	contended, err := bw.Put(NameKey("Ent", "contended", nil), &ent{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// A key can't have two pending writes.
+	if _, err := bw.Put(NameKey("Ent", "contended", nil), &ent{}); err == nil {
+		t.Error("got nil, want error for a second pending write")
+	}
+	bw.Flush()
+	if k, err := contended.Results(); err != nil || k.Name != "contended" {
+		t.Errorf("got %v, %v", k, err)
+	}
+	if got := contended.Attempts(); got != 2 {
+		t.Errorf("got %d attempts, want 2", got)
+	}
+
+	bad, err := bw.Put(NameKey("Ent", "bad", nil), &ent{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	bw.End()
+	if _, err := bad.Results(); err == nil || err.Error() != "bad entity" {
+		t.Errorf("got %v, want bad entity", err)
+	}
+	if len(failed) != 1 || failed[0] != bad {
+		t.Errorf("OnError got %v, want the bad job", failed)
+	}
+	if p := bw.Progress(); p.Enqueued != 2 || p.Succeeded != 1 || p.Failed != 1 || p.Retries != 2 {
+		t.Errorf("got %+v", p)
+	}
+
+	for _, k := range []*Key{nil, IncompleteKey("Ent", nil)} {
+		if _, err := newTestBulkWriter(client).Delete(k); err == nil {
+			t.Errorf("Delete(%v): got nil, want error", k)
+		}
+	}
+}
+
+func TestBulkWriterSplit(t *testing.T) {
+	type ent struct{ N int }
+	// Commits holding the entity "invalid" fail with InvalidArgument.
+	client, commits := newBulkClient(func(req *pb.CommitRequest) error {
+		for _, m := range req.Mutations {
+			if m.GetUpsert().Key.Path[0].GetName() == "invalid" {
+				return status.Error(codes.InvalidArgument, "property too long")
+			}
+		}
+		return nil
+	})
+	client.WithWriteOptions(MaxBatchSize(8))
+	bw := newTestBulkWriter(client)
+
+	var jobs []*BulkWriterJob
+	for i := 0; i < 8; i++ {
+		name := fmt.Sprint(i)
+		if i == 5 {
+			name = "invalid"
+		}
+		j, err := bw.Put(NameKey("Ent", name, nil), &ent{N: i})
+		if err != nil {
+			t.Fatal(err)
+		}
+		jobs = append(jobs, j)
+	}
+	bw.End()
+
+	for i, j := range jobs {
+		_, err := j.Results()
+		if i == 5 {
+			if status.Code(err) != codes.InvalidArgument {
+				t.Errorf("%d: got %v, want InvalidArgument", i, err)
+			}
+		} else if err != nil {
+			t.Errorf("%d: got %v", i, err)
+		}
+	}
+	// The batch of 8 is split until the invalid write is alone: 8, 4, 4, 2,
+	// 2, 1, 1.
+	if got, want := len(commits()), 7; got != want {
+		t.Errorf("got %d commits (%v), want %d", got, commits(), want)
+	}
+	if p := bw.Progress(); p.Succeeded != 7 || p.Failed != 1 {
+		t.Errorf("got %+v", p)
+	}
+}
+
+func TestRampedOpsPerSecond(t *testing.T) {
+	for _, test := range []struct {
+		elapsed time.Duration
+		want    int
+	}{
+		{0, 500},
+		{4 * time.Minute, 500},
+		{5 * time.Minute, 750},
+		{10 * time.Minute, 1125},
+		{time.Hour, 2000},
+	} {
+		if got := rampedOpsPerSecond(500, 2000, test.elapsed); got != test.want {
+			t.Errorf("%v: got %d, want %d", test.elapsed, got, test.want)
+		}
+	}
+}
@@ -17,6 +17,7 @@ package datastore
 import (
 	"context"
 	"fmt"
+	"net/url"
 	"time"
 
 	"cloud.google.com/go/datastore/internal"
@@ -44,13 +45,16 @@ type datastoreClient struct {
 
 func newDatastoreClient(conn grpc.ClientConnInterface, projectID, databaseID string) pb.DatastoreClient {
 	resourcePrefixValue := "projects/" + projectID
+	reqParamsValue := "project_id=" + url.QueryEscape(projectID)
 	if databaseID != "" {
 		resourcePrefixValue += "/databases/" + databaseID
+		reqParamsValue += "&database_id=" + url.QueryEscape(databaseID)
 	}
 	return &datastoreClient{
 		c: pb.NewDatastoreClient(conn),
 		md: metadata.Pairs(
 			resourcePrefixHeader, resourcePrefixValue,
+			reqParamsHeader, reqParamsValue,
 			"x-goog-api-client", fmt.Sprintf("gl-go/%s gccl/%s grpc/", version.Go(), internal.Version)),
 	}
 }
@@ -0,0 +1,500 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+/*
+datastoregen generates Load, Save and LoadKey methods for struct types, which
+make them implement datastore.PropertyLoadSaver and datastore.KeyLoader
+without the reflection that the datastore package otherwise uses to load and
+save them.
+
+It is meant to be run by go generate. For example, given
+
+	//go:generate datastoregen -type=Gopher,Burrow
+
+in a file of a package, go generate writes gopher_datastore.go to the
+directory of the package, with the methods for the types Gopher and Burrow.
+
+The generated methods load and save the fields of the types like the
+datastore package does, honoring the "-", "noindex", "omitempty" and
+"omitzero" options of their struct tags. The types may only have fields of
+the types string, bool, int, int8, int16, int32, int64, float32, float64,
+[]byte, time.Time, datastore.GeoPoint and *datastore.Key, or slices of them,
+and must not embed other types. A *datastore.Key field named "__key__" by its
+tag is also given the key of the entities loaded, by the LoadKey method.
+
+For each type T, datastoregen also declares a variable TFields, which holds a
+datastore.Field for each indexed property of T, so that the names and the
+types of the values of the filters of queries are checked by the compiler:
+
+	q := datastore.NewQuery("Gopher").FilterEntity(GopherFields.Height.Gt(30))
+
+Usage:
+
+	datastoregen -type=T[,T...] [-output=file] [directory]
+*/
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"log"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+const datastorePath = "cloud.google.com/go/datastore"
+
+var (
+	typeNames = flag.String("type", "", "comma-separated list of the struct types; required")
+	output    = flag.String("output", "", "output file name; default <directory>/<type>_datastore.go")
+)
+
+func main() {
+	log.SetFlags(0)
+	log.SetPrefix("datastoregen: ")
+	flag.Parse()
+	if *typeNames == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+	dir := "."
+	if args := flag.Args(); len(args) == 1 {
+		dir = args[0]
+	} else if len(args) > 1 {
+		log.Fatal("at most one directory may be given")
+	}
+	types := strings.Split(*typeNames, ",")
+	src, err := generate(dir, types)
+	if err != nil {
+		log.Fatal(err)
+	}
+	out := *output
+	if out == "" {
+		out = filepath.Join(dir, strings.ToLower(types[0])+"_datastore.go")
+	}
+	if err := os.WriteFile(out, src, 0644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// generate returns the source of a file with the methods of the named
+// struct types of the package in dir.
+func generate(dir string, types []string) ([]byte, error) {
+	fset := token.NewFileSet()
+	// The packages include those of the test files, so that types declared in
+	// them can be used in tests.
+	pkgs, err := parser.ParseDir(fset, dir, nil, parser.ParseComments)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		g       generator
+		pkgName string
+	)
+	for _, name := range types {
+		st, file, pkg, err := findStruct(pkgs, name)
+		if err != nil {
+			return nil, err
+		}
+		if pkgName == "" {
+			pkgName = pkg
+		} else if pkg != pkgName {
+			return nil, fmt.Errorf("%s is declared in package %s, not %s", name, pkg, pkgName)
+		}
+		fields, err := structFields(name, st, file)
+		if err != nil {
+			return nil, err
+		}
+		g.generate(name, fields)
+	}
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "// Code generated by \"datastoregen -type=%s\"; DO NOT EDIT.\n\n", strings.Join(types, ","))
+	fmt.Fprintf(&buf, "package %s\n\n", pkgName)
+	fmt.Fprintf(&buf, "import (\n")
+	if g.usesTime {
+		fmt.Fprintf(&buf, "%q\n\n", "time")
+	}
+	fmt.Fprintf(&buf, "%q\n)\n", datastorePath)
+	buf.Write(g.buf.Bytes())
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("formatting generated code: %v", err)
+	}
+	return src, nil
+}
+
+// findStruct returns the declaration of the struct type name in pkgs, and the
+// file and the name of the package it is declared in.
+func findStruct(pkgs map[string]*ast.Package, name string) (*ast.StructType, *ast.File, string, error) {
+	for _, pkg := range pkgs {
+		for _, file := range pkg.Files {
+			for _, decl := range file.Decls {
+				gd, ok := decl.(*ast.GenDecl)
+				if !ok || gd.Tok != token.TYPE {
+					continue
+				}
+				for _, spec := range gd.Specs {
+					ts := spec.(*ast.TypeSpec)
+					if ts.Name.Name != name {
+						continue
+					}
+					st, ok := ts.Type.(*ast.StructType)
+					if !ok || ts.Assign.IsValid() {
+						return nil, nil, "", fmt.Errorf("%s is not a struct type", name)
+					}
+					if ts.TypeParams != nil {
+						return nil, nil, "", fmt.Errorf("%s is a generic type", name)
+					}
+					return st, file, pkg.Name, nil
+				}
+			}
+		}
+	}
+	return nil, nil, "", fmt.Errorf("type %s not found", name)
+}
+
+// kind is a kind of field type supported by datastoregen.
+type kind int
+
+const (
+	kindInt kind = iota
+	kindFloat
+	kindString
+	kindBool
+	kindBytes
+	kindTime
+	kindGeoPoint
+	kindKey
+)
+
+// field is a field of a struct type, which holds a property.
+type field struct {
+	goName  string // The name of the field.
+	name    string // The name of the property.
+	typ     string // The type of the field, or of its elements if slice.
+	kind    kind
+	slice   bool
+	noIndex bool
+	// omit is "omitempty" or "omitzero" if the field is omitted when it is
+	// empty or zero, or else "".
+	omit string
+}
+
+// structFields returns the fields of the struct type name, which is declared
+// as st in file.
+func structFields(name string, st *ast.StructType, file *ast.File) ([]field, error) {
+	imports := map[string]string{} // Import paths by name.
+	for _, imp := range file.Imports {
+		path, _ := strconv.Unquote(imp.Path.Value)
+		local := path[strings.LastIndex(path, "/")+1:]
+		if imp.Name != nil {
+			local = imp.Name.Name
+		}
+		imports[local] = path
+	}
+
+	var fields []field
+	seen := map[string]string{}
+	for _, f := range st.Fields.List {
+		var tag reflect.StructTag
+		if f.Tag != nil {
+			s, _ := strconv.Unquote(f.Tag.Value)
+			tag = reflect.StructTag(s)
+		}
+		propName, opts, _ := strings.Cut(tag.Get("datastore"), ",")
+		if propName == "-" {
+			continue
+		}
+		if len(f.Names) == 0 {
+			return nil, fmt.Errorf("%s: embedded field %s is not supported", name, exprString(f.Type))
+		}
+		for _, id := range f.Names {
+			if !id.IsExported() {
+				continue
+			}
+			fd := field{goName: id.Name, name: propName}
+			if fd.name == "" {
+				fd.name = id.Name
+			}
+			if err := fd.setOptions(opts); err != nil {
+				return nil, fmt.Errorf("%s.%s: %v", name, id.Name, err)
+			}
+			if err := fd.setType(f.Type, imports); err != nil {
+				return nil, fmt.Errorf("%s.%s: %v", name, id.Name, err)
+			}
+			if other, ok := seen[fd.name]; ok {
+				return nil, fmt.Errorf("%s.%s: property name %q is also used by field %s", name, id.Name, fd.name, other)
+			}
+			seen[fd.name] = id.Name
+			fields = append(fields, fd)
+		}
+	}
+	return fields, nil
+}
+
+func (f *field) setOptions(opts string) error {
+	if opts == "" {
+		return nil
+	}
+	for _, o := range strings.Split(opts, ",") {
+		switch o {
+		case "noindex":
+			f.noIndex = true
+		case "omitempty", "omitzero":
+			f.omit = o
+		default:
+			return fmt.Errorf("option %q is not supported", o)
+		}
+	}
+	return nil
+}
+
+func (f *field) setType(expr ast.Expr, imports map[string]string) error {
+	if at, ok := expr.(*ast.ArrayType); ok && at.Len == nil && !isByte(at.Elt) {
+		f.slice = true
+		expr = at.Elt
+	}
+	f.typ = exprString(expr)
+	switch t := expr.(type) {
+	case *ast.Ident:
+		switch t.Name {
+		case "int", "int8", "int16", "int32", "int64":
+			f.kind = kindInt
+			return nil
+		case "float32", "float64":
+			f.kind = kindFloat
+			return nil
+		case "string":
+			f.kind = kindString
+			return nil
+		case "bool":
+			f.kind = kindBool
+			return nil
+		}
+	case *ast.ArrayType:
+		if t.Len == nil && isByte(t.Elt) {
+			f.kind = kindBytes
+			return nil
+		}
+	case *ast.SelectorExpr:
+		switch qualifiedName(t, imports) {
+		case "time.Time":
+			f.kind = kindTime
+			return nil
+		case datastorePath + ".GeoPoint":
+			f.kind = kindGeoPoint
+			f.typ = "datastore.GeoPoint"
+			return nil
+		}
+	case *ast.StarExpr:
+		if sel, ok := t.X.(*ast.SelectorExpr); ok && qualifiedName(sel, imports) == datastorePath+".Key" {
+			f.kind = kindKey
+			f.typ = "*datastore.Key"
+			return nil
+		}
+	}
+	return fmt.Errorf("type %s is not supported", exprString(expr))
+}
+
+func isByte(expr ast.Expr) bool {
+	id, ok := expr.(*ast.Ident)
+	return ok && (id.Name == "byte" || id.Name == "uint8")
+}
+
+// qualifiedName returns the name of the type sel, qualified by the path of
+// its package.
+func qualifiedName(sel *ast.SelectorExpr, imports map[string]string) string {
+	pkg, ok := sel.X.(*ast.Ident)
+	if !ok {
+		return ""
+	}
+	return imports[pkg.Name] + "." + sel.Sel.Name
+}
+
+// exprString returns the source of the type expression expr.
+func exprString(expr ast.Expr) string {
+	var buf bytes.Buffer
+	format.Node(&buf, token.NewFileSet(), expr)
+	return buf.String()
+}
+
+type generator struct {
+	buf bytes.Buffer
+	// usesTime records whether the generated code refers to the time
+	// package.
+	usesTime bool
+}
+
+func (g *generator) printf(format string, args ...interface{}) {
+	fmt.Fprintf(&g.buf, format, args...)
+}
+
+// generate generates the methods of the struct type name.
+func (g *generator) generate(name string, fields []field) {
+	g.printf("\n// Load implements datastore.PropertyLoadSaver.\n")
+	g.printf("func (x *%s) Load(ps []datastore.Property) error {\n", name)
+	g.printf("l := datastore.NewFieldLoader(x)\n")
+	g.printf("for _, p := range ps {\n")
+	g.printf("switch p.Name {\n")
+	for _, f := range fields {
+		g.printf("case %q:\n", f.name)
+		if f.slice {
+			g.printf("datastore.LoadSlice(l, p, &x.%s, %s)\n", f.goName, f.loadFunc(true))
+		} else {
+			g.printf("%s(l, p, &x.%s)\n", f.loadFunc(false), f.goName)
+		}
+	}
+	g.printf("default:\nl.NoSuchField(p)\n")
+	g.printf("}\n}\nreturn l.Err()\n}\n")
+
+	g.printf("\n// Save implements datastore.PropertyLoadSaver.\n")
+	g.printf("func (x *%s) Save() ([]datastore.Property, error) {\n", name)
+	g.printf("ps := make([]datastore.Property, 0, %d)\n", len(fields))
+	for _, f := range fields {
+		noIndex := ""
+		if f.noIndex {
+			noIndex = ", NoIndex: true"
+		}
+		if f.slice {
+			g.printf("if len(x.%s) > 0 {\n", f.goName)
+			g.printf("vs := make([]interface{}, len(x.%s))\n", f.goName)
+			g.printf("for i, v := range x.%s {\nvs[i] = %s\n}\n", f.goName, f.value("v"))
+			g.printf("ps = append(ps, datastore.Property{Name: %q, Value: vs%s})\n}\n", f.name, noIndex)
+			continue
+		}
+		cond := f.saveCond("x." + f.goName)
+		if cond != "" {
+			g.printf("if %s {\n", cond)
+		}
+		g.printf("ps = append(ps, datastore.Property{Name: %q, Value: %s%s})\n", f.name, f.value("x."+f.goName), noIndex)
+		if cond != "" {
+			g.printf("}\n")
+		}
+	}
+	g.printf("return ps, nil\n}\n")
+
+	for _, f := range fields {
+		if f.name == "__key__" && f.kind == kindKey && !f.slice {
+			g.printf("\n// LoadKey implements datastore.KeyLoader.\n")
+			g.printf("func (x *%s) LoadKey(k *datastore.Key) error {\n", name)
+			g.printf("if k != nil {\nx.%s = k\n}\nreturn nil\n}\n", f.goName)
+		}
+	}
+
+	// The unindexed fields cannot be queried, so they have no Field.
+	var indexed []field
+	for _, f := range fields {
+		if !f.noIndex {
+			indexed = append(indexed, f)
+		}
+	}
+	g.printf("\n// %sFields holds the fields of the indexed properties of %s, for\n", name, name)
+	g.printf("// the filters and orders of queries.\n")
+	g.printf("var %sFields = struct {\n", name)
+	for _, f := range indexed {
+		g.printf("%s datastore.Field[%s]\n", f.goName, f.typ)
+		if f.kind == kindTime {
+			g.usesTime = true
+		}
+	}
+	g.printf("}{\n")
+	for _, f := range indexed {
+		g.printf("%s: datastore.NewField[%s](%q),\n", f.goName, f.typ, f.name)
+	}
+	g.printf("}\n")
+}
+
+// loadFunc returns the function that loads the field, or its elements if
+// elem is true.
+func (f *field) loadFunc(elem bool) string {
+	var fn string
+	switch f.kind {
+	case kindInt:
+		fn = "datastore.LoadInt"
+	case kindFloat:
+		fn = "datastore.LoadFloat"
+	case kindString:
+		fn = "datastore.LoadString"
+	case kindBool:
+		fn = "datastore.LoadBool"
+	case kindBytes:
+		return "datastore.LoadBytes"
+	case kindTime:
+		return "datastore.LoadTime"
+	case kindGeoPoint:
+		return "datastore.LoadGeoPoint"
+	case kindKey:
+		return "datastore.LoadKeyField"
+	}
+	if elem {
+		fn += "[" + f.typ + "]"
+	}
+	return fn
+}
+
+// value returns the expression of the property value of the field, or of an
+// element of it, whose value is x.
+func (f *field) value(x string) string {
+	switch f.kind {
+	case kindInt:
+		if f.typ != "int64" {
+			return "int64(" + x + ")"
+		}
+	case kindFloat:
+		if f.typ != "float64" {
+			return "float64(" + x + ")"
+		}
+	}
+	return x
+}
+
+// saveCond returns the condition for saving the field, whose value is x, or
+// "" if it is always saved.
+func (f *field) saveCond(x string) string {
+	if f.omit == "" {
+		return ""
+	}
+	switch f.kind {
+	case kindInt, kindFloat:
+		return x + " != 0"
+	case kindString:
+		return x + ` != ""`
+	case kindBool:
+		return x
+	case kindBytes:
+		if f.omit == "omitzero" {
+			return x + " != nil"
+		}
+		return "len(" + x + ") > 0"
+	case kindTime:
+		return "!" + x + ".IsZero()"
+	case kindGeoPoint:
+		if f.omit == "omitzero" {
+			return x + " != (datastore.GeoPoint{})"
+		}
+	case kindKey:
+		return x + " != nil"
+	}
+	return ""
+}
@@ -0,0 +1,71 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"cloud.google.com/go/internal/testutil"
+)
+
+var updateGolden = flag.Bool("update-golden", false, "update the golden file")
+
+func TestGenerate(t *testing.T) {
+	got, err := generate(filepath.Join("testdata", "gophers"), []string{"Gopher", "Burrow"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	golden := filepath.Join("testdata", "gopher_datastore.go.golden")
+	if *updateGolden {
+		if err := os.WriteFile(golden, got, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	want, err := os.ReadFile(golden)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := testutil.Diff(string(got), string(want)); diff != "" {
+		t.Errorf("(-got +want)\n%s", diff)
+	}
+}
+
+func TestGenerateErrors(t *testing.T) {
+	for _, test := range []struct {
+		src, want string
+	}{
+		{"type T int", "T is not a struct type"},
+		{"type T[E any] struct{ E E }", "T is a generic type"},
+		{"type U struct{}", "type T not found"},
+		{"type T struct{ N uint }", "T.N: type uint is not supported"},
+		{"type T struct{ M map[string]int }", "T.M: type map[string]int is not supported"},
+		{"type T struct{ S struct{ A int } `datastore:\",flatten\"` }", `T.S: option "flatten" is not supported`},
+		{"type T struct{ U }; type U struct{ A int }", "T: embedded field U is not supported"},
+		{"type T struct{ A int; B int `datastore:\"A\"` }", `T.B: property name "A" is also used by field A`},
+	} {
+		dir := t.TempDir()
+		if err := os.WriteFile(filepath.Join(dir, "t.go"), []byte("package p\n\n"+test.src+"\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+		_, err := generate(dir, []string{"T"})
+		if err == nil || !strings.Contains(err.Error(), test.want) {
+			t.Errorf("%s: got %v, want error containing %q", test.src, err, test.want)
+		}
+	}
+}
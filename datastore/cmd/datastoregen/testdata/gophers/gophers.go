@@ -0,0 +1,41 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gophers
+
+import (
+	"time"
+
+	ds "cloud.google.com/go/datastore"
+)
+
+type Gopher struct {
+	K        *ds.Key `datastore:"__key__"`
+	Name     string
+	Height   int8
+	Weight   float32 `datastore:"weight,noindex"`
+	Admin    bool    `datastore:",omitempty"`
+	Photo    []byte  `datastore:",noindex"`
+	Born     time.Time
+	Home     ds.GeoPoint `datastore:",omitzero"`
+	Burrow   *ds.Key
+	Tags     []string
+	Scores   []int
+	Secret   string `datastore:"-"`
+	internal int
+}
+
+type Burrow struct {
+	Depth, Width float64
+}
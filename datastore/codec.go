@@ -0,0 +1,92 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datastore
+
+import (
+	"reflect"
+	"sync"
+
+	"cloud.google.com/go/internal/fields"
+)
+
+// A structCodec describes how the properties of an entity are loaded into and
+// saved from a struct type. It is computed once for each type, so that the
+// reflection it takes is not repeated for each entity.
+type structCodec struct {
+	fields fields.List
+	// byName maps the names of the fields to them, so that the fields of
+	// properties are found without comparing their names to all the fields.
+	byName map[string]*fields.Field
+}
+
+// codecCache holds the *structCodec of each struct type.
+var codecCache sync.Map // map[reflect.Type]*structCodec
+
+// codecFor returns the structCodec of the struct type t.
+func codecFor(t reflect.Type) (*structCodec, error) {
+	if c, ok := codecCache.Load(t); ok {
+		return c.(*structCodec), nil
+	}
+	fs, err := structCache.Fields(t)
+	if err != nil {
+		return nil, err
+	}
+	c := &structCodec{fields: fs, byName: make(map[string]*fields.Field, len(fs))}
+	for i := range fs {
+		if _, ok := c.byName[fs[i].Name]; !ok {
+			c.byName[fs[i].Name] = &fs[i]
+		}
+	}
+	cc, _ := codecCache.LoadOrStore(t, c)
+	return cc.(*structCodec), nil
+}
+
+// match returns the field of the property name, preferring an exact match
+// to a case-insensitive one, or nil if there is none.
+func (c *structCodec) match(name string) *fields.Field {
+	if f, ok := c.byName[name]; ok {
+		return f
+	}
+	return c.fields.Match(name)
+}
+
+// A typeInfo records which of the interfaces of the datastore package a type
+// may implement, which is costly to find for each value.
+type typeInfo struct {
+	// pls reports whether values of the type may implement
+	// PropertyLoadSaver: whether the type or a pointer to it does, or it is
+	// an interface type.
+	pls bool
+	// converter reports whether the type or a pointer to it implements
+	// PropertyConverter.
+	converter bool
+}
+
+// typeInfoCache holds the typeInfo of each type.
+var typeInfoCache sync.Map // map[reflect.Type]typeInfo
+
+// typeInfoOf returns the typeInfo of t.
+func typeInfoOf(t reflect.Type) typeInfo {
+	if ti, ok := typeInfoCache.Load(t); ok {
+		return ti.(typeInfo)
+	}
+	ti := typeInfo{
+		pls: t.Kind() == reflect.Interface || t.Implements(typeOfPropertyLoadSaver) ||
+			reflect.PtrTo(t).Implements(typeOfPropertyLoadSaver),
+		converter: isConverterType(t),
+	}
+	typeInfoCache.Store(t, ti)
+	return ti
+}
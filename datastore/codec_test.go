@@ -0,0 +1,109 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datastore
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestStructCodec(t *testing.T) {
+	type S struct {
+		Name  string
+		Other string `datastore:"name"`
+		Case  int
+	}
+	c, err := codecFor(reflect.TypeOf(S{}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c2, _ := codecFor(reflect.TypeOf(S{})); c2 != c {
+		t.Error("codec is not cached")
+	}
+	for name, want := range map[string]string{
+		"Name":  "Name",
+		"name":  "name",
+		"CASE":  "Case",
+		"other": "",
+	} {
+		f := c.match(name)
+		got := ""
+		if f != nil {
+			got = f.Name
+		}
+		if got != want {
+			t.Errorf("match(%q): got field %q, want %q", name, got, want)
+		}
+	}
+
+	if ti := typeInfoOf(reflect.TypeOf(Decimal{})); ti.pls || !ti.converter {
+		t.Errorf("Decimal: got %+v", ti)
+	}
+	if ti := typeInfoOf(reflect.TypeOf(PropertyList{})); !ti.pls || ti.converter {
+		t.Errorf("PropertyList: got %+v", ti)
+	}
+	if ti := typeInfoOf(reflect.TypeOf((*interface{})(nil)).Elem()); !ti.pls {
+		t.Errorf("interface{}: got %+v", ti)
+	}
+}
+
+// wideEntity has many fields, as the entities of many applications do.
+type wideEntity struct {
+	S0, S1, S2, S3, S4, S5, S6, S7, S8, S9 string
+	I0, I1, I2, I3, I4, I5, I6, I7, I8, I9 int64
+	F0, F1, F2, F3, F4                     float64
+	B0, B1, B2, B3, B4                     bool
+	T0, T1, T2, T3, T4                     time.Time
+	K                                      *Key
+	Tags                                   []string
+}
+
+func newWideEntity() *wideEntity {
+	now := time.Date(2023, 9, 1, 12, 0, 0, 0, time.UTC)
+	return &wideEntity{
+		S0: "zero", S5: "five", S9: "nine",
+		I0: 0, I3: 3, I9: 9,
+		F1: 1.5, B2: true,
+		T0: now, T4: now,
+		K:    NameKey("Kind", "name", nil),
+		Tags: []string{"a", "b", "c"},
+	}
+}
+
+func BenchmarkSaveWideEntity(b *testing.B) {
+	src := newWideEntity()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := saveEntity(testKey0, src); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkLoadWideEntity(b *testing.B) {
+	e, err := saveEntity(testKey0, newWideEntity())
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		var dst wideEntity
+		if err := loadEntityProto(&dst, e); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
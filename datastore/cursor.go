@@ -0,0 +1,164 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datastore
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"strings"
+
+	pb "google.golang.org/genproto/googleapis/datastore/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+// ErrCursorMismatch is returned by Query.DecodeCursor when a cursor was
+// encoded for a different query.
+var ErrCursorMismatch = errors.New("datastore: cursor does not belong to the query")
+
+const (
+	// keyCursorPrefix begins the string representation of the cursors made
+	// by CursorAfterKey. The dot is not a base-64 character, so they are not
+	// confused with the cursors of Datastore.
+	keyCursorPrefix = "k."
+	// queryCursorPrefix begins the representation of a cursor by
+	// Query.EncodeCursor, which is followed by the fingerprint of the query,
+	// a dot, and the representation of the cursor by Cursor.String.
+	queryCursorPrefix = "q."
+)
+
+// CursorAfterKey returns a cursor positioned just after the entity with the
+// complete key k, whether or not it exists. As a start cursor, a query returns
+// the results after k; as an end cursor, the results up to and including k.
+// If k is nil, it returns the zero Cursor.
+//
+// Unlike the cursors of an Iterator, which only Datastore can make, such a
+// cursor is applied as a filter on the key, so it can only be used with a
+// query without orders, or with only an order by key, like a query of a kind.
+// A query with a start cursor made by CursorAfterKey returns cursors of
+// Datastore once it has read a result; they are only valid for a query with
+// the same start cursor.
+func CursorAfterKey(k *Key) Cursor {
+	return Cursor{key: k}
+}
+
+// keyCursorFilters returns the filters on the key of the start and end
+// cursors of q that were made by CursorAfterKey.
+func (q *Query) keyCursorFilters() ([]*pb.Filter, error) {
+	if q.startKey == nil && q.endKey == nil {
+		return nil, nil
+	}
+	desc := false
+	switch {
+	case len(q.order) == 0:
+	case len(q.order) == 1 && q.order[0].FieldName == keyFieldName:
+		desc = q.order[0].Direction == descending
+	default:
+		return nil, errors.New("datastore: a cursor made from a key requires a query without orders or with only an order by key")
+	}
+	startOp, endOp := pb.PropertyFilter_GREATER_THAN, pb.PropertyFilter_LESS_THAN_OR_EQUAL
+	if desc {
+		startOp, endOp = pb.PropertyFilter_LESS_THAN, pb.PropertyFilter_GREATER_THAN_OR_EQUAL
+	}
+	var filters []*pb.Filter
+	for _, kf := range []struct {
+		key *Key
+		op  pb.PropertyFilter_Operator
+	}{{q.startKey, startOp}, {q.endKey, endOp}} {
+		if kf.key == nil {
+			continue
+		}
+		filters = append(filters, &pb.Filter{
+			FilterType: &pb.Filter_PropertyFilter{PropertyFilter: &pb.PropertyFilter{
+				Property: &pb.PropertyReference{Name: keyFieldName},
+				Op:       kf.op,
+				Value:    &pb.Value{ValueType: &pb.Value_KeyValue{KeyValue: keyToProto(kf.key)}},
+			}}})
+	}
+	return filters, nil
+}
+
+// EncodeCursor returns a string representation of c that also records q, so
+// that Query.DecodeCursor can check that the cursor is used with the same
+// query. The representation is stable: it is "q.", followed by a fingerprint
+// of the namespace, kind, ancestor, filters and orders of q, a dot, and the
+// representation of c by Cursor.String. The limit, offset, cursors,
+// projection and distinct options of q, and its read options, are not
+// recorded, so the cursor can be used with a query that only differs by them.
+func (q *Query) EncodeCursor(c Cursor) (string, error) {
+	fp, err := q.cursorFingerprint()
+	if err != nil {
+		return "", err
+	}
+	return queryCursorPrefix + fp + "." + c.String(), nil
+}
+
+// DecodeCursor decodes a cursor from its representation by
+// Query.EncodeCursor, and returns ErrCursorMismatch if it was encoded for a
+// query which differs from q other than by the options that
+// Query.EncodeCursor does not record.
+func (q *Query) DecodeCursor(s string) (Cursor, error) {
+	if !strings.HasPrefix(s, queryCursorPrefix) {
+		return Cursor{}, errors.New("datastore: cursor was not encoded by Query.EncodeCursor")
+	}
+	got, c, err := splitQueryCursor(s)
+	if err != nil {
+		return Cursor{}, err
+	}
+	want, err := q.cursorFingerprint()
+	if err != nil {
+		return Cursor{}, err
+	}
+	if got != want {
+		return Cursor{}, ErrCursorMismatch
+	}
+	return DecodeCursor(c)
+}
+
+// splitQueryCursor splits s, a representation of a cursor by
+// Query.EncodeCursor, into the fingerprint of the query and the
+// representation of the cursor.
+func splitQueryCursor(s string) (fingerprint, cursor string, err error) {
+	fingerprint, cursor, ok := strings.Cut(strings.TrimPrefix(s, queryCursorPrefix), ".")
+	if !ok || fingerprint == "" || strings.HasPrefix(cursor, queryCursorPrefix) {
+		return "", "", errors.New("datastore: malformed query cursor")
+	}
+	return fingerprint, cursor, nil
+}
+
+// cursorFingerprint returns a short hash of the namespace, kind, ancestor,
+// filters and orders of q.
+func (q *Query) cursorFingerprint() (string, error) {
+	if q.err != nil {
+		return "", q.err
+	}
+	base := NewQuery(q.kind)
+	base.ancestor = q.ancestor
+	base.filter = q.filter
+	base.order = q.order
+	pq, err := base.toProto()
+	if err != nil {
+		return "", err
+	}
+	b, err := proto.MarshalOptions{Deterministic: true}.Marshal(pq)
+	if err != nil {
+		return "", err
+	}
+	h := sha256.New()
+	h.Write([]byte(q.namespace))
+	h.Write([]byte{0})
+	h.Write(b)
+	return base64.RawURLEncoding.EncodeToString(h.Sum(nil)[:12]), nil
+}
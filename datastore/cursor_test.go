@@ -0,0 +1,180 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datastore
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"cloud.google.com/go/internal/testutil"
+	"github.com/google/go-cmp/cmp"
+	pb "google.golang.org/genproto/googleapis/datastore/v1"
+)
+
+func TestCursorString(t *testing.T) {
+	key := NameKey("Gopher", "george", IDKey("Burrow", 1, nil))
+	for _, c := range []Cursor{
+		{},
+		{cc: []byte{0, 1, 2, 0xfe, 0xff}},
+		CursorAfterKey(key),
+	} {
+		s := c.String()
+		got, err := DecodeCursor(s)
+		if err != nil {
+			t.Fatalf("DecodeCursor(%q): %v", s, err)
+		}
+		if diff := testutil.Diff(got, c, cmp.AllowUnexported(Cursor{})); diff != "" {
+			t.Errorf("DecodeCursor(%q): got=-, want=+\n%s", s, diff)
+		}
+	}
+
+	// The representations are stable.
+	if got, want := (Cursor{cc: []byte("cursor")}).String(), "Y3Vyc29y"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+	if got, want := CursorAfterKey(key).String(), "k."+key.Encode(); got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+
+	for _, s := range []string{
+		"k.",
+		"k.!",
+		"k." + IncompleteKey("Gopher", nil).Encode(),
+		"q.",
+		"q.abc",
+		"q..Y3Vyc29y",
+		"q.abc.q.abc.Y3Vyc29y",
+	} {
+		if _, err := DecodeCursor(s); err == nil {
+			t.Errorf("DecodeCursor(%q): got nil, want error", s)
+		}
+	}
+}
+
+func TestQueryCursor(t *testing.T) {
+	q := NewQuery("Gopher").FilterField("Height", ">", 10).Order("Height").Namespace("ns")
+	for _, c := range []Cursor{
+		{},
+		{cc: []byte("cursor")},
+		CursorAfterKey(IDKey("Gopher", 6, nil)),
+	} {
+		s, err := q.EncodeCursor(c)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !strings.HasPrefix(s, "q.") || !strings.HasSuffix(s, "."+c.String()) {
+			t.Errorf("EncodeCursor: got %q", s)
+		}
+		// The options that do not change the positions of the results do
+		// not change the fingerprint.
+		for _, q := range []*Query{
+			q,
+			q.Limit(5).Offset(2).KeysOnly().Start(c).EventualConsistency(),
+		} {
+			got, err := q.DecodeCursor(s)
+			if err != nil {
+				t.Fatalf("DecodeCursor(%q): %v", s, err)
+			}
+			if diff := testutil.Diff(got, c, cmp.AllowUnexported(Cursor{})); diff != "" {
+				t.Errorf("DecodeCursor(%q): got=-, want=+\n%s", s, diff)
+			}
+		}
+		// Other queries reject the cursor.
+		for _, q := range []*Query{
+			NewQuery("Gopher").FilterField("Height", ">", 10).Order("Height"),
+			NewQuery("Gopher").FilterField("Height", ">", 11).Order("Height").Namespace("ns"),
+			NewQuery("Gopher").FilterField("Height", ">", 10).Order("-Height").Namespace("ns"),
+			NewQuery("Gopher").FilterField("Height", ">", 10).Order("Height").Namespace("ns").Ancestor(IDKey("Burrow", 1, nil)),
+			NewQuery("Burrow").FilterField("Height", ">", 10).Order("Height").Namespace("ns"),
+		} {
+			if _, err := q.DecodeCursor(s); err != ErrCursorMismatch {
+				t.Errorf("DecodeCursor(%q) for %+v: got %v, want ErrCursorMismatch", s, q, err)
+			}
+		}
+		// The cursor can also be decoded without its query.
+		if got, err := DecodeCursor(s); err != nil || !testutil.Equal(got, c, cmp.AllowUnexported(Cursor{})) {
+			t.Errorf("DecodeCursor(%q) = %v, %v; want %v", s, got, err, c)
+		}
+	}
+
+	if _, err := q.DecodeCursor("Y3Vyc29y"); err == nil {
+		t.Error("DecodeCursor of an unbound cursor: got nil, want error")
+	}
+}
+
+func TestCursorAfterKey(t *testing.T) {
+	ctx := context.Background()
+	keys := partitionKeys()
+	client := newPartitionClient(keys)
+
+	start, end := keys[10], keys[20]
+	got, err := client.GetAll(ctx, NewQuery("Gopher").KeysOnly().
+		Start(CursorAfterKey(start)).End(CursorAfterKey(end)), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := testutil.Diff(got, keys[11:21]); diff != "" {
+		t.Errorf("got=-, want=+\n%s", diff)
+	}
+
+	// Until it reads a result, an iterator is at its start cursor.
+	it := client.Run(ctx, NewQuery("Gopher").Start(CursorAfterKey(start)))
+	c, err := it.Cursor()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := testutil.Diff(c, CursorAfterKey(start), cmp.AllowUnexported(Cursor{})); diff != "" {
+		t.Errorf("Cursor: got=-, want=+\n%s", diff)
+	}
+
+	for _, test := range []struct {
+		q    *Query
+		want []pb.PropertyFilter_Operator
+	}{
+		{NewQuery("Gopher").Start(CursorAfterKey(start)), []pb.PropertyFilter_Operator{pb.PropertyFilter_GREATER_THAN}},
+		{NewQuery("Gopher").End(CursorAfterKey(end)), []pb.PropertyFilter_Operator{pb.PropertyFilter_LESS_THAN_OR_EQUAL}},
+		{
+			NewQuery("Gopher").Order("-__key__").Start(CursorAfterKey(start)).End(CursorAfterKey(end)),
+			[]pb.PropertyFilter_Operator{pb.PropertyFilter_LESS_THAN, pb.PropertyFilter_GREATER_THAN_OR_EQUAL},
+		},
+	} {
+		p, err := test.q.toProto()
+		if err != nil {
+			t.Fatal(err)
+		}
+		var got []pb.PropertyFilter_Operator
+		if cf := p.Filter.GetCompositeFilter(); cf != nil {
+			for _, f := range cf.Filters {
+				got = append(got, f.GetPropertyFilter().Op)
+			}
+		} else {
+			got = append(got, p.Filter.GetPropertyFilter().Op)
+		}
+		if diff := testutil.Diff(got, test.want); diff != "" {
+			t.Errorf("%+v: got=-, want=+\n%s", test.q, diff)
+		}
+	}
+
+	for _, q := range []*Query{
+		NewQuery("Gopher").Order("Height").Start(CursorAfterKey(start)),
+		NewQuery("Gopher").Order("__key__").Order("Height").End(CursorAfterKey(end)),
+		NewQuery("Gopher").Start(CursorAfterKey(IncompleteKey("Gopher", nil))),
+	} {
+		if _, err := client.GetAll(ctx, q.KeysOnly(), nil); err == nil {
+			t.Errorf("%+v: got nil, want error", q)
+		}
+	}
+}
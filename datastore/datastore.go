@@ -21,6 +21,8 @@ import (
 	"log"
 	"os"
 	"reflect"
+	"strings"
+	"sync"
 	"time"
 
 	"cloud.google.com/go/internal/trace"
@@ -53,6 +55,10 @@ const DetectProjectID = "*detect-project-id*"
 // the resource being operated on.
 const resourcePrefixHeader = "google-cloud-resource-prefix"
 
+// reqParamsHeader is the name of the metadata header used to route requests
+// to the project and database being operated on.
+const reqParamsHeader = "x-goog-request-params"
+
 // DefaultDatabaseID is ID of the default database denoted by an empty string
 const DefaultDatabaseID = ""
 
@@ -63,11 +69,12 @@ var (
 
 // Client is a client for reading and writing data in a datastore dataset.
 type Client struct {
-	connPool     gtransport.ConnPool
-	client       pb.DatastoreClient
-	dataset      string // Called dataset by the datastore API, synonym for project ID.
-	databaseID   string // Default value is empty string
-	readSettings *readSettings
+	connPool      gtransport.ConnPool
+	client        pb.DatastoreClient
+	dataset       string // Called dataset by the datastore API, synonym for project ID.
+	databaseID    string // Default value is empty string
+	readSettings  *readSettings
+	writeSettings *writeSettings
 }
 
 // NewClient creates a new Client for a given dataset.  If the project ID is
@@ -145,11 +152,12 @@ func NewClientWithDatabase(ctx context.Context, projectID, databaseID string, op
 		return nil, fmt.Errorf("dialing: %w", err)
 	}
 	return &Client{
-		connPool:     connPool,
-		client:       newDatastoreClient(connPool, projectID, databaseID),
-		dataset:      projectID,
-		readSettings: &readSettings{},
-		databaseID:   databaseID,
+		connPool:      connPool,
+		client:        newDatastoreClient(connPool, projectID, databaseID),
+		dataset:       projectID,
+		readSettings:  &readSettings{},
+		writeSettings: &writeSettings{},
+		databaseID:    databaseID,
 	}, nil
 }
 
@@ -184,6 +192,7 @@ const (
 	multiArgTypeStruct
 	multiArgTypeStructPtr
 	multiArgTypeInterface
+	multiArgTypeMap
 )
 
 // ErrFieldMismatch is returned when a field is to be loaded into a different
@@ -191,15 +200,63 @@ const (
 // unexported in the destination struct.
 // StructType is the type of the struct pointed to by the destination argument
 // passed to Get or to Iterator.Next.
+//
+// If several fields of an entity cannot be loaded, the ErrFieldMismatch
+// describes the last one, and errors.As with a *FieldMismatchErrors target
+// retrieves all of them.
 type ErrFieldMismatch struct {
 	StructType reflect.Type
 	FieldName  string
 	Reason     string
+
+	all *FieldMismatchErrors // All the mismatches, if there are several.
 }
 
 func (e *ErrFieldMismatch) Error() string {
-	return fmt.Sprintf("datastore: cannot load field %q into a %q: %s",
+	msg := fmt.Sprintf("datastore: cannot load field %q into a %q: %s",
 		e.FieldName, e.StructType, e.Reason)
+	if e.all != nil {
+		msg += fmt.Sprintf(" (and %d other fields)", len(*e.all)-1)
+	}
+	return msg
+}
+
+// As sets a *FieldMismatchErrors target to all the field mismatches of the
+// entity, for errors.As.
+func (e *ErrFieldMismatch) As(target interface{}) bool {
+	t, ok := target.(*FieldMismatchErrors)
+	if !ok {
+		return false
+	}
+	if e.all != nil {
+		*t = *e.all
+	} else {
+		*t = FieldMismatchErrors{e}
+	}
+	return true
+}
+
+// FieldMismatchErrors lists all the fields of an entity that could not be
+// loaded into a struct, in the order of the entity's properties. It is
+// retrieved from an error with errors.As:
+//
+//	var mismatches datastore.FieldMismatchErrors
+//	if errors.As(err, &mismatches) {
+//		for _, m := range mismatches {
+//			log.Printf("%s: %s", m.FieldName, m.Reason)
+//		}
+//	}
+type FieldMismatchErrors []*ErrFieldMismatch
+
+func (e FieldMismatchErrors) Error() string {
+	var b strings.Builder
+	for i, m := range e {
+		if i > 0 {
+			b.WriteString("; ")
+		}
+		b.WriteString(m.Error())
+	}
+	return b.String()
 }
 
 // GeoPoint represents a location as latitude/longitude in degrees.
@@ -319,12 +376,13 @@ func multiValid(key []*Key) error {
 	return err
 }
 
-// checkMultiArg checks that v has type []S, []*S, []I, or []P, for some struct
-// type S, for some interface type I, or some non-interface non-pointer type P
-// such that P or *P implements PropertyLoadSaver.
+// checkMultiArg checks that v has type []S, []*S, []I, []P or
+// []map[string]interface{}, for some struct type S, for some interface type
+// I, or some non-interface non-pointer type P such that P or *P implements
+// PropertyLoadSaver.
 //
 // It returns what category the slice's elements are, and the reflect.Type
-// that represents S, I or P.
+// that represents S, I, P or map[string]interface{}.
 //
 // As a special case, PropertyList is an invalid type for v.
 func checkMultiArg(v reflect.Value) (m multiArgType, elemType reflect.Type) {
@@ -350,6 +408,10 @@ func checkMultiArg(v reflect.Value) (m multiArgType, elemType reflect.Type) {
 		if elemType.Kind() == reflect.Struct {
 			return multiArgTypeStructPtr, elemType
 		}
+	case reflect.Map:
+		if elemType == typeOfInterfaceMap {
+			return multiArgTypeMap, elemType
+		}
 	}
 	return multiArgTypeInvalid, nil
 }
@@ -380,17 +442,7 @@ func (c *Client) Get(ctx context.Context, key *Key, dst interface{}) (err error)
 		return fmt.Errorf("%w: dst cannot be nil", ErrInvalidEntityType)
 	}
 
-	var opts *pb.ReadOptions
-	if !c.readSettings.readTime.IsZero() {
-		opts = &pb.ReadOptions{
-			ConsistencyType: &pb.ReadOptions_ReadTime{
-				// Timestamp cannot be less than microseconds accuracy. See #6938
-				ReadTime: &timestamppb.Timestamp{Seconds: c.readSettings.readTime.Unix()},
-			},
-		}
-	}
-
-	err = c.get(ctx, []*Key{key}, []interface{}{dst}, opts)
+	err = c.get(ctx, []*Key{key}, []interface{}{dst}, c.readSettings.readOptions())
 	if me, ok := err.(MultiError); ok {
 		return me[0]
 	}
@@ -408,22 +460,16 @@ func (c *Client) Get(ctx context.Context, key *Key, dst interface{}) (err error)
 // PropertyList is a slice of structs. It is treated as invalid to avoid being
 // mistakenly passed when []PropertyList was intended.
 //
+// GetMulti looks up any number of keys: it splits them into Lookup requests
+// of at most 1000 keys, the limit of the API, which are made one at a time,
+// or as many at a time as LookupConcurrency allows.
+//
 // err may be a MultiError. See ExampleMultiError to check it.
 func (c *Client) GetMulti(ctx context.Context, keys []*Key, dst interface{}) (err error) {
 	ctx = trace.StartSpan(ctx, "cloud.google.com/go/datastore.GetMulti")
 	defer func() { trace.EndSpan(ctx, err) }()
 
-	var opts *pb.ReadOptions
-	if c.readSettings != nil && !c.readSettings.readTime.IsZero() {
-		opts = &pb.ReadOptions{
-			ConsistencyType: &pb.ReadOptions_ReadTime{
-				// Timestamp cannot be less than microseconds accuracy. See #6938
-				ReadTime: &timestamppb.Timestamp{Seconds: c.readSettings.readTime.Unix()},
-			},
-		}
-	}
-
-	return c.get(ctx, keys, dst, opts)
+	return c.get(ctx, keys, dst, c.readSettings.readOptions())
 }
 
 func (c *Client) get(ctx context.Context, keys []*Key, dst interface{}, opts *pb.ReadOptions) error {
@@ -491,31 +537,10 @@ func (c *Client) get(ctx context.Context, keys []*Key, dst interface{}, opts *pb
 	if any {
 		return multiErr
 	}
-	req := &pb.LookupRequest{
-		ProjectId:   c.dataset,
-		DatabaseId:  c.databaseID,
-		Keys:        pbKeys,
-		ReadOptions: opts,
-	}
-	resp, err := c.client.Lookup(ctx, req)
+	found, missing, err := c.lookup(ctx, pbKeys, opts)
 	if err != nil {
 		return err
 	}
-	found := resp.Found
-	missing := resp.Missing
-	// Upper bound 1000 iterations to prevent infinite loop. This matches the max
-	// number of Entities you can request from Datastore.
-	// Note that if ctx has a deadline, the deadline will probably
-	// be hit before we reach 1000 iterations.
-	for i := 0; len(resp.Deferred) > 0 && i < 1000; i++ {
-		req.Keys = resp.Deferred
-		resp, err = c.client.Lookup(ctx, req)
-		if err != nil {
-			return err
-		}
-		found = append(found, resp.Found...)
-		missing = append(missing, resp.Missing...)
-	}
 
 	filled := 0
 	for _, e := range found {
@@ -532,7 +557,7 @@ func (c *Client) get(ctx context.Context, keys []*Key, dst interface{}, opts *pb
 			if multiArgType == multiArgTypeStructPtr && elem.IsNil() {
 				elem.Set(reflect.New(elem.Type().Elem()))
 			}
-			if err := loadEntityProto(elem.Interface(), e.Entity); err != nil {
+			if err := c.readSettings.loadError(loadEntityProto(elem.Interface(), e.Entity)); err != nil {
 				multiErr[index] = err
 				any = true
 			}
@@ -560,6 +585,114 @@ func (c *Client) get(ctx context.Context, keys []*Key, dst interface{}, opts *pb
 	return nil
 }
 
+// maxLookupKeys is the maximum number of keys of a Lookup request.
+const maxLookupKeys = 1000
+
+// lookup looks up keys with Lookup requests of at most maxLookupKeys keys,
+// as many at a time as the lookup concurrency of the client allows, and
+// returns the entities found and missing.
+func (c *Client) lookup(ctx context.Context, keys []*pb.Key, opts *pb.ReadOptions) (found, missing []*pb.EntityResult, err error) {
+	if len(keys) <= maxLookupKeys {
+		return c.lookupChunk(ctx, keys, opts)
+	}
+	var chunks [][]*pb.Key
+	for len(keys) > 0 {
+		n := len(keys)
+		if n > maxLookupKeys {
+			n = maxLookupKeys
+		}
+		chunks, keys = append(chunks, keys[:n]), keys[n:]
+	}
+	concurrency := 1
+	if c.readSettings != nil && c.readSettings.lookupConcurrency > 1 {
+		concurrency = c.readSettings.lookupConcurrency
+	}
+	if concurrency == 1 {
+		for _, chunk := range chunks {
+			f, m, err := c.lookupChunk(ctx, chunk, opts)
+			if err != nil {
+				return nil, nil, err
+			}
+			found, missing = append(found, f...), append(missing, m...)
+		}
+		return found, missing, nil
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		firstErr error
+		sem      = make(chan struct{}, concurrency)
+	)
+	for _, chunk := range chunks {
+		chunk := chunk
+		// Stop scheduling chunks once a lookup has failed, which cancels ctx.
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+		}
+		if ctx.Err() != nil {
+			break
+		}
+		wg.Add(1)
+		go func() {
+			defer func() { <-sem; wg.Done() }()
+			f, m, err := c.lookupChunk(ctx, chunk, opts)
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				if firstErr == nil {
+					firstErr = err
+					cancel()
+				}
+				return
+			}
+			found, missing = append(found, f...), append(missing, m...)
+		}()
+	}
+	wg.Wait()
+	if firstErr == nil {
+		firstErr = ctx.Err()
+	}
+	if firstErr != nil {
+		return nil, nil, firstErr
+	}
+	return found, missing, nil
+}
+
+// lookupChunk looks up keys, which are at most maxLookupKeys, including the
+// keys whose lookup the server defers.
+func (c *Client) lookupChunk(ctx context.Context, keys []*pb.Key, opts *pb.ReadOptions) (found, missing []*pb.EntityResult, err error) {
+	req := &pb.LookupRequest{
+		ProjectId:   c.dataset,
+		DatabaseId:  c.databaseID,
+		Keys:        keys,
+		ReadOptions: opts,
+	}
+	resp, err := c.client.Lookup(ctx, req)
+	if err != nil {
+		return nil, nil, err
+	}
+	found = resp.Found
+	missing = resp.Missing
+	// Upper bound 1000 iterations to prevent infinite loop. This matches the max
+	// number of Entities you can request from Datastore.
+	// Note that if ctx has a deadline, the deadline will probably
+	// be hit before we reach 1000 iterations.
+	for i := 0; len(resp.Deferred) > 0 && i < 1000; i++ {
+		req.Keys = resp.Deferred
+		resp, err = c.client.Lookup(ctx, req)
+		if err != nil {
+			return nil, nil, err
+		}
+		found = append(found, resp.Found...)
+		missing = append(missing, resp.Missing...)
+	}
+	return found, missing, nil
+}
+
 // Put saves the entity src into the datastore with the given key. src must be
 // a struct pointer or implement PropertyLoadSaver; if the struct pointer has
 // any unexported fields they will be skipped. If the key is incomplete, the
@@ -579,6 +712,12 @@ func (c *Client) Put(ctx context.Context, key *Key, src interface{}) (*Key, erro
 //
 // src must satisfy the same conditions as the dst argument to GetMulti.
 // err may be a MultiError. See ExampleMultiError to check it.
+//
+// The entities are saved with Commit requests of at most MaxBatchSize
+// entities, which are made as many at a time as MaxConcurrentBatches allows.
+// If some of several requests fail, err is a MultiError with the error of
+// each failed request at the indexes of its entities, and ret has the keys
+// of the entities which were saved.
 func (c *Client) PutMulti(ctx context.Context, keys []*Key, src interface{}) (ret []*Key, err error) {
 	// TODO(jba): rewrite in terms of Mutate.
 	ctx = trace.StartSpan(ctx, "cloud.google.com/go/datastore.PutMulti")
@@ -589,30 +728,39 @@ func (c *Client) PutMulti(ctx context.Context, keys []*Key, src interface{}) (re
 		return nil, err
 	}
 
-	// Make the request.
-	req := &pb.CommitRequest{
-		ProjectId:  c.dataset,
-		DatabaseId: c.databaseID,
-		Mutations:  mutations,
-		Mode:       pb.CommitRequest_NON_TRANSACTIONAL,
-	}
-	resp, err := c.client.Commit(ctx, req)
-	if err != nil {
-		return nil, err
-	}
-
-	// Copy any newly minted keys into the returned keys.
 	ret = make([]*Key, len(keys))
-	for i, key := range keys {
-		if key.Incomplete() {
-			// This key is in the mutation results.
-			ret[i], err = protoToKey(resp.MutationResults[i].Key)
-			if err != nil {
-				return nil, errors.New("datastore: internal error: server returned an invalid key")
+	err = c.writeBatches(ctx, len(mutations), func(ctx context.Context, i, j int) error {
+		// Make the request.
+		req := &pb.CommitRequest{
+			ProjectId:  c.dataset,
+			DatabaseId: c.databaseID,
+			Mutations:  mutations[i:j],
+			Mode:       pb.CommitRequest_NON_TRANSACTIONAL,
+		}
+		resp, err := c.client.Commit(ctx, req)
+		if err != nil {
+			return err
+		}
+
+		// Copy any newly minted keys into the returned keys.
+		for k, key := range keys[i:j] {
+			if key.Incomplete() {
+				// This key is in the mutation results.
+				ret[i+k], err = protoToKey(resp.MutationResults[k].Key)
+				if err != nil {
+					return errors.New("datastore: internal error: server returned an invalid key")
+				}
+			} else {
+				ret[i+k] = key
 			}
-		} else {
-			ret[i] = key
 		}
+		return nil
+	})
+	if err != nil {
+		if _, ok := err.(MultiError); ok {
+			return ret, err
+		}
+		return nil, err
 	}
 	return ret, nil
 }
@@ -704,24 +852,80 @@ func (c *Client) Delete(ctx context.Context, key *Key) error {
 // DeleteMulti is a batch version of Delete.
 //
 // err may be a MultiError. See ExampleMultiError to check it.
+//
+// The entities are deleted with Commit requests of at most MaxBatchSize keys,
+// as PutMulti saves them. If some of several requests fail, err is a
+// MultiError with the error of each failed request at the indexes of its
+// keys.
 func (c *Client) DeleteMulti(ctx context.Context, keys []*Key) (err error) {
 	// TODO(jba): rewrite in terms of Mutate.
 	ctx = trace.StartSpan(ctx, "cloud.google.com/go/datastore.DeleteMulti")
 	defer func() { trace.EndSpan(ctx, err) }()
 
-	mutations, err := deleteMutations(keys)
-	if err != nil {
+	if _, err := deleteMutations(keys); err != nil {
 		return err
 	}
 
-	req := &pb.CommitRequest{
-		ProjectId:  c.dataset,
-		DatabaseId: c.databaseID,
-		Mutations:  mutations,
-		Mode:       pb.CommitRequest_NON_TRANSACTIONAL,
+	return c.writeBatches(ctx, len(keys), func(ctx context.Context, i, j int) error {
+		// A key may be repeated in other batches, but deleteMutations
+		// removes its duplicates in this one.
+		mutations, err := deleteMutations(keys[i:j])
+		if err != nil {
+			return err
+		}
+		req := &pb.CommitRequest{
+			ProjectId:  c.dataset,
+			DatabaseId: c.databaseID,
+			Mutations:  mutations,
+			Mode:       pb.CommitRequest_NON_TRANSACTIONAL,
+		}
+		_, err = c.client.Commit(ctx, req)
+		return err
+	})
+}
+
+// writeBatches calls commit for the batches [i, j) of the n mutations of a
+// non-transactional write, of at most the batch size of the client, as many
+// at a time as the client allows. If there is a single batch, its error is
+// returned; otherwise, the error is a MultiError with the error of each
+// failed batch at the indexes of its mutations.
+func (c *Client) writeBatches(ctx context.Context, n int, commit func(ctx context.Context, i, j int) error) error {
+	size, concurrency := c.writeSettings.batching()
+	if n <= size {
+		return commit(ctx, 0, n)
+	}
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		multiErr MultiError
+		sem      = make(chan struct{}, concurrency)
+	)
+	for i := 0; i < n; i += size {
+		i, j := i, i+size
+		if j > n {
+			j = n
+		}
+		sem <- struct{}{}
+		wg.Add(1)
+		go func() {
+			defer func() { <-sem; wg.Done() }()
+			if err := commit(ctx, i, j); err != nil {
+				mu.Lock()
+				defer mu.Unlock()
+				if multiErr == nil {
+					multiErr = make(MultiError, n)
+				}
+				for k := i; k < j; k++ {
+					multiErr[k] = err
+				}
+			}
+		}()
 	}
-	_, err = c.client.Commit(ctx, req)
-	return err
+	wg.Wait()
+	if multiErr != nil {
+		return multiErr
+	}
+	return nil
 }
 
 func deleteMutations(keys []*Key) ([]*pb.Mutation, error) {
@@ -804,20 +1008,172 @@ func (drt docReadTime) apply(rs *readSettings) {
 	rs.readTime = time.Time(drt)
 }
 
+// IgnoreFieldMismatch returns a ReadOption that suppresses ErrFieldMismatch
+// errors: the properties that cannot be loaded into a struct are dropped
+// silently, and the entity is loaded as if they were absent.
+func IgnoreFieldMismatch() ReadOption {
+	return docFieldMismatch(fieldMismatchIgnore)
+}
+
+// StrictFieldMismatch returns a ReadOption that makes properties that cannot
+// be loaded into a struct, such as unknown properties, a hard error. The
+// error wraps the *ErrFieldMismatch, so it can be inspected with errors.As,
+// but it is not an *ErrFieldMismatch, and GetAll returns it without loading
+// the remaining entities.
+func StrictFieldMismatch() ReadOption {
+	return docFieldMismatch(fieldMismatchStrict)
+}
+
+// fieldMismatchMode is how loading entities handles ErrFieldMismatch errors.
+type fieldMismatchMode int
+
+const (
+	fieldMismatchReport fieldMismatchMode = iota
+	fieldMismatchIgnore
+	fieldMismatchStrict
+)
+
+type docFieldMismatch fieldMismatchMode
+
+func (dfm docFieldMismatch) apply(rs *readSettings) {
+	rs.fieldMismatch = fieldMismatchMode(dfm)
+}
+
+// LookupConcurrency returns a ReadOption that lets GetMulti, and the other
+// methods which get entities by their keys, make up to n of the Lookup
+// requests of a call at a time. A Lookup request has at most 1000 keys, so
+// the keys of a call are split into several requests when there are more;
+// by default, they are made one at a time.
+func LookupConcurrency(n int) ReadOption {
+	return docLookupConcurrency(n)
+}
+
+type docLookupConcurrency int
+
+func (dlc docLookupConcurrency) apply(rs *readSettings) {
+	rs.lookupConcurrency = int(dlc)
+}
+
 // ReadOption provides specific instructions for how to access documents in the database.
-// Currently, only ReadTime is supported.
 type ReadOption interface {
 	apply(*readSettings)
 }
 
 type readSettings struct {
-	readTime time.Time
+	readTime          time.Time
+	fieldMismatch     fieldMismatchMode
+	lookupConcurrency int
+}
+
+// loadError returns the error to report for an error returned by loading an
+// entity, according to the field mismatch mode.
+func (rs *readSettings) loadError(err error) error {
+	e, ok := err.(*ErrFieldMismatch)
+	if !ok || rs == nil {
+		return err
+	}
+	switch rs.fieldMismatch {
+	case fieldMismatchIgnore:
+		return nil
+	case fieldMismatchStrict:
+		return fmt.Errorf("datastore: strict field mismatch: %w", e)
+	}
+	return err
+}
+
+// readOptions returns the read options of non-transactional reads, or nil
+// for the defaults.
+func (rs *readSettings) readOptions() *pb.ReadOptions {
+	if rs == nil || rs.readTime.IsZero() {
+		return nil
+	}
+	return readTimeOptions(rs.readTime)
+}
+
+// readTimeOptions returns the read options of reads at t.
+func readTimeOptions(t time.Time) *pb.ReadOptions {
+	return &pb.ReadOptions{
+		ConsistencyType: &pb.ReadOptions_ReadTime{
+			// Timestamp cannot be less than microseconds accuracy. See #6938
+			ReadTime: &timestamppb.Timestamp{Seconds: t.Unix()},
+		},
+	}
+}
+
+// maxWriteBatchSize is the maximum number of mutations of a Commit request.
+const maxWriteBatchSize = 500
+
+// MaxBatchSize returns a WriteOption that limits the Commit requests of
+// PutMulti and DeleteMulti to n entities; a call with more entities makes
+// several requests. The default, and the limit of the API, is 500; larger
+// values of n are reduced to it.
+func MaxBatchSize(n int) WriteOption {
+	return docMaxBatchSize(n)
+}
+
+type docMaxBatchSize int
+
+func (dmbs docMaxBatchSize) apply(ws *writeSettings) {
+	ws.maxBatchSize = int(dmbs)
+}
+
+// MaxConcurrentBatches returns a WriteOption that lets PutMulti and
+// DeleteMulti make up to n of the Commit requests of a call at a time. By
+// default, they are made one at a time.
+func MaxConcurrentBatches(n int) WriteOption {
+	return docMaxConcurrentBatches(n)
+}
+
+type docMaxConcurrentBatches int
+
+func (dmcb docMaxConcurrentBatches) apply(ws *writeSettings) {
+	ws.maxConcurrentBatches = int(dmcb)
+}
+
+// WriteOption configures the non-transactional writes of a Client.
+type WriteOption interface {
+	apply(*writeSettings)
+}
+
+type writeSettings struct {
+	maxBatchSize         int
+	maxConcurrentBatches int
+}
+
+// batching returns the batch size and the number of concurrent batches of
+// non-transactional writes.
+func (ws *writeSettings) batching() (size, concurrency int) {
+	size, concurrency = maxWriteBatchSize, 1
+	if ws == nil {
+		return size, concurrency
+	}
+	if ws.maxBatchSize > 0 && ws.maxBatchSize < maxWriteBatchSize {
+		size = ws.maxBatchSize
+	}
+	if ws.maxConcurrentBatches > 1 {
+		concurrency = ws.maxConcurrentBatches
+	}
+	return size, concurrency
+}
+
+// WithWriteOptions configures the client's subsequent calls of PutMulti and
+// DeleteMulti, and of Put and Delete. Writes in transactions, and Mutate, are
+// not batched.
+func (c *Client) WithWriteOptions(wo ...WriteOption) *Client {
+	for _, w := range wo {
+		w.apply(c.writeSettings)
+	}
+	return c
 }
 
 // WithReadOptions specifies constraints for accessing documents from the database,
 // e.g. at what time snapshot to read the documents.
 // The client uses this value for subsequent reads, unless additional ReadOptions
-// are provided.
+// are provided. A ReadTime applies to Get, GetMulti and the queries and
+// aggregation queries that are not run in a transaction, and do not use
+// EventualConsistency or their own ReadTime. IgnoreFieldMismatch and
+// StrictFieldMismatch apply to all the entities loaded by the client, including
+// in transactions; the last one provided wins.
 func (c *Client) WithReadOptions(ro ...ReadOption) *Client {
 	for _, r := range ro {
 		r.apply(c.readSettings)
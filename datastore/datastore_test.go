@@ -17,8 +17,10 @@ package datastore
 import (
 	"context"
 	"errors"
+	"fmt"
 	"sort"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -83,6 +85,72 @@ func TestNewClientWithDatabase(t *testing.T) {
 	}
 }
 
+func TestDatabaseIDInRequests(t *testing.T) {
+	for _, test := range []struct {
+		databaseID    string
+		wantPrefix    string
+		wantReqParams string
+	}{
+		{DefaultDatabaseID, "projects/projectID", "project_id=projectID"},
+		{"db1", "projects/projectID/databases/db1", "project_id=projectID&database_id=db1"},
+	} {
+		client, srv, cleanup := newMockWithDatabase(t, test.databaseID)
+		ctx := context.Background()
+		k := NameKey("K", "a", nil)
+
+		srv.addRPC(nil, &pb.LookupResponse{Missing: []*pb.EntityResult{{Entity: &pb.Entity{Key: keyToProto(k)}}}})
+		if err := client.Get(ctx, k, &struct{ A int }{}); err != ErrNoSuchEntity {
+			t.Fatalf("Get: got %v, want %v", err, ErrNoSuchEntity)
+		}
+		srv.addRPC(nil, &pb.CommitResponse{MutationResults: []*pb.MutationResult{{}}})
+		if _, err := client.Put(ctx, k, &struct{ A int }{1}); err != nil {
+			t.Fatalf("Put: %v", err)
+		}
+		srv.addRPC(nil, &pb.AllocateIdsResponse{Keys: []*pb.Key{keyToProto(IDKey("K", 1, nil))}})
+		if _, err := client.AllocateIDs(ctx, []*Key{IncompleteKey("K", nil)}); err != nil {
+			t.Fatalf("AllocateIDs: %v", err)
+		}
+		srv.addRPC(nil, &pb.RunQueryResponse{Batch: &pb.QueryResultBatch{MoreResults: pb.QueryResultBatch_NO_MORE_RESULTS}})
+		if _, err := client.GetAll(ctx, NewQuery("K").KeysOnly(), nil); err != nil {
+			t.Fatalf("GetAll: %v", err)
+		}
+		srv.addRPC(nil, &pb.RunAggregationQueryResponse{Batch: &pb.AggregationResultBatch{
+			AggregationResults: []*pb.AggregationResult{{AggregateProperties: map[string]*pb.Value{
+				"count": {ValueType: &pb.Value_IntegerValue{IntegerValue: 0}},
+			}}},
+		}})
+		if _, err := client.RunAggregationQuery(ctx, NewQuery("K").NewAggregationQuery().WithCount("count")); err != nil {
+			t.Fatalf("RunAggregationQuery: %v", err)
+		}
+		srv.addRPC(nil, &pb.BeginTransactionResponse{Transaction: []byte("tid")})
+		tx, err := client.NewTransaction(ctx)
+		if err != nil {
+			t.Fatalf("NewTransaction: %v", err)
+		}
+		srv.addRPC(nil, &pb.RollbackResponse{})
+		if err := tx.Rollback(); err != nil {
+			t.Fatalf("Rollback: %v", err)
+		}
+
+		calls := srv.receivedCalls()
+		if len(calls) != 7 {
+			t.Fatalf("%q: got %d calls, want 7", test.databaseID, len(calls))
+		}
+		for _, c := range calls {
+			if got := c.req.(interface{ GetDatabaseId() string }).GetDatabaseId(); got != test.databaseID {
+				t.Errorf("%q: %s: got database ID %q, want %q", test.databaseID, c.method, got, test.databaseID)
+			}
+			if got := c.md.Get(resourcePrefixHeader); len(got) != 1 || got[0] != test.wantPrefix {
+				t.Errorf("%q: %s: got %s %q, want %q", test.databaseID, c.method, resourcePrefixHeader, got, test.wantPrefix)
+			}
+			if got := c.md.Get(reqParamsHeader); len(got) != 1 || got[0] != test.wantReqParams {
+				t.Errorf("%q: %s: got %s %q, want %q", test.databaseID, c.method, reqParamsHeader, got, test.wantReqParams)
+			}
+		}
+		cleanup()
+	}
+}
+
 func TestQueryConstruction(t *testing.T) {
 	tests := []struct {
 		q, exp *Query
@@ -794,6 +862,133 @@ func TestDeferredMissing(t *testing.T) {
 	}
 }
 
+func TestGetMultiChunks(t *testing.T) {
+	type Ent struct {
+		A int
+	}
+
+	// Keys with odd IDs are missing, and the lookups defer the last key of
+	// each request once.
+	const n = 2*maxLookupKeys + 10
+	keys := make([]*Key, n)
+	for i := range keys {
+		keys[i] = IDKey("testKind", int64(i+1), nil)
+	}
+	var (
+		mu       sync.Mutex
+		requests int
+		deferred = map[int64]bool{}
+	)
+	fakeClient := &fakeDatastoreClient{
+		lookup: func(req *pb.LookupRequest) (*pb.LookupResponse, error) {
+			mu.Lock()
+			defer mu.Unlock()
+			requests++
+			if len(req.Keys) > maxLookupKeys {
+				return nil, fmt.Errorf("lookup of %d keys", len(req.Keys))
+			}
+			resp := &pb.LookupResponse{}
+			for i, k := range req.Keys {
+				id := k.Path[0].GetId()
+				if i == len(req.Keys)-1 && !deferred[id] {
+					deferred[id] = true
+					resp.Deferred = append(resp.Deferred, k)
+					continue
+				}
+				if id%2 == 1 {
+					resp.Missing = append(resp.Missing, &pb.EntityResult{Entity: &pb.Entity{Key: k}})
+					continue
+				}
+				resp.Found = append(resp.Found, &pb.EntityResult{Entity: &pb.Entity{
+					Key:        k,
+					Properties: map[string]*pb.Value{"A": {ValueType: &pb.Value_IntegerValue{IntegerValue: id}}},
+				}})
+			}
+			return resp, nil
+		},
+	}
+
+	ctx := context.Background()
+	for _, concurrency := range []int{0, 1, 3} {
+		requests = 0
+		deferred = map[int64]bool{}
+		client := &Client{
+			client:       fakeClient,
+			readSettings: &readSettings{},
+		}
+		client.WithReadOptions(LookupConcurrency(concurrency))
+
+		dst := make([]Ent, n)
+		err := client.GetMulti(ctx, keys, dst)
+		me, ok := err.(MultiError)
+		if !ok {
+			t.Fatalf("concurrency=%d: got %v, want MultiError", concurrency, err)
+		}
+		if len(me) != n {
+			t.Fatalf("concurrency=%d: got a MultiError of %d errors, want %d", concurrency, len(me), n)
+		}
+		for i := range keys {
+			id := keys[i].ID
+			if id%2 == 1 {
+				if me[i] != ErrNoSuchEntity {
+					t.Errorf("concurrency=%d: key %d: got %v, want ErrNoSuchEntity", concurrency, id, me[i])
+				}
+				continue
+			}
+			if me[i] != nil || dst[i].A != int(id) {
+				t.Errorf("concurrency=%d: key %d: got %+v, %v", concurrency, id, dst[i], me[i])
+			}
+		}
+		// Three chunks, each with one deferred key.
+		if requests != 6 {
+			t.Errorf("concurrency=%d: got %d lookups, want 6", concurrency, requests)
+		}
+	}
+
+	// An error of one chunk is the error of GetMulti.
+	fakeClient.lookup = func(req *pb.LookupRequest) (*pb.LookupResponse, error) {
+		if req.Keys[0].Path[0].GetId() > maxLookupKeys {
+			return nil, errors.New("lookup failed")
+		}
+		return &pb.LookupResponse{}, nil
+	}
+	for _, concurrency := range []int{1, 3} {
+		client := &Client{
+			client:       fakeClient,
+			readSettings: &readSettings{lookupConcurrency: concurrency},
+		}
+		if err := client.GetMulti(ctx, keys, make([]Ent, n)); err == nil || err.Error() != "lookup failed" {
+			t.Errorf("concurrency=%d: got %v, want lookup failed", concurrency, err)
+		}
+	}
+
+	// After a chunk fails, no more chunks are looked up. The second chunk
+	// returns after the first fails, so the third is not started.
+	failed := make(chan struct{})
+	requests = 0
+	fakeClient.lookup = func(req *pb.LookupRequest) (*pb.LookupResponse, error) {
+		mu.Lock()
+		requests++
+		mu.Unlock()
+		if req.Keys[0].Path[0].GetId() == 1 {
+			defer close(failed)
+			return nil, errors.New("lookup failed")
+		}
+		<-failed
+		return &pb.LookupResponse{}, nil
+	}
+	client := &Client{
+		client:       fakeClient,
+		readSettings: &readSettings{lookupConcurrency: 2},
+	}
+	if err := client.GetMulti(ctx, keys, make([]Ent, n)); err == nil || err.Error() != "lookup failed" {
+		t.Errorf("got %v, want lookup failed", err)
+	}
+	if requests != 2 {
+		t.Errorf("got %d lookups, want 2", requests)
+	}
+}
+
 func TestGetWithNilKey(t *testing.T) {
 	client := &Client{readSettings: &readSettings{}}
 	err := client.Get(context.Background(), nil, []Property{})
@@ -868,6 +1063,127 @@ func TestDeleteMultiWithIncompleteKey(t *testing.T) {
 	}
 }
 
+func TestWriteBatches(t *testing.T) {
+	type Ent struct {
+		N int
+	}
+
+	// Entities with even N have incomplete keys. The commits fail if they
+	// have the entity or key named "bad".
+	const n = 2*maxWriteBatchSize + 50
+	keys := make([]*Key, n)
+	src := make([]Ent, n)
+	for i := range keys {
+		src[i].N = i
+		if i%2 == 0 {
+			keys[i] = IncompleteKey("Ent", nil)
+		} else {
+			keys[i] = NameKey("Ent", fmt.Sprint(i), nil)
+		}
+	}
+	const bad = 601
+	keys[bad] = NameKey("Ent", "bad", nil)
+	// saved has the keys of the saved entities.
+	saved := make([]*Key, n)
+	for i, k := range keys {
+		saved[i] = k
+		if k.Incomplete() {
+			saved[i] = IDKey("Ent", int64(i+1), nil)
+		}
+	}
+
+	var (
+		mu      sync.Mutex
+		commits []int
+	)
+	fakeClient := &fakeDatastoreClient{
+		commit: func(req *pb.CommitRequest) (*pb.CommitResponse, error) {
+			mu.Lock()
+			commits = append(commits, len(req.Mutations))
+			mu.Unlock()
+			resp := &pb.CommitResponse{}
+			var failed bool
+			for _, m := range req.Mutations {
+				var k *pb.Key
+				switch op := m.Operation.(type) {
+				case *pb.Mutation_Insert:
+					id := op.Insert.Properties["N"].GetIntegerValue()
+					k = keyToProto(IDKey("Ent", id+1, nil))
+				case *pb.Mutation_Upsert:
+					k = op.Upsert.Key
+				case *pb.Mutation_Delete:
+					k = op.Delete
+				}
+				failed = failed || k.Path[0].GetName() == "bad"
+				resp.MutationResults = append(resp.MutationResults, &pb.MutationResult{Key: k})
+			}
+			if failed {
+				return nil, errors.New("commit failed")
+			}
+			return resp, nil
+		},
+	}
+	ctx := context.Background()
+
+	for _, test := range []struct {
+		opts    []WriteOption
+		size    int
+		commits int
+	}{
+		{nil, maxWriteBatchSize, 3},
+		{[]WriteOption{MaxBatchSize(100), MaxConcurrentBatches(4)}, 100, 11},
+		{[]WriteOption{MaxBatchSize(1000)}, maxWriteBatchSize, 3},
+	} {
+		client := &Client{client: fakeClient, writeSettings: &writeSettings{}}
+		client.WithWriteOptions(test.opts...)
+		lo, hi := bad/test.size*test.size, (bad/test.size+1)*test.size
+
+		commits = nil
+		ret, err := client.PutMulti(ctx, keys, src)
+		me, ok := err.(MultiError)
+		if !ok || len(me) != n {
+			t.Fatalf("size=%d: PutMulti: got %v, want MultiError of %d errors", test.size, err, n)
+		}
+		for i := range keys {
+			if i >= lo && i < hi {
+				if me[i] == nil || ret[i] != nil {
+					t.Errorf("size=%d: PutMulti %d: got %v, %v, want error", test.size, i, ret[i], me[i])
+				}
+				continue
+			}
+			if me[i] != nil || !ret[i].Equal(saved[i]) {
+				t.Errorf("size=%d: PutMulti %d: got %v, %v, want %v", test.size, i, ret[i], me[i], saved[i])
+			}
+		}
+		if len(commits) != test.commits {
+			t.Errorf("size=%d: PutMulti: got %d commits, want %d", test.size, len(commits), test.commits)
+		}
+		for _, c := range commits {
+			if c > test.size {
+				t.Errorf("size=%d: PutMulti: got a commit of %d mutations", test.size, c)
+			}
+		}
+
+		commits = nil
+		err = client.DeleteMulti(ctx, saved)
+		me, ok = err.(MultiError)
+		if !ok || len(me) != n {
+			t.Fatalf("size=%d: DeleteMulti: got %v, want MultiError of %d errors", test.size, err, n)
+		}
+		for i := range me {
+			if (me[i] != nil) != (i >= lo && i < hi) {
+				t.Errorf("size=%d: DeleteMulti %d: got %v", test.size, i, me[i])
+			}
+		}
+	}
+
+	// The error of a single commit is returned as it is.
+	client := &Client{client: fakeClient, writeSettings: &writeSettings{}}
+	if _, err := client.PutMulti(ctx, keys[600:610], src[600:610]); err == nil || err.Error() != "commit failed" {
+		t.Errorf("PutMulti: got %v, want commit failed", err)
+	}
+}
+
 func TestBasicGet(t *testing.T) {
 	cl, srv, cleanup := newMock(t)
 	defer cleanup()
@@ -903,3 +1219,66 @@ func TestBasicGet(t *testing.T) {
 		t.Fatalf("datastore: test failed to get entity: %v", err)
 	}
 }
+
+func TestFieldMismatchModes(t *testing.T) {
+	ctx := context.Background()
+	// nameOnly cannot hold the Height property of the Gophers.
+	type nameOnly struct {
+		Name string
+	}
+	for _, test := range []struct {
+		desc       string
+		opt        ReadOption
+		wantGetAll int // The number of entities GetAll loads.
+		check      func(error) bool
+	}{
+		{"default", nil, 2, func(err error) bool { _, ok := err.(*ErrFieldMismatch); return ok }},
+		{"ignore", IgnoreFieldMismatch(), 2, func(err error) bool { return err == nil }},
+		{"strict", StrictFieldMismatch(), 0, func(err error) bool {
+			var fm *ErrFieldMismatch
+			_, ok := err.(*ErrFieldMismatch)
+			return !ok && errors.As(err, &fm) && fm.FieldName == "Height"
+		}},
+	} {
+		client := newGopherQueryClient()
+		client.readSettings = &readSettings{}
+		if test.opt != nil {
+			client.WithReadOptions(test.opt)
+		}
+
+		var dst []nameOnly
+		_, err := client.GetAll(ctx, NewQuery("Gopher"), &dst)
+		if !test.check(err) {
+			t.Errorf("%s: GetAll: unexpected error %v", test.desc, err)
+		}
+		if len(dst) != test.wantGetAll {
+			t.Errorf("%s: GetAll: got %d entities, want %d", test.desc, len(dst), test.wantGetAll)
+		}
+
+		var n nameOnly
+		_, err = client.Run(ctx, NewQuery("Gopher")).Next(&n)
+		if !test.check(err) {
+			t.Errorf("%s: Next: unexpected error %v", test.desc, err)
+		}
+		if n.Name != "George" {
+			t.Errorf("%s: Next: got %+v, want George", test.desc, n)
+		}
+	}
+
+	client, srv, cleanup := newMock(t)
+	defer cleanup()
+	k := NameKey("Gopher", "George", nil)
+	srv.addRPC(&pb.LookupRequest{ProjectId: "projectID", Keys: []*pb.Key{keyToProto(k)}},
+		&pb.LookupResponse{Found: []*pb.EntityResult{{Entity: &pb.Entity{
+			Key: keyToProto(k),
+			Properties: map[string]*pb.Value{
+				"Name":   {ValueType: &pb.Value_StringValue{StringValue: "George"}},
+				"Height": {ValueType: &pb.Value_IntegerValue{IntegerValue: 32}},
+			},
+		}}}})
+	client.WithReadOptions(IgnoreFieldMismatch())
+	var n nameOnly
+	if err := client.Get(ctx, k, &n); err != nil || n.Name != "George" {
+		t.Errorf("Get: got %+v, %v, want George and no error", n, err)
+	}
+}
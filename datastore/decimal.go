@@ -0,0 +1,170 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datastore
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// Decimal is an arbitrary-precision decimal number, such as a monetary
+// amount, whose value is Unscaled() × 10^-Scale(). The zero value is 0.
+//
+// By default, a Decimal field is saved as an entity value with the integer
+// properties "Unscaled" and "Scale", which requires the unscaled value to fit
+// in an int64. With the "text" option, it is saved as a string, such as
+// "-12.50", without limit on its precision. A Decimal can be loaded from
+// either representation.
+//
+// A Decimal is immutable, so it may be copied and compared with Cmp.
+type Decimal struct {
+	unscaled *big.Int // nil means 0
+	scale    int32
+}
+
+// NewDecimal returns the Decimal unscaled × 10^-scale.
+func NewDecimal(unscaled *big.Int, scale int32) Decimal {
+	return Decimal{unscaled: new(big.Int).Set(unscaled), scale: scale}
+}
+
+// ParseDecimal parses a decimal number such as "12", "-0.05" or "+3.50". The
+// scale of the result is the number of digits after the decimal point.
+func ParseDecimal(s string) (Decimal, error) {
+	digits := strings.TrimLeft(s, "+-")
+	if len(s)-len(digits) > 1 {
+		return Decimal{}, fmt.Errorf("datastore: invalid decimal %q", s)
+	}
+	var scale int
+	if i := strings.IndexByte(digits, '.'); i >= 0 {
+		scale = len(digits) - i - 1
+		digits = digits[:i] + digits[i+1:]
+	}
+	if digits == "" || strings.TrimLeft(digits, "0123456789") != "" || scale > maxDecimalScale {
+		return Decimal{}, fmt.Errorf("datastore: invalid decimal %q", s)
+	}
+	unscaled, _ := new(big.Int).SetString(digits, 10)
+	if s[0] == '-' {
+		unscaled.Neg(unscaled)
+	}
+	return Decimal{unscaled: unscaled, scale: int32(scale)}, nil
+}
+
+const maxDecimalScale = 1<<31 - 1
+
+// Unscaled returns the unscaled value of d.
+func (d Decimal) Unscaled() *big.Int {
+	if d.unscaled == nil {
+		return new(big.Int)
+	}
+	return new(big.Int).Set(d.unscaled)
+}
+
+// Scale returns the scale of d, the number of digits after its decimal point.
+func (d Decimal) Scale() int32 {
+	return d.scale
+}
+
+// Rat returns the value of d as a rational number.
+func (d Decimal) Rat() *big.Rat {
+	pow := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(abs32(d.scale))), nil)
+	if d.scale < 0 {
+		return new(big.Rat).SetInt(pow.Mul(pow, d.Unscaled()))
+	}
+	return new(big.Rat).SetFrac(d.Unscaled(), pow)
+}
+
+// Cmp compares the values of d and e, regardless of their scales, and returns
+// -1 if d < e, 0 if d == e, and +1 if d > e.
+func (d Decimal) Cmp(e Decimal) int {
+	return d.Rat().Cmp(e.Rat())
+}
+
+// String returns d in decimal notation, with Scale() digits after the
+// decimal point.
+func (d Decimal) String() string {
+	u := d.Unscaled()
+	digits := new(big.Int).Abs(u).String()
+	switch {
+	case d.scale < 0:
+		digits += strings.Repeat("0", int(-d.scale))
+	case d.scale > 0:
+		if n := int(d.scale) + 1 - len(digits); n > 0 {
+			digits = strings.Repeat("0", n) + digits
+		}
+		digits = digits[:len(digits)-int(d.scale)] + "." + digits[len(digits)-int(d.scale):]
+	}
+	if u.Sign() < 0 {
+		return "-" + digits
+	}
+	return digits
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (d Decimal) MarshalText() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (d *Decimal) UnmarshalText(b []byte) error {
+	x, err := ParseDecimal(string(b))
+	if err != nil {
+		return err
+	}
+	*d = x
+	return nil
+}
+
+// ToProperty implements PropertyConverter.
+func (d Decimal) ToProperty() (interface{}, error) {
+	u := d.Unscaled()
+	if !u.IsInt64() {
+		return nil, fmt.Errorf("datastore: unscaled value of %v overflows int64; use the text option", d)
+	}
+	return &Entity{Properties: []Property{
+		{Name: "Unscaled", Value: u.Int64()},
+		{Name: "Scale", Value: int64(d.scale)},
+	}}, nil
+}
+
+// FromProperty implements PropertyConverter.
+func (d *Decimal) FromProperty(v interface{}) error {
+	switch v := v.(type) {
+	case nil:
+		*d = Decimal{}
+		return nil
+	case string:
+		return d.UnmarshalText([]byte(v))
+	case *Entity:
+		var x struct {
+			Unscaled int64
+			Scale    int32
+		}
+		if err := LoadStruct(&x, v.Properties); err != nil {
+			return err
+		}
+		*d = Decimal{unscaled: big.NewInt(x.Unscaled), scale: x.Scale}
+		return nil
+	}
+	return errors.New("datastore: a Decimal must be loaded from a string or an entity")
+}
+
+func abs32(x int32) int64 {
+	if x < 0 {
+		return -int64(x)
+	}
+	return int64(x)
+}
@@ -0,0 +1,148 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datastore
+
+import (
+	"math/big"
+	"strings"
+	"testing"
+
+	"cloud.google.com/go/internal/testutil"
+)
+
+func TestParseDecimal(t *testing.T) {
+	for _, test := range []struct {
+		in       string
+		unscaled int64
+		scale    int32
+		str      string
+	}{
+		{"0", 0, 0, "0"},
+		{"12", 12, 0, "12"},
+		{"-0.05", -5, 2, "-0.05"},
+		{"+3.50", 350, 2, "3.50"},
+		{".5", 5, 1, "0.5"},
+		{"1234.5678", 12345678, 4, "1234.5678"},
+	} {
+		d, err := ParseDecimal(test.in)
+		if err != nil {
+			t.Errorf("%q: %v", test.in, err)
+			continue
+		}
+		if d.Unscaled().Int64() != test.unscaled || d.Scale() != test.scale {
+			t.Errorf("%q: got %v × 10^-%d, want %d × 10^-%d", test.in, d.Unscaled(), d.Scale(), test.unscaled, test.scale)
+		}
+		if got := d.String(); got != test.str {
+			t.Errorf("%q: String() = %q, want %q", test.in, got, test.str)
+		}
+	}
+	for _, in := range []string{"", "-", "+-1", "1.2.3", "1e3", "abc", "1,5"} {
+		if _, err := ParseDecimal(in); err == nil {
+			t.Errorf("%q: got nil, want error", in)
+		}
+	}
+}
+
+func TestDecimalValue(t *testing.T) {
+	if got := NewDecimal(big.NewInt(-15), -2).String(); got != "-1500" {
+		t.Errorf("negative scale: got %q, want -1500", got)
+	}
+	if got := (Decimal{}).String(); got != "0" {
+		t.Errorf("zero value: got %q, want 0", got)
+	}
+	a, _ := ParseDecimal("1.50")
+	b, _ := ParseDecimal("1.5")
+	if a.Cmp(b) != 0 || a.String() == b.String() {
+		t.Errorf("got %v.Cmp(%v) = %d, want equal values with different scales", a, b, a.Cmp(b))
+	}
+	if want := big.NewRat(3, 2); a.Rat().Cmp(want) != 0 {
+		t.Errorf("Rat: got %v, want %v", a.Rat(), want)
+	}
+	u := big.NewInt(7)
+	d := NewDecimal(u, 1)
+	u.SetInt64(8)
+	d.Unscaled().SetInt64(9)
+	if got := d.String(); got != "0.7" {
+		t.Errorf("got %q, want 0.7 regardless of changes to the unscaled values", got)
+	}
+}
+
+func TestDecimalProperties(t *testing.T) {
+	type prices struct {
+		Price  Decimal
+		Text   Decimal `datastore:",text"`
+		Prices []Decimal
+		Big    *big.Int `datastore:",text"`
+		Rat    big.Rat  `datastore:",text"`
+	}
+	price, _ := ParseDecimal("19.99")
+	huge, _ := ParseDecimal("123456789012345678901234567890.12")
+	src := &prices{
+		Price:  price,
+		Text:   huge,
+		Prices: []Decimal{price, NewDecimal(big.NewInt(-1), 0)},
+		Big:    new(big.Int).Lsh(big.NewInt(1), 100),
+	}
+	src.Rat.SetFrac64(1, 3)
+	props, err := SaveStruct(src)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantProps := []Property{
+		{Name: "Price", Value: &Entity{Properties: []Property{
+			{Name: "Unscaled", Value: int64(1999)},
+			{Name: "Scale", Value: int64(2)},
+		}}},
+		{Name: "Text", Value: "123456789012345678901234567890.12"},
+		{Name: "Prices", Value: []interface{}{
+			&Entity{Properties: []Property{{Name: "Unscaled", Value: int64(1999)}, {Name: "Scale", Value: int64(2)}}},
+			&Entity{Properties: []Property{{Name: "Unscaled", Value: int64(-1)}, {Name: "Scale", Value: int64(0)}}},
+		}},
+		{Name: "Big", Value: "1267650600228229401496703205376"},
+		{Name: "Rat", Value: "1/3"},
+	}
+	if diff := testutil.Diff(props, wantProps); diff != "" {
+		t.Errorf("SaveStruct: (-got +want)\n%s", diff)
+	}
+
+	e, err := saveEntity(testKey0, src)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got prices
+	if err := loadEntityProto(&got, e); err != nil {
+		t.Fatal(err)
+	}
+	if got.Price.String() != "19.99" || got.Text.String() != huge.String() || len(got.Prices) != 2 || got.Prices[1].String() != "-1" {
+		t.Errorf("got decimals %v, %v and %v", got.Price, got.Text, got.Prices)
+	}
+	if got.Big.Cmp(src.Big) != 0 || got.Rat.Cmp(&src.Rat) != 0 {
+		t.Errorf("got %v and %v, want %v and %v", got.Big, &got.Rat, src.Big, &src.Rat)
+	}
+
+	// Either representation can be loaded regardless of the tag.
+	var swapped prices
+	err = LoadStruct(&swapped, []Property{{Name: "Price", Value: "1.25"}, {Name: "Text", Value: props[0].Value}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if swapped.Price.String() != "1.25" || swapped.Text.String() != "19.99" {
+		t.Errorf("got %v and %v, want 1.25 and 19.99", swapped.Price, swapped.Text)
+	}
+
+	if _, err := SaveStruct(&struct{ D Decimal }{huge}); err == nil || !strings.Contains(err.Error(), "overflows int64") {
+		t.Errorf("huge decimal without text option: got %v, want error", err)
+	}
+}
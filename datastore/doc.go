@@ -37,14 +37,19 @@ Valid value types are:
   - string
   - float32 and float64
   - []byte (up to 1 megabyte in length)
+  - Arrays of bytes, such as uuid.UUID (stored as blobs, or as strings with the
+    "uuid" option)
   - Any type whose underlying type is one of the above predeclared types
   - *Key
   - GeoPoint
   - time.Time (stored with microsecond precision, retrieved as UTC)
+  - civil.Date, civil.Time and civil.DateTime (stored as timestamps in UTC, or
+    as strings with the "text" option)
   - Structs whose fields are all valid value types
   - Pointers to structs whose fields are all valid value types
   - Slices of any of the above
-  - Pointers to a signed integer, bool, string, float32, or float64
+  - Pointers to a signed integer, bool, string, float32, float64, GeoPoint,
+    time.Time or civil type
 
 Slices of structs are valid, as are structs that contain slices.
 
@@ -87,6 +92,9 @@ Example code:
 GetMulti, PutMulti and DeleteMulti are batch versions of the Get, Put and
 Delete functions. They take a []*Key instead of a *Key, and may return a
 datastore.MultiError when encountering partial failure.
+They split any number of keys into requests within the limits of the API,
+which are made one at a time unless Client.WithReadOptions is given
+LookupConcurrency, or Client.WithWriteOptions is given MaxConcurrentBatches.
 
 Mutate generalizes PutMulti and DeleteMulti to a sequence of any Datastore
 mutations. It takes a series of mutations created with NewInsert, NewUpdate,
@@ -94,6 +102,19 @@ NewUpsert and NewDelete and applies them. Datastore.Mutate uses
 non-transactional mode; if atomicity is required, use Transaction.Mutate
 instead.
 
+To write many entities, such as in a nightly load, a BulkWriter, returned by
+Client.BulkWriter, commits Put and Delete writes in batches in the
+background, several at a time, throttled and retried on contention; each
+write returns a BulkWriterJob whose Results are those of the write:
+
+	bw := client.BulkWriter(ctx)
+	for _, w := range widgets {
+		if _, err := bw.Put(datastore.IncompleteKey("Widget", nil), w); err != nil {
+			// TODO: Handle error.
+		}
+	}
+	bw.End()
+
 # Properties
 
 An entity's contents can be represented by a variety of types. These are
@@ -112,7 +133,10 @@ Conceptually, any entity is saved as a sequence of properties, and is loaded
 into the destination value on a property-by-property basis. When loading into
 a struct pointer, an entity that cannot be completely represented (such as a
 missing field) will result in an ErrFieldMismatch error but it is up to the
-caller whether this error is fatal, recoverable or ignorable.
+caller whether this error is fatal, recoverable or ignorable. A Client can also
+make the choice for all its reads: with Client.WithReadOptions, the
+IgnoreFieldMismatch option suppresses these errors, and the StrictFieldMismatch
+option turns them into hard errors.
 
 By default, for struct pointers, all properties are potentially indexed, and
 the property name is the same as the field name (and hence must start with an
@@ -123,23 +147,59 @@ property name, which must be one or more valid Go identifiers joined by ".",
 but may start with a lower case letter. An empty tag name means to just use the
 field name. A "-" tag name means that the datastore will ignore that field.
 
-The only valid options are "omitempty", "noindex" and "flatten".
+The only valid options are "omitempty", "omitzero", "noindex", "nocascade",
+"flatten", "text" and "uuid".
 
 If the options include "omitempty" and the value of the field is an empty
 value, then the field will be omitted on Save. Empty values are defined as
-false, 0, a nil pointer, a nil interface value, the zero time.Time, and any
-empty slice or string. (Empty slices are never saved, even without
-"omitempty".) Other structs, including GeoPoint, are never considered empty.
+false, 0, a nil pointer, a nil interface value, and any empty slice or string.
+(Empty slices are never saved, even without "omitempty".) A value that is not a
+pointer, and has an "IsZero() bool" method, such as time.Time, is empty if the
+method returns true. Other structs, including GeoPoint, are never considered
+empty.
+
+If the options include "omitzero" and the value of the field is zero, then the
+field will be omitted on Save. If the type of the field has an "IsZero() bool"
+method, the value is zero if the method returns true. Otherwise, a value is zero
+if it is the zero value of its type, such as a nil pointer or a struct whose
+fields are all zero, or if it is a pointer to a zero value.
 
 If options include "noindex" then the field will not be indexed. All fields
 are indexed by default. Strings or byte slices longer than 1500 bytes cannot
 be indexed; fields used to store long strings and byte slices must be tagged
 with "noindex" or they will cause Put operations to fail.
 
+For a field of struct or map type, "noindex" also applies to all the properties
+of the Entity value, recursively. If the options also include "nocascade", only
+the Entity value itself is not indexed, and its properties are indexed as if
+the option were absent.
+
+The elements of a slice field are indexed individually. An element of type
+NoIndexElement, such as in a []interface{} field, is not indexed, even if the
+other elements are.
+
 For a nested struct field, the options may also include "flatten". This
 indicates that the immediate fields and any nested substruct fields of the
 nested struct should be flattened. See below for examples.
 
+If the options include "text", the field is saved as the string returned by
+its MarshalText method, and loaded with its UnmarshalText method, so its type
+or a pointer to it must implement both encoding.TextMarshaler and
+encoding.TextUnmarshaler. This takes precedence over the PropertyLoadSaver and
+PropertyConverter interfaces. For a slice field whose type does not implement
+them, such as []net.IP, the option applies to each element. A nil pointer is
+saved as a Datastore Null property. Fields of the civil types can be loaded
+from either representation, so the option can be added to or removed from
+them without migrating existing entities.
+
+If the options include "uuid", the field, which must be an array of 16 bytes
+such as uuid.UUID from github.com/google/uuid, or a pointer or slice of them, is
+saved in the canonical string form of a UUID, such as
+"f47ac10b-58cc-0372-8567-0e02b2c3d479", instead of as a 16-byte blob. Either form
+can be loaded regardless of the option. A UUID value in a query filter is
+compared as a blob, so to filter on a field with the option, use the string form
+of the UUID, such as id.String(), as the value.
+
 To use multiple options together, separate them by a comma.
 The order does not matter.
 
@@ -162,6 +222,14 @@ Example code:
 		J int `datastore:",noindex" json:"j"`
 	}
 
+Problems with a struct's tags and field types are otherwise only reported when
+an entity is first saved or loaded. To report all of them when a program starts,
+call ValidateEntityType:
+
+	if err := datastore.ValidateEntityType(reflect.TypeOf(TaggedStruct{})); err != nil {
+		log.Fatal(err)
+	}
+
 # Slice Fields
 
 A field of slice type corresponds to a Datastore array property, except for []byte, which corresponds
@@ -174,6 +242,19 @@ the slice field remains unchanged.
 If a non-array value is loaded into a slice field, the result will be a slice with
 one element, containing the value.
 
+# Map Fields
+
+A field of map type with string keys, such as map[string]int or map[string]T for
+a struct type T, corresponds to a Datastore entity value, with a property for each
+entry of the map. The map values follow the same rules as struct fields. The
+properties are saved in the order of their keys. A nil map is saved as a Datastore
+Null property, unless the field is marked omitempty, in which case no property will
+be stored. If a map field is marked noindex, its values are not indexed.
+
+Loading an entity value into a map field adds its properties to the map,
+replacing the entries with the same keys, and keeping the others. If the field is
+nil, a new map is allocated. Loading a Null into a map field sets it to nil.
+
 # Loading Nulls
 
 Loading a Datastore Null into a basic type (int, float, etc.) results in a zero value.
@@ -316,9 +397,13 @@ an Outer's properties would be equivalent to those of:
 		Z          bool
 	}
 
-Note that the "flatten" option cannot be used for Entity value fields or
-PropertyLoadSaver implementers. The server will reject any dotted field names
-for an Entity value.
+The "flatten" option may also be used on pointers to structs and slices of
+pointers to structs. A nil pointer field is not saved, and a slice with nil
+elements cannot be saved.
+
+Note that the "flatten" option cannot be used for Entity value fields,
+PropertyLoadSaver implementers or map fields, or within them. The server will
+reject any dotted field names for an Entity value.
 
 # The PropertyLoadSaver Interface
 
@@ -370,6 +455,63 @@ Example code:
 The *PropertyList type implements PropertyLoadSaver, and can therefore hold an
 arbitrary entity's contents.
 
+Loading and saving structs uses reflection, which can be costly for large
+batches of entities. The datastoregen command
+(cloud.google.com/go/datastore/cmd/datastoregen) generates Load, Save and
+LoadKey methods for struct types of simple fields, which load and save them
+like the datastore package does, but without reflection:
+
+	//go:generate go run cloud.google.com/go/datastore/cmd/datastoregen -type=Gopher
+
+# The PropertyConverter Interface
+
+A struct field whose type implements the PropertyConverter interface is stored as the
+single property value returned by its ToProperty method, and loaded with its
+FromProperty method. This lets custom types round-trip without the structs that
+contain them implementing PropertyLoadSaver. The field may also be a pointer to, or a
+slice of, such a type.
+
+Example code:
+
+	// Money is stored as a string, such as "1234 EUR".
+	type Money struct {
+		Cents    int64
+		Currency string
+	}
+
+	func (m *Money) ToProperty() (interface{}, error) {
+		return fmt.Sprintf("%d %s", m.Cents, m.Currency), nil
+	}
+
+	func (m *Money) FromProperty(v interface{}) error {
+		s, ok := v.(string)
+		if !ok {
+			return fmt.Errorf("Money: got %T, want string", v)
+		}
+		_, err := fmt.Sscanf(s, "%d %s", &m.Cents, &m.Currency)
+		return err
+	}
+
+	type Order struct {
+		Total Money
+	}
+
+# Arbitrary-Precision Numbers
+
+Values that cannot be represented exactly by float64, such as monetary amounts,
+can be stored in fields of type Decimal. By default a Decimal is saved as an
+entity value holding its unscaled value and scale as integers. With the "text"
+option it is saved as a string in decimal notation, which has no limit on its
+precision:
+
+	type Invoice struct {
+		Amount datastore.Decimal
+		Total  datastore.Decimal `datastore:",text"`
+	}
+
+Fields of type big.Int, big.Rat and big.Float, or pointers to them, can be saved
+as strings with the "text" option.
+
 # The KeyLoader Interface
 
 If a type implements the PropertyLoadSaver interface, it may
@@ -428,6 +570,29 @@ by a chain of zero or more such methods. These methods are:
     relative to Start+Offset, not relative to End. As a special case, a
     negative limit means unlimited.
 
+The filters and orders of a query can also be made from a Field of a property,
+whose methods take values of the Go type of the property. FieldOf checks the
+name and type of a property against a struct type when a program builds its
+queries, and the datastoregen command declares the Fields of a struct type, so
+that the compiler checks them:
+
+	q := datastore.NewQuery("Widget").
+		FilterEntity(WidgetFields.Price.Lt(1000)).
+		Order(WidgetFields.Price.Desc())
+
+The cursors of an Iterator mark its position among the results of a query, and
+CursorAfterKey makes a cursor from the key of an entity, for queries ordered by
+key. Query.EncodeCursor and Query.DecodeCursor convert a cursor to and from a
+stable string which records the query, so that a stored cursor is not used with
+a different query.
+Pages returns the results of a query a page at a time, with an opaque token for
+each next page, encoded that way.
+
+The results of a projection query only have the projected properties that the
+entities have. To tell which fields of a struct were loaded, load the results
+into a Projection, whose Fields records the names of their properties; or load
+them into a map[string]interface{}.
+
 Example code:
 
 	type Widget struct {
@@ -490,6 +655,19 @@ Example code:
 Pass the ReadOnly option to RunInTransaction if your transaction is used only for Get,
 GetMulti or queries. Read-only transactions are more efficient.
 
+# Databases
+
+A Client created with NewClient uses the default database of its project. To use a
+named Firestore in Datastore mode database, create a Client for it with
+NewClientWithDatabase; all the reads, writes, queries and transactions of that Client
+use that database. Clients for several databases can be used side by side:
+
+	orders, err := datastore.NewClientWithDatabase(ctx, "my-project-id", "orders")
+	if err != nil {
+		// TODO: Handle error.
+	}
+	defer orders.Close()
+
 # Google Cloud Datastore Emulator
 
 This package supports the Cloud Datastore emulator, which is useful for testing and
@@ -143,6 +143,28 @@ func ExampleClient_DeleteMulti() {
 	}
 }
 
+func ExampleClient_BulkWriter() {
+	ctx := context.Background()
+	client, err := datastore.NewClient(ctx, "project-id")
+	if err != nil {
+		// TODO: Handle error.
+	}
+	bw := client.BulkWriter(ctx,
+		datastore.BulkWriterThrottling(500, 5000),
+		datastore.BulkWriterOnError(func(j *datastore.BulkWriterJob, err error) {
+			log.Printf("writing %v: %v", j.Key(), err)
+		}))
+	for i := 1; i <= 100000; i++ {
+		post := &Post{Title: fmt.Sprintf("Post %d", i)}
+		if _, err := bw.Put(datastore.IDKey("Post", int64(i), nil), post); err != nil {
+			// TODO: Handle error.
+		}
+	}
+	// End waits for the writes to succeed or fail.
+	bw.End()
+	fmt.Printf("%+v\n", bw.Progress())
+}
+
 type Post struct {
 	Title       string
 	PublishedAt time.Time
@@ -422,6 +444,40 @@ func ExampleClient_GetAll() {
 	}
 }
 
+func ExampleGetAll() {
+	ctx := context.Background()
+	client, err := datastore.NewClient(ctx, "project-id")
+	if err != nil {
+		// TODO: Handle error.
+	}
+	posts, keys, err := datastore.GetAll[Post](ctx, client, datastore.NewQuery("Post"))
+	if err != nil {
+		// TODO: Handle error.
+	}
+	for i, key := range keys {
+		fmt.Println(key, posts[i].Title)
+	}
+}
+
+func ExampleTypedIterator_Next() {
+	ctx := context.Background()
+	client, err := datastore.NewClient(ctx, "project-id")
+	if err != nil {
+		// TODO: Handle error.
+	}
+	it := datastore.Run[Post](ctx, client, datastore.NewQuery("Post"))
+	for {
+		p, key, err := it.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			// TODO: Handle error.
+		}
+		fmt.Println(key, p.Title)
+	}
+}
+
 func ExampleClient_Mutate() {
 	ctx := context.Background()
 	client, err := datastore.NewClient(ctx, "project-id")
@@ -554,6 +610,62 @@ func ExampleQuery_Start() {
 	_ = it // TODO: Use iterator.
 }
 
+func ExampleCursorAfterKey() {
+	ctx := context.Background()
+	client, err := datastore.NewClient(ctx, "project-id")
+	if err != nil {
+		// TODO: Handle error.
+	}
+	// Resume a scan of the posts after the last one that was processed.
+	lastKey := datastore.IDKey("Post", 42, nil)
+	it := client.Run(ctx, datastore.NewQuery("Post").Start(datastore.CursorAfterKey(lastKey)))
+	_ = it // TODO: Use iterator.
+}
+
+func ExampleQuery_DecodeCursor() {
+	ctx := context.Background()
+	client, err := datastore.NewClient(ctx, "project-id")
+	if err != nil {
+		// TODO: Handle error.
+	}
+	q := datastore.NewQuery("Post").FilterField("Author", "=", "gopher")
+	// getCursor represents a function that returns a cursor stored by
+	// Query.EncodeCursor, possibly by an earlier version of the program.
+	cursor, err := q.DecodeCursor(getCursor())
+	if err == datastore.ErrCursorMismatch {
+		// The query has changed since the cursor was stored: start again.
+		cursor = datastore.Cursor{}
+	} else if err != nil {
+		// TODO: Handle error.
+	}
+	it := client.Run(ctx, q.Start(cursor))
+	_ = it // TODO: Use iterator, and store its cursor with q.EncodeCursor.
+}
+
+func ExamplePages() {
+	ctx := context.Background()
+	client, err := datastore.NewClient(ctx, "project-id")
+	if err != nil {
+		// TODO: Handle error.
+	}
+	type Post struct {
+		Title string
+	}
+	// pageToken is the token of the page requested by a client of an API,
+	// which is empty for the first page.
+	pageToken := ""
+	q := datastore.NewQuery("Post").Order("-PublishedAt")
+	page, err := datastore.Pages[Post](ctx, client, q, 20, pageToken).Next()
+	if err != nil {
+		// TODO: Handle error.
+	}
+	for _, p := range page.Entities {
+		fmt.Println(p.Title)
+	}
+	// Return page.NextPageToken to the client, to request the next page.
+	_ = page.NextPageToken
+}
+
 func ExampleLoadStruct() {
 	type Player struct {
 		User  string
@@ -0,0 +1,140 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datastore
+
+import (
+	"strconv"
+	"time"
+
+	pb "google.golang.org/genproto/googleapis/datastore/v1"
+)
+
+// QueryMode is the mode in which a query is run. It is set with Query.Mode.
+type QueryMode int
+
+const (
+	// NormalMode runs the query and returns its results. It is the default.
+	NormalMode QueryMode = iota
+
+	// ExplainMode plans the query without running it. The query returns no
+	// results, and its plan is returned by Iterator.ExplainMetrics.
+	ExplainMode
+
+	// ExplainAnalyzeMode runs the query and returns its results. Once they
+	// have been read, Iterator.ExplainMetrics returns the plan of the query
+	// and the statistics of its execution.
+	ExplainAnalyzeMode
+)
+
+// explainOptions returns the explain options of a request in mode m, or nil
+// in NormalMode.
+func (m QueryMode) explainOptions() *pb.ExplainOptions {
+	switch m {
+	case ExplainMode:
+		return &pb.ExplainOptions{}
+	case ExplainAnalyzeMode:
+		return &pb.ExplainOptions{Analyze: true}
+	}
+	return nil
+}
+
+// ExplainMetrics is the plan of a query run in ExplainMode or
+// ExplainAnalyzeMode, and the statistics of its execution.
+type ExplainMetrics struct {
+	// PlanSummary is the plan of the query.
+	PlanSummary *PlanSummary
+
+	// ExecutionStats are the statistics of the execution of the query. They
+	// are nil in ExplainMode, in which the query is not run.
+	ExecutionStats *ExecutionStats
+}
+
+// PlanSummary is the plan of a query.
+type PlanSummary struct {
+	// IndexesUsed describes the indexes that the query uses, for example:
+	//
+	//	[{"query_scope": "Collection", "properties": "(foo ASC, __name__ ASC)"}]
+	IndexesUsed []map[string]interface{}
+}
+
+// ExecutionStats are the statistics of the execution of a query. A query whose
+// results are fetched from the service in several batches is executed once
+// for each batch, and its statistics add up those of all the batches.
+type ExecutionStats struct {
+	// ResultsReturned is the number of results returned.
+	ResultsReturned int64
+
+	// ExecutionDuration is the time spent executing the query in the
+	// service.
+	ExecutionDuration time.Duration
+
+	// ReadOperations is the number of billable read operations, the read
+	// units that the query is charged for.
+	ReadOperations int64
+
+	// EntitiesScanned and IndexEntriesScanned are the numbers of entities
+	// and index entries scanned, taken from the debugging statistics. They
+	// are 0 if the service does not report them.
+	EntitiesScanned     int64
+	IndexEntriesScanned int64
+
+	// DebugStats are debugging statistics of the last batch, such as the
+	// numbers of index entries and entities scanned. Their contents may change
+	// as the service evolves.
+	DebugStats map[string]interface{}
+}
+
+// addExplainMetrics adds the metrics of the response to a batch of results
+// to m, which may be nil, and returns the result.
+func addExplainMetrics(m *ExplainMetrics, p *pb.ExplainMetrics) *ExplainMetrics {
+	if p == nil {
+		return m
+	}
+	if m == nil {
+		m = &ExplainMetrics{}
+	}
+	if ps := p.GetPlanSummary(); ps != nil {
+		m.PlanSummary = &PlanSummary{}
+		for _, s := range ps.GetIndexesUsed() {
+			m.PlanSummary.IndexesUsed = append(m.PlanSummary.IndexesUsed, s.AsMap())
+		}
+	}
+	if es := p.GetExecutionStats(); es != nil {
+		if m.ExecutionStats == nil {
+			m.ExecutionStats = &ExecutionStats{}
+		}
+		m.ExecutionStats.ResultsReturned += es.GetResultsReturned()
+		m.ExecutionStats.ExecutionDuration += es.GetExecutionDuration().AsDuration()
+		m.ExecutionStats.ReadOperations += es.GetReadOperations()
+		debug := es.GetDebugStats().AsMap()
+		m.ExecutionStats.EntitiesScanned += debugStat(debug, "documents_scanned")
+		m.ExecutionStats.IndexEntriesScanned += debugStat(debug, "index_entries_scanned")
+		m.ExecutionStats.DebugStats = debug
+	}
+	return m
+}
+
+// debugStat returns the count named key in debugging statistics, which the
+// service reports as a decimal string, or 0 if it is missing or malformed.
+func debugStat(debug map[string]interface{}, key string) int64 {
+	switch v := debug[key].(type) {
+	case string:
+		n, _ := strconv.ParseInt(v, 10, 64)
+		return n
+	case float64:
+		return int64(v)
+	}
+	return 0
+}
@@ -0,0 +1,145 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datastore
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/internal/testutil"
+	"google.golang.org/api/iterator"
+	pb "google.golang.org/genproto/googleapis/datastore/v1"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+func TestQueryExplain(t *testing.T) {
+	index, err := structpb.NewStruct(map[string]interface{}{"query_scope": "Collection", "properties": "(Name ASC, __name__ ASC)"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	planSummary := &pb.PlanSummary{IndexesUsed: []*structpb.Struct{index}}
+	wantPlan := &PlanSummary{IndexesUsed: []map[string]interface{}{{"query_scope": "Collection", "properties": "(Name ASC, __name__ ASC)"}}}
+	stats := func(n int64) *pb.ExecutionStats {
+		debug, err := structpb.NewStruct(map[string]interface{}{
+			"documents_scanned":     fmt.Sprint(n),
+			"index_entries_scanned": fmt.Sprint(10 * n),
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return &pb.ExecutionStats{
+			ResultsReturned:   n,
+			ExecutionDuration: durationpb.New(time.Duration(n) * time.Millisecond),
+			ReadOperations:    n + 1,
+			DebugStats:        debug,
+		}
+	}
+	entity := &pb.EntityResult{Entity: &pb.Entity{Key: key1}}
+
+	for _, test := range []struct {
+		mode      QueryMode
+		resps     []*pb.RunQueryResponse
+		wantOpts  *pb.ExplainOptions
+		nResults  int
+		wantStats *ExplainMetrics
+	}{
+		{
+			mode: NormalMode,
+			resps: []*pb.RunQueryResponse{{
+				Batch: &pb.QueryResultBatch{EntityResults: []*pb.EntityResult{entity}, MoreResults: pb.QueryResultBatch_NO_MORE_RESULTS},
+			}},
+			nResults: 1,
+		},
+		{
+			mode:      ExplainMode,
+			resps:     []*pb.RunQueryResponse{{ExplainMetrics: &pb.ExplainMetrics{PlanSummary: planSummary}}},
+			wantOpts:  &pb.ExplainOptions{},
+			wantStats: &ExplainMetrics{PlanSummary: wantPlan},
+		},
+		{
+			mode: ExplainAnalyzeMode,
+			resps: []*pb.RunQueryResponse{
+				{
+					Batch: &pb.QueryResultBatch{
+						EntityResults: []*pb.EntityResult{entity, entity},
+						MoreResults:   pb.QueryResultBatch_NOT_FINISHED,
+						EndCursor:     []byte("end"),
+					},
+					ExplainMetrics: &pb.ExplainMetrics{PlanSummary: planSummary, ExecutionStats: stats(2)},
+				},
+				{
+					Batch: &pb.QueryResultBatch{
+						EntityResults: []*pb.EntityResult{entity},
+						MoreResults:   pb.QueryResultBatch_NO_MORE_RESULTS,
+					},
+					ExplainMetrics: &pb.ExplainMetrics{PlanSummary: planSummary, ExecutionStats: stats(1)},
+				},
+			},
+			wantOpts: &pb.ExplainOptions{Analyze: true},
+			nResults: 3,
+			wantStats: &ExplainMetrics{
+				PlanSummary: wantPlan,
+				ExecutionStats: &ExecutionStats{
+					ResultsReturned:     3,
+					ExecutionDuration:   3 * time.Millisecond,
+					ReadOperations:      5,
+					EntitiesScanned:     3,
+					IndexEntriesScanned: 30,
+					DebugStats:          map[string]interface{}{"documents_scanned": "1", "index_entries_scanned": "10"},
+				},
+			},
+		},
+	} {
+		var reqs []*pb.RunQueryRequest
+		client := &Client{client: &fakeClient{
+			queryFn: func(req *pb.RunQueryRequest) (*pb.RunQueryResponse, error) {
+				reqs = append(reqs, req)
+				return test.resps[len(reqs)-1], nil
+			},
+		}}
+		it := client.Run(context.Background(), NewQuery("Gopher").Mode(test.mode))
+		n := 0
+		for {
+			if m := it.ExplainMetrics(); m != nil {
+				t.Fatalf("mode %d: got explain metrics %v before the end of the results", test.mode, m)
+			}
+			_, err := it.Next(nil)
+			if err == iterator.Done {
+				break
+			}
+			if err != nil {
+				t.Fatalf("mode %d: %v", test.mode, err)
+			}
+			n++
+		}
+		if n != test.nResults {
+			t.Errorf("mode %d: got %d results, want %d", test.mode, n, test.nResults)
+		}
+		if len(reqs) != len(test.resps) {
+			t.Fatalf("mode %d: got %d requests, want %d", test.mode, len(reqs), len(test.resps))
+		}
+		for _, req := range reqs {
+			if !testutil.Equal(req.ExplainOptions, test.wantOpts) {
+				t.Errorf("mode %d: got explain options %v, want %v", test.mode, req.ExplainOptions, test.wantOpts)
+			}
+		}
+		if got := it.ExplainMetrics(); !testutil.Equal(got, test.wantStats) {
+			t.Errorf("mode %d: got explain metrics %+v, want %+v", test.mode, got, test.wantStats)
+		}
+	}
+}
@@ -0,0 +1,339 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package export
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"cloud.google.com/go/datastore"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// The records of an export are EntityProto messages of the App Engine
+// datastore_v3 API, which are decoded here from the wire format, as the
+// subset of its messages in internal/gaepb has no entities. The field
+// numbers are those of datastore_v3.proto; its PropertyValue and Path
+// messages have groups, whose fields are numbered within the message.
+const (
+	// EntityProto
+	entityKey         = 13 // Reference
+	entityProperty    = 14 // repeated Property
+	entityRawProperty = 15 // repeated Property, not indexed
+
+	// Reference
+	referenceNamespace = 20
+	referencePath      = 14 // Path
+
+	// Path
+	pathElement     = 1 // repeated group
+	pathElementType = 2
+	pathElementID   = 3
+	pathElementName = 4
+
+	// Property
+	propertyMeaning  = 1
+	propertyName     = 3
+	propertyMultiple = 4
+	propertyValue    = 5 // PropertyValue
+
+	// PropertyValue
+	valueInt64     = 1
+	valueBoolean   = 2
+	valueString    = 3
+	valueDouble    = 4
+	valuePoint     = 5 // group
+	valuePointX    = 6
+	valuePointY    = 7
+	valueUser      = 8 // group
+	valueUserEmail = 9
+	valueReference = 12 // group
+	valueRefNS     = 20
+	valueRefPath   = 14 // repeated group
+	valueRefType   = 15
+	valueRefID     = 16
+	valueRefName   = 17
+
+	// Property.Meaning
+	meaningGDWhen      = 7
+	meaningBlob        = 14
+	meaningByteString  = 16
+	meaningIndexValue  = 18
+	meaningEntityProto = 19
+)
+
+// field is a field of a message.
+type field struct {
+	num protowire.Number
+	typ protowire.Type
+	v   uint64 // the value of a varint or fixed field
+	b   []byte // the value of a length-delimited field, or of a group
+}
+
+// fields returns the fields of the message, or group, m.
+func fields(m []byte) ([]field, error) {
+	var fs []field
+	for len(m) > 0 {
+		num, typ, n := protowire.ConsumeTag(m)
+		if n < 0 {
+			return nil, fmt.Errorf("%w: %v", ErrCorrupt, protowire.ParseError(n))
+		}
+		m = m[n:]
+		f := field{num: num, typ: typ}
+		switch typ {
+		case protowire.VarintType:
+			f.v, n = protowire.ConsumeVarint(m)
+		case protowire.Fixed64Type:
+			f.v, n = protowire.ConsumeFixed64(m)
+		case protowire.Fixed32Type:
+			var v uint32
+			v, n = protowire.ConsumeFixed32(m)
+			f.v = uint64(v)
+		case protowire.BytesType:
+			f.b, n = protowire.ConsumeBytes(m)
+		case protowire.StartGroupType:
+			f.b, n = protowire.ConsumeGroup(num, m)
+		default:
+			n = -1
+		}
+		if n < 0 {
+			return nil, fmt.Errorf("%w: bad field %d", ErrCorrupt, num)
+		}
+		m = m[n:]
+		fs = append(fs, f)
+	}
+	return fs, nil
+}
+
+// decodeEntity decodes an EntityProto. Its key is nil if it has no key, as
+// entity values may not.
+func decodeEntity(m []byte) (*datastore.Entity, error) {
+	fs, err := fields(m)
+	if err != nil {
+		return nil, err
+	}
+	e := &datastore.Entity{}
+	// index has the indexes in e.Properties of the properties of multiple
+	// values, by name.
+	index := map[string]int{}
+	for _, f := range fs {
+		switch f.num {
+		case entityKey:
+			if e.Key, err = decodeReference(f.b); err != nil {
+				return nil, err
+			}
+		case entityProperty, entityRawProperty:
+			p, multiple, err := decodeProperty(f.b)
+			if err != nil {
+				return nil, err
+			}
+			p.NoIndex = f.num == entityRawProperty
+			if !multiple {
+				e.Properties = append(e.Properties, p)
+				continue
+			}
+			i, ok := index[p.Name]
+			if !ok {
+				index[p.Name] = len(e.Properties)
+				p.Value = []interface{}{p.Value}
+				e.Properties = append(e.Properties, p)
+				continue
+			}
+			// The property is not indexed if none of its values are.
+			q := &e.Properties[i]
+			q.Value = append(q.Value.([]interface{}), p.Value)
+			q.NoIndex = q.NoIndex && p.NoIndex
+		}
+	}
+	return e, nil
+}
+
+// decodeReference decodes a Reference into a key, which is nil if it has
+// no path.
+func decodeReference(m []byte) (*datastore.Key, error) {
+	fs, err := fields(m)
+	if err != nil {
+		return nil, err
+	}
+	var (
+		ns   string
+		path []field
+	)
+	for _, f := range fs {
+		switch f.num {
+		case referenceNamespace:
+			ns = string(f.b)
+		case referencePath:
+			if path, err = fields(f.b); err != nil {
+				return nil, err
+			}
+		}
+	}
+	var k *datastore.Key
+	for _, f := range path {
+		if f.num != pathElement {
+			continue
+		}
+		if k, err = decodePathElement(f.b, k, ns, pathElementType, pathElementID, pathElementName); err != nil {
+			return nil, err
+		}
+	}
+	return k, nil
+}
+
+// decodePathElement decodes an element of a path, of the fields of the given
+// numbers, into the key of the element, a child of parent.
+func decodePathElement(m []byte, parent *datastore.Key, ns string, typeNum, idNum, nameNum protowire.Number) (*datastore.Key, error) {
+	fs, err := fields(m)
+	if err != nil {
+		return nil, err
+	}
+	k := &datastore.Key{Parent: parent, Namespace: ns}
+	for _, f := range fs {
+		switch f.num {
+		case typeNum:
+			k.Kind = string(f.b)
+		case idNum:
+			k.ID = int64(f.v)
+		case nameNum:
+			k.Name = string(f.b)
+		}
+	}
+	return k, nil
+}
+
+// decodeProperty decodes a Property, and returns whether it is one of the
+// values of a property of multiple values.
+func decodeProperty(m []byte) (p datastore.Property, multiple bool, err error) {
+	fs, err := fields(m)
+	if err != nil {
+		return p, false, err
+	}
+	var (
+		meaning uint64
+		value   []byte
+	)
+	for _, f := range fs {
+		switch f.num {
+		case propertyMeaning:
+			meaning = f.v
+		case propertyName:
+			p.Name = string(f.b)
+		case propertyMultiple:
+			multiple = f.v != 0
+		case propertyValue:
+			value = f.b
+		}
+	}
+	if meaning == meaningIndexValue {
+		return p, false, fmt.Errorf("export: property %q has an index value", p.Name)
+	}
+	p.Value, err = decodeValue(value, meaning)
+	if err != nil {
+		return p, false, fmt.Errorf("export: property %q: %w", p.Name, err)
+	}
+	return p, multiple, nil
+}
+
+// decodeValue decodes a PropertyValue of a property of the given meaning, as
+// the App Engine datastore package does, into a value of a type of the
+// properties of the datastore package. A value without any field is nil.
+func decodeValue(m []byte, meaning uint64) (interface{}, error) {
+	fs, err := fields(m)
+	if err != nil {
+		return nil, err
+	}
+	for _, f := range fs {
+		switch f.num {
+		case valueInt64:
+			if meaning == meaningGDWhen {
+				micros := int64(f.v)
+				return time.Unix(micros/1e6, (micros%1e6)*1e3).In(time.UTC), nil
+			}
+			return int64(f.v), nil
+		case valueBoolean:
+			return f.v != 0, nil
+		case valueString:
+			switch meaning {
+			case meaningBlob, meaningByteString:
+				return append([]byte(nil), f.b...), nil
+			case meaningEntityProto:
+				return decodeEntity(f.b)
+			}
+			// Text, blob keys, and the Atom and GData types are
+			// strings.
+			return string(f.b), nil
+		case valueDouble:
+			return math.Float64frombits(f.v), nil
+		case valuePoint:
+			// Latitude is X, and longitude is Y.
+			gs, err := fields(f.b)
+			if err != nil {
+				return nil, err
+			}
+			var g datastore.GeoPoint
+			for _, gf := range gs {
+				switch gf.num {
+				case valuePointX:
+					g.Lat = math.Float64frombits(gf.v)
+				case valuePointY:
+					g.Lng = math.Float64frombits(gf.v)
+				}
+			}
+			return g, nil
+		case valueUser:
+			// The datastore package has no user values; a user is its
+			// email.
+			us, err := fields(f.b)
+			if err != nil {
+				return nil, err
+			}
+			for _, uf := range us {
+				if uf.num == valueUserEmail {
+					return string(uf.b), nil
+				}
+			}
+			return "", nil
+		case valueReference:
+			return decodeReferenceValue(f.b)
+		}
+	}
+	return nil, nil
+}
+
+// decodeReferenceValue decodes the ReferenceValue group of a PropertyValue.
+func decodeReferenceValue(m []byte) (*datastore.Key, error) {
+	fs, err := fields(m)
+	if err != nil {
+		return nil, err
+	}
+	var ns string
+	for _, f := range fs {
+		if f.num == valueRefNS {
+			ns = string(f.b)
+		}
+	}
+	var k *datastore.Key
+	for _, f := range fs {
+		if f.num != valueRefPath {
+			continue
+		}
+		if k, err = decodePathElement(f.b, k, ns, valueRefType, valueRefID, valueRefName); err != nil {
+			return nil, err
+		}
+	}
+	return k, nil
+}
@@ -0,0 +1,76 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package export_test
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"os"
+
+	"cloud.google.com/go/datastore"
+	"cloud.google.com/go/datastore/export"
+)
+
+func ExampleClient_Export() {
+	ctx := context.Background()
+	client, err := export.NewClient(ctx, "project-id")
+	if err != nil {
+		// TODO: Handle error.
+	}
+	defer client.Close()
+
+	op, err := client.Export(ctx, "gs://my-bucket/nightly", &export.Filter{Kinds: []string{"Task"}})
+	if err != nil {
+		// TODO: Handle error.
+	}
+	err = op.Wait(ctx, func(p export.Progress) {
+		log.Printf("%v: exported %d of about %d entities", p.State, p.EntitiesCompleted, p.EntitiesEstimated)
+	})
+	if err != nil {
+		// TODO: Handle error.
+	}
+	fmt.Println(op.OutputURL())
+}
+
+func ExampleReader() {
+	// A file of an export, such as all_namespaces/kind_Task/output-0.
+	f, err := os.Open("output-0")
+	if err != nil {
+		// TODO: Handle error.
+	}
+	defer f.Close()
+
+	type Task struct {
+		Description string
+		Done        bool
+	}
+	r := export.NewReader(f)
+	for {
+		e, err := r.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			// TODO: Handle error.
+		}
+		var t Task
+		if err := datastore.LoadStruct(&t, e.Properties); err != nil {
+			// TODO: Handle error.
+		}
+		fmt.Println(e.Key, t)
+	}
+}
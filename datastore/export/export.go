@@ -0,0 +1,295 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package export exports the entities of a Datastore database to Cloud
+// Storage and imports them back, with the managed export and import
+// operations of the Datastore Admin API, and reads the files of exports.
+//
+// A Client starts the operations, which Operation.Wait waits for, reporting
+// their progress:
+//
+//	client, err := export.NewClient(ctx, "project-id")
+//	if err != nil {
+//		// TODO: Handle error.
+//	}
+//	defer client.Close()
+//	op, err := client.Export(ctx, "my-bucket", &export.Filter{Kinds: []string{"Task"}})
+//	if err != nil {
+//		// TODO: Handle error.
+//	}
+//	err = op.Wait(ctx, func(p export.Progress) {
+//		log.Printf("exported %d of about %d entities", p.EntitiesCompleted, p.EntitiesEstimated)
+//	})
+//	if err != nil {
+//		// TODO: Handle error.
+//	}
+//	// op.OutputURL() is the URL of the export, to be given to Client.Import.
+//
+// An export writes the entities of each kind to files named output-0,
+// output-1 and so on, in LevelDB log format, in a directory such as
+// all_namespaces/kind_Task under the prefix of the export. A Reader reads the
+// entities of such a file, which has been downloaded or is read from Cloud
+// Storage, into datastore.Entity values for offline processing.
+package export // import "cloud.google.com/go/datastore/export"
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	admin "cloud.google.com/go/datastore/admin/apiv1"
+	"cloud.google.com/go/datastore/admin/apiv1/adminpb"
+	gax "github.com/googleapis/gax-go/v2"
+	"google.golang.org/api/option"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// A Client starts the export and import operations of the entities of a
+// project.
+type Client struct {
+	admin     *admin.DatastoreAdminClient
+	projectID string
+}
+
+// NewClient returns a Client of the project, with a new Datastore Admin API
+// client made with the options. Call Close when done with it.
+func NewClient(ctx context.Context, projectID string, opts ...option.ClientOption) (*Client, error) {
+	if projectID == "" {
+		return nil, errors.New("export: missing project id")
+	}
+	ac, err := admin.NewDatastoreAdminClient(ctx, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &Client{admin: ac, projectID: projectID}, nil
+}
+
+// Close closes the connection of the client.
+func (c *Client) Close() error {
+	return c.admin.Close()
+}
+
+// A Filter selects the entities of an export or an import. The zero Filter,
+// as a nil *Filter, selects all the entities.
+type Filter struct {
+	// Kinds are the kinds of the entities, or all the kinds if empty.
+	Kinds []string
+	// Namespaces are the namespaces of the entities, or all the namespaces
+	// if empty. The default namespace is the empty string.
+	Namespaces []string
+	// Labels are the client-assigned labels of the operation.
+	Labels map[string]string
+}
+
+func (f *Filter) entityFilter() *adminpb.EntityFilter {
+	if f == nil || len(f.Kinds) == 0 && len(f.Namespaces) == 0 {
+		return nil
+	}
+	return &adminpb.EntityFilter{Kinds: f.Kinds, NamespaceIds: f.Namespaces}
+}
+
+func (f *Filter) labels() map[string]string {
+	if f == nil {
+		return nil
+	}
+	return f.Labels
+}
+
+// Export starts the export of the entities of f to Cloud Storage. dst is a
+// bucket, as "my-bucket" or "gs://my-bucket", with an optional prefix of the
+// names of the files, as "gs://my-bucket/exports/nightly"; the export is
+// written in a directory of a name made from the start time under it.
+func (c *Client) Export(ctx context.Context, dst string, f *Filter) (*Operation, error) {
+	op, err := c.admin.ExportEntities(ctx, &adminpb.ExportEntitiesRequest{
+		ProjectId:       c.projectID,
+		Labels:          f.labels(),
+		EntityFilter:    f.entityFilter(),
+		OutputUrlPrefix: storageURL(dst),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return newOperation(op.Name(), &exportOperation{op}), nil
+}
+
+// Import starts the import of the entities of f of an export. src is the
+// OutputURL of the Operation of the export, which is the URL of its
+// .overall_export_metadata file, as
+// "gs://my-bucket/2023-08-01T00:00:00_12345/2023-08-01T00:00:00_12345.overall_export_metadata";
+// the "gs://" may be omitted. The import writes the entities with their keys,
+// overwriting any entities of those keys.
+func (c *Client) Import(ctx context.Context, src string, f *Filter) (*Operation, error) {
+	op, err := c.admin.ImportEntities(ctx, &adminpb.ImportEntitiesRequest{
+		ProjectId:    c.projectID,
+		Labels:       f.labels(),
+		EntityFilter: f.entityFilter(),
+		InputUrl:     storageURL(src),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return newOperation(op.Name(), &importOperation{op}), nil
+}
+
+// storageURL returns the Cloud Storage URL of s, a URL or a bucket with an
+// optional path.
+func storageURL(s string) string {
+	if strings.HasPrefix(s, "gs://") {
+		return s
+	}
+	return "gs://" + s
+}
+
+// Progress is a snapshot of the progress of an Operation.
+type Progress struct {
+	// State is the state of the operation.
+	State adminpb.CommonMetadata_State
+	// StartTime is when the operation started, and EndTime when it ended, if
+	// it has ended.
+	StartTime, EndTime time.Time
+	// EntitiesCompleted is the number of entities processed, of an estimated
+	// total of EntitiesEstimated.
+	EntitiesCompleted, EntitiesEstimated int64
+	// BytesCompleted is the number of bytes processed, of an estimated total
+	// of BytesEstimated.
+	BytesCompleted, BytesEstimated int64
+}
+
+// An Operation is an export or an import started by a Client.
+type Operation struct {
+	name    string
+	lro     lro
+	backoff gax.Backoff // the intervals of the polls of Wait
+}
+
+// lro is the long-running operation of an export or an import.
+type lro interface {
+	// poll fetches the latest state of the operation, and returns whether
+	// it is done, with its error if it failed.
+	poll(ctx context.Context) (done bool, err error)
+	// metadata returns the common metadata and the progress of the entities
+	// and of the bytes of the operation, as of the latest poll.
+	metadata() (*adminpb.CommonMetadata, *adminpb.Progress, *adminpb.Progress, error)
+	// outputURL returns the output URL of an export which is done.
+	outputURL() string
+}
+
+func newOperation(name string, l lro) *Operation {
+	return &Operation{
+		name:    name,
+		lro:     l,
+		backoff: gax.Backoff{Initial: 5 * time.Second, Max: time.Minute, Multiplier: 1.5},
+	}
+}
+
+// Name returns the name of the long-running operation, with which the
+// client of the Datastore Admin API can get, cancel or resume it.
+func (op *Operation) Name() string {
+	return op.name
+}
+
+// Progress returns the progress of the operation, as of the latest poll.
+func (op *Operation) Progress() (Progress, error) {
+	common, entities, bytes, err := op.lro.metadata()
+	if err != nil {
+		return Progress{}, err
+	}
+	var p Progress
+	if common != nil {
+		p.State = common.State
+		p.StartTime = timeOf(common.StartTime)
+		p.EndTime = timeOf(common.EndTime)
+	}
+	p.EntitiesCompleted, p.EntitiesEstimated = entities.GetWorkCompleted(), entities.GetWorkEstimated()
+	p.BytesCompleted, p.BytesEstimated = bytes.GetWorkCompleted(), bytes.GetWorkEstimated()
+	return p, nil
+}
+
+func timeOf(ts *timestamppb.Timestamp) time.Time {
+	if ts == nil {
+		return time.Time{}
+	}
+	return ts.AsTime()
+}
+
+// Wait polls the operation until it is done, and returns its error if it
+// failed. If progress is not nil, it is called with the progress of the
+// operation after each poll.
+func (op *Operation) Wait(ctx context.Context, progress func(Progress)) error {
+	bo := op.backoff
+	for {
+		done, err := op.lro.poll(ctx)
+		if progress != nil {
+			if p, perr := op.Progress(); perr == nil {
+				progress(p)
+			}
+		}
+		if done || err != nil {
+			return err
+		}
+		if err := gax.Sleep(ctx, bo.Pause()); err != nil {
+			return err
+		}
+	}
+}
+
+// OutputURL returns the URL of the export of an Operation of Client.Export
+// which is done, to be passed to Client.Import, or the empty string.
+func (op *Operation) OutputURL() string {
+	return op.lro.outputURL()
+}
+
+type exportOperation struct {
+	op *admin.ExportEntitiesOperation
+}
+
+func (e *exportOperation) poll(ctx context.Context) (bool, error) {
+	_, err := e.op.Poll(ctx)
+	return e.op.Done(), err
+}
+
+func (e *exportOperation) metadata() (*adminpb.CommonMetadata, *adminpb.Progress, *adminpb.Progress, error) {
+	m, err := e.op.Metadata()
+	return m.GetCommon(), m.GetProgressEntities(), m.GetProgressBytes(), err
+}
+
+func (e *exportOperation) outputURL() string {
+	if !e.op.Done() {
+		return ""
+	}
+	resp, err := e.op.Poll(context.Background())
+	if err != nil {
+		return ""
+	}
+	return resp.GetOutputUrl()
+}
+
+type importOperation struct {
+	op *admin.ImportEntitiesOperation
+}
+
+func (i *importOperation) poll(ctx context.Context) (bool, error) {
+	err := i.op.Poll(ctx)
+	return i.op.Done(), err
+}
+
+func (i *importOperation) metadata() (*adminpb.CommonMetadata, *adminpb.Progress, *adminpb.Progress, error) {
+	m, err := i.op.Metadata()
+	return m.GetCommon(), m.GetProgressEntities(), m.GetProgressBytes(), err
+}
+
+func (i *importOperation) outputURL() string {
+	return ""
+}
@@ -0,0 +1,198 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package export
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/datastore/admin/apiv1/adminpb"
+	"cloud.google.com/go/internal/testutil"
+	"cloud.google.com/go/longrunning/autogen/longrunningpb"
+	gax "github.com/googleapis/gax-go/v2"
+	"google.golang.org/api/option"
+	statuspb "google.golang.org/genproto/googleapis/rpc/status"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// fakeAdmin is a Datastore Admin API server whose operations process 10
+// entities a poll, and are done after polls polls; then they fail with fail,
+// if it is not nil.
+type fakeAdmin struct {
+	adminpb.UnimplementedDatastoreAdminServer
+	longrunningpb.UnimplementedOperationsServer
+
+	polls int
+	fail  *statuspb.Status
+
+	mu       sync.Mutex
+	requests []proto.Message
+	polled   int
+}
+
+func (s *fakeAdmin) ExportEntities(_ context.Context, req *adminpb.ExportEntitiesRequest) (*longrunningpb.Operation, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.requests = append(s.requests, req)
+	return s.operation("export")
+}
+
+func (s *fakeAdmin) ImportEntities(_ context.Context, req *adminpb.ImportEntitiesRequest) (*longrunningpb.Operation, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.requests = append(s.requests, req)
+	return s.operation("import")
+}
+
+func (s *fakeAdmin) GetOperation(_ context.Context, req *longrunningpb.GetOperationRequest) (*longrunningpb.Operation, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.polled++
+	return s.operation(req.Name)
+}
+
+// operation returns the operation of the given name, as of s.polled polls.
+func (s *fakeAdmin) operation(name string) (*longrunningpb.Operation, error) {
+	done := s.polled >= s.polls
+	common := &adminpb.CommonMetadata{State: adminpb.CommonMetadata_PROCESSING}
+	if done {
+		common.State = adminpb.CommonMetadata_SUCCESSFUL
+	}
+	progress := &adminpb.Progress{WorkCompleted: int64(10 * s.polled), WorkEstimated: int64(10 * s.polls)}
+	var (
+		meta proto.Message
+		resp proto.Message
+	)
+	if name == "export" {
+		meta = &adminpb.ExportEntitiesMetadata{Common: common, ProgressEntities: progress}
+		resp = &adminpb.ExportEntitiesResponse{OutputUrl: "gs://bucket/x/x.overall_export_metadata"}
+	} else {
+		meta = &adminpb.ImportEntitiesMetadata{Common: common, ProgressEntities: progress}
+		resp = &emptypb.Empty{}
+	}
+	op := &longrunningpb.Operation{Name: name, Done: done}
+	var err error
+	if op.Metadata, err = anypb.New(meta); err != nil {
+		return nil, err
+	}
+	if done {
+		if s.fail != nil {
+			op.Result = &longrunningpb.Operation_Error{Error: s.fail}
+		} else {
+			any, err := anypb.New(resp)
+			if err != nil {
+				return nil, err
+			}
+			op.Result = &longrunningpb.Operation_Response{Response: any}
+		}
+	}
+	return op, nil
+}
+
+func newTestClient(t *testing.T, s *fakeAdmin) *Client {
+	srv, err := testutil.NewServer()
+	if err != nil {
+		t.Fatal(err)
+	}
+	adminpb.RegisterDatastoreAdminServer(srv.Gsrv, s)
+	longrunningpb.RegisterOperationsServer(srv.Gsrv, s)
+	srv.Start()
+	t.Cleanup(srv.Close)
+	conn, err := grpc.Dial(srv.Addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	c, err := NewClient(context.Background(), "project", option.WithGRPCConn(conn))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { c.Close() })
+	return c
+}
+
+func TestExport(t *testing.T) {
+	ctx := context.Background()
+	s := &fakeAdmin{polls: 3}
+	c := newTestClient(t, s)
+
+	op, err := c.Export(ctx, "bucket/nightly", &Filter{Kinds: []string{"Task"}, Labels: map[string]string{"job": "nightly"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if op.OutputURL() != "" {
+		t.Errorf("got OutputURL %q before the export is done", op.OutputURL())
+	}
+	op.backoff = gax.Backoff{Initial: time.Millisecond, Max: time.Millisecond}
+	var progress []Progress
+	if err := op.Wait(ctx, func(p Progress) { progress = append(progress, p) }); err != nil {
+		t.Fatal(err)
+	}
+	want := &adminpb.ExportEntitiesRequest{
+		ProjectId:       "project",
+		Labels:          map[string]string{"job": "nightly"},
+		EntityFilter:    &adminpb.EntityFilter{Kinds: []string{"Task"}},
+		OutputUrlPrefix: "gs://bucket/nightly",
+	}
+	if diff := testutil.Diff(s.requests[0], want); diff != "" {
+		t.Errorf("got=-, want=+\n%s", diff)
+	}
+	if len(progress) != 3 {
+		t.Fatalf("got %d progress reports, want 3", len(progress))
+	}
+	for i, p := range progress {
+		wantState := adminpb.CommonMetadata_PROCESSING
+		if i == 2 {
+			wantState = adminpb.CommonMetadata_SUCCESSFUL
+		}
+		if p.State != wantState || p.EntitiesCompleted != int64(10*(i+1)) || p.EntitiesEstimated != 30 {
+			t.Errorf("progress %d: got %+v", i, p)
+		}
+	}
+	if got, want := op.OutputURL(), "gs://bucket/x/x.overall_export_metadata"; got != want {
+		t.Errorf("got OutputURL %q, want %q", got, want)
+	}
+}
+
+func TestImport(t *testing.T) {
+	ctx := context.Background()
+	s := &fakeAdmin{polls: 1, fail: &statuspb.Status{Code: int32(codes.NotFound), Message: "no export"}}
+	c := newTestClient(t, s)
+
+	op, err := c.Import(ctx, "gs://bucket/x/x.overall_export_metadata", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	op.backoff = gax.Backoff{Initial: time.Millisecond, Max: time.Millisecond}
+	if err := op.Wait(ctx, nil); err == nil {
+		t.Fatal("got nil, want error")
+	}
+	want := &adminpb.ImportEntitiesRequest{
+		ProjectId: "project",
+		InputUrl:  "gs://bucket/x/x.overall_export_metadata",
+	}
+	if diff := testutil.Diff(s.requests[0], want); diff != "" {
+		t.Errorf("got=-, want=+\n%s", diff)
+	}
+	if op.OutputURL() != "" {
+		t.Errorf("got OutputURL %q for an import", op.OutputURL())
+	}
+}
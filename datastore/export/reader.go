@@ -0,0 +1,183 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package export
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+
+	"cloud.google.com/go/datastore"
+)
+
+// The LevelDB log format: the file is a sequence of blocks of blockSize
+// bytes, the last of which may be shorter. A block holds chunks, each a header
+// of the checksum, length and type of its data, and the data. A record is the
+// data of a full chunk, or of a first chunk, any middle chunks and a last
+// chunk. A block whose rest is too short for a header is padded with zeros.
+const (
+	blockSize  = 32 << 10
+	headerSize = 7
+
+	chunkZero   = 0 // padding, written by some implementations
+	chunkFull   = 1
+	chunkFirst  = 2
+	chunkMiddle = 3
+	chunkLast   = 4
+)
+
+// ErrCorrupt is returned by Reader.Read for a file which is not a valid
+// export file.
+var ErrCorrupt = errors.New("export: corrupt export file")
+
+var crcTable = crc32.MakeTable(crc32.Castagnoli)
+
+// A Reader reads the entities of an export file, such as output-0 of the
+// directory of a kind of an export.
+//
+// The values of the properties of the entities are of the types that
+// datastore.LoadStruct loads; entity values are *datastore.Entity values,
+// whose keys may be nil or incomplete. The properties of multiple values are
+// []interface{} values.
+type Reader struct {
+	r        *bufio.Reader
+	buf      []byte // the current block
+	block    []byte // the rest of the current block
+	blockOff int64  // the offset of the current block in the file
+	nextOff  int64  // the offset of the next block in the file
+	err      error
+}
+
+// NewReader returns a Reader that reads the entities of an export file from r.
+func NewReader(r io.Reader) *Reader {
+	return &Reader{r: bufio.NewReaderSize(r, blockSize)}
+}
+
+// Read returns the next entity of the file. It returns io.EOF at the end of
+// the file, and ErrCorrupt, possibly wrapped, if the file is not a valid
+// export file.
+func (r *Reader) Read() (*datastore.Entity, error) {
+	if r.err != nil {
+		return nil, r.err
+	}
+	rec, err := r.record()
+	if err == nil {
+		var e *datastore.Entity
+		if e, err = decodeEntity(rec); err == nil {
+			return e, nil
+		}
+	}
+	r.err = err
+	return nil, err
+}
+
+// record returns the next record of the file.
+func (r *Reader) record() ([]byte, error) {
+	var (
+		rec     []byte
+		inChunk bool // whether a first chunk has been read, but not a last one
+	)
+	for {
+		typ, data, err := r.chunk()
+		if err == io.EOF && inChunk {
+			err = fmt.Errorf("%w: truncated record", ErrCorrupt)
+		}
+		if err != nil {
+			return nil, err
+		}
+		switch typ {
+		case chunkZero:
+			continue
+		case chunkFull:
+			if inChunk {
+				return nil, fmt.Errorf("%w: unterminated record at offset %d", ErrCorrupt, r.blockOff)
+			}
+			return data, nil
+		case chunkFirst:
+			if inChunk {
+				return nil, fmt.Errorf("%w: unterminated record at offset %d", ErrCorrupt, r.blockOff)
+			}
+			rec, inChunk = append(rec[:0], data...), true
+		case chunkMiddle, chunkLast:
+			if !inChunk {
+				return nil, fmt.Errorf("%w: chunk without a first chunk at offset %d", ErrCorrupt, r.blockOff)
+			}
+			rec = append(rec, data...)
+			if typ == chunkLast {
+				return rec, nil
+			}
+		default:
+			return nil, fmt.Errorf("%w: unknown chunk type %d", ErrCorrupt, typ)
+		}
+	}
+}
+
+// chunk returns the type and the data of the next chunk of the file, reading
+// the next block if the current one has no more chunks.
+func (r *Reader) chunk() (typ byte, data []byte, err error) {
+	if len(r.block) < headerSize {
+		if err := r.nextBlock(); err != nil {
+			return 0, nil, err
+		}
+	}
+	h := r.block[:headerSize]
+	sum := binary.LittleEndian.Uint32(h[0:4])
+	n := int(binary.LittleEndian.Uint16(h[4:6]))
+	typ = h[6]
+	if typ == chunkZero && sum == 0 && n == 0 {
+		// The rest of the block is padding.
+		r.block = nil
+		return chunkZero, nil, nil
+	}
+	if headerSize+n > len(r.block) {
+		return 0, nil, fmt.Errorf("%w: chunk of %d bytes past its block at offset %d", ErrCorrupt, n, r.blockOff)
+	}
+	data = r.block[headerSize : headerSize+n]
+	if unmask(sum) != crc32.Update(crc32.Checksum(h[6:7], crcTable), crcTable, data) {
+		return 0, nil, fmt.Errorf("%w: checksum mismatch at offset %d", ErrCorrupt, r.blockOff)
+	}
+	r.block = r.block[headerSize+n:]
+	return typ, data, nil
+}
+
+// nextBlock reads the next block of the file.
+func (r *Reader) nextBlock() error {
+	if r.buf == nil {
+		r.buf = make([]byte, blockSize)
+	}
+	n, err := io.ReadFull(r.r, r.buf)
+	if err == io.ErrUnexpectedEOF {
+		err = nil
+	}
+	if err != nil {
+		return err
+	}
+	if n < headerSize {
+		// A last block too short for a chunk is padding.
+		return io.EOF
+	}
+	r.blockOff, r.nextOff = r.nextOff, r.nextOff+int64(n)
+	r.block = r.buf[:n]
+	return nil
+}
+
+// unmask returns the CRC of a masked CRC of the log format.
+func unmask(masked uint32) uint32 {
+	rot := masked - 0xa282ead8
+	return rot>>17 | rot<<15
+}
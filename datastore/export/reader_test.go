@@ -0,0 +1,271 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package export
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"hash/crc32"
+	"io"
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/datastore"
+	"cloud.google.com/go/internal/testutil"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// writeLog returns the records in LevelDB log format.
+func writeLog(records ...[]byte) []byte {
+	var (
+		buf  bytes.Buffer
+		left = blockSize // the bytes left in the current block
+	)
+	for _, rec := range records {
+		first := true
+		for {
+			if left < headerSize {
+				buf.Write(make([]byte, left))
+				left = blockSize
+			}
+			n := len(rec)
+			if n > left-headerSize {
+				n = left - headerSize
+			}
+			last := n == len(rec)
+			typ := byte(chunkMiddle)
+			switch {
+			case first && last:
+				typ = chunkFull
+			case first:
+				typ = chunkFirst
+			case last:
+				typ = chunkLast
+			}
+			var h [headerSize]byte
+			crc := crc32.Update(crc32.Checksum([]byte{typ}, crcTable), crcTable, rec[:n])
+			binary.LittleEndian.PutUint32(h[0:4], (crc>>15|crc<<17)+0xa282ead8)
+			binary.LittleEndian.PutUint16(h[4:6], uint16(n))
+			h[6] = typ
+			buf.Write(h[:])
+			buf.Write(rec[:n])
+			left -= headerSize + n
+			rec, first = rec[n:], false
+			if last {
+				break
+			}
+		}
+	}
+	return buf.Bytes()
+}
+
+// The functions below encode EntityProto messages.
+
+func appendBytes(b []byte, num protowire.Number, v []byte) []byte {
+	b = protowire.AppendTag(b, num, protowire.BytesType)
+	return protowire.AppendBytes(b, v)
+}
+
+func appendVarint(b []byte, num protowire.Number, v uint64) []byte {
+	b = protowire.AppendTag(b, num, protowire.VarintType)
+	return protowire.AppendVarint(b, v)
+}
+
+func appendDouble(b []byte, num protowire.Number, v float64) []byte {
+	b = protowire.AppendTag(b, num, protowire.Fixed64Type)
+	return protowire.AppendFixed64(b, math.Float64bits(v))
+}
+
+func appendGroup(b []byte, num protowire.Number, v []byte) []byte {
+	b = protowire.AppendTag(b, num, protowire.StartGroupType)
+	b = append(b, v...)
+	return protowire.AppendTag(b, num, protowire.EndGroupType)
+}
+
+// encodePath encodes the path of k, with the field numbers of the elements
+// of a Path, or of a ReferenceValue.
+func encodePath(b []byte, k *datastore.Key, elem, typ, id, name protowire.Number) []byte {
+	if k == nil {
+		return b
+	}
+	b = encodePath(b, k.Parent, elem, typ, id, name)
+	var e []byte
+	e = appendBytes(e, typ, []byte(k.Kind))
+	if k.ID != 0 {
+		e = appendVarint(e, id, uint64(k.ID))
+	}
+	if k.Name != "" {
+		e = appendBytes(e, name, []byte(k.Name))
+	}
+	return appendGroup(b, elem, e)
+}
+
+func encodeReference(k *datastore.Key) []byte {
+	b := appendBytes(nil, 13, []byte("s~project"))
+	if k.Namespace != "" {
+		b = appendBytes(b, referenceNamespace, []byte(k.Namespace))
+	}
+	return appendBytes(b, referencePath, encodePath(nil, k, pathElement, pathElementType, pathElementID, pathElementName))
+}
+
+type testProperty struct {
+	name     string
+	meaning  uint64
+	multiple bool
+	raw      bool
+	value    []byte // an encoded PropertyValue
+}
+
+func encodeEntity(k *datastore.Key, props ...testProperty) []byte {
+	var b []byte
+	if k != nil {
+		b = appendBytes(b, entityKey, encodeReference(k))
+	}
+	for _, p := range props {
+		var pb []byte
+		if p.meaning != 0 {
+			pb = appendVarint(pb, propertyMeaning, p.meaning)
+		}
+		pb = appendBytes(pb, propertyName, []byte(p.name))
+		multiple := uint64(0)
+		if p.multiple {
+			multiple = 1
+		}
+		pb = appendVarint(pb, propertyMultiple, multiple)
+		pb = appendBytes(pb, propertyValue, p.value)
+		num := protowire.Number(entityProperty)
+		if p.raw {
+			num = entityRawProperty
+		}
+		b = appendBytes(b, num, pb)
+	}
+	return b
+}
+
+func TestReader(t *testing.T) {
+	parent := datastore.NameKey("Parent", "p", nil)
+	parent.Namespace = "ns"
+	key := datastore.IDKey("Task", 42, parent)
+	key.Namespace = "ns"
+	ref := datastore.NameKey("Other", "o", nil)
+	when := time.Date(2023, 8, 1, 12, 30, 0, 123456000, time.UTC)
+	long := strings.Repeat("x", 3*blockSize)
+
+	str := func(s string) []byte { return appendBytes(nil, valueString, []byte(s)) }
+	integer := func(i int64) []byte { return appendVarint(nil, valueInt64, uint64(i)) }
+	inner := encodeEntity(nil, testProperty{name: "Inner", value: integer(7)})
+	records := [][]byte{
+		encodeEntity(key,
+			testProperty{name: "Int", value: integer(-3)},
+			testProperty{name: "Bool", value: appendVarint(nil, valueBoolean, 1)},
+			testProperty{name: "Float", value: appendDouble(nil, valueDouble, 1.5)},
+			testProperty{name: "String", value: str("hello")},
+			testProperty{name: "When", meaning: meaningGDWhen, value: integer(when.UnixMicro())},
+			testProperty{name: "Point", value: appendGroup(nil, valuePoint, appendDouble(appendDouble(nil, valuePointX, 1.25), valuePointY, -2.5))},
+			testProperty{name: "Ref", value: appendGroup(nil, valueReference,
+				encodePath(appendBytes(nil, 13, []byte("s~project")), ref, valueRefPath, valueRefType, valueRefID, valueRefName))},
+			testProperty{name: "User", value: appendGroup(nil, valueUser, appendBytes(nil, valueUserEmail, []byte("a@example.com")))},
+			testProperty{name: "Tags", multiple: true, value: str("a")},
+			testProperty{name: "Nested", meaning: meaningEntityProto, value: str(string(inner))},
+			testProperty{name: "Null", value: nil},
+			testProperty{name: "Text", raw: true, meaning: 15, value: str(long)},
+			testProperty{name: "Blob", raw: true, meaning: meaningBlob, value: str("\x00\x01")},
+			testProperty{name: "Tags", multiple: true, raw: true, value: str("b")},
+		),
+		encodeEntity(datastore.NameKey("Task", "second", nil)),
+	}
+	want := []*datastore.Entity{
+		{
+			Key: key,
+			Properties: []datastore.Property{
+				{Name: "Int", Value: int64(-3)},
+				{Name: "Bool", Value: true},
+				{Name: "Float", Value: 1.5},
+				{Name: "String", Value: "hello"},
+				{Name: "When", Value: when},
+				{Name: "Point", Value: datastore.GeoPoint{Lat: 1.25, Lng: -2.5}},
+				{Name: "Ref", Value: ref},
+				{Name: "User", Value: "a@example.com"},
+				{Name: "Tags", Value: []interface{}{"a", "b"}},
+				{Name: "Nested", Value: &datastore.Entity{Properties: []datastore.Property{{Name: "Inner", Value: int64(7)}}}},
+				{Name: "Null", Value: nil},
+				{Name: "Text", Value: long, NoIndex: true},
+				{Name: "Blob", Value: []byte{0, 1}, NoIndex: true},
+			},
+		},
+		{Key: datastore.NameKey("Task", "second", nil)},
+	}
+
+	r := NewReader(bytes.NewReader(writeLog(records...)))
+	var got []*datastore.Entity
+	for {
+		e, err := r.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		got = append(got, e)
+	}
+	if diff := testutil.Diff(got, want); diff != "" {
+		t.Errorf("got=-, want=+\n%s", diff)
+	}
+
+	// The entity loads into a struct.
+	var task struct {
+		Int    int
+		When   time.Time
+		Tags   []string
+		Text   string
+		Nested struct{ Inner int }
+	}
+	if err := datastore.LoadStruct(&task, got[0].Properties); err != nil {
+		if _, ok := err.(*datastore.ErrFieldMismatch); !ok {
+			t.Fatal(err)
+		}
+	}
+	if task.Int != -3 || !task.When.Equal(when) || len(task.Tags) != 2 || task.Text != long || task.Nested.Inner != 7 {
+		t.Errorf("got %+v", task)
+	}
+}
+
+func TestReaderCorrupt(t *testing.T) {
+	log := writeLog(encodeEntity(datastore.NameKey("Task", "a", nil)), []byte(strings.Repeat("y", 2*blockSize)))
+	for _, test := range []struct {
+		desc string
+		log  []byte
+	}{
+		{"checksum", func() []byte {
+			b := append([]byte(nil), log...)
+			b[headerSize] ^= 1
+			return b
+		}()},
+		{"truncated record", log[:blockSize+100]},
+		{"bad entity", writeLog([]byte{0xff})},
+	} {
+		r := NewReader(bytes.NewReader(test.log))
+		var err error
+		for err == nil {
+			_, err = r.Read()
+		}
+		if !errors.Is(err, ErrCorrupt) {
+			t.Errorf("%s: got %v, want ErrCorrupt", test.desc, err)
+		}
+	}
+}
@@ -0,0 +1,207 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datastore
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"cloud.google.com/go/internal/fields"
+)
+
+// A Field is a property of an entity whose values have the Go type V. Its
+// methods make the filters and orders of queries on the property, so that
+// their values are of the type of the property.
+//
+// Fields are made by FieldOf, which checks the name and type of the property
+// against a struct type, or by NewField, as in the code generated by the
+// datastoregen command, which declares the fields of a type T in a variable
+// TFields:
+//
+//	q := datastore.NewQuery("User").
+//		FilterEntity(UserFields.Age.Gt(21)).
+//		Order(UserFields.Age.Desc())
+type Field[V any] struct {
+	name string
+}
+
+// NewField returns the Field of the property name, without checking that
+// an entity type has such a property of type V.
+func NewField[V any](name string) Field[V] {
+	return Field[V]{name: name}
+}
+
+// FieldOf returns the Field of the property name of the struct type T,
+// checking that T has an indexed property of that name, whose values have
+// the Go type V. The name is that of the property, which is the name of the
+// field unless its struct tag renames it; the properties of the fields of
+// nested structs are named by their paths, such as "Address.City". If the
+// field is a slice, other than a []byte, V is the type of its elements, which
+// the filters compare; if it has the "text" or "uuid" option, V is string.
+// The name "__key__" is that of the key, whose type is *Key.
+func FieldOf[T any, V any](name string) (Field[V], error) {
+	t := reflect.TypeOf((*T)(nil)).Elem()
+	v := reflect.TypeOf((*V)(nil)).Elem()
+	ft, err := propertyType(t, name)
+	if err != nil {
+		return Field[V]{}, err
+	}
+	if ft != v {
+		return Field[V]{}, fmt.Errorf("datastore: property %q of %v has type %v, not %v", name, t, ft, v)
+	}
+	return Field[V]{name: name}, nil
+}
+
+// propertyType returns the Go type of the values of the indexed property
+// name of the struct type t.
+func propertyType(t reflect.Type, name string) (reflect.Type, error) {
+	if name == keyFieldName {
+		return typeOfKeyPtr, nil
+	}
+	if t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("datastore: %v is not a struct type", t)
+	}
+	st := t
+	path := strings.Split(name, ".")
+	for i := 0; i < len(path); i++ {
+		codec, err := codecFor(st)
+		if err != nil {
+			return nil, err
+		}
+		// A property name given by a struct tag may have dots, so look for
+		// the longest prefix of the rest of the path which names a field.
+		var f *fields.Field
+		for j := len(path); j > i && f == nil; j-- {
+			f = codec.byName[strings.Join(path[i:j], ".")]
+			if f != nil {
+				i = j - 1
+			}
+		}
+		if f == nil {
+			return nil, fmt.Errorf("datastore: %v has no property %q", t, name)
+		}
+		opts, _ := f.ParsedTag.(saveOpts)
+		if opts.noIndex {
+			return nil, fmt.Errorf("datastore: property %q of %v is not indexed", name, t)
+		}
+		ft := f.Type
+		if ft.Kind() == reflect.Slice && ft.Elem().Kind() != reflect.Uint8 {
+			ft = ft.Elem()
+		}
+		if opts.text || opts.uuid {
+			ft = reflect.TypeOf("")
+		}
+		if i == len(path)-1 {
+			return ft, nil
+		}
+		if ft.Kind() == reflect.Ptr {
+			ft = ft.Elem()
+		}
+		if ft.Kind() != reflect.Struct || isLeafType(ft) {
+			return nil, fmt.Errorf("datastore: %v has no property %q", t, name)
+		}
+		st = ft
+	}
+	return nil, fmt.Errorf("datastore: %v has no property %q", t, name)
+}
+
+// Name returns the name of the property.
+func (f Field[V]) Name() string {
+	return f.name
+}
+
+// Eq returns a filter for the entities whose property equals v.
+func (f Field[V]) Eq(v V) PropertyFilter { return f.filter(equal, v) }
+
+// Ne returns a filter for the entities whose property does not equal v.
+func (f Field[V]) Ne(v V) PropertyFilter { return f.filter(notEqual, v) }
+
+// Lt returns a filter for the entities whose property is less than v.
+func (f Field[V]) Lt(v V) PropertyFilter { return f.filter(lessThan, v) }
+
+// Le returns a filter for the entities whose property is less than or equal
+// to v.
+func (f Field[V]) Le(v V) PropertyFilter { return f.filter(lessEq, v) }
+
+// Gt returns a filter for the entities whose property is greater than v.
+func (f Field[V]) Gt(v V) PropertyFilter { return f.filter(greaterThan, v) }
+
+// Ge returns a filter for the entities whose property is greater than or
+// equal to v.
+func (f Field[V]) Ge(v V) PropertyFilter { return f.filter(greaterEq, v) }
+
+// In returns a filter for the entities whose property equals one of vs.
+func (f Field[V]) In(vs ...V) PropertyFilter { return f.listFilter(in, vs) }
+
+// NotIn returns a filter for the entities whose property equals none of vs.
+func (f Field[V]) NotIn(vs ...V) PropertyFilter { return f.listFilter(notIn, vs) }
+
+// Asc returns the order of a query by the property, in ascending order, for
+// Query.Order.
+func (f Field[V]) Asc() string {
+	return strconv.Quote(f.name)
+}
+
+// Desc returns the order of a query by the property, in descending order,
+// for Query.Order.
+func (f Field[V]) Desc() string {
+	return "-" + strconv.Quote(f.name)
+}
+
+func (f Field[V]) filter(op operator, v V) PropertyFilter {
+	return PropertyFilter{FieldName: f.fieldName(), Operator: string(op), Value: filterValue(reflect.ValueOf(&v).Elem())}
+}
+
+func (f Field[V]) listFilter(op operator, vs []V) PropertyFilter {
+	values := make([]interface{}, len(vs))
+	for i := range vs {
+		values[i] = filterValue(reflect.ValueOf(&vs[i]).Elem())
+	}
+	return PropertyFilter{FieldName: f.fieldName(), Operator: string(op), Value: values}
+}
+
+// fieldName returns the name of the property for a PropertyFilter, quoted if
+// it would otherwise be taken for a quoted name.
+func (f Field[V]) fieldName() string {
+	if strings.HasPrefix(f.name, `"`) || strings.HasPrefix(f.name, "`") {
+		return strconv.Quote(f.name)
+	}
+	return f.name
+}
+
+// filterValue returns the value of a filter for v, a value of a field, which
+// is converted as when the field is saved: the values of integer, floating
+// point, string and boolean kinds are converted to int64, float64, string
+// and bool, and pointers are dereferenced.
+func filterValue(v reflect.Value) interface{} {
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int()
+	case reflect.Float32, reflect.Float64:
+		return v.Float()
+	case reflect.String:
+		return v.String()
+	case reflect.Bool:
+		return v.Bool()
+	case reflect.Ptr:
+		if v.IsNil() || v.Type() == typeOfKeyPtr {
+			return v.Interface()
+		}
+		return filterValue(v.Elem())
+	}
+	return v.Interface()
+}
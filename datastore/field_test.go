@@ -0,0 +1,128 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datastore
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/internal/testutil"
+	pb "google.golang.org/genproto/googleapis/datastore/v1"
+)
+
+type fieldAddress struct {
+	City string
+	Zip  int `datastore:",noindex"`
+}
+
+type fieldEntity struct {
+	Name    string
+	Age     int8
+	Tags    []string
+	Photo   []byte
+	Born    time.Time
+	Notes   string `datastore:",noindex"`
+	Home    fieldAddress
+	Work    *fieldAddress `datastore:",flatten"`
+	Renamed float32       `datastore:"r.x"`
+	Secret  string        `datastore:"-"`
+}
+
+func TestFieldOf(t *testing.T) {
+	mustField := func(f interface{}, err error) {
+		t.Helper()
+		if err != nil {
+			t.Error(err)
+		}
+	}
+	mustField(FieldOf[fieldEntity, string]("Name"))
+	mustField(FieldOf[fieldEntity, int8]("Age"))
+	mustField(FieldOf[fieldEntity, string]("Tags"))
+	mustField(FieldOf[fieldEntity, []byte]("Photo"))
+	mustField(FieldOf[fieldEntity, time.Time]("Born"))
+	mustField(FieldOf[fieldEntity, string]("Home.City"))
+	mustField(FieldOf[fieldEntity, string]("Work.City"))
+	mustField(FieldOf[fieldEntity, float32]("r.x"))
+	mustField(FieldOf[fieldEntity, *Key]("__key__"))
+
+	for _, test := range []struct {
+		f    func() error
+		want string
+	}{
+		{func() error { _, err := FieldOf[fieldEntity, int]("Age"); return err }, `property "Age" of datastore.fieldEntity has type int8, not int`},
+		{func() error { _, err := FieldOf[fieldEntity, []string]("Tags"); return err }, `has type string, not []string`},
+		{func() error { _, err := FieldOf[fieldEntity, string]("Notes"); return err }, `property "Notes" of datastore.fieldEntity is not indexed`},
+		{func() error { _, err := FieldOf[fieldEntity, int]("Home.Zip"); return err }, `is not indexed`},
+		{func() error { _, err := FieldOf[fieldEntity, string]("Secret"); return err }, `has no property "Secret"`},
+		{func() error { _, err := FieldOf[fieldEntity, string]("name"); return err }, `has no property "name"`},
+		{func() error { _, err := FieldOf[fieldEntity, string]("Name.X"); return err }, `has no property "Name.X"`},
+		{func() error { _, err := FieldOf[fieldEntity, string]("Born.X"); return err }, `has no property "Born.X"`},
+		{func() error { _, err := FieldOf[int, int]("X"); return err }, `int is not a struct type`},
+	} {
+		if err := test.f(); err == nil || !strings.Contains(err.Error(), test.want) {
+			t.Errorf("got %v, want error containing %q", err, test.want)
+		}
+	}
+}
+
+func TestFieldFilters(t *testing.T) {
+	age := NewField[int8]("Age")
+	tags := NewField[string]("Tags")
+	q := NewQuery("Gopher").
+		FilterEntity(age.Gt(21)).
+		FilterEntity(OrFilter{Filters: []EntityFilter{tags.Eq("a"), tags.In("b", "c")}}).
+		FilterEntity(NewField[string](`"quoted"`).Ne("x")).
+		Order(age.Desc()).
+		Order(NewField[string]("a -b").Asc())
+	got, err := q.toProto()
+	if err != nil {
+		t.Fatal(err)
+	}
+	propFilter := func(name string, op pb.PropertyFilter_Operator, v *pb.Value) *pb.Filter {
+		return &pb.Filter{FilterType: &pb.Filter_PropertyFilter{PropertyFilter: &pb.PropertyFilter{
+			Property: &pb.PropertyReference{Name: name},
+			Op:       op,
+			Value:    v,
+		}}}
+	}
+	str := func(s string) *pb.Value { return &pb.Value{ValueType: &pb.Value_StringValue{StringValue: s}} }
+	want := &pb.Query{
+		Kind: []*pb.KindExpression{{Name: "Gopher"}},
+		Filter: &pb.Filter{FilterType: &pb.Filter_CompositeFilter{CompositeFilter: &pb.CompositeFilter{
+			Op: pb.CompositeFilter_AND,
+			Filters: []*pb.Filter{
+				propFilter("Age", pb.PropertyFilter_GREATER_THAN, &pb.Value{ValueType: &pb.Value_IntegerValue{IntegerValue: 21}}),
+				{FilterType: &pb.Filter_CompositeFilter{CompositeFilter: &pb.CompositeFilter{
+					Op: pb.CompositeFilter_OR,
+					Filters: []*pb.Filter{
+						propFilter("Tags", pb.PropertyFilter_EQUAL, str("a")),
+						propFilter("Tags", pb.PropertyFilter_IN, &pb.Value{ValueType: &pb.Value_ArrayValue{ArrayValue: &pb.ArrayValue{
+							Values: []*pb.Value{str("b"), str("c")},
+						}}}),
+					},
+				}}},
+				propFilter(`"quoted"`, pb.PropertyFilter_NOT_EQUAL, str("x")),
+			},
+		}}},
+		Order: []*pb.PropertyOrder{
+			{Property: &pb.PropertyReference{Name: "Age"}, Direction: pb.PropertyOrder_DESCENDING},
+			{Property: &pb.PropertyReference{Name: "a -b"}, Direction: pb.PropertyOrder_ASCENDING},
+		},
+	}
+	if diff := testutil.Diff(got, want); diff != "" {
+		t.Errorf("got=-, want=+\n%s", diff)
+	}
+}
@@ -0,0 +1,258 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datastore
+
+import (
+	"math"
+	"reflect"
+	"time"
+)
+
+// The declarations in this file support the Load methods generated by the
+// datastoregen command (cloud.google.com/go/datastore/cmd/datastoregen),
+// which load properties into struct fields the same way as the
+// reflection-based loading of structs, but without its overhead. They are not
+// meant to be used directly.
+
+// A FieldLoader records the fields that could not be loaded by a generated
+// Load method.
+type FieldLoader struct {
+	dst        interface{}
+	mismatches FieldMismatchErrors
+	// counts holds the number of values loaded so far into each slice field,
+	// by property name. The map is constructed lazily.
+	counts map[string]int
+}
+
+// NewFieldLoader returns a FieldLoader for loading properties into dst,
+// which is a pointer to a struct.
+func NewFieldLoader(dst interface{}) *FieldLoader {
+	return &FieldLoader{dst: dst}
+}
+
+// NoSuchField records that the property p has no matching field.
+func (l *FieldLoader) NoSuchField(p Property) {
+	l.mismatch(p, "no such struct field")
+}
+
+// Err returns nil if all the properties were loaded. Otherwise it returns an
+// *ErrFieldMismatch, as the reflection-based loading of structs does.
+func (l *FieldLoader) Err() error {
+	return l.mismatches.err()
+}
+
+func (l *FieldLoader) mismatch(p Property, reason string) {
+	l.mismatches = append(l.mismatches, &ErrFieldMismatch{
+		StructType: reflect.TypeOf(l.dst).Elem(),
+		FieldName:  p.Name,
+		Reason:     reason,
+	})
+}
+
+// single returns the value of p for a field that is not a slice, and reports
+// whether there is one. A multiple-valued property is a mismatch, which
+// resets the field.
+func single[T any](l *FieldLoader, p Property, dst *T) (interface{}, bool) {
+	v := p.Value
+	if vs, ok := v.([]interface{}); ok {
+		switch len(vs) {
+		case 0:
+			return nil, false
+		case 1:
+			v = vs[0]
+		default:
+			var zero T
+			*dst = zero
+			l.mismatch(p, "multiple-valued property requires a slice field type")
+			return nil, false
+		}
+	}
+	if x, ok := v.(NoIndexElement); ok {
+		v = x.Value
+	}
+	return v, true
+}
+
+func typeMismatch[T any](l *FieldLoader, p Property, v interface{}, dst *T) {
+	p.Value = v
+	l.mismatch(p, typeMismatchReason(p, reflect.ValueOf(dst).Elem()))
+}
+
+// LoadInt loads p into the integer field *dst.
+func LoadInt[T ~int | ~int8 | ~int16 | ~int32 | ~int64](l *FieldLoader, p Property, dst *T) {
+	v, ok := single(l, p, dst)
+	if !ok {
+		return
+	}
+	switch x := v.(type) {
+	case nil:
+		*dst = 0
+	case int64:
+		if int64(T(x)) != x {
+			l.mismatch(p, overflowReason(x, reflect.ValueOf(dst).Elem()))
+			return
+		}
+		*dst = T(x)
+	default:
+		typeMismatch(l, p, v, dst)
+	}
+}
+
+// LoadFloat loads p into the floating-point field *dst.
+func LoadFloat[T ~float32 | ~float64](l *FieldLoader, p Property, dst *T) {
+	v, ok := single(l, p, dst)
+	if !ok {
+		return
+	}
+	switch x := v.(type) {
+	case nil:
+		*dst = 0
+	case float64:
+		if math.IsInf(float64(T(x)), 0) && !math.IsInf(x, 0) {
+			l.mismatch(p, overflowReason(x, reflect.ValueOf(dst).Elem()))
+			return
+		}
+		*dst = T(x)
+	default:
+		typeMismatch(l, p, v, dst)
+	}
+}
+
+// LoadString loads p into the string field *dst.
+func LoadString[T ~string](l *FieldLoader, p Property, dst *T) {
+	v, ok := single(l, p, dst)
+	if !ok {
+		return
+	}
+	switch x := v.(type) {
+	case nil:
+		*dst = ""
+	case string:
+		*dst = T(x)
+	default:
+		typeMismatch(l, p, v, dst)
+	}
+}
+
+// LoadBool loads p into the boolean field *dst.
+func LoadBool[T ~bool](l *FieldLoader, p Property, dst *T) {
+	v, ok := single(l, p, dst)
+	if !ok {
+		return
+	}
+	switch x := v.(type) {
+	case nil:
+		*dst = false
+	case bool:
+		*dst = T(x)
+	default:
+		typeMismatch(l, p, v, dst)
+	}
+}
+
+// LoadBytes loads p into the []byte field *dst.
+func LoadBytes(l *FieldLoader, p Property, dst *[]byte) {
+	v, ok := single(l, p, dst)
+	if !ok {
+		return
+	}
+	switch x := v.(type) {
+	case nil:
+		*dst = nil
+	case []byte:
+		*dst = x
+	default:
+		typeMismatch(l, p, v, dst)
+	}
+}
+
+// LoadTime loads p into the time.Time field *dst.
+func LoadTime(l *FieldLoader, p Property, dst *time.Time) {
+	v, ok := single(l, p, dst)
+	if !ok {
+		return
+	}
+	switch x := v.(type) {
+	case nil:
+		*dst = time.Time{}
+	case time.Time:
+		*dst = x
+	case int64:
+		// Projections return times as microseconds; see setVal.
+		*dst = time.Unix(x/1e6, x%1e6).In(time.UTC)
+	default:
+		typeMismatch(l, p, v, dst)
+	}
+}
+
+// LoadGeoPoint loads p into the GeoPoint field *dst.
+func LoadGeoPoint(l *FieldLoader, p Property, dst *GeoPoint) {
+	v, ok := single(l, p, dst)
+	if !ok {
+		return
+	}
+	switch x := v.(type) {
+	case nil:
+		*dst = GeoPoint{}
+	case GeoPoint:
+		*dst = x
+	default:
+		typeMismatch(l, p, v, dst)
+	}
+}
+
+// LoadKeyField loads p into the *Key field *dst.
+func LoadKeyField(l *FieldLoader, p Property, dst **Key) {
+	v, ok := single(l, p, dst)
+	if !ok {
+		return
+	}
+	switch x := v.(type) {
+	case nil:
+		*dst = nil
+	case *Key:
+		*dst = x
+	default:
+		typeMismatch(l, p, v, dst)
+	}
+}
+
+// LoadSlice loads p into the slice field *dst, using load to load each of its
+// values into an element. Like the reflection-based loading of structs, the
+// values of the properties of the same name are loaded into consecutive
+// elements, starting with the first, which are appended if needed.
+func LoadSlice[T any](l *FieldLoader, p Property, dst *[]T, load func(*FieldLoader, Property, *T)) {
+	vs, ok := p.Value.([]interface{})
+	if !ok {
+		vs = []interface{}{p.Value}
+	}
+	if l.counts == nil {
+		l.counts = make(map[string]int)
+	}
+	n := len(l.mismatches)
+	for _, v := range vs {
+		i := l.counts[p.Name]
+		l.counts[p.Name] = i + 1
+		for len(*dst) <= i {
+			var zero T
+			*dst = append(*dst, zero)
+		}
+		load(l, Property{Name: p.Name, Value: v}, &(*dst)[i])
+		if len(l.mismatches) > n {
+			*dst = nil
+			return
+		}
+	}
+}
@@ -0,0 +1,160 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datastore_test
+
+import (
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/datastore"
+	"cloud.google.com/go/internal/testutil"
+)
+
+//go:generate go run ./cmd/datastoregen -type=genEntity -output=genentity_datastore_test.go
+
+// genEntity has methods generated by datastoregen.
+type genEntity struct {
+	K      *datastore.Key `datastore:"__key__"`
+	Name   string
+	Small  int8
+	Ratio  float32 `datastore:"ratio,noindex"`
+	Admin  bool    `datastore:",omitempty"`
+	Photo  []byte  `datastore:",noindex"`
+	Born   time.Time
+	Home   datastore.GeoPoint `datastore:",omitzero"`
+	Parent *datastore.Key
+	Tags   []string
+	Scores []int64
+}
+
+// reflectEntity is loaded and saved with reflection.
+type reflectEntity genEntity
+
+func TestGeneratedSave(t *testing.T) {
+	for _, src := range []genEntity{
+		{},
+		{
+			K:      datastore.NameKey("Gopher", "g", nil),
+			Name:   "George",
+			Small:  -3,
+			Ratio:  0.5,
+			Admin:  true,
+			Photo:  []byte{1, 2},
+			Born:   time.Date(2009, 11, 10, 23, 0, 0, 0, time.UTC),
+			Home:   datastore.GeoPoint{Lat: 1, Lng: 2},
+			Parent: datastore.IDKey("Burrow", 7, nil),
+			Tags:   []string{"a", "b"},
+			Scores: []int64{1},
+		},
+	} {
+		got, err := src.Save()
+		if err != nil {
+			t.Fatal(err)
+		}
+		r := reflectEntity(src)
+		want, err := datastore.SaveStruct(&r)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := testutil.Diff(got, want); diff != "" {
+			t.Errorf("%+v: (-generated +reflection)\n%s", src, diff)
+		}
+	}
+}
+
+func TestGeneratedLoad(t *testing.T) {
+	for _, props := range [][]datastore.Property{
+		{
+			{Name: "Name", Value: "George"},
+			{Name: "Small", Value: int64(-3)},
+			{Name: "ratio", Value: 0.5},
+			{Name: "Admin", Value: true},
+			{Name: "Photo", Value: []byte{1, 2}},
+			{Name: "Born", Value: time.Date(2009, 11, 10, 23, 0, 0, 0, time.UTC)},
+			{Name: "Home", Value: datastore.GeoPoint{Lat: 1, Lng: 2}},
+			{Name: "Parent", Value: datastore.IDKey("Burrow", 7, nil)},
+			{Name: "Tags", Value: []interface{}{"a", datastore.NoIndexElement{Value: "b"}}},
+			{Name: "Scores", Value: int64(4)},
+		},
+		{
+			{Name: "Name", Value: nil},
+			{Name: "Born", Value: int64(1500000)},
+			{Name: "Tags", Value: []interface{}{}},
+		},
+		{
+			{Name: "Name", Value: int64(1)},
+			{Name: "Small", Value: int64(300)},
+			{Name: "ratio", Value: math.MaxFloat64},
+			{Name: "Admin", Value: []interface{}{true, false}},
+			{Name: "Tags", Value: []interface{}{"a", int64(1)}},
+			{Name: "Unknown", Value: "x"},
+		},
+	} {
+		var got genEntity
+		gotErr := got.Load(props)
+		var want reflectEntity
+		wantErr := datastore.LoadStruct(&want, props)
+		if diff := testutil.Diff(got, genEntity(want)); diff != "" {
+			t.Errorf("%v: (-generated +reflection)\n%s", props, diff)
+		}
+		if diff := testutil.Diff(mismatches(gotErr), mismatches(wantErr)); diff != "" {
+			t.Errorf("%v: mismatches (-generated +reflection)\n%s", props, diff)
+		}
+	}
+}
+
+// mismatches returns the fields and reasons of the field mismatches of err.
+func mismatches(err error) []string {
+	var all datastore.FieldMismatchErrors
+	errors.As(err, &all)
+	var s []string
+	for _, e := range all {
+		s = append(s, e.FieldName+": "+e.Reason)
+	}
+	return s
+}
+
+func TestGeneratedLoadKey(t *testing.T) {
+	var e genEntity
+	k := datastore.NameKey("Gopher", "g", nil)
+	if err := e.LoadKey(k); err != nil || e.K != k {
+		t.Errorf("got %v and key %v, want key %v", err, e.K, k)
+	}
+}
+
+func TestGeneratedFields(t *testing.T) {
+	// The generated fields are those that FieldOf checks.
+	checkField(t, genEntityFields.K)
+	checkField(t, genEntityFields.Name)
+	checkField(t, genEntityFields.Small)
+	checkField(t, genEntityFields.Admin)
+	checkField(t, genEntityFields.Born)
+	checkField(t, genEntityFields.Home)
+	checkField(t, genEntityFields.Parent)
+	checkField(t, genEntityFields.Tags)
+	checkField(t, genEntityFields.Scores)
+}
+
+func checkField[V any](t *testing.T, f datastore.Field[V]) {
+	t.Helper()
+	want, err := datastore.FieldOf[genEntity, V](f.Name())
+	if err != nil {
+		t.Error(err)
+	} else if f != want {
+		t.Errorf("got %v, want %v", f, want)
+	}
+}
@@ -0,0 +1,184 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package index
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	admin "cloud.google.com/go/datastore/admin/apiv1"
+	"cloud.google.com/go/datastore/admin/apiv1/adminpb"
+	gax "github.com/googleapis/gax-go/v2"
+	"google.golang.org/api/iterator"
+	"google.golang.org/api/option"
+)
+
+// A Client manages the composite indexes of a project.
+type Client struct {
+	admin     *admin.DatastoreAdminClient
+	projectID string
+	backoff   gax.Backoff // the intervals of the polls of waits
+}
+
+// NewClient returns a Client of the project, with a new Datastore Admin API
+// client made with the options. Call Close when done with it.
+func NewClient(ctx context.Context, projectID string, opts ...option.ClientOption) (*Client, error) {
+	if projectID == "" {
+		return nil, errors.New("index: missing project id")
+	}
+	ac, err := admin.NewDatastoreAdminClient(ctx, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &Client{
+		admin:     ac,
+		projectID: projectID,
+		backoff:   gax.Backoff{Initial: 5 * time.Second, Max: time.Minute, Multiplier: 1.5},
+	}, nil
+}
+
+// Close closes the connection of the client.
+func (c *Client) Close() error {
+	return c.admin.Close()
+}
+
+// CreateIndex starts the creation of the index idx, whose ID and State are
+// ignored. Creating an index which exists does nothing.
+func (c *Client) CreateIndex(ctx context.Context, idx *Index) (*Operation, error) {
+	pb, err := idx.toProto()
+	if err != nil {
+		return nil, err
+	}
+	op, err := c.admin.CreateIndex(ctx, &adminpb.CreateIndexRequest{ProjectId: c.projectID, Index: pb})
+	if err != nil {
+		return nil, err
+	}
+	return c.newOperation(op.Name(), op), nil
+}
+
+// DeleteIndex starts the deletion of the index of the given ID.
+func (c *Client) DeleteIndex(ctx context.Context, id string) (*Operation, error) {
+	op, err := c.admin.DeleteIndex(ctx, &adminpb.DeleteIndexRequest{ProjectId: c.projectID, IndexId: id})
+	if err != nil {
+		return nil, err
+	}
+	return c.newOperation(op.Name(), op), nil
+}
+
+// GetIndex returns the index of the given ID.
+func (c *Client) GetIndex(ctx context.Context, id string) (*Index, error) {
+	pb, err := c.admin.GetIndex(ctx, &adminpb.GetIndexRequest{ProjectId: c.projectID, IndexId: id})
+	if err != nil {
+		return nil, err
+	}
+	return indexFromProto(pb), nil
+}
+
+// ListIndexes returns the composite indexes of the project.
+func (c *Client) ListIndexes(ctx context.Context) ([]*Index, error) {
+	var idxs []*Index
+	it := c.admin.ListIndexes(ctx, &adminpb.ListIndexesRequest{ProjectId: c.projectID})
+	for {
+		pb, err := it.Next()
+		if err == iterator.Done {
+			return idxs, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		idxs = append(idxs, indexFromProto(pb))
+	}
+}
+
+// WaitForIndex polls the index of the given ID until it is ready, and returns
+// it. It returns an error if the index fails to be built, or is deleted. It
+// waits for indexes created by other means than CreateIndex, such as by
+// deploying an index.yaml file.
+func (c *Client) WaitForIndex(ctx context.Context, id string) (*Index, error) {
+	bo := c.backoff
+	for {
+		idx, err := c.GetIndex(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		switch idx.State {
+		case adminpb.Index_READY:
+			return idx, nil
+		case adminpb.Index_ERROR:
+			return idx, fmt.Errorf("index: index %s %v failed to be built", id, idx)
+		case adminpb.Index_DELETING:
+			return idx, fmt.Errorf("index: index %s %v is being deleted", id, idx)
+		}
+		if err := gax.Sleep(ctx, bo.Pause()); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// An Operation is the creation or the deletion of an index.
+type Operation struct {
+	name    string
+	lro     lro
+	backoff gax.Backoff // the intervals of the polls of Wait
+}
+
+// lro is the long-running operation of the creation or the deletion of an
+// index, which is an *admin.CreateIndexOperation or an
+// *admin.DeleteIndexOperation.
+type lro interface {
+	Poll(ctx context.Context, opts ...gax.CallOption) (*adminpb.Index, error)
+	Done() bool
+	Metadata() (*adminpb.IndexOperationMetadata, error)
+}
+
+func (c *Client) newOperation(name string, l lro) *Operation {
+	return &Operation{name: name, lro: l, backoff: c.backoff}
+}
+
+// Name returns the name of the long-running operation, with which the
+// client of the Datastore Admin API can get, cancel or resume it.
+func (op *Operation) Name() string {
+	return op.name
+}
+
+// IndexID returns the ID of the index of the operation, as of the latest
+// poll, or the empty string if it is not known yet.
+func (op *Operation) IndexID() string {
+	m, err := op.lro.Metadata()
+	if err != nil {
+		return ""
+	}
+	return m.GetIndexId()
+}
+
+// Wait polls the operation until it is done, and returns the index, which is
+// ready if it was created.
+func (op *Operation) Wait(ctx context.Context) (*Index, error) {
+	bo := op.backoff
+	for {
+		pb, err := op.lro.Poll(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if op.lro.Done() {
+			return indexFromProto(pb), nil
+		}
+		if err := gax.Sleep(ctx, bo.Pause()); err != nil {
+			return nil, err
+		}
+	}
+}
@@ -0,0 +1,238 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package index
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/datastore/admin/apiv1/adminpb"
+	"cloud.google.com/go/internal/testutil"
+	"cloud.google.com/go/longrunning/autogen/longrunningpb"
+	gax "github.com/googleapis/gax-go/v2"
+	"google.golang.org/api/option"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+// fakeAdmin is a Datastore Admin API server whose indexes are built, or
+// deleted, after two polls of their operations or of the indexes.
+type fakeAdmin struct {
+	adminpb.UnimplementedDatastoreAdminServer
+	longrunningpb.UnimplementedOperationsServer
+
+	mu      sync.Mutex
+	indexes map[string]*adminpb.Index
+	polls   map[string]int // the polls of the indexes being built or deleted
+}
+
+func newFakeAdmin() *fakeAdmin {
+	return &fakeAdmin{indexes: map[string]*adminpb.Index{}, polls: map[string]int{}}
+}
+
+func (s *fakeAdmin) CreateIndex(_ context.Context, req *adminpb.CreateIndexRequest) (*longrunningpb.Operation, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	idx := proto.Clone(req.Index).(*adminpb.Index)
+	idx.ProjectId = req.ProjectId
+	idx.IndexId = fmt.Sprintf("idx-%d", len(s.indexes)+1)
+	idx.State = adminpb.Index_CREATING
+	s.indexes[idx.IndexId] = idx
+	return s.operation("create/" + idx.IndexId)
+}
+
+func (s *fakeAdmin) DeleteIndex(_ context.Context, req *adminpb.DeleteIndexRequest) (*longrunningpb.Operation, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	idx, ok := s.indexes[req.IndexId]
+	if !ok {
+		return nil, status.Error(codes.NotFound, req.IndexId)
+	}
+	idx.State = adminpb.Index_DELETING
+	s.polls[idx.IndexId] = 0
+	return s.operation("delete/" + idx.IndexId)
+}
+
+func (s *fakeAdmin) GetIndex(_ context.Context, req *adminpb.GetIndexRequest) (*adminpb.Index, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	idx, ok := s.indexes[req.IndexId]
+	if !ok {
+		return nil, status.Error(codes.NotFound, req.IndexId)
+	}
+	s.poll(idx.IndexId)
+	return idx, nil
+}
+
+func (s *fakeAdmin) ListIndexes(_ context.Context, req *adminpb.ListIndexesRequest) (*adminpb.ListIndexesResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	resp := &adminpb.ListIndexesResponse{}
+	for _, idx := range s.indexes {
+		resp.Indexes = append(resp.Indexes, idx)
+	}
+	sort.Slice(resp.Indexes, func(i, j int) bool { return resp.Indexes[i].IndexId < resp.Indexes[j].IndexId })
+	return resp, nil
+}
+
+func (s *fakeAdmin) GetOperation(_ context.Context, req *longrunningpb.GetOperationRequest) (*longrunningpb.Operation, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, id, _ := strings.Cut(req.Name, "/")
+	s.poll(id)
+	return s.operation(req.Name)
+}
+
+// poll advances the state of the index id.
+func (s *fakeAdmin) poll(id string) {
+	idx := s.indexes[id]
+	if idx == nil {
+		return
+	}
+	if s.polls[id]++; s.polls[id] < 2 {
+		return
+	}
+	switch idx.State {
+	case adminpb.Index_CREATING:
+		idx.State = adminpb.Index_READY
+	case adminpb.Index_DELETING:
+		delete(s.indexes, id)
+	}
+}
+
+// operation returns the operation of the given name, of the creation or the
+// deletion of an index.
+func (s *fakeAdmin) operation(name string) (*longrunningpb.Operation, error) {
+	verb, id, _ := strings.Cut(name, "/")
+	idx := s.indexes[id]
+	done := verb == "create" && idx.GetState() == adminpb.Index_READY || verb == "delete" && idx == nil
+	op := &longrunningpb.Operation{Name: name, Done: done}
+	var err error
+	if op.Metadata, err = anypb.New(&adminpb.IndexOperationMetadata{IndexId: id}); err != nil {
+		return nil, err
+	}
+	if done {
+		if idx == nil {
+			idx = &adminpb.Index{IndexId: id}
+		}
+		any, err := anypb.New(idx)
+		if err != nil {
+			return nil, err
+		}
+		op.Result = &longrunningpb.Operation_Response{Response: any}
+	}
+	return op, nil
+}
+
+func newTestClient(t *testing.T, s *fakeAdmin) *Client {
+	srv, err := testutil.NewServer()
+	if err != nil {
+		t.Fatal(err)
+	}
+	adminpb.RegisterDatastoreAdminServer(srv.Gsrv, s)
+	longrunningpb.RegisterOperationsServer(srv.Gsrv, s)
+	srv.Start()
+	t.Cleanup(srv.Close)
+	conn, err := grpc.Dial(srv.Addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	c, err := NewClient(context.Background(), "project", option.WithGRPCConn(conn))
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.backoff = gax.Backoff{Initial: time.Millisecond, Max: time.Millisecond}
+	t.Cleanup(func() { c.Close() })
+	return c
+}
+
+func TestIndexLifecycle(t *testing.T) {
+	ctx := context.Background()
+	s := newFakeAdmin()
+	c := newTestClient(t, s)
+
+	task := &Index{
+		Kind:       "Task",
+		Properties: []Property{{Name: "Done"}, {Name: "Priority", Descending: true}},
+	}
+	op, err := c.CreateIndex(ctx, task)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := op.IndexID(); got != "idx-1" {
+		t.Errorf("got IndexID %q, want idx-1", got)
+	}
+	idx, err := op.Wait(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := &Index{ID: "idx-1", Kind: "Task", Properties: task.Properties, State: adminpb.Index_READY}
+	if diff := testutil.Diff(idx, want); diff != "" {
+		t.Errorf("got=-, want=+\n%s", diff)
+	}
+	if got := s.indexes["idx-1"].Ancestor; got != adminpb.Index_NONE {
+		t.Errorf("got ancestor mode %v, want NONE", got)
+	}
+
+	// An index created by other means.
+	if _, err := c.CreateIndex(ctx, &Index{Kind: "Task", Ancestor: true, Properties: []Property{{Name: "Done"}}}); err != nil {
+		t.Fatal(err)
+	}
+	idx, err = c.WaitForIndex(ctx, "idx-2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if idx.State != adminpb.Index_READY || !idx.Ancestor {
+		t.Errorf("got %+v", idx)
+	}
+
+	idxs, err := c.ListIndexes(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, idx := range idxs {
+		got = append(got, idx.ID+" "+idx.String())
+	}
+	if diff := testutil.Diff(got, []string{"idx-1 Task(Done, -Priority)", "idx-2 Task(ancestor, Done)"}); diff != "" {
+		t.Errorf("got=-, want=+\n%s", diff)
+	}
+
+	op, err = c.DeleteIndex(ctx, "idx-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := op.Wait(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.GetIndex(ctx, "idx-1"); status.Code(err) != codes.NotFound {
+		t.Errorf("got %v, want NotFound", err)
+	}
+
+	for _, bad := range []*Index{{}, {Kind: "Task", Properties: []Property{{}}}} {
+		if _, err := c.CreateIndex(ctx, bad); err == nil {
+			t.Errorf("%+v: got nil, want error", bad)
+		}
+	}
+}
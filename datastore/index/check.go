@@ -0,0 +1,258 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package index
+
+import (
+	"fmt"
+	"sort"
+
+	"cloud.google.com/go/datastore"
+	pb "google.golang.org/genproto/googleapis/datastore/v1"
+)
+
+const keyFieldName = "__key__"
+
+// A MissingIndexError is the error of Config.Check for a query which is
+// served by neither the built-in indexes nor the declared ones. Running the
+// query fails with a FAILED_PRECONDITION error until the index is created.
+type MissingIndexError struct {
+	// Index is the composite index which would serve the query.
+	Index *Index
+}
+
+func (e *MissingIndexError) Error() string {
+	return fmt.Sprintf("index: query needs the composite index %v", e.Index)
+}
+
+// Check reports whether the query is served by the built-in indexes or by
+// the indexes of the config, without calling Datastore. It returns nil if it
+// is, a *MissingIndexError if it is not, or the error of an invalid query.
+//
+// A query with OR filters is served if each of its disjunctions is. Check
+// assumes that the query is otherwise valid: it does not report, for
+// instance, a sort order which does not begin with the property of an
+// inequality filter.
+func (c *Config) Check(q *datastore.Query) error {
+	pq, err := q.Proto()
+	if err != nil {
+		return err
+	}
+	var kind string
+	if len(pq.Kind) > 0 {
+		kind = pq.Kind[0].Name
+	}
+	for _, filters := range disjuncts(pq.Filter) {
+		s := newShape(kind, filters, pq)
+		if s.builtIn() || c.serves(s) {
+			continue
+		}
+		return &MissingIndexError{Index: s.index()}
+	}
+	return nil
+}
+
+// disjuncts returns the filter in disjunctive normal form: the property
+// filters of each of the conjunctions whose disjunction it is.
+func disjuncts(f *pb.Filter) [][]*pb.PropertyFilter {
+	switch f := f.GetFilterType().(type) {
+	case *pb.Filter_PropertyFilter:
+		return [][]*pb.PropertyFilter{{f.PropertyFilter}}
+	case *pb.Filter_CompositeFilter:
+		if f.CompositeFilter.Op == pb.CompositeFilter_OR {
+			var ds [][]*pb.PropertyFilter
+			for _, g := range f.CompositeFilter.Filters {
+				ds = append(ds, disjuncts(g)...)
+			}
+			return ds
+		}
+		ds := [][]*pb.PropertyFilter{nil}
+		for _, g := range f.CompositeFilter.Filters {
+			var next [][]*pb.PropertyFilter
+			for _, e := range disjuncts(g) {
+				for _, d := range ds {
+					next = append(next, append(append([]*pb.PropertyFilter(nil), d...), e...))
+				}
+			}
+			ds = next
+		}
+		return ds
+	}
+	return [][]*pb.PropertyFilter{nil}
+}
+
+// A shape is what determines the indexes which serve a query without OR
+// filters. An index serves it if its properties are some of the properties
+// of equality filters, in any order and direction, followed by the ordered
+// properties, followed by the unordered ones in any order and direction.
+// Several such indexes serve it together if they cover all the properties of
+// equality filters.
+type shape struct {
+	kind     string
+	ancestor bool
+	// equality is the properties of equality and "in" filters, save the key.
+	equality map[string]bool
+	// ordered is the sort orders, in which the properties of inequality
+	// filters are implicitly ordered, and orders on properties of equality
+	// filters or by ascending key at the end are dropped.
+	ordered []Property
+	// unordered is the projected properties which are in no filter or order.
+	unordered map[string]bool
+}
+
+func newShape(kind string, filters []*pb.PropertyFilter, pq *pb.Query) *shape {
+	s := &shape{kind: kind, equality: map[string]bool{}, unordered: map[string]bool{}}
+	var inequality []string
+	for _, f := range filters {
+		name := f.GetProperty().GetName()
+		switch f.Op {
+		case pb.PropertyFilter_HAS_ANCESTOR:
+			s.ancestor = true
+		case pb.PropertyFilter_EQUAL, pb.PropertyFilter_IN:
+			if name != keyFieldName {
+				s.equality[name] = true
+			}
+		default:
+			// Inequalities on the key are served by the key at the end of
+			// every index.
+			if name != keyFieldName {
+				inequality = append(inequality, name)
+			}
+		}
+	}
+	ordered := map[string]bool{}
+	for _, o := range pq.Order {
+		name := o.GetProperty().GetName()
+		if s.equality[name] || ordered[name] {
+			continue
+		}
+		ordered[name] = true
+		s.ordered = append(s.ordered, Property{Name: name, Descending: o.Direction == pb.PropertyOrder_DESCENDING})
+	}
+	sort.Strings(inequality)
+	for _, name := range inequality {
+		if !ordered[name] {
+			ordered[name] = true
+			s.ordered = append(s.ordered, Property{Name: name})
+		}
+	}
+	if n := len(s.ordered); n > 0 && s.ordered[n-1] == (Property{Name: keyFieldName}) {
+		s.ordered = s.ordered[:n-1]
+	}
+	for _, p := range pq.Projection {
+		name := p.GetProperty().GetName()
+		if name != keyFieldName && !s.equality[name] && !ordered[name] {
+			s.unordered[name] = true
+		}
+	}
+	return s
+}
+
+// builtIn reports whether the built-in indexes serve the query: kindless
+// queries, queries on a single property without an ancestor, and queries
+// with only equality filters, on the ancestor or properties.
+func (s *shape) builtIn() bool {
+	if s.kind == "" {
+		return true
+	}
+	if len(s.ordered) == 0 && len(s.unordered) == 0 {
+		return true
+	}
+	props := map[string]bool{}
+	for name := range s.equality {
+		props[name] = true
+	}
+	for _, p := range s.ordered {
+		if p.Name == keyFieldName {
+			return false
+		}
+		props[p.Name] = true
+	}
+	for name := range s.unordered {
+		props[name] = true
+	}
+	return !s.ancestor && len(props) <= 1
+}
+
+// serves reports whether indexes of the config serve the query.
+func (c *Config) serves(s *shape) bool {
+	found := false
+	missing := map[string]bool{}
+	for name := range s.equality {
+		missing[name] = true
+	}
+	for _, idx := range c.Indexes {
+		if idx.Kind != s.kind || idx.Ancestor != s.ancestor {
+			continue
+		}
+		prefix, ok := s.match(idx)
+		if !ok {
+			continue
+		}
+		found = true
+		for _, p := range prefix {
+			delete(missing, p.Name)
+		}
+	}
+	return found && len(missing) == 0
+}
+
+// match reports whether the index can serve the query, alone or with
+// others, and returns its properties of equality filters.
+func (s *shape) match(idx *Index) (prefix []Property, ok bool) {
+	props := idx.Properties
+	i := 0
+	for i < len(props) && s.equality[props[i].Name] {
+		i++
+	}
+	prefix, rest := props[:i], props[i:]
+	if len(rest) != len(s.ordered)+len(s.unordered) {
+		return nil, false
+	}
+	for j, p := range s.ordered {
+		if rest[j] != p {
+			return nil, false
+		}
+	}
+	seen := map[string]bool{}
+	for _, p := range rest[len(s.ordered):] {
+		if !s.unordered[p.Name] || seen[p.Name] {
+			return nil, false
+		}
+		seen[p.Name] = true
+	}
+	return prefix, true
+}
+
+// index returns the index which serves the query alone.
+func (s *shape) index() *Index {
+	idx := &Index{Kind: s.kind, Ancestor: s.ancestor}
+	for _, name := range sortedKeys(s.equality) {
+		idx.Properties = append(idx.Properties, Property{Name: name})
+	}
+	idx.Properties = append(idx.Properties, s.ordered...)
+	for _, name := range sortedKeys(s.unordered) {
+		idx.Properties = append(idx.Properties, Property{Name: name})
+	}
+	return idx
+}
+
+func sortedKeys(m map[string]bool) []string {
+	var keys []string
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,99 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package index
+
+import (
+	"errors"
+	"testing"
+
+	"cloud.google.com/go/datastore"
+)
+
+func TestCheck(t *testing.T) {
+	c, err := ParseConfig([]byte(testConfig + `
+- kind: Task
+  properties:
+  - name: Done
+  - name: Tag
+  - name: Due
+
+- kind: Task
+  properties:
+  - name: Owner
+  - name: Due
+    direction: desc
+  - name: Title
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	parent := datastore.NameKey("List", "l", nil)
+	task := func() *datastore.Query { return datastore.NewQuery("Task") }
+	for _, test := range []struct {
+		desc string
+		q    *datastore.Query
+		want string // the missing index, or "" if the query is served
+	}{
+		{"kind", task(), ""},
+		{"kindless", datastore.NewQuery("").Ancestor(parent).FilterField("__key__", ">", parent), ""},
+		{"key order", task().Order("__key__"), ""},
+		{"equalities", task().FilterField("Done", "=", false).FilterField("Owner", "in", []string{"a", "b"}), ""},
+		{"ancestor and equalities", task().Ancestor(parent).FilterField("Done", "=", false), ""},
+		{"single property", task().FilterField("Priority", ">", 1).Order("-Priority"), ""},
+		{"single order", task().Order("-Priority"), ""},
+		{"order on equality", task().FilterField("Done", "=", true).Order("Done"), ""},
+		{"declared", task().FilterField("Done", "=", false).Order("-Priority"), ""},
+		{"declared, key order", task().FilterField("Done", "=", false).Order("-Priority").Order("__key__"), ""},
+		{"declared ancestor", task().Ancestor(parent).Order("Created"), ""},
+		{"merge join", task().FilterField("Done", "=", false).FilterField("Owner", "=", "a").Order("-Priority"), ""},
+		{"implicit order", task().FilterField("Done", "=", false).FilterField("Tag", "=", "x").FilterField("Due", "<", 5), ""},
+		{"projection", task().FilterField("Owner", "=", "a").Order("-Due").Project("Title", "Owner"), ""},
+		{"or", datastore.NewQuery("Task").FilterEntity(datastore.OrFilter{Filters: []datastore.EntityFilter{
+			datastore.PropertyFilter{FieldName: "Done", Operator: "=", Value: false},
+			datastore.PropertyFilter{FieldName: "Owner", Operator: "=", Value: "a"},
+		}}).Order("-Priority"), ""},
+
+		{"wrong direction", task().FilterField("Done", "=", false).Order("Priority"), "Task(Done, Priority)"},
+		{"ancestor", task().Ancestor(parent).Order("-Priority"), "Task(ancestor, -Priority)"},
+		{"not ancestor", task().Order("Created").Order("Done"), "Task(Created, Done)"},
+		{"merge join, missing equality", task().FilterField("Done", "=", false).FilterField("Tag", "=", "x").Order("-Priority"), "Task(Done, Tag, -Priority)"},
+		{"inequality", task().FilterField("Owner", "=", "a").FilterField("Priority", ">", 1), "Task(Owner, Priority)"},
+		{"key descending", task().Order("-__key__"), "Task(-__key__)"},
+		{"projection", task().Order("-Due").Project("Title", "Owner"), "Task(-Due, Owner, Title)"},
+		{"or, one disjunction", datastore.NewQuery("Task").FilterEntity(datastore.OrFilter{Filters: []datastore.EntityFilter{
+			datastore.PropertyFilter{FieldName: "Done", Operator: "=", Value: false},
+			datastore.PropertyFilter{FieldName: "Title", Operator: "=", Value: "t"},
+		}}).Order("-Priority"), "Task(Title, -Priority)"},
+	} {
+		err := c.Check(test.q)
+		var got string
+		var mie *MissingIndexError
+		if errors.As(err, &mie) {
+			got = mie.Index.String()
+		} else if err != nil {
+			t.Errorf("%s: %v", test.desc, err)
+			continue
+		}
+		if got != test.want {
+			t.Errorf("%s: got missing index %q, want %q", test.desc, got, test.want)
+		}
+	}
+
+	if err := c.Check(task().Order("+Done")); err == nil {
+		t.Error("invalid query: got nil, want error")
+	} else if errors.As(err, new(*MissingIndexError)) {
+		t.Errorf("invalid query: got %v, want the error of the query", err)
+	}
+}
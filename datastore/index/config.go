@@ -0,0 +1,111 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package index
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// A Config is the composite indexes declared by an index.yaml file, as
+// deployed with "gcloud datastore indexes create".
+type Config struct {
+	Indexes []*Index
+}
+
+// The types below are the format of index.yaml.
+
+type yamlConfig struct {
+	Indexes []yamlIndex `yaml:"indexes"`
+}
+
+type yamlIndex struct {
+	Kind       string         `yaml:"kind"`
+	Ancestor   string         `yaml:"ancestor"`
+	Properties []yamlProperty `yaml:"properties"`
+}
+
+type yamlProperty struct {
+	Name      string `yaml:"name"`
+	Direction string `yaml:"direction"`
+}
+
+// LoadConfig reads and parses the index.yaml file of the given name.
+func LoadConfig(filename string) (*Config, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	c, err := ParseConfig(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", filename, err)
+	}
+	return c, nil
+}
+
+// ParseConfig parses the contents of an index.yaml file. Unknown fields,
+// such as misspelled ones, are errors.
+func ParseConfig(data []byte) (*Config, error) {
+	var yc yamlConfig
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(&yc); err != nil && err != io.EOF {
+		return nil, fmt.Errorf("index: %w", err)
+	}
+	c := &Config{}
+	for i, yi := range yc.Indexes {
+		idx, err := yi.index()
+		if err != nil {
+			return nil, fmt.Errorf("index: index %d: %w", i, err)
+		}
+		c.Indexes = append(c.Indexes, idx)
+	}
+	return c, nil
+}
+
+func (yi *yamlIndex) index() (*Index, error) {
+	if yi.Kind == "" {
+		return nil, errors.New("missing kind")
+	}
+	idx := &Index{Kind: yi.Kind}
+	switch strings.ToLower(yi.Ancestor) {
+	case "", "no", "false":
+	case "yes", "true":
+		idx.Ancestor = true
+	default:
+		return nil, fmt.Errorf("invalid ancestor %q, want yes or no", yi.Ancestor)
+	}
+	for _, yp := range yi.Properties {
+		if yp.Name == "" {
+			return nil, errors.New("missing property name")
+		}
+		p := Property{Name: yp.Name}
+		switch strings.ToLower(yp.Direction) {
+		case "", "asc", "ascending":
+		case "desc", "descending":
+			p.Descending = true
+		default:
+			return nil, fmt.Errorf("property %s: invalid direction %q, want asc or desc", yp.Name, yp.Direction)
+		}
+		idx.Properties = append(idx.Properties, p)
+	}
+	return idx, nil
+}
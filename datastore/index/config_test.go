@@ -0,0 +1,95 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package index
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"cloud.google.com/go/internal/testutil"
+)
+
+const testConfig = `
+indexes:
+
+# A comment.
+- kind: Task
+  properties:
+  - name: Done
+  - name: Priority
+    direction: desc
+
+- kind: Task
+  ancestor: yes
+  properties:
+  - name: Created
+    direction: asc
+
+- kind: Task
+  properties:
+  - name: Owner
+  - name: Priority
+    direction: desc
+`
+
+func TestParseConfig(t *testing.T) {
+	c, err := ParseConfig([]byte(testConfig))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := &Config{Indexes: []*Index{
+		{Kind: "Task", Properties: []Property{{Name: "Done"}, {Name: "Priority", Descending: true}}},
+		{Kind: "Task", Ancestor: true, Properties: []Property{{Name: "Created"}}},
+		{Kind: "Task", Properties: []Property{{Name: "Owner"}, {Name: "Priority", Descending: true}}},
+	}}
+	if diff := testutil.Diff(c, want); diff != "" {
+		t.Errorf("got=-, want=+\n%s", diff)
+	}
+
+	if c, err := ParseConfig(nil); err != nil || len(c.Indexes) != 0 {
+		t.Errorf("empty file: got %v, %v", c, err)
+	}
+
+	for _, bad := range []string{
+		"indexes: [{kind: Task, ancestr: yes}]",
+		"indexes: [{kind: Task, ancestor: maybe}]",
+		"indexes: [{properties: [{name: Done}]}]",
+		"indexes: [{kind: Task, properties: [{direction: asc}]}]",
+		"indexes: [{kind: Task, properties: [{name: Done, direction: up}]}]",
+		"indexes: {kind: Task}",
+	} {
+		if _, err := ParseConfig([]byte(bad)); err == nil {
+			t.Errorf("%q: got nil, want error", bad)
+		}
+	}
+}
+
+func TestLoadConfig(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "index.yaml")
+	if err := os.WriteFile(filename, []byte(testConfig), 0644); err != nil {
+		t.Fatal(err)
+	}
+	c, err := LoadConfig(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(c.Indexes) != 3 {
+		t.Errorf("got %d indexes, want 3", len(c.Indexes))
+	}
+	if _, err := LoadConfig(filepath.Join(t.TempDir(), "missing.yaml")); err == nil {
+		t.Error("missing file: got nil, want error")
+	}
+}
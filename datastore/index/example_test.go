@@ -0,0 +1,79 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package index_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"cloud.google.com/go/datastore"
+	"cloud.google.com/go/datastore/index"
+)
+
+func ExampleClient_CreateIndex() {
+	ctx := context.Background()
+	client, err := index.NewClient(ctx, "project-id")
+	if err != nil {
+		// TODO: Handle error.
+	}
+	defer client.Close()
+
+	op, err := client.CreateIndex(ctx, &index.Index{
+		Kind:       "Task",
+		Ancestor:   true,
+		Properties: []index.Property{{Name: "Priority", Descending: true}},
+	})
+	if err != nil {
+		// TODO: Handle error.
+	}
+	idx, err := op.Wait(ctx)
+	if err != nil {
+		// TODO: Handle error.
+	}
+	fmt.Println(idx.ID, idx, idx.State)
+}
+
+func ExampleClient_ListIndexes() {
+	ctx := context.Background()
+	client, err := index.NewClient(ctx, "project-id")
+	if err != nil {
+		// TODO: Handle error.
+	}
+	defer client.Close()
+
+	idxs, err := client.ListIndexes(ctx)
+	if err != nil {
+		// TODO: Handle error.
+	}
+	for _, idx := range idxs {
+		fmt.Println(idx.ID, idx, idx.State)
+	}
+}
+
+func ExampleConfig_Check() {
+	config, err := index.LoadConfig("index.yaml")
+	if err != nil {
+		// TODO: Handle error.
+	}
+	q := datastore.NewQuery("Task").FilterField("Done", "=", false).Order("-Priority")
+	err = config.Check(q)
+	var mie *index.MissingIndexError
+	if errors.As(err, &mie) {
+		fmt.Println("index.yaml needs the index", mie.Index)
+	} else if err != nil {
+		// TODO: Handle error.
+	}
+}
@@ -0,0 +1,145 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package index manages the composite indexes of a Datastore database, with
+// the index methods of the Datastore Admin API.
+//
+// An Index describes a composite index; a Client creates, lists, gets and
+// deletes them, and waits for them to be ready:
+//
+//	client, err := index.NewClient(ctx, "project-id")
+//	if err != nil {
+//		// TODO: Handle error.
+//	}
+//	defer client.Close()
+//	op, err := client.CreateIndex(ctx, &index.Index{
+//		Kind: "Task",
+//		Properties: []index.Property{
+//			{Name: "Done"},
+//			{Name: "Priority", Descending: true},
+//		},
+//	})
+//	if err != nil {
+//		// TODO: Handle error.
+//	}
+//	idx, err := op.Wait(ctx)
+//	if err != nil {
+//		// TODO: Handle error.
+//	}
+//	fmt.Println(idx.ID, idx.State)
+//
+// A Config is the indexes declared by an index.yaml file. Its Check method
+// reports, without calling Datastore, whether a query is served by them or
+// by the built-in indexes, so that tests can catch queries which would fail
+// for want of an index before the indexes are deployed:
+//
+//	config, err := index.LoadConfig("index.yaml")
+//	if err != nil {
+//		// TODO: Handle error.
+//	}
+//	q := datastore.NewQuery("Task").FilterField("Done", "=", false).Order("-Priority")
+//	if err := config.Check(q); err != nil {
+//		// TODO: Handle error, such as a *MissingIndexError.
+//	}
+package index // import "cloud.google.com/go/datastore/index"
+
+import (
+	"fmt"
+	"strings"
+
+	"cloud.google.com/go/datastore/admin/apiv1/adminpb"
+)
+
+// An Index is a composite index of the entities of a kind.
+type Index struct {
+	// ID is the ID of the index, which Datastore assigns when it is created.
+	ID string
+	// Kind is the kind of the entities of the index.
+	Kind string
+	// Ancestor is whether the index includes the ancestors of the entities,
+	// as queries with an ancestor filter require.
+	Ancestor bool
+	// Properties are the indexed properties, in order.
+	Properties []Property
+	// State is the state of the index, which is not given to create it.
+	State adminpb.Index_State
+}
+
+// A Property is an indexed property of an Index.
+type Property struct {
+	// Name is the name of the property.
+	Name string
+	// Descending is whether the property is indexed in descending order.
+	Descending bool
+}
+
+// String returns a description of the index, as
+// "Task(Done, -Priority)" or "Task(ancestor, Priority)".
+func (idx *Index) String() string {
+	var b strings.Builder
+	b.WriteString(idx.Kind)
+	b.WriteByte('(')
+	sep := ""
+	if idx.Ancestor {
+		b.WriteString("ancestor")
+		sep = ", "
+	}
+	for _, p := range idx.Properties {
+		b.WriteString(sep)
+		if p.Descending {
+			b.WriteByte('-')
+		}
+		b.WriteString(p.Name)
+		sep = ", "
+	}
+	b.WriteByte(')')
+	return b.String()
+}
+
+func (idx *Index) toProto() (*adminpb.Index, error) {
+	if idx.Kind == "" {
+		return nil, fmt.Errorf("index: index %v has no kind", idx)
+	}
+	pb := &adminpb.Index{Kind: idx.Kind, Ancestor: adminpb.Index_NONE}
+	if idx.Ancestor {
+		pb.Ancestor = adminpb.Index_ALL_ANCESTORS
+	}
+	for _, p := range idx.Properties {
+		if p.Name == "" {
+			return nil, fmt.Errorf("index: index %v has a property with no name", idx)
+		}
+		d := adminpb.Index_ASCENDING
+		if p.Descending {
+			d = adminpb.Index_DESCENDING
+		}
+		pb.Properties = append(pb.Properties, &adminpb.Index_IndexedProperty{Name: p.Name, Direction: d})
+	}
+	return pb, nil
+}
+
+func indexFromProto(pb *adminpb.Index) *Index {
+	if pb == nil {
+		return nil
+	}
+	idx := &Index{
+		ID:       pb.IndexId,
+		Kind:     pb.Kind,
+		Ancestor: pb.Ancestor == adminpb.Index_ALL_ANCESTORS,
+		State:    pb.State,
+	}
+	for _, p := range pb.Properties {
+		idx.Properties = append(idx.Properties, Property{Name: p.Name, Descending: p.Direction == adminpb.Index_DESCENDING})
+	}
+	return idx
+}
@@ -15,6 +15,7 @@
 package datastore
 
 import (
+	"encoding"
 	"fmt"
 	"reflect"
 	"strings"
@@ -35,6 +36,11 @@ var (
 	typeOfKeyPtr        = reflect.TypeOf(&Key{})
 )
 
+// isCivilType reports whether t is civil.Date, civil.DateTime or civil.Time.
+func isCivilType(t reflect.Type) bool {
+	return t == typeOfCivilDate || t == typeOfCivilDateTime || t == typeOfCivilTime
+}
+
 // typeMismatchReason returns a string explaining why the property p could not
 // be stored in an entity field of type v.Type().
 func typeMismatchReason(p Property, v reflect.Value) string {
@@ -73,7 +79,7 @@ type propertyLoader struct {
 	m map[string]int
 }
 
-func (l *propertyLoader) load(codec fields.List, structValue reflect.Value, p Property, prev map[string]struct{}) string {
+func (l *propertyLoader) load(codec *structCodec, structValue reflect.Value, p Property, prev map[string]struct{}) string {
 	sl, ok := p.Value.([]interface{})
 	if !ok {
 		return l.loadOneElement(codec, structValue, p, prev)
@@ -90,13 +96,20 @@ func (l *propertyLoader) load(codec fields.List, structValue reflect.Value, p Pr
 // loadOneElement loads the value of Property p into structValue based on the provided
 // codec. codec is used to find the field in structValue into which p should be loaded.
 // prev is the set of property names already seen for structValue.
-func (l *propertyLoader) loadOneElement(codec fields.List, structValue reflect.Value, p Property, prev map[string]struct{}) string {
+func (l *propertyLoader) loadOneElement(codec *structCodec, structValue reflect.Value, p Property, prev map[string]struct{}) string {
 	var sliceOk bool
 	var sliceIndex int
 	var v reflect.Value
+	var text bool // Whether the field has the text option.
 
 	name := p.Name
-	fieldNames := strings.Split(name, ".")
+	var fieldNames []string
+	if strings.Contains(name, ".") {
+		fieldNames = strings.Split(name, ".")
+	} else {
+		// Most names are not those of flattened fields.
+		fieldNames = []string{name}
+	}
 
 	for len(fieldNames) > 0 {
 		var field *fields.Field
@@ -109,7 +122,7 @@ func (l *propertyLoader) loadOneElement(codec fields.List, structValue reflect.V
 		// Loop again with "A.B", etc.
 		for i := len(fieldNames); i > 0; i-- {
 			parent := strings.Join(fieldNames[:i], ".")
-			field = codec.Match(parent)
+			field = codec.match(parent)
 			if field != nil {
 				fieldNames = fieldNames[i:]
 				break
@@ -129,10 +142,14 @@ func (l *propertyLoader) loadOneElement(codec fields.List, structValue reflect.V
 		if !v.CanSet() {
 			return "cannot set struct field"
 		}
+		text = field.ParsedTag != nil && field.ParsedTag.(saveOpts).text
 
 		// If field implements PLS, we delegate loading to the PLS's Load early,
 		// and stop iterating through fields.
-		ok, err := plsFieldLoad(v, p, fieldNames)
+		ok, err := false, error(nil)
+		if !text {
+			ok, err = plsFieldLoad(v, p, fieldNames)
+		}
 		if err != nil {
 			return err.Error()
 		}
@@ -141,7 +158,7 @@ func (l *propertyLoader) loadOneElement(codec fields.List, structValue reflect.V
 		}
 
 		if field.Type.Kind() == reflect.Ptr && field.Type.Elem().Kind() == reflect.Struct {
-			codec, err = structCache.Fields(field.Type.Elem())
+			codec, err = codecFor(field.Type.Elem())
 			if err != nil {
 				return err.Error()
 			}
@@ -154,15 +171,16 @@ func (l *propertyLoader) loadOneElement(codec fields.List, structValue reflect.V
 		}
 
 		if field.Type.Kind() == reflect.Struct {
-			codec, err = structCache.Fields(field.Type)
+			codec, err = codecFor(field.Type)
 			if err != nil {
 				return err.Error()
 			}
 			structValue = v
 		}
 
-		// If the element is a slice, we need to accommodate it.
-		if v.Kind() == reflect.Slice && v.Type() != typeOfByteSlice {
+		// If the element is a slice, we need to accommodate it, unless it is
+		// loaded as a whole from text.
+		if v.Kind() == reflect.Slice && v.Type() != typeOfByteSlice && !(text && isTextUnmarshalerType(v.Type())) {
 			if l.m == nil {
 				l.m = make(map[string]int)
 			}
@@ -175,7 +193,10 @@ func (l *propertyLoader) loadOneElement(codec fields.List, structValue reflect.V
 
 			// If structValue implements PLS, we delegate loading to the PLS's
 			// Load early, and stop iterating through fields.
-			ok, err := plsFieldLoad(structValue, p, fieldNames)
+			ok, err := false, error(nil)
+			if !text {
+				ok, err = plsFieldLoad(structValue, p, fieldNames)
+			}
 			if err != nil {
 				return err.Error()
 			}
@@ -183,8 +204,17 @@ func (l *propertyLoader) loadOneElement(codec fields.List, structValue reflect.V
 				return ""
 			}
 
+			// Load the subfields of a flattened slice of struct pointers into
+			// the element, allocating it if needed.
+			if len(fieldNames) > 0 && structValue.Kind() == reflect.Ptr && structValue.Type().Elem().Kind() == reflect.Struct {
+				if structValue.IsNil() {
+					structValue.Set(reflect.New(structValue.Type().Elem()))
+				}
+				structValue = structValue.Elem()
+			}
+
 			if structValue.Type().Kind() == reflect.Struct {
-				codec, err = structCache.Fields(structValue.Type())
+				codec, err = codecFor(structValue.Type())
 				if err != nil {
 					return err.Error()
 				}
@@ -194,7 +224,7 @@ func (l *propertyLoader) loadOneElement(codec fields.List, structValue reflect.V
 	}
 
 	var slice reflect.Value
-	if v.Kind() == reflect.Slice && v.Type().Elem().Kind() != reflect.Uint8 {
+	if v.Kind() == reflect.Slice && v.Type().Elem().Kind() != reflect.Uint8 && !(text && isTextUnmarshalerType(v.Type())) {
 		slice = v
 		v = reflect.New(v.Type().Elem()).Elem()
 	} else if _, ok := prev[p.Name]; ok && !sliceOk {
@@ -206,7 +236,14 @@ func (l *propertyLoader) loadOneElement(codec fields.List, structValue reflect.V
 
 	prev[p.Name] = struct{}{}
 
-	if errReason := setVal(v, p); errReason != "" {
+	if x, ok := p.Value.(NoIndexElement); ok {
+		p.Value = x.Value
+	}
+	set := setVal
+	if text {
+		set = setTextVal
+	}
+	if errReason := set(v, p); errReason != "" {
 		// Set the slice back to its zero value.
 		if slice.IsValid() {
 			slice.Set(reflect.Zero(slice.Type()))
@@ -264,6 +301,9 @@ func plsFieldLoad(v reflect.Value, p Property, subfields []string) (ok bool, err
 
 // setVal sets 'v' to the value of the Property 'p'.
 func setVal(v reflect.Value, p Property) (s string) {
+	if ok, s := convertVal(v, p); ok {
+		return s
+	}
 	pValue := p.Value
 	switch v.Kind() {
 	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
@@ -332,6 +372,12 @@ func setVal(v reflect.Value, p Property) (s string) {
 		if v.IsNil() {
 			v.Set(reflect.New(v.Type().Elem()))
 		}
+		if isCivilType(v.Type().Elem()) {
+			return setCivilVal(v.Elem(), p)
+		}
+		if v.Type().Elem().Kind() == reflect.Array {
+			return setByteArrayVal(v.Elem(), p)
+		}
 		switch x := pValue.(type) {
 		case *Entity:
 			err := loadEntity(v.Interface(), x)
@@ -383,15 +429,8 @@ func setVal(v reflect.Value, p Property) (s string) {
 				return typeMismatchReason(p, v)
 			}
 			v.Set(reflect.ValueOf(x))
-		case typeOfCivilDate:
-			date := civil.DateOf(pValue.(time.Time).In(time.UTC))
-			v.Set(reflect.ValueOf(date))
-		case typeOfCivilDateTime:
-			dateTime := civil.DateTimeOf(pValue.(time.Time).In(time.UTC))
-			v.Set(reflect.ValueOf(dateTime))
-		case typeOfCivilTime:
-			timeVal := civil.TimeOf(pValue.(time.Time).In(time.UTC))
-			v.Set(reflect.ValueOf(timeVal))
+		case typeOfCivilDate, typeOfCivilDateTime, typeOfCivilTime:
+			return setCivilVal(v, p)
 		default:
 			ent, ok := pValue.(*Entity)
 			if !ok {
@@ -402,6 +441,13 @@ func setVal(v reflect.Value, p Property) (s string) {
 				return err.Error()
 			}
 		}
+	case reflect.Map:
+		return setMapVal(v, p)
+	case reflect.Array:
+		if v.Type().Elem().Kind() != reflect.Uint8 {
+			return typeMismatchReason(p, v)
+		}
+		return setByteArrayVal(v, p)
 	case reflect.Slice:
 		x, ok := pValue.([]byte)
 		if !ok && pValue != nil {
@@ -417,6 +463,155 @@ func setVal(v reflect.Value, p Property) (s string) {
 	return ""
 }
 
+// setCivilVal loads p into v, which is a civil.Date, civil.DateTime or
+// civil.Time. The value may be a timestamp, or a string as saved with the
+// text option.
+func setCivilVal(v reflect.Value, p Property) string {
+	switch x := p.Value.(type) {
+	case nil:
+		v.Set(reflect.Zero(v.Type()))
+	case time.Time:
+		x = x.In(time.UTC)
+		switch v.Type() {
+		case typeOfCivilDate:
+			v.Set(reflect.ValueOf(civil.DateOf(x)))
+		case typeOfCivilDateTime:
+			v.Set(reflect.ValueOf(civil.DateTimeOf(x)))
+		case typeOfCivilTime:
+			v.Set(reflect.ValueOf(civil.TimeOf(x)))
+		}
+	case string:
+		if err := v.Addr().Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(x)); err != nil {
+			return err.Error()
+		}
+	default:
+		return typeMismatchReason(p, v)
+	}
+	return ""
+}
+
+// setByteArrayVal loads p into v, an array of bytes. The value may be a blob
+// of the same length, or for a UUID, a string as saved with the uuid option.
+func setByteArrayVal(v reflect.Value, p Property) string {
+	switch x := p.Value.(type) {
+	case nil:
+		v.Set(reflect.Zero(v.Type()))
+	case []byte:
+		if len(x) != v.Len() {
+			return fmt.Sprintf("got %d bytes, want %d for %v", len(x), v.Len(), v.Type())
+		}
+		reflect.Copy(v, reflect.ValueOf(x))
+	case string:
+		if !isUUIDType(v.Type()) {
+			return typeMismatchReason(p, v)
+		}
+		b := make([]byte, 16)
+		if err := parseUUID(b, x); err != nil {
+			return err.Error()
+		}
+		reflect.Copy(v, reflect.ValueOf(b))
+	default:
+		return typeMismatchReason(p, v)
+	}
+	return ""
+}
+
+// setMapVal loads p, which must be an entity value or nil, into the map v,
+// whose keys are strings. The entries of the entity replace those of the
+// same keys in v, and the other entries of v are kept. A nil value sets v
+// to nil.
+func setMapVal(v reflect.Value, p Property) string {
+	if p.Value == nil {
+		v.Set(reflect.Zero(v.Type()))
+		return ""
+	}
+	ent, ok := p.Value.(*Entity)
+	if !ok || v.Type().Key().Kind() != reflect.String {
+		return typeMismatchReason(p, v)
+	}
+	if v.IsNil() {
+		v.Set(reflect.MakeMapWithSize(v.Type(), len(ent.Properties)))
+	}
+	elemType := v.Type().Elem()
+	for _, ep := range ent.Properties {
+		elem := reflect.New(elemType).Elem()
+		if xs, ok := ep.Value.([]interface{}); ok && elemType.Kind() == reflect.Slice {
+			elem.Set(reflect.MakeSlice(elemType, len(xs), len(xs)))
+			for i, x := range xs {
+				if s := setVal(elem.Index(i), Property{Name: ep.Name, Value: x}); s != "" {
+					return fmt.Sprintf("map key %q: %s", ep.Name, s)
+				}
+			}
+		} else if s := setVal(elem, ep); s != "" {
+			return fmt.Sprintf("map key %q: %s", ep.Name, s)
+		}
+		v.SetMapIndex(reflect.ValueOf(ep.Name).Convert(v.Type().Key()), elem)
+	}
+	return ""
+}
+
+// setTextVal loads p, which must be a string, into v with its UnmarshalText
+// method, for a field with the text option.
+func setTextVal(v reflect.Value, p Property) string {
+	if p.Value == nil {
+		v.Set(reflect.Zero(v.Type()))
+		return ""
+	}
+	if v.Kind() != reflect.Ptr {
+		v = v.Addr()
+	} else if v.IsNil() {
+		v.Set(reflect.New(v.Type().Elem()))
+	}
+	if isCivilType(v.Type().Elem()) {
+		// Civil values may also have been saved as timestamps.
+		return setCivilVal(v.Elem(), p)
+	}
+	u, ok := v.Interface().(encoding.TextUnmarshaler)
+	if !ok {
+		return fmt.Sprintf("field has the text option, but %v does not implement encoding.TextUnmarshaler", v.Type())
+	}
+	s, ok := p.Value.(string)
+	if !ok {
+		// The value may have been saved by a PropertyConverter, without the
+		// text option.
+		if ok, reason := convertVal(v, p); ok {
+			return reason
+		}
+		return typeMismatchReason(p, v.Elem())
+	}
+	if err := u.UnmarshalText([]byte(s)); err != nil {
+		return err.Error()
+	}
+	return ""
+}
+
+// convertVal loads p into v with PropertyConverter.FromProperty, if v
+// implements PropertyConverter. It reports whether it did.
+func convertVal(v reflect.Value, p Property) (ok bool, s string) {
+	t := v.Type()
+	if !typeInfoOf(t).converter {
+		return false, ""
+	}
+	switch {
+	case t.Kind() == reflect.Ptr && t.Implements(typeOfPropertyConverter):
+		if p.Value == nil {
+			v.Set(reflect.Zero(t))
+			return true, ""
+		}
+		if v.IsNil() {
+			v.Set(reflect.New(t.Elem()))
+		}
+	case reflect.PtrTo(t).Implements(typeOfPropertyConverter) && v.CanAddr():
+		v = v.Addr()
+	default:
+		return false, ""
+	}
+	if err := v.Interface().(PropertyConverter).FromProperty(p.Value); err != nil {
+		return true, err.Error()
+	}
+	return true, ""
+}
+
 // initField is similar to reflect's Value.FieldByIndex, in that it
 // returns the nested struct field corresponding to index, but it
 // initialises any nil pointers encountered when traversing the structure.
@@ -433,7 +628,8 @@ func initField(val reflect.Value, index []int) reflect.Value {
 	return val.Field(index[len(index)-1])
 }
 
-// loadEntityProto loads an EntityProto into PropertyLoadSaver or struct pointer.
+// loadEntityProto loads an EntityProto into PropertyLoadSaver, struct pointer
+// or *map[string]interface{}.
 func loadEntityProto(dst interface{}, src *pb.Entity) error {
 	ent, err := protoToEntity(src)
 	if err != nil {
@@ -458,9 +654,25 @@ func loadEntity(dst interface{}, ent *Entity) error {
 		}
 		return loadErr
 	}
+	if m, ok := dst.(*map[string]interface{}); ok {
+		loadEntityToMap(m, ent)
+		return nil
+	}
 	return loadEntityToStruct(dst, ent)
 }
 
+// loadEntityToMap loads the properties of ent into *m, by name, making *m if
+// it is nil. The values are those of the properties: a multi-valued property
+// is a []interface{}, and an entity value is an *Entity.
+func loadEntityToMap(m *map[string]interface{}, ent *Entity) {
+	if *m == nil {
+		*m = make(map[string]interface{}, len(ent.Properties))
+	}
+	for _, p := range ent.Properties {
+		(*m)[p.Name] = p.Value
+	}
+}
+
 func loadEntityToStruct(dst interface{}, ent *Entity) error {
 	pls, err := newStructPLS(dst)
 	if err != nil {
@@ -468,7 +680,7 @@ func loadEntityToStruct(dst interface{}, ent *Entity) error {
 	}
 
 	// Try and load key.
-	keyField := pls.codec.Match(keyFieldName)
+	keyField := pls.codec.match(keyFieldName)
 	if keyField != nil && ent.Key != nil {
 		pls.v.FieldByIndex(keyField.Index).Set(reflect.ValueOf(ent.Key))
 	}
@@ -478,26 +690,36 @@ func loadEntityToStruct(dst interface{}, ent *Entity) error {
 }
 
 func (s structPLS) Load(props []Property) error {
-	var fieldName, errReason string
+	var mismatches FieldMismatchErrors
 	var l propertyLoader
 
-	prev := make(map[string]struct{})
+	prev := make(map[string]struct{}, len(props))
 	for _, p := range props {
 		if errStr := l.load(s.codec, s.v, p, prev); errStr != "" {
 			// We don't return early, as we try to load as many properties as possible.
 			// It is valid to load an entity into a struct that cannot fully represent it.
 			// That case returns an error, but the caller is free to ignore it.
-			fieldName, errReason = p.Name, errStr
+			mismatches = append(mismatches, &ErrFieldMismatch{
+				StructType: s.v.Type(),
+				FieldName:  p.Name,
+				Reason:     errStr,
+			})
 		}
 	}
-	if errReason != "" {
-		return &ErrFieldMismatch{
-			StructType: s.v.Type(),
-			FieldName:  fieldName,
-			Reason:     errReason,
-		}
+	return mismatches.err()
+}
+
+// err returns the error for the mismatches of an entity: nil if there are
+// none, or else the last mismatch, which holds the others.
+func (m FieldMismatchErrors) err() error {
+	if len(m) == 0 {
+		return nil
+	}
+	e := *m[len(m)-1]
+	if len(m) > 1 {
+		e.all = &m
 	}
-	return nil
+	return &e
 }
 
 func protoToEntity(src *pb.Entity) (*Entity, error) {
@@ -16,8 +16,10 @@ package datastore
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"reflect"
+	"strings"
 	"testing"
 	"time"
 
@@ -639,6 +641,22 @@ func TestLoadArrayIndex(t *testing.T) {
 					},
 				},
 			},
+			"mixed": {
+				ValueType: &pb.Value_ArrayValue{
+					ArrayValue: &pb.ArrayValue{
+						Values: []*pb.Value{
+							{
+								ValueType:          &pb.Value_StringValue{StringValue: "5"},
+								ExcludeFromIndexes: true,
+							},
+							{
+								ValueType:          &pb.Value_StringValue{StringValue: "6"},
+								ExcludeFromIndexes: false,
+							},
+						},
+					},
+				},
+			},
 		},
 	}
 	want := &Entity{
@@ -646,6 +664,7 @@ func TestLoadArrayIndex(t *testing.T) {
 		Properties: []Property{
 			{Name: "indexed", Value: []interface{}{"1", "2"}, NoIndex: false},
 			{Name: "non-indexed", Value: []interface{}{"3", "4"}, NoIndex: true},
+			{Name: "mixed", Value: []interface{}{"5", "6"}, NoIndex: true},
 		},
 	}
 
@@ -1368,3 +1387,147 @@ func TestKeyLoaderEndToEnd(t *testing.T) {
 		}
 	}
 }
+
+func TestLoadMaps(t *testing.T) {
+	src := &Maps{
+		Counts: map[string]int{"a": 1, "b": 2},
+		Tags:   map[string][]string{"x": {"1", "2"}},
+		Inner:  map[string]Inner1{"k": {W: 1, X: "x"}},
+		Nested: map[string]map[string]bool{"n": {"t": true}},
+	}
+	props, err := SaveStruct(src)
+	if err != nil {
+		t.Fatal(err)
+	}
+	e, err := saveEntity(testKey0, src)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got := &Maps{}
+	if err := loadEntityProto(got, e); err != nil {
+		t.Fatal(err)
+	}
+	if diff := testutil.Diff(got, src); diff != "" {
+		t.Errorf("round trip: (-got +want)\n%s", diff)
+	}
+
+	// Loading merges the entries into existing maps, and a null clears them.
+	got = &Maps{
+		Counts: map[string]int{"b": 20, "z": 26},
+		Nil:    map[string]int{"a": 1},
+	}
+	if err := LoadStruct(got, props); err != nil {
+		t.Fatal(err)
+	}
+	if want := map[string]int{"a": 1, "b": 2, "z": 26}; !testutil.Equal(got.Counts, want) {
+		t.Errorf("merged map: got %v, want %v", got.Counts, want)
+	}
+	if got.Nil != nil {
+		t.Errorf("null: got %v, want nil map", got.Nil)
+	}
+
+	err = LoadStruct(&Maps{}, []Property{{Name: "Counts", Value: &Entity{Properties: []Property{{Name: "a", Value: "one"}}}}})
+	if e, ok := err.(*ErrFieldMismatch); !ok || e.FieldName != "Counts" || !strings.Contains(e.Reason, `map key "a"`) {
+		t.Errorf("bad element: got %v, want ErrFieldMismatch for Counts", err)
+	}
+	err = LoadStruct(&Maps{}, []Property{{Name: "Counts", Value: int64(1)}})
+	if e, ok := err.(*ErrFieldMismatch); !ok || e.FieldName != "Counts" {
+		t.Errorf("non-entity: got %v, want ErrFieldMismatch for Counts", err)
+	}
+}
+
+func TestFlattenPointers(t *testing.T) {
+	type inner struct {
+		A int
+		B string
+	}
+	type outer struct {
+		P   *inner   `datastore:",flatten"`
+		Nil *inner   `datastore:",flatten"`
+		S   []*inner `datastore:",flatten"`
+	}
+	src := &outer{P: &inner{1, "x"}, S: []*inner{{2, "y"}, {3, "z"}}}
+	props, err := SaveStruct(src)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []Property{
+		{Name: "P.A", Value: int64(1)},
+		{Name: "P.B", Value: "x"},
+		{Name: "S.A", Value: []interface{}{int64(2), int64(3)}},
+		{Name: "S.B", Value: []interface{}{"y", "z"}},
+	}
+	if diff := testutil.Diff(props, want); diff != "" {
+		t.Errorf("SaveStruct: (-got +want)\n%s", diff)
+	}
+
+	e, err := saveEntity(testKey0, src)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := &outer{}
+	if err := loadEntityProto(got, e); err != nil {
+		t.Fatal(err)
+	}
+	if diff := testutil.Diff(got, src); diff != "" {
+		t.Errorf("round trip: (-got +want)\n%s", diff)
+	}
+
+	if _, err := SaveStruct(&outer{S: []*inner{{}, nil}}); err == nil {
+		t.Error("flattened slice with nil element: got nil, want error")
+	}
+
+	type flatMap struct {
+		M map[string]int `datastore:",flatten"`
+	}
+	if _, err := SaveStruct(&flatMap{}); err == nil {
+		t.Error("flattened map: got nil, want error")
+	}
+}
+
+func TestFieldMismatchErrors(t *testing.T) {
+	type small struct {
+		A int
+	}
+	props := []Property{
+		{Name: "B", Value: int64(1)},
+		{Name: "A", Value: "one"},
+		{Name: "C", Value: int64(3)},
+	}
+	err := LoadStruct(&small{}, props)
+	e, ok := err.(*ErrFieldMismatch)
+	if !ok || e.FieldName != "C" {
+		t.Fatalf("got %v, want *ErrFieldMismatch for the last field", err)
+	}
+	if !strings.HasSuffix(err.Error(), "(and 2 other fields)") {
+		t.Errorf("got message %q, want it to count the other fields", err)
+	}
+
+	// The mismatches can be retrieved through wrapping errors.
+	var all FieldMismatchErrors
+	if !errors.As(fmt.Errorf("wrapped: %w", err), &all) {
+		t.Fatal("errors.As: got false, want true")
+	}
+	var got []string
+	for _, m := range all {
+		got = append(got, m.FieldName)
+		if m.StructType != reflect.TypeOf(small{}) {
+			t.Errorf("%s: got struct type %v", m.FieldName, m.StructType)
+		}
+	}
+	if want := []string{"B", "A", "C"}; !testutil.Equal(got, want) {
+		t.Errorf("got fields %v, want %v", got, want)
+	}
+	if !strings.Contains(all[1].Reason, "type mismatch") {
+		t.Errorf("A: got reason %q, want type mismatch", all[1].Reason)
+	}
+
+	err = LoadStruct(&small{}, props[:1])
+	if !errors.As(err, &all) || len(all) != 1 || all[0] != err {
+		t.Errorf("single mismatch: got %v, want a list of the error itself", all)
+	}
+	if strings.Contains(err.Error(), "other fields") {
+		t.Errorf("single mismatch: got message %q", err)
+	}
+}
@@ -20,11 +20,15 @@ package datastore
 // https://github.com/googleapis/google-cloud-go/blob/main/firestore/mock_test.go
 //
 // You must add new methods to this server when testing additional
+//
+// testutil.MockServer generalizes this server. Switch to it once the version
+// of cloud.google.com/go required by this module has it.
 
 import (
 	"context"
 	"fmt"
 	"reflect"
+	"sync"
 	"testing"
 
 	"cloud.google.com/go/internal/testutil"
@@ -33,6 +37,7 @@ import (
 	pb "google.golang.org/genproto/googleapis/datastore/v1"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
 )
 
 type mockServer struct {
@@ -41,6 +46,16 @@ type mockServer struct {
 	Addr     string
 	reqItems []reqItem
 	resps    []interface{}
+
+	mu    sync.Mutex
+	calls []mockCall
+}
+
+// mockCall is an RPC received by the mockServer.
+type mockCall struct {
+	method string
+	req    proto.Message
+	md     metadata.MD
 }
 
 type reqItem struct {
@@ -49,6 +64,10 @@ type reqItem struct {
 }
 
 func newMock(t *testing.T) (_ *Client, _ *mockServer, _ func()) {
+	return newMockWithDatabase(t, DefaultDatabaseID)
+}
+
+func newMockWithDatabase(t *testing.T, databaseID string) (_ *Client, _ *mockServer, _ func()) {
 	srv, cleanup, err := newMockServer()
 	if err != nil {
 		t.Fatal(err)
@@ -58,7 +77,7 @@ func newMock(t *testing.T) (_ *Client, _ *mockServer, _ func()) {
 	if err != nil {
 		t.Fatal(err)
 	}
-	client, err := NewClient(context.Background(), "projectID", option.WithGRPCConn(conn))
+	client, err := NewClientWithDatabase(context.Background(), "projectID", databaseID, option.WithGRPCConn(conn))
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -70,12 +89,13 @@ func newMock(t *testing.T) (_ *Client, _ *mockServer, _ func()) {
 }
 
 func newMockServer() (_ *mockServer, cleanup func(), _ error) {
-	srv, err := testutil.NewServer()
+	mock := &mockServer{}
+	srv, err := testutil.NewServer(grpc.UnaryInterceptor(mock.record))
 	if err != nil {
 		return nil, func() {}, err
 	}
 
-	mock := &mockServer{Addr: srv.Addr}
+	mock.Addr = srv.Addr
 	pb.RegisterDatastoreServer(srv.Gsrv, mock)
 	srv.Start()
 
@@ -132,6 +152,22 @@ func (s *mockServer) popRPC(gotReq proto.Message) (interface{}, error) {
 	return resp, nil
 }
 
+// record records the RPCs received by the server, with their metadata.
+func (s *mockServer) record(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	s.mu.Lock()
+	s.calls = append(s.calls, mockCall{method: info.FullMethod, req: req.(proto.Message), md: md})
+	s.mu.Unlock()
+	return handler(ctx, req)
+}
+
+// receivedCalls returns the RPCs received by the server.
+func (s *mockServer) receivedCalls() []mockCall {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]mockCall(nil), s.calls...)
+}
+
 func (s *mockServer) reset() {
 	s.reqItems = nil
 	s.resps = nil
@@ -152,3 +188,43 @@ func (s *mockServer) Commit(_ context.Context, in *pb.CommitRequest) (*pb.Commit
 	}
 	return res.(*pb.CommitResponse), nil
 }
+
+func (s *mockServer) RunQuery(_ context.Context, in *pb.RunQueryRequest) (*pb.RunQueryResponse, error) {
+	res, err := s.popRPC(in)
+	if err != nil {
+		return nil, err
+	}
+	return res.(*pb.RunQueryResponse), nil
+}
+
+func (s *mockServer) RunAggregationQuery(_ context.Context, in *pb.RunAggregationQueryRequest) (*pb.RunAggregationQueryResponse, error) {
+	res, err := s.popRPC(in)
+	if err != nil {
+		return nil, err
+	}
+	return res.(*pb.RunAggregationQueryResponse), nil
+}
+
+func (s *mockServer) BeginTransaction(_ context.Context, in *pb.BeginTransactionRequest) (*pb.BeginTransactionResponse, error) {
+	res, err := s.popRPC(in)
+	if err != nil {
+		return nil, err
+	}
+	return res.(*pb.BeginTransactionResponse), nil
+}
+
+func (s *mockServer) Rollback(_ context.Context, in *pb.RollbackRequest) (*pb.RollbackResponse, error) {
+	res, err := s.popRPC(in)
+	if err != nil {
+		return nil, err
+	}
+	return res.(*pb.RollbackResponse), nil
+}
+
+func (s *mockServer) AllocateIds(_ context.Context, in *pb.AllocateIdsRequest) (*pb.AllocateIdsResponse, error) {
+	res, err := s.popRPC(in)
+	if err != nil {
+		return nil, err
+	}
+	return res.(*pb.AllocateIdsResponse), nil
+}
@@ -0,0 +1,144 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datastore
+
+import (
+	"context"
+	"errors"
+
+	"google.golang.org/api/iterator"
+)
+
+// A Page is a page of the results of a query, returned by PageIterator.Next.
+type Page[T any] struct {
+	// Entities and Keys are the entities and keys of the results, in order.
+	// If the query is keys-only, the entities are zero values.
+	Entities []T
+	Keys     []*Key
+
+	// NextPageToken is the token of the next page, to be passed to Pages to
+	// resume the query, or the empty string if this is the last page.
+	NextPageToken string
+}
+
+// PageIterator is the result of Pages. It returns the results of a query a
+// page at a time.
+//
+// It is not safe for concurrent use.
+type PageIterator[T any] struct {
+	ctx      context.Context
+	client   *Client
+	query    *Query
+	pageSize int
+	token    string
+	started  bool
+	err      error
+}
+
+// Pages returns an iterator over the results of q in pages of pageSize
+// results, starting at the page of pageToken, which is the empty string for
+// the first page, or the NextPageToken of a Page of the same query.
+//
+// The page tokens are opaque strings which encode a cursor of q with
+// Query.EncodeCursor, so that they can be passed to the clients of an API and
+// back. A token of a different query is rejected with ErrCursorMismatch. q
+// must not have a limit or an offset; if it has a start cursor, it is the
+// start of the first page.
+func Pages[T any](ctx context.Context, c *Client, q *Query, pageSize int, pageToken string) *PageIterator[T] {
+	p := &PageIterator[T]{ctx: ctx, client: c, query: q, pageSize: pageSize, token: pageToken}
+	switch {
+	case q.err != nil:
+		p.err = q.err
+	case pageSize <= 0:
+		p.err = errors.New("datastore: page size must be positive")
+	case q.limit >= 0 || q.offset != 0:
+		p.err = errors.New("datastore: Pages requires a query without a limit or an offset")
+	}
+	return p
+}
+
+// Next returns the next page of results. When there are no more pages,
+// iterator.Done is returned as the error; the last page may be empty if the
+// query has no results.
+//
+// If the query is not keys-only and a field of an entity cannot be loaded
+// into T, Next returns the page, with the entity as far as it was loaded,
+// and an *ErrFieldMismatch.
+func (p *PageIterator[T]) Next() (*Page[T], error) {
+	if p.err != nil {
+		return nil, p.err
+	}
+	if p.started && p.token == "" {
+		return nil, iterator.Done
+	}
+	p.started = true
+
+	q := p.query
+	if p.token != "" {
+		c, err := q.DecodeCursor(p.token)
+		if err != nil {
+			p.err = err
+			return nil, err
+		}
+		q = q.Start(c)
+		if c.key == nil {
+			// The cursors of Datastore are only valid for the query
+			// which returned them, which has the same filter on the key
+			// if the query started after a key.
+			q.startKey = p.query.startKey
+		}
+	}
+	// Fetch one more result than the page holds, to find whether there is
+	// a next page.
+	it := Run[T](p.ctx, p.client, q.Limit(p.pageSize+1))
+	page := &Page[T]{}
+	var (
+		cursor           Cursor
+		errFieldMismatch error
+	)
+	for {
+		dst, k, err := it.Next()
+		if err == iterator.Done {
+			break
+		}
+		_, mismatch := err.(*ErrFieldMismatch)
+		if err != nil && !mismatch {
+			p.err = err
+			return nil, err
+		}
+		if len(page.Keys) == p.pageSize {
+			// The cursor is after the last result of the page.
+			page.NextPageToken, err = p.query.EncodeCursor(cursor)
+			if err != nil {
+				p.err = err
+				return nil, err
+			}
+			break
+		}
+		if mismatch {
+			errFieldMismatch = err
+		}
+		page.Entities = append(page.Entities, dst)
+		page.Keys = append(page.Keys, k)
+		if len(page.Keys) == p.pageSize {
+			if cursor, err = it.Cursor(); err != nil {
+				p.err = err
+				return nil, err
+			}
+		}
+	}
+	p.token = page.NextPageToken
+	return page, errFieldMismatch
+}
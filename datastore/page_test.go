@@ -0,0 +1,115 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datastore
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"cloud.google.com/go/internal/testutil"
+	"google.golang.org/api/iterator"
+)
+
+func TestPages(t *testing.T) {
+	ctx := context.Background()
+	keys := partitionKeys()[:25]
+	client := newPartitionClient(keys)
+	q := NewQuery("Gopher")
+
+	type gopher struct{ Name string }
+	for _, pageSize := range []int{1, 10, 25, 30} {
+		var (
+			got   []*Key
+			pages int
+		)
+		it := Pages[gopher](ctx, client, q, pageSize, "")
+		for {
+			page, err := it.Next()
+			if err == iterator.Done {
+				break
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			pages++
+			if len(page.Keys) > pageSize || len(page.Entities) != len(page.Keys) {
+				t.Fatalf("pageSize=%d: got a page of %d entities and %d keys", pageSize, len(page.Entities), len(page.Keys))
+			}
+			for i, k := range page.Keys {
+				if page.Entities[i].Name != fmt.Sprint(k) {
+					t.Errorf("pageSize=%d: got %q for key %v", pageSize, page.Entities[i].Name, k)
+				}
+			}
+			got = append(got, page.Keys...)
+			if page.NextPageToken != "" {
+				// Resuming from the token gives the same pages.
+				next, err := Pages[gopher](ctx, client, q, pageSize, page.NextPageToken).Next()
+				if err != nil {
+					t.Fatal(err)
+				}
+				if len(next.Keys) == 0 || !next.Keys[0].Equal(keys[len(got)]) {
+					t.Errorf("pageSize=%d: resumed at %v, want %v", pageSize, next.Keys, keys[len(got)])
+				}
+			}
+		}
+		if diff := testutil.Diff(got, keys); diff != "" {
+			t.Errorf("pageSize=%d: got=-, want=+\n%s", pageSize, diff)
+		}
+		if want := (len(keys) + pageSize - 1) / pageSize; pages != want {
+			t.Errorf("pageSize=%d: got %d pages, want %d", pageSize, pages, want)
+		}
+	}
+
+	// The tokens of a query are rejected by other queries.
+	page, err := Pages[gopher](ctx, client, q, 10, "").Next()
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = Pages[gopher](ctx, client, q.FilterField("Name", "=", "x"), 10, page.NextPageToken).Next()
+	if err != ErrCursorMismatch {
+		t.Errorf("got %v, want ErrCursorMismatch", err)
+	}
+
+	// A query with a start cursor made from a key keeps it on the next pages.
+	qs := q.Start(CursorAfterKey(keys[4]))
+	page, err = Pages[gopher](ctx, client, qs, 10, "").Next()
+	if err != nil {
+		t.Fatal(err)
+	}
+	page, err = Pages[gopher](ctx, client, qs, 10, page.NextPageToken).Next()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := testutil.Diff(page.Keys, keys[15:25]); diff != "" {
+		t.Errorf("got=-, want=+\n%s", diff)
+	}
+	if page.NextPageToken != "" {
+		t.Errorf("got next page token %q, want none", page.NextPageToken)
+	}
+
+	for _, test := range []struct {
+		q        *Query
+		pageSize int
+	}{
+		{q.Limit(5), 10},
+		{q.Offset(5), 10},
+		{q, 0},
+	} {
+		if _, err := Pages[gopher](ctx, client, test.q, test.pageSize, "").Next(); err == nil {
+			t.Errorf("%+v, pageSize=%d: got nil, want error", test.q, test.pageSize)
+		}
+	}
+}
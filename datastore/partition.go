@@ -0,0 +1,193 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datastore
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strings"
+	"sync"
+
+	"cloud.google.com/go/internal/trace"
+)
+
+// scatterProperty is the reserved property by which queries can be ordered
+// to return a random sample of the entities of a kind.
+const scatterProperty = "__scatter__"
+
+// scatterOversampling is the number of keys sampled for each partition of a
+// query, so that the partitions are of similar sizes.
+const scatterOversampling = 32
+
+// PartitionQuery splits q into at most n queries whose results are disjoint
+// ranges of keys, and together are the results of q, so that they can be run
+// in parallel, as by RunPartitioned. The ranges are found from a random
+// sample of the keys of the kind of q, so their results are of similar sizes.
+//
+// q must be a query of a kind, without an ancestor, filters, orders, limits,
+// offsets, cursors or distinct options. It may be keys-only or a projection,
+// and have a namespace, a read time or a transaction.
+func (c *Client) PartitionQuery(ctx context.Context, q *Query, n int) (_ []*Query, err error) {
+	ctx = trace.StartSpan(ctx, "cloud.google.com/go/datastore.Client.PartitionQuery")
+	defer func() { trace.EndSpan(ctx, err) }()
+
+	if q.err != nil {
+		return nil, q.err
+	}
+	if n < 1 {
+		return nil, errors.New("datastore: PartitionQuery requires at least 1 partition")
+	}
+	if q.kind == "" {
+		return nil, errors.New("datastore: PartitionQuery requires a query of a kind")
+	}
+	if q.ancestor != nil || len(q.filter) > 0 || len(q.order) > 0 || q.limit >= 0 || q.offset != 0 ||
+		q.start != nil || q.end != nil || q.startKey != nil || q.endKey != nil || q.distinct || len(q.distinctOn) > 0 {
+		return nil, errors.New("datastore: PartitionQuery requires a query without an ancestor, filters, orders, limits, offsets, cursors or distinct options")
+	}
+	if n == 1 {
+		return []*Query{q}, nil
+	}
+
+	sample := q.clone()
+	sample.projection = nil
+	sample.keysOnly = true
+	sample.order = []order{{FieldName: scatterProperty, Direction: ascending}}
+	sample.limit = int32((n - 1) * scatterOversampling)
+	keys, err := c.GetAll(ctx, sample, nil)
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(keys, func(i, j int) bool { return compareKeys(keys[i], keys[j]) < 0 })
+
+	// Split the sorted sample into n parts of equal sizes, or into single
+	// keys if it is smaller.
+	if n > len(keys) {
+		n = len(keys)
+	}
+	var splits []*Key
+	for i := 1; i < n; i++ {
+		k := keys[i*len(keys)/n]
+		if len(splits) == 0 || compareKeys(splits[len(splits)-1], k) < 0 {
+			splits = append(splits, k)
+		}
+	}
+	if len(splits) == 0 {
+		return []*Query{q}, nil
+	}
+	qs := make([]*Query, 0, len(splits)+1)
+	qs = append(qs, q.FilterField(keyFieldName, "<", splits[0]))
+	for i := 1; i < len(splits); i++ {
+		qs = append(qs, q.FilterField(keyFieldName, ">=", splits[i-1]).FilterField(keyFieldName, "<", splits[i]))
+	}
+	qs = append(qs, q.FilterField(keyFieldName, ">=", splits[len(splits)-1]))
+	return qs, nil
+}
+
+// RunPartitioned splits q into at most n queries with Client.PartitionQuery,
+// and runs them concurrently, calling f with the entity and key of each of
+// their results, as RunStream does. f is called concurrently from several
+// goroutines, so it must be safe for concurrent use, and the results are not
+// in order.
+//
+// RunPartitioned stops all the queries when f returns an error or a query
+// fails, and returns the first such error. As with RunStream, iterator.Done
+// stops RunPartitioned without error, and if a field of an entity cannot be
+// loaded into T, f is called with the entity as far as it was loaded, and if
+// there is no other error, RunPartitioned returns an *ErrFieldMismatch.
+func RunPartitioned[T any](ctx context.Context, c *Client, q *Query, n int, f func(T, *Key) error) error {
+	qs, err := c.PartitionQuery(ctx, q, n)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		wg               sync.WaitGroup
+		mu               sync.Mutex
+		firstErr         error
+		errFieldMismatch error
+	)
+	for _, q := range qs {
+		q := q
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			err := RunStream(ctx, c, q, f)
+			mu.Lock()
+			defer mu.Unlock()
+			if _, ok := err.(*ErrFieldMismatch); ok {
+				errFieldMismatch = err
+			} else if err != nil && firstErr == nil {
+				firstErr = err
+				cancel()
+			}
+		}()
+	}
+	wg.Wait()
+	if firstErr != nil {
+		return firstErr
+	}
+	return errFieldMismatch
+}
+
+// compareKeys compares the complete keys a and b of the same namespace in the
+// order of Datastore, and returns -1 if a < b, 0 if a == b, and +1 if a > b:
+// their paths are compared element by element, from the root, by kind and
+// then by identifier, with IDs before names, and an ancestor before its
+// descendants.
+func compareKeys(a, b *Key) int {
+	pa, pb := keyPath(a), keyPath(b)
+	for i := 0; i < len(pa) && i < len(pb); i++ {
+		x, y := pa[i], pb[i]
+		if c := strings.Compare(x.Kind, y.Kind); c != 0 {
+			return c
+		}
+		switch {
+		case x.Name == "" && y.Name != "":
+			return -1
+		case x.Name != "" && y.Name == "":
+			return +1
+		case x.Name != "":
+			if c := strings.Compare(x.Name, y.Name); c != 0 {
+				return c
+			}
+		case x.ID < y.ID:
+			return -1
+		case x.ID > y.ID:
+			return +1
+		}
+	}
+	switch {
+	case len(pa) < len(pb):
+		return -1
+	case len(pa) > len(pb):
+		return +1
+	}
+	return 0
+}
+
+// keyPath returns the elements of the path of k, from the root.
+func keyPath(k *Key) []*Key {
+	var path []*Key
+	for ; k != nil; k = k.Parent {
+		path = append(path, k)
+	}
+	for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+		path[i], path[j] = path[j], path[i]
+	}
+	return path
+}
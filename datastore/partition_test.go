@@ -0,0 +1,255 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datastore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"sort"
+	"sync"
+	"testing"
+
+	"google.golang.org/api/iterator"
+	pb "google.golang.org/genproto/googleapis/datastore/v1"
+)
+
+func TestCompareKeys(t *testing.T) {
+	parent := NameKey("Burrow", "b", nil)
+	// The keys in order.
+	keys := []*Key{
+		IDKey("Burrow", 7, nil),
+		NameKey("Burrow", "a", nil),
+		parent,
+		IDKey("Gopher", 2, parent),
+		IDKey("Gopher", 10, parent),
+		NameKey("Gopher", "a", parent),
+		NameKey("Burrow", "c", nil),
+		IDKey("Gopher", 1, nil),
+	}
+	for i, a := range keys {
+		for j, b := range keys {
+			want := 0
+			if i < j {
+				want = -1
+			} else if i > j {
+				want = +1
+			}
+			if got := compareKeys(a, b); got != want {
+				t.Errorf("compareKeys(%v, %v) = %d, want %d", a, b, got, want)
+			}
+		}
+	}
+}
+
+// newPartitionClient returns a client for a fake database with the entities
+// of kind Gopher and the given keys, each with a Name property. The cursors
+// of its results are the encodings of their keys.
+func newPartitionClient(keys []*Key) *Client {
+	return &Client{
+		client: &fakeClient{
+			queryFn: func(req *pb.RunQueryRequest) (*pb.RunQueryResponse, error) {
+				q := req.GetQuery()
+				var results []*pb.EntityResult
+				more := pb.QueryResultBatch_NO_MORE_RESULTS
+				if len(q.Order) > 0 && q.Order[0].Property.Name == scatterProperty {
+					// Return a random sample of the keys.
+					sample := append([]*Key(nil), keys...)
+					rand.New(rand.NewSource(1)).Shuffle(len(sample), func(i, j int) {
+						sample[i], sample[j] = sample[j], sample[i]
+					})
+					if n := int(q.Limit.GetValue()); n < len(sample) {
+						sample = sample[:n]
+					}
+					for _, k := range sample {
+						results = append(results, &pb.EntityResult{Entity: &pb.Entity{Key: keyToProto(k)}})
+					}
+				} else {
+					var start *Key
+					if q.StartCursor != nil {
+						var err error
+						if start, err = DecodeKey(string(q.StartCursor)); err != nil {
+							return nil, err
+						}
+					}
+					for _, k := range keys {
+						if start != nil && compareKeys(k, start) <= 0 || !keyMatchesFilter(k, q.Filter) {
+							continue
+						}
+						if q.Limit != nil && len(results) == int(q.Limit.Value) {
+							more = pb.QueryResultBatch_MORE_RESULTS_AFTER_LIMIT
+							break
+						}
+						results = append(results, &pb.EntityResult{
+							Entity: &pb.Entity{
+								Key: keyToProto(k),
+								Properties: map[string]*pb.Value{
+									"Name": {ValueType: &pb.Value_StringValue{StringValue: fmt.Sprint(k)}},
+								},
+							},
+							Cursor: []byte(k.Encode()),
+						})
+					}
+				}
+				batch := &pb.QueryResultBatch{
+					MoreResults:   more,
+					EntityResults: results,
+				}
+				if len(results) > 0 {
+					batch.EndCursor = results[len(results)-1].Cursor
+				}
+				return &pb.RunQueryResponse{Batch: batch}, nil
+			},
+		},
+	}
+}
+
+// keyMatchesFilter reports whether k matches f, a conjunction of filters on
+// keys.
+func keyMatchesFilter(k *Key, f *pb.Filter) bool {
+	if f == nil {
+		return true
+	}
+	if cf := f.GetCompositeFilter(); cf != nil {
+		for _, f := range cf.Filters {
+			if !keyMatchesFilter(k, f) {
+				return false
+			}
+		}
+		return true
+	}
+	pf := f.GetPropertyFilter()
+	bound, err := protoToKey(pf.Value.GetKeyValue())
+	if err != nil {
+		panic(err)
+	}
+	c := compareKeys(k, bound)
+	switch pf.Op {
+	case pb.PropertyFilter_LESS_THAN:
+		return c < 0
+	case pb.PropertyFilter_LESS_THAN_OR_EQUAL:
+		return c <= 0
+	case pb.PropertyFilter_GREATER_THAN:
+		return c > 0
+	case pb.PropertyFilter_GREATER_THAN_OR_EQUAL:
+		return c >= 0
+	}
+	panic(fmt.Sprintf("unexpected operator %v", pf.Op))
+}
+
+// partitionKeys returns the keys of the fake database, in order.
+func partitionKeys() []*Key {
+	var keys []*Key
+	for i := 1; i <= 50; i++ {
+		keys = append(keys, IDKey("Gopher", int64(i), nil))
+		keys = append(keys, NameKey("Gopher", fmt.Sprintf("g%02d", i), nil))
+	}
+	sort.Slice(keys, func(i, j int) bool { return compareKeys(keys[i], keys[j]) < 0 })
+	return keys
+}
+
+func TestPartitionQuery(t *testing.T) {
+	ctx := context.Background()
+	keys := partitionKeys()
+	client := newPartitionClient(keys)
+
+	for _, n := range []int{1, 2, 4, 200} {
+		qs, err := client.PartitionQuery(ctx, NewQuery("Gopher"), n)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(qs) > n {
+			t.Errorf("n=%d: got %d partitions", n, len(qs))
+		}
+		var got []*Key
+		for _, q := range qs {
+			ks, err := client.GetAll(ctx, q.KeysOnly(), nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(qs) > 1 && (len(ks) == 0 || len(ks) == len(keys)) {
+				t.Errorf("n=%d: got an unbalanced partition of %d keys", n, len(ks))
+			}
+			got = append(got, ks...)
+		}
+		if len(got) != len(keys) {
+			t.Errorf("n=%d: got %d keys, want %d", n, len(got), len(keys))
+		}
+		for i := 1; i < len(got); i++ {
+			if compareKeys(got[i-1], got[i]) >= 0 {
+				t.Errorf("n=%d: partitions overlap or are out of order at %v", n, got[i])
+			}
+		}
+	}
+
+	for _, q := range []*Query{
+		NewQuery(""),
+		NewQuery("Gopher").Filter("Name =", "x"),
+		NewQuery("Gopher").Order("Name"),
+		NewQuery("Gopher").Limit(3),
+		NewQuery("Gopher").Ancestor(NameKey("Burrow", "b", nil)),
+	} {
+		if _, err := client.PartitionQuery(ctx, q, 2); err == nil {
+			t.Errorf("%+v: got nil, want error", q)
+		}
+	}
+	if _, err := client.PartitionQuery(ctx, NewQuery("Gopher"), 0); err == nil {
+		t.Error("0 partitions: got nil, want error")
+	}
+}
+
+func TestRunPartitioned(t *testing.T) {
+	ctx := context.Background()
+	keys := partitionKeys()
+	client := newPartitionClient(keys)
+
+	type gopher struct{ Name string }
+	var (
+		mu  sync.Mutex
+		got []string
+	)
+	err := RunPartitioned(ctx, client, NewQuery("Gopher"), 4, func(g gopher, k *Key) error {
+		mu.Lock()
+		defer mu.Unlock()
+		if g.Name != fmt.Sprint(k) {
+			return fmt.Errorf("got %q for key %v", g.Name, k)
+		}
+		got = append(got, g.Name)
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var want []string
+	for _, k := range keys {
+		want = append(want, fmt.Sprint(k))
+	}
+	sort.Strings(got)
+	sort.Strings(want)
+	if fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+
+	errStop := errors.New("stop")
+	err = RunPartitioned(ctx, client, NewQuery("Gopher"), 4, func(gopher, *Key) error { return errStop })
+	if err != errStop {
+		t.Errorf("got %v, want %v", err, errStop)
+	}
+	err = RunPartitioned(ctx, client, NewQuery("Gopher"), 4, func(gopher, *Key) error { return iterator.Done })
+	if err != nil {
+		t.Errorf("stopped with iterator.Done: got %v, want nil", err)
+	}
+}
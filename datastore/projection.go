@@ -0,0 +1,58 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datastore
+
+// A Projection is a result of a projection query, loaded into an entity of
+// type T, which must be a struct type or implement PropertyLoadSaver, with
+// the names of the properties that the result had. The fields of Entity whose
+// properties were not projected, or which the entity did not have, keep
+// their zero values; Fields tells them apart from projected zero values.
+//
+// *Projection[T] implements PropertyLoadSaver and KeyLoader, so a Projection
+// can be the destination of Client.GetAll, Iterator.Next and the typed
+// functions, such as GetAll:
+//
+//	ps, keys, err := datastore.GetAll[datastore.Projection[Widget]](ctx, client, q)
+type Projection[T any] struct {
+	Entity T
+	// Fields has the names of the properties of the result.
+	Fields map[string]bool
+
+	key *Key
+}
+
+// LoadKey records k, so that Load loads it into a field of Entity for the
+// key, if it has one.
+func (p *Projection[T]) LoadKey(k *Key) error {
+	p.key = k
+	return nil
+}
+
+// Load loads props into p.Entity and records their names in p.Fields.
+func (p *Projection[T]) Load(props []Property) error {
+	p.Fields = make(map[string]bool, len(props))
+	for _, prop := range props {
+		p.Fields[prop.Name] = true
+	}
+	return loadEntity(&p.Entity, &Entity{Key: p.key, Properties: props})
+}
+
+// Save saves p.Entity.
+func (p *Projection[T]) Save() ([]Property, error) {
+	if pls, ok := interface{}(&p.Entity).(PropertyLoadSaver); ok {
+		return pls.Save()
+	}
+	return SaveStruct(&p.Entity)
+}
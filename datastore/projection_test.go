@@ -0,0 +1,130 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datastore
+
+import (
+	"context"
+	"testing"
+
+	"cloud.google.com/go/internal/testutil"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	pb "google.golang.org/genproto/googleapis/datastore/v1"
+)
+
+// newProjectionClient returns a client whose queries return the results of a
+// projection on Name and Height of two gophers, the second of which has no
+// Height.
+func newProjectionClient() *Client {
+	return &Client{
+		client: &fakeClient{
+			queryFn: func(*pb.RunQueryRequest) (*pb.RunQueryResponse, error) {
+				return &pb.RunQueryResponse{Batch: &pb.QueryResultBatch{
+					MoreResults: pb.QueryResultBatch_NO_MORE_RESULTS,
+					EntityResults: []*pb.EntityResult{
+						{Entity: &pb.Entity{
+							Key: keyToProto(IDKey("Gopher", 1, nil)),
+							Properties: map[string]*pb.Value{
+								"Name":   {ValueType: &pb.Value_StringValue{StringValue: "George"}},
+								"Height": {ValueType: &pb.Value_IntegerValue{IntegerValue: 0}},
+							},
+						}},
+						{Entity: &pb.Entity{
+							Key: keyToProto(IDKey("Gopher", 2, nil)),
+							Properties: map[string]*pb.Value{
+								"Name": {ValueType: &pb.Value_StringValue{StringValue: "Rufus"}},
+							},
+						}},
+					},
+				}}, nil
+			},
+		},
+	}
+}
+
+type projectedGopher struct {
+	Name   string
+	Height int
+	Weight int
+	K      *Key `datastore:"__key__"`
+}
+
+func TestProjection(t *testing.T) {
+	ctx := context.Background()
+	client := newProjectionClient()
+	q := NewQuery("Gopher").Project("Name", "Height")
+	want := []Projection[projectedGopher]{
+		{
+			Entity: projectedGopher{Name: "George", K: IDKey("Gopher", 1, nil)},
+			Fields: map[string]bool{"Name": true, "Height": true},
+		},
+		{
+			Entity: projectedGopher{Name: "Rufus", K: IDKey("Gopher", 2, nil)},
+			Fields: map[string]bool{"Name": true},
+		},
+	}
+	opt := cmpopts.IgnoreUnexported(Projection[projectedGopher]{})
+
+	var got []Projection[projectedGopher]
+	if _, err := client.GetAll(ctx, q, &got); err != nil {
+		t.Fatal(err)
+	}
+	if diff := testutil.Diff(got, want, opt); diff != "" {
+		t.Errorf("Client.GetAll: got=-, want=+\n%s", diff)
+	}
+
+	got, _, err := GetAll[Projection[projectedGopher]](ctx, client, q)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := testutil.Diff(got, want, opt); diff != "" {
+		t.Errorf("GetAll: got=-, want=+\n%s", diff)
+	}
+}
+
+func TestProjectionIntoMap(t *testing.T) {
+	ctx := context.Background()
+	client := newProjectionClient()
+	q := NewQuery("Gopher").Project("Name", "Height")
+	want := []map[string]interface{}{
+		{"Name": "George", "Height": int64(0)},
+		{"Name": "Rufus"},
+	}
+
+	var got []map[string]interface{}
+	if _, err := client.GetAll(ctx, q, &got); err != nil {
+		t.Fatal(err)
+	}
+	if diff := testutil.Diff(got, want); diff != "" {
+		t.Errorf("Client.GetAll: got=-, want=+\n%s", diff)
+	}
+
+	got, _, err := GetAll[map[string]interface{}](ctx, client, q)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := testutil.Diff(got, want); diff != "" {
+		t.Errorf("GetAll: got=-, want=+\n%s", diff)
+	}
+
+	// A map passed to Iterator.Next is added to.
+	m := map[string]interface{}{"Weight": int64(5)}
+	it := client.Run(ctx, q)
+	if _, err := it.Next(&m); err != nil {
+		t.Fatal(err)
+	}
+	if diff := testutil.Diff(m, map[string]interface{}{"Name": "George", "Height": int64(0), "Weight": int64(5)}); diff != "" {
+		t.Errorf("Iterator.Next: got=-, want=+\n%s", diff)
+	}
+}
@@ -15,6 +15,8 @@
 package datastore
 
 import (
+	"encoding"
+	"encoding/hex"
 	"fmt"
 	"reflect"
 	"strings"
@@ -43,7 +45,8 @@ type Property struct {
 	//	- []byte (up to 1 megabyte in length)
 	//	- *Entity (representing a nested struct)
 	// Value can also be:
-	//	- []interface{} where each element is one of the above types
+	//	- []interface{} where each element is one of the above types, or a
+	//	  NoIndexElement holding one of them
 	// This set is smaller than the set of valid struct field types that the
 	// datastore can load and save. A Value's type must be explicitly on
 	// the list above; it is not sufficient for the underlying type to be
@@ -63,9 +66,23 @@ type Property struct {
 	// NoIndex is whether the datastore cannot index this property.
 	// If NoIndex is set to false, []byte and string values are limited to
 	// 1500 bytes.
+	//
+	// For a []interface{} value, NoIndex applies to each element, except
+	// for the NoIndexElement values, which are never indexed.
 	NoIndex bool
 }
 
+// NoIndexElement is an element of a []interface{} property value that is
+// not indexed, even though the property is. It allows the elements of an
+// array to be indexed individually.
+//
+// NoIndexElement only affects saving. Loaded arrays never hold
+// NoIndexElements; as for other arrays, NoIndex is taken from their first
+// element.
+type NoIndexElement struct {
+	Value interface{}
+}
+
 // An Entity is the value type for a nested struct.
 // This type is only used for a Property's Value.
 type Entity struct {
@@ -79,6 +96,67 @@ type PropertyLoadSaver interface {
 	Save() ([]Property, error)
 }
 
+// PropertyConverter can be converted from and to a single property value. It
+// is implemented by the types of struct fields that need a custom
+// representation in Datastore, such as amounts of money, enumerations or
+// encrypted strings, without the struct that contains them implementing
+// PropertyLoadSaver. The methods should be implemented on a pointer to the
+// field type; the field may also be a pointer, or a slice of values or
+// pointers, but the field type itself cannot be a slice type other than a
+// byte slice type.
+//
+// If a type implements both PropertyLoadSaver and PropertyConverter,
+// PropertyLoadSaver is used.
+type PropertyConverter interface {
+	// ToProperty returns the value to save for the receiver. It must be of
+	// one of the types listed for Property.Value, except []interface{}.
+	ToProperty() (interface{}, error)
+	// FromProperty sets the receiver to a loaded value, which may be nil.
+	FromProperty(value interface{}) error
+}
+
+var typeOfPropertyConverter = reflect.TypeOf((*PropertyConverter)(nil)).Elem()
+
+// isConverterType reports whether t or *t implements PropertyConverter.
+func isConverterType(t reflect.Type) bool {
+	return t.Implements(typeOfPropertyConverter) || reflect.PtrTo(t).Implements(typeOfPropertyConverter)
+}
+
+var (
+	typeOfTextMarshaler   = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+	typeOfTextUnmarshaler = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+)
+
+// isTextUnmarshalerType reports whether t or *t implements
+// encoding.TextUnmarshaler.
+func isTextUnmarshalerType(t reflect.Type) bool {
+	return t.Implements(typeOfTextUnmarshaler) || reflect.PtrTo(t).Implements(typeOfTextUnmarshaler)
+}
+
+// isUUIDType reports whether t is an array of 16 bytes, such as the UUID
+// type of github.com/google/uuid.
+func isUUIDType(t reflect.Type) bool {
+	return t.Kind() == reflect.Array && t.Len() == 16 && t.Elem().Kind() == reflect.Uint8
+}
+
+// formatUUID returns the canonical string representation of a UUID, such as
+// "f47ac10b-58cc-0372-8567-0e02b2c3d479".
+func formatUUID(b []byte) string {
+	s := hex.EncodeToString(b)
+	return s[:8] + "-" + s[8:12] + "-" + s[12:16] + "-" + s[16:20] + "-" + s[20:]
+}
+
+// parseUUID parses the canonical string representation of a UUID into b.
+func parseUUID(b []byte, s string) error {
+	if len(s) != 36 || s[8] != '-' || s[13] != '-' || s[18] != '-' || s[23] != '-' {
+		return fmt.Errorf("invalid UUID %q", s)
+	}
+	if _, err := hex.Decode(b, []byte(s[:8]+s[9:13]+s[14:18]+s[19:23]+s[24:])); err != nil {
+		return fmt.Errorf("invalid UUID %q", s)
+	}
+	return nil
+}
+
 // KeyLoader can store a Key.
 type KeyLoader interface {
 	// PropertyLoadSaver is embedded because a KeyLoader
@@ -93,6 +171,7 @@ type PropertyList []Property
 var (
 	typeOfPropertyLoadSaver = reflect.TypeOf((*PropertyLoadSaver)(nil)).Elem()
 	typeOfPropertyList      = reflect.TypeOf(PropertyList(nil))
+	typeOfInterfaceMap      = reflect.TypeOf(map[string]interface{}(nil))
 )
 
 // Load loads all of the provided properties into l.
@@ -154,8 +233,16 @@ func parseTag(t reflect.StructTag) (name string, keep bool, other interface{}, e
 				opts.flatten = true
 			case "omitempty":
 				opts.omitEmpty = true
+			case "omitzero":
+				opts.omitZero = true
 			case "noindex":
 				opts.noIndex = true
+			case "nocascade":
+				opts.noCascade = true
+			case "text":
+				opts.text = true
+			case "uuid":
+				opts.uuid = true
 			default:
 				err = fmt.Errorf("datastore: struct tag has invalid option: %q", p)
 				return "", false, nil, err
@@ -188,7 +275,7 @@ func validateChildType(t reflect.Type, fieldName string, flatten, prevSlice bool
 		}
 		return validateChildType(t.Elem(), fieldName, flatten, true, prevTypes)
 	case reflect.Struct:
-		if t == typeOfTime || t == typeOfGeoPoint {
+		if t == typeOfTime || t == typeOfGeoPoint || isConverterType(t) {
 			return nil
 		}
 
@@ -224,6 +311,10 @@ func validateChildType(t reflect.Type, fieldName string, flatten, prevSlice bool
 			return nil
 		}
 		return validateChildType(t.Elem(), fieldName, flatten, prevSlice, prevTypes)
+	case reflect.Map:
+		if flatten {
+			return fmt.Errorf("datastore: map fields cannot be flattened: field %q", fieldName)
+		}
 	}
 	return nil
 }
@@ -231,7 +322,7 @@ func validateChildType(t reflect.Type, fieldName string, flatten, prevSlice bool
 // isLeafType determines whether or not a type is a 'leaf type'
 // and should not be recursed into, but considered one field.
 func isLeafType(t reflect.Type) bool {
-	return t == typeOfTime || t == typeOfGeoPoint
+	return t == typeOfTime || t == typeOfGeoPoint || isConverterType(t)
 }
 
 // structCache collects the structs whose fields have already been calculated.
@@ -240,7 +331,7 @@ var structCache = fields.NewCache(parseTag, validateType, isLeafType)
 // structPLS adapts a struct to be a PropertyLoadSaver.
 type structPLS struct {
 	v     reflect.Value
-	codec fields.List
+	codec *structCodec
 }
 
 // newStructPLS returns a structPLS, which implements the
@@ -251,11 +342,11 @@ func newStructPLS(p interface{}) (*structPLS, error) {
 		return nil, ErrInvalidEntityType
 	}
 	v = v.Elem()
-	f, err := structCache.Fields(v.Type())
+	c, err := codecFor(v.Type())
 	if err != nil {
 		return nil, err
 	}
-	return &structPLS{v, f}, nil
+	return &structPLS{v, c}, nil
 }
 
 // LoadStruct loads the properties from p to dst.
@@ -291,6 +382,9 @@ func SaveStruct(src interface{}) ([]Property, error) {
 //
 // v must be settable.
 func plsForLoad(v reflect.Value) (PropertyLoadSaver, error) {
+	if !typeInfoOf(v.Type()).pls {
+		return nil, nil
+	}
 	var nilPtr bool
 	if v.Kind() == reflect.Ptr && v.IsNil() {
 		nilPtr = true
@@ -314,6 +408,9 @@ func plsForLoad(v reflect.Value) (PropertyLoadSaver, error) {
 //
 // v must be settable.
 func plsForSave(v reflect.Value) (PropertyLoadSaver, error) {
+	if !typeInfoOf(v.Type()).pls {
+		return nil, nil
+	}
 	switch v.Kind() {
 	case reflect.Ptr, reflect.Slice, reflect.Map, reflect.Interface, reflect.Chan, reflect.Func:
 		// If v is nil, return early. v contains no data to save.
@@ -15,6 +15,9 @@
 package datastore
 
 import (
+	"errors"
+	"fmt"
+	"net"
 	"reflect"
 	"strings"
 	"testing"
@@ -649,3 +652,272 @@ func TestLoadSavePLS(t *testing.T) {
 		}
 	}
 }
+
+// money is stored as a string of cents, such as "1234 EUR".
+type money struct {
+	Cents    int64
+	Currency string
+}
+
+func (m *money) ToProperty() (interface{}, error) {
+	if m.Currency == "" {
+		return nil, errors.New("no currency")
+	}
+	return fmt.Sprintf("%d %s", m.Cents, m.Currency), nil
+}
+
+func (m *money) FromProperty(v interface{}) error {
+	s, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("money: want a string, got %T", v)
+	}
+	_, err := fmt.Sscanf(s, "%d %s", &m.Cents, &m.Currency)
+	return err
+}
+
+// color is stored as its name.
+type color int
+
+var colorNames = []string{"red", "green"}
+
+func (c *color) ToProperty() (interface{}, error) {
+	return colorNames[*c], nil
+}
+
+func (c *color) FromProperty(v interface{}) error {
+	for i, name := range colorNames {
+		if name == v {
+			*c = color(i)
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown color %v", v)
+}
+
+func TestPropertyConverter(t *testing.T) {
+	type withConverters struct {
+		Price    money
+		Discount *money
+		Refund   *money
+		Colors   []color
+		Tip      money `datastore:",noindex"`
+	}
+	src := &withConverters{
+		Price:    money{1234, "EUR"},
+		Discount: &money{100, "EUR"},
+		Colors:   []color{1, 0},
+		Tip:      money{5, "USD"},
+	}
+	props, err := SaveStruct(src)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantProps := []Property{
+		{Name: "Price", Value: "1234 EUR"},
+		{Name: "Discount", Value: "100 EUR"},
+		{Name: "Refund", Value: nil},
+		{Name: "Colors", Value: []interface{}{"green", "red"}},
+		{Name: "Tip", Value: "5 USD", NoIndex: true},
+	}
+	if diff := testutil.Diff(props, wantProps); diff != "" {
+		t.Errorf("SaveStruct: (-got +want)\n%s", diff)
+	}
+
+	got := &withConverters{Refund: &money{1, "EUR"}}
+	if err := LoadStruct(got, props); err != nil {
+		t.Fatal(err)
+	}
+	src.Refund = nil
+	if diff := testutil.Diff(got, src); diff != "" {
+		t.Errorf("LoadStruct: (-got +want)\n%s", diff)
+	}
+
+	if _, err := SaveStruct(&withConverters{}); err == nil || !strings.Contains(err.Error(), "no currency") {
+		t.Errorf("SaveStruct with invalid value: got %v, want error", err)
+	}
+	err = LoadStruct(&withConverters{}, []Property{{Name: "Price", Value: int64(3)}})
+	if e, ok := err.(*ErrFieldMismatch); !ok || e.FieldName != "Price" {
+		t.Errorf("LoadStruct with invalid value: got %v, want ErrFieldMismatch for Price", err)
+	}
+}
+
+// level implements encoding.TextMarshaler and encoding.TextUnmarshaler.
+type level int
+
+var levelNames = []string{"low", "high"}
+
+func (l level) MarshalText() ([]byte, error) {
+	if l < 0 || int(l) >= len(levelNames) {
+		return nil, fmt.Errorf("invalid level %d", int(l))
+	}
+	return []byte(levelNames[l]), nil
+}
+
+func (l *level) UnmarshalText(b []byte) error {
+	for i, name := range levelNames {
+		if name == string(b) {
+			*l = level(i)
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown level %q", b)
+}
+
+func TestTextOption(t *testing.T) {
+	type withText struct {
+		Level    level    `datastore:",text"`
+		Max      *level   `datastore:",text"`
+		Min      *level   `datastore:",text"`
+		Int      level    // Saved as an integer without the option.
+		IP       net.IP   `datastore:",text,noindex"`
+		Allowed  []net.IP `datastore:",text"`
+		Renamed  level    `datastore:"lvl,text"`
+		Filtered level    `datastore:",text,omitempty"`
+	}
+	high := level(1)
+	src := &withText{
+		Level:   1,
+		Max:     &high,
+		Int:     1,
+		IP:      net.IPv4(10, 0, 0, 1),
+		Allowed: []net.IP{net.ParseIP("::1"), net.IPv4(192, 168, 0, 1)},
+	}
+	props, err := SaveStruct(src)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantProps := []Property{
+		{Name: "Level", Value: "high"},
+		{Name: "Max", Value: "high"},
+		{Name: "Min", Value: nil},
+		{Name: "Int", Value: int64(1)},
+		{Name: "IP", Value: "10.0.0.1", NoIndex: true},
+		{Name: "Allowed", Value: []interface{}{"::1", "192.168.0.1"}},
+		{Name: "lvl", Value: "low"},
+	}
+	if diff := testutil.Diff(props, wantProps); diff != "" {
+		t.Errorf("SaveStruct: (-got +want)\n%s", diff)
+	}
+
+	low := level(0)
+	got := &withText{Min: &low}
+	if err := LoadStruct(got, props); err != nil {
+		t.Fatal(err)
+	}
+	if diff := testutil.Diff(got, src); diff != "" {
+		t.Errorf("LoadStruct: (-got +want)\n%s", diff)
+	}
+
+	if _, err := SaveStruct(&withText{Level: 5}); err == nil || !strings.Contains(err.Error(), "invalid level") {
+		t.Errorf("SaveStruct with invalid value: got %v, want error", err)
+	}
+	for _, p := range []Property{{Name: "Level", Value: "medium"}, {Name: "Level", Value: int64(1)}} {
+		err := LoadStruct(&withText{}, []Property{p})
+		if e, ok := err.(*ErrFieldMismatch); !ok || e.FieldName != "Level" {
+			t.Errorf("LoadStruct(%v): got %v, want ErrFieldMismatch for Level", p.Value, err)
+		}
+	}
+
+	type notText struct {
+		N int `datastore:",text"`
+	}
+	if _, err := SaveStruct(&notText{}); err == nil || !strings.Contains(err.Error(), "encoding.TextMarshaler") {
+		t.Errorf("SaveStruct without TextMarshaler: got %v, want error", err)
+	}
+	err = LoadStruct(&notText{}, []Property{{Name: "N", Value: "1"}})
+	if e, ok := err.(*ErrFieldMismatch); !ok || !strings.Contains(e.Reason, "encoding.TextUnmarshaler") {
+		t.Errorf("LoadStruct without TextUnmarshaler: got %v, want ErrFieldMismatch", err)
+	}
+}
+
+// testUUID has the same underlying type as the UUID type of
+// github.com/google/uuid.
+type testUUID [16]byte
+
+func TestUUIDOption(t *testing.T) {
+	type withUUIDs struct {
+		Blob   testUUID
+		Str    testUUID   `datastore:",uuid"`
+		Ptr    *testUUID  `datastore:",uuid"`
+		Nil    *testUUID  `datastore:",uuid"`
+		Strs   []testUUID `datastore:",uuid"`
+		Hash   [4]byte
+		PBlob  *testUUID
+		NoUUID testUUID `datastore:",noindex"`
+	}
+	id := testUUID{0xf4, 0x7a, 0xc1, 0x0b, 0x58, 0xcc, 0x03, 0x72, 0x85, 0x67, 0x0e, 0x02, 0xb2, 0xc3, 0xd4, 0x79}
+	const idStr = "f47ac10b-58cc-0372-8567-0e02b2c3d479"
+	src := &withUUIDs{
+		Blob:   id,
+		Str:    id,
+		Ptr:    &id,
+		Strs:   []testUUID{id, {}},
+		Hash:   [4]byte{1, 2, 3, 4},
+		PBlob:  &id,
+		NoUUID: id,
+	}
+	props, err := SaveStruct(src)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantProps := []Property{
+		{Name: "Blob", Value: id[:]},
+		{Name: "Str", Value: idStr},
+		{Name: "Ptr", Value: idStr},
+		{Name: "Nil", Value: nil},
+		{Name: "Strs", Value: []interface{}{idStr, "00000000-0000-0000-0000-000000000000"}},
+		{Name: "Hash", Value: []byte{1, 2, 3, 4}},
+		{Name: "PBlob", Value: id[:]},
+		{Name: "NoUUID", Value: id[:], NoIndex: true},
+	}
+	if diff := testutil.Diff(props, wantProps); diff != "" {
+		t.Errorf("SaveStruct: (-got +want)\n%s", diff)
+	}
+
+	e, err := saveEntity(testKey0, src)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := &withUUIDs{}
+	if err := loadEntityProto(got, e); err != nil {
+		t.Fatal(err)
+	}
+	if diff := testutil.Diff(got, src); diff != "" {
+		t.Errorf("round trip: (-got +want)\n%s", diff)
+	}
+
+	// Either representation can be loaded regardless of the tag.
+	var swapped withUUIDs
+	if err := LoadStruct(&swapped, []Property{{Name: "Blob", Value: strings.ToUpper(idStr)}, {Name: "Str", Value: id[:]}}); err != nil {
+		t.Fatal(err)
+	}
+	if swapped.Blob != id || swapped.Str != id {
+		t.Errorf("got %v and %v, want %v", swapped.Blob, swapped.Str, id)
+	}
+	for _, p := range []Property{
+		{Name: "Blob", Value: []byte{1}},
+		{Name: "Str", Value: "f47ac10b58cc037285670e02b2c3d479"},
+		{Name: "Hash", Value: "1234"},
+	} {
+		err := LoadStruct(&withUUIDs{}, []Property{p})
+		if e, ok := err.(*ErrFieldMismatch); !ok || e.FieldName != p.Name {
+			t.Errorf("LoadStruct(%v): got %v, want ErrFieldMismatch", p, err)
+		}
+	}
+
+	type notUUID struct {
+		S string `datastore:",uuid"`
+	}
+	if _, err := SaveStruct(&notUUID{}); err == nil {
+		t.Error("uuid option on a string: got nil, want error")
+	}
+
+	// UUIDs in filters are blobs.
+	v, err := interfaceToProto(id, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := v.GetBlobValue(); string(got) != string(id[:]) {
+		t.Errorf("interfaceToProto: got %v, want blob %v", v, id[:])
+	}
+}
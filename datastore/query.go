@@ -23,9 +23,11 @@ import (
 	"reflect"
 	"strconv"
 	"strings"
+	"time"
 
 	"cloud.google.com/go/internal/trace"
 	wrapperspb "github.com/golang/protobuf/ptypes/wrappers"
+	gax "github.com/googleapis/gax-go/v2"
 	"google.golang.org/api/iterator"
 	pb "google.golang.org/genproto/googleapis/datastore/v1"
 )
@@ -95,6 +97,7 @@ type EntityFilter interface {
 // The operator parameter takes the following strings: ">", "<", ">=", "<=",
 // "=", "!=", "in", and "not-in".
 // Fields are compared against the provided value using the operator.
+// For "in" and "not-in", Value must be a non-empty slice or array of values.
 // Field names which contain spaces, quote marks, or operator characters
 // should be passed as quoted Go string literals as returned by strconv.Quote
 // or the fmt package's %q verb.
@@ -109,12 +112,18 @@ func (pf PropertyFilter) toProto() (*pb.Filter, error) {
 	if pf.FieldName == "" {
 		return nil, errors.New("datastore: empty query filter field name")
 	}
+	op, isOp := stringToOperator[pf.Operator]
+	if isOp && (op == in || op == notIn) {
+		return pf.listFilterToProto(op)
+	}
 	v, err := interfaceToProto(reflect.ValueOf(pf.Value).Interface(), false)
 	if err != nil {
 		return nil, fmt.Errorf("datastore: bad query filter value type: %w", err)
 	}
+	if _, ok := v.ValueType.(*pb.Value_ArrayValue); ok {
+		return nil, fmt.Errorf("datastore: query filter value for operator %q cannot be a list; use \"in\" or \"not-in\"", pf.Operator)
+	}
 
-	op, isOp := stringToOperator[pf.Operator]
 	if !isOp {
 		return nil, fmt.Errorf("datastore: invalid operator %q in filter", pf.Operator)
 	}
@@ -133,6 +142,38 @@ func (pf PropertyFilter) toProto() (*pb.Filter, error) {
 	}, nil
 }
 
+// listFilterToProto converts a filter with the "in" or "not-in" operator,
+// whose value must be a non-empty slice or array of values that are not
+// themselves lists, into an array value.
+func (pf PropertyFilter) listFilterToProto(op operator) (*pb.Filter, error) {
+	rv := reflect.ValueOf(pf.Value)
+	if k := rv.Kind(); k != reflect.Slice && k != reflect.Array || rv.Type().Elem().Kind() == reflect.Uint8 {
+		return nil, fmt.Errorf("datastore: query filter value for operator %q must be a slice or array, not %T", op, pf.Value)
+	}
+	if rv.Len() == 0 {
+		return nil, fmt.Errorf("datastore: query filter value for operator %q must not be empty", op)
+	}
+	values := make([]*pb.Value, rv.Len())
+	for i := range values {
+		v, err := interfaceToProto(rv.Index(i).Interface(), false)
+		if err != nil {
+			return nil, fmt.Errorf("datastore: bad query filter value type: %v at index %d", err, i)
+		}
+		if _, ok := v.ValueType.(*pb.Value_ArrayValue); ok {
+			return nil, fmt.Errorf("datastore: query filter value for operator %q cannot contain a list, at index %d", op, i)
+		}
+		values[i] = v
+	}
+	xf := &pb.PropertyFilter{
+		Op:       operatorToProto[op],
+		Property: &pb.PropertyReference{Name: pf.FieldName},
+		Value:    &pb.Value{ValueType: &pb.Value_ArrayValue{ArrayValue: &pb.ArrayValue{Values: values}}},
+	}
+	return &pb.Filter{
+		FilterType: &pb.Filter_PropertyFilter{PropertyFilter: xf},
+	}, nil
+}
+
 func (pf PropertyFilter) toValidFilter() (EntityFilter, error) {
 	op := strings.TrimSpace(pf.Operator)
 	_, isOp := stringToOperator[op]
@@ -252,15 +293,26 @@ type Query struct {
 	distinctOn []string
 	keysOnly   bool
 	eventual   bool
+	readTime   time.Time
 	limit      int32
 	offset     int32
 	start      []byte
 	end        []byte
+	// startKey and endKey are the keys of the start and end cursors made by
+	// CursorAfterKey, which are applied as filters on the key.
+	startKey *Key
+	endKey   *Key
 
 	namespace string
 
 	trans *Transaction
 
+	// newRetryer returns the retryer of each batch of results, if the
+	// query has a retry policy.
+	newRetryer func() gax.Retryer
+
+	mode QueryMode
+
 	err error
 }
 
@@ -299,6 +351,24 @@ func (q *Query) EventualConsistency() *Query {
 	return q
 }
 
+// ReadTime returns a derivative query that reads a snapshot of the database
+// as of t, which overrides the read time of the Client, if any. It cannot be
+// combined with EventualConsistency or Transaction.
+func (q *Query) ReadTime(t time.Time) *Query {
+	q = q.clone()
+	q.readTime = t
+	return q
+}
+
+// Mode returns a derivative query that is run in mode m. Use ExplainMode or
+// ExplainAnalyzeMode to see how the query is planned and executed, with
+// Iterator.ExplainMetrics.
+func (q *Query) Mode(m QueryMode) *Query {
+	q = q.clone()
+	q.mode = m
+	return q
+}
+
 // Namespace returns a derivative query that is associated with the given
 // namespace.
 //
@@ -367,6 +437,9 @@ func (q *Query) Filter(filterStr string, value interface{}) *Query {
 // The operation parameter takes the following strings: ">", "<", ">=", "<=",
 // "=", "!=", "in", and "not-in".
 // Fields are compared against the provided value using the operator.
+// For "in" and "not-in", the value must be a non-empty slice or array, such
+// as a []string or a []interface{}, of the values to compare against; for
+// the other operators, it must not be a slice or array, except a []byte.
 // Multiple filters are AND'ed together.
 // Field names which contain spaces, quote marks, or operator characters
 // should be passed as quoted Go string literals as returned by strconv.Quote
@@ -446,6 +519,28 @@ func (q *Query) DistinctOn(fieldNames ...string) *Query {
 	return q
 }
 
+// RetryPolicy returns a derivative query whose iterators retry the calls
+// that fetch the batches of its results when they fail with an error for
+// which the gax.Retryer returned by newRetryer says to retry, after the pause
+// it returns. The calls are made again from the cursor after the last result
+// that was returned, so that a long scan of the results is not stopped by a
+// transient error. Each batch is retried with a new Retryer, such as
+//
+//	q = q.RetryPolicy(func() gax.Retryer {
+//		return gax.OnCodes([]codes.Code{codes.Unavailable, codes.DeadlineExceeded}, gax.Backoff{
+//			Initial: 100 * time.Millisecond,
+//			Max:     10 * time.Second,
+//		})
+//	})
+//
+// Even without a retry policy, the Client retries the calls which fail with
+// codes.Unavailable until the context of the query is done.
+func (q *Query) RetryPolicy(newRetryer func() gax.Retryer) *Query {
+	q = q.clone()
+	q.newRetryer = newRetryer
+	return q
+}
+
 // KeysOnly returns a derivative query that yields only keys, not keys and
 // entities. It cannot be used with projection queries.
 func (q *Query) KeysOnly() *Query {
@@ -486,6 +581,10 @@ func (q *Query) Offset(offset int) *Query {
 func (q *Query) Start(c Cursor) *Query {
 	q = q.clone()
 	q.start = c.cc
+	q.startKey = c.key
+	if c.key != nil && (!c.key.valid() || c.key.Incomplete()) {
+		q.err = errors.New("datastore: start cursor has an invalid or incomplete key")
+	}
 	return q
 }
 
@@ -493,25 +592,41 @@ func (q *Query) Start(c Cursor) *Query {
 func (q *Query) End(c Cursor) *Query {
 	q = q.clone()
 	q.end = c.cc
+	q.endKey = c.key
+	if c.key != nil && (!c.key.valid() || c.key.Incomplete()) {
+		q.err = errors.New("datastore: end cursor has an invalid or incomplete key")
+	}
 	return q
 }
 
 // toRunQueryRequest converts the query to a protocol buffer.
-func (q *Query) toRunQueryRequest(req *pb.RunQueryRequest) error {
+func (q *Query) toRunQueryRequest(req *pb.RunQueryRequest, rs *readSettings) error {
 	dst, err := q.toProto()
 	if err != nil {
 		return err
 	}
 
-	req.ReadOptions, err = parseReadOptions(q)
+	req.ReadOptions, err = parseReadOptions(q, rs)
 	if err != nil {
 		return err
 	}
 
 	req.QueryType = &pb.RunQueryRequest_Query{Query: dst}
+	req.ExplainOptions = q.mode.explainOptions()
 	return nil
 }
 
+// Proto returns the query as the protocol buffer sent to run it, in which an
+// ancestor and cursors made by CursorAfterKey are filters on the key. It is
+// for tools which analyze queries, such as the index package; the namespace,
+// transaction and read options of the query are not part of it.
+func (q *Query) Proto() (*pb.Query, error) {
+	if q.err != nil {
+		return nil, q.err
+	}
+	return q.toProto()
+}
+
 func (q *Query) toProto() (*pb.Query, error) {
 	if len(q.projection) != 0 && q.keysOnly {
 		return nil, errors.New("datastore: query cannot both project and be keys-only")
@@ -558,6 +673,11 @@ func (q *Query) toProto() (*pb.Query, error) {
 				Value:    &pb.Value{ValueType: &pb.Value_KeyValue{KeyValue: keyToProto(q.ancestor)}},
 			}}})
 	}
+	keyFilters, err := q.keyCursorFilters()
+	if err != nil {
+		return nil, err
+	}
+	filters = append(filters, keyFilters...)
 
 	if len(filters) == 1 {
 		dst.Filter = filters[0]
@@ -630,6 +750,8 @@ func (c *Client) Count(ctx context.Context, q *Query) (n int, err error) {
 //
 // dst must have type *[]S or *[]*S or *[]P, for some struct type S or some non-
 // interface, non-pointer type P such that P or *P implements PropertyLoadSaver.
+// It may also have type *[]map[string]interface{}, to load the properties of
+// the entities by name, as for the results of a projection query.
 //
 // As a special case, *PropertyList is an invalid type for dst, even though a
 // PropertyList is a slice of structs. It is treated as invalid to avoid being
@@ -693,7 +815,7 @@ func (c *Client) GetAll(ctx context.Context, q *Query, dst interface{}) (keys []
 				x := reflect.MakeMap(elemType)
 				ev.Elem().Set(x)
 			}
-			if err = loadEntityProto(ev.Interface(), e); err != nil {
+			if err = c.readSettings.loadError(loadEntityProto(ev.Interface(), e)); err != nil {
 				if _, ok := err.(*ErrFieldMismatch); ok {
 					// We continue loading entities even in the face of field mismatch errors.
 					// If we encounter any other error, that other error is returned. Otherwise,
@@ -726,6 +848,8 @@ func (c *Client) Run(ctx context.Context, q *Query) *Iterator {
 		keysOnly:     q.keysOnly,
 		pageCursor:   q.start,
 		entityCursor: q.start,
+		startKey:     q.startKey,
+		newRetryer:   q.newRetryer,
 		req: &pb.RunQueryRequest{
 			ProjectId:  c.dataset,
 			DatabaseId: c.databaseID,
@@ -738,7 +862,7 @@ func (c *Client) Run(ctx context.Context, q *Query) *Iterator {
 		}
 	}
 
-	if err := q.toRunQueryRequest(t.req); err != nil {
+	if err := q.toRunQueryRequest(t.req, c.readSettings); err != nil {
 		t.err = err
 	}
 	return t
@@ -760,6 +884,12 @@ func (c *Client) RunAggregationQuery(ctx context.Context, aq *AggregationQuery)
 	if len(aq.aggregationQueries) == 0 {
 		return nil, errors.New("datastore: aggregation query must contain one or more operators (e.g. count)")
 	}
+	for _, a := range aq.aggregationQueries {
+		if a.GetSum() != nil && a.GetSum().GetProperty().GetName() == "" ||
+			a.GetAvg() != nil && a.GetAvg().GetProperty().GetName() == "" {
+			return nil, errors.New("datastore: sum and average aggregations must name a property")
+		}
+	}
 
 	q, err := aq.query.toProto()
 	if err != nil {
@@ -786,7 +916,7 @@ func (c *Client) RunAggregationQuery(ctx context.Context, aq *AggregationQuery)
 	}
 
 	// Parse the read options.
-	req.ReadOptions, err = parseReadOptions(aq.query)
+	req.ReadOptions, err = parseReadOptions(aq.query, c.readSettings)
 	if err != nil {
 		return nil, err
 	}
@@ -798,7 +928,6 @@ func (c *Client) RunAggregationQuery(ctx context.Context, aq *AggregationQuery)
 
 	ar = make(AggregationResult)
 
-	// TODO(developer): change batch parsing logic if other aggregations are supported.
 	for _, a := range res.Batch.AggregationResults {
 		for k, v := range a.AggregateProperties {
 			ar[k] = v
@@ -808,8 +937,9 @@ func (c *Client) RunAggregationQuery(ctx context.Context, aq *AggregationQuery)
 	return ar, nil
 }
 
-// parseReadOptions translates Query read options into protobuf format.
-func parseReadOptions(q *Query) (*pb.ReadOptions, error) {
+// parseReadOptions translates Query read options into protobuf format. The
+// read settings of the client, rs, apply to queries that have none.
+func parseReadOptions(q *Query, rs *readSettings) (*pb.ReadOptions, error) {
 	if t := q.trans; t != nil {
 		if t.id == nil {
 			return nil, errExpiredTransaction
@@ -817,16 +947,25 @@ func parseReadOptions(q *Query) (*pb.ReadOptions, error) {
 		if q.eventual {
 			return nil, errors.New("datastore: cannot use EventualConsistency query in a transaction")
 		}
+		if !q.readTime.IsZero() {
+			return nil, errors.New("datastore: cannot use ReadTime query in a transaction")
+		}
 		return &pb.ReadOptions{
 			ConsistencyType: &pb.ReadOptions_Transaction{Transaction: t.id},
 		}, nil
 	}
 
 	if q.eventual {
+		if !q.readTime.IsZero() {
+			return nil, errors.New("datastore: cannot use both EventualConsistency and ReadTime in a query")
+		}
 		return &pb.ReadOptions{ConsistencyType: &pb.ReadOptions_ReadConsistency_{ReadConsistency: pb.ReadOptions_EVENTUAL}}, nil
 	}
 
-	return nil, nil
+	if !q.readTime.IsZero() {
+		return readTimeOptions(q.readTime), nil
+	}
+	return rs.readOptions(), nil
 }
 
 // Iterator is the result of running a query.
@@ -858,6 +997,15 @@ type Iterator struct {
 	pageCursor []byte
 	// entityCursor is the compiled cursor of the next result.
 	entityCursor []byte
+	// startKey is the key of the start cursor of the query, if it was made
+	// by CursorAfterKey. It is the iterator's position until a result is
+	// read.
+	startKey *Key
+	// newRetryer is the retry policy of the query, or nil.
+	newRetryer func() gax.Retryer
+	// explainMetrics are the explain metrics of the batches so far, if the
+	// query is not run in NormalMode.
+	explainMetrics *ExplainMetrics
 }
 
 // Next returns the key of the next result. When there are no more results,
@@ -865,14 +1013,16 @@ type Iterator struct {
 //
 // If the query is not keys only and dst is non-nil, it also loads the entity
 // stored for that key into the struct pointer or PropertyLoadSaver dst, with
-// the same semantics and possible errors as for the Get function.
+// the same semantics and possible errors as for the Get function. dst may
+// also be a *map[string]interface{}, into which the properties of the entity
+// are loaded by name.
 func (t *Iterator) Next(dst interface{}) (k *Key, err error) {
 	k, e, err := t.next()
 	if err != nil {
 		return nil, err
 	}
 	if dst != nil && !t.keysOnly {
-		err = loadEntityProto(dst, e)
+		err = t.client.readSettings.loadError(loadEntityProto(dst, e))
 	}
 	return k, err
 }
@@ -925,10 +1075,16 @@ func (t *Iterator) nextBatch() error {
 	}
 
 	// Run the query.
-	resp, err := t.client.client.RunQuery(t.ctx, t.req)
+	resp, err := t.runQuery()
 	if err != nil {
 		return err
 	}
+	t.explainMetrics = addExplainMetrics(t.explainMetrics, resp.ExplainMetrics)
+	if eo := t.req.ExplainOptions; eo != nil && !eo.Analyze {
+		// The query was planned but not run, so there are no results.
+		t.limit = 0
+		return nil
+	}
 
 	// Adjust any offset from skipped results.
 	skip := resp.Batch.SkippedResults
@@ -972,6 +1128,39 @@ func (t *Iterator) nextBatch() error {
 	return nil
 }
 
+// runQuery runs the request of the next batch of results, retrying it as
+// the retry policy of the query says. The request starts at the cursor after
+// the last batch, so retrying it does not repeat or skip results.
+func (t *Iterator) runQuery() (*pb.RunQueryResponse, error) {
+	var retryer gax.Retryer
+	for {
+		resp, err := t.client.client.RunQuery(t.ctx, t.req)
+		if err == nil || t.newRetryer == nil {
+			return resp, err
+		}
+		if retryer == nil {
+			retryer = t.newRetryer()
+		}
+		pause, ok := retryer.Retry(err)
+		if !ok {
+			return nil, err
+		}
+		if err := gax.Sleep(t.ctx, pause); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// ExplainMetrics returns the plan of a query run in ExplainMode or
+// ExplainAnalyzeMode, and the statistics of its execution. It returns nil
+// until Next has returned iterator.Done, and for a query run in NormalMode.
+func (t *Iterator) ExplainMetrics() *ExplainMetrics {
+	if t.err != iterator.Done {
+		return nil
+	}
+	return t.explainMetrics
+}
+
 // Cursor returns a cursor for the iterator's current location.
 func (t *Iterator) Cursor() (c Cursor, err error) {
 	t.ctx = trace.StartSpan(t.ctx, "cloud.google.com/go/datastore.Query.Cursor")
@@ -986,7 +1175,10 @@ func (t *Iterator) Cursor() (c Cursor, err error) {
 		return Cursor{}, t.err
 	}
 
-	return Cursor{t.entityCursor}, nil
+	if t.entityCursor == nil && t.startKey != nil {
+		return Cursor{key: t.startKey}, nil
+	}
+	return Cursor{cc: t.entityCursor}, nil
 }
 
 // Cursor is an iterator's position. It can be converted to and from an opaque
@@ -997,10 +1189,25 @@ func (t *Iterator) Cursor() (c Cursor, err error) {
 // constraint for a query.
 type Cursor struct {
 	cc []byte
+	// key is the key of a cursor made by CursorAfterKey.
+	key *Key
 }
 
-// String returns a base-64 string representation of a cursor.
+// String returns a string representation of a cursor, which DecodeCursor
+// decodes. The representation is stable, so that it can be stored:
+//
+//   - the zero Cursor is the empty string;
+//   - a cursor of an Iterator is the unpadded URL-safe base-64 encoding of
+//     the cursor of Datastore;
+//   - a cursor made by CursorAfterKey is "k." followed by the encoding of
+//     the key by Key.Encode.
+//
+// Query.EncodeCursor returns a representation which also records the query of
+// the cursor.
 func (c Cursor) String() string {
+	if c.key != nil {
+		return keyCursorPrefix + c.key.Encode()
+	}
 	if c.cc == nil {
 		return ""
 	}
@@ -1008,11 +1215,33 @@ func (c Cursor) String() string {
 	return strings.TrimRight(base64.URLEncoding.EncodeToString(c.cc), "=")
 }
 
-// DecodeCursor decodes a cursor from its base-64 string representation.
+// DecodeCursor decodes a cursor from its string representation, as returned
+// by Cursor.String or Query.EncodeCursor. It does not check the query that
+// Query.EncodeCursor recorded; Query.DecodeCursor does.
 func DecodeCursor(s string) (Cursor, error) {
 	if s == "" {
 		return Cursor{}, nil
 	}
+	if strings.HasPrefix(s, queryCursorPrefix) {
+		_, c, err := splitQueryCursor(s)
+		if err != nil {
+			return Cursor{}, err
+		}
+		if c == "" {
+			return Cursor{}, nil
+		}
+		s = c
+	}
+	if strings.HasPrefix(s, keyCursorPrefix) {
+		k, err := DecodeKey(strings.TrimPrefix(s, keyCursorPrefix))
+		if err != nil {
+			return Cursor{}, err
+		}
+		if k.Incomplete() {
+			return Cursor{}, errors.New("datastore: cursor has an incomplete key")
+		}
+		return Cursor{key: k}, nil
+	}
 	if n := len(s) % 4; n != 0 {
 		s += strings.Repeat("=", 4-n)
 	}
@@ -1020,7 +1249,7 @@ func DecodeCursor(s string) (Cursor, error) {
 	if err != nil {
 		return Cursor{}, err
 	}
-	return Cursor{b}, nil
+	return Cursor{cc: b}, nil
 }
 
 // NewAggregationQuery returns an AggregationQuery with this query as its
@@ -1081,7 +1310,7 @@ func (aq *AggregationQuery) WithSum(fieldName string, alias string) *Aggregation
 // WithAvg specifies that the aggregation query should provide an average of the values
 // of the provided field in the results returned by the underlying Query.
 // The alias argument can be empty or a valid Datastore entity property name. It can be used
-// as key in the AggregationResult to get the sum value. If alias is empty, Datastore
+// as key in the AggregationResult to get the average value. If alias is empty, Datastore
 // will autogenerate a key.
 func (aq *AggregationQuery) WithAvg(fieldName string, alias string) *AggregationQuery {
 	aqpb := &pb.AggregationQuery_Aggregation{
@@ -1101,4 +1330,65 @@ func (aq *AggregationQuery) WithAvg(fieldName string, alias string) *Aggregation
 }
 
 // AggregationResult contains the results of an aggregation query.
+// Its values are *pb.Value; use Int64 and Float64 to decode them.
 type AggregationResult map[string]interface{}
+
+// Int64 returns the integer result of the aggregation with the given alias,
+// such as that of WithCount, or that of WithSum when all the summed values
+// are integers.
+func (ar AggregationResult) Int64(alias string) (int64, error) {
+	v, err := ar.value(alias)
+	if err != nil {
+		return 0, err
+	}
+	i, ok := v.ValueType.(*pb.Value_IntegerValue)
+	if !ok {
+		return 0, fmt.Errorf("datastore: aggregation result %q is %s, not an integer", alias, aggregationValueType(v))
+	}
+	return i.IntegerValue, nil
+}
+
+// Float64 returns the numeric result of the aggregation with the given alias,
+// such as that of WithAvg or WithSum. Integer results are converted to
+// float64.
+//
+// The average of no values is null, and Float64 returns an error for it.
+func (ar AggregationResult) Float64(alias string) (float64, error) {
+	v, err := ar.value(alias)
+	if err != nil {
+		return 0, err
+	}
+	switch x := v.ValueType.(type) {
+	case *pb.Value_DoubleValue:
+		return x.DoubleValue, nil
+	case *pb.Value_IntegerValue:
+		return float64(x.IntegerValue), nil
+	}
+	return 0, fmt.Errorf("datastore: aggregation result %q is %s, not a number", alias, aggregationValueType(v))
+}
+
+func (ar AggregationResult) value(alias string) (*pb.Value, error) {
+	r, ok := ar[alias]
+	if !ok {
+		return nil, fmt.Errorf("datastore: no aggregation result with alias %q", alias)
+	}
+	v, ok := r.(*pb.Value)
+	if !ok {
+		return nil, fmt.Errorf("datastore: aggregation result %q has type %T", alias, r)
+	}
+	return v, nil
+}
+
+// aggregationValueType describes the type of an aggregation result, for
+// error messages.
+func aggregationValueType(v *pb.Value) string {
+	switch v.ValueType.(type) {
+	case *pb.Value_NullValue:
+		return "null"
+	case *pb.Value_IntegerValue:
+		return "an integer"
+	case *pb.Value_DoubleValue:
+		return "a double"
+	}
+	return fmt.Sprintf("of type %T", v.ValueType)
+}
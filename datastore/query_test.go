@@ -21,12 +21,14 @@ import (
 	"reflect"
 	"sort"
 	"testing"
+	"time"
 
 	"cloud.google.com/go/internal/testutil"
 	"github.com/golang/protobuf/proto"
 	"github.com/google/go-cmp/cmp"
 	pb "google.golang.org/genproto/googleapis/datastore/v1"
 	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 var (
@@ -290,12 +292,17 @@ func TestSimpleQuery(t *testing.T) {
 		dst  interface{}
 		want interface{}
 	}{
-		// The destination must have type *[]P, *[]S or *[]*S, for some non-interface
-		// type P such that *P implements PropertyLoadSaver, or for some struct type S.
+		// The destination must have type *[]P, *[]S, *[]*S or
+		// *[]map[string]interface{}, for some non-interface type P such that
+		// *P implements PropertyLoadSaver, or for some struct type S.
 		{new([]Gopher), &[]Gopher{struct1, struct2}},
 		{new([]*Gopher), &[]*Gopher{&struct1, &struct2}},
 		{new([]PropertyList), &[]PropertyList{pList1, pList2}},
 		{new([]PropertyMap), &[]PropertyMap{pMap1, pMap2}},
+		{new([]map[string]interface{}), &[]map[string]interface{}{
+			{"Name": "George", "Height": int64(32)},
+			{"Name": "Rufus"},
+		}},
 
 		// Any other destination type is invalid.
 		{0, nil},
@@ -312,7 +319,6 @@ func TestSimpleQuery(t *testing.T) {
 		{new([]int), nil},
 		{new([]map[int]int), nil},
 		{new([]map[string]Property), nil},
-		{new([]map[string]interface{}), nil},
 		{new([]*int), nil},
 		{new([]*map[int]int), nil},
 		{new([]*map[string]Property), nil},
@@ -508,12 +514,27 @@ func TestPropertyFilterToProto(t *testing.T) {
 		{PropertyFilter{FieldName: "", Operator: "=", Value: 4}, "datastore: empty query filter field name"},
 		{PropertyFilter{FieldName: "x", Operator: "==", Value: 4}, "datastore: invalid operator \"==\" in filter"},
 		{PropertyFilter{FieldName: "x", Operator: "==", Value: struct{ x string }{x: "sample"}}, "datastore: bad query filter value type: invalid Value type struct { x string }"},
+		{PropertyFilter{FieldName: "x", Operator: "in", Value: []string{"a", "b"}}, ""},
+		{PropertyFilter{FieldName: "x", Operator: "not-in", Value: [2]int{1, 2}}, ""},
+		{PropertyFilter{FieldName: "x", Operator: "in", Value: []interface{}{1, "a"}}, ""},
+		{PropertyFilter{FieldName: "x", Operator: "in", Value: []int{}}, "datastore: query filter value for operator \"in\" must not be empty"},
+		{PropertyFilter{FieldName: "x", Operator: "in", Value: 4}, "datastore: query filter value for operator \"in\" must be a slice or array, not int"},
+		{PropertyFilter{FieldName: "x", Operator: "not-in", Value: []byte("ab")}, "datastore: query filter value for operator \"not-in\" must be a slice or array, not []uint8"},
+		{PropertyFilter{FieldName: "x", Operator: "in", Value: []interface{}{[]interface{}{1}}}, "datastore: query filter value for operator \"in\" cannot contain a list, at index 0"},
+		{PropertyFilter{FieldName: "x", Operator: "in", Value: []interface{}{1, struct{}{}}}, "datastore: bad query filter value type: invalid Value type struct {} at index 1"},
+		{PropertyFilter{FieldName: "x", Operator: "=", Value: []interface{}{1}}, "datastore: query filter value for operator \"=\" cannot be a list; use \"in\" or \"not-in\""},
+		{PropertyFilter{FieldName: "x", Operator: "!=", Value: []interface{}{1}}, "datastore: query filter value for operator \"!=\" cannot be a list; use \"in\" or \"not-in\""},
+		{PropertyFilter{FieldName: "x", Operator: "!=", Value: []byte("ab")}, ""},
 	}
 
 	successFilterFieldTestCases := append(filterTestCases, filterFieldTestCases...)
 	for _, sfftc := range successFilterFieldTestCases {
+		var value interface{} = 4
+		if sfftc.wantOp == in || sfftc.wantOp == notIn {
+			value = []int{4}
+		}
 		testCases = append(testCases, pfToProtoTestCase{
-			PropertyFilter{FieldName: sfftc.fieldName, Operator: sfftc.operator, Value: 4}, "",
+			PropertyFilter{FieldName: sfftc.fieldName, Operator: sfftc.operator, Value: value}, "",
 		})
 	}
 
@@ -724,14 +745,47 @@ func TestNamespaceQuery(t *testing.T) {
 
 func TestReadOptions(t *testing.T) {
 	tid := []byte{1}
+	qt := time.Unix(1000, 0)
+	ct := time.Unix(2000, 0)
+	clientReadTime := &readSettings{readTime: ct}
 	for _, test := range []struct {
 		q    *Query
+		rs   *readSettings
 		want *pb.ReadOptions
 	}{
 		{
 			q:    NewQuery(""),
 			want: nil,
 		},
+		{
+			q:    NewQuery(""),
+			rs:   &readSettings{},
+			want: nil,
+		},
+		{
+			q:    NewQuery("").ReadTime(qt),
+			want: &pb.ReadOptions{ConsistencyType: &pb.ReadOptions_ReadTime{ReadTime: timestamppb.New(qt)}},
+		},
+		{
+			q:    NewQuery(""),
+			rs:   clientReadTime,
+			want: &pb.ReadOptions{ConsistencyType: &pb.ReadOptions_ReadTime{ReadTime: timestamppb.New(ct)}},
+		},
+		{
+			q:    NewQuery("").ReadTime(qt),
+			rs:   clientReadTime,
+			want: &pb.ReadOptions{ConsistencyType: &pb.ReadOptions_ReadTime{ReadTime: timestamppb.New(qt)}},
+		},
+		{
+			q:    NewQuery("").EventualConsistency(),
+			rs:   clientReadTime,
+			want: &pb.ReadOptions{ConsistencyType: &pb.ReadOptions_ReadConsistency_{ReadConsistency: pb.ReadOptions_EVENTUAL}},
+		},
+		{
+			q:    NewQuery("").Transaction(&Transaction{id: tid}),
+			rs:   clientReadTime,
+			want: &pb.ReadOptions{ConsistencyType: &pb.ReadOptions_Transaction{Transaction: tid}},
+		},
 		{
 			q:    NewQuery("").Transaction(nil),
 			want: nil,
@@ -754,7 +808,7 @@ func TestReadOptions(t *testing.T) {
 		},
 	} {
 		req := &pb.RunQueryRequest{}
-		if err := test.q.toRunQueryRequest(req); err != nil {
+		if err := test.q.toRunQueryRequest(req, test.rs); err != nil {
 			t.Fatalf("%+v: got %v, want no error", test.q, err)
 		}
 		if got := req.ReadOptions; !proto.Equal(got, test.want) {
@@ -765,9 +819,11 @@ func TestReadOptions(t *testing.T) {
 	for _, q := range []*Query{
 		NewQuery("").Transaction(&Transaction{id: nil}),
 		NewQuery("").Transaction(&Transaction{id: tid}).EventualConsistency(),
+		NewQuery("").Transaction(&Transaction{id: tid}).ReadTime(qt),
+		NewQuery("").EventualConsistency().ReadTime(qt),
 	} {
 		req := &pb.RunQueryRequest{}
-		if err := q.toRunQueryRequest(req); err == nil {
+		if err := q.toRunQueryRequest(req, nil); err == nil {
 			t.Errorf("%+v: got nil, wanted error", q)
 		}
 	}
@@ -858,3 +914,84 @@ func TestAggregationQueryIsNil(t *testing.T) {
 		t.Fatal(err)
 	}
 }
+
+func TestAggregationQuerySumAvg(t *testing.T) {
+	var gotReq *pb.RunAggregationQueryRequest
+	client := &Client{
+		client: &fakeClient{
+			aggQueryFn: func(req *pb.RunAggregationQueryRequest) (*pb.RunAggregationQueryResponse, error) {
+				gotReq = req
+				return &pb.RunAggregationQueryResponse{
+					Batch: &pb.AggregationResultBatch{
+						AggregationResults: []*pb.AggregationResult{{
+							AggregateProperties: map[string]*pb.Value{
+								"total":   {ValueType: &pb.Value_IntegerValue{IntegerValue: 42}},
+								"average": {ValueType: &pb.Value_DoubleValue{DoubleValue: 10.5}},
+								"none":    {ValueType: &pb.Value_NullValue{}},
+							},
+						}},
+					},
+				}, nil
+			},
+		},
+	}
+
+	tx := &Transaction{id: []byte("tid"), client: client}
+	aq := NewQuery("Gopher").Transaction(tx).NewAggregationQuery().
+		WithSum("Height", "total").
+		WithAvg("Height", "average").
+		WithAvg("Weight", "none")
+	res, err := client.RunAggregationQuery(context.Background(), aq)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	wantAggs := []*pb.AggregationQuery_Aggregation{
+		{Alias: "total", Operator: &pb.AggregationQuery_Aggregation_Sum_{Sum: &pb.AggregationQuery_Aggregation_Sum{Property: &pb.PropertyReference{Name: "Height"}}}},
+		{Alias: "average", Operator: &pb.AggregationQuery_Aggregation_Avg_{Avg: &pb.AggregationQuery_Aggregation_Avg{Property: &pb.PropertyReference{Name: "Height"}}}},
+		{Alias: "none", Operator: &pb.AggregationQuery_Aggregation_Avg_{Avg: &pb.AggregationQuery_Aggregation_Avg{Property: &pb.PropertyReference{Name: "Weight"}}}},
+	}
+	gotAggs := gotReq.GetAggregationQuery().GetAggregations()
+	if len(gotAggs) != len(wantAggs) {
+		t.Fatalf("got %d aggregations, want %d", len(gotAggs), len(wantAggs))
+	}
+	for i, want := range wantAggs {
+		if !proto.Equal(gotAggs[i], want) {
+			t.Errorf("aggregation %d: got %v, want %v", i, gotAggs[i], want)
+		}
+	}
+	if got := gotReq.GetReadOptions().GetTransaction(); string(got) != "tid" {
+		t.Errorf("transaction: got %q, want %q", got, "tid")
+	}
+
+	if got, err := res.Int64("total"); err != nil || got != 42 {
+		t.Errorf("Int64(total): got %d, %v, want 42, nil", got, err)
+	}
+	if got, err := res.Float64("total"); err != nil || got != 42 {
+		t.Errorf("Float64(total): got %v, %v, want 42, nil", got, err)
+	}
+	if got, err := res.Float64("average"); err != nil || got != 10.5 {
+		t.Errorf("Float64(average): got %v, %v, want 10.5, nil", got, err)
+	}
+	if _, err := res.Int64("average"); err == nil {
+		t.Error("Int64(average): got nil, want error")
+	}
+	if _, err := res.Float64("none"); err == nil {
+		t.Error("Float64(none): got nil, want error")
+	}
+	if _, err := res.Int64("missing"); err == nil {
+		t.Error("Int64(missing): got nil, want error")
+	}
+}
+
+func TestAggregationQuerySumWithoutProperty(t *testing.T) {
+	client := &Client{client: &fakeClient{}}
+	for _, aq := range []*AggregationQuery{
+		NewQuery("Gopher").NewAggregationQuery().WithSum("", "total"),
+		NewQuery("Gopher").NewAggregationQuery().WithAvg("", "average"),
+	} {
+		if _, err := client.RunAggregationQuery(context.Background(), aq); err == nil {
+			t.Error("got nil, want error")
+		}
+	}
+}
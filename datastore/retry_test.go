@@ -0,0 +1,115 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datastore
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/internal/testutil"
+	gax "github.com/googleapis/gax-go/v2"
+	pb "google.golang.org/genproto/googleapis/datastore/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// newFlakyClient returns a client whose queries return the keys of 6
+// gophers, in batches of 2, and fail with the code of each error in errs,
+// in turn, before returning the second batch.
+func newFlakyClient(errs []codes.Code) (*Client, *[][]byte) {
+	var starts [][]byte
+	return &Client{
+		client: &fakeClient{
+			queryFn: func(req *pb.RunQueryRequest) (*pb.RunQueryResponse, error) {
+				start := req.GetQuery().StartCursor
+				starts = append(starts, start)
+				if bytes.Equal(start, []byte{2}) && len(errs) > 0 {
+					code := errs[0]
+					errs = errs[1:]
+					return nil, status.Error(code, "flaky")
+				}
+				var n byte
+				if len(start) > 0 {
+					n = start[0]
+				}
+				batch := &pb.QueryResultBatch{
+					MoreResults: pb.QueryResultBatch_NOT_FINISHED,
+					EndCursor:   []byte{n + 2},
+				}
+				if n+2 == 6 {
+					batch.MoreResults = pb.QueryResultBatch_NO_MORE_RESULTS
+				}
+				for i := n + 1; i <= n+2; i++ {
+					batch.EntityResults = append(batch.EntityResults, &pb.EntityResult{
+						Entity: &pb.Entity{Key: keyToProto(IDKey("Gopher", int64(i), nil))},
+						Cursor: []byte{i},
+					})
+				}
+				return &pb.RunQueryResponse{Batch: batch}, nil
+			},
+		},
+	}, &starts
+}
+
+func TestQueryRetryPolicy(t *testing.T) {
+	ctx := context.Background()
+	policy := func() gax.Retryer {
+		return gax.OnCodes([]codes.Code{codes.Unavailable, codes.DeadlineExceeded}, gax.Backoff{Initial: time.Microsecond})
+	}
+	var want []*Key
+	for i := 1; i <= 6; i++ {
+		want = append(want, IDKey("Gopher", int64(i), nil))
+	}
+
+	client, starts := newFlakyClient([]codes.Code{codes.DeadlineExceeded, codes.Unavailable})
+	got, err := client.GetAll(ctx, NewQuery("Gopher").KeysOnly().RetryPolicy(policy), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := testutil.Diff(got, want); diff != "" {
+		t.Errorf("got=-, want=+\n%s", diff)
+	}
+	// The failed batch is fetched again from the same cursor.
+	wantStarts := [][]byte{nil, {2}, {2}, {2}, {4}}
+	if diff := testutil.Diff(*starts, wantStarts); diff != "" {
+		t.Errorf("start cursors: got=-, want=+\n%s", diff)
+	}
+
+	// The errors that the policy does not retry stop the query.
+	client, _ = newFlakyClient([]codes.Code{codes.DeadlineExceeded, codes.InvalidArgument})
+	if _, err := client.GetAll(ctx, NewQuery("Gopher").KeysOnly().RetryPolicy(policy), nil); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("got %v, want code InvalidArgument", err)
+	}
+	client, _ = newFlakyClient([]codes.Code{codes.DeadlineExceeded})
+	if _, err := client.GetAll(ctx, NewQuery("Gopher").KeysOnly(), nil); status.Code(err) != codes.DeadlineExceeded {
+		t.Errorf("without a retry policy: got %v, want code DeadlineExceeded", err)
+	}
+
+	// The retries stop when the context is done.
+	cctx, cancel := context.WithCancel(ctx)
+	client, _ = newFlakyClient([]codes.Code{codes.Unavailable, codes.Unavailable, codes.Unavailable})
+	it := client.Run(cctx, NewQuery("Gopher").KeysOnly().RetryPolicy(func() gax.Retryer {
+		cancel()
+		return policy()
+	}))
+	for err == nil {
+		_, err = it.Next(nil)
+	}
+	if err != context.Canceled {
+		t.Errorf("got %v, want %v", err, context.Canceled)
+	}
+}
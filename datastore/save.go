@@ -15,9 +15,11 @@
 package datastore
 
 import (
+	"encoding"
 	"errors"
 	"fmt"
 	"reflect"
+	"sort"
 	"time"
 	"unicode/utf8"
 
@@ -29,8 +31,12 @@ import (
 
 type saveOpts struct {
 	noIndex   bool
+	noCascade bool
 	flatten   bool
 	omitEmpty bool
+	omitZero  bool
+	text      bool
+	uuid      bool
 }
 
 // saveEntity saves an EntityProto into a PropertyLoadSaver or struct pointer.
@@ -51,9 +57,28 @@ func saveEntity(key *Key, src interface{}) (*pb.Entity, error) {
 // reflectFieldSave extracts the underlying value of v by reflection,
 // and tries to extract a Property that'll be appended to props.
 func reflectFieldSave(props *[]Property, p Property, name string, opts saveOpts, v reflect.Value) error {
-	switch x := v.Interface().(type) {
+	// Only structs, pointers and interfaces can hold the types of the first
+	// cases, so other values are saved without the cost of Interface.
+	var vi interface{}
+	switch v.Kind() {
+	case reflect.Struct, reflect.Ptr, reflect.Interface:
+		vi = v.Interface()
+	}
+	switch x := vi.(type) {
 	case *Key, time.Time, GeoPoint:
 		p.Value = x
+	case NoIndexElement:
+		if x.Value == nil {
+			p.NoIndex = true
+			*props = append(*props, p)
+			return nil
+		}
+		// Save a copy of the value, which may be a struct, which must be
+		// addressable.
+		elem := reflect.New(reflect.TypeOf(x.Value)).Elem()
+		elem.Set(reflect.ValueOf(x.Value))
+		opts.noIndex = true
+		return saveStructProperty(props, name, opts, elem)
 	case civil.Date:
 		p.Value = x.In(time.UTC)
 		*props = append(*props, p)
@@ -99,12 +124,34 @@ func reflectFieldSave(props *[]Property, p Property, name string, opts saveOpts,
 			}
 			return reflectFieldSave(props, p, name, opts, v.Elem())
 
+		case reflect.Array:
+			if v.Type().Elem().Kind() == reflect.Uint8 {
+				p.Value = byteArray(v)
+			}
 		case reflect.Slice:
 			if v.Type().Elem().Kind() == reflect.Uint8 {
 				p.Value = v.Bytes()
 			} else {
 				return saveSliceProperty(props, name, opts, v)
 			}
+		case reflect.Map:
+			// Other maps are unsupported, unless they implement PLS, which is
+			// handled below.
+			if v.Type().Key().Kind() != reflect.String || reflect.PtrTo(v.Type()).Implements(typeOfPropertyLoadSaver) {
+				break
+			}
+			if v.IsNil() {
+				// Nil map becomes a nil property value (unless
+				// omitEmpty is true, which is handled by the caller).
+				p.Value = nil
+				*props = append(*props, p)
+				return nil
+			}
+			ent, err := saveMap(opts, v)
+			if err != nil {
+				return err
+			}
+			p.Value = ent
 		case reflect.Ptr:
 			if isValidPointerType(v.Type().Elem()) {
 				if v.IsNil() {
@@ -116,8 +163,9 @@ func reflectFieldSave(props *[]Property, p Property, name string, opts saveOpts,
 				}
 				// When we recurse on the derefenced pointer, omitempty no longer applies:
 				// we already know the pointer is not empty, it doesn't matter if its referent
-				// is empty or not.
+				// is empty or not. The same goes for omitzero, which has checked the referent.
 				opts.omitEmpty = false
+				opts.omitZero = false
 				return saveStructProperty(props, name, opts, v.Elem())
 			}
 			if v.Type().Elem().Kind() != reflect.Struct {
@@ -144,8 +192,12 @@ func reflectFieldSave(props *[]Property, p Property, name string, opts saveOpts,
 				return sub.save(props, opts, name+".")
 			}
 
+			subOpts := opts
+			if opts.noCascade {
+				subOpts.noIndex = false
+			}
 			var subProps []Property
-			err = sub.save(&subProps, opts, "")
+			err = sub.save(&subProps, subOpts, "")
 			if err != nil {
 				return err
 			}
@@ -161,7 +213,7 @@ func reflectFieldSave(props *[]Property, p Property, name string, opts saveOpts,
 		}
 	}
 
-	if v.CanAddr() {
+	if v.CanAddr() && typeInfoOf(v.Type()).pls {
 		vi := v.Addr().Interface()
 		if pSaver, ok := vi.(PropertyLoadSaver); ok {
 			val, err := pSaver.Save()
@@ -179,6 +231,24 @@ func reflectFieldSave(props *[]Property, p Property, name string, opts saveOpts,
 	return nil
 }
 
+// saveMap returns an entity with a property for each entry of the map v,
+// whose keys are strings. The properties are sorted by key.
+func saveMap(opts saveOpts, v reflect.Value) (*Entity, error) {
+	keys := v.MapKeys()
+	sort.Slice(keys, func(i, j int) bool { return keys[i].String() < keys[j].String() })
+	elemOpts := saveOpts{noIndex: opts.noIndex && !opts.noCascade}
+	var props []Property
+	for _, k := range keys {
+		// Map elements are not addressable, so save a copy.
+		elem := reflect.New(v.Type().Elem()).Elem()
+		elem.Set(v.MapIndex(k))
+		if err := saveStructProperty(&props, k.String(), elemOpts, elem); err != nil {
+			return nil, err
+		}
+	}
+	return &Entity{Properties: props}, nil
+}
+
 // TODO(djd): Convert this and below to return ([]Property, error).
 func saveStructProperty(props *[]Property, name string, opts saveOpts, v reflect.Value) error {
 	p := Property{
@@ -186,10 +256,18 @@ func saveStructProperty(props *[]Property, name string, opts saveOpts, v reflect
 		NoIndex: opts.noIndex,
 	}
 
-	if opts.omitEmpty && isEmptyValue(v) {
+	if opts.omitEmpty && isEmptyValue(v) || opts.omitZero && isZeroValue(v) {
 		return nil
 	}
 
+	// The text option takes precedence over the interfaces of the field type.
+	if opts.text {
+		return textFieldSave(props, p, name, opts, v)
+	}
+	if opts.uuid {
+		return uuidFieldSave(props, p, name, opts, v)
+	}
+
 	// First check if field type implements PLS. If so, use PLS to
 	// save.
 	ok, err := plsFieldSave(props, p, name, opts, v)
@@ -200,9 +278,110 @@ func saveStructProperty(props *[]Property, name string, opts saveOpts, v reflect
 		return nil
 	}
 
+	// Then check if field type implements PropertyConverter.
+	ok, err = converterFieldSave(props, p, v)
+	if err != nil {
+		return err
+	}
+	if ok {
+		return nil
+	}
+
 	return reflectFieldSave(props, p, name, opts, v)
 }
 
+// textFieldSave saves v as the string returned by its MarshalText method,
+// for a field with the text option. If v is a slice, and its type does not
+// implement encoding.TextMarshaler, its elements are saved.
+func textFieldSave(props *[]Property, p Property, name string, opts saveOpts, v reflect.Value) error {
+	t := v.Type()
+	switch {
+	case t.Kind() == reflect.Ptr && v.IsNil():
+		// Nil pointer becomes a nil property value (unless
+		// omitEmpty is true, which is handled by the caller).
+		*props = append(*props, p)
+		return nil
+	case t.Implements(typeOfTextMarshaler):
+	case reflect.PtrTo(t).Implements(typeOfTextMarshaler) && v.CanAddr():
+		v = v.Addr()
+	case t.Kind() == reflect.Slice:
+		return saveSliceProperty(props, name, opts, v)
+	default:
+		return fmt.Errorf("datastore: field %q has the text option, but type %s does not implement encoding.TextMarshaler", name, t)
+	}
+	b, err := v.Interface().(encoding.TextMarshaler).MarshalText()
+	if err != nil {
+		return fmt.Errorf("datastore: marshaling field %q: %w", name, err)
+	}
+	p.Value = string(b)
+	*props = append(*props, p)
+	return nil
+}
+
+// uuidFieldSave saves v, an array of 16 bytes, as the canonical string
+// representation of a UUID, for a field with the uuid option. If v is a
+// slice, its elements are saved.
+func uuidFieldSave(props *[]Property, p Property, name string, opts saveOpts, v reflect.Value) error {
+	t := v.Type()
+	switch {
+	case t.Kind() == reflect.Ptr && isUUIDType(t.Elem()):
+		if v.IsNil() {
+			// Nil pointer becomes a nil property value (unless
+			// omitEmpty is true, which is handled by the caller).
+			*props = append(*props, p)
+			return nil
+		}
+		v = v.Elem()
+	case isUUIDType(t):
+	case t.Kind() == reflect.Slice:
+		return saveSliceProperty(props, name, opts, v)
+	default:
+		return fmt.Errorf("datastore: field %q has the uuid option, but type %s is not an array of 16 bytes", name, t)
+	}
+	p.Value = formatUUID(byteArray(v))
+	*props = append(*props, p)
+	return nil
+}
+
+// byteArray returns a copy of the contents of v, an array of bytes.
+func byteArray(v reflect.Value) []byte {
+	b := make([]byte, v.Len())
+	reflect.Copy(reflect.ValueOf(b), v)
+	return b
+}
+
+// converterFieldSave saves v with PropertyConverter.ToProperty, if v
+// implements PropertyConverter. It reports whether it did.
+func converterFieldSave(props *[]Property, p Property, v reflect.Value) (ok bool, err error) {
+	t := v.Type()
+	if !typeInfoOf(t).converter {
+		return false, nil
+	}
+	switch {
+	case t.Kind() == reflect.Ptr && t.Implements(typeOfPropertyConverter):
+		if v.IsNil() {
+			// Nil pointer becomes a nil property value (unless
+			// omitEmpty is true, which is handled by the caller).
+			*props = append(*props, p)
+			return true, nil
+		}
+	case t.Implements(typeOfPropertyConverter):
+	case reflect.PtrTo(t).Implements(typeOfPropertyConverter) && v.CanAddr():
+		v = v.Addr()
+	default:
+		return false, nil
+	}
+	p.Value, err = v.Interface().(PropertyConverter).ToProperty()
+	if err != nil {
+		return true, fmt.Errorf("datastore: converting field %q: %w", p.Name, err)
+	}
+	if _, isSlice := p.Value.([]interface{}); isSlice {
+		return true, fmt.Errorf("datastore: converting field %q: ToProperty returned a []interface{}", p.Name)
+	}
+	*props = append(*props, p)
+	return true, nil
+}
+
 // plsFieldSave first tries to converts v's value to a PLS, then v's addressed
 // value to a PLS. If neither succeeds, plsFieldSave returns false for first return
 // value.
@@ -247,7 +426,7 @@ func (s structPLS) key(v reflect.Value) (*Key, error) {
 		return nil, errors.New("datastore: cannot save key of non-struct type")
 	}
 
-	keyField := s.codec.Match(keyFieldName)
+	keyField := s.codec.match(keyFieldName)
 
 	if keyField == nil {
 		return nil, nil
@@ -267,6 +446,15 @@ func saveSliceProperty(props *[]Property, name string, opts saveOpts, v reflect.
 	if v.Len() == 0 {
 		return nil
 	}
+	// A nil struct pointer saves no properties, which would misalign the
+	// values of a flattened slice.
+	if opts.flatten && v.Type().Elem().Kind() == reflect.Ptr && v.Type().Elem().Elem().Kind() == reflect.Struct {
+		for i := 0; i < v.Len(); i++ {
+			if v.Index(i).IsNil() {
+				return fmt.Errorf("datastore: flattened slice %q has a nil element at index %d", name, i)
+			}
+		}
+	}
 	// Work out the properties generated by the first element in the slice. This will
 	// usually be a single property, but will be more if this is a slice of structs.
 	var headProps []Property
@@ -275,10 +463,12 @@ func saveSliceProperty(props *[]Property, name string, opts saveOpts, v reflect.
 	}
 
 	// Convert the first element's properties into slice properties, and
-	// keep track of the values in a map.
+	// keep track of the values and whether they are indexed in maps.
 	values := make(map[string][]interface{}, len(headProps))
+	noIndex := make(map[string][]bool, len(headProps))
 	for _, p := range headProps {
 		values[p.Name] = append(make([]interface{}, 0, v.Len()), p.Value)
+		noIndex[p.Name] = append(make([]bool, 0, v.Len()), p.NoIndex)
 	}
 
 	// Find the elements for the subsequent elements.
@@ -293,27 +483,44 @@ func saveSliceProperty(props *[]Property, name string, opts saveOpts, v reflect.
 				return fmt.Errorf("datastore: unexpected property %q in elem %d of slice", p.Name, i)
 			}
 			values[p.Name] = append(v, p.Value)
+			noIndex[p.Name] = append(noIndex[p.Name], p.NoIndex)
 		}
 	}
 
-	// Convert to the final properties.
+	// Convert to the final properties. A property is indexed unless none of
+	// its values are. If it is, the values that are not are NoIndexElements.
 	for _, p := range headProps {
-		p.Value = values[p.Name]
+		vals := values[p.Name]
+		p.NoIndex = true
+		for _, b := range noIndex[p.Name] {
+			p.NoIndex = p.NoIndex && b
+		}
+		if !p.NoIndex {
+			for i, b := range noIndex[p.Name] {
+				if b {
+					vals[i] = NoIndexElement{Value: vals[i]}
+				}
+			}
+		}
+		p.Value = vals
 		*props = append(*props, p)
 	}
 	return nil
 }
 
 func (s structPLS) Save() ([]Property, error) {
-	var props []Property
+	props := make([]Property, 0, len(s.codec.fields))
 	if err := s.save(&props, saveOpts{}, ""); err != nil {
 		return nil, err
 	}
+	if len(props) == 0 {
+		return nil, nil
+	}
 	return props, nil
 }
 
 func (s structPLS) save(props *[]Property, opts saveOpts, prefix string) error {
-	for _, f := range s.codec {
+	for _, f := range s.codec.fields {
 		name := prefix + f.Name
 		v := getField(s.v, f.Index)
 		if !v.IsValid() || !v.CanSet() {
@@ -328,7 +535,11 @@ func (s structPLS) save(props *[]Property, opts saveOpts, prefix string) error {
 		var opts1 saveOpts
 		opts1.noIndex = opts.noIndex || tagOpts.noIndex
 		opts1.flatten = opts.flatten || tagOpts.flatten
+		opts1.noCascade = tagOpts.noCascade // don't propagate
 		opts1.omitEmpty = tagOpts.omitEmpty // don't propagate
+		opts1.omitZero = tagOpts.omitZero   // don't propagate
+		opts1.text = tagOpts.text           // don't propagate
+		opts1.uuid = tagOpts.uuid           // don't propagate
 		if err := saveStructProperty(props, name, opts1, v); err != nil {
 			return err
 		}
@@ -361,22 +572,32 @@ func getField(v reflect.Value, index []int) reflect.Value {
 func propertiesToProto(key *Key, props []Property) (*pb.Entity, error) {
 	e := &pb.Entity{
 		Key:        keyToProto(key),
-		Properties: map[string]*pb.Value{},
+		Properties: make(map[string]*pb.Value, len(props)),
 	}
 	indexedProps := 0
-	for _, p := range props {
+	// The values are allocated together, which is much cheaper than
+	// allocating them one by one.
+	vals := make([]pb.Value, len(props))
+	for i, p := range props {
 		// Do not send a Key value a field to datastore.
 		if p.Name == keyFieldName {
 			continue
 		}
 
-		val, err := interfaceToProto(p.Value, p.NoIndex)
+		val := &vals[i]
+		err := setValueProto(val, p.Value, p.NoIndex)
 		if err != nil {
 			return nil, fmt.Errorf("datastore: %v for a Property with Name %q", err, p.Name)
 		}
 		if !p.NoIndex {
 			rVal := reflect.ValueOf(p.Value)
-			if rVal.Kind() == reflect.Slice && rVal.Type().Elem().Kind() != reflect.Uint8 {
+			if vals, ok := p.Value.([]interface{}); ok {
+				for _, v := range vals {
+					if _, ok := v.(NoIndexElement); !ok {
+						indexedProps++
+					}
+				}
+			} else if rVal.Kind() == reflect.Slice && rVal.Type().Elem().Kind() != reflect.Uint8 {
 				indexedProps += rVal.Len()
 			} else {
 				indexedProps++
@@ -395,7 +616,18 @@ func propertiesToProto(key *Key, props []Property) (*pb.Entity, error) {
 }
 
 func interfaceToProto(iv interface{}, noIndex bool) (*pb.Value, error) {
-	val := &pb.Value{ExcludeFromIndexes: noIndex}
+	val := new(pb.Value)
+	if err := setValueProto(val, iv, noIndex); err != nil {
+		return nil, err
+	}
+	return val, nil
+}
+
+// setValueProto sets val to the protocol buffer value of iv. As in
+// propertiesToProto, the values of the elements of an array are allocated
+// together.
+func setValueProto(val *pb.Value, iv interface{}, noIndex bool) error {
+	val.ExcludeFromIndexes = noIndex
 	switch v := iv.(type) {
 	case int:
 		val.ValueType = &pb.Value_IntegerValue{IntegerValue: int64(v)}
@@ -407,10 +639,10 @@ func interfaceToProto(iv interface{}, noIndex bool) (*pb.Value, error) {
 		val.ValueType = &pb.Value_BooleanValue{BooleanValue: v}
 	case string:
 		if len(v) > 1500 && !noIndex {
-			return nil, errors.New("string property too long to index")
+			return errors.New("string property too long to index")
 		}
 		if !utf8.ValidString(v) {
-			return nil, fmt.Errorf("string is not valid utf8: %q", v)
+			return fmt.Errorf("string is not valid utf8: %q", v)
 		}
 		val.ValueType = &pb.Value_StringValue{StringValue: v}
 	case float32:
@@ -425,7 +657,7 @@ func interfaceToProto(iv interface{}, noIndex bool) (*pb.Value, error) {
 		}
 	case GeoPoint:
 		if !v.Valid() {
-			return nil, errors.New("invalid GeoPoint value")
+			return errors.New("invalid GeoPoint value")
 		}
 		val.ValueType = &pb.Value_GeoPointValue{GeoPointValue: &llpb.LatLng{
 			Latitude:  v.Lat,
@@ -433,7 +665,7 @@ func interfaceToProto(iv interface{}, noIndex bool) (*pb.Value, error) {
 		}}
 	case time.Time:
 		if v.Before(minTime) || v.After(maxTime) {
-			return nil, errors.New("time value out of range")
+			return errors.New("time value out of range")
 		}
 		val.ValueType = &pb.Value_TimestampValue{TimestampValue: &timepb.Timestamp{
 			Seconds: v.Unix(),
@@ -441,23 +673,25 @@ func interfaceToProto(iv interface{}, noIndex bool) (*pb.Value, error) {
 		}}
 	case []byte:
 		if len(v) > 1500 && !noIndex {
-			return nil, errors.New("[]byte property too long to index")
+			return errors.New("[]byte property too long to index")
 		}
 		val.ValueType = &pb.Value_BlobValue{BlobValue: v}
+	case NoIndexElement:
+		return setValueProto(val, v.Value, true)
 	case *Entity:
 		e, err := propertiesToProto(v.Key, v.Properties)
 		if err != nil {
-			return nil, err
+			return err
 		}
 		val.ValueType = &pb.Value_EntityValue{EntityValue: e}
 	case []interface{}:
-		arr := make([]*pb.Value, 0, len(v))
+		arr := make([]*pb.Value, len(v))
+		elems := make([]pb.Value, len(v))
 		for i, v := range v {
-			elem, err := interfaceToProto(v, noIndex)
-			if err != nil {
-				return nil, fmt.Errorf("%v at index %d", err, i)
+			if err := setValueProto(&elems[i], v, noIndex); err != nil {
+				return fmt.Errorf("%v at index %d", err, i)
 			}
-			arr = append(arr, elem)
+			arr[i] = &elems[i]
 		}
 		val.ValueType = &pb.Value_ArrayValue{ArrayValue: &pb.ArrayValue{Values: arr}}
 		// ArrayValues have ExcludeFromIndexes set on the individual items, rather
@@ -467,23 +701,32 @@ func interfaceToProto(iv interface{}, noIndex bool) (*pb.Value, error) {
 		rv := reflect.ValueOf(iv)
 		if !rv.IsValid() {
 			val.ValueType = &pb.Value_NullValue{}
+		} else if rv.Kind() == reflect.Array && rv.Type().Elem().Kind() == reflect.Uint8 {
+			// Byte arrays, such as UUIDs, are saved as blobs.
+			val.ValueType = &pb.Value_BlobValue{BlobValue: byteArray(rv)}
 		} else if rv.Kind() == reflect.Ptr { // non-nil pointer: dereference
 			if rv.IsNil() {
 				val.ValueType = &pb.Value_NullValue{}
-				return val, nil
+				return nil
 			}
-			return interfaceToProto(rv.Elem().Interface(), noIndex)
+			return setValueProto(val, rv.Elem().Interface(), noIndex)
 		} else {
-			return nil, fmt.Errorf("invalid Value type %T", iv)
+			return fmt.Errorf("invalid Value type %T", iv)
 		}
 	}
 	// TODO(jbd): Support EntityValue.
-	return val, nil
+	return nil
 }
 
 // isEmptyValue is taken from the encoding/json package in the
-// standard library.
+// standard library. Values with an IsZero method, such as time.Time,
+// are empty if it returns true, unless they are pointers.
 func isEmptyValue(v reflect.Value) bool {
+	if v.Kind() != reflect.Ptr {
+		if zero, ok := callIsZero(v); ok {
+			return zero
+		}
+	}
 	switch v.Kind() {
 	case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
 		return v.Len() == 0
@@ -497,21 +740,54 @@ func isEmptyValue(v reflect.Value) bool {
 		return v.Float() == 0
 	case reflect.Interface, reflect.Ptr:
 		return v.IsNil()
-	case reflect.Struct:
-		if t, ok := v.Interface().(time.Time); ok {
-			return t.IsZero()
-		}
 	}
 	return false
 }
 
+// isZeroValue reports whether v is omitted by the omitzero option: whether
+// its IsZero method returns true, if it has one, or else whether v is the zero
+// value of its type, or a pointer to one.
+func isZeroValue(v reflect.Value) bool {
+	if zero, ok := callIsZero(v); ok {
+		return zero
+	}
+	if v.Kind() == reflect.Ptr && !v.IsNil() {
+		return isZeroValue(v.Elem())
+	}
+	return v.IsZero()
+}
+
+type isZeroer interface {
+	IsZero() bool
+}
+
+var typeOfIsZeroer = reflect.TypeOf((*isZeroer)(nil)).Elem()
+
+// callIsZero calls the IsZero method of v, or of its address, and returns
+// its result. ok reports whether v has such a method, and is not nil.
+func callIsZero(v reflect.Value) (zero, ok bool) {
+	t := v.Type()
+	switch {
+	case (t.Kind() == reflect.Ptr || t.Kind() == reflect.Interface) && v.IsNil():
+		return false, false
+	case t.Implements(typeOfIsZeroer):
+	case v.CanAddr() && reflect.PtrTo(t).Implements(typeOfIsZeroer):
+		v = v.Addr()
+	default:
+		return false, false
+	}
+	return v.Interface().(isZeroer).IsZero(), true
+}
+
 // isValidPointerType reports whether a struct field can be a pointer to type t
 // for the purposes of saving and loading.
 func isValidPointerType(t reflect.Type) bool {
-	if t == typeOfTime || t == typeOfGeoPoint {
+	if t == typeOfTime || t == typeOfGeoPoint || isCivilType(t) {
 		return true
 	}
 	switch t.Kind() {
+	case reflect.Array:
+		return t.Elem().Kind() == reflect.Uint8
 	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
 		return true
 	case reflect.Bool:
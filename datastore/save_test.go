@@ -492,3 +492,270 @@ func TestSaveFieldsWithInterface(t *testing.T) {
 		})
 	}
 }
+
+type Maps struct {
+	Counts  map[string]int
+	Tags    map[string][]string `datastore:",noindex"`
+	Inner   map[string]Inner1
+	Nested  map[string]map[string]bool
+	Nil     map[string]int
+	Omitted map[string]int `datastore:",omitempty"`
+}
+
+func TestSaveMaps(t *testing.T) {
+	src := &Maps{
+		Counts: map[string]int{"b": 2, "a": 1, "c": 3},
+		Tags:   map[string][]string{"x": {"1", "2"}, "y": nil},
+		Inner:  map[string]Inner1{"k": {W: 1, X: "x"}},
+		Nested: map[string]map[string]bool{"n": {"t": true}},
+	}
+	got, err := SaveStruct(src)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []Property{
+		{Name: "Counts", Value: &Entity{Properties: []Property{
+			{Name: "a", Value: int64(1)},
+			{Name: "b", Value: int64(2)},
+			{Name: "c", Value: int64(3)},
+		}}},
+		{Name: "Tags", NoIndex: true, Value: &Entity{Properties: []Property{
+			{Name: "x", Value: []interface{}{"1", "2"}, NoIndex: true},
+		}}},
+		{Name: "Inner", Value: &Entity{Properties: []Property{
+			{Name: "k", Value: &Entity{Properties: []Property{
+				{Name: "W", Value: int64(1)},
+				{Name: "X", Value: "x"},
+			}}},
+		}}},
+		{Name: "Nested", Value: &Entity{Properties: []Property{
+			{Name: "n", Value: &Entity{Properties: []Property{{Name: "t", Value: true}}}},
+		}}},
+		{Name: "Nil", Value: nil},
+	}
+	if diff := testutil.Diff(got, want); diff != "" {
+		t.Errorf("SaveStruct: (-got +want)\n%s", diff)
+	}
+
+	type badKey struct {
+		M map[int]string
+	}
+	if _, err := SaveStruct(&badKey{M: map[int]string{1: "a"}}); err == nil {
+		t.Error("map with int keys: got nil, want error")
+	}
+}
+
+func TestCivilRoundTrip(t *testing.T) {
+	type civilTypes struct {
+		Date      civil.Date
+		Time      civil.Time
+		DateTime  civil.DateTime
+		PDate     *civil.Date
+		PNil      *civil.DateTime
+		Dates     []civil.Date
+		TextDate  civil.Date       `datastore:",text"`
+		TextTime  *civil.Time      `datastore:",text"`
+		TextDates []civil.DateTime `datastore:",text"`
+	}
+	date := civil.Date{Year: 2023, Month: 9, Day: 14}
+	tm := civil.Time{Hour: 13, Minute: 14, Second: 15, Nanosecond: 16000}
+	dt := civil.DateTime{Date: date, Time: tm}
+	src := &civilTypes{
+		Date:      date,
+		Time:      tm,
+		DateTime:  dt,
+		PDate:     &date,
+		Dates:     []civil.Date{date, {Year: 1999, Month: 12, Day: 31}},
+		TextDate:  date,
+		TextTime:  &tm,
+		TextDates: []civil.DateTime{dt},
+	}
+	props, err := SaveStruct(src)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, p := range props {
+		switch p.Name {
+		case "TextDate":
+			if p.Value != "2023-09-14" {
+				t.Errorf("TextDate: got %v, want string", p.Value)
+			}
+		case "PDate":
+			if _, ok := p.Value.(time.Time); !ok {
+				t.Errorf("PDate: got %T, want time.Time", p.Value)
+			}
+		}
+	}
+
+	e, err := saveEntity(testKey0, src)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := &civilTypes{PNil: &dt}
+	if err := loadEntityProto(got, e); err != nil {
+		t.Fatal(err)
+	}
+	if diff := testutil.Diff(got, src); diff != "" {
+		t.Errorf("round trip: (-got +want)\n%s", diff)
+	}
+
+	// Both representations can be loaded regardless of the tag.
+	var both struct {
+		Date     civil.Date
+		TextDate civil.Date `datastore:",text"`
+	}
+	err = LoadStruct(&both, []Property{{Name: "Date", Value: "2023-09-14"}, {Name: "TextDate", Value: date.In(time.UTC)}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if both.Date != date {
+		t.Errorf("Date from string: got %v, want %v", both.Date, date)
+	}
+	err = LoadStruct(&both, []Property{{Name: "Date", Value: int64(1)}})
+	if e, ok := err.(*ErrFieldMismatch); !ok || e.FieldName != "Date" {
+		t.Errorf("Date from int: got %v, want ErrFieldMismatch", err)
+	}
+}
+
+// zeroer has an IsZero method, which reports whether it is below 1.
+type zeroer float64
+
+func (z zeroer) IsZero() bool { return z < 1 }
+
+func TestSaveOmitZero(t *testing.T) {
+	type inner struct {
+		A int
+	}
+	type omitZero struct {
+		Time    time.Time      `datastore:",omitzero"`
+		Struct  inner          `datastore:",omitzero"`
+		Ptr     *int           `datastore:",omitzero"`
+		PtrZero *int           `datastore:",omitzero"`
+		Zeroer  zeroer         `datastore:",omitzero"`
+		PZeroer *zeroer        `datastore:",omitzero"`
+		Date    civil.Date     `datastore:",omitzero"`
+		Slice   []int          `datastore:",omitzero"`
+		Map     map[string]int `datastore:",omitzero"`
+		Empty   zeroer         `datastore:",omitempty"`
+		PEmpty  *zeroer        `datastore:",omitempty"`
+		Kept    inner          `datastore:",omitempty"`
+		Decimal Decimal        `datastore:",omitzero"`
+		Geo     GeoPoint       `datastore:",omitzero"`
+		Iface   interface{}    `datastore:",omitzero"`
+		Key     *Key           `datastore:",omitzero"`
+		PStruct *inner         `datastore:",omitzero"`
+	}
+	zero, half := 0, zeroer(0.5)
+	got, err := SaveStruct(&omitZero{
+		PtrZero: &zero,
+		Zeroer:  0.5,
+		PZeroer: &half,
+		Empty:   0.5,
+		PEmpty:  &half,
+		PStruct: &inner{},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []Property{
+		{Name: "PEmpty", Value: 0.5},
+		{Name: "Kept", Value: &Entity{Properties: []Property{{Name: "A", Value: int64(0)}}}},
+	}
+	if diff := testutil.Diff(got, want); diff != "" {
+		t.Errorf("zero values: (-got +want)\n%s", diff)
+	}
+
+	one := 1
+	got, err = SaveStruct(&omitZero{Struct: inner{A: 1}, Ptr: &one, Zeroer: 2, Kept: inner{A: 1}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want = []Property{
+		{Name: "Struct", Value: &Entity{Properties: []Property{{Name: "A", Value: int64(1)}}}},
+		{Name: "Ptr", Value: int64(1)},
+		{Name: "Zeroer", Value: 2.0},
+		{Name: "Kept", Value: &Entity{Properties: []Property{{Name: "A", Value: int64(1)}}}},
+	}
+	if diff := testutil.Diff(got, want); diff != "" {
+		t.Errorf("non-zero values: (-got +want)\n%s", diff)
+	}
+}
+
+func TestSaveNoIndexElements(t *testing.T) {
+	type inner struct {
+		A string
+	}
+	type withElems struct {
+		Mixed   []interface{}
+		AllNo   []interface{}
+		Cascade inner          `datastore:",noindex"`
+		NoCasc  inner          `datastore:",noindex,nocascade"`
+		NoCascs []inner        `datastore:",noindex,nocascade"`
+		NoCascM map[string]int `datastore:",noindex,nocascade"`
+		Plain   []string
+	}
+	src := &withElems{
+		Mixed:   []interface{}{"a", NoIndexElement{Value: "b"}, NoIndexElement{}},
+		AllNo:   []interface{}{NoIndexElement{Value: int64(1)}, NoIndexElement{Value: inner{"x"}}},
+		Cascade: inner{"c"},
+		NoCasc:  inner{"d"},
+		NoCascs: []inner{{"e"}},
+		NoCascM: map[string]int{"f": 1},
+		Plain:   []string{"g"},
+	}
+	got, err := SaveStruct(src)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []Property{
+		{Name: "Mixed", Value: []interface{}{"a", NoIndexElement{Value: "b"}, NoIndexElement{}}},
+		{Name: "AllNo", NoIndex: true, Value: []interface{}{
+			int64(1),
+			&Entity{Properties: []Property{{Name: "A", Value: "x", NoIndex: true}}},
+		}},
+		{Name: "Cascade", NoIndex: true, Value: &Entity{Properties: []Property{{Name: "A", Value: "c", NoIndex: true}}}},
+		{Name: "NoCasc", NoIndex: true, Value: &Entity{Properties: []Property{{Name: "A", Value: "d"}}}},
+		{Name: "NoCascs", NoIndex: true, Value: []interface{}{&Entity{Properties: []Property{{Name: "A", Value: "e"}}}}},
+		{Name: "NoCascM", NoIndex: true, Value: &Entity{Properties: []Property{{Name: "f", Value: int64(1)}}}},
+		{Name: "Plain", Value: []interface{}{"g"}},
+	}
+	if diff := testutil.Diff(got, want); diff != "" {
+		t.Errorf("SaveStruct: (-got +want)\n%s", diff)
+	}
+
+	e, err := saveEntity(testKey0, src)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var excluded []bool
+	for _, v := range e.Properties["Mixed"].GetArrayValue().Values {
+		excluded = append(excluded, v.ExcludeFromIndexes)
+	}
+	if want := []bool{false, true, true}; !testutil.Equal(excluded, want) {
+		t.Errorf("Mixed: got ExcludeFromIndexes %v, want %v", excluded, want)
+	}
+
+	// The values load as plain values, with NoIndex from the first element.
+	var pl PropertyList
+	if err := loadEntityProto(&pl, e); err != nil {
+		t.Fatal(err)
+	}
+	for _, p := range pl {
+		if p.Name == "Mixed" {
+			want := Property{Name: "Mixed", Value: []interface{}{"a", "b", nil}}
+			if diff := testutil.Diff(p, want); diff != "" {
+				t.Errorf("PropertyList: (-got +want)\n%s", diff)
+			}
+		}
+	}
+	type loaded struct {
+		Mixed []string
+	}
+	var l loaded
+	if err := loadEntityProto(&l, &pb.Entity{Properties: map[string]*pb.Value{"Mixed": e.Properties["Mixed"]}}); err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"a", "b", ""}; !testutil.Equal(l.Mixed, want) {
+		t.Errorf("struct: got %v, want %v", l.Mixed, want)
+	}
+}
@@ -52,6 +52,13 @@ func newTransactionSettings(opts []TransactionOption) *transactionSettings {
 	return s
 }
 
+func (s *transactionSettings) validate() error {
+	if s.readTime != nil && !s.readOnly {
+		return errors.New("datastore: WithReadTime can only be used in a ReadOnly transaction")
+	}
+	return nil
+}
+
 // TransactionOption configures the way a transaction is executed.
 type TransactionOption interface {
 	apply(*transactionSettings)
@@ -71,7 +78,7 @@ func (w maxAttempts) apply(s *transactionSettings) {
 }
 
 // WithReadTime returns a TransactionOption that specifies a snapshot of the
-// database to view.
+// database to view. It can only be used together with ReadOnly.
 func WithReadTime(t time.Time) TransactionOption {
 	return readTime{t}
 }
@@ -126,7 +133,11 @@ func (c *Client) NewTransaction(ctx context.Context, opts ...TransactionOption)
 			return nil, errors.New("datastore: NewTransaction does not accept MaxAttempts option")
 		}
 	}
-	return c.newTransaction(ctx, newTransactionSettings(opts))
+	s := newTransactionSettings(opts)
+	if err := s.validate(); err != nil {
+		return nil, err
+	}
+	return c.newTransaction(ctx, s)
 }
 
 func (c *Client) newTransaction(ctx context.Context, s *transactionSettings) (_ *Transaction, err error) {
@@ -197,6 +208,9 @@ func (c *Client) RunInTransaction(ctx context.Context, f func(tx *Transaction) e
 	defer func() { trace.EndSpan(ctx, err) }()
 
 	settings := newTransactionSettings(opts)
+	if err := settings.validate(); err != nil {
+		return nil, err
+	}
 	for n := 0; n < settings.attempts; n++ {
 		tx, err := c.newTransaction(ctx, settings)
 		if err != nil {
@@ -17,6 +17,7 @@ package datastore
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/golang/protobuf/proto"
 	pb "google.golang.org/genproto/googleapis/datastore/v1"
@@ -88,3 +89,25 @@ func TestNewTransaction(t *testing.T) {
 		}
 	}
 }
+
+func TestReadTimeRequiresReadOnly(t *testing.T) {
+	client := &Client{
+		dataset: "project",
+		client: &fakeDatastoreClient{
+			beginTransaction: func(req *pb.BeginTransactionRequest) (*pb.BeginTransactionResponse, error) {
+				return &pb.BeginTransactionResponse{Transaction: []byte("tid")}, nil
+			},
+		},
+	}
+	ctx := context.Background()
+	rt := WithReadTime(time.Now())
+	if _, err := client.NewTransaction(ctx, rt); err == nil {
+		t.Error("NewTransaction: got nil, want error")
+	}
+	if _, err := client.RunInTransaction(ctx, func(*Transaction) error { return nil }, rt); err == nil {
+		t.Error("RunInTransaction: got nil, want error")
+	}
+	if _, err := client.NewTransaction(ctx, ReadOnly, rt); err != nil {
+		t.Errorf("NewTransaction with ReadOnly: %v", err)
+	}
+}
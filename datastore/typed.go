@@ -0,0 +1,121 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datastore
+
+import (
+	"context"
+
+	"google.golang.org/api/iterator"
+)
+
+// The functions in this file are typed versions of the Client methods of the
+// same names. Their type parameter T is the type of the entities, which must
+// be a struct type S or a non-pointer type P such that *P implements
+// PropertyLoadSaver, such as PropertyList.
+
+// Get loads the entity stored for key into a new T and returns it.
+//
+// As with Client.Get, if there is no such entity for the key, Get returns
+// ErrNoSuchEntity, and if a field of the entity cannot be loaded into T, Get
+// returns the entity as far as it was loaded, and an *ErrFieldMismatch.
+func Get[T any](ctx context.Context, c *Client, key *Key) (T, error) {
+	var dst T
+	err := c.Get(ctx, key, &dst)
+	return dst, err
+}
+
+// GetMulti is a batch version of Get. It returns the entities in the same
+// order as keys.
+//
+// As with Client.GetMulti, the error may be a MultiError, in which case the
+// entities that were loaded are still returned.
+func GetMulti[T any](ctx context.Context, c *Client, keys []*Key) ([]T, error) {
+	dst := make([]T, len(keys))
+	err := c.GetMulti(ctx, keys, dst)
+	return dst, err
+}
+
+// GetAll runs q and returns all the entities that match it, and their keys,
+// in a 1-1 correspondence.
+//
+// If q is a “keys-only” query, GetAll only returns the keys.
+//
+// See Client.GetAll for details.
+func GetAll[T any](ctx context.Context, c *Client, q *Query) ([]T, []*Key, error) {
+	var dst []T
+	keys, err := c.GetAll(ctx, q, &dst)
+	return dst, keys, err
+}
+
+// Run runs q and returns an iterator over its results.
+func Run[T any](ctx context.Context, c *Client, q *Query) *TypedIterator[T] {
+	return &TypedIterator[T]{it: c.Run(ctx, q)}
+}
+
+// RunStream runs q and calls f with the entity and key of each of its results,
+// in order. The results are fetched in batches as f consumes them, so that a
+// large result set can be processed without holding it all in memory.
+//
+// RunStream stops when f returns an error, and returns it, unless it is
+// iterator.Done, which stops RunStream early without error. If q is a
+// “keys-only” query, f is called with the zero T.
+//
+// As with GetAll, if a field of an entity cannot be loaded into T, f is
+// called with the entity as far as it was loaded, and if there is no other
+// error, RunStream returns the last *ErrFieldMismatch.
+func RunStream[T any](ctx context.Context, c *Client, q *Query, f func(T, *Key) error) error {
+	var errFieldMismatch error
+	for it := Run[T](ctx, c, q); ; {
+		dst, k, err := it.Next()
+		if err == iterator.Done {
+			return errFieldMismatch
+		}
+		if _, ok := err.(*ErrFieldMismatch); ok {
+			errFieldMismatch = err
+		} else if err != nil {
+			return err
+		}
+		if err := f(dst, k); err == iterator.Done {
+			return errFieldMismatch
+		} else if err != nil {
+			return err
+		}
+	}
+}
+
+// TypedIterator is the result of running a query with Run. It is like
+// Iterator, but returns the entities as values of type T.
+//
+// It is not safe for concurrent use.
+type TypedIterator[T any] struct {
+	it *Iterator
+}
+
+// Next returns the entity of the next result and its key. When there are no
+// more results, iterator.Done is returned as the error.
+//
+// If the query is not keys-only and a field of the entity cannot be loaded
+// into T, Next returns the entity as far as it was loaded, its key, and an
+// *ErrFieldMismatch. If the query is keys-only, the entity is the zero T.
+func (t *TypedIterator[T]) Next() (T, *Key, error) {
+	var dst T
+	k, err := t.it.Next(&dst)
+	return dst, k, err
+}
+
+// Cursor returns a cursor for the iterator's current location.
+func (t *TypedIterator[T]) Cursor() (Cursor, error) {
+	return t.it.Cursor()
+}
@@ -0,0 +1,182 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datastore
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"cloud.google.com/go/internal/testutil"
+	"google.golang.org/api/iterator"
+	pb "google.golang.org/genproto/googleapis/datastore/v1"
+)
+
+func newGopherQueryClient() *Client {
+	return &Client{
+		client: &fakeClient{
+			queryFn: func(req *pb.RunQueryRequest) (*pb.RunQueryResponse, error) {
+				return fakeRunQuery(req)
+			},
+		},
+	}
+}
+
+var wantGophers = []Gopher{{Name: "George", Height: 32}, {Name: "Rufus"}}
+
+func TestTypedGetAll(t *testing.T) {
+	ctx := context.Background()
+	client := newGopherQueryClient()
+
+	got, keys, err := GetAll[Gopher](ctx, client, NewQuery("Gopher"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := testutil.Diff(got, wantGophers); diff != "" {
+		t.Errorf("entities: (-got +want)\n%s", diff)
+	}
+	if len(keys) != 2 || keys[0].ID != 6 || keys[1].ID != 8 {
+		t.Errorf("got keys %v", keys)
+	}
+
+	pls, _, err := GetAll[PropertyList](ctx, client, NewQuery("Gopher"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pls) != 2 || len(pls[0]) != 2 {
+		t.Errorf("got property lists %v", pls)
+	}
+}
+
+func TestTypedIterator(t *testing.T) {
+	it := Run[Gopher](context.Background(), newGopherQueryClient(), NewQuery("Gopher"))
+	var (
+		got  []Gopher
+		keys []*Key
+	)
+	for {
+		g, k, err := it.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		got = append(got, g)
+		keys = append(keys, k)
+	}
+	if diff := testutil.Diff(got, wantGophers); diff != "" {
+		t.Errorf("entities: (-got +want)\n%s", diff)
+	}
+	if len(keys) != 2 || keys[0].ID != 6 || keys[1].ID != 8 {
+		t.Errorf("got keys %v", keys)
+	}
+	if _, err := it.Cursor(); err != nil {
+		t.Errorf("Cursor: %v", err)
+	}
+}
+
+func TestRunStream(t *testing.T) {
+	ctx := context.Background()
+	client := newGopherQueryClient()
+
+	var (
+		got  []Gopher
+		keys []*Key
+	)
+	err := RunStream(ctx, client, NewQuery("Gopher"), func(g Gopher, k *Key) error {
+		got = append(got, g)
+		keys = append(keys, k)
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := testutil.Diff(got, wantGophers); diff != "" {
+		t.Errorf("entities: (-got +want)\n%s", diff)
+	}
+	if len(keys) != 2 || keys[0].ID != 6 || keys[1].ID != 8 {
+		t.Errorf("got keys %v", keys)
+	}
+
+	n := 0
+	err = RunStream(ctx, client, NewQuery("Gopher"), func(Gopher, *Key) error {
+		n++
+		return iterator.Done
+	})
+	if err != nil || n != 1 {
+		t.Errorf("stopped with iterator.Done: got %v after %d results, want nil after 1", err, n)
+	}
+
+	errStop := errors.New("stop")
+	n = 0
+	err = RunStream(ctx, client, NewQuery("Gopher"), func(Gopher, *Key) error {
+		n++
+		return errStop
+	})
+	if err != errStop || n != 1 {
+		t.Errorf("stopped with error: got %v after %d results, want %v after 1", err, n, errStop)
+	}
+
+	type shortGopher struct{ Name string }
+	var names []string
+	err = RunStream(ctx, client, NewQuery("Gopher"), func(g shortGopher, _ *Key) error {
+		names = append(names, g.Name)
+		return nil
+	})
+	if _, ok := err.(*ErrFieldMismatch); !ok || len(names) != 2 {
+		t.Errorf("field mismatch: got %v and names %v, want *ErrFieldMismatch and 2 names", err, names)
+	}
+}
+
+func TestTypedGet(t *testing.T) {
+	client, srv, cleanup := newMock(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	k := NameKey("Gopher", "George", nil)
+	missing := NameKey("Gopher", "Nobody", nil)
+	found := &pb.EntityResult{Entity: &pb.Entity{
+		Key: keyToProto(k),
+		Properties: map[string]*pb.Value{
+			"Name":   {ValueType: &pb.Value_StringValue{StringValue: "George"}},
+			"Height": {ValueType: &pb.Value_IntegerValue{IntegerValue: 32}},
+		},
+	}}
+
+	srv.addRPC(&pb.LookupRequest{ProjectId: "projectID", Keys: []*pb.Key{keyToProto(k)}},
+		&pb.LookupResponse{Found: []*pb.EntityResult{found}})
+	g, err := Get[Gopher](ctx, client, k)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if g != wantGophers[0] {
+		t.Errorf("got %+v, want %+v", g, wantGophers[0])
+	}
+
+	srv.addRPC(&pb.LookupRequest{ProjectId: "projectID", Keys: []*pb.Key{keyToProto(k), keyToProto(missing)}},
+		&pb.LookupResponse{
+			Found:   []*pb.EntityResult{found},
+			Missing: []*pb.EntityResult{{Entity: &pb.Entity{Key: keyToProto(missing)}}},
+		})
+	gs, err := GetMulti[Gopher](ctx, client, []*Key{k, missing})
+	var me MultiError
+	if !errors.As(err, &me) || me[0] != nil || me[1] != ErrNoSuchEntity {
+		t.Fatalf("got error %v, want MultiError{nil, ErrNoSuchEntity}", err)
+	}
+	if len(gs) != 2 || gs[0] != wantGophers[0] || gs[1] != (Gopher{}) {
+		t.Errorf("got %+v", gs)
+	}
+}
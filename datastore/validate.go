@@ -0,0 +1,318 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datastore
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+)
+
+// ValidateEntityType reports the problems of the struct type t, or a pointer
+// to it, as the type of entities. It lets programs check their entity types
+// when they start, rather than when they first save or load an entity.
+//
+// The problems include invalid struct tags, fields of unsupported types,
+// options that do not apply to their fields, and property names that are
+// used by several fields, including fields whose names conflict because of
+// embedding, which are neither saved nor loaded.
+//
+// ValidateEntityType returns nil if there are no problems, and otherwise a
+// MultiError with one error for each of them, sorted by field.
+func ValidateEntityType(t reflect.Type) error {
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return MultiError{fmt.Errorf("datastore: %v is not a struct type", t)}
+	}
+	v := &typeValidator{checked: map[reflect.Type]bool{}}
+	v.validateStruct(t, "", "", saveOpts{}, false, map[string]string{}, map[reflect.Type]bool{})
+	if len(v.errs) == 0 {
+		return nil
+	}
+	sort.SliceStable(v.errs, func(i, j int) bool { return v.errs[i].Error() < v.errs[j].Error() })
+	return v.errs
+}
+
+// typeValidator collects the problems of an entity type.
+type typeValidator struct {
+	errs MultiError
+	// checked holds the struct types of the entity values that have been
+	// checked, which are checked once.
+	checked map[reflect.Type]bool
+}
+
+func (v *typeValidator) errorf(path, format string, args ...interface{}) {
+	v.errs = append(v.errs, fmt.Errorf("datastore: field %s: %s", path, fmt.Sprintf(format, args...)))
+}
+
+// candidateField is a field of a struct, or promoted from an embedded struct,
+// which may hold a property.
+type candidateField struct {
+	name   string
+	tagged bool // Whether the name is from a tag.
+	depth  int
+	path   string
+	field  reflect.StructField
+	opts   saveOpts
+}
+
+// validateStruct checks the fields of the struct type t, whose properties
+// are named with prefix, with the options opts inherited from the field of
+// type t. names maps the property names of the entity to the fields that
+// hold them. flattening is the set of struct types being flattened.
+func (v *typeValidator) validateStruct(t reflect.Type, path, prefix string, opts saveOpts, inSlice bool, names map[string]string, flattening map[reflect.Type]bool) {
+	if isLeafType(t) {
+		return
+	}
+	if flattening[t] {
+		v.errorf(path, "flattening recursive type %v", t)
+		return
+	}
+	flattening[t] = true
+	defer delete(flattening, t)
+
+	for _, f := range v.dominantFields(t, path) {
+		if f.opts.flatten && f.field.Type.Kind() != reflect.Map && !isFlattenable(f.field.Type) {
+			v.errorf(f.path, `option "flatten" does not apply to %v`, f.field.Type)
+		}
+		if f.opts.noCascade && !hasEntityValues(f.field.Type) {
+			v.errorf(f.path, `option "nocascade" does not apply to %v, which has no entity values`, f.field.Type)
+		}
+		fopts := f.opts
+		fopts.noIndex = fopts.noIndex || opts.noIndex
+		fopts.flatten = fopts.flatten || opts.flatten
+		name := prefix + f.name
+		if other, ok := names[name]; ok {
+			v.errorf(f.path, "property name %q is also used by field %s", name, other)
+		} else {
+			names[name] = f.path
+		}
+		v.validateField(f.field.Type, f.path, name, fopts, inSlice, names, flattening)
+	}
+}
+
+// dominantFields returns the fields of the struct type t that hold its
+// properties, following the Go rules for embedded fields like the fields
+// package, and reports the fields that conflict.
+func (v *typeValidator) dominantFields(t reflect.Type, path string) []candidateField {
+	var all []candidateField
+	var walk func(t reflect.Type, path string, depth int, visited map[reflect.Type]bool)
+	walk = func(t reflect.Type, path string, depth int, visited map[reflect.Type]bool) {
+		if visited[t] {
+			return
+		}
+		visited[t] = true
+		defer delete(visited, t)
+		for i := 0; i < t.NumField(); i++ {
+			f := t.Field(i)
+			fpath := f.Name
+			if path != "" {
+				fpath = path + "." + f.Name
+			}
+			if f.PkgPath != "" && !f.Anonymous {
+				continue
+			}
+			name, keep, other, err := parseTag(f.Tag)
+			if err != nil {
+				v.errorf(fpath, "%v", err)
+				continue
+			}
+			if !keep {
+				continue
+			}
+			var opts saveOpts
+			if other != nil {
+				opts = other.(saveOpts)
+			}
+			ft := f.Type
+			if ft.Kind() == reflect.Ptr {
+				ft = ft.Elem()
+			}
+			if f.Anonymous && name == "" && ft.Kind() == reflect.Struct && !isLeafType(ft) {
+				walk(ft, fpath, depth+1, visited)
+				continue
+			}
+			if f.PkgPath != "" {
+				continue
+			}
+			all = append(all, candidateField{
+				name:   name,
+				tagged: name != "",
+				depth:  depth,
+				path:   fpath,
+				field:  f,
+				opts:   opts,
+			})
+			if name == "" {
+				all[len(all)-1].name = f.Name
+			}
+		}
+	}
+	walk(t, path, 0, map[reflect.Type]bool{})
+
+	// As in the fields package, the dominant field of a name is the one at
+	// the least depth, preferring tagged fields, and there is none if two
+	// fields are equally dominant.
+	sort.SliceStable(all, func(i, j int) bool {
+		if all[i].name != all[j].name {
+			return all[i].name < all[j].name
+		}
+		if all[i].depth != all[j].depth {
+			return all[i].depth < all[j].depth
+		}
+		return all[i].tagged && !all[j].tagged
+	})
+	var out []candidateField
+	for i := 0; i < len(all); {
+		j := i + 1
+		for j < len(all) && all[j].name == all[i].name {
+			j++
+		}
+		switch {
+		case j-i == 1 || all[i].depth < all[i+1].depth:
+			out = append(out, all[i])
+		case all[i].tagged == all[i+1].tagged:
+			v.errorf(all[i].path, "property name %q conflicts with field %s, so neither is saved or loaded", all[i].name, all[i+1].path)
+		default:
+			// Go would allow the tag to hide a field of the same name at the
+			// same depth, but that is most likely a mistake.
+			out = append(out, all[i])
+			v.errorf(all[i+1].path, "property name %q is also used by field %s, so it is not saved or loaded", all[i].name, all[i].path)
+		}
+		i = j
+	}
+	sort.SliceStable(out, func(i, j int) bool { return out[i].path < out[j].path })
+	return out
+}
+
+// validateField checks a field of type t with the options opts, whose
+// property is named name.
+func (v *typeValidator) validateField(t reflect.Type, path, name string, opts saveOpts, inSlice bool, names map[string]string, flattening map[reflect.Type]bool) {
+	if name == keyFieldName {
+		if t != typeOfKeyPtr {
+			v.errorf(path, "the %s field must be of type *Key, not %v", keyFieldName, t)
+		}
+		return
+	}
+	if opts.noCascade && !opts.noIndex {
+		v.errorf(path, `option "nocascade" requires "noindex"`)
+	}
+	if opts.text {
+		elem := t
+		if elem.Kind() == reflect.Slice && !isTextUnmarshalerType(elem) {
+			elem = elem.Elem()
+		}
+		if elem.Kind() == reflect.Ptr {
+			elem = elem.Elem()
+		}
+		p := reflect.PtrTo(elem)
+		if !p.Implements(typeOfTextMarshaler) || !p.Implements(typeOfTextUnmarshaler) {
+			v.errorf(path, `option "text" requires %v to implement encoding.TextMarshaler and encoding.TextUnmarshaler`, t)
+		}
+		return
+	}
+	if opts.uuid {
+		elem := t
+		if elem.Kind() == reflect.Slice {
+			elem = elem.Elem()
+		}
+		if elem.Kind() == reflect.Ptr {
+			elem = elem.Elem()
+		}
+		if !isUUIDType(elem) {
+			v.errorf(path, `option "uuid" requires an array of 16 bytes, not %v`, t)
+		}
+		return
+	}
+	if reflect.PtrTo(t).Implements(typeOfPropertyLoadSaver) || isLeafType(t) || isCivilType(t) {
+		return
+	}
+	switch t.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Bool, reflect.String, reflect.Float32, reflect.Float64, reflect.Interface:
+	case reflect.Array:
+		if t.Elem().Kind() != reflect.Uint8 {
+			v.errorf(path, "unsupported type %v: only arrays of bytes are supported", t)
+		}
+	case reflect.Slice:
+		elem := t.Elem()
+		if elem.Kind() == reflect.Uint8 {
+			return
+		}
+		if elem.Kind() == reflect.Slice && elem.Elem().Kind() != reflect.Uint8 {
+			v.errorf(path, "unsupported type %v: slices of slices are not supported", t)
+			return
+		}
+		if inSlice && opts.flatten {
+			v.errorf(path, "flattening nested structs leads to a slice of slices")
+			return
+		}
+		v.validateField(elem, path, name, opts, true, names, flattening)
+	case reflect.Map:
+		if t.Key().Kind() != reflect.String {
+			v.errorf(path, "unsupported type %v: map keys must be strings", t)
+			return
+		}
+		if opts.flatten {
+			v.errorf(path, "map fields cannot be flattened")
+			return
+		}
+		v.validateField(t.Elem(), path+"[key]", "", saveOpts{noIndex: opts.noIndex && !opts.noCascade}, false, map[string]string{}, flattening)
+	case reflect.Ptr:
+		elem := t.Elem()
+		if t == typeOfKeyPtr || isValidPointerType(elem) {
+			return
+		}
+		if elem.Kind() != reflect.Struct {
+			v.errorf(path, "unsupported pointer type %v", t)
+			return
+		}
+		v.validateField(elem, path, name, opts, inSlice, names, flattening)
+	case reflect.Struct:
+		if opts.flatten {
+			v.validateStruct(t, path, name+".", opts, inSlice, names, flattening)
+			return
+		}
+		// An entity value, whose properties have their own names.
+		if v.checked[t] {
+			return
+		}
+		v.checked[t] = true
+		subOpts := saveOpts{noIndex: opts.noIndex && !opts.noCascade}
+		v.validateStruct(t, path, "", subOpts, false, map[string]string{}, map[reflect.Type]bool{})
+	default:
+		v.errorf(path, "unsupported type %v", t)
+	}
+}
+
+// isFlattenable reports whether the flatten option applies to a field of
+// type t: a struct, or a slice of or pointer to one.
+func isFlattenable(t reflect.Type) bool {
+	for t.Kind() == reflect.Slice || t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t.Kind() == reflect.Struct && !isLeafType(t)
+}
+
+// hasEntityValues reports whether a field of type t is saved as entity values
+// when it is not flattened: a struct or a map, or a slice of or pointer to one.
+func hasEntityValues(t reflect.Type) bool {
+	for t.Kind() == reflect.Slice || t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return (t.Kind() == reflect.Struct || t.Kind() == reflect.Map) && t != typeOfTime && t != typeOfGeoPoint
+}
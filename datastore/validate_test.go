@@ -0,0 +1,130 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datastore
+
+import (
+	"net"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/civil"
+	"cloud.google.com/go/internal/testutil"
+)
+
+type validInner struct {
+	A int
+	B []string `datastore:",noindex"`
+}
+
+type validFlat struct {
+	A int
+	B string `datastore:",noindex"`
+}
+
+type validEntity struct {
+	K        *Key `datastore:"__key__"`
+	Name     string
+	Created  time.Time
+	Day      civil.Date
+	Loc      GeoPoint
+	Price    Decimal
+	IP       net.IP   `datastore:",text"`
+	ID       [16]byte `datastore:",uuid"`
+	Labels   map[string]string
+	Inner    validInner  `datastore:",noindex,nocascade"`
+	Flat     []validFlat `datastore:",flatten"`
+	Any      interface{}
+	Skipped  chan int `datastore:"-"`
+	unexport complex128
+	validInner
+}
+
+type conflictA struct{ X, Y int }
+
+type conflictB struct {
+	X int
+	Y int `datastore:"Y"`
+}
+
+type invalidEntity struct {
+	Count   uint
+	Tags    [][]string
+	Nums    [3]int
+	ByID    map[int]string
+	Fn      func()
+	Bad     int `datastore:",bogus"`
+	Name    string
+	Other   string                `datastore:"Name"`
+	Text    int                   `datastore:",text"`
+	UUID    []byte                `datastore:",uuid"`
+	Flat    string                `datastore:",flatten"`
+	Cascade validInner            `datastore:",nocascade"`
+	Map     map[string]validInner `datastore:",flatten"`
+	Nested  []struct {
+		Inner []validInner
+	} `datastore:",flatten"`
+	Ptr *uint8
+	conflictA
+	conflictB
+}
+
+func TestValidateEntityType(t *testing.T) {
+	for _, typ := range []reflect.Type{
+		reflect.TypeOf(validEntity{}),
+		reflect.TypeOf(&validEntity{}),
+		reflect.TypeOf(Gopher{}),
+	} {
+		if err := ValidateEntityType(typ); err != nil {
+			t.Errorf("%v: %v", typ, err)
+		}
+	}
+
+	if err := ValidateEntityType(reflect.TypeOf(3)); err == nil {
+		t.Error("int: got nil, want error")
+	}
+
+	err := ValidateEntityType(reflect.TypeOf(invalidEntity{}))
+	me, ok := err.(MultiError)
+	if !ok {
+		t.Fatalf("got %v, want a MultiError", err)
+	}
+	var got []string
+	for _, e := range me {
+		got = append(got, strings.TrimPrefix(e.Error(), "datastore: field "))
+	}
+	want := []string{
+		`Bad: datastore: struct tag has invalid option: "bogus"`,
+		`ByID: unsupported type map[int]string: map keys must be strings`,
+		`Cascade: option "nocascade" requires "noindex"`,
+		`Count: unsupported type uint`,
+		`Flat: option "flatten" does not apply to string`,
+		`Fn: unsupported type func()`,
+		`Map: map fields cannot be flattened`,
+		`Name: property name "Name" is also used by field Other, so it is not saved or loaded`,
+		`Nested.Inner: flattening nested structs leads to a slice of slices`,
+		`Nums: unsupported type [3]int: only arrays of bytes are supported`,
+		`Ptr: unsupported pointer type *uint8`,
+		`Tags: unsupported type [][]string: slices of slices are not supported`,
+		`Text: option "text" requires int to implement encoding.TextMarshaler and encoding.TextUnmarshaler`,
+		`UUID: option "uuid" requires an array of 16 bytes, not []uint8`,
+		`conflictA.X: property name "X" conflicts with field conflictB.X, so neither is saved or loaded`,
+		`conflictA.Y: property name "Y" is also used by field conflictB.Y, so it is not saved or loaded`,
+	}
+	if diff := testutil.Diff(got, want); diff != "" {
+		t.Errorf("(-got +want)\n%s", diff)
+	}
+}
@@ -63,6 +63,10 @@ type Entry struct {
 	Req   *http.Request // if error is associated with a request.
 	User  string        // an identifier for the user affected by the error
 
+	// Status is the HTTP status code of the response to Req, if known.
+	// It is ignored if Req is nil.
+	Status int
+
 	// Stack specifies the stacktrace and call sequence correlated with
 	// the error. Stack's content must match the format specified by
 	// https://cloud.google.com/error-reporting/reference/rest/v1beta1/projects.events/report#ReportedErrorEvent.message
@@ -188,6 +192,8 @@ func (c *Client) newRequest(e Entry) *pb.ReportErrorEventRequest {
 				UserAgent: r.UserAgent(),
 				Referrer:  r.Referer(),
 				RemoteIp:  r.RemoteAddr,
+
+				ResponseStatusCode: int32(e.Status),
 			},
 		}
 	}
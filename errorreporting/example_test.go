@@ -18,8 +18,10 @@ import (
 	"context"
 	"errors"
 	"log"
+	"net/http"
 
 	"cloud.google.com/go/errorreporting"
+	"google.golang.org/grpc"
 )
 
 func Example() {
@@ -47,6 +49,40 @@ func Example() {
 	}
 }
 
+func ExampleClient_HTTPHandler() {
+	ctx := context.Background()
+	ec, err := errorreporting.NewClient(ctx, "my-gcp-project", errorreporting.Config{})
+	if err != nil {
+		// TODO: handle error
+	}
+	defer ec.Close()
+
+	// Report the requests that panic or fail with a 5xx status, and
+	// recover from the panics.
+	var handler http.Handler // TODO: the handler of your server.
+	http.ListenAndServe(":8080", ec.HTTPHandler(handler, &errorreporting.MiddlewareConfig{
+		// Report a tenth of the errors.
+		SampleRate: 0.1,
+	}))
+}
+
+func ExampleClient_UnaryServerInterceptor() {
+	ctx := context.Background()
+	ec, err := errorreporting.NewClient(ctx, "my-gcp-project", errorreporting.Config{})
+	if err != nil {
+		// TODO: handle error
+	}
+	defer ec.Close()
+
+	// Report the calls that panic or fail with an Unknown, Internal or
+	// DataLoss code, and recover from the panics.
+	srv := grpc.NewServer(
+		grpc.UnaryInterceptor(ec.UnaryServerInterceptor(nil)),
+		grpc.StreamInterceptor(ec.StreamServerInterceptor(nil)),
+	)
+	_ = srv // TODO: register services and serve.
+}
+
 func doSomething() error {
 	return errors.New("something went wrong")
 }
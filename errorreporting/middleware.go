@@ -0,0 +1,241 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package errorreporting
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"math/rand"
+	"net"
+	"net/http"
+	"runtime/debug"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// MiddlewareConfig configures the middleware returned by HTTPHandler,
+// UnaryServerInterceptor and StreamServerInterceptor. The zero value of each
+// field selects its default.
+type MiddlewareConfig struct {
+	// SampleRate is the fraction of the errors and panics that are reported,
+	// between 0 and 1. The default, 0, reports all of them.
+	SampleRate float64
+
+	// ReportStatus reports whether an HTTP response with the given status
+	// code should be reported as an error. By default, responses with 5xx
+	// codes are reported.
+	ReportStatus func(status int) bool
+
+	// ReportCode reports whether a gRPC call that failed with the given code
+	// should be reported as an error. By default, calls that failed with
+	// Unknown, Internal or DataLoss are reported.
+	ReportCode func(code codes.Code) bool
+
+	// HTTPUser, if not nil, returns an identifier for the user who made an
+	// HTTP request, to include in its reports.
+	HTTPUser func(r *http.Request) string
+
+	// GRPCUser, if not nil, returns an identifier for the user who made a gRPC
+	// call, given the context of the call, to include in its reports.
+	GRPCUser func(ctx context.Context) string
+}
+
+func (mc *MiddlewareConfig) sampled() bool {
+	return mc.SampleRate <= 0 || rand.Float64() < mc.SampleRate
+}
+
+func (mc *MiddlewareConfig) reportStatus(status int) bool {
+	if mc.ReportStatus != nil {
+		return mc.ReportStatus(status)
+	}
+	return status >= 500
+}
+
+func (mc *MiddlewareConfig) reportCode(code codes.Code) bool {
+	if mc.ReportCode != nil {
+		return mc.ReportCode(code)
+	}
+	switch code {
+	case codes.Unknown, codes.Internal, codes.DataLoss:
+		return true
+	}
+	return false
+}
+
+// HTTPHandler returns an http.Handler that calls h, and reports the requests
+// for which h panics or responds with an error status, as determined by
+// config. config may be nil to use the defaults.
+//
+// If h panics, the panic is recovered and reported with its stack trace, and
+// a 500 Internal Server Error response is sent if h did not send a response
+// header yet. Panics with http.ErrAbortHandler are not recovered.
+func (c *Client) HTTPHandler(h http.Handler, config *MiddlewareConfig) http.Handler {
+	if config == nil {
+		config = &MiddlewareConfig{}
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		sw := &statusWriter{ResponseWriter: w}
+		defer func() {
+			if x := recover(); x != nil {
+				if x == http.ErrAbortHandler {
+					panic(x)
+				}
+				if !sw.wroteHeader {
+					http.Error(sw, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+				}
+				if config.sampled() {
+					c.Report(Entry{
+						Error:  fmt.Errorf("panic: %v", x),
+						Req:    r,
+						User:   httpUser(config, r),
+						Status: sw.status,
+						Stack:  debug.Stack(),
+					})
+				}
+				return
+			}
+			if sw.hijacked {
+				return
+			}
+			if sw.status == 0 {
+				sw.status = http.StatusOK
+			}
+			if config.reportStatus(sw.status) && config.sampled() {
+				c.Report(Entry{
+					Error:  fmt.Errorf("%s %s: %d %s", r.Method, r.URL.Path, sw.status, http.StatusText(sw.status)),
+					Req:    r,
+					User:   httpUser(config, r),
+					Status: sw.status,
+				})
+			}
+		}()
+		h.ServeHTTP(sw, r)
+	})
+}
+
+func httpUser(config *MiddlewareConfig, r *http.Request) string {
+	if config.HTTPUser == nil {
+		return ""
+	}
+	return config.HTTPUser(r)
+}
+
+// statusWriter records the status code of a response.
+type statusWriter struct {
+	http.ResponseWriter
+	status      int
+	wroteHeader bool
+	hijacked    bool
+}
+
+func (w *statusWriter) WriteHeader(status int) {
+	if !w.wroteHeader {
+		w.status = status
+		w.wroteHeader = true
+	}
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *statusWriter) Write(b []byte) (int, error) {
+	if !w.wroteHeader {
+		w.WriteHeader(http.StatusOK)
+	}
+	return w.ResponseWriter.Write(b)
+}
+
+// Flush implements http.Flusher, if the underlying ResponseWriter does.
+func (w *statusWriter) Flush() {
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		if !w.wroteHeader {
+			w.WriteHeader(http.StatusOK)
+		}
+		f.Flush()
+	}
+}
+
+// Hijack implements http.Hijacker, if the underlying ResponseWriter does.
+// The status of a hijacked connection is not reported.
+func (w *statusWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	h, ok := w.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, fmt.Errorf("errorreporting: %T does not implement http.Hijacker", w.ResponseWriter)
+	}
+	conn, rw, err := h.Hijack()
+	if err == nil {
+		w.hijacked = true
+	}
+	return conn, rw, err
+}
+
+// Unwrap returns the underlying ResponseWriter, for http.ResponseController.
+func (w *statusWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// UnaryServerInterceptor returns a gRPC interceptor that reports the unary
+// calls that panic or fail with an error code, as determined by config.
+// config may be nil to use the defaults.
+//
+// If a call panics, the panic is recovered and reported with its stack trace,
+// and the call fails with code Internal.
+func (c *Client) UnaryServerInterceptor(config *MiddlewareConfig) grpc.UnaryServerInterceptor {
+	if config == nil {
+		config = &MiddlewareConfig{}
+	}
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
+		defer c.reportGRPC(ctx, config, info.FullMethod, &err)
+		return handler(ctx, req)
+	}
+}
+
+// StreamServerInterceptor returns a gRPC interceptor that reports the
+// streaming calls that panic or fail with an error code, as determined by
+// config. config may be nil to use the defaults.
+//
+// If a call panics, the panic is recovered and reported with its stack trace,
+// and the call fails with code Internal.
+func (c *Client) StreamServerInterceptor(config *MiddlewareConfig) grpc.StreamServerInterceptor {
+	if config == nil {
+		config = &MiddlewareConfig{}
+	}
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+		defer c.reportGRPC(ss.Context(), config, info.FullMethod, &err)
+		return handler(srv, ss)
+	}
+}
+
+// reportGRPC reports the result of a gRPC call, recovering a panic. It must
+// be deferred, with a pointer to the error returned by the call.
+func (c *Client) reportGRPC(ctx context.Context, config *MiddlewareConfig, method string, errp *error) {
+	var e Entry
+	if x := recover(); x != nil {
+		*errp = status.Error(codes.Internal, "internal error")
+		e = Entry{Error: fmt.Errorf("%s: panic: %v", method, x), Stack: debug.Stack()}
+	} else if *errp != nil && config.reportCode(status.Code(*errp)) {
+		e = Entry{Error: fmt.Errorf("%s: %w", method, *errp)}
+	} else {
+		return
+	}
+	if !config.sampled() {
+		return
+	}
+	if config.GRPCUser != nil {
+		e.User = config.GRPCUser(ctx)
+	}
+	c.Report(e)
+}
@@ -0,0 +1,291 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package errorreporting
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	pb "cloud.google.com/go/errorreporting/apiv1beta1/errorreportingpb"
+	"cloud.google.com/go/internal/testutil"
+	gax "github.com/googleapis/gax-go/v2"
+	"google.golang.org/api/option"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// recordingClient records all the error reports.
+type recordingClient struct {
+	mu   sync.Mutex
+	reqs []*pb.ReportErrorEventRequest
+}
+
+func (c *recordingClient) ReportErrorEvent(_ context.Context, req *pb.ReportErrorEventRequest, _ ...gax.CallOption) (*pb.ReportErrorEventResponse, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.reqs = append(c.reqs, req)
+	return &pb.ReportErrorEventResponse{}, nil
+}
+
+func (c *recordingClient) Close() error {
+	return nil
+}
+
+// newRecordingClient returns a Client whose reports are recorded by the
+// returned function, which flushes the Client.
+func newRecordingClient(t *testing.T) (*Client, func() []*pb.ReportErrorEventRequest) {
+	rc := &recordingClient{}
+	newClient = func(ctx context.Context, opts ...option.ClientOption) (client, error) {
+		return rc, nil
+	}
+	c, err := NewClient(context.Background(), testutil.ProjID(), defaultConfig)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return c, func() []*pb.ReportErrorEventRequest {
+		c.Flush()
+		rc.mu.Lock()
+		defer rc.mu.Unlock()
+		reqs := rc.reqs
+		rc.reqs = nil
+		return reqs
+	}
+}
+
+func TestHTTPHandler(t *testing.T) {
+	c, reports := newRecordingClient(t)
+	mux := http.NewServeMux()
+	mux.HandleFunc("/ok", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("ok"))
+	})
+	mux.HandleFunc("/notfound", func(w http.ResponseWriter, r *http.Request) {
+		http.NotFound(w, r)
+	})
+	mux.HandleFunc("/unavailable", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+	mux.HandleFunc("/panic", func(w http.ResponseWriter, r *http.Request) {
+		panic("boom")
+	})
+	h := c.HTTPHandler(mux, &MiddlewareConfig{
+		HTTPUser: func(r *http.Request) string { return r.Header.Get("X-User") },
+	})
+
+	for _, test := range []struct {
+		path       string
+		wantStatus int
+		wantReport string // A substring of the report message, or "" for no report.
+	}{
+		{"/ok", http.StatusOK, ""},
+		{"/notfound", http.StatusNotFound, ""},
+		{"/unavailable", http.StatusServiceUnavailable, "GET /unavailable: 503 Service Unavailable"},
+		{"/panic", http.StatusInternalServerError, "panic: boom"},
+	} {
+		req := httptest.NewRequest("GET", test.path, nil)
+		req.Header.Set("X-User", "alice")
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		if rec.Code != test.wantStatus {
+			t.Errorf("%s: got status %d, want %d", test.path, rec.Code, test.wantStatus)
+		}
+		got := reports()
+		if test.wantReport == "" {
+			if len(got) != 0 {
+				t.Errorf("%s: got %d reports, want none", test.path, len(got))
+			}
+			continue
+		}
+		if len(got) != 1 {
+			t.Fatalf("%s: got %d reports, want 1", test.path, len(got))
+		}
+		ev := got[0].Event
+		if !strings.Contains(ev.Message, test.wantReport) {
+			t.Errorf("%s: got message %q, want it to contain %q", test.path, ev.Message, test.wantReport)
+		}
+		if got := ev.Context.GetUser(); got != "alice" {
+			t.Errorf("%s: got user %q, want %q", test.path, got, "alice")
+		}
+		if got := ev.Context.GetHttpRequest().GetResponseStatusCode(); got != int32(test.wantStatus) {
+			t.Errorf("%s: got reported status %d, want %d", test.path, got, test.wantStatus)
+		}
+	}
+}
+
+func TestHTTPHandlerAbort(t *testing.T) {
+	c, reports := newRecordingClient(t)
+	h := c.HTTPHandler(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+		panic(http.ErrAbortHandler)
+	}), nil)
+	func() {
+		defer func() {
+			if x := recover(); x != http.ErrAbortHandler {
+				t.Errorf("got panic %v, want %v", x, http.ErrAbortHandler)
+			}
+		}()
+		h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/", nil))
+	}()
+	if got := reports(); len(got) != 0 {
+		t.Errorf("got %d reports, want none", len(got))
+	}
+}
+
+func TestHTTPHandlerHijack(t *testing.T) {
+	c, reports := newRecordingClient(t)
+	h := c.HTTPHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, ok := w.(interface{ Unwrap() http.ResponseWriter }); !ok {
+			t.Error("ResponseWriter does not implement Unwrap")
+		}
+		conn, rw, err := w.(http.Hijacker).Hijack()
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		defer conn.Close()
+		rw.WriteString("HTTP/1.1 101 Switching Protocols\r\nUpgrade: test\r\nConnection: Upgrade\r\n\r\nhello")
+		rw.Flush()
+	}), &MiddlewareConfig{ReportStatus: func(int) bool { return true }})
+	done := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		defer close(done)
+		h.ServeHTTP(w, r)
+	}))
+	defer srv.Close()
+
+	resp, err := http.Get(srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusSwitchingProtocols {
+		t.Errorf("got status %d, want %d", resp.StatusCode, http.StatusSwitchingProtocols)
+	}
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(body) != "hello" {
+		t.Errorf("got body %q, want hello", body)
+	}
+	<-done
+	if got := reports(); len(got) != 0 {
+		t.Errorf("got %d reports, want none", len(got))
+	}
+}
+
+func TestMiddlewareSampling(t *testing.T) {
+	c, reports := newRecordingClient(t)
+	h := c.HTTPHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}), &MiddlewareConfig{SampleRate: 1e-12})
+	for i := 0; i < 10; i++ {
+		h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/", nil))
+	}
+	if got := reports(); len(got) != 0 {
+		t.Errorf("got %d reports, want none", len(got))
+	}
+}
+
+func TestUnaryServerInterceptor(t *testing.T) {
+	c, reports := newRecordingClient(t)
+	interceptor := c.UnaryServerInterceptor(&MiddlewareConfig{
+		GRPCUser: func(context.Context) string { return "bob" },
+	})
+	info := &grpc.UnaryServerInfo{FullMethod: "/pkg.Service/Method"}
+
+	for _, test := range []struct {
+		desc       string
+		handler    grpc.UnaryHandler
+		wantCode   codes.Code
+		wantReport string
+	}{
+		{
+			desc:     "success",
+			handler:  func(context.Context, interface{}) (interface{}, error) { return "resp", nil },
+			wantCode: codes.OK,
+		},
+		{
+			desc:     "client error",
+			handler:  func(context.Context, interface{}) (interface{}, error) { return nil, status.Error(codes.NotFound, "") },
+			wantCode: codes.NotFound,
+		},
+		{
+			desc:       "server error",
+			handler:    func(context.Context, interface{}) (interface{}, error) { return nil, errors.New("oops") },
+			wantCode:   codes.Unknown,
+			wantReport: "/pkg.Service/Method: oops",
+		},
+		{
+			desc:       "panic",
+			handler:    func(context.Context, interface{}) (interface{}, error) { panic("boom") },
+			wantCode:   codes.Internal,
+			wantReport: "/pkg.Service/Method: panic: boom",
+		},
+	} {
+		_, err := interceptor(context.Background(), "req", info, test.handler)
+		if got := status.Code(err); got != test.wantCode {
+			t.Errorf("%s: got code %v, want %v", test.desc, got, test.wantCode)
+		}
+		got := reports()
+		if test.wantReport == "" {
+			if len(got) != 0 {
+				t.Errorf("%s: got %d reports, want none", test.desc, len(got))
+			}
+			continue
+		}
+		if len(got) != 1 {
+			t.Fatalf("%s: got %d reports, want 1", test.desc, len(got))
+		}
+		if msg := got[0].Event.Message; !strings.Contains(msg, test.wantReport) {
+			t.Errorf("%s: got message %q, want it to contain %q", test.desc, msg, test.wantReport)
+		}
+		if user := got[0].Event.Context.GetUser(); user != "bob" {
+			t.Errorf("%s: got user %q, want %q", test.desc, user, "bob")
+		}
+	}
+}
+
+// fakeServerStream is a grpc.ServerStream with a context.
+type fakeServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *fakeServerStream) Context() context.Context {
+	return s.ctx
+}
+
+func TestStreamServerInterceptor(t *testing.T) {
+	c, reports := newRecordingClient(t)
+	interceptor := c.StreamServerInterceptor(nil)
+	err := interceptor(nil, &fakeServerStream{ctx: context.Background()}, &grpc.StreamServerInfo{FullMethod: "/pkg.Service/Stream"},
+		func(interface{}, grpc.ServerStream) error { panic("boom") })
+	if got := status.Code(err); got != codes.Internal {
+		t.Errorf("got code %v, want %v", got, codes.Internal)
+	}
+	got := reports()
+	if len(got) != 1 {
+		t.Fatalf("got %d reports, want 1", len(got))
+	}
+	if msg := got[0].Event.Message; !strings.Contains(msg, "/pkg.Service/Stream: panic: boom") {
+		t.Errorf("got message %q", msg)
+	}
+}
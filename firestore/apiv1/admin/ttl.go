@@ -0,0 +1,129 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apiv1
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	adminpb "cloud.google.com/go/firestore/apiv1/admin/adminpb"
+	gax "github.com/googleapis/gax-go/v2"
+	"google.golang.org/api/iterator"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+var validDBPattern = regexp.MustCompile("^projects/[^/]+/databases/[^/]+$")
+
+// TTLPolicy is the time-to-live policy of a field. Firestore deletes a
+// document of the collection group some time after the timestamp stored in the
+// field has passed.
+type TTLPolicy struct {
+	// CollectionGroup is the ID of the collection group the policy applies to.
+	CollectionGroup string
+
+	// Field is the path of the field holding the expiration time, as it
+	// appears in the field's resource name.
+	Field string
+
+	// State is the state of the policy. A policy is CREATING until existing
+	// documents have been processed, after which it becomes ACTIVE.
+	State adminpb.Field_TtlConfig_State
+}
+
+// CreateTTLPolicy enables a TTL policy on the given field of a collection
+// group, so that documents whose field holds a timestamp in the past are
+// deleted. Call Wait on the returned operation to block until the policy has
+// been applied to all existing documents.
+//
+// database must have the form projects/<project>/databases/<database>.
+func (c *FirestoreAdminClient) CreateTTLPolicy(ctx context.Context, database, collectionGroup, field string, opts ...gax.CallOption) (*UpdateFieldOperation, error) {
+	return c.updateTTLConfig(ctx, database, collectionGroup, field, &adminpb.Field_TtlConfig{}, opts...)
+}
+
+// DeleteTTLPolicy disables the TTL policy on the given field of a collection
+// group. Documents are no longer deleted based on the field's value.
+//
+// database must have the form projects/<project>/databases/<database>.
+func (c *FirestoreAdminClient) DeleteTTLPolicy(ctx context.Context, database, collectionGroup, field string, opts ...gax.CallOption) (*UpdateFieldOperation, error) {
+	return c.updateTTLConfig(ctx, database, collectionGroup, field, nil, opts...)
+}
+
+func (c *FirestoreAdminClient) updateTTLConfig(ctx context.Context, database, collectionGroup, field string, cfg *adminpb.Field_TtlConfig, opts ...gax.CallOption) (*UpdateFieldOperation, error) {
+	if !validDBPattern.MatchString(database) {
+		return nil, fmt.Errorf("database name %q should conform to pattern %q", database, validDBPattern)
+	}
+	if collectionGroup == "" || field == "" {
+		return nil, fmt.Errorf("collection group and field must not be empty")
+	}
+	req := &adminpb.UpdateFieldRequest{
+		Field: &adminpb.Field{
+			Name:      fmt.Sprintf("%s/collectionGroups/%s/fields/%s", database, collectionGroup, field),
+			TtlConfig: cfg,
+		},
+		UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"ttl_config"}},
+	}
+	return c.UpdateField(ctx, req, opts...)
+}
+
+// ListTTLPolicies returns the TTL policies of all the collection groups in the
+// database.
+//
+// database must have the form projects/<project>/databases/<database>.
+func (c *FirestoreAdminClient) ListTTLPolicies(ctx context.Context, database string, opts ...gax.CallOption) ([]*TTLPolicy, error) {
+	if !validDBPattern.MatchString(database) {
+		return nil, fmt.Errorf("database name %q should conform to pattern %q", database, validDBPattern)
+	}
+	it := c.ListFields(ctx, &adminpb.ListFieldsRequest{
+		Parent: database + "/collectionGroups/-",
+		Filter: "ttlConfig:*",
+	}, opts...)
+	var policies []*TTLPolicy
+	for {
+		f, err := it.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if f.GetTtlConfig() == nil {
+			continue
+		}
+		p, err := ttlPolicyFromField(f)
+		if err != nil {
+			return nil, err
+		}
+		policies = append(policies, p)
+	}
+	return policies, nil
+}
+
+func ttlPolicyFromField(f *adminpb.Field) (*TTLPolicy, error) {
+	_, rest, ok := strings.Cut(f.Name, "/collectionGroups/")
+	if !ok {
+		return nil, fmt.Errorf("malformed field name %q", f.Name)
+	}
+	cg, field, ok := strings.Cut(rest, "/fields/")
+	if !ok {
+		return nil, fmt.Errorf("malformed field name %q", f.Name)
+	}
+	return &TTLPolicy{
+		CollectionGroup: cg,
+		Field:           field,
+		State:           f.TtlConfig.State,
+	}, nil
+}
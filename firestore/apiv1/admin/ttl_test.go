@@ -0,0 +1,174 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apiv1
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	adminpb "cloud.google.com/go/firestore/apiv1/admin/adminpb"
+	longrunningpb "cloud.google.com/go/longrunning/autogen/longrunningpb"
+	"google.golang.org/api/option"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+type mockTTLServer struct {
+	adminpb.FirestoreAdminServer
+
+	reqs   []proto.Message
+	fields [][]*adminpb.Field // pages of ListFields results
+}
+
+func (s *mockTTLServer) UpdateField(_ context.Context, req *adminpb.UpdateFieldRequest) (*longrunningpb.Operation, error) {
+	s.reqs = append(s.reqs, req)
+	return &longrunningpb.Operation{Name: "op"}, nil
+}
+
+func (s *mockTTLServer) ListFields(_ context.Context, req *adminpb.ListFieldsRequest) (*adminpb.ListFieldsResponse, error) {
+	s.reqs = append(s.reqs, req)
+	page := 0
+	if req.PageToken != "" {
+		page = 1
+	}
+	res := &adminpb.ListFieldsResponse{Fields: s.fields[page]}
+	if page+1 < len(s.fields) {
+		res.NextPageToken = "next"
+	}
+	return res, nil
+}
+
+func newMockTTLClient(t *testing.T, srv *mockTTLServer) *FirestoreAdminClient {
+	t.Helper()
+	l, err := net.Listen("tcp", "localhost:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	gsrv := grpc.NewServer()
+	adminpb.RegisterFirestoreAdminServer(gsrv, srv)
+	go gsrv.Serve(l)
+	t.Cleanup(gsrv.Stop)
+
+	conn, err := grpc.Dial(l.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	c, err := NewFirestoreAdminClient(context.Background(), option.WithGRPCConn(conn))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { c.Close() })
+	return c
+}
+
+func TestCreateDeleteTTLPolicy(t *testing.T) {
+	ctx := context.Background()
+	const db = "projects/p/databases/(default)"
+	srv := &mockTTLServer{}
+	c := newMockTTLClient(t, srv)
+
+	op, err := c.CreateTTLPolicy(ctx, db, "sessions", "expireAt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := op.Name(), "op"; got != want {
+		t.Errorf("got operation %q, want %q", got, want)
+	}
+	if _, err := c.DeleteTTLPolicy(ctx, db, "sessions", "expireAt"); err != nil {
+		t.Fatal(err)
+	}
+	mask := &fieldmaskpb.FieldMask{Paths: []string{"ttl_config"}}
+	want := []proto.Message{
+		&adminpb.UpdateFieldRequest{
+			Field: &adminpb.Field{
+				Name:      db + "/collectionGroups/sessions/fields/expireAt",
+				TtlConfig: &adminpb.Field_TtlConfig{},
+			},
+			UpdateMask: mask,
+		},
+		&adminpb.UpdateFieldRequest{
+			Field:      &adminpb.Field{Name: db + "/collectionGroups/sessions/fields/expireAt"},
+			UpdateMask: mask,
+		},
+	}
+	if len(srv.reqs) != len(want) {
+		t.Fatalf("got %d requests, want %d", len(srv.reqs), len(want))
+	}
+	for i, req := range srv.reqs {
+		if !proto.Equal(req, want[i]) {
+			t.Errorf("#%d: got %v, want %v", i, req, want[i])
+		}
+	}
+
+	for _, test := range []struct {
+		db, cg, field string
+	}{
+		{"projects/p", "sessions", "expireAt"},
+		{db, "", "expireAt"},
+		{db, "sessions", ""},
+	} {
+		if _, err := c.CreateTTLPolicy(ctx, test.db, test.cg, test.field); err == nil {
+			t.Errorf("%q, %q, %q: got nil, want error", test.db, test.cg, test.field)
+		}
+	}
+}
+
+func TestListTTLPolicies(t *testing.T) {
+	ctx := context.Background()
+	const db = "projects/p/databases/(default)"
+	srv := &mockTTLServer{
+		fields: [][]*adminpb.Field{
+			{
+				{
+					Name:      db + "/collectionGroups/sessions/fields/expireAt",
+					TtlConfig: &adminpb.Field_TtlConfig{State: adminpb.Field_TtlConfig_ACTIVE},
+				},
+				// Fields with only index overrides have no TTL policy.
+				{Name: db + "/collectionGroups/sessions/fields/user"},
+			},
+			{
+				{
+					Name:      db + "/collectionGroups/logs/fields/`expire.at`",
+					TtlConfig: &adminpb.Field_TtlConfig{State: adminpb.Field_TtlConfig_CREATING},
+				},
+			},
+		},
+	}
+	c := newMockTTLClient(t, srv)
+
+	got, err := c.ListTTLPolicies(ctx, db)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []*TTLPolicy{
+		{CollectionGroup: "sessions", Field: "expireAt", State: adminpb.Field_TtlConfig_ACTIVE},
+		{CollectionGroup: "logs", Field: "`expire.at`", State: adminpb.Field_TtlConfig_CREATING},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("got %d policies, want %d", len(got), len(want))
+	}
+	for i := range got {
+		if *got[i] != *want[i] {
+			t.Errorf("#%d: got %+v, want %+v", i, got[i], want[i])
+		}
+	}
+	wantReq := &adminpb.ListFieldsRequest{Parent: db + "/collectionGroups/-", Filter: "ttlConfig:*"}
+	if !proto.Equal(srv.reqs[0], wantReq) {
+		t.Errorf("got %v, want %v", srv.reqs[0], wantReq)
+	}
+}
@@ -1,4 +1,4 @@
-// Copyright 2023 Google LLC
+// Copyright 2024 Google LLC
 //
 // Licensed under the Apache License, Version 2.0 (the "License");
 // you may not use this file except in compliance with the License.
@@ -14,8 +14,8 @@
 
 // Code generated by protoc-gen-go. DO NOT EDIT.
 // versions:
-// 	protoc-gen-go v1.31.0
-// 	protoc        v4.23.2
+// 	protoc-gen-go v1.34.2
+// 	protoc        v4.25.3
 // source: google/firestore/v1/aggregation_result.proto
 
 package firestorepb
@@ -145,7 +145,7 @@ func file_google_firestore_v1_aggregation_result_proto_rawDescGZIP() []byte {
 }
 
 var file_google_firestore_v1_aggregation_result_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
-var file_google_firestore_v1_aggregation_result_proto_goTypes = []interface{}{
+var file_google_firestore_v1_aggregation_result_proto_goTypes = []any{
 	(*AggregationResult)(nil), // 0: google.firestore.v1.AggregationResult
 	nil,                       // 1: google.firestore.v1.AggregationResult.AggregateFieldsEntry
 	(*Value)(nil),             // 2: google.firestore.v1.Value
@@ -167,7 +167,7 @@ func file_google_firestore_v1_aggregation_result_proto_init() {
 	}
 	file_google_firestore_v1_document_proto_init()
 	if !protoimpl.UnsafeEnabled {
-		file_google_firestore_v1_aggregation_result_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+		file_google_firestore_v1_aggregation_result_proto_msgTypes[0].Exporter = func(v any, i int) any {
 			switch v := v.(*AggregationResult); i {
 			case 0:
 				return &v.state
@@ -1,4 +1,4 @@
-// Copyright 2023 Google LLC
+// Copyright 2024 Google LLC
 //
 // Licensed under the Apache License, Version 2.0 (the "License");
 // you may not use this file except in compliance with the License.
@@ -14,8 +14,8 @@
 
 // Code generated by protoc-gen-go. DO NOT EDIT.
 // versions:
-// 	protoc-gen-go v1.31.0
-// 	protoc        v4.23.2
+// 	protoc-gen-go v1.34.2
+// 	protoc        v4.25.3
 // source: google/firestore/v1/bloom_filter.proto
 
 package firestorepb
@@ -226,7 +226,7 @@ func file_google_firestore_v1_bloom_filter_proto_rawDescGZIP() []byte {
 }
 
 var file_google_firestore_v1_bloom_filter_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
-var file_google_firestore_v1_bloom_filter_proto_goTypes = []interface{}{
+var file_google_firestore_v1_bloom_filter_proto_goTypes = []any{
 	(*BitSequence)(nil), // 0: google.firestore.v1.BitSequence
 	(*BloomFilter)(nil), // 1: google.firestore.v1.BloomFilter
 }
@@ -245,7 +245,7 @@ func file_google_firestore_v1_bloom_filter_proto_init() {
 		return
 	}
 	if !protoimpl.UnsafeEnabled {
-		file_google_firestore_v1_bloom_filter_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+		file_google_firestore_v1_bloom_filter_proto_msgTypes[0].Exporter = func(v any, i int) any {
 			switch v := v.(*BitSequence); i {
 			case 0:
 				return &v.state
@@ -257,7 +257,7 @@ func file_google_firestore_v1_bloom_filter_proto_init() {
 				return nil
 			}
 		}
-		file_google_firestore_v1_bloom_filter_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+		file_google_firestore_v1_bloom_filter_proto_msgTypes[1].Exporter = func(v any, i int) any {
 			switch v := v.(*BloomFilter); i {
 			case 0:
 				return &v.state
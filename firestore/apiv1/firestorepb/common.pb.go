@@ -1,4 +1,4 @@
-// Copyright 2023 Google LLC
+// Copyright 2024 Google LLC
 //
 // Licensed under the Apache License, Version 2.0 (the "License");
 // you may not use this file except in compliance with the License.
@@ -14,8 +14,8 @@
 
 // Code generated by protoc-gen-go. DO NOT EDIT.
 // versions:
-// 	protoc-gen-go v1.31.0
-// 	protoc        v4.23.2
+// 	protoc-gen-go v1.34.2
+// 	protoc        v4.25.3
 // source: google/firestore/v1/common.proto
 
 package firestorepb
@@ -101,6 +101,7 @@ type Precondition struct {
 	// The type of precondition.
 	//
 	// Types that are assignable to ConditionType:
+	//
 	//	*Precondition_Exists
 	//	*Precondition_UpdateTime
 	ConditionType isPrecondition_ConditionType `protobuf_oneof:"condition_type"`
@@ -188,6 +189,7 @@ type TransactionOptions struct {
 	// The mode of the transaction.
 	//
 	// Types that are assignable to Mode:
+	//
 	//	*TransactionOptions_ReadOnly_
 	//	*TransactionOptions_ReadWrite_
 	Mode isTransactionOptions_Mode `protobuf_oneof:"mode"`
@@ -326,6 +328,7 @@ type TransactionOptions_ReadOnly struct {
 	// consistency.
 	//
 	// Types that are assignable to ConsistencySelector:
+	//
 	//	*TransactionOptions_ReadOnly_ReadTime
 	ConsistencySelector isTransactionOptions_ReadOnly_ConsistencySelector `protobuf_oneof:"consistency_selector"`
 }
@@ -460,7 +463,7 @@ func file_google_firestore_v1_common_proto_rawDescGZIP() []byte {
 }
 
 var file_google_firestore_v1_common_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
-var file_google_firestore_v1_common_proto_goTypes = []interface{}{
+var file_google_firestore_v1_common_proto_goTypes = []any{
 	(*DocumentMask)(nil),                 // 0: google.firestore.v1.DocumentMask
 	(*Precondition)(nil),                 // 1: google.firestore.v1.Precondition
 	(*TransactionOptions)(nil),           // 2: google.firestore.v1.TransactionOptions
@@ -486,7 +489,7 @@ func file_google_firestore_v1_common_proto_init() {
 		return
 	}
 	if !protoimpl.UnsafeEnabled {
-		file_google_firestore_v1_common_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+		file_google_firestore_v1_common_proto_msgTypes[0].Exporter = func(v any, i int) any {
 			switch v := v.(*DocumentMask); i {
 			case 0:
 				return &v.state
@@ -498,7 +501,7 @@ func file_google_firestore_v1_common_proto_init() {
 				return nil
 			}
 		}
-		file_google_firestore_v1_common_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+		file_google_firestore_v1_common_proto_msgTypes[1].Exporter = func(v any, i int) any {
 			switch v := v.(*Precondition); i {
 			case 0:
 				return &v.state
@@ -510,7 +513,7 @@ func file_google_firestore_v1_common_proto_init() {
 				return nil
 			}
 		}
-		file_google_firestore_v1_common_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+		file_google_firestore_v1_common_proto_msgTypes[2].Exporter = func(v any, i int) any {
 			switch v := v.(*TransactionOptions); i {
 			case 0:
 				return &v.state
@@ -522,7 +525,7 @@ func file_google_firestore_v1_common_proto_init() {
 				return nil
 			}
 		}
-		file_google_firestore_v1_common_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+		file_google_firestore_v1_common_proto_msgTypes[3].Exporter = func(v any, i int) any {
 			switch v := v.(*TransactionOptions_ReadWrite); i {
 			case 0:
 				return &v.state
@@ -534,7 +537,7 @@ func file_google_firestore_v1_common_proto_init() {
 				return nil
 			}
 		}
-		file_google_firestore_v1_common_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+		file_google_firestore_v1_common_proto_msgTypes[4].Exporter = func(v any, i int) any {
 			switch v := v.(*TransactionOptions_ReadOnly); i {
 			case 0:
 				return &v.state
@@ -547,15 +550,15 @@ func file_google_firestore_v1_common_proto_init() {
 			}
 		}
 	}
-	file_google_firestore_v1_common_proto_msgTypes[1].OneofWrappers = []interface{}{
+	file_google_firestore_v1_common_proto_msgTypes[1].OneofWrappers = []any{
 		(*Precondition_Exists)(nil),
 		(*Precondition_UpdateTime)(nil),
 	}
-	file_google_firestore_v1_common_proto_msgTypes[2].OneofWrappers = []interface{}{
+	file_google_firestore_v1_common_proto_msgTypes[2].OneofWrappers = []any{
 		(*TransactionOptions_ReadOnly_)(nil),
 		(*TransactionOptions_ReadWrite_)(nil),
 	}
-	file_google_firestore_v1_common_proto_msgTypes[4].OneofWrappers = []interface{}{
+	file_google_firestore_v1_common_proto_msgTypes[4].OneofWrappers = []any{
 		(*TransactionOptions_ReadOnly_ReadTime)(nil),
 	}
 	type x struct{}
@@ -1,4 +1,4 @@
-// Copyright 2023 Google LLC
+// Copyright 2024 Google LLC
 //
 // Licensed under the Apache License, Version 2.0 (the "License");
 // you may not use this file except in compliance with the License.
@@ -14,8 +14,8 @@
 
 // Code generated by protoc-gen-go. DO NOT EDIT.
 // versions:
-// 	protoc-gen-go v1.31.0
-// 	protoc        v4.23.2
+// 	protoc-gen-go v1.34.2
+// 	protoc        v4.25.3
 // source: google/firestore/v1/document.proto
 
 package firestorepb
@@ -53,26 +53,26 @@ type Document struct {
 	//
 	// The map keys represent field names.
 	//
-	// A simple field name contains only characters `a` to `z`, `A` to `Z`,
-	// `0` to `9`, or `_`, and must not start with `0` to `9`. For example,
-	// `foo_bar_17`.
-	//
 	// Field names matching the regular expression `__.*__` are reserved. Reserved
-	// field names are forbidden except in certain documented contexts. The map
-	// keys, represented as UTF-8, must not exceed 1,500 bytes and cannot be
+	// field names are forbidden except in certain documented contexts. The field
+	// names, represented as UTF-8, must not exceed 1,500 bytes and cannot be
 	// empty.
 	//
 	// Field paths may be used in other contexts to refer to structured fields
-	// defined here. For `map_value`, the field path is represented by the simple
-	// or quoted field names of the containing fields, delimited by `.`. For
-	// example, the structured field
-	// `"foo" : { map_value: { "x&y" : { string_value: "hello" }}}` would be
-	// represented by the field path `foo.x&y`.
+	// defined here. For `map_value`, the field path is represented by a
+	// dot-delimited (`.`) string of segments. Each segment is either a simple
+	// field name (defined below) or a quoted field name. For example, the
+	// structured field `"foo" : { map_value: { "x&y" : { string_value: "hello"
+	// }}}` would be represented by the field path “ foo.`x&y` “.
 	//
-	// Within a field path, a quoted field name starts and ends with `` ` `` and
-	// may contain any character. Some characters, including `` ` ``, must be
-	// escaped using a `\`. For example, `` `x&y` `` represents `x&y` and
-	// `` `bak\`tik` `` represents `` bak`tik ``.
+	// A simple field name contains only characters `a` to `z`, `A` to `Z`,
+	// `0` to `9`, or `_`, and must not start with `0` to `9`. For example,
+	// `foo_bar_17`.
+	//
+	// A quoted field name starts and ends with “ ` “ and
+	// may contain any character. Some characters, including “ ` “, must be
+	// escaped using a `\`. For example, “ `x&y` “ represents `x&y` and
+	// “ `bak\`tik` “ represents “ bak`tik “.
 	Fields map[string]*Value `protobuf:"bytes,2,rep,name=fields,proto3" json:"fields,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
 	// Output only. The time at which the document was created.
 	//
@@ -157,6 +157,7 @@ type Value struct {
 	// Must have a value set.
 	//
 	// Types that are assignable to ValueType:
+	//
 	//	*Value_NullValue
 	//	*Value_BooleanValue
 	//	*Value_IntegerValue
@@ -596,7 +597,7 @@ func file_google_firestore_v1_document_proto_rawDescGZIP() []byte {
 }
 
 var file_google_firestore_v1_document_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
-var file_google_firestore_v1_document_proto_goTypes = []interface{}{
+var file_google_firestore_v1_document_proto_goTypes = []any{
 	(*Document)(nil),              // 0: google.firestore.v1.Document
 	(*Value)(nil),                 // 1: google.firestore.v1.Value
 	(*ArrayValue)(nil),            // 2: google.firestore.v1.ArrayValue
@@ -633,7 +634,7 @@ func file_google_firestore_v1_document_proto_init() {
 		return
 	}
 	if !protoimpl.UnsafeEnabled {
-		file_google_firestore_v1_document_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+		file_google_firestore_v1_document_proto_msgTypes[0].Exporter = func(v any, i int) any {
 			switch v := v.(*Document); i {
 			case 0:
 				return &v.state
@@ -645,7 +646,7 @@ func file_google_firestore_v1_document_proto_init() {
 				return nil
 			}
 		}
-		file_google_firestore_v1_document_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+		file_google_firestore_v1_document_proto_msgTypes[1].Exporter = func(v any, i int) any {
 			switch v := v.(*Value); i {
 			case 0:
 				return &v.state
@@ -657,7 +658,7 @@ func file_google_firestore_v1_document_proto_init() {
 				return nil
 			}
 		}
-		file_google_firestore_v1_document_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+		file_google_firestore_v1_document_proto_msgTypes[2].Exporter = func(v any, i int) any {
 			switch v := v.(*ArrayValue); i {
 			case 0:
 				return &v.state
@@ -669,7 +670,7 @@ func file_google_firestore_v1_document_proto_init() {
 				return nil
 			}
 		}
-		file_google_firestore_v1_document_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+		file_google_firestore_v1_document_proto_msgTypes[3].Exporter = func(v any, i int) any {
 			switch v := v.(*MapValue); i {
 			case 0:
 				return &v.state
@@ -682,7 +683,7 @@ func file_google_firestore_v1_document_proto_init() {
 			}
 		}
 	}
-	file_google_firestore_v1_document_proto_msgTypes[1].OneofWrappers = []interface{}{
+	file_google_firestore_v1_document_proto_msgTypes[1].OneofWrappers = []any{
 		(*Value_NullValue)(nil),
 		(*Value_BooleanValue)(nil),
 		(*Value_IntegerValue)(nil),
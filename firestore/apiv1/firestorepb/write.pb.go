@@ -1,4 +1,4 @@
-// Copyright 2023 Google LLC
+// Copyright 2024 Google LLC
 //
 // Licensed under the Apache License, Version 2.0 (the "License");
 // you may not use this file except in compliance with the License.
@@ -14,8 +14,8 @@
 
 // Code generated by protoc-gen-go. DO NOT EDIT.
 // versions:
-// 	protoc-gen-go v1.31.0
-// 	protoc        v4.23.2
+// 	protoc-gen-go v1.34.2
+// 	protoc        v4.25.3
 // source: google/firestore/v1/write.proto
 
 package firestorepb
@@ -96,6 +96,7 @@ type Write struct {
 	// The operation to execute.
 	//
 	// Types that are assignable to Operation:
+	//
 	//	*Write_Update
 	//	*Write_Delete
 	//	*Write_Transform
@@ -685,6 +686,7 @@ type DocumentTransform_FieldTransform struct {
 	// The transformation to apply on the field.
 	//
 	// Types that are assignable to TransformType:
+	//
 	//	*DocumentTransform_FieldTransform_SetToServerValue
 	//	*DocumentTransform_FieldTransform_Increment
 	//	*DocumentTransform_FieldTransform_Maximum
@@ -1048,7 +1050,7 @@ func file_google_firestore_v1_write_proto_rawDescGZIP() []byte {
 
 var file_google_firestore_v1_write_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
 var file_google_firestore_v1_write_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
-var file_google_firestore_v1_write_proto_goTypes = []interface{}{
+var file_google_firestore_v1_write_proto_goTypes = []any{
 	(DocumentTransform_FieldTransform_ServerValue)(0), // 0: google.firestore.v1.DocumentTransform.FieldTransform.ServerValue
 	(*Write)(nil),                            // 1: google.firestore.v1.Write
 	(*DocumentTransform)(nil),                // 2: google.firestore.v1.DocumentTransform
@@ -1101,7 +1103,7 @@ func file_google_firestore_v1_write_proto_init() {
 	file_google_firestore_v1_common_proto_init()
 	file_google_firestore_v1_document_proto_init()
 	if !protoimpl.UnsafeEnabled {
-		file_google_firestore_v1_write_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+		file_google_firestore_v1_write_proto_msgTypes[0].Exporter = func(v any, i int) any {
 			switch v := v.(*Write); i {
 			case 0:
 				return &v.state
@@ -1113,7 +1115,7 @@ func file_google_firestore_v1_write_proto_init() {
 				return nil
 			}
 		}
-		file_google_firestore_v1_write_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+		file_google_firestore_v1_write_proto_msgTypes[1].Exporter = func(v any, i int) any {
 			switch v := v.(*DocumentTransform); i {
 			case 0:
 				return &v.state
@@ -1125,7 +1127,7 @@ func file_google_firestore_v1_write_proto_init() {
 				return nil
 			}
 		}
-		file_google_firestore_v1_write_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+		file_google_firestore_v1_write_proto_msgTypes[2].Exporter = func(v any, i int) any {
 			switch v := v.(*WriteResult); i {
 			case 0:
 				return &v.state
@@ -1137,7 +1139,7 @@ func file_google_firestore_v1_write_proto_init() {
 				return nil
 			}
 		}
-		file_google_firestore_v1_write_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+		file_google_firestore_v1_write_proto_msgTypes[3].Exporter = func(v any, i int) any {
 			switch v := v.(*DocumentChange); i {
 			case 0:
 				return &v.state
@@ -1149,7 +1151,7 @@ func file_google_firestore_v1_write_proto_init() {
 				return nil
 			}
 		}
-		file_google_firestore_v1_write_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+		file_google_firestore_v1_write_proto_msgTypes[4].Exporter = func(v any, i int) any {
 			switch v := v.(*DocumentDelete); i {
 			case 0:
 				return &v.state
@@ -1161,7 +1163,7 @@ func file_google_firestore_v1_write_proto_init() {
 				return nil
 			}
 		}
-		file_google_firestore_v1_write_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+		file_google_firestore_v1_write_proto_msgTypes[5].Exporter = func(v any, i int) any {
 			switch v := v.(*DocumentRemove); i {
 			case 0:
 				return &v.state
@@ -1173,7 +1175,7 @@ func file_google_firestore_v1_write_proto_init() {
 				return nil
 			}
 		}
-		file_google_firestore_v1_write_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+		file_google_firestore_v1_write_proto_msgTypes[6].Exporter = func(v any, i int) any {
 			switch v := v.(*ExistenceFilter); i {
 			case 0:
 				return &v.state
@@ -1185,7 +1187,7 @@ func file_google_firestore_v1_write_proto_init() {
 				return nil
 			}
 		}
-		file_google_firestore_v1_write_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+		file_google_firestore_v1_write_proto_msgTypes[7].Exporter = func(v any, i int) any {
 			switch v := v.(*DocumentTransform_FieldTransform); i {
 			case 0:
 				return &v.state
@@ -1198,12 +1200,12 @@ func file_google_firestore_v1_write_proto_init() {
 			}
 		}
 	}
-	file_google_firestore_v1_write_proto_msgTypes[0].OneofWrappers = []interface{}{
+	file_google_firestore_v1_write_proto_msgTypes[0].OneofWrappers = []any{
 		(*Write_Update)(nil),
 		(*Write_Delete)(nil),
 		(*Write_Transform)(nil),
 	}
-	file_google_firestore_v1_write_proto_msgTypes[7].OneofWrappers = []interface{}{
+	file_google_firestore_v1_write_proto_msgTypes[7].OneofWrappers = []any{
 		(*DocumentTransform_FieldTransform_SetToServerValue)(nil),
 		(*DocumentTransform_FieldTransform_Increment)(nil),
 		(*DocumentTransform_FieldTransform_Maximum)(nil),
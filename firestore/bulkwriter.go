@@ -18,7 +18,9 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"math"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	vkit "cloud.google.com/go/firestore/apiv1"
@@ -36,8 +38,92 @@ const (
 	defaultStartingMaximumOpsPerSecond = 500
 	// maxWritesPerSecond is the starting limit of writes allowed to callers per second
 	maxWritesPerSecond = maxBatchSize * defaultStartingMaximumOpsPerSecond
+	// rampUpInterval is how often a throttled BulkWriter increases its rate
+	rampUpInterval = 5 * time.Minute
+	// rampUpFactor is how much a throttled BulkWriter increases its rate by
+	rampUpFactor = 1.5
 )
 
+// A BulkWriterOption is an option passed to Client.BulkWriter.
+type BulkWriterOption interface {
+	config(bw *BulkWriter)
+}
+
+// BulkWriterThrottling is a BulkWriterOption that limits the number of writes
+// the BulkWriter sends per second. The limit starts at initialOpsPerSecond and
+// increases by 50% every 5 minutes, up to maxOpsPerSecond. This follows the
+// "500/50/5" rule for ramping up traffic to Firestore, which avoids hotspots
+// when writing to a new or cold range of documents.
+//
+// Without this option, a BulkWriter sends up to 10,000 writes per second from
+// the start.
+func BulkWriterThrottling(initialOpsPerSecond, maxOpsPerSecond int) BulkWriterOption {
+	return bulkWriterThrottling{initial: initialOpsPerSecond, max: maxOpsPerSecond}
+}
+
+type bulkWriterThrottling struct{ initial, max int }
+
+func (t bulkWriterThrottling) config(bw *BulkWriter) {
+	initial := t.initial
+	if initial < 1 {
+		initial = 1
+	}
+	max := t.max
+	if max < initial {
+		max = initial
+	}
+	bw.rampUpInitial, bw.rampUpMax = initial, max
+}
+
+// BulkWriterNoThrottling is a BulkWriterOption that removes the limit on the
+// number of writes the BulkWriter sends per second.
+var BulkWriterNoThrottling = bulkWriterNoThrottling{}
+
+type bulkWriterNoThrottling struct{}
+
+func (bulkWriterNoThrottling) config(bw *BulkWriter) { bw.unthrottled = true }
+
+// BulkWriterOnSuccess is a BulkWriterOption that calls f with each write that
+// succeeds and its result. f is called from the goroutine that sends the
+// writes, so it should return quickly.
+func BulkWriterOnSuccess(f func(*BulkWriterJob, *WriteResult)) BulkWriterOption {
+	return bulkWriterOnSuccess(f)
+}
+
+type bulkWriterOnSuccess func(*BulkWriterJob, *WriteResult)
+
+func (f bulkWriterOnSuccess) config(bw *BulkWriter) { bw.onSuccess = f }
+
+// BulkWriterOnError is a BulkWriterOption that calls f with each write that
+// fails, after any retries, and its error. f is called from the goroutine that
+// sends the writes, so it should return quickly.
+func BulkWriterOnError(f func(*BulkWriterJob, error)) BulkWriterOption {
+	return bulkWriterOnError(f)
+}
+
+type bulkWriterOnError func(*BulkWriterJob, error)
+
+func (f bulkWriterOnError) config(bw *BulkWriter) { bw.onError = f }
+
+// BulkWriterProgress is a snapshot of the progress of a BulkWriter.
+type BulkWriterProgress struct {
+	// Enqueued is the number of writes added to the BulkWriter.
+	Enqueued int64
+	// Succeeded is the number of writes that succeeded.
+	Succeeded int64
+	// Failed is the number of writes that failed after any retries.
+	Failed int64
+	// Pending is the number of writes that have neither succeeded nor failed.
+	Pending int64
+	// Retries is the number of times writes were retried.
+	Retries int64
+	// OpsPerSecond is the current limit on writes per second, or +Inf if the
+	// BulkWriter is not throttled.
+	OpsPerSecond float64
+	// Elapsed is the time since the BulkWriter was created.
+	Elapsed time.Duration
+}
+
 // bulkWriterResult contains the WriteResult or error results from an individual
 // write to the database.
 type bulkWriterResult struct {
@@ -49,7 +135,9 @@ type bulkWriterResult struct {
 type BulkWriterJob struct {
 	resultChan  chan bulkWriterResult // send errors and results to this channel
 	write       *pb.Write             // the writes to apply to the database
-	attempts    int                   // number of times this write has been attempted
+	doc         *DocumentRef          // the document written
+	op          string                // the kind of write: create, set, update or delete
+	attempts    int32                 // number of times this write has been attempted; accessed atomically
 	resultsLock sync.Mutex            // guards the cached wr and e values for the job
 	result      *WriteResult          // (cached) result from the operation
 	err         error                 // (cached) any errors that occurred
@@ -68,6 +156,23 @@ func (j *BulkWriterJob) Results() (*WriteResult, error) {
 	return j.result, j.err
 }
 
+// Document returns the document written by the job.
+func (j *BulkWriterJob) Document() *DocumentRef {
+	return j.doc
+}
+
+// Operation returns the kind of write of the job: "create", "set", "update"
+// or "delete".
+func (j *BulkWriterJob) Operation() string {
+	return j.op
+}
+
+// Attempts returns the number of times the write of the job has been
+// attempted and failed.
+func (j *BulkWriterJob) Attempts() int {
+	return int(atomic.LoadInt32(&j.attempts))
+}
+
 // processResults checks for errors returned from send() and packages up the
 // results as WriteResult objects
 func (j *BulkWriterJob) processResults() (*WriteResult, error) {
@@ -112,10 +217,20 @@ type BulkWriter struct {
 	ctx             context.Context  // context for canceling all BulkWriter operations
 	isOpenLock      sync.RWMutex     // guards against setting isOpen concurrently
 	isOpen          bool             // flag that the BulkWriter is closed
+
+	// Throttling and callbacks, set by BulkWriterOptions.
+	rampUpInitial int  // if non-zero, the limit of writes per second ramps up from this value
+	rampUpMax     int  // the value the limit of writes per second ramps up to
+	unthrottled   bool // no limit on writes per second
+	onSuccess     func(*BulkWriterJob, *WriteResult)
+	onError       func(*BulkWriterJob, error)
+
+	// Progress counters, accessed atomically.
+	enqueued, succeeded, failed, retries int64
 }
 
 // newBulkWriter creates a new instance of the BulkWriter.
-func newBulkWriter(ctx context.Context, c *Client, database string) *BulkWriter {
+func newBulkWriter(ctx context.Context, c *Client, database string, opts ...BulkWriterOption) *BulkWriter {
 	// Although typically we shouldn't store Context objects, in this case we
 	// need to pass this Context through to the Bundler handler.
 	ctx = withResourceHeader(ctx, c.path())
@@ -130,6 +245,15 @@ func newBulkWriter(ctx context.Context, c *Client, database string) *BulkWriter
 		ctx:             ctx,
 		limiter:         *rate.NewLimiter(rate.Limit(maxWritesPerSecond), 1),
 	}
+	for _, opt := range opts {
+		opt.config(bw)
+	}
+	switch {
+	case bw.unthrottled:
+		bw.limiter.SetLimit(rate.Inf)
+	case bw.rampUpInitial > 0:
+		bw.limiter.SetLimit(rate.Limit(bw.rampUpInitial))
+	}
 
 	// can't initialize within struct above; need instance reference to BulkWriter.send()
 	bw.bundler = bundler.NewBundler(&BulkWriterJob{}, bw.send)
@@ -150,6 +274,23 @@ func (bw *BulkWriter) End() {
 	bw.Flush()
 }
 
+// Progress returns a snapshot of the progress of the BulkWriter.
+func (bw *BulkWriter) Progress() BulkWriterProgress {
+	p := BulkWriterProgress{
+		Enqueued:     atomic.LoadInt64(&bw.enqueued),
+		Succeeded:    atomic.LoadInt64(&bw.succeeded),
+		Failed:       atomic.LoadInt64(&bw.failed),
+		Retries:      atomic.LoadInt64(&bw.retries),
+		OpsPerSecond: float64(bw.limiter.Limit()),
+		Elapsed:      time.Since(bw.start),
+	}
+	if bw.limiter.Limit() == rate.Inf {
+		p.OpsPerSecond = math.Inf(1)
+	}
+	p.Pending = p.Enqueued - p.Succeeded - p.Failed
+	return p
+}
+
 // Flush commits all writes that have been enqueued up to this point in parallel.
 // This method blocks execution.
 func (bw *BulkWriter) Flush() {
@@ -175,7 +316,7 @@ func (bw *BulkWriter) Create(doc *DocumentRef, datum interface{}) (*BulkWriterJo
 		return nil, fmt.Errorf("firestore: too many document writes sent to bulkwriter")
 	}
 
-	j := bw.write(w[0])
+	j := bw.write(w[0], doc, "create")
 	return j, nil
 }
 
@@ -198,7 +339,7 @@ func (bw *BulkWriter) Delete(doc *DocumentRef, preconds ...Precondition) (*BulkW
 		return nil, fmt.Errorf("firestore: too many document writes sent to bulkwriter")
 	}
 
-	j := bw.write(w[0])
+	j := bw.write(w[0], doc, "delete")
 	return j, nil
 }
 
@@ -221,7 +362,7 @@ func (bw *BulkWriter) Set(doc *DocumentRef, datum interface{}, opts ...SetOption
 		return nil, fmt.Errorf("firestore: too many writes sent to bulkwriter")
 	}
 
-	j := bw.write(w[0])
+	j := bw.write(w[0], doc, "set")
 	return j, nil
 }
 
@@ -244,7 +385,7 @@ func (bw *BulkWriter) Update(doc *DocumentRef, updates []Update, preconds ...Pre
 		return nil, fmt.Errorf("firestore: too many writes sent to bulkwriter")
 	}
 
-	j := bw.write(w[0])
+	j := bw.write(w[0], doc, "update")
 	return j, nil
 }
 
@@ -271,14 +412,22 @@ func (bw *BulkWriter) checkWriteConditions(doc *DocumentRef) error {
 }
 
 // write packages up write requests into bulkWriterJob objects.
-func (bw *BulkWriter) write(w *pb.Write) *BulkWriterJob {
+func (bw *BulkWriter) write(w *pb.Write, doc *DocumentRef, op string) *BulkWriterJob {
 
 	j := &BulkWriterJob{
 		resultChan: make(chan bulkWriterResult, 1),
 		write:      w,
+		doc:        doc,
+		op:         op,
 		ctx:        bw.ctx,
 	}
+	atomic.AddInt64(&bw.enqueued, 1)
 
+	if bw.rampUpInitial > 0 && !bw.unthrottled {
+		if l := rate.Limit(rampedOpsPerSecond(bw.rampUpInitial, bw.rampUpMax, time.Since(bw.start))); l != bw.limiter.Limit() {
+			bw.limiter.SetLimit(l)
+		}
+	}
 	bw.limiter.Wait(bw.ctx)
 	// ignore operation size constraints and related errors; can't be inferred at compile time
 	// Bundler is set to accept an unlimited amount of bytes
@@ -287,6 +436,38 @@ func (bw *BulkWriter) write(w *pb.Write) *BulkWriterJob {
 	return j
 }
 
+// rampedOpsPerSecond returns the limit of writes per second after elapsed
+// time, starting at initial and increasing by rampUpFactor every
+// rampUpInterval, up to max.
+func rampedOpsPerSecond(initial, max int, elapsed time.Duration) int {
+	n := float64(initial) * math.Pow(rampUpFactor, float64(elapsed/rampUpInterval))
+	if n > float64(max) {
+		return max
+	}
+	return int(n)
+}
+
+// succeed delivers the result of a successful write to j.
+func (bw *BulkWriter) succeed(j *BulkWriterJob, res *pb.WriteResult) {
+	atomic.AddInt64(&bw.succeeded, 1)
+	if bw.onSuccess != nil {
+		if wr, err := writeResultFromProto(res); err == nil {
+			bw.onSuccess(j, wr)
+		}
+	}
+	j.resultChan <- bulkWriterResult{err: nil, result: res}
+	close(j.resultChan)
+}
+
+// fail delivers the error of a failed write to j.
+func (bw *BulkWriter) fail(j *BulkWriterJob, err error) {
+	atomic.AddInt64(&bw.failed, 1)
+	if bw.onError != nil {
+		bw.onError(j, err)
+	}
+	j.setError(err)
+}
+
 // send transmits writes to the service and matches response results to job channels.
 func (bw *BulkWriter) send(i interface{}) {
 	bwj := i.([]*BulkWriterJob)
@@ -314,7 +495,7 @@ func (bw *BulkWriter) send(i interface{}) {
 		if err != nil {
 			// Do we need to be selective about what kind of errors we send?
 			for _, j := range bwj {
-				j.setError(err)
+				bw.fail(j, err)
 			}
 			return
 		}
@@ -324,21 +505,20 @@ func (bw *BulkWriter) send(i interface{}) {
 			c := s.GetCode()
 			if c != 0 { // Should we do an explicit check against rpc.Code enum?
 				j := bwj[i]
-				j.attempts++
 
 				// Do we need separate retry bundler?
-				if j.attempts < maxRetryAttempts {
+				if atomic.AddInt32(&j.attempts, 1) < maxRetryAttempts {
+					atomic.AddInt64(&bw.retries, 1)
 					// ignore operation size constraints and related errors; job size can't be inferred at compile time
 					// Bundler is set to accept an unlimited amount of bytes
 					_ = bw.bundler.Add(j, 0)
 				} else {
-					j.setError(fmt.Errorf("firestore: write failed with status: %v", s))
+					bw.fail(j, fmt.Errorf("firestore: write failed with status: %v", s))
 				}
 				continue
 			}
 
-			bwj[i].resultChan <- bulkWriterResult{err: nil, result: res}
-			close(bwj[i].resultChan)
+			bw.succeed(bwj[i], res)
 		}
 	}
 }
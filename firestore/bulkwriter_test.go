@@ -16,11 +16,14 @@ package firestore
 
 import (
 	"context"
+	"math"
 	"testing"
+	"time"
 
 	pb "cloud.google.com/go/firestore/apiv1/firestorepb"
 	"google.golang.org/genproto/googleapis/rpc/status"
 	"google.golang.org/grpc/codes"
+	grpcstatus "google.golang.org/grpc/status"
 )
 
 type bulkwriterTestCase struct {
@@ -251,3 +254,105 @@ func TestBulkWriterErrors(t *testing.T) {
 		})
 	}
 }
+
+func TestBulkWriterOptions(t *testing.T) {
+	c, srv, cleanup := newMock(t)
+	defer cleanup()
+	docPrefix := c.Collection("C").Path + "/"
+
+	srv.addRPC(
+		&pb.BatchWriteRequest{
+			Database: c.path(),
+			Writes:   []*pb.Write{{Operation: &pb.Write_Delete{Delete: docPrefix + "a"}}},
+		},
+		&pb.BatchWriteResponse{
+			WriteResults: []*pb.WriteResult{{UpdateTime: aTimestamp}},
+			Status:       []*status.Status{{Code: int32(codes.OK)}},
+		},
+	)
+	srv.addRPC(
+		&pb.BatchWriteRequest{
+			Database: c.path(),
+			Writes:   []*pb.Write{{Operation: &pb.Write_Delete{Delete: docPrefix + "b"}}},
+		},
+		grpcstatus.Error(codes.PermissionDenied, "denied"),
+	)
+
+	var succeeded, failed []string
+	bw := c.BulkWriter(context.Background(),
+		BulkWriterNoThrottling,
+		BulkWriterOnSuccess(func(j *BulkWriterJob, wr *WriteResult) {
+			if !testEqual(wr, &WriteResult{aTime}) {
+				t.Errorf("got result %v, want %v", wr, &WriteResult{aTime})
+			}
+			succeeded = append(succeeded, j.Operation()+" "+j.Document().ID)
+		}),
+		BulkWriterOnError(func(j *BulkWriterJob, err error) {
+			if grpcstatus.Code(err) != codes.PermissionDenied {
+				t.Errorf("got error %v, want PermissionDenied", err)
+			}
+			failed = append(failed, j.Operation()+" "+j.Document().ID)
+		}),
+	)
+
+	if _, err := bw.Delete(c.Doc("C/a")); err != nil {
+		t.Fatal(err)
+	}
+	if got := bw.Progress(); got.Enqueued != 1 || got.Pending != 1 {
+		t.Errorf("before flush: got %+v, want 1 enqueued and pending", got)
+	}
+	bw.Flush()
+	if _, err := bw.Delete(c.Doc("C/b")); err != nil {
+		t.Fatal(err)
+	}
+	bw.End()
+
+	if want := []string{"delete a"}; !testEqual(succeeded, want) {
+		t.Errorf("succeeded: got %v, want %v", succeeded, want)
+	}
+	if want := []string{"delete b"}; !testEqual(failed, want) {
+		t.Errorf("failed: got %v, want %v", failed, want)
+	}
+	got := bw.Progress()
+	if got.Enqueued != 2 || got.Succeeded != 1 || got.Failed != 1 || got.Pending != 0 || got.Retries != 0 {
+		t.Errorf("after end: got %+v", got)
+	}
+	if !math.IsInf(got.OpsPerSecond, 1) {
+		t.Errorf("got %v ops per second, want +Inf", got.OpsPerSecond)
+	}
+}
+
+func TestBulkWriterThrottling(t *testing.T) {
+	c, _, cleanup := newMock(t)
+	defer cleanup()
+
+	for _, test := range []struct {
+		opts []BulkWriterOption
+		want float64
+	}{
+		{nil, maxWritesPerSecond},
+		{[]BulkWriterOption{BulkWriterThrottling(500, 10000)}, 500},
+		{[]BulkWriterOption{BulkWriterThrottling(0, 0)}, 1},
+		{[]BulkWriterOption{BulkWriterThrottling(500, 10000), BulkWriterNoThrottling}, math.Inf(1)},
+	} {
+		bw := c.BulkWriter(context.Background(), test.opts...)
+		if got := bw.Progress().OpsPerSecond; got != test.want {
+			t.Errorf("%v: got %v ops per second, want %v", test.opts, got, test.want)
+		}
+	}
+
+	for _, test := range []struct {
+		elapsed time.Duration
+		want    int
+	}{
+		{0, 500},
+		{4 * time.Minute, 500},
+		{5 * time.Minute, 750},
+		{11 * time.Minute, 1125},
+		{time.Hour, 2000},
+	} {
+		if got := rampedOpsPerSecond(500, 2000, test.elapsed); got != test.want {
+			t.Errorf("after %v: got %d, want %d", test.elapsed, got, test.want)
+		}
+	}
+}
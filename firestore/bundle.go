@@ -0,0 +1,205 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package firestore
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	pb "cloud.google.com/go/firestore/apiv1/firestorepb"
+	bundlepb "google.golang.org/genproto/firestore/bundle"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// bundleVersion is the version of the bundle format written by BundleBuilder.
+const bundleVersion = 1
+
+// A BundleBuilder builds a Firestore data bundle: a file of documents and
+// named queries, read on the server, that web and mobile clients can load
+// into their local cache, for example after downloading it from a CDN. Use
+// Client.Bundle to create one.
+//
+// A BundleBuilder is not safe for concurrent use.
+type BundleBuilder struct {
+	id       string
+	docs     []*bundledDocument
+	docIndex map[string]*bundledDocument // keyed by document path
+	queries  []*bundlepb.NamedQuery
+	readTime time.Time // latest read time of a document or query
+}
+
+type bundledDocument struct {
+	metadata *bundlepb.BundledDocumentMetadata
+	proto    *pb.Document // nil if the document does not exist
+}
+
+// Bundle returns a BundleBuilder for a bundle with the given ID. Clients use
+// the ID to tell whether they have already loaded the bundle.
+func (c *Client) Bundle(id string) *BundleBuilder {
+	return &BundleBuilder{
+		id:       id,
+		docIndex: map[string]*bundledDocument{},
+	}
+}
+
+// AddDocument adds a document snapshot to the bundle. Snapshots of
+// documents that do not exist are recorded as such. If the bundle already
+// holds a snapshot of the same document, the one with the later read time is
+// kept.
+func (b *BundleBuilder) AddDocument(ds *DocumentSnapshot) error {
+	if ds == nil || ds.Ref == nil {
+		return errors.New("firestore: nil document snapshot")
+	}
+	if ds.ReadTime.IsZero() {
+		return fmt.Errorf("firestore: snapshot of %q has no read time", ds.Ref.Path)
+	}
+	b.addDocument(ds, "")
+	return nil
+}
+
+func (b *BundleBuilder) addDocument(ds *DocumentSnapshot, queryName string) {
+	bd := b.docIndex[ds.Ref.Path]
+	if bd == nil {
+		bd = &bundledDocument{metadata: &bundlepb.BundledDocumentMetadata{Name: ds.Ref.Path}}
+		b.docIndex[ds.Ref.Path] = bd
+		b.docs = append(b.docs, bd)
+	}
+	if md := bd.metadata; md.ReadTime == nil || md.ReadTime.AsTime().Before(ds.ReadTime) {
+		md.ReadTime = timestamppb.New(ds.ReadTime)
+		md.Exists = ds.Exists()
+		bd.proto = ds.proto
+	}
+	if queryName != "" {
+		bd.metadata.Queries = append(bd.metadata.Queries, queryName)
+	}
+	b.updateReadTime(ds.ReadTime)
+}
+
+func (b *BundleBuilder) updateReadTime(t time.Time) {
+	if t.After(b.readTime) {
+		b.readTime = t
+	}
+}
+
+// AddQuery runs the query q and adds it to the bundle under the given name,
+// along with the documents it returns. Clients can run the named query
+// against their cache once they have loaded the bundle. The query is read at
+// its read time, if one was set with Query.WithReadOptions.
+//
+// The name must be unique within the bundle.
+func (b *BundleBuilder) AddQuery(ctx context.Context, name string, q Queryer) error {
+	if name == "" {
+		return errors.New("firestore: empty query name")
+	}
+	for _, nq := range b.queries {
+		if nq.Name == name {
+			return fmt.Errorf("firestore: duplicate query name %q", name)
+		}
+	}
+	query := q.query()
+	sq, err := query.toProto()
+	if err != nil {
+		return err
+	}
+	bq := &bundlepb.BundledQuery{
+		Parent:    query.parentPath,
+		QueryType: &bundlepb.BundledQuery_StructuredQuery{StructuredQuery: sq},
+		LimitType: bundlepb.BundledQuery_FIRST,
+	}
+	if query.limitToLast {
+		bq.LimitType = bundlepb.BundledQuery_LAST
+	}
+
+	it := query.Documents(ctx)
+	docs, err := it.GetAll()
+	if err != nil {
+		return err
+	}
+	readTime := it.iter.(*queryDocumentIterator).readTime
+	if readTime == nil {
+		return fmt.Errorf("firestore: no read time for query %q", name)
+	}
+	for _, ds := range docs {
+		b.addDocument(ds, name)
+	}
+	b.queries = append(b.queries, &bundlepb.NamedQuery{
+		Name:         name,
+		BundledQuery: bq,
+		ReadTime:     readTime,
+	})
+	b.updateReadTime(readTime.AsTime())
+	return nil
+}
+
+// Build returns the bundle in the length-prefixed JSON format understood by
+// the Firestore web and mobile SDKs. The bundle's metadata comes first,
+// followed by the named queries and then the documents.
+func (b *BundleBuilder) Build() ([]byte, error) {
+	var elems []*bundlepb.BundleElement
+	for _, nq := range b.queries {
+		elems = append(elems, &bundlepb.BundleElement{
+			ElementType: &bundlepb.BundleElement_NamedQuery{NamedQuery: nq},
+		})
+	}
+	for _, bd := range b.docs {
+		elems = append(elems, &bundlepb.BundleElement{
+			ElementType: &bundlepb.BundleElement_DocumentMetadata{DocumentMetadata: bd.metadata},
+		})
+		if bd.proto != nil {
+			elems = append(elems, &bundlepb.BundleElement{
+				ElementType: &bundlepb.BundleElement_Document{Document: bd.proto},
+			})
+		}
+	}
+	var body bytes.Buffer
+	for _, e := range elems {
+		if err := writeBundleElement(&body, e); err != nil {
+			return nil, err
+		}
+	}
+
+	md := &bundlepb.BundleMetadata{
+		Id:             b.id,
+		CreateTime:     timestamppb.New(b.readTime),
+		Version:        bundleVersion,
+		TotalDocuments: uint32(len(b.docs)),
+		TotalBytes:     uint64(body.Len()),
+	}
+	var buf bytes.Buffer
+	if err := writeBundleElement(&buf, &bundlepb.BundleElement{
+		ElementType: &bundlepb.BundleElement_Metadata{Metadata: md},
+	}); err != nil {
+		return nil, err
+	}
+	buf.Write(body.Bytes())
+	return buf.Bytes(), nil
+}
+
+// writeBundleElement writes e as JSON, preceded by its length in bytes.
+func writeBundleElement(buf *bytes.Buffer, e proto.Message) error {
+	js, err := protojson.Marshal(e)
+	if err != nil {
+		return err
+	}
+	buf.WriteString(strconv.Itoa(len(js)))
+	buf.Write(js)
+	return nil
+}
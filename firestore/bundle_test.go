@@ -0,0 +1,196 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package firestore
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	pb "cloud.google.com/go/firestore/apiv1/firestorepb"
+	bundlepb "google.golang.org/genproto/firestore/bundle"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// parseBundle splits a bundle into its elements, returning them with their
+// sizes in bytes, including the length prefix.
+func parseBundle(t *testing.T, b []byte) ([]*bundlepb.BundleElement, []int) {
+	t.Helper()
+	var elems []*bundlepb.BundleElement
+	var sizes []int
+	for len(b) > 0 {
+		i := 0
+		for i < len(b) && b[i] >= '0' && b[i] <= '9' {
+			i++
+		}
+		n, err := strconv.Atoi(string(b[:i]))
+		if err != nil || i+n > len(b) {
+			t.Fatalf("bad length prefix at %q", b)
+		}
+		e := &bundlepb.BundleElement{}
+		if err := protojson.Unmarshal(b[i:i+n], e); err != nil {
+			t.Fatal(err)
+		}
+		elems = append(elems, e)
+		sizes = append(sizes, i+n)
+		b = b[i+n:]
+	}
+	return elems, sizes
+}
+
+func TestBundleBuilder(t *testing.T) {
+	ctx := context.Background()
+	c, srv, cleanup := newMock(t)
+	defer cleanup()
+
+	const dbPath = "projects/projectID/databases/(default)"
+	docA := &pb.Document{
+		Name:       dbPath + "/documents/C/a",
+		CreateTime: aTimestamp,
+		UpdateTime: aTimestamp,
+		Fields:     map[string]*pb.Value{"f": intval(1)},
+	}
+	docB := &pb.Document{
+		Name:       dbPath + "/documents/C/b",
+		CreateTime: aTimestamp,
+		UpdateTime: aTimestamp,
+		Fields:     map[string]*pb.Value{"f": intval(2)},
+	}
+
+	b := c.Bundle("my-bundle")
+	srv.addRPC(&pb.BatchGetDocumentsRequest{
+		Database:  dbPath,
+		Documents: []string{docA.Name, dbPath + "/documents/C/x"},
+	}, []interface{}{
+		&pb.BatchGetDocumentsResponse{
+			Result:   &pb.BatchGetDocumentsResponse_Found{Found: docA},
+			ReadTime: aTimestamp,
+		},
+		&pb.BatchGetDocumentsResponse{
+			Result:   &pb.BatchGetDocumentsResponse_Missing{Missing: dbPath + "/documents/C/x"},
+			ReadTime: aTimestamp,
+		},
+	})
+	dss, err := c.GetAll(ctx, []*DocumentRef{c.Doc("C/a"), c.Doc("C/x")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, ds := range dss {
+		if err := b.AddDocument(ds); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	q := c.Collection("C").Where("f", ">", 0)
+	sq, err := q.toProto()
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv.addRPC(nil, []interface{}{
+		&pb.RunQueryResponse{Document: docA, ReadTime: aTimestamp2},
+		&pb.RunQueryResponse{Document: docB, ReadTime: aTimestamp2},
+	})
+	if err := b.AddQuery(ctx, "positive", q); err != nil {
+		t.Fatal(err)
+	}
+	// Empty results still have a read time.
+	srv.addRPC(nil, []interface{}{&pb.RunQueryResponse{ReadTime: aTimestamp}})
+	if err := b.AddQuery(ctx, "none", c.Collection("D")); err != nil {
+		t.Fatal(err)
+	}
+	if err := b.AddQuery(ctx, "none", c.Collection("D")); err == nil {
+		t.Error("got nil, want error for duplicate query name")
+	}
+
+	buf, err := b.Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, sizes := parseBundle(t, buf)
+	sqD, _ := c.Collection("D").toProto()
+	want := []*bundlepb.BundleElement{
+		{ElementType: &bundlepb.BundleElement_NamedQuery{NamedQuery: &bundlepb.NamedQuery{
+			Name: "positive",
+			BundledQuery: &bundlepb.BundledQuery{
+				Parent:    dbPath + "/documents",
+				QueryType: &bundlepb.BundledQuery_StructuredQuery{StructuredQuery: sq},
+			},
+			ReadTime: aTimestamp2,
+		}}},
+		{ElementType: &bundlepb.BundleElement_NamedQuery{NamedQuery: &bundlepb.NamedQuery{
+			Name: "none",
+			BundledQuery: &bundlepb.BundledQuery{
+				Parent:    dbPath + "/documents",
+				QueryType: &bundlepb.BundledQuery_StructuredQuery{StructuredQuery: sqD},
+			},
+			ReadTime: aTimestamp,
+		}}},
+		// C/a is kept at the later read time of the query.
+		{ElementType: &bundlepb.BundleElement_DocumentMetadata{DocumentMetadata: &bundlepb.BundledDocumentMetadata{
+			Name:     docA.Name,
+			ReadTime: aTimestamp2,
+			Exists:   true,
+			Queries:  []string{"positive"},
+		}}},
+		{ElementType: &bundlepb.BundleElement_Document{Document: docA}},
+		{ElementType: &bundlepb.BundleElement_DocumentMetadata{DocumentMetadata: &bundlepb.BundledDocumentMetadata{
+			Name:     dbPath + "/documents/C/x",
+			ReadTime: aTimestamp,
+		}}},
+		{ElementType: &bundlepb.BundleElement_DocumentMetadata{DocumentMetadata: &bundlepb.BundledDocumentMetadata{
+			Name:     docB.Name,
+			ReadTime: aTimestamp2,
+			Exists:   true,
+			Queries:  []string{"positive"},
+		}}},
+		{ElementType: &bundlepb.BundleElement_Document{Document: docB}},
+	}
+	if len(got) != len(want)+1 {
+		t.Fatalf("got %d elements, want %d", len(got), len(want)+1)
+	}
+	md := got[0].GetMetadata()
+	if md == nil {
+		t.Fatalf("got %v first, want metadata", got[0])
+	}
+	wantMD := &bundlepb.BundleMetadata{
+		Id:             "my-bundle",
+		CreateTime:     aTimestamp2,
+		Version:        bundleVersion,
+		TotalDocuments: 3,
+		TotalBytes:     uint64(len(buf) - sizes[0]),
+	}
+	if !testEqual(md, wantMD) {
+		t.Errorf("got %v, want %v", md, wantMD)
+	}
+	for i, e := range got[1:] {
+		if !testEqual(e, want[i]) {
+			t.Errorf("#%d: got %v, want %v", i, e, want[i])
+		}
+	}
+}
+
+func TestBundleBuilderErrors(t *testing.T) {
+	c := &Client{projectID: "P", databaseID: "D"}
+	b := c.Bundle("id")
+	if err := b.AddDocument(nil); err == nil {
+		t.Error("nil snapshot: got nil, want error")
+	}
+	if err := b.AddDocument(&DocumentSnapshot{Ref: c.Doc("C/a")}); err == nil {
+		t.Error("no read time: got nil, want error")
+	}
+	if err := b.AddQuery(context.Background(), "", c.Collection("C")); err == nil {
+		t.Error("empty query name: got nil, want error")
+	}
+}
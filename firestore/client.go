@@ -313,8 +313,10 @@ func (c *Client) Batch() *WriteBatch {
 // BulkWriter returns a BulkWriter instance.
 // The context passed to the BulkWriter remains stored through the lifecycle
 // of the object. This context allows callers to cancel BulkWriter operations.
-func (c *Client) BulkWriter(ctx context.Context) *BulkWriter {
-	bw := newBulkWriter(ctx, c, c.path())
+// The options configure the throttling of the BulkWriter and callbacks for
+// the results of its writes.
+func (c *Client) BulkWriter(ctx context.Context, opts ...BulkWriterOption) *BulkWriter {
+	bw := newBulkWriter(ctx, c, c.path(), opts...)
 	return bw
 }
 
@@ -43,6 +43,7 @@ func newCollectionGroupRef(c *Client, dbPath, collectionID string) *CollectionGr
 			path:           dbPath,
 			parentPath:     dbPath + "/documents",
 			allDescendants: true,
+			readSettings:   &readSettings{},
 		},
 	}
 }
@@ -57,6 +58,11 @@ func newCollectionGroupRef(c *Client, dbPath, collectionID string) *CollectionGr
 //
 // If the goal is to run the queries across processes or workers, it may be useful to use
 // `Query.Serialize` and `Query.Deserialize` to serialize the query.
+//
+// If the CollectionGroupRef has a read time set with WithReadOptions, the
+// partitions are computed at that time, and the returned queries read at that
+// time too, so that together they cover a consistent snapshot of the
+// collection group.
 func (cgr CollectionGroupRef) GetPartitionedQueries(ctx context.Context, partitionCount int) ([]Query, error) {
 	qp, err := cgr.getPartitions(ctx, partitionCount)
 	if err != nil {
@@ -101,6 +107,9 @@ func (cgr CollectionGroupRef) getPartitions(ctx context.Context, partitionCount
 		PartitionCount: int64(partitionCount),
 		QueryType:      structuredQuery,
 	}
+	if rt, hasOpts := parseReadTime(cgr.c, cgr.readSettings); hasOpts {
+		pbr.ConsistencySelector = &firestorepb.PartitionQueryRequest_ReadTime{ReadTime: rt}
+	}
 	cursorReferences := make([]*firestorepb.Value, 0, partitionCount)
 	iter := cgr.c.c.PartitionQuery(ctx, pbr)
 	for {
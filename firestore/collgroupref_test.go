@@ -17,6 +17,8 @@ package firestore
 import (
 	"context"
 	"testing"
+
+	pb "cloud.google.com/go/firestore/apiv1/firestorepb"
 )
 
 func TestCGR_TestQueryPartition_ToQuery(t *testing.T) {
@@ -40,6 +42,7 @@ func TestCGR_TestQueryPartition_ToQuery(t *testing.T) {
 		endBefore:      true,
 		allDescendants: true,
 		orders:         []order{{fieldPath: []string{"__name__"}, dir: 1}},
+		readSettings:   &readSettings{},
 	}
 
 	if !testEqual(got, want) {
@@ -71,3 +74,51 @@ func TestCGR_TestGetPartitions(t *testing.T) {
 		t.Errorf("got %+v, want %+v", got, want)
 	}
 }
+
+func TestCGR_GetPartitionedQueries(t *testing.T) {
+	ctx := context.Background()
+	c, srv, cleanup := newMock(t)
+	defer cleanup()
+
+	cgr := c.CollectionGroup("C")
+	cgr.WithReadOptions(ReadTime(aTime))
+	sq, err := cgr.Query.OrderBy(DocumentID, Asc).toProto()
+	if err != nil {
+		t.Fatal(err)
+	}
+	ref := func(path string) *pb.Value {
+		return refval(c.path() + "/documents/" + path)
+	}
+	srv.addRPC(&pb.PartitionQueryRequest{
+		Parent:              c.path() + "/documents",
+		QueryType:           &pb.PartitionQueryRequest_StructuredQuery{StructuredQuery: sq},
+		PartitionCount:      3,
+		ConsistencySelector: &pb.PartitionQueryRequest_ReadTime{ReadTime: aTimestamp},
+	}, &pb.PartitionQueryResponse{
+		// Cursors may be returned in any order.
+		Partitions: []*pb.Cursor{
+			{Values: []*pb.Value{ref("D/d/C/b")}},
+			{Values: []*pb.Value{ref("C/a")}},
+		},
+	})
+
+	qs, err := cgr.GetPartitionedQueries(ctx, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(qs) != 3 {
+		t.Fatalf("got %d queries, want 3", len(qs))
+	}
+	for i, want := range []struct{ start, end []interface{} }{
+		{nil, []interface{}{"documents/C/a"}},
+		{[]interface{}{"documents/C/a"}, []interface{}{"documents/D/d/C/b"}},
+		{[]interface{}{"documents/D/d/C/b"}, nil},
+	} {
+		if !testEqual(qs[i].startVals, want.start) || !testEqual(qs[i].endVals, want.end) {
+			t.Errorf("partition %d: got range [%v, %v), want [%v, %v)", i, qs[i].startVals, qs[i].endVals, want.start, want.end)
+		}
+		if rt, _ := parseReadTime(c, qs[i].readSettings); !testEqual(rt, aTimestamp) {
+			t.Errorf("partition %d: got read time %v, want %v", i, rt, aTimestamp)
+		}
+	}
+}
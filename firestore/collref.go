@@ -51,11 +51,13 @@ type CollectionRef struct {
 	Query
 
 	// readSettings specifies constraints for reading documents in the collection
-	// e.g. read time
+	// e.g. read time. It is shared with the embedded Query, so that they apply to
+	// queries on the collection too.
 	readSettings *readSettings
 }
 
 func newTopLevelCollRef(c *Client, dbPath, id string) *CollectionRef {
+	rs := &readSettings{}
 	return &CollectionRef{
 		c:          c,
 		ID:         id,
@@ -67,13 +69,15 @@ func newTopLevelCollRef(c *Client, dbPath, id string) *CollectionRef {
 			collectionID: id,
 			path:         dbPath + "/documents/" + id,
 			parentPath:   dbPath + "/documents",
+			readSettings: rs,
 		},
-		readSettings: &readSettings{},
+		readSettings: rs,
 	}
 }
 
 func newCollRefWithParent(c *Client, parent *DocumentRef, id string) *CollectionRef {
 	selfPath := parent.shortPath + "/" + id
+	rs := &readSettings{}
 	return &CollectionRef{
 		c:          c,
 		Parent:     parent,
@@ -86,8 +90,9 @@ func newCollRefWithParent(c *Client, parent *DocumentRef, id string) *Collection
 			collectionID: id,
 			path:         parent.Path + "/" + id,
 			parentPath:   parent.Path,
+			readSettings: rs,
 		},
-		readSettings: &readSettings{},
+		readSettings: rs,
 	}
 }
 
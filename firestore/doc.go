@@ -80,6 +80,17 @@ we can extract the document's data into a value of type State:
 		// TODO: Handle error.
 	}
 
+The generic function Get does both steps at once, returning the data as the
+type you name:
+
+	nyData, err := firestore.Get[State](ctx, ny)
+	if err != nil {
+		// TODO: Handle error.
+	}
+
+Set and Documents are its counterparts for writing a document and iterating over
+the results of a query.
+
 Note that this client supports struct tags beginning with "firestore:" that work like
 the tags of the encoding/json package, letting you rename fields, ignore them, or
 omit their values when empty.
@@ -238,6 +249,25 @@ read and write methods of the Transaction passed to it.
 		// TODO: Handle error.
 	}
 
+A transaction that only reads can be run with the ReadOnly option, which avoids
+taking locks. With ReadOnlyAt, its reads also see the database as it was at a
+given time, so that a report spanning many documents is consistent:
+
+	err = client.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		docs, err := tx.Documents(client.Collection("States")).GetAll()
+		if err != nil {
+			return err
+		}
+		_ = docs // TODO: Use docs.
+		return nil
+	}, firestore.ReadOnlyAt(time.Now().Add(-time.Minute)))
+
+Outside a transaction, the ReadTime option reads documents and query results at
+a point in time:
+
+	docsnap, err = ny.WithReadOptions(firestore.ReadTime(t)).Get(ctx)
+	iter = q.WithReadOptions(firestore.ReadTime(t)).Documents(ctx)
+
 # Google Cloud Firestore Emulator
 
 This package supports the Cloud Firestore emulator, which is useful for testing and
@@ -28,6 +28,7 @@ import (
 	"google.golang.org/api/iterator"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
 )
 
 var errNilDocRef = errors.New("firestore: nil DocumentRef")
@@ -423,19 +424,17 @@ type arrayUnion struct {
 // ArrayUnion must be the value of a field directly; it cannot appear in
 // array or struct values, or in any value that is itself inside an array or
 // struct.
+// A field directly inside a map, at any depth, qualifies, as does the value
+// of an Update on a nested FieldPath.
 func ArrayUnion(elems ...interface{}) arrayUnion {
 	return arrayUnion{elems: elems}
 }
 
 // This helper converts an arrayUnion into a proto object.
 func arrayUnionTransform(au arrayUnion, fp FieldPath) (*pb.DocumentTransform_FieldTransform, error) {
-	var elems []*pb.Value
-	for _, v := range au.elems {
-		pv, _, err := toProtoValue(reflect.ValueOf(v))
-		if err != nil {
-			return nil, err
-		}
-		elems = append(elems, pv)
+	elems, err := transformElements("ArrayUnion", au.elems, fp)
+	if err != nil {
+		return nil, err
 	}
 	return &pb.DocumentTransform_FieldTransform{
 		FieldPath: fp.toServiceFieldPath(),
@@ -463,20 +462,17 @@ type arrayRemove struct {
 // ArrayRemove must be the value of a field directly; it cannot appear in
 // array or struct values, or in any value that is itself inside an array or
 // struct.
+// A field directly inside a map, at any depth, qualifies, as does the value
+// of an Update on a nested FieldPath.
 func ArrayRemove(elems ...interface{}) arrayRemove {
 	return arrayRemove{elems: elems}
 }
 
 // This helper converts an arrayRemove into a proto object.
 func arrayRemoveTransform(ar arrayRemove, fp FieldPath) (*pb.DocumentTransform_FieldTransform, error) {
-	var elems []*pb.Value
-	for _, v := range ar.elems {
-		// ServerTimestamp cannot occur in an array, so we ignore transformations here.
-		pv, _, err := toProtoValue(reflect.ValueOf(v))
-		if err != nil {
-			return nil, err
-		}
-		elems = append(elems, pv)
+	elems, err := transformElements("ArrayRemove", ar.elems, fp)
+	if err != nil {
+		return nil, err
 	}
 	return &pb.DocumentTransform_FieldTransform{
 		FieldPath: fp.toServiceFieldPath(),
@@ -486,6 +482,24 @@ func arrayRemoveTransform(ar arrayRemove, fp FieldPath) (*pb.DocumentTransform_F
 	}, nil
 }
 
+// transformElements converts the elements of an ArrayUnion or ArrayRemove at
+// fp to protos. The elements end up in an array, so they cannot themselves
+// contain transforms.
+func transformElements(name string, vs []interface{}, fp FieldPath) ([]*pb.Value, error) {
+	var elems []*pb.Value
+	for i, v := range vs {
+		pv, sawTransform, err := toProtoValue(reflect.ValueOf(v))
+		if err != nil {
+			return nil, fmt.Errorf("firestore: %s element %d for field %s: %w", name, i, fp.toServiceFieldPath(), err)
+		}
+		if sawTransform {
+			return nil, fmt.Errorf("firestore: %s element %d for field %s contains a transform", name, i, fp.toServiceFieldPath())
+		}
+		elems = append(elems, pv)
+	}
+	return elems, nil
+}
+
 type transform struct {
 	t *pb.DocumentTransform_FieldTransform
 
@@ -506,8 +520,11 @@ type transform struct {
 //
 // If the field does not yet exist, the transformation will set the field to
 // the given value.
+//
+// Like ArrayUnion, the value can be nested in maps or be the value of an
+// Update on a nested FieldPath, but cannot appear in arrays or structs.
 func FieldTransformIncrement(n interface{}) transform {
-	v, err := numericTransformValue(n)
+	v, err := numericTransformValue("Increment", n)
 	return transform{
 		t: &pb.DocumentTransform_FieldTransform{
 			TransformType: &pb.DocumentTransform_FieldTransform_Increment{
@@ -541,8 +558,11 @@ func Increment(n interface{}) transform {
 // (e.g. 3 and 3.0), the field does not change. 0, 0.0, and -0.0 are all zero.
 // The maximum of a zero stored value and zero input value is always the
 // stored value. The maximum of any numeric value x and NaN is NaN.
+//
+// Like Increment, the value can be nested in maps but cannot appear in arrays
+// or structs.
 func FieldTransformMaximum(n interface{}) transform {
-	v, err := numericTransformValue(n)
+	v, err := numericTransformValue("Maximum", n)
 	return transform{
 		t: &pb.DocumentTransform_FieldTransform{
 			TransformType: &pb.DocumentTransform_FieldTransform_Maximum{
@@ -571,8 +591,11 @@ func FieldTransformMaximum(n interface{}) transform {
 // (e.g. 3 and 3.0), the field does not change. 0, 0.0, and -0.0 are all zero.
 // The minimum of a zero stored value and zero input value is always the
 // stored value. The minimum of any numeric value x and NaN is NaN.
+//
+// Like Increment, the value can be nested in maps but cannot appear in arrays
+// or structs.
 func FieldTransformMinimum(n interface{}) transform {
-	v, err := numericTransformValue(n)
+	v, err := numericTransformValue("Minimum", n)
 	return transform{
 		t: &pb.DocumentTransform_FieldTransform{
 			TransformType: &pb.DocumentTransform_FieldTransform_Minimum{
@@ -583,13 +606,13 @@ func FieldTransformMinimum(n interface{}) transform {
 	}
 }
 
-func numericTransformValue(n interface{}) (*pb.Value, error) {
+func numericTransformValue(name string, n interface{}) (*pb.Value, error) {
 	switch n.(type) {
 	case int, int8, int16, int32, int64,
 		uint8, uint16, uint32,
 		float32, float64:
 	default:
-		return nil, fmt.Errorf("unsupported type %T for Increment; supported values include int, int8, int16, int32, int64, uint8, uint16, uint32, float32, float64", n)
+		return nil, fmt.Errorf("unsupported type %T for %s; supported values include int, int8, int16, int32, int64, uint8, uint16, uint32, float32, float64", n, name)
 	}
 
 	v, _, err := toProtoValue(reflect.ValueOf(n))
@@ -601,11 +624,11 @@ func numericTransformValue(n interface{}) (*pb.Value, error) {
 
 func fieldTransform(ar transform, fp FieldPath) (*pb.DocumentTransform_FieldTransform, error) {
 	if ar.err != nil {
-		return nil, ar.err
+		return nil, fmt.Errorf("firestore: field %s: %w", fp.toServiceFieldPath(), ar.err)
 	}
-	ft := *ar.t
+	ft := proto.Clone(ar.t).(*pb.DocumentTransform_FieldTransform)
 	ft.FieldPath = fp.toServiceFieldPath()
-	return &ft, nil
+	return ft, nil
 }
 
 type sentinel int
@@ -786,11 +809,13 @@ func iterFetch(pageSize int, pageToken string, pi *iterator.PageInfo, next func(
 }
 
 // Snapshots returns an iterator over snapshots of the document. Each time the document
-// changes or is added or deleted, a new snapshot will be generated.
-func (d *DocumentRef) Snapshots(ctx context.Context) *DocumentSnapshotIterator {
+// changes or is added or deleted, a new snapshot will be generated. The options
+// can resume listening from a resume token and control how the stream is
+// restarted after failures.
+func (d *DocumentRef) Snapshots(ctx context.Context, opts ...SnapshotOption) *DocumentSnapshotIterator {
 	return &DocumentSnapshotIterator{
 		docref: d,
-		ws:     newWatchStreamForDocument(ctx, d),
+		ws:     newWatchStreamForDocument(ctx, d, opts...),
 	}
 }
 
@@ -826,6 +851,13 @@ func (it *DocumentSnapshotIterator) Next() (*DocumentSnapshot, error) {
 	return snap.(*DocumentSnapshot), nil
 }
 
+// ResumeToken returns the resume token of the snapshot most recently returned
+// by Next, or nil if Next has not returned a snapshot. See
+// QuerySnapshotIterator.ResumeToken.
+func (it *DocumentSnapshotIterator) ResumeToken() []byte {
+	return it.ws.resumeToken
+}
+
 // Stop stops receiving snapshots. You should always call Stop when you are done with
 // a DocumentSnapshotIterator, to free up resources. It is not safe to call Stop
 // concurrently with Next.
@@ -18,6 +18,7 @@ import (
 	"context"
 	"reflect"
 	"sort"
+	"strings"
 	"testing"
 	"time"
 
@@ -135,6 +136,105 @@ func TestDocSet(t *testing.T) {
 	}
 }
 
+func TestNestedTransforms(t *testing.T) {
+	c := &Client{projectID: "P", databaseID: "D"}
+	doc := c.Doc("C/d")
+	incr := func(path string, n *pb.Value) *pb.DocumentTransform_FieldTransform {
+		return &pb.DocumentTransform_FieldTransform{
+			FieldPath:     path,
+			TransformType: &pb.DocumentTransform_FieldTransform_Increment{Increment: n},
+		}
+	}
+
+	// A transform nested in a map is not also written as a value.
+	got, err := doc.newSetWrites(map[string]interface{}{
+		"a": map[string]interface{}{"b": Increment(1), "c": 2},
+	}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []*pb.Write{{
+		Operation: &pb.Write_Update{Update: &pb.Document{
+			Name: doc.Path,
+			Fields: map[string]*pb.Value{
+				"a": mapval(map[string]*pb.Value{"c": intval(2)}),
+			},
+		}},
+		UpdateTransforms: []*pb.DocumentTransform_FieldTransform{incr("a.b", intval(1))},
+	}}
+	if !testEqual(got, want) {
+		t.Errorf("Set:\ngot  %v\nwant %v", got, want)
+	}
+
+	// Transforms on FieldPaths addressing nested fields.
+	got, err = doc.newUpdatePathWrites([]Update{
+		{FieldPath: []string{"a", "b.c"}, Value: FieldTransformMaximum(3.5)},
+		{FieldPath: []string{"a", "d"}, Value: ArrayUnion("x")},
+	}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want = []*pb.Write{{
+		Operation:       &pb.Write_Update{Update: &pb.Document{Name: doc.Path, Fields: map[string]*pb.Value{}}},
+		UpdateMask:      &pb.DocumentMask{},
+		CurrentDocument: &pb.Precondition{ConditionType: &pb.Precondition_Exists{true}},
+		UpdateTransforms: []*pb.DocumentTransform_FieldTransform{
+			{
+				FieldPath:     "a.`b.c`",
+				TransformType: &pb.DocumentTransform_FieldTransform_Maximum{Maximum: floatval(3.5)},
+			},
+			{
+				FieldPath: "a.d",
+				TransformType: &pb.DocumentTransform_FieldTransform_AppendMissingElements{
+					AppendMissingElements: &pb.ArrayValue{Values: []*pb.Value{strval("x")}},
+				},
+			},
+		},
+	}}
+	if !testEqual(got, want) {
+		t.Errorf("Update:\ngot  %v\nwant %v", got, want)
+	}
+
+	// Invalid transforms are reported before sending anything.
+	type S struct{ N interface{} }
+	for _, test := range []struct {
+		desc    string
+		data    interface{}
+		wantErr string
+	}{
+		{
+			"bad increment type",
+			map[string]interface{}{"a": map[string]interface{}{"b": Increment("1")}},
+			"firestore: field a.b: unsupported type string for Increment;",
+		},
+		{
+			"bad minimum type",
+			map[string]interface{}{"a": map[string]interface{}{"b": FieldTransformMinimum(uint64(1))}},
+			"firestore: field a.b: unsupported type uint64 for Minimum;",
+		},
+		{
+			"transform in ArrayUnion element",
+			map[string]interface{}{"a": ArrayUnion(map[string]interface{}{"t": ServerTimestamp})},
+			"firestore: ArrayUnion element 0 for field a contains a transform",
+		},
+		{
+			"transform in array",
+			map[string]interface{}{"a": []interface{}{Increment(1)}},
+			"firestore: Increment, Maximum and Minimum may not be used in structs or arrays",
+		},
+		{
+			"transform in struct",
+			map[string]interface{}{"a": S{N: Increment(1)}},
+			"firestore: Increment, Maximum and Minimum may not be used in structs or arrays",
+		},
+	} {
+		_, err := doc.newSetWrites(test.data, nil)
+		if err == nil || !strings.HasPrefix(err.Error(), test.wantErr) {
+			t.Errorf("%s: got %v, want error starting with %q", test.desc, err, test.wantErr)
+		}
+	}
+}
+
 func TestDocCreate(t *testing.T) {
 	// Verify creation with structs. In particular, make sure zero values
 	// are handled well.
@@ -0,0 +1,475 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package firestoretest provides a fake Cloud Firestore service for testing.
+// It implements a simplified form of the service in memory, suitable for unit
+// tests that would otherwise need the Firestore emulator.
+//
+// The fake supports document reads and writes, including preconditions and
+// field transforms; queries, with filters, ordering, cursors, offsets, limits
+// and projections; aggregation queries; partition queries; read-only and
+// read-write transactions; reads at a past time; and listeners.
+//
+// It may behave differently from the actual service in ways in which the
+// service is non-deterministic or unspecified, and it does not enforce
+// security rules, indexes or quotas. Read-write transactions are optimistic:
+// instead of locking the documents a transaction reads, the fake aborts the
+// commit if any of them have changed since they were read.
+//
+// This package is EXPERIMENTAL and is subject to change without notice.
+//
+// See the example for usage.
+package firestoretest
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	pb "cloud.google.com/go/firestore/apiv1/firestorepb"
+	"cloud.google.com/go/internal/testutil"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Server is a fake Firestore server.
+type Server struct {
+	Addr string // The address that the server is listening on.
+
+	srv *testutil.Server
+	s   *server
+}
+
+// server implements the Firestore service.
+type server struct {
+	pb.UnimplementedFirestoreServer
+
+	mu      sync.Mutex
+	docs    map[string][]version    // document versions by name, oldest first
+	commits []time.Time             // commits[i] is the time of commit i+1
+	txns    map[string]*transaction // open transactions by ID
+	nextTxn int
+	changed chan struct{} // closed and replaced after each commit
+}
+
+// version is a document as of a commit. A nil doc marks a deletion.
+type version struct {
+	seq int64
+	doc *pb.Document
+}
+
+type transaction struct {
+	readOnly bool
+	seq      int64            // for read-only transactions, the commit to read at
+	reads    map[string]int64 // for read-write transactions, the version of each document read
+}
+
+// NewServer creates a new fake server running in the current process.
+func NewServer() (*Server, error) {
+	srv, err := testutil.NewServer()
+	if err != nil {
+		return nil, fmt.Errorf("firestoretest.NewServer: %w", err)
+	}
+	s := &server{
+		docs:    map[string][]version{},
+		txns:    map[string]*transaction{},
+		changed: make(chan struct{}),
+	}
+	pb.RegisterFirestoreServer(srv.Gsrv, s)
+	srv.Start()
+	return &Server{Addr: srv.Addr, srv: srv, s: s}, nil
+}
+
+// Close shuts down the server.
+func (s *Server) Close() {
+	s.srv.Close()
+}
+
+// seq returns the sequence number of the latest commit.
+func (s *server) seq() int64 {
+	return int64(len(s.commits))
+}
+
+// commitTime returns the time of commit seq.
+func (s *server) commitTime(seq int64) time.Time {
+	if seq == 0 {
+		return time.Unix(0, 0).UTC()
+	}
+	return s.commits[seq-1]
+}
+
+// seqAt returns the sequence number of the latest commit at or before t.
+func (s *server) seqAt(t time.Time) int64 {
+	return int64(sort.Search(len(s.commits), func(i int) bool {
+		return s.commits[i].After(t)
+	}))
+}
+
+// docAt returns the document named name as of commit seq, or nil if it did
+// not exist.
+func (s *server) docAt(name string, seq int64) *pb.Document {
+	vs := s.docs[name]
+	for i := len(vs) - 1; i >= 0; i-- {
+		if vs[i].seq <= seq {
+			return vs[i].doc
+		}
+	}
+	return nil
+}
+
+// latestSeq returns the sequence number of the last write to the document
+// named name.
+func (s *server) latestSeq(name string) int64 {
+	if vs := s.docs[name]; len(vs) > 0 {
+		return vs[len(vs)-1].seq
+	}
+	return 0
+}
+
+// docsAt returns the documents that exist as of commit seq and whose names
+// have the given prefix, sorted by name.
+func (s *server) docsAt(prefix string, seq int64) []*pb.Document {
+	var res []*pb.Document
+	for name := range s.docs {
+		if !strings.HasPrefix(name, prefix) {
+			continue
+		}
+		if d := s.docAt(name, seq); d != nil {
+			res = append(res, d)
+		}
+	}
+	sort.Slice(res, func(i, j int) bool { return compareNames(res[i].Name, res[j].Name) < 0 })
+	return res
+}
+
+// readContext describes how a read operation reads the database.
+type readContext struct {
+	seq   int64
+	txn   *transaction
+	txnID []byte // set if the read began a new transaction
+}
+
+// newReadContext interprets the consistency selector of a read request.
+// At most one of txnID, newTxn and readTime may be set. s.mu must be held.
+func (s *server) newReadContext(txnID []byte, newTxn *pb.TransactionOptions, readTime *timestamppb.Timestamp) (*readContext, error) {
+	switch {
+	case txnID != nil:
+		t, ok := s.txns[string(txnID)]
+		if !ok {
+			return nil, status.Errorf(codes.InvalidArgument, "transaction %q not found", txnID)
+		}
+		rc := &readContext{seq: s.seq(), txn: t}
+		if t.readOnly {
+			rc.seq = t.seq
+		}
+		return rc, nil
+	case newTxn != nil:
+		id, t, err := s.beginTransaction(newTxn)
+		if err != nil {
+			return nil, err
+		}
+		rc := &readContext{seq: s.seq(), txn: t, txnID: id}
+		if t.readOnly {
+			rc.seq = t.seq
+		}
+		return rc, nil
+	case readTime != nil:
+		if err := readTime.CheckValid(); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid read time: %v", err)
+		}
+		return &readContext{seq: s.seqAt(readTime.AsTime())}, nil
+	}
+	return &readContext{seq: s.seq()}, nil
+}
+
+// get reads the document named name, recording the read in a read-write
+// transaction.
+func (s *server) get(rc *readContext, name string) *pb.Document {
+	if rc.txn != nil && !rc.txn.readOnly {
+		rc.txn.reads[name] = s.latestSeq(name)
+	}
+	return s.docAt(name, rc.seq)
+}
+
+// readTime returns the time of commit seq as a proto timestamp.
+func (s *server) readTime(seq int64) *timestamppb.Timestamp {
+	return timestamppb.New(s.commitTime(seq))
+}
+
+// beginTransaction starts a transaction. s.mu must be held.
+func (s *server) beginTransaction(opts *pb.TransactionOptions) ([]byte, *transaction, error) {
+	t := &transaction{reads: map[string]int64{}}
+	if ro := opts.GetReadOnly(); ro != nil {
+		t.readOnly = true
+		t.seq = s.seq()
+		if rt := ro.GetReadTime(); rt != nil {
+			if err := rt.CheckValid(); err != nil {
+				return nil, nil, status.Errorf(codes.InvalidArgument, "invalid read time: %v", err)
+			}
+			t.seq = s.seqAt(rt.AsTime())
+		}
+	}
+	s.nextTxn++
+	id := make([]byte, 8)
+	binary.BigEndian.PutUint64(id, uint64(s.nextTxn))
+	s.txns[string(id)] = t
+	return id, t, nil
+}
+
+// BeginTransaction implements the BeginTransaction RPC.
+func (s *server) BeginTransaction(_ context.Context, req *pb.BeginTransactionRequest) (*pb.BeginTransactionResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	id, _, err := s.beginTransaction(req.Options)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.BeginTransactionResponse{Transaction: id}, nil
+}
+
+// Rollback implements the Rollback RPC.
+func (s *server) Rollback(_ context.Context, req *pb.RollbackRequest) (*emptypb.Empty, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.txns, string(req.Transaction))
+	return &emptypb.Empty{}, nil
+}
+
+// GetDocument implements the GetDocument RPC.
+func (s *server) GetDocument(_ context.Context, req *pb.GetDocumentRequest) (*pb.Document, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	rc, err := s.newReadContext(req.GetTransaction(), nil, req.GetReadTime())
+	if err != nil {
+		return nil, err
+	}
+	doc := s.get(rc, req.Name)
+	if doc == nil {
+		return nil, status.Errorf(codes.NotFound, "document %q not found", req.Name)
+	}
+	return projectDoc(doc, req.Mask.GetFieldPaths()), nil
+}
+
+// BatchGetDocuments implements the BatchGetDocuments RPC.
+func (s *server) BatchGetDocuments(req *pb.BatchGetDocumentsRequest, stream pb.Firestore_BatchGetDocumentsServer) error {
+	s.mu.Lock()
+	rc, err := s.newReadContext(req.GetTransaction(), req.GetNewTransaction(), req.GetReadTime())
+	if err != nil {
+		s.mu.Unlock()
+		return err
+	}
+	var resps []*pb.BatchGetDocumentsResponse
+	for _, name := range req.Documents {
+		if err := validateDocName(name); err != nil {
+			s.mu.Unlock()
+			return status.Error(codes.InvalidArgument, err.Error())
+		}
+		res := &pb.BatchGetDocumentsResponse{ReadTime: s.readTime(rc.seq)}
+		if doc := s.get(rc, name); doc != nil {
+			res.Result = &pb.BatchGetDocumentsResponse_Found{Found: projectDoc(doc, req.Mask.GetFieldPaths())}
+		} else {
+			res.Result = &pb.BatchGetDocumentsResponse_Missing{Missing: name}
+		}
+		resps = append(resps, res)
+	}
+	s.mu.Unlock()
+
+	if rc.txnID != nil {
+		if len(resps) == 0 {
+			resps = append(resps, &pb.BatchGetDocumentsResponse{})
+		}
+		resps[0].Transaction = rc.txnID
+	}
+	for _, res := range resps {
+		if err := stream.Send(res); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ListDocuments implements the ListDocuments RPC.
+func (s *server) ListDocuments(_ context.Context, req *pb.ListDocumentsRequest) (*pb.ListDocumentsResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	rc, err := s.newReadContext(req.GetTransaction(), nil, req.GetReadTime())
+	if err != nil {
+		return nil, err
+	}
+	prefix := req.Parent + "/" + req.CollectionId + "/"
+	var names []string
+	seen := map[string]bool{}
+	for _, d := range s.docsAt(prefix, rc.seq) {
+		id := strings.SplitN(strings.TrimPrefix(d.Name, prefix), "/", 2)[0]
+		name := prefix + id
+		if seen[name] {
+			continue
+		}
+		// A document that does not exist but has subcollections is missing.
+		if name == d.Name || req.ShowMissing {
+			seen[name] = true
+			names = append(names, name)
+		}
+	}
+	sort.Slice(names, func(i, j int) bool { return compareNames(names[i], names[j]) < 0 })
+
+	res := &pb.ListDocumentsResponse{}
+	for _, name := range names {
+		if req.PageToken != "" && compareNames(name, req.PageToken) <= 0 {
+			continue
+		}
+		if req.PageSize > 0 && len(res.Documents) == int(req.PageSize) {
+			res.NextPageToken = res.Documents[len(res.Documents)-1].Name
+			break
+		}
+		doc := s.get(rc, name)
+		if doc == nil {
+			doc = &pb.Document{Name: name}
+		} else if req.Mask != nil {
+			doc = projectDoc(doc, req.Mask.FieldPaths)
+		}
+		res.Documents = append(res.Documents, doc)
+	}
+	return res, nil
+}
+
+// ListCollectionIds implements the ListCollectionIds RPC.
+func (s *server) ListCollectionIds(_ context.Context, req *pb.ListCollectionIdsRequest) (*pb.ListCollectionIdsResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	rc, err := s.newReadContext(nil, nil, req.GetReadTime())
+	if err != nil {
+		return nil, err
+	}
+	prefix := req.Parent + "/"
+	seen := map[string]bool{}
+	var ids []string
+	for _, d := range s.docsAt(prefix, rc.seq) {
+		id := strings.SplitN(strings.TrimPrefix(d.Name, prefix), "/", 2)[0]
+		if !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+
+	res := &pb.ListCollectionIdsResponse{}
+	for _, id := range ids {
+		if req.PageToken != "" && id <= req.PageToken {
+			continue
+		}
+		if req.PageSize > 0 && len(res.CollectionIds) == int(req.PageSize) {
+			res.NextPageToken = res.CollectionIds[len(res.CollectionIds)-1]
+			break
+		}
+		res.CollectionIds = append(res.CollectionIds, id)
+	}
+	return res, nil
+}
+
+// Commit implements the Commit RPC.
+func (s *server) Commit(_ context.Context, req *pb.CommitRequest) (*pb.CommitResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if req.Transaction != nil {
+		t, ok := s.txns[string(req.Transaction)]
+		if !ok {
+			return nil, status.Errorf(codes.InvalidArgument, "transaction %q not found", req.Transaction)
+		}
+		delete(s.txns, string(req.Transaction))
+		if t.readOnly {
+			return nil, status.Error(codes.InvalidArgument, "cannot commit a read-only transaction")
+		}
+		for name, seq := range t.reads {
+			if s.latestSeq(name) != seq {
+				return nil, status.Errorf(codes.Aborted, "document %q changed during the transaction", name)
+			}
+		}
+	}
+	results, commitTime, err := s.commit(req.Writes)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.CommitResponse{WriteResults: results, CommitTime: commitTime}, nil
+}
+
+// BatchWrite implements the BatchWrite RPC. Each write is applied separately.
+func (s *server) BatchWrite(_ context.Context, req *pb.BatchWriteRequest) (*pb.BatchWriteResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	res := &pb.BatchWriteResponse{}
+	for _, w := range req.Writes {
+		results, _, err := s.commit([]*pb.Write{w})
+		if err != nil {
+			res.WriteResults = append(res.WriteResults, &pb.WriteResult{})
+			res.Status = append(res.Status, status.Convert(err).Proto())
+			continue
+		}
+		res.WriteResults = append(res.WriteResults, results[0])
+		res.Status = append(res.Status, status.New(codes.OK, "").Proto())
+	}
+	return res, nil
+}
+
+// commit atomically applies writes as a new commit and notifies listeners.
+// If the writes fail, nothing is applied. s.mu must be held.
+func (s *server) commit(writes []*pb.Write) ([]*pb.WriteResult, *timestamppb.Timestamp, error) {
+	now := time.Now().UTC().Truncate(time.Microsecond)
+	if last := s.commitTime(s.seq()); !now.After(last) {
+		now = last.Add(time.Microsecond)
+	}
+	seq := s.seq() + 1
+	ts := timestamppb.New(now)
+
+	pending := map[string]*pb.Document{}
+	var order []string
+	var results []*pb.WriteResult
+	for _, w := range writes {
+		name := writeName(w)
+		if err := validateDocName(name); err != nil {
+			return nil, nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		cur, ok := pending[name]
+		if !ok {
+			cur = s.docAt(name, seq-1)
+			order = append(order, name)
+		}
+		doc, transformResults, err := applyWrite(cur, w, ts)
+		if err != nil {
+			return nil, nil, err
+		}
+		pending[name] = doc
+		results = append(results, &pb.WriteResult{UpdateTime: ts, TransformResults: transformResults})
+	}
+
+	for _, name := range order {
+		s.docs[name] = append(s.docs[name], version{seq: seq, doc: pending[name]})
+	}
+	s.commits = append(s.commits, now)
+	close(s.changed)
+	s.changed = make(chan struct{})
+	return results, ts, nil
+}
+
+// cloneDoc returns a deep copy of d.
+func cloneDoc(d *pb.Document) *pb.Document {
+	return proto.Clone(d).(*pb.Document)
+}
@@ -0,0 +1,394 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package firestoretest
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/firestore"
+	pb "cloud.google.com/go/firestore/apiv1/firestorepb"
+	"cloud.google.com/go/internal/testutil"
+	"google.golang.org/api/iterator"
+	"google.golang.org/api/option"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func newClient(t *testing.T) (*firestore.Client, *Server) {
+	t.Helper()
+	srv, err := NewServer()
+	if err != nil {
+		t.Fatal(err)
+	}
+	conn, err := grpc.Dial(srv.Addr, grpc.WithInsecure())
+	if err != nil {
+		t.Fatal(err)
+	}
+	c, err := firestore.NewClient(context.Background(), "P", option.WithGRPCConn(conn))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		c.Close()
+		conn.Close()
+		srv.Close()
+	})
+	return c, srv
+}
+
+func TestDocuments(t *testing.T) {
+	ctx := context.Background()
+	c, _ := newClient(t)
+	doc := c.Doc("C/a")
+
+	wr, err := doc.Create(ctx, map[string]interface{}{"n": 1, "m": map[string]interface{}{"x": "y"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := doc.Create(ctx, map[string]interface{}{}); status.Code(err) != codes.AlreadyExists {
+		t.Errorf("second Create: got %v, want AlreadyExists", err)
+	}
+	ds, err := doc.Get(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !ds.CreateTime.Equal(wr.UpdateTime) || !ds.UpdateTime.Equal(wr.UpdateTime) {
+		t.Errorf("got create time %v and update time %v, want %v", ds.CreateTime, ds.UpdateTime, wr.UpdateTime)
+	}
+
+	if _, err := doc.Set(ctx, map[string]interface{}{"m": map[string]interface{}{"z": 2}}, firestore.MergeAll); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := doc.Update(ctx, []firestore.Update{
+		{Path: "n", Value: firestore.Increment(2)},
+		{Path: "a", Value: firestore.ArrayUnion(1, 2)},
+		{Path: "t", Value: firestore.ServerTimestamp},
+		{Path: "m.x", Value: firestore.Delete},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	ds, err = doc.Get(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := ds.Data()
+	if !got["t"].(time.Time).Equal(ds.UpdateTime) {
+		t.Errorf("server timestamp: got %v, want %v", got["t"], ds.UpdateTime)
+	}
+	delete(got, "t")
+	want := map[string]interface{}{
+		"n": int64(3),
+		"a": []interface{}{int64(1), int64(2)},
+		"m": map[string]interface{}{"z": int64(2)},
+	}
+	if !testutil.Equal(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+
+	if _, err := c.Doc("C/missing").Update(ctx, []firestore.Update{{Path: "n", Value: 1}}); status.Code(err) != codes.NotFound {
+		t.Errorf("Update of missing document: got %v, want NotFound", err)
+	}
+	if _, err := doc.Delete(ctx, firestore.LastUpdateTime(wr.UpdateTime)); status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("Delete with stale update time: got %v, want FailedPrecondition", err)
+	}
+
+	if _, err := c.Doc("C/b/D/c").Set(ctx, map[string]interface{}{}); err != nil {
+		t.Fatal(err)
+	}
+	refs, err := c.Collection("C").DocumentRefs(ctx).GetAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var ids []string
+	for _, r := range refs {
+		ids = append(ids, r.ID)
+	}
+	if want := []string{"a", "b"}; !testutil.Equal(ids, want) {
+		t.Errorf("DocumentRefs: got %v, want %v", ids, want)
+	}
+	colls, err := c.Doc("C/b").Collections(ctx).GetAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(colls) != 1 || colls[0].ID != "D" {
+		t.Errorf("Collections: got %v, want [D]", colls)
+	}
+
+	// Read times have a granularity of seconds.
+	time.Sleep(time.Until(ds.UpdateTime.Truncate(time.Second).Add(time.Second)))
+	readTime := time.Now()
+	if _, err := doc.Delete(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := doc.Get(ctx); status.Code(err) != codes.NotFound {
+		t.Errorf("Get after Delete: got %v, want NotFound", err)
+	}
+	old, err := doc.WithReadOptions(firestore.ReadTime(readTime)).Get(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := old.Data()["n"]; got != int64(3) {
+		t.Errorf("read at %v: got n=%v, want 3", readTime, got)
+	}
+}
+
+func TestQueries(t *testing.T) {
+	ctx := context.Background()
+	c, _ := newClient(t)
+	coll := c.Collection("C")
+	for id, data := range map[string]map[string]interface{}{
+		"a": {"n": 1, "s": "x", "tags": []interface{}{"red"}},
+		"b": {"n": 2, "s": "y", "tags": []interface{}{"red", "blue"}},
+		"c": {"n": 3.5, "s": "x"},
+		"d": {"n": "str"},
+		"e": {"s": "z"},
+	} {
+		if _, err := coll.Doc(id).Create(ctx, data); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := c.Doc("C/a/C/f").Create(ctx, map[string]interface{}{"n": 4}); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, test := range []struct {
+		name string
+		q    firestore.Query
+		want []string
+	}{
+		{"all", coll.Query, []string{"a", "b", "c", "d", "e"}},
+		{"equal", coll.Where("s", "==", "x"), []string{"a", "c"}},
+		{"range", coll.Where("n", ">", 1), []string{"b", "c"}},
+		{"not equal", coll.Where("s", "!=", "x"), []string{"b", "e"}},
+		{"in", coll.Where("n", "in", []int{1, 3}), []string{"a"}},
+		{"not in", coll.Where("n", "not-in", []interface{}{1, "str"}), []string{"b", "c"}},
+		{"array contains", coll.Where("tags", "array-contains", "blue"), []string{"b"}},
+		{"array contains any", coll.Where("tags", "array-contains-any", []string{"red", "green"}), []string{"a", "b"}},
+		{"order", coll.OrderBy("n", firestore.Desc), []string{"d", "c", "b", "a"}},
+		{"start after", coll.OrderBy("n", firestore.Asc).StartAfter(1), []string{"b", "c", "d"}},
+		{"end before", coll.OrderBy("n", firestore.Asc).EndBefore(3.5), []string{"a", "b"}},
+		{"offset and limit", coll.OrderBy("s", firestore.Asc).Offset(1).Limit(2), []string{"c", "b"}},
+		{"limit to last", coll.OrderBy("n", firestore.Asc).LimitToLast(2), []string{"c", "d"}},
+		{"or", coll.WhereEntity(firestore.OrFilter{Filters: []firestore.EntityFilter{
+			firestore.PropertyFilter{Path: "n", Operator: "==", Value: 2},
+			firestore.PropertyFilter{Path: "s", Operator: "==", Value: "z"},
+		}}), []string{"b", "e"}},
+		{"collection group", c.CollectionGroup("C").Where("n", ">=", 3), []string{"c", "f"}},
+	} {
+		docs, err := test.q.Documents(ctx).GetAll()
+		if err != nil {
+			t.Errorf("%s: %v", test.name, err)
+			continue
+		}
+		var got []string
+		for _, d := range docs {
+			got = append(got, d.Ref.ID)
+		}
+		if !testutil.Equal(got, test.want) {
+			t.Errorf("%s: got %v, want %v", test.name, got, test.want)
+		}
+	}
+
+	docs, err := coll.Select("s").Where("n", "==", 1).Documents(ctx).GetAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := map[string]interface{}{"s": "x"}; len(docs) != 1 || !testutil.Equal(docs[0].Data(), want) {
+		t.Errorf("Select: got %v, want one document with %v", docs, want)
+	}
+}
+
+func TestAggregationQueries(t *testing.T) {
+	ctx := context.Background()
+	c, _ := newClient(t)
+	coll := c.Collection("C")
+	for i, n := range []interface{}{1, 2, 4.5, "x"} {
+		if _, err := coll.NewDoc().Create(ctx, map[string]interface{}{"n": n, "i": i}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	q := coll.Where("i", "<", 3)
+	res, err := q.NewAggregationQuery().
+		WithCount("count").
+		WithSum("n", "sum").
+		WithAvg("n", "avg").
+		Get(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := firestore.AggregationResult{
+		"count": &pb.Value{ValueType: &pb.Value_IntegerValue{IntegerValue: 3}},
+		"sum":   &pb.Value{ValueType: &pb.Value_DoubleValue{DoubleValue: 7.5}},
+		"avg":   &pb.Value{ValueType: &pb.Value_DoubleValue{DoubleValue: 2.5}},
+	}
+	if !testutil.Equal(res, want) {
+		t.Errorf("got %v, want %v", res, want)
+	}
+}
+
+func TestTransactions(t *testing.T) {
+	ctx := context.Background()
+	c, _ := newClient(t)
+	doc := c.Doc("C/counter")
+	if _, err := doc.Create(ctx, map[string]interface{}{"n": 0}); err != nil {
+		t.Fatal(err)
+	}
+
+	// Concurrent read-modify-write transactions must not lose updates.
+	const workers = 5
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			err := c.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+				ds, err := tx.Get(doc)
+				if err != nil {
+					return err
+				}
+				return tx.Set(doc, map[string]interface{}{"n": ds.Data()["n"].(int64) + 1})
+			}, firestore.MaxAttempts(50))
+			if err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+	ds, err := doc.Get(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := ds.Data()["n"]; got != int64(workers) {
+		t.Errorf("got n=%v, want %d", got, workers)
+	}
+
+	err = c.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		return tx.Delete(doc)
+	}, firestore.ReadOnly)
+	if err == nil {
+		t.Error("write in read-only transaction: got nil, want error")
+	}
+}
+
+func TestListen(t *testing.T) {
+	ctx := context.Background()
+	c, _ := newClient(t)
+	coll := c.Collection("C")
+	if _, err := coll.Doc("a").Create(ctx, map[string]interface{}{"n": 1}); err != nil {
+		t.Fatal(err)
+	}
+
+	it := coll.Where("n", ">", 0).Snapshots(ctx)
+	defer it.Stop()
+	next := func() *firestore.QuerySnapshot {
+		t.Helper()
+		qs, err := it.Next()
+		if err != nil {
+			t.Fatal(err)
+		}
+		return qs
+	}
+	if qs := next(); qs.Size != 1 || len(qs.Changes) != 1 || qs.Changes[0].Kind != firestore.DocumentAdded {
+		t.Fatalf("initial snapshot: got size %d and changes %v", qs.Size, qs.Changes)
+	}
+
+	if _, err := coll.Doc("b").Create(ctx, map[string]interface{}{"n": 2}); err != nil {
+		t.Fatal(err)
+	}
+	if qs := next(); qs.Size != 2 || len(qs.Changes) != 1 || qs.Changes[0].Doc.Ref.ID != "b" {
+		t.Fatalf("after add: got size %d and changes %v", qs.Size, qs.Changes)
+	}
+
+	// Moving a document out of the query removes it.
+	if _, err := coll.Doc("a").Set(ctx, map[string]interface{}{"n": 0}); err != nil {
+		t.Fatal(err)
+	}
+	qs := next()
+	if qs.Size != 1 || len(qs.Changes) != 1 || qs.Changes[0].Kind != firestore.DocumentRemoved {
+		t.Fatalf("after remove: got size %d and changes %v", qs.Size, qs.Changes)
+	}
+
+	// Resuming sends only the changes since the token.
+	token := it.ResumeToken()
+	if _, err := coll.Doc("c").Create(ctx, map[string]interface{}{"n": 3}); err != nil {
+		t.Fatal(err)
+	}
+	it2 := coll.Where("n", ">", 0).Snapshots(ctx, firestore.ResumeFromToken(token))
+	defer it2.Stop()
+	qs, err := it2.Next()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(qs.Changes) != 1 || qs.Changes[0].Doc.Ref.ID != "c" {
+		t.Errorf("resumed snapshot: got changes %v, want only c", qs.Changes)
+	}
+
+	dit := coll.Doc("d").Snapshots(ctx)
+	defer dit.Stop()
+	ds, err := dit.Next()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ds.Exists() {
+		t.Error("got existing document, want missing")
+	}
+	if _, err := coll.Doc("d").Create(ctx, map[string]interface{}{"n": 4}); err != nil {
+		t.Fatal(err)
+	}
+	if ds, err = dit.Next(); err != nil {
+		t.Fatal(err)
+	}
+	if !ds.Exists() || ds.Data()["n"] != int64(4) {
+		t.Errorf("got %v, want document with n=4", ds.Data())
+	}
+}
+
+func TestPartitionQuery(t *testing.T) {
+	ctx := context.Background()
+	c, _ := newClient(t)
+	for _, path := range []string{"A/a/G/1", "A/b/G/2", "G/3", "G/4", "B/c/G/5", "G/6"} {
+		if _, err := c.Doc(path).Create(ctx, map[string]interface{}{}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	qs, err := c.CollectionGroup("G").GetPartitionedQueries(ctx, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(qs) != 3 {
+		t.Fatalf("got %d partitions, want 3", len(qs))
+	}
+	n := 0
+	for _, q := range qs {
+		it := q.Documents(ctx)
+		for {
+			_, err := it.Next()
+			if err == iterator.Done {
+				break
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			n++
+		}
+	}
+	if n != 6 {
+		t.Errorf("got %d documents in all partitions, want 6", n)
+	}
+}
@@ -0,0 +1,221 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package firestoretest
+
+import (
+	"encoding/binary"
+	"io"
+
+	pb "cloud.google.com/go/firestore/apiv1/firestorepb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// A listenTarget is a target added to a Listen stream.
+type listenTarget struct {
+	target  *pb.Target
+	current bool                    // whether CURRENT has been sent
+	sent    map[string]*pb.Document // the documents last sent to the client
+}
+
+// Listen implements the Listen RPC.
+//
+// The resume token of a target is the sequence number of the commit that the
+// target is up to date with. When a target is resumed, only the changes since
+// that commit are sent.
+func (s *server) Listen(stream pb.Firestore_ListenServer) error {
+	reqs := make(chan *pb.ListenRequest)
+	errc := make(chan error, 1)
+	go func() {
+		for {
+			req, err := stream.Recv()
+			if err != nil {
+				errc <- err
+				return
+			}
+			select {
+			case reqs <- req:
+			case <-stream.Context().Done():
+				return
+			}
+		}
+	}()
+
+	targets := map[int32]*listenTarget{}
+	for {
+		s.mu.Lock()
+		changed := s.changed
+		resps, err := s.listenChanges(targets)
+		s.mu.Unlock()
+		if err != nil {
+			return err
+		}
+		for _, res := range resps {
+			if err := stream.Send(res); err != nil {
+				return err
+			}
+		}
+
+		select {
+		case <-changed:
+		case req := <-reqs:
+			resps, err := s.handleListenRequest(targets, req)
+			if err != nil {
+				return err
+			}
+			for _, res := range resps {
+				if err := stream.Send(res); err != nil {
+					return err
+				}
+			}
+		case err := <-errc:
+			if err == io.EOF {
+				return nil
+			}
+			return err
+		case <-stream.Context().Done():
+			return stream.Context().Err()
+		}
+	}
+}
+
+// handleListenRequest adds or removes a target.
+func (s *server) handleListenRequest(targets map[int32]*listenTarget, req *pb.ListenRequest) ([]*pb.ListenResponse, error) {
+	switch tc := req.TargetChange.(type) {
+	case *pb.ListenRequest_AddTarget:
+		t := tc.AddTarget
+		if _, ok := targets[t.TargetId]; ok {
+			return nil, status.Errorf(codes.InvalidArgument, "target %d already exists", t.TargetId)
+		}
+		lt := &listenTarget{target: t, sent: map[string]*pb.Document{}}
+		if t.ResumeType != nil {
+			s.mu.Lock()
+			seq, err := s.resumeSeq(t)
+			if err == nil {
+				docs, err2 := s.targetDocs(t, seq)
+				err = err2
+				for _, d := range docs {
+					lt.sent[d.Name] = d
+				}
+			}
+			s.mu.Unlock()
+			if err != nil {
+				return nil, err
+			}
+		}
+		targets[t.TargetId] = lt
+		return []*pb.ListenResponse{targetChange(pb.TargetChange_ADD, t.TargetId)}, nil
+	case *pb.ListenRequest_RemoveTarget:
+		delete(targets, tc.RemoveTarget)
+		return []*pb.ListenResponse{targetChange(pb.TargetChange_REMOVE, tc.RemoveTarget)}, nil
+	}
+	return nil, status.Errorf(codes.InvalidArgument, "unknown target change %T", req.TargetChange)
+}
+
+// resumeSeq returns the sequence number of the commit that t resumes from.
+func (s *server) resumeSeq(t *pb.Target) (int64, error) {
+	if tok := t.GetResumeToken(); tok != nil {
+		if len(tok) != 8 {
+			return 0, status.Error(codes.InvalidArgument, "invalid resume token")
+		}
+		seq := int64(binary.BigEndian.Uint64(tok))
+		if seq > s.seq() {
+			return 0, status.Error(codes.InvalidArgument, "invalid resume token")
+		}
+		return seq, nil
+	}
+	if err := t.GetReadTime().CheckValid(); err != nil {
+		return 0, status.Errorf(codes.InvalidArgument, "invalid read time: %v", err)
+	}
+	return s.seqAt(t.GetReadTime().AsTime()), nil
+}
+
+// targetDocs returns the documents that match t as of commit seq.
+func (s *server) targetDocs(t *pb.Target, seq int64) ([]*pb.Document, error) {
+	switch tt := t.TargetType.(type) {
+	case *pb.Target_Documents:
+		var docs []*pb.Document
+		for _, name := range tt.Documents.Documents {
+			if d := s.docAt(name, seq); d != nil {
+				docs = append(docs, d)
+			}
+		}
+		return docs, nil
+	case *pb.Target_Query:
+		sq := tt.Query.GetStructuredQuery()
+		if sq == nil {
+			return nil, status.Error(codes.InvalidArgument, "missing structured query")
+		}
+		return s.runQuery(&readContext{seq: seq}, tt.Query.Parent, sq)
+	}
+	return nil, status.Errorf(codes.InvalidArgument, "unknown target type %T", t.TargetType)
+}
+
+// listenChanges returns the responses that bring the targets up to date with
+// the latest commit, and updates the targets. s.mu must be held.
+func (s *server) listenChanges(targets map[int32]*listenTarget) ([]*pb.ListenResponse, error) {
+	if len(targets) == 0 {
+		return nil, nil
+	}
+	seq := s.seq()
+	var resps []*pb.ListenResponse
+	for id, lt := range targets {
+		docs, err := s.targetDocs(lt.target, seq)
+		if err != nil {
+			return nil, err
+		}
+		matches := map[string]*pb.Document{}
+		for _, d := range docs {
+			matches[d.Name] = d
+			if old, ok := lt.sent[d.Name]; !ok || !proto.Equal(old, d) {
+				resps = append(resps, &pb.ListenResponse{ResponseType: &pb.ListenResponse_DocumentChange{
+					DocumentChange: &pb.DocumentChange{Document: d, TargetIds: []int32{id}},
+				}})
+			}
+		}
+		for name := range lt.sent {
+			if _, ok := matches[name]; ok {
+				continue
+			}
+			if s.docAt(name, seq) == nil {
+				resps = append(resps, &pb.ListenResponse{ResponseType: &pb.ListenResponse_DocumentDelete{
+					DocumentDelete: &pb.DocumentDelete{Document: name, RemovedTargetIds: []int32{id}},
+				}})
+			} else {
+				resps = append(resps, &pb.ListenResponse{ResponseType: &pb.ListenResponse_DocumentRemove{
+					DocumentRemove: &pb.DocumentRemove{Document: name, RemovedTargetIds: []int32{id}},
+				}})
+			}
+		}
+		lt.sent = matches
+		if !lt.current {
+			lt.current = true
+			resps = append(resps, targetChange(pb.TargetChange_CURRENT, id))
+		}
+	}
+	token := make([]byte, 8)
+	binary.BigEndian.PutUint64(token, uint64(seq))
+	res := targetChange(pb.TargetChange_NO_CHANGE)
+	res.GetTargetChange().ResumeToken = token
+	res.GetTargetChange().ReadTime = s.readTime(seq)
+	return append(resps, res), nil
+}
+
+func targetChange(typ pb.TargetChange_TargetChangeType, ids ...int32) *pb.ListenResponse {
+	return &pb.ListenResponse{ResponseType: &pb.ListenResponse_TargetChange{
+		TargetChange: &pb.TargetChange{TargetChangeType: typ, TargetIds: ids},
+	}}
+}
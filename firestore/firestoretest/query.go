@@ -0,0 +1,471 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package firestoretest
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	pb "cloud.google.com/go/firestore/apiv1/firestorepb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// RunQuery implements the RunQuery RPC.
+func (s *server) RunQuery(req *pb.RunQueryRequest, stream pb.Firestore_RunQueryServer) error {
+	sq := req.GetStructuredQuery()
+	if sq == nil {
+		return status.Error(codes.InvalidArgument, "missing structured query")
+	}
+	s.mu.Lock()
+	rc, err := s.newReadContext(req.GetTransaction(), req.GetNewTransaction(), req.GetReadTime())
+	if err != nil {
+		s.mu.Unlock()
+		return err
+	}
+	docs, err := s.runQuery(rc, req.Parent, sq)
+	readTime := s.readTime(rc.seq)
+	s.mu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	var resps []*pb.RunQueryResponse
+	for _, d := range docs {
+		resps = append(resps, &pb.RunQueryResponse{Document: d, ReadTime: readTime})
+	}
+	if len(resps) == 0 {
+		resps = append(resps, &pb.RunQueryResponse{ReadTime: readTime})
+	}
+	resps[0].Transaction = rc.txnID
+	for _, res := range resps {
+		if err := stream.Send(res); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RunAggregationQuery implements the RunAggregationQuery RPC.
+func (s *server) RunAggregationQuery(req *pb.RunAggregationQueryRequest, stream pb.Firestore_RunAggregationQueryServer) error {
+	aq := req.GetStructuredAggregationQuery()
+	if aq == nil || aq.GetStructuredQuery() == nil {
+		return status.Error(codes.InvalidArgument, "missing structured aggregation query")
+	}
+	s.mu.Lock()
+	rc, err := s.newReadContext(req.GetTransaction(), req.GetNewTransaction(), req.GetReadTime())
+	if err != nil {
+		s.mu.Unlock()
+		return err
+	}
+	docs, err := s.runQuery(rc, req.Parent, aq.GetStructuredQuery())
+	readTime := s.readTime(rc.seq)
+	s.mu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	fields := map[string]*pb.Value{}
+	for _, a := range aq.Aggregations {
+		if _, ok := fields[a.Alias]; ok || a.Alias == "" {
+			return status.Errorf(codes.InvalidArgument, "invalid or duplicate aggregation alias %q", a.Alias)
+		}
+		v, err := aggregate(a, docs)
+		if err != nil {
+			return err
+		}
+		fields[a.Alias] = v
+	}
+	return stream.Send(&pb.RunAggregationQueryResponse{
+		Result:      &pb.AggregationResult{AggregateFields: fields},
+		Transaction: rc.txnID,
+		ReadTime:    readTime,
+	})
+}
+
+// aggregate computes the aggregation a over docs.
+func aggregate(a *pb.StructuredAggregationQuery_Aggregation, docs []*pb.Document) (*pb.Value, error) {
+	switch op := a.Operator.(type) {
+	case *pb.StructuredAggregationQuery_Aggregation_Count_:
+		n := int64(len(docs))
+		if upTo := op.Count.GetUpTo(); upTo != nil && upTo.Value < n {
+			n = upTo.Value
+		}
+		return &pb.Value{ValueType: &pb.Value_IntegerValue{IntegerValue: n}}, nil
+	case *pb.StructuredAggregationQuery_Aggregation_Sum_:
+		nums := numbers(docs, op.Sum.GetField().GetFieldPath())
+		var (
+			isum    int64
+			fsum    float64
+			isFloat bool
+		)
+		for _, v := range nums {
+			if i, ok := v.ValueType.(*pb.Value_IntegerValue); ok && !isFloat {
+				s := isum + i.IntegerValue
+				if (i.IntegerValue > 0 && s < isum) || (i.IntegerValue < 0 && s > isum) {
+					isFloat, fsum = true, float64(isum)+float64(i.IntegerValue)
+					continue
+				}
+				isum = s
+				continue
+			}
+			if !isFloat {
+				isFloat, fsum = true, float64(isum)
+			}
+			fsum += toFloat(v)
+		}
+		if isFloat {
+			return &pb.Value{ValueType: &pb.Value_DoubleValue{DoubleValue: fsum}}, nil
+		}
+		return &pb.Value{ValueType: &pb.Value_IntegerValue{IntegerValue: isum}}, nil
+	case *pb.StructuredAggregationQuery_Aggregation_Avg_:
+		nums := numbers(docs, op.Avg.GetField().GetFieldPath())
+		if len(nums) == 0 {
+			return nullValue, nil
+		}
+		var sum float64
+		for _, v := range nums {
+			sum += toFloat(v)
+		}
+		return &pb.Value{ValueType: &pb.Value_DoubleValue{DoubleValue: sum / float64(len(nums))}}, nil
+	}
+	return nil, status.Errorf(codes.InvalidArgument, "unknown aggregation %T", a.Operator)
+}
+
+// numbers returns the numeric values of the field fp of docs.
+func numbers(docs []*pb.Document, fp string) []*pb.Value {
+	var res []*pb.Value
+	for _, d := range docs {
+		if v, ok := docField(d, fp); ok && isNumber(v) {
+			res = append(res, v)
+		}
+	}
+	return res
+}
+
+// PartitionQuery implements the PartitionQuery RPC. It splits the documents
+// matching the query into partitions of about the same size.
+func (s *server) PartitionQuery(_ context.Context, req *pb.PartitionQueryRequest) (*pb.PartitionQueryResponse, error) {
+	sq := req.GetStructuredQuery()
+	if sq == nil {
+		return nil, status.Error(codes.InvalidArgument, "missing structured query")
+	}
+	if req.PartitionCount <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "partition count must be positive")
+	}
+	for _, sel := range sq.From {
+		if !sel.AllDescendants {
+			return nil, status.Error(codes.InvalidArgument, "partition queries must be collection group queries")
+		}
+	}
+	if len(sq.OrderBy) != 1 || sq.OrderBy[0].GetField().GetFieldPath() != "__name__" ||
+		sq.OrderBy[0].Direction == pb.StructuredQuery_DESCENDING {
+		return nil, status.Error(codes.InvalidArgument, "partition queries must be ordered by __name__ ascending")
+	}
+	s.mu.Lock()
+	rc, err := s.newReadContext(nil, nil, req.GetReadTime())
+	if err != nil {
+		s.mu.Unlock()
+		return nil, err
+	}
+	docs, err := s.runQuery(rc, req.Parent, sq)
+	s.mu.Unlock()
+	if err != nil {
+		return nil, err
+	}
+
+	res := &pb.PartitionQueryResponse{}
+	n := int64(len(docs))
+	for i := int64(1); i < req.PartitionCount && i < n; i++ {
+		name := docs[i*n/req.PartitionCount].Name
+		res.Partitions = append(res.Partitions, &pb.Cursor{
+			Values: []*pb.Value{{ValueType: &pb.Value_ReferenceValue{ReferenceValue: name}}},
+		})
+	}
+	return res, nil
+}
+
+// runQuery returns the documents that match sq, read as described by rc.
+// s.mu must be held.
+func (s *server) runQuery(rc *readContext, parent string, sq *pb.StructuredQuery) ([]*pb.Document, error) {
+	if len(sq.ProtoReflect().GetUnknown()) > 0 {
+		return nil, status.Error(codes.Unimplemented, "query uses features that the fake does not support")
+	}
+	if len(sq.From) != 1 {
+		return nil, status.Error(codes.InvalidArgument, "query must have exactly one collection selector")
+	}
+	orders := queryOrders(sq)
+
+	var docs []*pb.Document
+	for _, d := range s.docsAt(parent+"/", rc.seq) {
+		if !inCollection(d.Name, parent, sq.From[0]) {
+			continue
+		}
+		ok, err := matchesFilter(d, sq.Where)
+		if err != nil {
+			return nil, err
+		}
+		if ok && hasOrderFields(d, orders) {
+			docs = append(docs, d)
+		}
+	}
+	sort.SliceStable(docs, func(i, j int) bool { return compareDocs(docs[i], docs[j], orders) < 0 })
+
+	var res []*pb.Document
+	skipped := int32(0)
+	for _, d := range docs {
+		if sq.StartAt != nil {
+			c := compareCursor(d, sq.StartAt, orders)
+			if c < 0 || (c == 0 && !sq.StartAt.Before) {
+				continue
+			}
+		}
+		if sq.EndAt != nil {
+			c := compareCursor(d, sq.EndAt, orders)
+			if c > 0 || (c == 0 && sq.EndAt.Before) {
+				continue
+			}
+		}
+		if skipped < sq.Offset {
+			skipped++
+			continue
+		}
+		if sq.Limit != nil && len(res) >= int(sq.Limit.Value) {
+			break
+		}
+		res = append(res, d)
+	}
+
+	if rc.txn != nil && !rc.txn.readOnly {
+		for _, d := range res {
+			rc.txn.reads[d.Name] = s.latestSeq(d.Name)
+		}
+	}
+	if sq.Select != nil {
+		paths := []string{}
+		for _, f := range sq.Select.Fields {
+			if f.FieldPath != "__name__" {
+				paths = append(paths, f.FieldPath)
+			}
+		}
+		for i, d := range res {
+			res[i] = projectDoc(d, paths)
+		}
+	}
+	return res, nil
+}
+
+// inCollection reports whether the document named name is in the collection
+// selected by sel under parent.
+func inCollection(name, parent string, sel *pb.StructuredQuery_CollectionSelector) bool {
+	rest := strings.Split(strings.TrimPrefix(name, parent+"/"), "/")
+	if !sel.AllDescendants {
+		return len(rest) == 2 && rest[0] == sel.CollectionId
+	}
+	return sel.CollectionId == "" || rest[len(rest)-2] == sel.CollectionId
+}
+
+// queryOrders returns the full ordering of sq: the explicit orders, then the
+// implicit order by the first inequality field, if it is not already ordered,
+// then the order by document name.
+func queryOrders(sq *pb.StructuredQuery) []*pb.StructuredQuery_Order {
+	orders := append([]*pb.StructuredQuery_Order(nil), sq.OrderBy...)
+	ordered := func(fp string) bool {
+		for _, o := range orders {
+			if o.GetField().GetFieldPath() == fp {
+				return true
+			}
+		}
+		return false
+	}
+	if len(orders) == 0 {
+		if fp := inequalityField(sq.Where); fp != "" && !ordered(fp) {
+			orders = append(orders, &pb.StructuredQuery_Order{
+				Field:     &pb.StructuredQuery_FieldReference{FieldPath: fp},
+				Direction: pb.StructuredQuery_ASCENDING,
+			})
+		}
+	}
+	if !ordered("__name__") {
+		dir := pb.StructuredQuery_ASCENDING
+		if len(orders) > 0 {
+			dir = orders[len(orders)-1].Direction
+		}
+		orders = append(orders, &pb.StructuredQuery_Order{
+			Field:     &pb.StructuredQuery_FieldReference{FieldPath: "__name__"},
+			Direction: dir,
+		})
+	}
+	return orders
+}
+
+// inequalityField returns the path of the first field in f that has an
+// inequality filter, or "" if there is none.
+func inequalityField(f *pb.StructuredQuery_Filter) string {
+	switch f := f.GetFilterType().(type) {
+	case *pb.StructuredQuery_Filter_CompositeFilter:
+		for _, sub := range f.CompositeFilter.Filters {
+			if fp := inequalityField(sub); fp != "" {
+				return fp
+			}
+		}
+	case *pb.StructuredQuery_Filter_FieldFilter:
+		switch f.FieldFilter.Op {
+		case pb.StructuredQuery_FieldFilter_LESS_THAN, pb.StructuredQuery_FieldFilter_LESS_THAN_OR_EQUAL,
+			pb.StructuredQuery_FieldFilter_GREATER_THAN, pb.StructuredQuery_FieldFilter_GREATER_THAN_OR_EQUAL,
+			pb.StructuredQuery_FieldFilter_NOT_EQUAL, pb.StructuredQuery_FieldFilter_NOT_IN:
+			return f.FieldFilter.GetField().GetFieldPath()
+		}
+	case *pb.StructuredQuery_Filter_UnaryFilter:
+		switch f.UnaryFilter.Op {
+		case pb.StructuredQuery_UnaryFilter_IS_NOT_NAN, pb.StructuredQuery_UnaryFilter_IS_NOT_NULL:
+			return f.UnaryFilter.GetField().GetFieldPath()
+		}
+	}
+	return ""
+}
+
+// hasOrderFields reports whether d has all the fields it is ordered by.
+func hasOrderFields(d *pb.Document, orders []*pb.StructuredQuery_Order) bool {
+	for _, o := range orders {
+		if _, ok := docField(d, o.GetField().GetFieldPath()); !ok {
+			return false
+		}
+	}
+	return true
+}
+
+func compareDocs(a, b *pb.Document, orders []*pb.StructuredQuery_Order) int {
+	for _, o := range orders {
+		fp := o.GetField().GetFieldPath()
+		av, _ := docField(a, fp)
+		bv, _ := docField(b, fp)
+		c := compareValues(av, bv)
+		if o.Direction == pb.StructuredQuery_DESCENDING {
+			c = -c
+		}
+		if c != 0 {
+			return c
+		}
+	}
+	return 0
+}
+
+// compareCursor compares d with the position of cursor c in the ordering.
+func compareCursor(d *pb.Document, c *pb.Cursor, orders []*pb.StructuredQuery_Order) int {
+	for i, cv := range c.Values {
+		if i >= len(orders) {
+			break
+		}
+		fp := orders[i].GetField().GetFieldPath()
+		v, _ := docField(d, fp)
+		cmp := compareValues(v, cv)
+		if orders[i].Direction == pb.StructuredQuery_DESCENDING {
+			cmp = -cmp
+		}
+		if cmp != 0 {
+			return cmp
+		}
+	}
+	return 0
+}
+
+// matchesFilter reports whether d satisfies f.
+func matchesFilter(d *pb.Document, f *pb.StructuredQuery_Filter) (bool, error) {
+	switch f := f.GetFilterType().(type) {
+	case nil:
+		return true, nil
+	case *pb.StructuredQuery_Filter_CompositeFilter:
+		cf := f.CompositeFilter
+		if len(cf.Filters) == 0 {
+			return false, status.Error(codes.InvalidArgument, "composite filter has no filters")
+		}
+		for _, sub := range cf.Filters {
+			ok, err := matchesFilter(d, sub)
+			if err != nil {
+				return false, err
+			}
+			switch {
+			case cf.Op == pb.StructuredQuery_CompositeFilter_OR && ok:
+				return true, nil
+			case cf.Op != pb.StructuredQuery_CompositeFilter_OR && !ok:
+				return false, nil
+			}
+		}
+		return cf.Op != pb.StructuredQuery_CompositeFilter_OR, nil
+	case *pb.StructuredQuery_Filter_FieldFilter:
+		return matchesFieldFilter(d, f.FieldFilter)
+	case *pb.StructuredQuery_Filter_UnaryFilter:
+		v, ok := docField(d, f.UnaryFilter.GetField().GetFieldPath())
+		if !ok {
+			return false, nil
+		}
+		switch f.UnaryFilter.Op {
+		case pb.StructuredQuery_UnaryFilter_IS_NAN:
+			return isNaN(v), nil
+		case pb.StructuredQuery_UnaryFilter_IS_NULL:
+			return typeOrder(v) == 0, nil
+		case pb.StructuredQuery_UnaryFilter_IS_NOT_NAN:
+			return !isNaN(v), nil
+		case pb.StructuredQuery_UnaryFilter_IS_NOT_NULL:
+			return typeOrder(v) != 0, nil
+		}
+		return false, status.Errorf(codes.InvalidArgument, "unknown unary operator %v", f.UnaryFilter.Op)
+	}
+	return false, status.Errorf(codes.InvalidArgument, "unknown filter %T", f.GetFilterType())
+}
+
+func matchesFieldFilter(d *pb.Document, f *pb.StructuredQuery_FieldFilter) (bool, error) {
+	v, ok := docField(d, f.GetField().GetFieldPath())
+	if !ok {
+		return false, nil
+	}
+	operand := f.Value
+	switch f.Op {
+	case pb.StructuredQuery_FieldFilter_EQUAL:
+		return valuesEqual(v, operand), nil
+	case pb.StructuredQuery_FieldFilter_NOT_EQUAL:
+		return typeOrder(v) != 0 && !valuesEqual(v, operand), nil
+	case pb.StructuredQuery_FieldFilter_LESS_THAN:
+		return rangeComparable(v, operand) && compareValues(v, operand) < 0, nil
+	case pb.StructuredQuery_FieldFilter_LESS_THAN_OR_EQUAL:
+		return rangeComparable(v, operand) && compareValues(v, operand) <= 0, nil
+	case pb.StructuredQuery_FieldFilter_GREATER_THAN:
+		return rangeComparable(v, operand) && compareValues(v, operand) > 0, nil
+	case pb.StructuredQuery_FieldFilter_GREATER_THAN_OR_EQUAL:
+		return rangeComparable(v, operand) && compareValues(v, operand) >= 0, nil
+	case pb.StructuredQuery_FieldFilter_ARRAY_CONTAINS:
+		return arrayContains(v.GetArrayValue().GetValues(), operand), nil
+	case pb.StructuredQuery_FieldFilter_IN:
+		return arrayContains(operand.GetArrayValue().GetValues(), v), nil
+	case pb.StructuredQuery_FieldFilter_ARRAY_CONTAINS_ANY:
+		for _, e := range operand.GetArrayValue().GetValues() {
+			if arrayContains(v.GetArrayValue().GetValues(), e) {
+				return true, nil
+			}
+		}
+		return false, nil
+	case pb.StructuredQuery_FieldFilter_NOT_IN:
+		return typeOrder(v) != 0 && !arrayContains(operand.GetArrayValue().GetValues(), v), nil
+	}
+	return false, status.Errorf(codes.InvalidArgument, "unknown field operator %v", f.Op)
+}
+
+// rangeComparable reports whether a range filter can compare a and b: they must
+// have the same type, and NaN is not comparable.
+func rangeComparable(a, b *pb.Value) bool {
+	return typeOrder(a) == typeOrder(b) && !isNaN(a) && !isNaN(b)
+}
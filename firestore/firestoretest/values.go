@@ -0,0 +1,335 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package firestoretest
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	pb "cloud.google.com/go/firestore/apiv1/firestorepb"
+	"google.golang.org/protobuf/proto"
+)
+
+var nullValue = &pb.Value{ValueType: &pb.Value_NullValue{}}
+
+// typeOrder returns the rank of the type of v in the ordering of values
+// across types.
+func typeOrder(v *pb.Value) int {
+	switch v.ValueType.(type) {
+	case *pb.Value_NullValue:
+		return 0
+	case *pb.Value_BooleanValue:
+		return 1
+	case *pb.Value_IntegerValue, *pb.Value_DoubleValue:
+		return 2
+	case *pb.Value_TimestampValue:
+		return 3
+	case *pb.Value_StringValue:
+		return 4
+	case *pb.Value_BytesValue:
+		return 5
+	case *pb.Value_ReferenceValue:
+		return 6
+	case *pb.Value_GeoPointValue:
+		return 7
+	case *pb.Value_ArrayValue:
+		return 8
+	case *pb.Value_MapValue:
+		return 9
+	}
+	return 10
+}
+
+// compareValues compares a and b in the order Firestore uses for sorting
+// query results.
+func compareValues(a, b *pb.Value) int {
+	ta, tb := typeOrder(a), typeOrder(b)
+	if ta != tb {
+		return compareInts(int64(ta), int64(tb))
+	}
+	switch x := a.ValueType.(type) {
+	case *pb.Value_NullValue:
+		return 0
+	case *pb.Value_BooleanValue:
+		y := b.GetBooleanValue()
+		switch {
+		case x.BooleanValue == y:
+			return 0
+		case !x.BooleanValue:
+			return -1
+		}
+		return 1
+	case *pb.Value_IntegerValue:
+		if y, ok := b.ValueType.(*pb.Value_IntegerValue); ok {
+			return compareInts(x.IntegerValue, y.IntegerValue)
+		}
+		return compareFloats(float64(x.IntegerValue), b.GetDoubleValue())
+	case *pb.Value_DoubleValue:
+		if y, ok := b.ValueType.(*pb.Value_IntegerValue); ok {
+			return compareFloats(x.DoubleValue, float64(y.IntegerValue))
+		}
+		return compareFloats(x.DoubleValue, b.GetDoubleValue())
+	case *pb.Value_TimestampValue:
+		y := b.GetTimestampValue()
+		if c := compareInts(x.TimestampValue.Seconds, y.Seconds); c != 0 {
+			return c
+		}
+		return compareInts(int64(x.TimestampValue.Nanos), int64(y.Nanos))
+	case *pb.Value_StringValue:
+		return strings.Compare(x.StringValue, b.GetStringValue())
+	case *pb.Value_BytesValue:
+		return bytes.Compare(x.BytesValue, b.GetBytesValue())
+	case *pb.Value_ReferenceValue:
+		return compareNames(x.ReferenceValue, b.GetReferenceValue())
+	case *pb.Value_GeoPointValue:
+		y := b.GetGeoPointValue()
+		if c := compareFloats(x.GeoPointValue.Latitude, y.Latitude); c != 0 {
+			return c
+		}
+		return compareFloats(x.GeoPointValue.Longitude, y.Longitude)
+	case *pb.Value_ArrayValue:
+		av, bv := x.ArrayValue.Values, b.GetArrayValue().GetValues()
+		for i := 0; i < len(av) && i < len(bv); i++ {
+			if c := compareValues(av[i], bv[i]); c != 0 {
+				return c
+			}
+		}
+		return compareInts(int64(len(av)), int64(len(bv)))
+	case *pb.Value_MapValue:
+		am, bm := x.MapValue.Fields, b.GetMapValue().GetFields()
+		ak, bk := sortedKeys(am), sortedKeys(bm)
+		for i := 0; i < len(ak) && i < len(bk); i++ {
+			if c := strings.Compare(ak[i], bk[i]); c != 0 {
+				return c
+			}
+			if c := compareValues(am[ak[i]], bm[bk[i]]); c != 0 {
+				return c
+			}
+		}
+		return compareInts(int64(len(ak)), int64(len(bk)))
+	}
+	return 0
+}
+
+// valuesEqual reports whether a and b are equal for the purposes of
+// filters. Integers and doubles with the same value are equal.
+func valuesEqual(a, b *pb.Value) bool {
+	if isNaN(a) || isNaN(b) {
+		return false
+	}
+	return typeOrder(a) == typeOrder(b) && compareValues(a, b) == 0
+}
+
+func isNaN(v *pb.Value) bool {
+	d, ok := v.ValueType.(*pb.Value_DoubleValue)
+	return ok && math.IsNaN(d.DoubleValue)
+}
+
+func isNumber(v *pb.Value) bool {
+	return typeOrder(v) == 2
+}
+
+func compareInts(a, b int64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+// compareFloats orders NaN before all other numbers.
+func compareFloats(a, b float64) int {
+	switch {
+	case math.IsNaN(a) && math.IsNaN(b):
+		return 0
+	case math.IsNaN(a):
+		return -1
+	case math.IsNaN(b):
+		return 1
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+func sortedKeys(m map[string]*pb.Value) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// compareNames compares document names segment by segment.
+func compareNames(a, b string) int {
+	as, bs := strings.Split(a, "/"), strings.Split(b, "/")
+	for i := 0; i < len(as) && i < len(bs); i++ {
+		if c := strings.Compare(as[i], bs[i]); c != 0 {
+			return c
+		}
+	}
+	return compareInts(int64(len(as)), int64(len(bs)))
+}
+
+// parseFieldPath splits a field path in the service's format, in which
+// segments are separated by dots and may be quoted with backticks.
+func parseFieldPath(s string) ([]string, error) {
+	var (
+		parts  []string
+		cur    strings.Builder
+		quoted bool
+	)
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case c == '\\' && quoted && i+1 < len(s):
+			i++
+			cur.WriteByte(s[i])
+		case c == '`':
+			quoted = !quoted
+		case c == '.' && !quoted:
+			if cur.Len() == 0 {
+				return nil, fmt.Errorf("invalid field path %q", s)
+			}
+			parts = append(parts, cur.String())
+			cur.Reset()
+		default:
+			cur.WriteByte(c)
+		}
+	}
+	if quoted || cur.Len() == 0 {
+		return nil, fmt.Errorf("invalid field path %q", s)
+	}
+	return append(parts, cur.String()), nil
+}
+
+// getField returns the value at path in fields.
+func getField(fields map[string]*pb.Value, path []string) (*pb.Value, bool) {
+	for i, p := range path {
+		v, ok := fields[p]
+		if !ok {
+			return nil, false
+		}
+		if i == len(path)-1 {
+			return v, true
+		}
+		m := v.GetMapValue()
+		if m == nil {
+			return nil, false
+		}
+		fields = m.Fields
+	}
+	return nil, false
+}
+
+// docField returns the value of the field of doc denoted by the service
+// field path fp. The special path __name__ denotes the name of doc.
+func docField(doc *pb.Document, fp string) (*pb.Value, bool) {
+	if fp == "__name__" {
+		return &pb.Value{ValueType: &pb.Value_ReferenceValue{ReferenceValue: doc.Name}}, true
+	}
+	path, err := parseFieldPath(fp)
+	if err != nil {
+		return nil, false
+	}
+	return getField(doc.Fields, path)
+}
+
+// setField sets the value at path in fields, creating or replacing maps along
+// the way.
+func setField(fields map[string]*pb.Value, path []string, v *pb.Value) {
+	for _, p := range path[:len(path)-1] {
+		m := fields[p].GetMapValue()
+		if m == nil {
+			m = &pb.MapValue{Fields: map[string]*pb.Value{}}
+			fields[p] = &pb.Value{ValueType: &pb.Value_MapValue{MapValue: m}}
+		}
+		if m.Fields == nil {
+			m.Fields = map[string]*pb.Value{}
+		}
+		fields = m.Fields
+	}
+	fields[path[len(path)-1]] = v
+}
+
+// deleteField removes the value at path from fields, if present.
+func deleteField(fields map[string]*pb.Value, path []string) {
+	for _, p := range path[:len(path)-1] {
+		m := fields[p].GetMapValue()
+		if m == nil {
+			return
+		}
+		fields = m.Fields
+	}
+	delete(fields, path[len(path)-1])
+}
+
+// projectDoc returns a copy of doc with only the fields in paths. If paths
+// is nil, it returns doc.
+func projectDoc(doc *pb.Document, paths []string) *pb.Document {
+	if paths == nil {
+		return doc
+	}
+	res := &pb.Document{
+		Name:       doc.Name,
+		CreateTime: doc.CreateTime,
+		UpdateTime: doc.UpdateTime,
+		Fields:     map[string]*pb.Value{},
+	}
+	for _, fp := range paths {
+		path, err := parseFieldPath(fp)
+		if err != nil {
+			continue
+		}
+		if v, ok := getField(doc.Fields, path); ok {
+			setField(res.Fields, path, proto.Clone(v).(*pb.Value))
+		}
+	}
+	return res
+}
+
+// docsPrefix returns the prefix of the names of the documents of the
+// database named in a resource name such as a database, document or
+// collection parent.
+func docsPrefix(name string) string {
+	if i := strings.Index(name, "/documents"); i >= 0 {
+		return name[:i] + "/documents"
+	}
+	return name + "/documents"
+}
+
+// databaseName returns the name of the database of a document or parent
+// resource name.
+func databaseName(name string) string {
+	return strings.TrimSuffix(docsPrefix(name), "/documents")
+}
+
+// validateDocName checks that name is the name of a document.
+func validateDocName(name string) error {
+	prefix := docsPrefix(name)
+	rest := strings.TrimPrefix(name, prefix+"/")
+	if !strings.HasPrefix(name, "projects/") || rest == name || len(strings.Split(rest, "/"))%2 != 0 {
+		return fmt.Errorf("invalid document name %q", name)
+	}
+	return nil
+}
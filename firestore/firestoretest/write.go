@@ -0,0 +1,235 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package firestoretest
+
+import (
+	"math"
+
+	pb "cloud.google.com/go/firestore/apiv1/firestorepb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// writeName returns the name of the document that w writes.
+func writeName(w *pb.Write) string {
+	switch op := w.Operation.(type) {
+	case *pb.Write_Update:
+		return op.Update.GetName()
+	case *pb.Write_Delete:
+		return op.Delete
+	case *pb.Write_Transform:
+		return op.Transform.GetDocument()
+	}
+	return ""
+}
+
+// applyWrite returns the result of applying w at commitTime to cur, the
+// current state of the document, which is nil if the document does not exist.
+// The returned document is nil if w deletes it.
+func applyWrite(cur *pb.Document, w *pb.Write, commitTime *timestamppb.Timestamp) (*pb.Document, []*pb.Value, error) {
+	name := writeName(w)
+	if err := checkPrecondition(name, cur, w.CurrentDocument); err != nil {
+		return nil, nil, err
+	}
+
+	var (
+		doc        *pb.Document
+		transforms = w.UpdateTransforms
+	)
+	switch op := w.Operation.(type) {
+	case *pb.Write_Delete:
+		return nil, nil, nil
+	case *pb.Write_Update:
+		if w.UpdateMask == nil || cur == nil {
+			doc = &pb.Document{Name: name, Fields: map[string]*pb.Value{}}
+		} else {
+			doc = cloneDoc(cur)
+		}
+		if w.UpdateMask == nil {
+			for k, v := range op.Update.Fields {
+				doc.Fields[k] = proto.Clone(v).(*pb.Value)
+			}
+		} else {
+			for _, fp := range w.UpdateMask.FieldPaths {
+				path, err := parseFieldPath(fp)
+				if err != nil {
+					return nil, nil, status.Error(codes.InvalidArgument, err.Error())
+				}
+				if v, ok := getField(op.Update.Fields, path); ok {
+					setField(doc.Fields, path, proto.Clone(v).(*pb.Value))
+				} else {
+					deleteField(doc.Fields, path)
+				}
+			}
+		}
+	case *pb.Write_Transform:
+		if cur == nil {
+			doc = &pb.Document{Name: name, Fields: map[string]*pb.Value{}}
+		} else {
+			doc = cloneDoc(cur)
+		}
+		transforms = append(op.Transform.FieldTransforms, transforms...)
+	default:
+		return nil, nil, status.Errorf(codes.InvalidArgument, "unknown write operation %T", op)
+	}
+	if doc.Fields == nil {
+		doc.Fields = map[string]*pb.Value{}
+	}
+
+	var results []*pb.Value
+	for _, ft := range transforms {
+		res, err := applyTransform(doc.Fields, ft, commitTime)
+		if err != nil {
+			return nil, nil, err
+		}
+		results = append(results, res)
+	}
+
+	if cur != nil {
+		doc.CreateTime = cur.CreateTime
+	} else {
+		doc.CreateTime = commitTime
+	}
+	doc.UpdateTime = commitTime
+	return doc, results, nil
+}
+
+func checkPrecondition(name string, cur *pb.Document, pc *pb.Precondition) error {
+	switch c := pc.GetConditionType().(type) {
+	case *pb.Precondition_Exists:
+		if c.Exists && cur == nil {
+			return status.Errorf(codes.NotFound, "document %q not found", name)
+		}
+		if !c.Exists && cur != nil {
+			return status.Errorf(codes.AlreadyExists, "document %q already exists", name)
+		}
+	case *pb.Precondition_UpdateTime:
+		if cur == nil || !proto.Equal(cur.UpdateTime, c.UpdateTime) {
+			return status.Errorf(codes.FailedPrecondition, "document %q was not last updated at %v", name, c.UpdateTime.AsTime())
+		}
+	}
+	return nil
+}
+
+// applyTransform applies ft to fields and returns the result of the
+// transform.
+func applyTransform(fields map[string]*pb.Value, ft *pb.DocumentTransform_FieldTransform, commitTime *timestamppb.Timestamp) (*pb.Value, error) {
+	path, err := parseFieldPath(ft.FieldPath)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	cur, _ := getField(fields, path)
+	var res *pb.Value
+	switch t := ft.TransformType.(type) {
+	case *pb.DocumentTransform_FieldTransform_SetToServerValue:
+		if t.SetToServerValue != pb.DocumentTransform_FieldTransform_REQUEST_TIME {
+			return nil, status.Errorf(codes.InvalidArgument, "unknown server value %v", t.SetToServerValue)
+		}
+		res = &pb.Value{ValueType: &pb.Value_TimestampValue{TimestampValue: commitTime}}
+		setField(fields, path, res)
+		return res, nil
+	case *pb.DocumentTransform_FieldTransform_Increment:
+		if err := checkNumber(t.Increment); err != nil {
+			return nil, err
+		}
+		if cur == nil || !isNumber(cur) {
+			res = t.Increment
+		} else {
+			res = add(cur, t.Increment)
+		}
+	case *pb.DocumentTransform_FieldTransform_Maximum:
+		if err := checkNumber(t.Maximum); err != nil {
+			return nil, err
+		}
+		res = t.Maximum
+		if cur != nil && isNumber(cur) && compareValues(cur, t.Maximum) >= 0 {
+			res = cur
+		}
+	case *pb.DocumentTransform_FieldTransform_Minimum:
+		if err := checkNumber(t.Minimum); err != nil {
+			return nil, err
+		}
+		res = t.Minimum
+		if cur != nil && isNumber(cur) && compareValues(cur, t.Minimum) <= 0 {
+			res = cur
+		}
+	case *pb.DocumentTransform_FieldTransform_AppendMissingElements:
+		elems := append([]*pb.Value(nil), cur.GetArrayValue().GetValues()...)
+		for _, e := range t.AppendMissingElements.GetValues() {
+			if !arrayContains(elems, e) {
+				elems = append(elems, e)
+			}
+		}
+		setField(fields, path, &pb.Value{ValueType: &pb.Value_ArrayValue{ArrayValue: &pb.ArrayValue{Values: elems}}})
+		return nullValue, nil
+	case *pb.DocumentTransform_FieldTransform_RemoveAllFromArray:
+		var elems []*pb.Value
+		for _, e := range cur.GetArrayValue().GetValues() {
+			if !arrayContains(t.RemoveAllFromArray.GetValues(), e) {
+				elems = append(elems, e)
+			}
+		}
+		setField(fields, path, &pb.Value{ValueType: &pb.Value_ArrayValue{ArrayValue: &pb.ArrayValue{Values: elems}}})
+		return nullValue, nil
+	default:
+		return nil, status.Errorf(codes.InvalidArgument, "unknown field transform %T", t)
+	}
+	res = proto.Clone(res).(*pb.Value)
+	setField(fields, path, res)
+	return res, nil
+}
+
+func checkNumber(v *pb.Value) error {
+	if !isNumber(v) {
+		return status.Errorf(codes.InvalidArgument, "transform operand %v is not a number", v)
+	}
+	return nil
+}
+
+// add returns the sum of the numbers a and b. The sum of two integers is an
+// integer, saturating on overflow; otherwise it is a double.
+func add(a, b *pb.Value) *pb.Value {
+	x, xok := a.ValueType.(*pb.Value_IntegerValue)
+	y, yok := b.ValueType.(*pb.Value_IntegerValue)
+	if xok && yok {
+		sum := x.IntegerValue + y.IntegerValue
+		switch {
+		case x.IntegerValue > 0 && y.IntegerValue > 0 && sum < 0:
+			sum = math.MaxInt64
+		case x.IntegerValue < 0 && y.IntegerValue < 0 && sum >= 0:
+			sum = math.MinInt64
+		}
+		return &pb.Value{ValueType: &pb.Value_IntegerValue{IntegerValue: sum}}
+	}
+	return &pb.Value{ValueType: &pb.Value_DoubleValue{DoubleValue: toFloat(a) + toFloat(b)}}
+}
+
+func toFloat(v *pb.Value) float64 {
+	if i, ok := v.ValueType.(*pb.Value_IntegerValue); ok {
+		return float64(i.IntegerValue)
+	}
+	return v.GetDoubleValue()
+}
+
+func arrayContains(vs []*pb.Value, v *pb.Value) bool {
+	for _, e := range vs {
+		if valuesEqual(e, v) {
+			return true
+		}
+	}
+	return false
+}
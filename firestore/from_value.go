@@ -100,6 +100,9 @@ func setReflectFromProtoValue(v reflect.Value, vproto *pb.Value, c *Client) erro
 		}
 		v.Set(reflect.ValueOf(dr))
 		return nil
+
+	case typeOfVector32, typeOfVector64:
+		return setVectorFromProtoValue(v, vproto)
 	}
 
 	switch v.Kind() {
@@ -379,6 +382,11 @@ func createFromProtoValue(vproto *pb.Value, c *Client) (interface{}, error) {
 		return ret, nil
 
 	case *pb.Value_MapValue:
+		if vec, ok, err := vectorFromProtoValue(vproto); err != nil {
+			return nil, err
+		} else if ok {
+			return Vector64(vec), nil
+		}
 		fields := v.MapValue.Fields
 		ret := make(map[string]interface{}, len(fields))
 		for k, v := range fields {
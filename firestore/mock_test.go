@@ -228,6 +228,14 @@ func (s *mockServer) RunAggregationQuery(req *pb.RunAggregationQueryRequest, qs
 	return nil
 }
 
+func (s *mockServer) PartitionQuery(_ context.Context, req *pb.PartitionQueryRequest) (*pb.PartitionQueryResponse, error) {
+	res, err := s.popRPC(req)
+	if err != nil {
+		return nil, err
+	}
+	return res.(*pb.PartitionQueryResponse), nil
+}
+
 func (s *mockServer) BeginTransaction(_ context.Context, req *pb.BeginTransactionRequest) (*pb.BeginTransactionResponse, error) {
 	res, err := s.popRPC(req)
 	if err != nil {
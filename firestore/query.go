@@ -29,6 +29,7 @@ import (
 	"github.com/golang/protobuf/proto"
 	"github.com/golang/protobuf/ptypes/wrappers"
 	"google.golang.org/api/iterator"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 // Query represents a Firestore query.
@@ -49,6 +50,7 @@ type Query struct {
 	startVals, endVals     []interface{}
 	startDoc, endDoc       *DocumentSnapshot
 	startBefore, endBefore bool
+	findNearest            *pb.StructuredQuery_FindNearest
 	err                    error
 
 	// allDescendants indicates whether this query is for all collections
@@ -147,6 +149,12 @@ func (q Query) WherePath(fp FieldPath, op string, value interface{}) Query {
 // PropertyFilter and PropertyPathFilter are supported simple filters
 // AndFilter and OrFilter are supported composite filters
 // Entity filters in multiple calls are joined together by AND
+//
+// A query may have at most 30 disjunctions once its filters are converted to
+// disjunctive normal form, where each value of an "in" or "array-contains-any"
+// filter counts as a disjunction. A query may have at most one "not-in"
+// filter, which cannot be combined with OrFilter, "in" or "array-contains-any".
+// These limits are checked before the query is sent.
 func (q Query) WhereEntity(ef EntityFilter) Query {
 	proto, err := ef.toProto()
 	if err != nil {
@@ -435,6 +443,9 @@ func (q Query) fromProto(pbQuery *pb.RunQueryRequest) (Query, error) {
 		q.limit = limit
 	}
 
+	// 	findNearest            *pb.StructuredQuery_FindNearest
+	q.findNearest = pbq.GetFindNearest()
+
 	// NOTE: limit to last isn't part of the proto, this is a client-side concept
 	// 	limitToLast            bool
 	return q, q.err
@@ -469,6 +480,9 @@ func (q Query) toProto() (*pb.StructuredQuery, error) {
 		p.Select = &pb.StructuredQuery_Projection{}
 		p.Select.Fields = q.selection
 	}
+	if err := validateFilters(q.filters); err != nil {
+		return nil, err
+	}
 	// If there is only filter, use it directly. Otherwise, construct
 	// a CompositeFilter.
 	if len(q.filters) == 1 {
@@ -508,6 +522,7 @@ func (q Query) toProto() (*pb.StructuredQuery, error) {
 		return nil, err
 	}
 	p.EndAt = cursor
+	p.FindNearest = q.findNearest
 	return p, nil
 }
 
@@ -680,6 +695,9 @@ type OrFilter struct {
 func (OrFilter) isCompositeFilter() {}
 
 func (f OrFilter) toProto() (*pb.StructuredQuery_Filter, error) {
+	if len(f.Filters) == 0 {
+		return nil, errors.New("firestore: OrFilter must contain at least one filter")
+	}
 	var pbFilters []*pb.StructuredQuery_Filter
 
 	for _, filter := range f.Filters {
@@ -711,6 +729,9 @@ type AndFilter struct {
 func (AndFilter) isCompositeFilter() {}
 
 func (f AndFilter) toProto() (*pb.StructuredQuery_Filter, error) {
+	if len(f.Filters) == 0 {
+		return nil, errors.New("firestore: AndFilter must contain at least one filter")
+	}
 	var pbFilters []*pb.StructuredQuery_Filter
 
 	for _, filter := range f.Filters {
@@ -734,6 +755,110 @@ func (f AndFilter) toProto() (*pb.StructuredQuery_Filter, error) {
 
 }
 
+// maxDisjunctions is the maximum number of disjunctions a query may have
+// when its filters are converted to disjunctive normal form. An "in" or
+// "array-contains-any" filter counts as one disjunction per value.
+const maxDisjunctions = 30
+
+// validateFilters checks the filters of a query, which are joined by AND,
+// against the limits the service imposes on disjunctions, so that invalid
+// queries fail before they are sent.
+func validateFilters(filters []*pb.StructuredQuery_Filter) error {
+	var notIn, ors, inOrAny int
+	var walk func(f *pb.StructuredQuery_Filter)
+	walk = func(f *pb.StructuredQuery_Filter) {
+		if cf := f.GetCompositeFilter(); cf != nil {
+			if cf.Op == pb.StructuredQuery_CompositeFilter_OR {
+				ors++
+			}
+			for _, sub := range cf.Filters {
+				walk(sub)
+			}
+			return
+		}
+		switch f.GetFieldFilter().GetOp() {
+		case pb.StructuredQuery_FieldFilter_NOT_IN:
+			notIn++
+		case pb.StructuredQuery_FieldFilter_IN, pb.StructuredQuery_FieldFilter_ARRAY_CONTAINS_ANY:
+			inOrAny++
+		}
+	}
+	for _, f := range filters {
+		walk(f)
+	}
+	if notIn > 1 {
+		return errors.New("firestore: a query can contain at most one not-in filter")
+	}
+	if notIn > 0 && ors+inOrAny > 0 {
+		return errors.New("firestore: a not-in filter cannot be combined with OR, in or array-contains-any filters")
+	}
+	n, err := disjunctions(&pb.StructuredQuery_Filter{
+		FilterType: &pb.StructuredQuery_Filter_CompositeFilter{
+			CompositeFilter: &pb.StructuredQuery_CompositeFilter{
+				Op:      pb.StructuredQuery_CompositeFilter_AND,
+				Filters: filters,
+			},
+		},
+	})
+	if err != nil {
+		return err
+	}
+	if n > maxDisjunctions {
+		return fmt.Errorf("firestore: query has %d disjunctions in disjunctive normal form, more than the limit of %d", n, maxDisjunctions)
+	}
+	return nil
+}
+
+// disjunctions returns the number of disjunctions of f in disjunctive normal
+// form. The result is capped just above maxDisjunctions to avoid overflow.
+func disjunctions(f *pb.StructuredQuery_Filter) (int, error) {
+	capped := func(n int) int {
+		if n > maxDisjunctions {
+			return maxDisjunctions + 1
+		}
+		return n
+	}
+	if cf := f.GetCompositeFilter(); cf != nil {
+		if cf.Op == pb.StructuredQuery_CompositeFilter_OR {
+			n := 0
+			for _, sub := range cf.Filters {
+				m, err := disjunctions(sub)
+				if err != nil {
+					return 0, err
+				}
+				n = capped(n + m)
+			}
+			return n, nil
+		}
+		n := 1
+		for _, sub := range cf.Filters {
+			m, err := disjunctions(sub)
+			if err != nil {
+				return 0, err
+			}
+			n = capped(n * m)
+		}
+		return n, nil
+	}
+	ff := f.GetFieldFilter()
+	switch ff.GetOp() {
+	case pb.StructuredQuery_FieldFilter_IN, pb.StructuredQuery_FieldFilter_ARRAY_CONTAINS_ANY:
+		op := "in"
+		if ff.Op == pb.StructuredQuery_FieldFilter_ARRAY_CONTAINS_ANY {
+			op = "array-contains-any"
+		}
+		av := ff.GetValue().GetArrayValue()
+		if av == nil {
+			return 0, fmt.Errorf("firestore: %s filter on %s requires an array value", op, ff.GetField().GetFieldPath())
+		}
+		if len(av.Values) == 0 {
+			return 0, fmt.Errorf("firestore: %s filter on %s requires a non-empty array", op, ff.GetField().GetFieldPath())
+		}
+		return capped(len(av.Values)), nil
+	}
+	return 1, nil
+}
+
 // SimpleFilter represents a simple Firestore filter.
 type SimpleFilter interface {
 	EntityFilter
@@ -1037,6 +1162,10 @@ type queryDocumentIterator struct {
 	tid          []byte // transaction ID, if any
 	streamClient pb.Firestore_RunQueryClient
 	readSettings *readSettings // readOptions, if any
+
+	// The read time of the most recent response, which the server sends even
+	// if the query has no results.
+	readTime *timestamppb.Timestamp
 }
 
 func newQueryDocumentIterator(ctx context.Context, q *Query, tid []byte, rs *readSettings) *queryDocumentIterator {
@@ -1092,6 +1221,9 @@ func (it *queryDocumentIterator) next() (_ *DocumentSnapshot, err error) {
 		if err != nil {
 			return nil, err
 		}
+		if res.ReadTime != nil {
+			it.readTime = res.ReadTime
+		}
 		if res.Document != nil {
 			break
 		}
@@ -1113,9 +1245,11 @@ func (it *queryDocumentIterator) stop() {
 }
 
 // Snapshots returns an iterator over snapshots of the query. Each time the query
-// results change, a new snapshot will be generated.
-func (q Query) Snapshots(ctx context.Context) *QuerySnapshotIterator {
-	ws, err := newWatchStreamForQuery(ctx, q)
+// results change, a new snapshot will be generated. The options can resume
+// listening from a resume token and control how the stream is restarted after
+// failures.
+func (q Query) Snapshots(ctx context.Context, opts ...SnapshotOption) *QuerySnapshotIterator {
+	ws, err := newWatchStreamForQuery(ctx, q, opts...)
 	if err != nil {
 		return &QuerySnapshotIterator{err: err}
 	}
@@ -1165,6 +1299,18 @@ func (it *QuerySnapshotIterator) Next() (*QuerySnapshot, error) {
 	}, nil
 }
 
+// ResumeToken returns the resume token of the snapshot most recently returned
+// by Next, or nil if Next has not returned a snapshot. Persist the token
+// after processing the snapshot, and pass it to ResumeFromToken to continue
+// listening from that point, even from another process. It is not safe to
+// call ResumeToken concurrently with Next.
+func (it *QuerySnapshotIterator) ResumeToken() []byte {
+	if it.ws == nil {
+		return nil
+	}
+	return it.ws.resumeToken
+}
+
 // Stop stops receiving snapshots. You should always call Stop when you are done with
 // a QuerySnapshotIterator, to free up resources. It is not safe to call Stop
 // concurrently with Next.
@@ -1203,11 +1349,17 @@ func (it *btreeDocumentIterator) next() (*DocumentSnapshot, error) {
 func (*btreeDocumentIterator) stop() {}
 
 // WithReadOptions specifies constraints for accessing documents from the database,
-// e.g. at what time snapshot to read the documents.
+// e.g. at what time snapshot to read the documents. The options apply to q only,
+// not to the collection or other queries it was derived from.
 func (q *Query) WithReadOptions(opts ...ReadOption) *Query {
+	rs := &readSettings{}
+	if q.readSettings != nil {
+		*rs = *q.readSettings
+	}
 	for _, ro := range opts {
-		ro.apply(q.readSettings)
+		ro.apply(rs)
 	}
+	q.readSettings = rs
 	return q
 }
 
@@ -1218,6 +1370,8 @@ type AggregationQuery struct {
 	aggregateQueries []*pb.StructuredAggregationQuery_Aggregation
 	// query contains a reference pointer to the underlying structured query.
 	query *Query
+	// err is the first error encountered while building the aggregations.
+	err error
 }
 
 // WithCount specifies that the aggregation query provide a count of results
@@ -1233,8 +1387,107 @@ func (a *AggregationQuery) WithCount(alias string) *AggregationQuery {
 	return a
 }
 
+// WithSum specifies that the aggregation query provide the sum of the values
+// of the field denoted by path across the documents returned by the
+// underlying Query. Non-numeric values are ignored. The sum is an integer if
+// all of the summed values are integers and the sum does not overflow, and a
+// float otherwise.
+//
+// The path argument can be a single field or a dot-separated sequence of
+// fields, and must not contain any of the runes "˜*/[]". Use WithSumPath
+// instead for such a path.
+func (a *AggregationQuery) WithSum(path string, alias string) *AggregationQuery {
+	fp, err := parseDotSeparatedString(path)
+	if err != nil {
+		a.setErr(err)
+		return a
+	}
+	return a.WithSumPath(fp, alias)
+}
+
+// WithSumPath specifies that the aggregation query provide the sum of the
+// values of the field denoted by fp. See WithSum for details.
+func (a *AggregationQuery) WithSumPath(fp FieldPath, alias string) *AggregationQuery {
+	ref, err := fref(fp)
+	if err != nil {
+		a.setErr(err)
+		return a
+	}
+	a.aggregateQueries = append(a.aggregateQueries, &pb.StructuredAggregationQuery_Aggregation{
+		Alias: alias,
+		Operator: &pb.StructuredAggregationQuery_Aggregation_Sum_{
+			Sum: &pb.StructuredAggregationQuery_Aggregation_Sum{Field: ref},
+		},
+	})
+	return a
+}
+
+// WithAvg specifies that the aggregation query provide the average of the
+// values of the field denoted by path across the documents returned by the
+// underlying Query. Non-numeric values are ignored. The average is always a
+// float, or null if there are no numeric values.
+//
+// The path argument can be a single field or a dot-separated sequence of
+// fields, and must not contain any of the runes "˜*/[]". Use WithAvgPath
+// instead for such a path.
+func (a *AggregationQuery) WithAvg(path string, alias string) *AggregationQuery {
+	fp, err := parseDotSeparatedString(path)
+	if err != nil {
+		a.setErr(err)
+		return a
+	}
+	return a.WithAvgPath(fp, alias)
+}
+
+// WithAvgPath specifies that the aggregation query provide the average of the
+// values of the field denoted by fp. See WithAvg for details.
+func (a *AggregationQuery) WithAvgPath(fp FieldPath, alias string) *AggregationQuery {
+	ref, err := fref(fp)
+	if err != nil {
+		a.setErr(err)
+		return a
+	}
+	a.aggregateQueries = append(a.aggregateQueries, &pb.StructuredAggregationQuery_Aggregation{
+		Alias: alias,
+		Operator: &pb.StructuredAggregationQuery_Aggregation_Avg_{
+			Avg: &pb.StructuredAggregationQuery_Aggregation_Avg{Field: ref},
+		},
+	})
+	return a
+}
+
+func (a *AggregationQuery) setErr(err error) {
+	if a.err == nil {
+		a.err = err
+	}
+}
+
+// validate checks that the aggregations are well formed.
+func (a *AggregationQuery) validate() error {
+	if a.err != nil {
+		return a.err
+	}
+	if len(a.aggregateQueries) == 0 {
+		return errors.New("firestore: aggregation query has no aggregations")
+	}
+	seen := map[string]bool{}
+	for _, aq := range a.aggregateQueries {
+		if aq.Alias == "" {
+			continue
+		}
+		if seen[aq.Alias] {
+			return fmt.Errorf("firestore: duplicate aggregation alias %q", aq.Alias)
+		}
+		seen[aq.Alias] = true
+	}
+	return nil
+}
+
 // Get retrieves the aggregation query results from the service.
 func (a *AggregationQuery) Get(ctx context.Context) (AggregationResult, error) {
+	if err := a.validate(); err != nil {
+		return nil, err
+	}
 
 	client := a.query.c.c
 	q, err := a.query.toProto()
@@ -1253,6 +1506,9 @@ func (a *AggregationQuery) Get(ctx context.Context) (AggregationResult, error) {
 			},
 		},
 	}
+	if rt, hasOpts := parseReadTime(a.query.c, a.query.readSettings); hasOpts {
+		req.ConsistencySelector = &pb.RunAggregationQueryRequest_ReadTime{ReadTime: rt}
+	}
 	ctx = withResourceHeader(ctx, a.query.c.path())
 	stream, err := client.RunAggregationQuery(ctx, req)
 	if err != nil {
@@ -1279,5 +1535,34 @@ func (a *AggregationQuery) Get(ctx context.Context) (AggregationResult, error) {
 	return resp, nil
 }
 
-// AggregationResult contains the results of an aggregation query.
+// AggregationResult contains the results of an aggregation query. The keys
+// are the aliases of the aggregations, and the values are *firestorepb.Value.
 type AggregationResult map[string]interface{}
+
+// DataTo uses the results of the aggregation query to populate p, which can
+// be a pointer to a map[string]interface{} or a pointer to a struct. The
+// aliases of the aggregations are used as the field names, as described in
+// DocumentSnapshot.DataTo. For example, the results of
+//
+//	q.NewAggregationQuery().WithCount("n").WithSum("price", "total")
+//
+// can be decoded into
+//
+//	struct {
+//		N     int64   `firestore:"n"`
+//		Total float64 `firestore:"total"`
+//	}
+//
+// Counts are integers, averages are floats or null, and sums are integers or
+// floats as described in AggregationQuery.WithSum.
+func (ar AggregationResult) DataTo(p interface{}) error {
+	fields := make(map[string]*pb.Value, len(ar))
+	for k, v := range ar {
+		pv, ok := v.(*pb.Value)
+		if !ok {
+			return fmt.Errorf("firestore: aggregation result %q has type %T, want *firestorepb.Value", k, v)
+		}
+		fields[k] = pv
+	}
+	return setFromProtoValue(p, &pb.Value{ValueType: &pb.Value_MapValue{MapValue: &pb.MapValue{Fields: fields}}}, nil)
+}
@@ -759,6 +759,50 @@ func TestQueryToProtoErrors(t *testing.T) {
 	}
 }
 
+func TestQueryDisjunctionLimits(t *testing.T) {
+	c := &Client{projectID: "P", databaseID: "DB"}
+	q := c.Collection("C").Query
+	vals := func(n int) []int {
+		v := make([]int, n)
+		for i := range v {
+			v[i] = i
+		}
+		return v
+	}
+	or := func(fs ...EntityFilter) EntityFilter { return OrFilter{Filters: fs} }
+	eq := func(path string) EntityFilter { return PropertyFilter{path, "==", 1} }
+
+	for i, query := range []Query{
+		q.Where("a", "in", vals(30)),
+		q.Where("a", "in", vals(5)).Where("b", "array-contains-any", vals(6)),
+		q.WhereEntity(or(eq("a"), eq("b"))).Where("c", "in", vals(15)),
+		q.WhereEntity(or(AndFilter{Filters: []EntityFilter{eq("a"), PropertyFilter{"b", "in", vals(10)}}}, eq("c"))),
+		q.Where("a", "not-in", vals(10)).Where("b", "==", 1),
+	} {
+		if _, err := query.toProto(); err != nil {
+			t.Errorf("query %d: %v", i, err)
+		}
+	}
+
+	for i, query := range []Query{
+		q.Where("a", "in", vals(31)),
+		q.Where("a", "in", vals(6)).Where("b", "in", vals(6)),
+		q.WhereEntity(or(eq("a"), eq("b"))).Where("c", "in", vals(16)),
+		q.WhereEntity(or(PropertyFilter{"a", "in", vals(20)}, PropertyFilter{"b", "in", vals(11)})),
+		q.Where("a", "in", []int{}),
+		q.Where("a", "in", 1),
+		q.Where("a", "not-in", vals(2)).Where("b", "not-in", vals(2)),
+		q.Where("a", "not-in", vals(2)).Where("b", "in", vals(2)),
+		q.Where("a", "not-in", vals(2)).WhereEntity(or(eq("b"), eq("c"))),
+		q.WhereEntity(OrFilter{}),
+		q.WhereEntity(AndFilter{}),
+	} {
+		if _, err := query.toProto(); err == nil {
+			t.Errorf("query %d: got nil, want error", i)
+		}
+	}
+}
+
 func TestQueryMethodsDoNotModifyReceiver(t *testing.T) {
 	var empty Query
 
@@ -1179,3 +1223,159 @@ func TestAggregationQuery(t *testing.T) {
 		t.Errorf("got: %v\nwant: %v\n; result: %v\n", cv.GetIntegerValue(), 1, count)
 	}
 }
+
+func TestAggregationQueryMulti(t *testing.T) {
+	ctx := context.Background()
+	c, srv, cleanup := newMock(t)
+	defer cleanup()
+
+	q := c.Collection("coll1").Where("f", "==", 2)
+	sq, err := q.toProto()
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv.addRPC(&pb.RunAggregationQueryRequest{
+		Parent: q.parentPath,
+		QueryType: &pb.RunAggregationQueryRequest_StructuredAggregationQuery{
+			StructuredAggregationQuery: &pb.StructuredAggregationQuery{
+				QueryType: &pb.StructuredAggregationQuery_StructuredQuery{StructuredQuery: sq},
+				Aggregations: []*pb.StructuredAggregationQuery_Aggregation{
+					{Alias: "n", Operator: &pb.StructuredAggregationQuery_Aggregation_Count_{}},
+					{Alias: "total", Operator: &pb.StructuredAggregationQuery_Aggregation_Sum_{
+						Sum: &pb.StructuredAggregationQuery_Aggregation_Sum{Field: &pb.StructuredQuery_FieldReference{FieldPath: "a.b"}},
+					}},
+					{Alias: "mean", Operator: &pb.StructuredAggregationQuery_Aggregation_Avg_{
+						Avg: &pb.StructuredAggregationQuery_Aggregation_Avg{Field: &pb.StructuredQuery_FieldReference{FieldPath: "`x.y`"}},
+					}},
+				},
+			},
+		},
+	}, []interface{}{
+		&pb.RunAggregationQueryResponse{
+			Result: &pb.AggregationResult{
+				AggregateFields: map[string]*pb.Value{
+					"n":     intval(3),
+					"total": intval(12),
+					"mean":  floatval(1.5),
+				},
+			},
+		},
+	})
+
+	ar, err := q.NewAggregationQuery().
+		WithCount("n").
+		WithSum("a.b", "total").
+		WithAvgPath(FieldPath{"x.y"}, "mean").
+		Get(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var got struct {
+		N     int64   `firestore:"n"`
+		Total float64 `firestore:"total"`
+		Mean  float64 `firestore:"mean"`
+	}
+	if err := ar.DataTo(&got); err != nil {
+		t.Fatal(err)
+	}
+	if got.N != 3 || got.Total != 12 || got.Mean != 1.5 {
+		t.Errorf("got %+v, want {N:3 Total:12 Mean:1.5}", got)
+	}
+
+	var m map[string]interface{}
+	if err := ar.DataTo(&m); err != nil {
+		t.Fatal(err)
+	}
+	if want := map[string]interface{}{"n": int64(3), "total": int64(12), "mean": 1.5}; !testEqual(m, want) {
+		t.Errorf("got %v, want %v", m, want)
+	}
+}
+
+func TestAggregationQueryErrors(t *testing.T) {
+	ctx := context.Background()
+	c, _, cleanup := newMock(t)
+	defer cleanup()
+
+	q := c.Collection("coll1").Query
+	for _, aq := range []*AggregationQuery{
+		q.NewAggregationQuery(),
+		q.NewAggregationQuery().WithSum("a..b", "s"),
+		q.NewAggregationQuery().WithAvgPath(FieldPath{}, "s"),
+		q.NewAggregationQuery().WithCount("x").WithSum("a", "x"),
+	} {
+		if _, err := aq.Get(ctx); err == nil {
+			t.Errorf("%+v: got nil, want error", aq.aggregateQueries)
+		}
+	}
+
+	var got struct{ N int64 }
+	if err := (AggregationResult{"N": 3}).DataTo(&got); err == nil {
+		t.Error("DataTo with non-proto value: got nil, want error")
+	}
+}
+
+func TestQueryWithReadOptions(t *testing.T) {
+	ctx := context.Background()
+	c, srv, cleanup := newMock(t)
+	defer cleanup()
+
+	const dbPath = "projects/projectID/databases/(default)"
+	from := []*pb.StructuredQuery_CollectionSelector{{CollectionId: "C"}}
+	readTime := &pb.RunQueryRequest_ReadTime{ReadTime: aTimestamp}
+	resp := []interface{}{&pb.RunQueryResponse{ReadTime: aTimestamp}}
+
+	// Read options on a collection apply to queries on it.
+	srv.addRPC(&pb.RunQueryRequest{
+		Parent:              dbPath + "/documents",
+		QueryType:           &pb.RunQueryRequest_StructuredQuery{&pb.StructuredQuery{From: from}},
+		ConsistencySelector: readTime,
+	}, resp)
+	if _, err := c.Collection("C").WithReadOptions(ReadTime(aTime)).Documents(ctx).GetAll(); err != nil {
+		t.Fatal(err)
+	}
+
+	// Read options on a query do not apply to the collection it came from.
+	coll := c.Collection("C")
+	q := coll.Offset(1)
+	q.WithReadOptions(ReadTime(aTime))
+	srv.addRPC(&pb.RunQueryRequest{
+		Parent:              dbPath + "/documents",
+		QueryType:           &pb.RunQueryRequest_StructuredQuery{&pb.StructuredQuery{From: from, Offset: 1}},
+		ConsistencySelector: readTime,
+	}, resp)
+	if _, err := q.Documents(ctx).GetAll(); err != nil {
+		t.Fatal(err)
+	}
+	srv.addRPC(&pb.RunQueryRequest{
+		Parent:    dbPath + "/documents",
+		QueryType: &pb.RunQueryRequest_StructuredQuery{&pb.StructuredQuery{From: from}},
+	}, resp)
+	if _, err := coll.Documents(ctx).GetAll(); err != nil {
+		t.Fatal(err)
+	}
+
+	// Aggregation queries read at the query's read time.
+	srv.addRPC(&pb.RunAggregationQueryRequest{
+		Parent: dbPath + "/documents",
+		QueryType: &pb.RunAggregationQueryRequest_StructuredAggregationQuery{
+			StructuredAggregationQuery: &pb.StructuredAggregationQuery{
+				QueryType: &pb.StructuredAggregationQuery_StructuredQuery{
+					StructuredQuery: &pb.StructuredQuery{From: from, Offset: 1},
+				},
+				Aggregations: []*pb.StructuredAggregationQuery_Aggregation{{
+					Alias:    "n",
+					Operator: &pb.StructuredAggregationQuery_Aggregation_Count_{Count: &pb.StructuredAggregationQuery_Aggregation_Count{}},
+				}},
+			},
+		},
+		ConsistencySelector: &pb.RunAggregationQueryRequest_ReadTime{ReadTime: aTimestamp},
+	}, []interface{}{
+		&pb.RunAggregationQueryResponse{
+			Result: &pb.AggregationResult{AggregateFields: map[string]*pb.Value{"n": intval(0)}},
+		},
+	})
+	if _, err := q.NewAggregationQuery().WithCount("n").Get(ctx); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -86,6 +86,16 @@ func toProtoValue(v reflect.Value) (pbv *pb.Value, sawTransform bool, err error)
 			return nullValue, false, nil
 		}
 		return &pb.Value{ValueType: &pb.Value_ReferenceValue{x.Path}}, false, nil
+	case Vector32:
+		if x == nil {
+			return nullValue, false, nil
+		}
+		return vectorToProtoValue(vector32To64(x)), false, nil
+	case Vector64:
+		if x == nil {
+			return nullValue, false, nil
+		}
+		return vectorToProtoValue(x), false, nil
 		// Do not add bool, string, int, etc. to this switch; leave them in the
 		// reflect-based switch below. Moving them here would drop support for
 		// types whose underlying types are those primitives.
@@ -177,6 +187,9 @@ func mapToProtoValue(v reflect.Value) (*pb.Value, bool, error) {
 		} else if _, ok := mi.Interface().(arrayRemove); ok {
 			sawTransform = true
 			continue
+		} else if _, ok := mi.Interface().(transform); ok {
+			sawTransform = true
+			continue
 		}
 		val, sst, err := toProtoValue(mi)
 		if err != nil {
@@ -215,6 +228,9 @@ func structToProtoValue(v reflect.Value) (*pb.Value, bool, error) {
 	if _, ok := v.Interface().(arrayRemove); ok {
 		return nil, false, errors.New("firestore: ArrayRemove may not be used in structs")
 	}
+	if _, ok := v.Interface().(transform); ok {
+		return nil, false, errors.New("firestore: Increment, Maximum and Minimum may not be used in structs or arrays")
+	}
 
 	for _, f := range fields {
 		fv := v.FieldByIndex(f.Index)
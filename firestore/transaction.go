@@ -17,6 +17,7 @@ package firestore
 import (
 	"context"
 	"errors"
+	"time"
 
 	pb "cloud.google.com/go/firestore/apiv1/firestorepb"
 	"cloud.google.com/go/internal/trace"
@@ -61,6 +62,22 @@ type ro struct{}
 
 func (ro) config(t *Transaction) { t.readOnly = true }
 
+// ReadOnlyAt returns a TransactionOption that makes the transaction read-only,
+// like ReadOnly, and makes all of its reads observe the database as it was at
+// readTime. Because no locks are taken, such a transaction can read a large
+// amount of data as a consistent snapshot without contending with writes.
+//
+// A read-only transaction without ReadOnlyAt reads at the read time of the
+// client, if one was set with Client.WithReadOptions.
+func ReadOnlyAt(readTime time.Time) TransactionOption { return roAt(readTime) }
+
+type roAt time.Time
+
+func (r roAt) config(t *Transaction) {
+	t.readOnly = true
+	t.readSettings.readTime = time.Time(r)
+}
+
 var (
 	// Defined here for testing.
 	errReadAfterWrite    = errors.New("firestore: read after write in transaction")
@@ -109,8 +126,12 @@ func (c *Client) RunTransaction(ctx context.Context, f func(context.Context, *Tr
 	}
 	var txOpts *pb.TransactionOptions
 	if t.readOnly {
+		ro := &pb.TransactionOptions_ReadOnly{}
+		if rt, hasOpts := parseReadTime(c, t.readSettings); hasOpts {
+			ro.ConsistencySelector = &pb.TransactionOptions_ReadOnly_ReadTime{ReadTime: rt}
+		}
 		txOpts = &pb.TransactionOptions{
-			Mode: &pb.TransactionOptions_ReadOnly_{&pb.TransactionOptions_ReadOnly{}},
+			Mode: &pb.TransactionOptions_ReadOnly_{ro},
 		}
 	}
 	var backoff gax.Backoff
@@ -543,3 +543,72 @@ func TestTransaction_WithReadOptions(t *testing.T) {
 		t.Fatal(err)
 	}
 }
+
+func TestRunTransaction_ReadOnlyAt(t *testing.T) {
+	ctx := context.Background()
+	c, srv, cleanup := newMock(t)
+	defer cleanup()
+
+	const db = "projects/projectID/databases/(default)"
+	tid := []byte{1}
+
+	srv.addRPC(
+		&pb.BeginTransactionRequest{
+			Database: db,
+			Options: &pb.TransactionOptions{
+				Mode: &pb.TransactionOptions_ReadOnly_{&pb.TransactionOptions_ReadOnly{
+					ConsistencySelector: &pb.TransactionOptions_ReadOnly_ReadTime{ReadTime: aTimestamp},
+				}},
+			},
+		},
+		&pb.BeginTransactionResponse{Transaction: tid},
+	)
+	srv.addRPC(
+		&pb.BatchGetDocumentsRequest{
+			Database:            db,
+			Documents:           []string{db + "/documents/C/a"},
+			ConsistencySelector: &pb.BatchGetDocumentsRequest_Transaction{tid},
+		},
+		[]interface{}{
+			&pb.BatchGetDocumentsResponse{
+				Result:   &pb.BatchGetDocumentsResponse_Missing{db + "/documents/C/a"},
+				ReadTime: aTimestamp,
+			},
+		},
+	)
+	srv.addRPC(&pb.CommitRequest{Database: db, Transaction: tid}, &pb.CommitResponse{CommitTime: aTimestamp2})
+	err := c.RunTransaction(ctx, func(_ context.Context, tx *Transaction) error {
+		ds, err := tx.Get(c.Doc("C/a"))
+		if status.Code(err) != codes.NotFound {
+			return err
+		}
+		if !ds.ReadTime.Equal(aTime) {
+			t.Errorf("got read time %v, want %v", ds.ReadTime, aTime)
+		}
+		return nil
+	}, ReadOnlyAt(aTime))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Writes are not allowed, as with ReadOnly.
+	srv.reset()
+	srv.addRPC(
+		&pb.BeginTransactionRequest{
+			Database: db,
+			Options: &pb.TransactionOptions{
+				Mode: &pb.TransactionOptions_ReadOnly_{&pb.TransactionOptions_ReadOnly{
+					ConsistencySelector: &pb.TransactionOptions_ReadOnly_ReadTime{ReadTime: aTimestamp},
+				}},
+			},
+		},
+		&pb.BeginTransactionResponse{Transaction: tid},
+	)
+	srv.addRPC(&pb.RollbackRequest{Database: db, Transaction: tid}, &empty.Empty{})
+	err = c.RunTransaction(ctx, func(_ context.Context, tx *Transaction) error {
+		return tx.Delete(c.Doc("C/a"))
+	}, ReadOnlyAt(aTime))
+	if err != errWriteReadOnly {
+		t.Errorf("got <%v>, want <%v>", err, errWriteReadOnly)
+	}
+}
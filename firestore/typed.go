@@ -0,0 +1,101 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package firestore
+
+import "context"
+
+// Get retrieves the document denoted by d and returns its data as a T. The
+// data is converted as described by DocumentSnapshot.DataTo; T is typically a
+// struct type or a map with string keys.
+//
+// If the document does not exist, Get returns the zero value of T and a
+// NotFound error.
+func Get[T any](ctx context.Context, d *DocumentRef) (T, error) {
+	var v T
+	ds, err := d.Get(ctx)
+	if err != nil {
+		return v, err
+	}
+	if err := ds.DataTo(&v); err != nil {
+		return v, err
+	}
+	return v, nil
+}
+
+// Set is like DocumentRef.Set, but its data argument is typed, so that
+// callers storing a document type have it checked at compile time.
+func Set[T any](ctx context.Context, d *DocumentRef, data T, opts ...SetOption) (*WriteResult, error) {
+	return d.Set(ctx, data, opts...)
+}
+
+// Documents returns an iterator over the results of the query or collection
+// q, whose data are returned as Ts.
+func Documents[T any](ctx context.Context, q Queryer) *TypedDocumentIterator[T] {
+	return &TypedDocumentIterator[T]{it: q.query().Documents(ctx)}
+}
+
+// TypedDocumentIterator is an iterator over documents whose data are
+// returned as Ts. Create one with Documents.
+type TypedDocumentIterator[T any] struct {
+	it   *DocumentIterator
+	snap *DocumentSnapshot
+}
+
+// Next returns the data of the next result, converted as described by
+// DocumentSnapshot.DataTo. Its second return value is iterator.Done if there
+// are no more results. Once Next returns Done, all subsequent calls will
+// return Done.
+func (it *TypedDocumentIterator[T]) Next() (T, error) {
+	var v T
+	ds, err := it.it.Next()
+	if err != nil {
+		return v, err
+	}
+	if err := ds.DataTo(&v); err != nil {
+		return v, err
+	}
+	it.snap = ds
+	return v, nil
+}
+
+// Snapshot returns the DocumentSnapshot of the result most recently returned
+// by Next, which holds its reference and timestamps. It returns nil if Next has
+// not returned a result.
+func (it *TypedDocumentIterator[T]) Snapshot() *DocumentSnapshot {
+	return it.snap
+}
+
+// GetAll returns the data of all the documents remaining from the iterator.
+// It is not necessary to call Stop on the iterator after calling GetAll.
+func (it *TypedDocumentIterator[T]) GetAll() ([]T, error) {
+	docs, err := it.it.GetAll()
+	if err != nil {
+		return nil, err
+	}
+	vs := make([]T, len(docs))
+	for i, ds := range docs {
+		if err := ds.DataTo(&vs[i]); err != nil {
+			return nil, err
+		}
+	}
+	return vs, nil
+}
+
+// Stop stops the iterator, freeing its resources.
+// Always call Stop when you are done with an iterator.
+// It is not safe to call Stop concurrently with Next.
+func (it *TypedDocumentIterator[T]) Stop() {
+	it.it.Stop()
+}
@@ -0,0 +1,146 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package firestore
+
+import (
+	"context"
+	"testing"
+
+	pb "cloud.google.com/go/firestore/apiv1/firestorepb"
+	"google.golang.org/api/iterator"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type typedDoc struct {
+	A int `firestore:"a"`
+}
+
+func TestTypedGetSet(t *testing.T) {
+	ctx := context.Background()
+	c, srv, cleanup := newMock(t)
+	defer cleanup()
+
+	srv.addRPC(commitRequestForSet(), commitResponseForSet)
+	wr, err := Set(ctx, c.Doc("C/d"), typedDoc{A: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !testEqual(wr, writeResultForSet) {
+		t.Errorf("got %v, want %v", wr, writeResultForSet)
+	}
+
+	path := "projects/projectID/databases/(default)/documents/C/d"
+	srv.addRPC(&pb.BatchGetDocumentsRequest{
+		Database:  c.path(),
+		Documents: []string{path},
+	}, []interface{}{
+		&pb.BatchGetDocumentsResponse{
+			Result: &pb.BatchGetDocumentsResponse_Found{Found: &pb.Document{
+				Name:       path,
+				CreateTime: aTimestamp,
+				UpdateTime: aTimestamp,
+				Fields:     testFields,
+			}},
+			ReadTime: aTimestamp2,
+		},
+	})
+	got, err := Get[typedDoc](ctx, c.Doc("C/d"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := (typedDoc{A: 1}); got != want {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+
+	srv.addRPC(&pb.BatchGetDocumentsRequest{
+		Database:  c.path(),
+		Documents: []string{path},
+	}, []interface{}{
+		&pb.BatchGetDocumentsResponse{
+			Result:   &pb.BatchGetDocumentsResponse_Missing{Missing: path},
+			ReadTime: aTimestamp2,
+		},
+	})
+	got, err = Get[typedDoc](ctx, c.Doc("C/d"))
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("got %v, want NotFound", err)
+	}
+	if got != (typedDoc{}) {
+		t.Errorf("got %+v, want zero value", got)
+	}
+}
+
+func TestTypedDocuments(t *testing.T) {
+	ctx := context.Background()
+	c, srv, cleanup := newMock(t)
+	defer cleanup()
+
+	const dbPath = "projects/projectID/databases/(default)"
+	pdocs := []*pb.Document{
+		{
+			Name:       dbPath + "/documents/C/a",
+			CreateTime: aTimestamp,
+			UpdateTime: aTimestamp,
+			Fields:     map[string]*pb.Value{"a": intval(2)},
+		},
+		{
+			Name:       dbPath + "/documents/C/b",
+			CreateTime: aTimestamp,
+			UpdateTime: aTimestamp,
+			Fields:     map[string]*pb.Value{"a": intval(1)},
+		},
+	}
+	resps := []interface{}{
+		&pb.RunQueryResponse{Document: pdocs[0], ReadTime: aTimestamp},
+		&pb.RunQueryResponse{Document: pdocs[1], ReadTime: aTimestamp},
+	}
+
+	srv.addRPC(nil, resps)
+	it := Documents[typedDoc](ctx, c.Collection("C"))
+	if it.Snapshot() != nil {
+		t.Error("got snapshot before Next, want nil")
+	}
+	var ids []string
+	var vals []typedDoc
+	for {
+		v, err := it.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		vals = append(vals, v)
+		ids = append(ids, it.Snapshot().Ref.ID)
+	}
+	it.Stop()
+	if want := []string{"a", "b"}; !testEqual(ids, want) {
+		t.Errorf("got IDs %v, want %v", ids, want)
+	}
+	if want := []typedDoc{{A: 2}, {A: 1}}; !testEqual(vals, want) {
+		t.Errorf("got %+v, want %+v", vals, want)
+	}
+
+	srv.addRPC(nil, resps)
+	got, err := Documents[map[string]interface{}](ctx, c.Collection("C").Where("a", ">", 0)).GetAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []map[string]interface{}{{"a": int64(2)}, {"a": int64(1)}}
+	if !testEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,221 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package firestore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+
+	pb "cloud.google.com/go/firestore/apiv1/firestorepb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+// Vector32 is an embedding vector of float32s. It is stored in Firestore as a
+// vector value, which can be searched with Query.FindNearest.
+type Vector32 []float32
+
+// Vector64 is an embedding vector of float64s. It is stored in Firestore as a
+// vector value, which can be searched with Query.FindNearest.
+//
+// Vector values read into an interface{} are returned as Vector64.
+type Vector64 []float64
+
+// Firestore represents a vector as a map whose vectorTypeField is
+// vectorTypeValue and whose vectorValueField is an array of doubles.
+const (
+	vectorTypeField  = "__type__"
+	vectorTypeValue  = "__vector__"
+	vectorValueField = "value"
+)
+
+const (
+	maxVectorDimensions = 2048
+	maxFindNearestLimit = 1000
+)
+
+var (
+	typeOfVector32 = reflect.TypeOf(Vector32(nil))
+	typeOfVector64 = reflect.TypeOf(Vector64(nil))
+)
+
+// vectorToProtoValue returns the Firestore Value of a vector.
+func vectorToProtoValue(v []float64) *pb.Value {
+	vals := make([]*pb.Value, len(v))
+	for i, f := range v {
+		vals[i] = &pb.Value{ValueType: &pb.Value_DoubleValue{DoubleValue: f}}
+	}
+	return &pb.Value{ValueType: &pb.Value_MapValue{MapValue: &pb.MapValue{
+		Fields: map[string]*pb.Value{
+			vectorTypeField:  {ValueType: &pb.Value_StringValue{StringValue: vectorTypeValue}},
+			vectorValueField: {ValueType: &pb.Value_ArrayValue{ArrayValue: &pb.ArrayValue{Values: vals}}},
+		},
+	}}}
+}
+
+// vectorFromProtoValue returns the elements of a vector value, and whether
+// v is a vector value.
+func vectorFromProtoValue(v *pb.Value) ([]float64, bool, error) {
+	fields := v.GetMapValue().GetFields()
+	if len(fields) != 2 || fields[vectorTypeField].GetStringValue() != vectorTypeValue {
+		return nil, false, nil
+	}
+	av, ok := fields[vectorValueField].GetValueType().(*pb.Value_ArrayValue)
+	if !ok {
+		return nil, true, errors.New("firestore: vector value has no array of values")
+	}
+	res := make([]float64, len(av.ArrayValue.Values))
+	for i, e := range av.ArrayValue.Values {
+		switch x := e.ValueType.(type) {
+		case *pb.Value_DoubleValue:
+			res[i] = x.DoubleValue
+		case *pb.Value_IntegerValue:
+			res[i] = float64(x.IntegerValue)
+		default:
+			return nil, true, fmt.Errorf("firestore: vector element %d has type %s", i, typeString(e))
+		}
+	}
+	return res, true, nil
+}
+
+func vector32To64(v Vector32) []float64 {
+	res := make([]float64, len(v))
+	for i, f := range v {
+		res[i] = float64(f)
+	}
+	return res
+}
+
+// setVectorFromProtoValue sets v, which must be a Vector32 or Vector64, from
+// a vector value.
+func setVectorFromProtoValue(v reflect.Value, vproto *pb.Value) error {
+	vec, ok, err := vectorFromProtoValue(vproto)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return fmt.Errorf("firestore: cannot set type %s to %s", v.Type(), typeString(vproto))
+	}
+	if v.Type() == typeOfVector64 {
+		v.Set(reflect.ValueOf(Vector64(vec)))
+		return nil
+	}
+	v32 := make(Vector32, len(vec))
+	for i, f := range vec {
+		v32[i] = float32(f)
+	}
+	v.Set(reflect.ValueOf(v32))
+	return nil
+}
+
+// DistanceMeasure is the measure used by Query.FindNearest to compare
+// vectors.
+type DistanceMeasure int32
+
+const (
+	// DistanceMeasureEuclidean measures the Euclidean distance between
+	// vectors. Smaller distances are nearer.
+	DistanceMeasureEuclidean DistanceMeasure = DistanceMeasure(pb.StructuredQuery_FindNearest_EUCLIDEAN)
+
+	// DistanceMeasureCosine measures the angle between vectors. Smaller
+	// angles are nearer.
+	DistanceMeasureCosine DistanceMeasure = DistanceMeasure(pb.StructuredQuery_FindNearest_COSINE)
+
+	// DistanceMeasureDotProduct measures the dot product of vectors. Larger
+	// products are nearer.
+	DistanceMeasureDotProduct DistanceMeasure = DistanceMeasure(pb.StructuredQuery_FindNearest_DOT_PRODUCT)
+)
+
+// VectorQuery is a query for the documents whose vector fields are nearest to
+// a query vector. Create one with Query.FindNearest.
+type VectorQuery struct {
+	q Query
+}
+
+// FindNearest returns a VectorQuery that finds the limit documents matching q
+// whose vector field denoted by path is nearest to queryVector, as measured by
+// measure. The documents are returned from nearest to farthest. The field
+// must have a vector index.
+//
+// queryVector must be a Vector32, Vector64, []float32 or []float64, with at
+// most 2048 elements. limit must be between 1 and 1000.
+//
+// The path argument can be a single field or a dot-separated sequence of
+// fields, and must not contain any of the runes "˜*/[]". Use FindNearestPath
+// instead for such a path.
+func (q Query) FindNearest(path string, queryVector interface{}, limit int, measure DistanceMeasure) VectorQuery {
+	fp, err := parseDotSeparatedString(path)
+	if err != nil {
+		q.err = err
+		return VectorQuery{q: q}
+	}
+	return q.FindNearestPath(fp, queryVector, limit, measure)
+}
+
+// FindNearestPath is like FindNearest, but takes the path of the vector field
+// as a FieldPath.
+func (q Query) FindNearestPath(fp FieldPath, queryVector interface{}, limit int, measure DistanceMeasure) VectorQuery {
+	fn, err := findNearestToProto(fp, queryVector, limit, measure)
+	if err != nil {
+		q.err = err
+	}
+	q.findNearest = fn
+	return VectorQuery{q: q}
+}
+
+// Documents returns an iterator over the documents found by the query, from
+// nearest to farthest.
+func (vq VectorQuery) Documents(ctx context.Context) *DocumentIterator {
+	return vq.q.Documents(ctx)
+}
+
+// findNearestToProto returns the find_nearest field of a StructuredQuery.
+func findNearestToProto(fp FieldPath, queryVector interface{}, limit int, measure DistanceMeasure) (*pb.StructuredQuery_FindNearest, error) {
+	var vec []float64
+	switch v := queryVector.(type) {
+	case Vector32:
+		vec = vector32To64(v)
+	case []float32:
+		vec = vector32To64(v)
+	case Vector64:
+		vec = v
+	case []float64:
+		vec = v
+	default:
+		return nil, fmt.Errorf("firestore: FindNearest query vector has type %T, want Vector32, Vector64, []float32 or []float64", queryVector)
+	}
+	if len(vec) == 0 || len(vec) > maxVectorDimensions {
+		return nil, fmt.Errorf("firestore: FindNearest query vector has %d dimensions, want between 1 and %d", len(vec), maxVectorDimensions)
+	}
+	if limit < 1 || limit > maxFindNearestLimit {
+		return nil, fmt.Errorf("firestore: FindNearest limit %d is not between 1 and %d", limit, maxFindNearestLimit)
+	}
+	switch measure {
+	case DistanceMeasureEuclidean, DistanceMeasureCosine, DistanceMeasureDotProduct:
+	default:
+		return nil, fmt.Errorf("firestore: invalid FindNearest distance measure %d", measure)
+	}
+	ref, err := fref(fp)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.StructuredQuery_FindNearest{
+		VectorField:     ref,
+		QueryVector:     vectorToProtoValue(vec),
+		DistanceMeasure: pb.StructuredQuery_FindNearest_DistanceMeasure(measure),
+		Limit:           wrapperspb.Int32(int32(limit)),
+	}, nil
+}
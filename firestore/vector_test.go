@@ -0,0 +1,174 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package firestore
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	pb "cloud.google.com/go/firestore/apiv1/firestorepb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+func vectorval(fs ...float64) *pb.Value {
+	vals := make([]*pb.Value, len(fs))
+	for i, f := range fs {
+		vals[i] = floatval(f)
+	}
+	return mapval(map[string]*pb.Value{
+		"__type__": strval("__vector__"),
+		"value":    arrayval(vals...),
+	})
+}
+
+func TestVectorToProtoValue(t *testing.T) {
+	for _, test := range []struct {
+		in   interface{}
+		want *pb.Value
+	}{
+		{Vector64{1, 2.5}, vectorval(1, 2.5)},
+		{Vector32{1, 2.5}, vectorval(1, 2.5)},
+		{Vector64{}, vectorval()},
+		{Vector64(nil), nullValue},
+		{Vector32(nil), nullValue},
+		{map[string]interface{}{"v": Vector64{3}}, mapval(map[string]*pb.Value{"v": vectorval(3)})},
+	} {
+		got, _, err := toProtoValue(reflect.ValueOf(test.in))
+		if err != nil {
+			t.Fatalf("%v: %v", test.in, err)
+		}
+		if !testEqual(got, test.want) {
+			t.Errorf("%+v: got %v, want %v", test.in, got, test.want)
+		}
+	}
+}
+
+func TestVectorFromProtoValue(t *testing.T) {
+	var v64 Vector64
+	if err := setFromProtoValue(&v64, vectorval(1, 2.5), nil); err != nil {
+		t.Fatal(err)
+	}
+	if want := (Vector64{1, 2.5}); !testEqual(v64, want) {
+		t.Errorf("Vector64: got %v, want %v", v64, want)
+	}
+
+	var v32 Vector32
+	if err := setFromProtoValue(&v32, vectorval(1, 2.5), nil); err != nil {
+		t.Fatal(err)
+	}
+	if want := (Vector32{1, 2.5}); !testEqual(v32, want) {
+		t.Errorf("Vector32: got %v, want %v", v32, want)
+	}
+
+	var s struct{ V Vector32 }
+	if err := setFromProtoValue(&s, mapval(map[string]*pb.Value{"V": vectorval(4)}), nil); err != nil {
+		t.Fatal(err)
+	}
+	if want := (Vector32{4}); !testEqual(s.V, want) {
+		t.Errorf("struct field: got %v, want %v", s.V, want)
+	}
+
+	got, err := createFromProtoValue(vectorval(1, 2), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := (Vector64{1, 2}); !testEqual(got, want) {
+		t.Errorf("interface: got %#v, want %#v", got, want)
+	}
+
+	for _, in := range []*pb.Value{
+		arrayval(floatval(1)),
+		mapval(map[string]*pb.Value{"__type__": strval("__vector__"), "value": strval("x")}),
+		mapval(map[string]*pb.Value{"__type__": strval("__vector__"), "value": arrayval(strval("x"))}),
+	} {
+		if err := setFromProtoValue(&v64, in, nil); err == nil {
+			t.Errorf("%v: got nil, want error", in)
+		}
+	}
+}
+
+func TestFindNearest(t *testing.T) {
+	ctx := context.Background()
+	c, srv, cleanup := newMock(t)
+	defer cleanup()
+
+	srv.addRPC(&pb.RunQueryRequest{
+		Parent: "projects/projectID/databases/(default)/documents",
+		QueryType: &pb.RunQueryRequest_StructuredQuery{StructuredQuery: &pb.StructuredQuery{
+			From: []*pb.StructuredQuery_CollectionSelector{{CollectionId: "C"}},
+			Where: &pb.StructuredQuery_Filter{FilterType: &pb.StructuredQuery_Filter_FieldFilter{
+				FieldFilter: &pb.StructuredQuery_FieldFilter{
+					Field: fref1("genre"),
+					Op:    pb.StructuredQuery_FieldFilter_EQUAL,
+					Value: strval("jazz"),
+				},
+			}},
+			FindNearest: &pb.StructuredQuery_FindNearest{
+				VectorField:     fref1("embedding"),
+				QueryVector:     vectorval(1, 2),
+				DistanceMeasure: pb.StructuredQuery_FindNearest_COSINE,
+				Limit:           wrapperspb.Int32(10),
+			},
+		}},
+	}, []interface{}{&pb.RunQueryResponse{ReadTime: aTimestamp}})
+	vq := c.Collection("C").Where("genre", "==", "jazz").FindNearest("embedding", Vector32{1, 2}, 10, DistanceMeasureCosine)
+	if _, err := vq.Documents(ctx).GetAll(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestFindNearestRoundTrip(t *testing.T) {
+	c := &Client{projectID: "P", databaseID: "DB"}
+	q := c.Collection("C").FindNearest("embedding.v", Vector64{1, 2}, 3, DistanceMeasureDotProduct).q
+	b, err := q.Serialize()
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := Query{c: c}.Deserialize(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want, err := q.toProto()
+	if err != nil {
+		t.Fatal(err)
+	}
+	gotp, err := got.toProto()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !testEqual(gotp, want) {
+		t.Errorf("got %v, want %v", gotp, want)
+	}
+}
+
+func TestFindNearestErrors(t *testing.T) {
+	c := &Client{projectID: "P", databaseID: "DB"}
+	q := c.Collection("C").Query
+	big := make(Vector64, maxVectorDimensions+1)
+	for i, vq := range []VectorQuery{
+		q.FindNearest("~", Vector64{1}, 1, DistanceMeasureEuclidean),
+		q.FindNearest("v", []int{1}, 1, DistanceMeasureEuclidean),
+		q.FindNearest("v", Vector64{}, 1, DistanceMeasureEuclidean),
+		q.FindNearest("v", big, 1, DistanceMeasureEuclidean),
+		q.FindNearest("v", Vector64{1}, 0, DistanceMeasureEuclidean),
+		q.FindNearest("v", Vector64{1}, maxFindNearestLimit+1, DistanceMeasureEuclidean),
+		q.FindNearest("v", Vector64{1}, 1, DistanceMeasure(0)),
+	} {
+		if _, err := vq.q.toProto(); err == nil {
+			t.Errorf("query %d: got nil, want error", i)
+		}
+	}
+}
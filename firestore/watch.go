@@ -76,18 +76,80 @@ var defaultBackoff = gax.Backoff{
 	Multiplier: 1.5,
 }
 
+// A SnapshotOption is an option passed to Query.Snapshots or
+// DocumentRef.Snapshots.
+type SnapshotOption interface {
+	config(s *watchStream)
+}
+
+// ResumeFromToken is a SnapshotOption that resumes listening from a resume
+// token returned by the ResumeToken method of a snapshot iterator, which may
+// have been persisted by an earlier process.
+//
+// When resuming, the first snapshot holds only the documents that were
+// added or changed after the token was obtained, each reported as
+// DocumentAdded; documents removed in the meantime are not reported. Later
+// snapshots report changes as usual. A token expires after some time, in
+// which case the server sends the full results instead.
+func ResumeFromToken(token []byte) SnapshotOption {
+	return resumeFromToken(token)
+}
+
+type resumeFromToken []byte
+
+func (t resumeFromToken) config(s *watchStream) {
+	if len(t) > 0 {
+		s.target.ResumeType = &pb.Target_ResumeToken{ResumeToken: t}
+	}
+}
+
+// RestartPolicy is a SnapshotOption that controls how a listener restarts its
+// stream after it fails. A RestartPolicy does not affect errors from the
+// caller's context, which always stop the listener.
+type RestartPolicy struct {
+	// Backoff controls the delay between restarts. The delay returns to
+	// Backoff.Initial once the restarted stream makes progress. If zero, the
+	// delay starts at 1 second and grows by 1.5 times up to 60 seconds.
+	Backoff gax.Backoff
+
+	// MaxRestarts is the maximum number of consecutive restarts without
+	// progress, after which the iterator returns the last error. If zero,
+	// the stream is restarted indefinitely.
+	MaxRestarts int
+
+	// Retryable reports whether a stream that failed with err is restarted.
+	// If nil, streams are restarted after io.EOF and the Unknown,
+	// DeadlineExceeded, ResourceExhausted, Internal, Unavailable and
+	// Unauthenticated codes.
+	Retryable func(err error) bool
+}
+
+func (p RestartPolicy) config(s *watchStream) {
+	if p.Backoff != (gax.Backoff{}) {
+		s.initialBackoff = p.Backoff
+		s.backoff = p.Backoff
+	}
+	s.maxRestarts = p.MaxRestarts
+	s.retryable = p.Retryable
+}
+
 // not goroutine-safe
 type watchStream struct {
-	ctx         context.Context
-	c           *Client
-	lc          pb.Firestore_ListenClient                 // the gRPC stream
-	target      *pb.Target                                // document or query being watched
-	backoff     gax.Backoff                               // for stream retries
-	err         error                                     // sticky permanent error
-	readTime    time.Time                                 // time of most recent snapshot
-	current     bool                                      // saw CURRENT, but not RESET; precondition for a snapshot
-	hasReturned bool                                      // have we returned a snapshot yet?
-	compare     func(a, b *DocumentSnapshot) (int, error) // compare documents according to query
+	ctx            context.Context
+	c              *Client
+	lc             pb.Firestore_ListenClient                 // the gRPC stream
+	target         *pb.Target                                // document or query being watched
+	backoff        gax.Backoff                               // for stream retries
+	initialBackoff gax.Backoff                               // backoff restored once the stream is healthy
+	maxRestarts    int                                       // if non-zero, the limit on consecutive restarts
+	restarts       int                                       // consecutive restarts without progress
+	retryable      func(error) bool                          // if non-nil, reports whether to restart after an error
+	resumeToken    []byte                                    // resume token of the most recent snapshot
+	err            error                                     // sticky permanent error
+	readTime       time.Time                                 // time of most recent snapshot
+	current        bool                                      // saw CURRENT, but not RESET; precondition for a snapshot
+	hasReturned    bool                                      // have we returned a snapshot yet?
+	compare        func(a, b *DocumentSnapshot) (int, error) // compare documents according to query
 
 	// An ordered tree where DocumentSnapshots are the keys.
 	docTree *btree.BTree
@@ -98,7 +160,7 @@ type watchStream struct {
 	changeMap map[string]*DocumentSnapshot
 }
 
-func newWatchStreamForDocument(ctx context.Context, dr *DocumentRef) *watchStream {
+func newWatchStreamForDocument(ctx context.Context, dr *DocumentRef, opts ...SnapshotOption) *watchStream {
 	// A single document is always equal to itself.
 	compare := func(_, _ *DocumentSnapshot) (int, error) { return 0, nil }
 	return newWatchStream(ctx, dr.Parent.c, compare, &pb.Target{
@@ -106,10 +168,10 @@ func newWatchStreamForDocument(ctx context.Context, dr *DocumentRef) *watchStrea
 			Documents: &pb.Target_DocumentsTarget{Documents: []string{dr.Path}},
 		},
 		TargetId: watchTargetID,
-	})
+	}, opts...)
 }
 
-func newWatchStreamForQuery(ctx context.Context, q Query) (*watchStream, error) {
+func newWatchStreamForQuery(ctx context.Context, q Query, opts ...SnapshotOption) (*watchStream, error) {
 	qp, err := q.toProto()
 	if err != nil {
 		return nil, err
@@ -123,20 +185,24 @@ func newWatchStreamForQuery(ctx context.Context, q Query) (*watchStream, error)
 		},
 		TargetId: watchTargetID,
 	}
-	return newWatchStream(ctx, q.c, q.compareFunc(), target), nil
+	return newWatchStream(ctx, q.c, q.compareFunc(), target, opts...), nil
 }
 
 const btreeDegree = 4
 
-func newWatchStream(ctx context.Context, c *Client, compare func(_, _ *DocumentSnapshot) (int, error), target *pb.Target) *watchStream {
+func newWatchStream(ctx context.Context, c *Client, compare func(_, _ *DocumentSnapshot) (int, error), target *pb.Target, opts ...SnapshotOption) *watchStream {
 	w := &watchStream{
-		ctx:       ctx,
-		c:         c,
-		compare:   compare,
-		target:    target,
-		backoff:   defaultBackoff,
-		docMap:    map[string]*DocumentSnapshot{},
-		changeMap: map[string]*DocumentSnapshot{},
+		ctx:            ctx,
+		c:              c,
+		compare:        compare,
+		target:         target,
+		backoff:        defaultBackoff,
+		initialBackoff: defaultBackoff,
+		docMap:         map[string]*DocumentSnapshot{},
+		changeMap:      map[string]*DocumentSnapshot{},
+	}
+	for _, opt := range opts {
+		opt.config(w)
 	}
 	w.docTree = btree.New(btreeDegree, func(a, b interface{}) bool {
 		return w.less(a.(*DocumentSnapshot), b.(*DocumentSnapshot))
@@ -180,6 +246,7 @@ func (s *watchStream) nextSnapshot() (*btree.BTree, []DocumentChange, time.Time,
 	}
 	s.changeMap = map[string]*DocumentSnapshot{}
 	s.hasReturned = true
+	s.resumeToken = append([]byte(nil), s.target.GetResumeToken()...)
 	return s.docTree, changes, s.readTime, nil
 }
 
@@ -291,7 +358,8 @@ func (s *watchStream) handleTargetChange(tc *pb.TargetChange) bool {
 	// If we see a resume token and our watch ID is affected, we assume the stream
 	// is now healthy, so we reset our backoff time to the minimum.
 	if tc.ResumeToken != nil && (len(tc.TargetIds) == 0 || hasWatchTargetID(tc.TargetIds)) {
-		s.backoff = defaultBackoff
+		s.backoff = s.initialBackoff
+		s.restarts = 0
 	}
 	return false // not in a consistent state, keep receiving
 }
@@ -476,9 +544,13 @@ func (s *watchStream) recv() (*pb.ListenResponse, error) {
 			}
 		}
 		res, err := s.lc.Recv()
-		if err == nil || isPermanentWatchError(err) {
+		if err == nil || !s.isRetryable(err) {
 			return res, err
 		}
+		s.restarts++
+		if s.maxRestarts > 0 && s.restarts > s.maxRestarts {
+			return nil, fmt.Errorf("firestore: listen stream failed after %d restarts: %w", s.maxRestarts, err)
+		}
 		// Non-permanent error. Sleep and retry.
 		s.changeMap = map[string]*DocumentSnapshot{} // clear changeMap
 		dur := s.backoff.Pause()
@@ -511,6 +583,14 @@ func (s *watchStream) open() (lc pb.Firestore_ListenClient, err error) {
 	return lc, nil
 }
 
+// isRetryable reports whether the stream should be restarted after err.
+func (s *watchStream) isRetryable(err error) bool {
+	if s.retryable != nil {
+		return s.retryable(err)
+	}
+	return !isPermanentWatchError(err)
+}
+
 func isPermanentWatchError(err error) bool {
 	if err == io.EOF {
 		// Retry on normal end-of-stream.
@@ -226,3 +226,101 @@ func TestWatchCancel(t *testing.T) {
 	// TODO(jba): Test that we get codes.Canceled when canceling an RPC.
 	// We had a test for this in a21236af, but it was flaky for unclear reasons.
 }
+
+func TestWatchRestartPolicy(t *testing.T) {
+	ctx := context.Background()
+	c, srv, cleanup := newMock(t)
+	defer cleanup()
+
+	request := &pb.ListenRequest{
+		Database:     "projects/projectID/databases/(default)",
+		TargetChange: &pb.ListenRequest_AddTarget{&pb.Target{}},
+	}
+	response := &pb.ListenResponse{ResponseType: &pb.ListenResponse_DocumentChange{&pb.DocumentChange{}}}
+	backoff := gax.Backoff{Initial: 1, Max: 1, Multiplier: 1}
+
+	// The stream gives up after MaxRestarts consecutive restarts.
+	ws := newWatchStream(ctx, c, nil, &pb.Target{}, RestartPolicy{Backoff: backoff, MaxRestarts: 2})
+	srv.addRPC(request, []interface{}{status.Error(codes.Unavailable, "")})
+	srv.addRPC(request, []interface{}{status.Error(codes.Unavailable, "")})
+	srv.addRPC(request, []interface{}{status.Error(codes.Unavailable, "")})
+	_, err := ws.recv()
+	if got, want := status.Code(err), codes.Unavailable; got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+
+	// Retryable overrides the classification of errors.
+	ws = newWatchStream(ctx, c, nil, &pb.Target{}, RestartPolicy{
+		Backoff:   backoff,
+		Retryable: func(err error) bool { return status.Code(err) == codes.Aborted },
+	})
+	srv.addRPC(request, []interface{}{status.Error(codes.Aborted, "")})
+	srv.addRPC(request, []interface{}{response, status.Error(codes.Unavailable, "")})
+	if _, err := ws.recv(); err != nil {
+		t.Fatal(err)
+	}
+	_, err = ws.recv()
+	if got, want := status.Code(err), codes.Unavailable; got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+}
+
+func TestWatchResumeToken(t *testing.T) {
+	ctx := context.Background()
+	c, srv, cleanup := newMock(t)
+	defer cleanup()
+
+	dr := c.Doc("C/d")
+	target := func(token []byte) *pb.Target {
+		t := &pb.Target{
+			TargetType: &pb.Target_Documents{
+				Documents: &pb.Target_DocumentsTarget{Documents: []string{dr.Path}},
+			},
+			TargetId: watchTargetID,
+		}
+		if token != nil {
+			t.ResumeType = &pb.Target_ResumeToken{ResumeToken: token}
+		}
+		return t
+	}
+	request := func(token []byte) *pb.ListenRequest {
+		return &pb.ListenRequest{
+			Database:     "projects/projectID/databases/(default)",
+			TargetChange: &pb.ListenRequest_AddTarget{target(token)},
+		}
+	}
+	current := &pb.ListenResponse{ResponseType: &pb.ListenResponse_TargetChange{&pb.TargetChange{
+		TargetChangeType: pb.TargetChange_CURRENT,
+	}}}
+	noChange := func(token string) *pb.ListenResponse {
+		return &pb.ListenResponse{ResponseType: &pb.ListenResponse_TargetChange{&pb.TargetChange{
+			TargetChangeType: pb.TargetChange_NO_CHANGE,
+			ReadTime:         aTimestamp,
+			ResumeToken:      []byte(token),
+		}}}
+	}
+
+	srv.addRPC(request(nil), []interface{}{current, noChange("t1")})
+	it := dr.Snapshots(ctx)
+	if got := it.ResumeToken(); got != nil {
+		t.Errorf("before Next: got %q, want nil", got)
+	}
+	if _, err := it.Next(); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := string(it.ResumeToken()), "t1"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+	it.Stop()
+
+	// A new listener resumes from the persisted token.
+	srv.addRPC(request([]byte("t1")), []interface{}{current, noChange("t2")})
+	it = dr.Snapshots(ctx, ResumeFromToken([]byte("t1")))
+	defer it.Stop()
+	if _, err := it.Next(); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := string(it.ResumeToken()), "t2"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
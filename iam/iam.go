@@ -24,13 +24,16 @@ package iam
 import (
 	"context"
 	"fmt"
+	"sort"
 	"time"
 
 	pb "cloud.google.com/go/iam/apiv1/iampb"
 	gax "github.com/googleapis/gax-go/v2"
+	"google.golang.org/genproto/googleapis/type/expr"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/proto"
 )
 
 // client abstracts the IAMPolicy API to allow multiple implementations.
@@ -385,3 +388,198 @@ func (h *Handle3) SetPolicy(ctx context.Context, policy *Policy3) error {
 func (h *Handle3) TestPermissions(ctx context.Context, permissions []string) ([]string, error) {
 	return h.c.Test(ctx, h.resource, permissions)
 }
+
+// Members returns the members granted role r under condition cond. A nil
+// cond selects the binding without a condition. The return value should not
+// be modified. Use Grant and Revoke to modify the members of a role.
+func (p *Policy3) Members(r RoleName, cond *expr.Expr) []string {
+	b := p.binding(r, cond)
+	if b == nil {
+		return nil
+	}
+	return b.Members
+}
+
+// HasRole reports whether member is granted role r under condition cond. A
+// nil cond selects the binding without a condition.
+func (p *Policy3) HasRole(member string, r RoleName, cond *expr.Expr) bool {
+	return memberIndex(member, p.binding(r, cond)) >= 0
+}
+
+// Grant grants role r to members under condition cond, which may be nil for
+// an unconditional grant. Members that already have the role under the same
+// condition are ignored. A new binding is created if there is no binding for
+// the role and condition.
+//
+// Grant returns p, so that calls can be chained:
+//
+//	p.Grant(iam.Viewer, nil, "user:alice@example.com").
+//		Grant(iam.Editor, cond, "group:admins@example.com")
+func (p *Policy3) Grant(r RoleName, cond *expr.Expr, members ...string) *Policy3 {
+	if len(members) == 0 {
+		return p
+	}
+	b := p.binding(r, cond)
+	if b == nil {
+		b = &pb.Binding{Role: string(r), Condition: cond}
+		p.Bindings = append(p.Bindings, b)
+	}
+	for _, m := range members {
+		if memberIndex(m, b) < 0 {
+			b.Members = append(b.Members, m)
+		}
+	}
+	return p
+}
+
+// Revoke revokes role r under condition cond, which may be nil for an
+// unconditional grant, from members. Members that do not have the role under
+// the condition are ignored. A binding left without members is removed.
+//
+// Revoke returns p, so that calls can be chained.
+func (p *Policy3) Revoke(r RoleName, cond *expr.Expr, members ...string) *Policy3 {
+	bi := p.bindingIndex(r, cond)
+	if bi < 0 {
+		return p
+	}
+	b := p.Bindings[bi]
+	for _, m := range members {
+		if mi := memberIndex(m, b); mi >= 0 {
+			b.Members = append(b.Members[:mi], b.Members[mi+1:]...)
+		}
+	}
+	if len(b.Members) == 0 {
+		p.Bindings = append(p.Bindings[:bi], p.Bindings[bi+1:]...)
+	}
+	return p
+}
+
+// Diff returns the changes that turn p into q: a BindingDelta that adds each
+// member granted a role under a condition in q but not in p, and one that
+// removes each member granted a role under a condition in p but not in q.
+// The deltas are sorted by role, condition expression, member and action.
+func (p *Policy3) Diff(q *Policy3) []*pb.BindingDelta {
+	var deltas []*pb.BindingDelta
+	diff := func(from, to *Policy3, action pb.BindingDelta_Action) {
+		for _, b := range from.Bindings {
+			for _, m := range b.Members {
+				if !to.HasRole(m, RoleName(b.Role), b.Condition) {
+					deltas = append(deltas, &pb.BindingDelta{
+						Action:    action,
+						Role:      b.Role,
+						Member:    m,
+						Condition: b.Condition,
+					})
+				}
+			}
+		}
+	}
+	diff(p, q, pb.BindingDelta_REMOVE)
+	diff(q, p, pb.BindingDelta_ADD)
+	sort.Slice(deltas, func(i, j int) bool {
+		di, dj := deltas[i], deltas[j]
+		if di.Role != dj.Role {
+			return di.Role < dj.Role
+		}
+		if ei, ej := di.Condition.GetExpression(), dj.Condition.GetExpression(); ei != ej {
+			return ei < ej
+		}
+		if di.Member != dj.Member {
+			return di.Member < dj.Member
+		}
+		return di.Action < dj.Action
+	})
+	return deltas
+}
+
+// copy returns a deep copy of p.
+func (p *Policy3) copy() *Policy3 {
+	q := &Policy3{etag: p.etag}
+	for _, b := range p.Bindings {
+		q.Bindings = append(q.Bindings, proto.Clone(b).(*pb.Binding))
+	}
+	return q
+}
+
+// binding returns the Binding for the supplied role and condition, or nil if
+// there isn't one.
+func (p *Policy3) binding(r RoleName, cond *expr.Expr) *pb.Binding {
+	i := p.bindingIndex(r, cond)
+	if i < 0 {
+		return nil
+	}
+	return p.Bindings[i]
+}
+
+func (p *Policy3) bindingIndex(r RoleName, cond *expr.Expr) int {
+	if p == nil {
+		return -1
+	}
+	for i, b := range p.Bindings {
+		if b.Role == string(r) && proto.Equal(b.Condition, cond) {
+			return i
+		}
+	}
+	return -1
+}
+
+// withConflictRetry retries the read-modify-write cycles of Update when the
+// policy changed between the read and the write, and the write was rejected
+// because of its etag.
+var withConflictRetry = gax.WithRetry(func() gax.Retryer {
+	return gax.OnCodes([]codes.Code{
+		codes.Aborted,
+	}, gax.Backoff{
+		Initial:    100 * time.Millisecond,
+		Max:        10 * time.Second,
+		Multiplier: 1.3,
+	})
+})
+
+// Update modifies the IAM policy of the resource with f. It retrieves the
+// policy, calls f with it and stores the result. If the policy changed since
+// it was retrieved, so that it cannot be stored, Update starts over, calling f
+// with the new policy, until it succeeds or ctx is done.
+//
+// If f returns an error, Update returns it without storing the policy. If f
+// makes no change to the policy, Update does not store it.
+func (h *Handle) Update(ctx context.Context, f func(*Policy) error) error {
+	return gax.Invoke(ctx, func(ctx context.Context, _ gax.CallSettings) error {
+		policy, err := h.Policy(ctx)
+		if err != nil {
+			return err
+		}
+		orig := proto.Clone(policy.InternalProto)
+		if err := f(policy); err != nil {
+			return err
+		}
+		if proto.Equal(orig, policy.InternalProto) {
+			return nil
+		}
+		return h.SetPolicy(ctx, policy)
+	}, withConflictRetry)
+}
+
+// Update modifies the IAM policy of the resource with f. It retrieves the
+// policy, calls f with it and stores the result. If the policy changed since
+// it was retrieved, so that it cannot be stored, Update starts over, calling f
+// with the new policy, until it succeeds or ctx is done.
+//
+// If f returns an error, Update returns it without storing the policy. If f
+// makes no change to the policy, Update does not store it.
+func (h *Handle3) Update(ctx context.Context, f func(*Policy3) error) error {
+	return gax.Invoke(ctx, func(ctx context.Context, _ gax.CallSettings) error {
+		policy, err := h.Policy(ctx)
+		if err != nil {
+			return err
+		}
+		orig := policy.copy()
+		if err := f(policy); err != nil {
+			return err
+		}
+		if len(orig.Diff(policy)) == 0 {
+			return nil
+		}
+		return h.SetPolicy(ctx, policy)
+	}, withConflictRetry)
+}
@@ -15,11 +15,18 @@
 package iam
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"sort"
 	"testing"
 
+	pb "cloud.google.com/go/iam/apiv1/iampb"
 	"cloud.google.com/go/internal/testutil"
+	"google.golang.org/genproto/googleapis/type/expr"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
 )
 
 func TestPolicy(t *testing.T) {
@@ -85,3 +92,161 @@ func checkMembers(p *Policy, role RoleName, wantMembers []string) (string, bool)
 	}
 	return "", true
 }
+
+func TestPolicy3(t *testing.T) {
+	cond := &expr.Expr{Title: "weekdays", Expression: "request.time.getDayOfWeek() < 5"}
+	p := &Policy3{}
+	p.Grant(Viewer, nil, "m1", "m2").
+		Grant(Viewer, cond, "m3").
+		Grant(Viewer, nil, "m1"). // duplicate grants ignored
+		Grant(Owner, nil)         // grants without members ignored
+	if got, want := len(p.Bindings), 2; got != want {
+		t.Fatalf("got %d bindings, want %d", got, want)
+	}
+	if got, want := p.Members(Viewer, nil), []string{"m1", "m2"}; !testutil.Equal(got, want) {
+		t.Errorf("unconditional viewers: got %v, want %v", got, want)
+	}
+	// Conditions are compared by value.
+	if got, want := p.Members(Viewer, proto.Clone(cond).(*expr.Expr)), []string{"m3"}; !testutil.Equal(got, want) {
+		t.Errorf("conditional viewers: got %v, want %v", got, want)
+	}
+	if p.HasRole("m3", Viewer, nil) {
+		t.Error("m3 has the unconditional role, want only the conditional one")
+	}
+
+	p.Revoke(Viewer, nil, "m1", "m4").Revoke(Viewer, cond, "m3").Revoke(Owner, nil, "m1")
+	if got, want := p.Members(Viewer, nil), []string{"m2"}; !testutil.Equal(got, want) {
+		t.Errorf("after Revoke: got %v, want %v", got, want)
+	}
+	if got, want := len(p.Bindings), 1; got != want {
+		t.Errorf("after Revoke: got %d bindings, want %d", got, want)
+	}
+}
+
+func TestPolicy3Diff(t *testing.T) {
+	cond := &expr.Expr{Expression: "true"}
+	p := (&Policy3{}).Grant(Viewer, nil, "m1", "m2").Grant(Editor, cond, "m3")
+	q := p.copy().Revoke(Viewer, nil, "m1").Grant(Viewer, cond, "m1").Grant(Owner, nil, "m4")
+	want := []*pb.BindingDelta{
+		{Action: pb.BindingDelta_ADD, Role: string(Owner), Member: "m4"},
+		{Action: pb.BindingDelta_REMOVE, Role: string(Viewer), Member: "m1"},
+		{Action: pb.BindingDelta_ADD, Role: string(Viewer), Member: "m1", Condition: cond},
+	}
+	if got := p.Diff(q); !testutil.Equal(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+	if got := p.Diff(p.copy()); len(got) != 0 {
+		t.Errorf("same policy: got %v, want no deltas", got)
+	}
+	if got := q.Diff(p); len(got) != len(want) {
+		t.Errorf("reverse: got %d deltas, want %d", len(got), len(want))
+	}
+}
+
+// fakeClient stores a policy, and rejects writes with stale etags like the
+// IAMPolicy service does.
+type fakeClient struct {
+	policy *pb.Policy
+	etag   int
+	sets   int
+	// beforeSet, if not nil, is called before each Set.
+	beforeSet func()
+}
+
+func (c *fakeClient) Get(ctx context.Context, resource string) (*pb.Policy, error) {
+	return c.GetWithVersion(ctx, resource, 1)
+}
+
+func (c *fakeClient) GetWithVersion(_ context.Context, _ string, _ int32) (*pb.Policy, error) {
+	p := proto.Clone(c.policy).(*pb.Policy)
+	p.Etag = []byte(fmt.Sprint(c.etag))
+	return p, nil
+}
+
+func (c *fakeClient) Set(_ context.Context, _ string, p *pb.Policy) error {
+	if c.beforeSet != nil {
+		c.beforeSet()
+	}
+	if string(p.Etag) != fmt.Sprint(c.etag) {
+		return status.Error(codes.Aborted, "etag mismatch")
+	}
+	c.sets++
+	c.etag++
+	c.policy = proto.Clone(p).(*pb.Policy)
+	return nil
+}
+
+func (c *fakeClient) Test(context.Context, string, []string) ([]string, error) {
+	return nil, nil
+}
+
+func TestUpdate(t *testing.T) {
+	ctx := context.Background()
+	c := &fakeClient{policy: &pb.Policy{}}
+	h := InternalNewHandleClient(c, "resource")
+
+	// A concurrent change makes the first write fail, so Update starts over
+	// from the changed policy.
+	conflicts := 1
+	c.beforeSet = func() {
+		if conflicts > 0 {
+			conflicts--
+			c.policy.Bindings = append(c.policy.Bindings, &pb.Binding{Role: string(Owner), Members: []string{"m0"}})
+			c.etag++
+		}
+	}
+	calls := 0
+	if err := h.V3().Update(ctx, func(p *Policy3) error {
+		calls++
+		p.Grant(Viewer, nil, "m1")
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if calls != 2 {
+		t.Errorf("got %d calls, want 2", calls)
+	}
+	p, err := h.V3().Policy(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !p.HasRole("m0", Owner, nil) || !p.HasRole("m1", Viewer, nil) {
+		t.Errorf("got %v, want both the concurrent change and the update", p.Bindings)
+	}
+	if c.policy.Version != 3 {
+		t.Errorf("got version %d, want 3", c.policy.Version)
+	}
+
+	// Updates that change nothing are not written.
+	sets := c.sets
+	if err := h.V3().Update(ctx, func(p *Policy3) error {
+		p.Grant(Viewer, nil, "m1")
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if err := h.Update(ctx, func(p *Policy) error {
+		p.Add("m1", Viewer)
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if c.sets != sets {
+		t.Errorf("got %d writes, want none", c.sets-sets)
+	}
+
+	if err := h.Update(ctx, func(p *Policy) error {
+		p.Remove("m1", Viewer)
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if c.sets != sets+1 || memberIndex("m1", (&Policy{InternalProto: c.policy}).binding(Viewer)) >= 0 {
+		t.Errorf("Handle.Update: got policy %v, want m1 removed", c.policy)
+	}
+
+	errStop := errors.New("stop")
+	if err := h.Update(ctx, func(*Policy) error { return errStop }); err != errStop {
+		t.Errorf("got %v, want %v", err, errStop)
+	}
+}
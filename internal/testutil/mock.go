@@ -0,0 +1,189 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package testutil
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/prototext"
+	"google.golang.org/protobuf/proto"
+)
+
+// A MockServer holds the RPCs that a test expects a gRPC service to receive,
+// in order, together with their responses. It is meant to be embedded in an
+// implementation of a generated service interface, whose methods pass their
+// requests to PopRPC and return its results:
+//
+//	type mockServer struct {
+//		mypb.UnimplementedMyServiceServer
+//		testutil.MockServer
+//	}
+//
+//	func (s *mockServer) Get(ctx context.Context, req *mypb.GetRequest) (*mypb.Thing, error) {
+//		return testutil.PopRPC[*mypb.Thing](&s.MockServer, req)
+//	}
+//
+// A test then adds the RPCs it expects, runs the code under test against a
+// client connected to the server, and checks that all the RPCs were made:
+//
+//	mock := &mockServer{}
+//	conn, cleanup, err := testutil.StartMockServer(func(s *grpc.Server) {
+//		mypb.RegisterMyServiceServer(s, mock)
+//	})
+//	...
+//	mock.AddRPC(&mypb.GetRequest{Name: "a"}, &mypb.Thing{Name: "a"})
+//	mock.AddRPC(&mypb.GetRequest{Name: "b"}, status.Error(codes.NotFound, "no b"))
+//	... // Call the service using conn.
+//	if err := mock.Done(); err != nil {
+//		t.Error(err)
+//	}
+//
+// The zero value is ready to use. A MockServer is safe for concurrent use.
+type MockServer struct {
+	mu   sync.Mutex
+	rpcs []mockRPC
+	errs []string
+}
+
+type mockRPC struct {
+	wantReq proto.Message
+	adjust  func(gotReq proto.Message)
+	resp    interface{}
+}
+
+// AddRPC adds a (request, response) pair to the server's list of expected
+// interactions. The server compares the next incoming request with wantReq
+// using proto.Equal. The response can be a message, or an error to inject,
+// which is returned to the client as is; use status.Error to control its
+// code.
+//
+// Passing nil for wantReq disables the request check.
+func (s *MockServer) AddRPC(wantReq proto.Message, resp interface{}) {
+	s.AddRPCAdjust(wantReq, resp, nil)
+}
+
+// AddRPCAdjust is like AddRPC, but accepts a function that can be used to
+// tweak the incoming request before comparison, for example to adjust for
+// randomness.
+func (s *MockServer) AddRPCAdjust(wantReq proto.Message, resp interface{}, adjust func(gotReq proto.Message)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.rpcs = append(s.rpcs, mockRPC{wantReq: wantReq, adjust: adjust, resp: resp})
+}
+
+// PopRPC compares gotReq with the next expected request, and returns its
+// response. It returns an error with code Internal if the request does not
+// match or there are no expected RPCs; such errors are also reported by
+// Done, in case the code under test retries or ignores them.
+func (s *MockServer) PopRPC(gotReq proto.Message) (interface{}, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(s.rpcs) == 0 {
+		return nil, s.fail("out of RPCs, saw %T", gotReq)
+	}
+	rpc := s.rpcs[0]
+	s.rpcs = s.rpcs[1:]
+	if rpc.wantReq != nil {
+		if rpc.adjust != nil {
+			rpc.adjust(gotReq)
+		}
+		if !proto.Equal(gotReq, rpc.wantReq) {
+			return nil, s.fail("bad request\ngot:\n%T\n%s\nwant:\n%T\n%s",
+				gotReq, prototext.Format(gotReq), rpc.wantReq, prototext.Format(rpc.wantReq))
+		}
+	}
+	if err, ok := rpc.resp.(error); ok {
+		return nil, err
+	}
+	return rpc.resp, nil
+}
+
+func (s *MockServer) fail(format string, args ...interface{}) error {
+	msg := fmt.Sprintf(format, args...)
+	s.errs = append(s.errs, msg)
+	return status.Error(codes.Internal, "MockServer: "+msg)
+}
+
+// PopRPC is like the PopRPC method of s, but converts the response to the
+// type returned by the RPC. It returns an error with code Internal if the
+// response has a different type.
+func PopRPC[R proto.Message](s *MockServer, gotReq proto.Message) (R, error) {
+	var zero R
+	resp, err := s.PopRPC(gotReq)
+	if err != nil {
+		return zero, err
+	}
+	r, ok := resp.(R)
+	if !ok {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		return zero, s.fail("response for %T has type %T, want %T", gotReq, resp, zero)
+	}
+	return r, nil
+}
+
+// Done returns an error describing the requests that did not match what was
+// expected, and the expected RPCs that were not made, if any.
+func (s *MockServer) Done() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	msgs := s.errs
+	for _, rpc := range s.rpcs {
+		if rpc.wantReq == nil {
+			msgs = append(msgs, "RPC not made: any request")
+		} else {
+			msgs = append(msgs, fmt.Sprintf("RPC not made: %T\n%s", rpc.wantReq, prototext.Format(rpc.wantReq)))
+		}
+	}
+	if len(msgs) == 0 {
+		return nil
+	}
+	return fmt.Errorf("MockServer: %s", strings.Join(msgs, "\n"))
+}
+
+// Reset clears the expected RPCs and the recorded failures.
+func (s *MockServer) Reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.rpcs = nil
+	s.errs = nil
+}
+
+// StartMockServer starts a Server with the services registered by register,
+// and returns a client connection to it, and a function that closes both.
+func StartMockServer(register func(*grpc.Server), opts ...grpc.ServerOption) (_ *grpc.ClientConn, cleanup func(), _ error) {
+	srv, err := NewServer(opts...)
+	if err != nil {
+		return nil, nil, err
+	}
+	register(srv.Gsrv)
+	srv.Start()
+	conn, err := grpc.DialContext(context.Background(), srv.Addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		srv.Close()
+		return nil, nil, err
+	}
+	return conn, func() {
+		conn.Close()
+		srv.Close()
+	}, nil
+}
@@ -0,0 +1,113 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package testutil
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+type mockHealthServer struct {
+	healthpb.UnimplementedHealthServer
+	MockServer
+}
+
+func (s *mockHealthServer) Check(_ context.Context, req *healthpb.HealthCheckRequest) (*healthpb.HealthCheckResponse, error) {
+	return PopRPC[*healthpb.HealthCheckResponse](&s.MockServer, req)
+}
+
+func newMockHealth(t *testing.T) (*mockHealthServer, healthpb.HealthClient) {
+	mock := &mockHealthServer{}
+	conn, cleanup, err := StartMockServer(func(s *grpc.Server) {
+		healthpb.RegisterHealthServer(s, mock)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(cleanup)
+	return mock, healthpb.NewHealthClient(conn)
+}
+
+func TestMockServer(t *testing.T) {
+	mock, client := newMockHealth(t)
+	ctx := context.Background()
+	serving := &healthpb.HealthCheckResponse{Status: healthpb.HealthCheckResponse_SERVING}
+	mock.AddRPC(&healthpb.HealthCheckRequest{Service: "a"}, serving)
+	mock.AddRPC(&healthpb.HealthCheckRequest{Service: "b"}, status.Error(codes.Unavailable, "injected"))
+	mock.AddRPCAdjust(&healthpb.HealthCheckRequest{Service: "c"}, serving, func(req proto.Message) {
+		req.(*healthpb.HealthCheckRequest).Service = "c"
+	})
+	mock.AddRPC(nil, serving)
+
+	got, err := client.Check(ctx, &healthpb.HealthCheckRequest{Service: "a"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !proto.Equal(got, serving) {
+		t.Errorf("got %v, want %v", got, serving)
+	}
+	if _, err := client.Check(ctx, &healthpb.HealthCheckRequest{Service: "b"}); status.Code(err) != codes.Unavailable {
+		t.Errorf("got %v, want code Unavailable", err)
+	}
+	if _, err := client.Check(ctx, &healthpb.HealthCheckRequest{Service: "random"}); err != nil {
+		t.Errorf("adjusted request: %v", err)
+	}
+	if _, err := client.Check(ctx, &healthpb.HealthCheckRequest{Service: "anything"}); err != nil {
+		t.Errorf("unchecked request: %v", err)
+	}
+	if err := mock.Done(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestMockServerFailures(t *testing.T) {
+	mock, client := newMockHealth(t)
+	ctx := context.Background()
+	serving := &healthpb.HealthCheckResponse{Status: healthpb.HealthCheckResponse_SERVING}
+
+	mock.AddRPC(&healthpb.HealthCheckRequest{Service: "a"}, serving)
+	mock.AddRPC(nil, &healthpb.HealthCheckRequest{})
+	mock.AddRPC(&healthpb.HealthCheckRequest{Service: "c"}, serving)
+	if _, err := client.Check(ctx, &healthpb.HealthCheckRequest{Service: "b"}); status.Code(err) != codes.Internal {
+		t.Errorf("bad request: got %v, want code Internal", err)
+	}
+	if _, err := client.Check(ctx, &healthpb.HealthCheckRequest{}); status.Code(err) != codes.Internal {
+		t.Errorf("bad response type: got %v, want code Internal", err)
+	}
+	err := mock.Done()
+	if err == nil {
+		t.Fatal("got nil, want error")
+	}
+	for _, want := range []string{"bad request", "has type", "RPC not made"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("got %q, want it to contain %q", err, want)
+		}
+	}
+
+	mock.Reset()
+	if _, err := client.Check(ctx, &healthpb.HealthCheckRequest{}); status.Code(err) != codes.Internal {
+		t.Errorf("out of RPCs: got %v, want code Internal", err)
+	}
+	if err := mock.Done(); err == nil || !strings.Contains(err.Error(), "out of RPCs") {
+		t.Errorf("got %v, want out of RPCs error", err)
+	}
+}
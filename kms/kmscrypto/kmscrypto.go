@@ -0,0 +1,309 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package kmscrypto adapts asymmetric Cloud KMS keys to the standard
+// crypto.Signer and crypto.Decrypter interfaces, so that packages such as
+// crypto/tls and crypto/x509, or JWT libraries, can use keys whose private
+// half never leaves Cloud KMS.
+//
+// A Signer or Decrypter uses a single version of a key, named by its
+// resource name:
+//
+//	client, err := kms.NewKeyManagementClient(ctx)
+//	if err != nil {
+//		// TODO: Handle error.
+//	}
+//	defer client.Close()
+//	signer, err := kmscrypto.NewSigner(ctx, client,
+//		"projects/my-project/locations/global/keyRings/my-ring/cryptoKeys/my-key/cryptoKeyVersions/1")
+//	if err != nil {
+//		// TODO: Handle error.
+//	}
+//	cert := tls.Certificate{Certificate: [][]byte{der}, PrivateKey: signer}
+//
+// Requests and responses are checked with the CRC32C checksums that Cloud KMS
+// supports, so that data corrupted in transit is reported as an error.
+package kmscrypto // import "cloud.google.com/go/kms/kmscrypto"
+
+import (
+	"context"
+	"crypto"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+
+	kms "cloud.google.com/go/kms/apiv1"
+	"cloud.google.com/go/kms/apiv1/kmspb"
+	"github.com/googleapis/gax-go/v2"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+// keyClient is the part of the Cloud KMS client used by Signer and Decrypter.
+type keyClient interface {
+	GetPublicKey(context.Context, *kmspb.GetPublicKeyRequest, ...gax.CallOption) (*kmspb.PublicKey, error)
+	AsymmetricSign(context.Context, *kmspb.AsymmetricSignRequest, ...gax.CallOption) (*kmspb.AsymmetricSignResponse, error)
+	AsymmetricDecrypt(context.Context, *kmspb.AsymmetricDecryptRequest, ...gax.CallOption) (*kmspb.AsymmetricDecryptResponse, error)
+}
+
+// signingAlgorithm describes how a key version signs.
+type signingAlgorithm struct {
+	hash crypto.Hash // Zero for raw PKCS #1 signatures.
+	pss  bool
+}
+
+var signingAlgorithms = map[kmspb.CryptoKeyVersion_CryptoKeyVersionAlgorithm]signingAlgorithm{
+	kmspb.CryptoKeyVersion_RSA_SIGN_PSS_2048_SHA256:   {hash: crypto.SHA256, pss: true},
+	kmspb.CryptoKeyVersion_RSA_SIGN_PSS_3072_SHA256:   {hash: crypto.SHA256, pss: true},
+	kmspb.CryptoKeyVersion_RSA_SIGN_PSS_4096_SHA256:   {hash: crypto.SHA256, pss: true},
+	kmspb.CryptoKeyVersion_RSA_SIGN_PSS_4096_SHA512:   {hash: crypto.SHA512, pss: true},
+	kmspb.CryptoKeyVersion_RSA_SIGN_PKCS1_2048_SHA256: {hash: crypto.SHA256},
+	kmspb.CryptoKeyVersion_RSA_SIGN_PKCS1_3072_SHA256: {hash: crypto.SHA256},
+	kmspb.CryptoKeyVersion_RSA_SIGN_PKCS1_4096_SHA256: {hash: crypto.SHA256},
+	kmspb.CryptoKeyVersion_RSA_SIGN_PKCS1_4096_SHA512: {hash: crypto.SHA512},
+	kmspb.CryptoKeyVersion_RSA_SIGN_RAW_PKCS1_2048:    {},
+	kmspb.CryptoKeyVersion_RSA_SIGN_RAW_PKCS1_3072:    {},
+	kmspb.CryptoKeyVersion_RSA_SIGN_RAW_PKCS1_4096:    {},
+	kmspb.CryptoKeyVersion_EC_SIGN_P256_SHA256:        {hash: crypto.SHA256},
+	kmspb.CryptoKeyVersion_EC_SIGN_P384_SHA384:        {hash: crypto.SHA384},
+}
+
+var decryptionHashes = map[kmspb.CryptoKeyVersion_CryptoKeyVersionAlgorithm]crypto.Hash{
+	kmspb.CryptoKeyVersion_RSA_DECRYPT_OAEP_2048_SHA256: crypto.SHA256,
+	kmspb.CryptoKeyVersion_RSA_DECRYPT_OAEP_3072_SHA256: crypto.SHA256,
+	kmspb.CryptoKeyVersion_RSA_DECRYPT_OAEP_4096_SHA256: crypto.SHA256,
+	kmspb.CryptoKeyVersion_RSA_DECRYPT_OAEP_4096_SHA512: crypto.SHA512,
+	kmspb.CryptoKeyVersion_RSA_DECRYPT_OAEP_2048_SHA1:   crypto.SHA1,
+	kmspb.CryptoKeyVersion_RSA_DECRYPT_OAEP_3072_SHA1:   crypto.SHA1,
+	kmspb.CryptoKeyVersion_RSA_DECRYPT_OAEP_4096_SHA1:   crypto.SHA1,
+}
+
+// A Signer implements crypto.Signer with an asymmetric signing key version
+// in Cloud KMS. It supports the RSA and the P-256 and P-384 elliptic curve
+// algorithms.
+//
+// A Signer is safe to use concurrently.
+type Signer struct {
+	ctx    context.Context
+	client keyClient
+	name   string
+	alg    signingAlgorithm
+	public crypto.PublicKey
+}
+
+// NewSigner returns a Signer for the key version with the given resource
+// name, in the format
+// projects/*/locations/*/keyRings/*/cryptoKeys/*/cryptoKeyVersions/*. It
+// fetches the public key of the key version. ctx is used for this call and for
+// all the calls of Sign, so it should outlive the Signer.
+func NewSigner(ctx context.Context, client *kms.KeyManagementClient, name string) (*Signer, error) {
+	return newSigner(ctx, client, name)
+}
+
+func newSigner(ctx context.Context, client keyClient, name string) (*Signer, error) {
+	pk, public, err := getPublicKey(ctx, client, name)
+	if err != nil {
+		return nil, err
+	}
+	alg, ok := signingAlgorithms[pk.Algorithm]
+	if !ok {
+		return nil, fmt.Errorf("kmscrypto: %s has algorithm %v, which is not a supported signing algorithm", name, pk.Algorithm)
+	}
+	return &Signer{ctx: ctx, client: client, name: name, alg: alg, public: public}, nil
+}
+
+// Public returns the public key of the key version: an *rsa.PublicKey or an
+// *ecdsa.PublicKey.
+func (s *Signer) Public() crypto.PublicKey {
+	return s.public
+}
+
+// Sign signs digest with the key version. rand is ignored, since the
+// signature is computed by Cloud KMS.
+//
+// opts.HashFunc() must be the hash function of the key version's algorithm,
+// and digest the result of hashing the message with it. For the
+// RSA_SIGN_RAW_PKCS1 algorithms, opts.HashFunc() must be zero and digest is
+// signed as is. For the RSA_SIGN_PSS algorithms, opts must be an
+// *rsa.PSSOptions whose salt length is rsa.PSSSaltLengthEqualsHash, or the
+// length of the digest, which is the salt length that Cloud KMS uses. For the
+// other algorithms, opts must not be an *rsa.PSSOptions.
+func (s *Signer) Sign(_ io.Reader, digest []byte, opts crypto.SignerOpts) ([]byte, error) {
+	if h := opts.HashFunc(); h != s.alg.hash {
+		return nil, fmt.Errorf("kmscrypto: %s signs with hash %v, not %v", s.name, s.alg.hash, h)
+	}
+	pssOpts, isPSS := opts.(*rsa.PSSOptions)
+	if isPSS != s.alg.pss {
+		if s.alg.pss {
+			return nil, fmt.Errorf("kmscrypto: %s signs with RSASSA-PSS, and requires *rsa.PSSOptions", s.name)
+		}
+		return nil, fmt.Errorf("kmscrypto: %s does not sign with RSASSA-PSS", s.name)
+	}
+	if isPSS && pssOpts.SaltLength != rsa.PSSSaltLengthEqualsHash && pssOpts.SaltLength != s.alg.hash.Size() {
+		return nil, fmt.Errorf("kmscrypto: %s signs with a salt length of %d, not %d", s.name, s.alg.hash.Size(), pssOpts.SaltLength)
+	}
+
+	req := &kmspb.AsymmetricSignRequest{Name: s.name}
+	if s.alg.hash == 0 {
+		req.Data = digest
+		req.DataCrc32C = checksum(digest)
+	} else {
+		if len(digest) != s.alg.hash.Size() {
+			return nil, fmt.Errorf("kmscrypto: got a digest of %d bytes, want %d for %v", len(digest), s.alg.hash.Size(), s.alg.hash)
+		}
+		switch s.alg.hash {
+		case crypto.SHA256:
+			req.Digest = &kmspb.Digest{Digest: &kmspb.Digest_Sha256{Sha256: digest}}
+		case crypto.SHA384:
+			req.Digest = &kmspb.Digest{Digest: &kmspb.Digest_Sha384{Sha384: digest}}
+		case crypto.SHA512:
+			req.Digest = &kmspb.Digest{Digest: &kmspb.Digest_Sha512{Sha512: digest}}
+		}
+		req.DigestCrc32C = checksum(digest)
+	}
+	resp, err := s.client.AsymmetricSign(s.ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.Name != s.name {
+		return nil, fmt.Errorf("kmscrypto: signed with %s, want %s", resp.Name, s.name)
+	}
+	if s.alg.hash == 0 && !resp.VerifiedDataCrc32C || s.alg.hash != 0 && !resp.VerifiedDigestCrc32C {
+		return nil, errCorruptedRequest
+	}
+	if !checksumMatches(resp.Signature, resp.SignatureCrc32C) {
+		return nil, errCorruptedResponse
+	}
+	return resp.Signature, nil
+}
+
+// A Decrypter implements crypto.Decrypter with an asymmetric decryption key
+// version in Cloud KMS, which uses RSAES-OAEP.
+//
+// A Decrypter is safe to use concurrently.
+type Decrypter struct {
+	ctx    context.Context
+	client keyClient
+	name   string
+	hash   crypto.Hash
+	public crypto.PublicKey
+}
+
+// NewDecrypter returns a Decrypter for the key version with the given resource
+// name, in the format
+// projects/*/locations/*/keyRings/*/cryptoKeys/*/cryptoKeyVersions/*. It
+// fetches the public key of the key version. ctx is used for this call and for
+// all the calls of Decrypt, so it should outlive the Decrypter.
+func NewDecrypter(ctx context.Context, client *kms.KeyManagementClient, name string) (*Decrypter, error) {
+	return newDecrypter(ctx, client, name)
+}
+
+func newDecrypter(ctx context.Context, client keyClient, name string) (*Decrypter, error) {
+	pk, public, err := getPublicKey(ctx, client, name)
+	if err != nil {
+		return nil, err
+	}
+	hash, ok := decryptionHashes[pk.Algorithm]
+	if !ok {
+		return nil, fmt.Errorf("kmscrypto: %s has algorithm %v, which is not a supported decryption algorithm", name, pk.Algorithm)
+	}
+	return &Decrypter{ctx: ctx, client: client, name: name, hash: hash, public: public}, nil
+}
+
+// Public returns the public key of the key version, an *rsa.PublicKey.
+// Encrypt with it using rsa.EncryptOAEP and the hash function of the key
+// version's algorithm.
+func (d *Decrypter) Public() crypto.PublicKey {
+	return d.public
+}
+
+// Decrypt decrypts ciphertext with the key version. rand is ignored, since
+// the plaintext is computed by Cloud KMS.
+//
+// opts may be nil or an *rsa.OAEPOptions. If it is an *rsa.OAEPOptions, its
+// hash function must be the one of the key version's algorithm, and its label
+// must be empty, since Cloud KMS does not support labels.
+func (d *Decrypter) Decrypt(_ io.Reader, ciphertext []byte, opts crypto.DecrypterOpts) ([]byte, error) {
+	switch opts := opts.(type) {
+	case nil:
+	case *rsa.OAEPOptions:
+		if opts.Hash != d.hash {
+			return nil, fmt.Errorf("kmscrypto: %s decrypts with hash %v, not %v", d.name, d.hash, opts.Hash)
+		}
+		if len(opts.Label) > 0 {
+			return nil, errors.New("kmscrypto: OAEP labels are not supported")
+		}
+	default:
+		return nil, fmt.Errorf("kmscrypto: %s decrypts with RSAES-OAEP, and does not support options of type %T", d.name, opts)
+	}
+
+	resp, err := d.client.AsymmetricDecrypt(d.ctx, &kmspb.AsymmetricDecryptRequest{
+		Name:             d.name,
+		Ciphertext:       ciphertext,
+		CiphertextCrc32C: checksum(ciphertext),
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !resp.VerifiedCiphertextCrc32C {
+		return nil, errCorruptedRequest
+	}
+	if !checksumMatches(resp.Plaintext, resp.PlaintextCrc32C) {
+		return nil, errCorruptedResponse
+	}
+	return resp.Plaintext, nil
+}
+
+var (
+	errCorruptedRequest  = errors.New("kmscrypto: the request was corrupted in transit")
+	errCorruptedResponse = errors.New("kmscrypto: the response was corrupted in transit")
+)
+
+// getPublicKey fetches the public key of a key version, and parses it.
+func getPublicKey(ctx context.Context, client keyClient, name string) (*kmspb.PublicKey, crypto.PublicKey, error) {
+	pk, err := client.GetPublicKey(ctx, &kmspb.GetPublicKeyRequest{Name: name})
+	if err != nil {
+		return nil, nil, err
+	}
+	if pk.Name != "" && pk.Name != name {
+		return nil, nil, fmt.Errorf("kmscrypto: got the public key of %s, want %s", pk.Name, name)
+	}
+	if !checksumMatches([]byte(pk.Pem), pk.PemCrc32C) {
+		return nil, nil, errCorruptedResponse
+	}
+	block, _ := pem.Decode([]byte(pk.Pem))
+	if block == nil {
+		return nil, nil, fmt.Errorf("kmscrypto: the public key of %s is not PEM-encoded", name)
+	}
+	public, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, nil, fmt.Errorf("kmscrypto: parsing the public key of %s: %w", name, err)
+	}
+	return pk, public, nil
+}
+
+var crc32cTable = crc32.MakeTable(crc32.Castagnoli)
+
+// checksum returns the CRC32C checksum of b.
+func checksum(b []byte) *wrapperspb.Int64Value {
+	return wrapperspb.Int64(int64(crc32.Checksum(b, crc32cTable)))
+}
+
+// checksumMatches reports whether crc is the CRC32C checksum of b.
+func checksumMatches(b []byte, crc *wrapperspb.Int64Value) bool {
+	return crc != nil && crc.Value == checksum(b).Value
+}
@@ -0,0 +1,297 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kmscrypto
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/kms/apiv1/kmspb"
+	"github.com/googleapis/gax-go/v2"
+)
+
+const keyName = "projects/p/locations/global/keyRings/r/cryptoKeys/k/cryptoKeyVersions/1"
+
+var (
+	rsaKey *rsa.PrivateKey
+	ecKey  *ecdsa.PrivateKey
+)
+
+func init() {
+	var err error
+	if rsaKey, err = rsa.GenerateKey(rand.Reader, 2048); err != nil {
+		panic(err)
+	}
+	if ecKey, err = ecdsa.GenerateKey(elliptic.P256(), rand.Reader); err != nil {
+		panic(err)
+	}
+}
+
+// fakeKMS signs and decrypts like Cloud KMS, with a local private key.
+type fakeKMS struct {
+	alg     kmspb.CryptoKeyVersion_CryptoKeyVersionAlgorithm
+	key     crypto.Signer
+	corrupt bool // Whether to corrupt responses.
+}
+
+func (f *fakeKMS) GetPublicKey(_ context.Context, req *kmspb.GetPublicKeyRequest, _ ...gax.CallOption) (*kmspb.PublicKey, error) {
+	der, err := x509.MarshalPKIXPublicKey(f.key.Public())
+	if err != nil {
+		return nil, err
+	}
+	p := string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}))
+	return &kmspb.PublicKey{Name: req.Name, Algorithm: f.alg, Pem: p, PemCrc32C: checksum([]byte(p))}, nil
+}
+
+func (f *fakeKMS) AsymmetricSign(_ context.Context, req *kmspb.AsymmetricSignRequest, _ ...gax.CallOption) (*kmspb.AsymmetricSignResponse, error) {
+	alg := signingAlgorithms[f.alg]
+	var (
+		sig []byte
+		err error
+	)
+	switch {
+	case alg.hash == 0:
+		if !checksumMatches(req.Data, req.DataCrc32C) {
+			return nil, errors.New("bad data checksum")
+		}
+		sig, err = rsa.SignPKCS1v15(rand.Reader, f.key.(*rsa.PrivateKey), 0, req.Data)
+	default:
+		var digest []byte
+		switch alg.hash {
+		case crypto.SHA256:
+			digest = req.Digest.GetSha256()
+		case crypto.SHA512:
+			digest = req.Digest.GetSha512()
+		}
+		if !checksumMatches(digest, req.DigestCrc32C) {
+			return nil, errors.New("bad digest checksum")
+		}
+		var opts crypto.SignerOpts = alg.hash
+		if alg.pss {
+			opts = &rsa.PSSOptions{SaltLength: rsa.PSSSaltLengthEqualsHash, Hash: alg.hash}
+		}
+		sig, err = f.key.Sign(rand.Reader, digest, opts)
+	}
+	if err != nil {
+		return nil, err
+	}
+	crc := checksum(sig)
+	if f.corrupt {
+		crc.Value++
+	}
+	return &kmspb.AsymmetricSignResponse{
+		Name:                 req.Name,
+		Signature:            sig,
+		SignatureCrc32C:      crc,
+		VerifiedDigestCrc32C: req.DigestCrc32C != nil,
+		VerifiedDataCrc32C:   req.DataCrc32C != nil,
+	}, nil
+}
+
+func (f *fakeKMS) AsymmetricDecrypt(_ context.Context, req *kmspb.AsymmetricDecryptRequest, _ ...gax.CallOption) (*kmspb.AsymmetricDecryptResponse, error) {
+	if !checksumMatches(req.Ciphertext, req.CiphertextCrc32C) {
+		return nil, errors.New("bad ciphertext checksum")
+	}
+	pt, err := rsa.DecryptOAEP(decryptionHashes[f.alg].New(), nil, f.key.(*rsa.PrivateKey), req.Ciphertext, nil)
+	if err != nil {
+		return nil, err
+	}
+	crc := checksum(pt)
+	if f.corrupt {
+		crc.Value++
+	}
+	return &kmspb.AsymmetricDecryptResponse{Plaintext: pt, PlaintextCrc32C: crc, VerifiedCiphertextCrc32C: true}, nil
+}
+
+func TestSigner(t *testing.T) {
+	ctx := context.Background()
+	msg := []byte("hello")
+	sum256 := sha256.Sum256(msg)
+	sum512 := sha512.Sum512(msg)
+	for _, test := range []struct {
+		alg    kmspb.CryptoKeyVersion_CryptoKeyVersionAlgorithm
+		key    crypto.Signer
+		digest []byte
+		opts   crypto.SignerOpts
+		verify func(sig []byte) error
+	}{
+		{
+			alg:    kmspb.CryptoKeyVersion_RSA_SIGN_PKCS1_2048_SHA256,
+			key:    rsaKey,
+			digest: sum256[:],
+			opts:   crypto.SHA256,
+			verify: func(sig []byte) error { return rsa.VerifyPKCS1v15(&rsaKey.PublicKey, crypto.SHA256, sum256[:], sig) },
+		},
+		{
+			alg:    kmspb.CryptoKeyVersion_RSA_SIGN_PSS_4096_SHA512,
+			key:    rsaKey,
+			digest: sum512[:],
+			opts:   &rsa.PSSOptions{SaltLength: rsa.PSSSaltLengthEqualsHash, Hash: crypto.SHA512},
+			verify: func(sig []byte) error {
+				return rsa.VerifyPSS(&rsaKey.PublicKey, crypto.SHA512, sum512[:], sig, &rsa.PSSOptions{SaltLength: rsa.PSSSaltLengthEqualsHash})
+			},
+		},
+		{
+			alg:    kmspb.CryptoKeyVersion_RSA_SIGN_RAW_PKCS1_2048,
+			key:    rsaKey,
+			digest: msg,
+			opts:   crypto.Hash(0),
+			verify: func(sig []byte) error { return rsa.VerifyPKCS1v15(&rsaKey.PublicKey, 0, msg, sig) },
+		},
+		{
+			alg:    kmspb.CryptoKeyVersion_EC_SIGN_P256_SHA256,
+			key:    ecKey,
+			digest: sum256[:],
+			opts:   crypto.SHA256,
+			verify: func(sig []byte) error {
+				if !ecdsa.VerifyASN1(&ecKey.PublicKey, sum256[:], sig) {
+					return errors.New("invalid signature")
+				}
+				return nil
+			},
+		},
+	} {
+		s, err := newSigner(ctx, &fakeKMS{alg: test.alg, key: test.key}, keyName)
+		if err != nil {
+			t.Fatalf("%v: %v", test.alg, err)
+		}
+		sig, err := s.Sign(rand.Reader, test.digest, test.opts)
+		if err != nil {
+			t.Fatalf("%v: Sign: %v", test.alg, err)
+		}
+		if err := test.verify(sig); err != nil {
+			t.Errorf("%v: %v", test.alg, err)
+		}
+	}
+}
+
+func TestSignerErrors(t *testing.T) {
+	ctx := context.Background()
+	sum := sha256.Sum256([]byte("hello"))
+	for _, test := range []struct {
+		desc   string
+		alg    kmspb.CryptoKeyVersion_CryptoKeyVersionAlgorithm
+		digest []byte
+		opts   crypto.SignerOpts
+	}{
+		{"wrong hash", kmspb.CryptoKeyVersion_RSA_SIGN_PKCS1_4096_SHA512, sum[:], crypto.SHA256},
+		{"wrong digest size", kmspb.CryptoKeyVersion_RSA_SIGN_PKCS1_2048_SHA256, sum[:20], crypto.SHA256},
+		{"PSS options for PKCS #1", kmspb.CryptoKeyVersion_RSA_SIGN_PKCS1_2048_SHA256, sum[:], &rsa.PSSOptions{Hash: crypto.SHA256}},
+		{"no PSS options for PSS", kmspb.CryptoKeyVersion_RSA_SIGN_PSS_2048_SHA256, sum[:], crypto.SHA256},
+		{"wrong salt length", kmspb.CryptoKeyVersion_RSA_SIGN_PSS_2048_SHA256, sum[:], &rsa.PSSOptions{Hash: crypto.SHA256, SaltLength: 10}},
+	} {
+		s, err := newSigner(ctx, &fakeKMS{alg: test.alg, key: rsaKey}, keyName)
+		if err != nil {
+			t.Fatalf("%s: %v", test.desc, err)
+		}
+		if _, err := s.Sign(rand.Reader, test.digest, test.opts); err == nil {
+			t.Errorf("%s: got nil, want error", test.desc)
+		}
+	}
+
+	s, err := newSigner(ctx, &fakeKMS{alg: kmspb.CryptoKeyVersion_RSA_SIGN_PKCS1_2048_SHA256, key: rsaKey, corrupt: true}, keyName)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.Sign(rand.Reader, sum[:], crypto.SHA256); err != errCorruptedResponse {
+		t.Errorf("corrupted response: got %v, want %v", err, errCorruptedResponse)
+	}
+
+	if _, err := newSigner(ctx, &fakeKMS{alg: kmspb.CryptoKeyVersion_RSA_DECRYPT_OAEP_2048_SHA256, key: rsaKey}, keyName); err == nil {
+		t.Error("decryption key: got nil, want error")
+	}
+}
+
+func TestSignerCertificate(t *testing.T) {
+	s, err := newSigner(context.Background(), &fakeKMS{alg: kmspb.CryptoKeyVersion_EC_SIGN_P256_SHA256, key: ecKey}, keyName)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "test"},
+		NotBefore:             time.Now(),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, s.Public(), s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := cert.CheckSignatureFrom(cert); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestDecrypter(t *testing.T) {
+	ctx := context.Background()
+	d, err := newDecrypter(ctx, &fakeKMS{alg: kmspb.CryptoKeyVersion_RSA_DECRYPT_OAEP_2048_SHA256, key: rsaKey}, keyName)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ct, err := rsa.EncryptOAEP(sha256.New(), rand.Reader, d.Public().(*rsa.PublicKey), []byte("hello"), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, opts := range []crypto.DecrypterOpts{nil, &rsa.OAEPOptions{Hash: crypto.SHA256}} {
+		pt, err := d.Decrypt(rand.Reader, ct, opts)
+		if err != nil {
+			t.Fatalf("%v: %v", opts, err)
+		}
+		if string(pt) != "hello" {
+			t.Errorf("%v: got %q, want %q", opts, pt, "hello")
+		}
+	}
+
+	for _, opts := range []crypto.DecrypterOpts{
+		&rsa.OAEPOptions{Hash: crypto.SHA1},
+		&rsa.OAEPOptions{Hash: crypto.SHA256, Label: []byte("label")},
+		&rsa.PKCS1v15DecryptOptions{},
+	} {
+		if _, err := d.Decrypt(rand.Reader, ct, opts); err == nil {
+			t.Errorf("%#v: got nil, want error", opts)
+		}
+	}
+
+	d, err = newDecrypter(ctx, &fakeKMS{alg: kmspb.CryptoKeyVersion_RSA_DECRYPT_OAEP_2048_SHA256, key: rsaKey, corrupt: true}, keyName)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := d.Decrypt(rand.Reader, ct, nil); err != errCorruptedResponse {
+		t.Errorf("corrupted response: got %v, want %v", err, errCorruptedResponse)
+	}
+
+	if _, err := newDecrypter(ctx, &fakeKMS{alg: kmspb.CryptoKeyVersion_EC_SIGN_P256_SHA256, key: ecKey}, keyName); err == nil {
+		t.Error("signing key: got nil, want error")
+	}
+}
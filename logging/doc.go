@@ -111,6 +111,12 @@ You may want use a standard log.Logger in your program.
 	stdlg := lg.StandardLogger(logging.Info)
 	stdlg.Println("some info")
 
+With Go 1.21 or later, you can also log through log/slog. Record attributes
+become fields of a JSON payload:
+
+	slg := slog.New(lg.SlogHandler(&logging.SlogHandlerOptions{AddSource: true}))
+	slg.Info("request served", "path", "/index.html", "latency", latency)
+
 # Log Levels
 
 An Entry may have one of a number of severity levels associated with it.
@@ -142,6 +148,8 @@ the parent.Timestamp and after the parent.Timestamp - parent.HTTPRequest.Latency
 parent.Timestamp marks the end of the request.)
 
 - The trace field must be populated in all of the entries and match exactly.
+If an entry has no trace, it is taken from the OpenTelemetry span or trace
+context headers of its HTTPRequest.Request, or of the context passed to LogSync.
 
 You should observe the child log entries grouped under the parent on the console. The
 parent entry will not inherit the severity of its children; you must update the
@@ -159,3 +159,21 @@ func ExampleClient_UpdateSink() {
 	}
 	fmt.Println(sink)
 }
+
+func ExampleClient_Tail() {
+	ctx := context.Background()
+	client, err := logadmin.NewClient(ctx, "my-project")
+	if err != nil {
+		// TODO: Handle error.
+	}
+	it := client.Tail(ctx, logadmin.Filter(`severity >= ERROR`))
+	defer it.Stop()
+	for {
+		entry, err := it.Next()
+		if err != nil {
+			// TODO: Handle error.
+			break
+		}
+		fmt.Println(entry)
+	}
+}
@@ -0,0 +1,189 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logadmin
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"cloud.google.com/go/logging"
+	logpb "cloud.google.com/go/logging/apiv2/loggingpb"
+	gax "github.com/googleapis/gax-go/v2"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Tail returns a TailIterator that follows log entries as they are written,
+// like "gcloud logging tail". By default, the log entries are restricted to
+// those from the parent resource passed to NewClient; use the ProjectIDs or
+// ResourceNames option to change that, and the Filter option to select
+// entries. The NewestFirst option is ignored. Requires ReadScope or
+// AdminScope.
+//
+// The service ends tail sessions from time to time. When that happens, or the
+// connection fails with a transient error, the iterator opens a new session
+// that resumes from the timestamp of the last entry it returned.
+func (c *Client) Tail(ctx context.Context, opts ...EntriesOption) *TailIterator {
+	lreq := &logpb.ListLogEntriesRequest{ResourceNames: []string{c.parent}}
+	for _, opt := range opts {
+		opt.set(lreq)
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	it := &TailIterator{
+		ctx:           ctx,
+		cancel:        cancel,
+		resourceNames: lreq.ResourceNames,
+		filter:        lreq.Filter,
+		open: func(ctx context.Context) (logpb.LoggingServiceV2_TailLogEntriesClient, error) {
+			return c.lClient.TailLogEntries(ctx)
+		},
+		backoff: gax.Backoff{Initial: time.Second, Max: 30 * time.Second, Multiplier: 2},
+		lastIDs: map[string]bool{},
+	}
+	it.pause = it.backoff
+	return it
+}
+
+// A TailIterator iterates over log entries as they are written. It never
+// returns iterator.Done; call Stop when you are done with it.
+type TailIterator struct {
+	ctx           context.Context
+	cancel        func()
+	resourceNames []string
+	filter        string
+	open          func(context.Context) (logpb.LoggingServiceV2_TailLogEntriesClient, error)
+	backoff       gax.Backoff // settings for the pauses between sessions
+	pause         gax.Backoff // pauses since the last response
+
+	stream     logpb.LoggingServiceV2_TailLogEntriesClient
+	items      []*logging.Entry
+	suppressed int64
+	err        error
+
+	// The timestamp of the last entry returned, and the insert IDs of the
+	// entries returned with that timestamp, so that a new session can resume
+	// without repeating them.
+	lastTime time.Time
+	lastIDs  map[string]bool
+}
+
+// Next returns the next log entry. It blocks until an entry is written, the
+// context passed to Tail is done, or an error that cannot be retried occurs.
+// Once Next returns an error, all subsequent calls return the same error.
+func (it *TailIterator) Next() (*logging.Entry, error) {
+	for len(it.items) == 0 {
+		if it.err != nil {
+			return nil, it.err
+		}
+		if err := it.fetch(); err != nil {
+			it.err = err
+			it.cancel()
+		}
+	}
+	e := it.items[0]
+	it.items = it.items[1:]
+	return e, nil
+}
+
+// Suppressed returns the number of entries that the service has omitted so
+// far, for example because they were written faster than the tail session's
+// rate limit allows.
+func (it *TailIterator) Suppressed() int64 { return it.suppressed }
+
+// Stop ends the tail session. Calls to Next after Stop return an error.
+func (it *TailIterator) Stop() {
+	it.cancel()
+}
+
+// fetch receives the next response, opening a session first if needed, and
+// adds its entries to it.items.
+func (it *TailIterator) fetch() error {
+	if it.stream == nil {
+		stream, err := it.open(it.ctx)
+		if err == nil {
+			err = stream.Send(it.request())
+		}
+		if err != nil {
+			return it.retry(err)
+		}
+		it.stream = stream
+	}
+	res, err := it.stream.Recv()
+	if err != nil {
+		it.stream = nil
+		return it.retry(err)
+	}
+	it.pause = it.backoff
+	for _, si := range res.SuppressionInfo {
+		it.suppressed += int64(si.SuppressedCount)
+	}
+	for _, le := range res.Entries {
+		e, err := fromLogEntry(le)
+		if err != nil {
+			return err
+		}
+		switch {
+		case e.Timestamp.Before(it.lastTime):
+		case e.Timestamp.Equal(it.lastTime):
+			if it.lastIDs[e.InsertID] {
+				continue
+			}
+		default:
+			it.lastTime = e.Timestamp
+			it.lastIDs = map[string]bool{}
+		}
+		if e.InsertID != "" && e.Timestamp.Equal(it.lastTime) {
+			it.lastIDs[e.InsertID] = true
+		}
+		it.items = append(it.items, e)
+	}
+	return nil
+}
+
+// retry returns nil after a pause if err ends a tail session but the session
+// can be resumed, and err otherwise.
+func (it *TailIterator) retry(err error) error {
+	if it.ctx.Err() != nil {
+		return it.ctx.Err()
+	}
+	if err != io.EOF {
+		switch status.Code(err) {
+		case codes.Unavailable, codes.Internal, codes.DeadlineExceeded, codes.ResourceExhausted:
+		default:
+			return err
+		}
+	}
+	return gax.Sleep(it.ctx, it.pause.Pause())
+}
+
+// request returns the request that opens a tail session. A session that
+// resumes a previous one starts at the timestamp of the last entry returned.
+func (it *TailIterator) request() *logpb.TailLogEntriesRequest {
+	filter := it.filter
+	if !it.lastTime.IsZero() {
+		ts := fmt.Sprintf(`timestamp >= "%s"`, it.lastTime.UTC().Format(time.RFC3339Nano))
+		if filter == "" {
+			filter = ts
+		} else {
+			filter = fmt.Sprintf("(%s) AND %s", filter, ts)
+		}
+	}
+	return &logpb.TailLogEntriesRequest{
+		ResourceNames: it.resourceNames,
+		Filter:        filter,
+	}
+}
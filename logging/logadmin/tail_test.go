@@ -0,0 +1,144 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logadmin
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	logpb "cloud.google.com/go/logging/apiv2/loggingpb"
+	gax "github.com/googleapis/gax-go/v2"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// fakeTailStream is a tail session that returns responses, then err.
+type fakeTailStream struct {
+	grpc.ClientStream
+	req       *logpb.TailLogEntriesRequest
+	responses []*logpb.TailLogEntriesResponse
+	err       error
+}
+
+func (s *fakeTailStream) Send(req *logpb.TailLogEntriesRequest) error {
+	s.req = req
+	return nil
+}
+
+func (s *fakeTailStream) Recv() (*logpb.TailLogEntriesResponse, error) {
+	if len(s.responses) == 0 {
+		return nil, s.err
+	}
+	res := s.responses[0]
+	s.responses = s.responses[1:]
+	return res, nil
+}
+
+func tailEntry(sec int64, id string) *logpb.LogEntry {
+	return &logpb.LogEntry{
+		Timestamp: &timestamppb.Timestamp{Seconds: sec},
+		InsertId:  id,
+		Payload:   &logpb.LogEntry_TextPayload{TextPayload: id},
+	}
+}
+
+func newFakeTailIterator(t *testing.T, filter string, streams ...*fakeTailStream) *TailIterator {
+	c := &Client{parent: "projects/P"}
+	var opts []EntriesOption
+	if filter != "" {
+		opts = append(opts, Filter(filter))
+	}
+	it := c.Tail(context.Background(), opts...)
+	it.open = func(context.Context) (logpb.LoggingServiceV2_TailLogEntriesClient, error) {
+		if len(streams) == 0 {
+			t.Fatal("too many sessions")
+		}
+		s := streams[0]
+		streams = streams[1:]
+		return s, nil
+	}
+	it.backoff = gax.Backoff{Initial: time.Millisecond}
+	it.pause = it.backoff
+	return it
+}
+
+func TestTail(t *testing.T) {
+	s1 := &fakeTailStream{
+		responses: []*logpb.TailLogEntriesResponse{
+			{Entries: []*logpb.LogEntry{tailEntry(1, "a"), tailEntry(2, "b")}},
+			{SuppressionInfo: []*logpb.TailLogEntriesResponse_SuppressionInfo{{SuppressedCount: 3}}},
+		},
+		err: status.Error(codes.Unavailable, "unavailable"),
+	}
+	// The second session repeats the entry at the resume timestamp.
+	s2 := &fakeTailStream{
+		responses: []*logpb.TailLogEntriesResponse{
+			{Entries: []*logpb.LogEntry{tailEntry(2, "b"), tailEntry(2, "c"), tailEntry(3, "d")}},
+		},
+		err: io.EOF,
+	}
+	s3 := &fakeTailStream{err: status.Error(codes.PermissionDenied, "denied")}
+	it := newFakeTailIterator(t, `severity >= ERROR`, s1, s2, s3)
+	defer it.Stop()
+
+	var got []string
+	var err error
+	for {
+		ent, nerr := it.Next()
+		if nerr != nil {
+			err = nerr
+			break
+		}
+		got = append(got, ent.Payload.(string))
+	}
+	if want := "a b c d"; strings.Join(got, " ") != want {
+		t.Errorf("got entries %v, want %s", got, want)
+	}
+	if status.Code(err) != codes.PermissionDenied {
+		t.Errorf("got %v, want PermissionDenied", err)
+	}
+	if _, err2 := it.Next(); err2 != err {
+		t.Errorf("got %v after error, want %v", err2, err)
+	}
+	if it.Suppressed() != 3 {
+		t.Errorf("got %d suppressed, want 3", it.Suppressed())
+	}
+
+	if got, want := s1.req.Filter, `severity >= ERROR`; got != want {
+		t.Errorf("first session: got filter %q, want %q", got, want)
+	}
+	if got, want := s1.req.ResourceNames, []string{"projects/P"}; len(got) != 1 || got[0] != want[0] {
+		t.Errorf("got resource names %v, want %v", got, want)
+	}
+	if got, want := s2.req.Filter, `(severity >= ERROR) AND timestamp >= "1970-01-01T00:00:02Z"`; got != want {
+		t.Errorf("second session: got filter %q, want %q", got, want)
+	}
+	if got, want := s3.req.Filter, `(severity >= ERROR) AND timestamp >= "1970-01-01T00:00:03Z"`; got != want {
+		t.Errorf("third session: got filter %q, want %q", got, want)
+	}
+}
+
+func TestTailStop(t *testing.T) {
+	it := newFakeTailIterator(t, "", &fakeTailStream{err: status.Error(codes.Unavailable, "unavailable")})
+	it.Stop()
+	if _, err := it.Next(); err != context.Canceled {
+		t.Errorf("got %v, want context.Canceled", err)
+	}
+}
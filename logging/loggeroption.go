@@ -19,6 +19,8 @@ import (
 	"io"
 	"os"
 	"time"
+
+	logpb "cloud.google.com/go/logging/apiv2/loggingpb"
 )
 
 // LoggerOption is a configuration option for a Logger.
@@ -187,3 +189,92 @@ type redirectOutputOption struct {
 func (o *redirectOutputOption) set(l *Logger) {
 	l.redirectOutputWriter = o.writer
 }
+
+// BufferOverflow sets what Logger.Log does when the Logger has BufferedByteLimit
+// bytes of entries in memory. The default is OverflowDrop. Whatever the
+// policy, dropped entries are written to the writer set with
+// SpillDroppedEntries, if any.
+func BufferOverflow(p OverflowPolicy) LoggerOption { return overflowPolicyOption(p) }
+
+// OverflowPolicy is what Logger.Log does with an entry that does not fit in the
+// Logger's buffer.
+type OverflowPolicy int
+
+const (
+	// OverflowDrop drops the entry, reporting ErrOverflow to Client.OnError
+	// and to the function set with OnWriteResult.
+	OverflowDrop OverflowPolicy = iota
+
+	// OverflowBlock makes Log block until enough buffered entries have been
+	// written to make room for the entry.
+	OverflowBlock
+
+	// OverflowDropOldest keeps the entry and drops the oldest entries instead.
+	// Entries that do not fit in the buffer wait for room in a second buffer
+	// of up to BufferedByteLimit bytes, from which the oldest entries are
+	// dropped, reporting ErrOverflow as for OverflowDrop.
+	OverflowDropOldest
+)
+
+type overflowPolicyOption OverflowPolicy
+
+func (o overflowPolicyOption) set(l *Logger) { l.overflowPolicy = OverflowPolicy(o) }
+
+// WriteRetryLimit is the maximum number of times that a failed write of entries
+// buffered by Logger.Log is retried before the entries are dropped. Only
+// errors that may be transient, like Unavailable, are retried. The default is
+// no limit: writes are retried until they succeed or time out.
+func WriteRetryLimit(n int) LoggerOption { return writeRetryLimit(n) }
+
+type writeRetryLimit int
+
+func (w writeRetryLimit) set(l *Logger) {
+	if w >= 0 {
+		l.writeRetryLimit = int(w)
+	}
+}
+
+// OnWriteResult sets a function that is called with the outcome of each batch of
+// entries buffered by Logger.Log, once the batch is written or dropped. It is
+// also called for each entry that Log drops because it cannot be buffered. Use
+// it to detect and react to lost entries; for errors alone, Client.OnError
+// is simpler.
+//
+// The function may be called concurrently, from Log and from the goroutines
+// that write entries, so it should be safe for concurrent use and return
+// quickly.
+func OnWriteResult(f func(WriteResult)) LoggerOption { return writeResultFunc(f) }
+
+type writeResultFunc func(WriteResult)
+
+func (f writeResultFunc) set(l *Logger) { l.writeResultFunc = f }
+
+// SpillDroppedEntries writes the entries that a Logger drops, because they
+// could not be buffered or written to the logging service, to w instead of
+// losing them. Each entry is written as one line of JSON, in the format used
+// by RedirectAsJSON, so a file of spilled entries can be ingested later by a
+// logging agent. Writes to w are serialized.
+func SpillDroppedEntries(w io.Writer) LoggerOption { return &spillWriterOption{w} }
+
+type spillWriterOption struct {
+	writer io.Writer
+}
+
+func (o *spillWriterOption) set(l *Logger) { l.spillWriter = o.writer }
+
+// EntryTransform sets a function that is applied to every entry written by the
+// Logger, with Log or LogSync, before it is sent or redirected. Use it to
+// scrub sensitive data, truncate payloads or add labels in one place. The
+// function may modify the entry and return it, return a different entry, or
+// return nil to drop the entry. If EntryTransform is given more than once,
+// the functions are applied in order.
+//
+// The function may be called concurrently, so it should be safe for concurrent
+// use.
+func EntryTransform(f func(*logpb.LogEntry) *logpb.LogEntry) LoggerOption {
+	return entryTransform(f)
+}
+
+type entryTransform func(*logpb.LogEntry) *logpb.LogEntry
+
+func (f entryTransform) set(l *Logger) { l.transforms = append(l.transforms, f) }
@@ -48,11 +48,13 @@ import (
 	"github.com/golang/protobuf/ptypes"
 	structpb "github.com/golang/protobuf/ptypes/struct"
 	gax "github.com/googleapis/gax-go/v2"
+	"go.opentelemetry.io/otel/trace"
 	"google.golang.org/api/option"
 	"google.golang.org/api/support/bundler"
 	mrpb "google.golang.org/genproto/googleapis/api/monitoredres"
 	logtypepb "google.golang.org/genproto/googleapis/logging/type"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/anypb"
 	"google.golang.org/protobuf/types/known/timestamppb"
@@ -261,10 +263,38 @@ type Logger struct {
 	populateSourceLocation int
 	partialSuccess         bool
 	redirectOutputWriter   io.Writer
+	overflowPolicy         OverflowPolicy
+	writeRetryLimit        int // negative for no limit
+	writeResultFunc        func(WriteResult)
+	spillWriter            io.Writer
+	transforms             []func(*logpb.LogEntry) *logpb.LogEntry
+
+	spillMu sync.Mutex // serializes writes to spillWriter
+
+	// Entries waiting for room in the bundler, for OverflowDropOldest.
+	pendingMu      sync.Mutex
+	pendingDone    *sync.Cond // signaled when feeding stops
+	pending        []*logpb.LogEntry
+	pendingBytes   int
+	pendingFeeding bool // a goroutine is moving pending entries to the bundler
+}
+
+// WriteResult is the outcome of writing a batch of entries buffered by
+// Logger.Log. It is passed to the function set with the OnWriteResult option.
+type WriteResult struct {
+	// Entries are the entries of the batch.
+	Entries []*logpb.LogEntry
+
+	// Err is nil if the entries were written. Otherwise the entries were
+	// dropped, and Err is the error from the logging service, or ErrOverflow
+	// or ErrOversizedEntry for an entry that could not be buffered.
+	Err error
 }
 
 type loggerRetryer struct {
 	defaultRetryer gax.Retryer
+	maxRetries     int // negative for no limit
+	retries        int
 }
 
 func newLoggerRetryer() gax.Retryer {
@@ -279,7 +309,7 @@ func newLoggerRetryer() gax.Retryer {
 		Multiplier: 1.30,
 	})
 
-	r := &loggerRetryer{defaultRetryer: d}
+	r := &loggerRetryer{defaultRetryer: d, maxRetries: -1}
 	return r
 }
 
@@ -291,6 +321,10 @@ func (r *loggerRetryer) Retry(err error) (pause time.Duration, shouldRetry bool)
 	if strings.Contains(s.Message(), utfErrorString) {
 		return 0, false
 	}
+	if r.maxRetries >= 0 && r.retries >= r.maxRetries {
+		return 0, false
+	}
+	r.retries++
 	return r.defaultRetryer.Retry(err)
 }
 
@@ -312,7 +346,10 @@ func (c *Client) Logger(logID string, opts ...LoggerOption) *Logger {
 		populateSourceLocation: DoNotPopulateSourceLocation,
 		partialSuccess:         false,
 		redirectOutputWriter:   nil,
+		overflowPolicy:         OverflowDrop,
+		writeRetryLimit:        -1,
 	}
+	l.pendingDone = sync.NewCond(&l.pendingMu)
 	l.bundler = bundler.NewBundler(&logpb.LogEntry{}, func(entries interface{}) {
 		l.writeLogEntries(entries.([]*logpb.LogEntry))
 	})
@@ -335,7 +372,7 @@ func (c *Client) Logger(logID string, opts ...LoggerOption) *Logger {
 	go func() {
 		defer c.loggers.Done()
 		<-c.donec
-		l.bundler.Flush()
+		l.flush()
 	}()
 	return l
 }
@@ -497,6 +534,10 @@ type Entry struct {
 	// Trace is the resource name of the trace associated with the log entry,
 	// if any. If it contains a relative resource name, the name is assumed to
 	// be relative to //tracing.googleapis.com.
+	//
+	// If Trace is empty, it is set, along with SpanID and TraceSampled, from
+	// the OpenTelemetry span or the trace context headers of the request in
+	// HTTPRequest, or of the context passed to LogSync.
 	Trace string
 
 	// ID of the span within the trace associated with the log entry.
@@ -672,11 +713,19 @@ func jsonValueToStructValue(v interface{}) *structpb.Value {
 // LogSync logs the Entry synchronously without any buffering. Because LogSync is slow
 // and will block, it is intended primarily for debugging or critical errors.
 // Prefer Log for most uses.
+//
+// If the entry has no Trace, LogSync sets it from ctx: from its OpenTelemetry
+// span, or from the Traceparent or X-Cloud-Trace-Context metadata of the
+// incoming gRPC request.
 func (l *Logger) LogSync(ctx context.Context, e Entry) error {
+	setTraceFromContext(ctx, &e, l.client.parent)
 	ent, err := toLogEntryInternal(e, l, l.client.parent, 1)
 	if err != nil {
 		return err
 	}
+	if ent = l.transform(ent); ent == nil {
+		return nil
+	}
 	entries, hasInstrumentation := l.instrumentLogs([]*logpb.LogEntry{ent})
 	if l.redirectOutputWriter != nil {
 		for _, ent = range entries {
@@ -697,7 +746,8 @@ func (l *Logger) LogSync(ctx context.Context, e Entry) error {
 	return err
 }
 
-// Log buffers the Entry for output to the logging service. It never blocks.
+// Log buffers the Entry for output to the logging service. It never blocks,
+// unless the Logger was created with the OverflowBlock policy.
 func (l *Logger) Log(e Entry) {
 	l.logInternal(e, 1)
 }
@@ -708,6 +758,9 @@ func (l *Logger) logInternal(e Entry, skipLevels int) {
 		l.client.error(err)
 		return
 	}
+	if ent = l.transform(ent); ent == nil {
+		return
+	}
 
 	entries, _ := l.instrumentLogs([]*logpb.LogEntry{ent})
 	if l.redirectOutputWriter != nil {
@@ -720,12 +773,116 @@ func (l *Logger) logInternal(e Entry, skipLevels int) {
 		return
 	}
 	for _, ent = range entries {
-		if err := l.bundler.Add(ent, proto.Size(ent)); err != nil {
+		l.buffer(ent)
+	}
+}
+
+// transform applies the Logger's entry transforms to ent, in order. It returns
+// nil if a transform dropped the entry.
+func (l *Logger) transform(ent *logpb.LogEntry) *logpb.LogEntry {
+	for _, f := range l.transforms {
+		if ent = f(ent); ent == nil {
+			return nil
+		}
+	}
+	return ent
+}
+
+// buffer adds ent to the bundler, following the Logger's overflow policy.
+func (l *Logger) buffer(ent *logpb.LogEntry) {
+	var err error
+	switch l.overflowPolicy {
+	case OverflowBlock:
+		err = l.bundler.AddWait(context.Background(), ent, proto.Size(ent))
+	case OverflowDropOldest:
+		l.bufferDropOldest(ent)
+		return
+	default:
+		err = l.bundler.Add(ent, proto.Size(ent))
+	}
+	if err != nil {
+		l.client.error(err)
+		l.reportResult([]*logpb.LogEntry{ent}, err)
+	}
+}
+
+// bufferDropOldest appends ent to the entries waiting for room in the
+// bundler, dropping the oldest of them if they exceed BufferedByteLimit
+// bytes, and makes sure that a goroutine is feeding them to the bundler.
+func (l *Logger) bufferDropOldest(ent *logpb.LogEntry) {
+	var dropped []*logpb.LogEntry
+	l.pendingMu.Lock()
+	l.pending = append(l.pending, ent)
+	l.pendingBytes += proto.Size(ent)
+	for l.pendingBytes > l.bundler.BufferedByteLimit && len(l.pending) > 1 {
+		dropped = append(dropped, l.pending[0])
+		l.pendingBytes -= proto.Size(l.pending[0])
+		l.pending[0] = nil
+		l.pending = l.pending[1:]
+	}
+	if !l.pendingFeeding {
+		l.pendingFeeding = true
+		go l.feed()
+	}
+	l.pendingMu.Unlock()
+	if len(dropped) > 0 {
+		l.client.error(ErrOverflow)
+		l.reportResult(dropped, ErrOverflow)
+	}
+}
+
+// feed moves the pending entries to the bundler, waiting for room in it,
+// until there are none left.
+func (l *Logger) feed() {
+	for {
+		l.pendingMu.Lock()
+		if len(l.pending) == 0 {
+			l.pendingFeeding = false
+			l.pendingDone.Broadcast()
+			l.pendingMu.Unlock()
+			return
+		}
+		ent := l.pending[0]
+		size := proto.Size(ent)
+		l.pendingBytes -= size
+		l.pending[0] = nil
+		l.pending = l.pending[1:]
+		l.pendingMu.Unlock()
+		if err := l.bundler.AddWait(context.Background(), ent, size); err != nil {
 			l.client.error(err)
+			l.reportResult([]*logpb.LogEntry{ent}, err)
 		}
 	}
 }
 
+// flush waits for the pending entries to reach the bundler, then flushes it.
+func (l *Logger) flush() {
+	l.pendingMu.Lock()
+	for l.pendingFeeding {
+		l.pendingDone.Wait()
+	}
+	l.pendingMu.Unlock()
+	l.bundler.Flush()
+}
+
+// reportResult passes the outcome of writing entries to the WriteResult
+// function, after spilling the entries if they were dropped.
+func (l *Logger) reportResult(entries []*logpb.LogEntry, err error) {
+	if err != nil && l.spillWriter != nil {
+		l.spillMu.Lock()
+		for _, ent := range entries {
+			if err := serializeEntryToWriter(ent, l.spillWriter); err != nil {
+				l.client.error(err)
+				break
+			}
+		}
+		l.spillMu.Unlock()
+	}
+	if l.writeResultFunc != nil {
+		l.writeResultFunc(WriteResult{Entries: entries, Err: err})
+	}
+}
+
 // Flush blocks until all currently buffered log entries are sent.
 //
 // If any errors occurred since the last call to Flush from any Logger, or the
@@ -733,7 +890,7 @@ func (l *Logger) logInternal(e Entry, skipLevels int) {
 // error with summary information about the errors. This information is unlikely to
 // be actionable. For more accurate error reporting, set Client.OnError.
 func (l *Logger) Flush() error {
-	l.bundler.Flush()
+	l.flush()
 	return l.client.extractErrorInfo()
 }
 
@@ -753,10 +910,16 @@ func (l *Logger) writeLogEntries(entries []*logpb.LogEntry) {
 	ctx, cancel := context.WithTimeout(ctx, defaultWriteTimeout)
 	defer cancel()
 
-	_, err := l.client.client.WriteLogEntries(ctx, req, gax.WithRetry(newLoggerRetryer))
+	newRetryer := func() gax.Retryer {
+		r := newLoggerRetryer().(*loggerRetryer)
+		r.maxRetries = l.writeRetryLimit
+		return r
+	}
+	_, err := l.client.client.WriteLogEntries(ctx, req, gax.WithRetry(newRetryer))
 	if err != nil {
 		l.client.error(err)
 	}
+	l.reportResult(entries, err)
 	if afterCall != nil {
 		afterCall()
 	}
@@ -791,7 +954,38 @@ func populateTraceInfo(e *Entry, req *http.Request) bool {
 			return false
 		}
 	}
-	header := req.Header.Get("Traceparent")
+	if populateTraceInfoFromContext(req.Context(), e) {
+		return true
+	}
+	return populateTraceInfoFromHeaders(e, req.Header.Get)
+}
+
+// populateTraceInfoFromContext sets the trace fields of e from ctx. It uses
+// the OpenTelemetry span in ctx, if there is one, or else the trace context
+// headers of the incoming gRPC request that ctx belongs to.
+func populateTraceInfoFromContext(ctx context.Context, e *Entry) bool {
+	if sc := trace.SpanContextFromContext(ctx); sc.IsValid() {
+		e.Trace = sc.TraceID().String()
+		e.SpanID = sc.SpanID().String()
+		e.TraceSampled = e.TraceSampled || sc.IsSampled()
+		return true
+	}
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return false
+	}
+	return populateTraceInfoFromHeaders(e, func(key string) string {
+		if vs := md.Get(key); len(vs) > 0 {
+			return vs[0]
+		}
+		return ""
+	})
+}
+
+// populateTraceInfoFromHeaders sets the trace fields of e from the Traceparent
+// or X-Cloud-Trace-Context header, whose values are returned by get.
+func populateTraceInfoFromHeaders(e *Entry, get func(key string) string) bool {
+	header := get("Traceparent")
 	if header != "" {
 		// do not use traceSampled flag defined by traceparent because
 		// flag's definition differs from expected by Cloud Tracing
@@ -802,7 +996,7 @@ func populateTraceInfo(e *Entry, req *http.Request) bool {
 			return true
 		}
 	}
-	header = req.Header.Get("X-Cloud-Trace-Context")
+	header = get("X-Cloud-Trace-Context")
 	if header != "" {
 		traceID, spanID, traceSampled := deconstructXCloudTraceContext(header)
 		if traceID != "" {
@@ -816,6 +1010,14 @@ func populateTraceInfo(e *Entry, req *http.Request) bool {
 	return false
 }
 
+// setTraceFromContext sets the trace fields of e from ctx, unless e already
+// has a trace. The trace is qualified with parent.
+func setTraceFromContext(ctx context.Context, e *Entry, parent string) {
+	if e.Trace == "" && populateTraceInfoFromContext(ctx, e) {
+		e.Trace = fmt.Sprintf("%s/traces/%s", parent, e.Trace)
+	}
+}
+
 // As per format described at https://www.w3.org/TR/trace-context/#traceparent-header-field-values
 var validTraceParentExpression = regexp.MustCompile(`^(00)-([a-fA-F\d]{32})-([a-f\d]{16})-([a-fA-F\d]{2})$`)
 
@@ -1283,18 +1283,28 @@ func BenchmarkSourceLocationPopulation(b *testing.B) {
 type writeLogEntriesTestHandler struct {
 	logpb.UnimplementedLoggingServiceV2Server
 	hook func(*logpb.WriteLogEntriesRequest)
+	// err, if set, returns the error for each request.
+	err func(*logpb.WriteLogEntriesRequest) error
 }
 
 func (f *writeLogEntriesTestHandler) WriteLogEntries(_ context.Context, e *logpb.WriteLogEntriesRequest) (*logpb.WriteLogEntriesResponse, error) {
 	if f.hook != nil {
 		f.hook(e)
 	}
+	if f.err != nil {
+		if err := f.err(e); err != nil {
+			return nil, err
+		}
+	}
 	return &logpb.WriteLogEntriesResponse{}, nil
 }
 
 func fakeClient(parent string, writeLogEntryHandler func(e *logpb.WriteLogEntriesRequest)) (*logging.Client, error) {
+	return fakeClientForBackend(parent, &writeLogEntriesTestHandler{hook: writeLogEntryHandler})
+}
+
+func fakeClientForBackend(parent string, fakeBackend *writeLogEntriesTestHandler) (*logging.Client, error) {
 	// setup fake server
-	fakeBackend := &writeLogEntriesTestHandler{}
 	l, err := net.Listen("tcp", "localhost:0")
 	if err != nil {
 		return nil, err
@@ -1307,7 +1317,6 @@ func fakeClient(parent string, writeLogEntryHandler func(e *logpb.WriteLogEntrie
 			panic(err)
 		}
 	}()
-	fakeBackend.hook = writeLogEntryHandler
 	ctx := context.Background()
 	client, _ := logging.NewClient(ctx, parent, option.WithEndpoint(fakeServerAddr),
 		option.WithoutAuthentication(),
@@ -1360,6 +1369,242 @@ func TestPartialSuccessOption(t *testing.T) {
 	}
 }
 
+// writeResults collects the results passed to an OnWriteResult function.
+type writeResults struct {
+	mu      sync.Mutex
+	results []logging.WriteResult
+}
+
+func (w *writeResults) add(r logging.WriteResult) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.results = append(w.results, r)
+}
+
+func (w *writeResults) get() []logging.WriteResult {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.results
+}
+
+func TestWriteResults(t *testing.T) {
+	var nRequests int32
+	client, err := fakeClientForBackend("projects/test", &writeLogEntriesTestHandler{
+		err: func(e *logpb.WriteLogEntriesRequest) error {
+			atomic.AddInt32(&nRequests, 1)
+			if e.Entries[0].GetTextPayload() == "bad" {
+				return status.Error(codes.Unavailable, "unavailable")
+			}
+			return nil
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	client.OnError = func(error) {}
+	defer client.Close()
+
+	var (
+		results writeResults
+		spilled strings.Builder
+	)
+	lg := client.Logger("results",
+		logging.OnWriteResult(results.add),
+		logging.SpillDroppedEntries(&spilled),
+		logging.WriteRetryLimit(2))
+	lg.Log(logging.Entry{Payload: "good"})
+	lg.Flush()
+	lg.Log(logging.Entry{Payload: "bad"})
+	lg.Flush()
+
+	got := results.get()
+	if len(got) != 2 {
+		t.Fatalf("got %d results, want 2", len(got))
+	}
+	if got[0].Err != nil || got[0].Entries[0].GetTextPayload() != "good" {
+		t.Errorf("got first result %+v, want written good entry", got[0])
+	}
+	if status.Code(got[1].Err) != codes.Unavailable || got[1].Entries[0].GetTextPayload() != "bad" {
+		t.Errorf("got second result %+v, want dropped bad entry", got[1])
+	}
+	// One request for the good entry, and three for the bad one.
+	if n := atomic.LoadInt32(&nRequests); n != 4 {
+		t.Errorf("got %d requests, want 4", n)
+	}
+	if want := `"message":"bad"`; !strings.Contains(spilled.String(), want) || strings.Contains(spilled.String(), "good") {
+		t.Errorf("got spilled entries %q, want only the bad entry", spilled.String())
+	}
+}
+
+func TestBufferOverflow(t *testing.T) {
+	var nEntries int32
+	client, err := fakeClientForBackend("projects/test", &writeLogEntriesTestHandler{
+		hook: func(e *logpb.WriteLogEntriesRequest) {
+			time.Sleep(10 * time.Millisecond)
+			atomic.AddInt32(&nEntries, int32(len(e.Entries)))
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	client.OnError = func(error) {}
+	defer client.Close()
+
+	payload := strings.Repeat("x", 100)
+	for _, test := range []struct {
+		policy      logging.OverflowPolicy
+		wantWritten int32
+	}{
+		{logging.OverflowDrop, 1},
+		{logging.OverflowBlock, 5},
+	} {
+		atomic.StoreInt32(&nEntries, 0)
+		var results writeResults
+		// The buffer only has room for one entry.
+		lg := client.Logger("overflow",
+			logging.BufferedByteLimit(150),
+			logging.EntryCountThreshold(1),
+			logging.BufferOverflow(test.policy),
+			logging.OnWriteResult(results.add))
+		for i := 0; i < 5; i++ {
+			lg.Log(logging.Entry{Payload: payload})
+		}
+		lg.Flush()
+		if got := atomic.LoadInt32(&nEntries); got != test.wantWritten {
+			t.Errorf("policy %d: got %d entries written, want %d", test.policy, got, test.wantWritten)
+		}
+		var nOverflow int32
+		for _, r := range results.get() {
+			if r.Err == logging.ErrOverflow {
+				nOverflow++
+			}
+		}
+		if want := 5 - test.wantWritten; nOverflow != want {
+			t.Errorf("policy %d: got %d overflow results, want %d", test.policy, nOverflow, want)
+		}
+	}
+}
+
+func TestBufferOverflowDropOldest(t *testing.T) {
+	var (
+		mu      sync.Mutex
+		written []string
+	)
+	client, err := fakeClientForBackend("projects/test", &writeLogEntriesTestHandler{
+		hook: func(e *logpb.WriteLogEntriesRequest) {
+			time.Sleep(10 * time.Millisecond)
+			mu.Lock()
+			for _, ent := range e.Entries {
+				written = append(written, ent.GetTextPayload())
+			}
+			mu.Unlock()
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	client.OnError = func(error) {}
+	defer client.Close()
+
+	var (
+		results writeResults
+		spilled strings.Builder
+	)
+	lg := client.Logger("overflow",
+		logging.BufferedByteLimit(150),
+		logging.EntryCountThreshold(1),
+		logging.BufferOverflow(logging.OverflowDropOldest),
+		logging.OnWriteResult(results.add),
+		logging.SpillDroppedEntries(&spilled))
+	const n = 5
+	payload := strings.Repeat("x", 100)
+	for i := 0; i < n; i++ {
+		lg.Log(logging.Entry{Payload: fmt.Sprint(i, payload)})
+	}
+	lg.Flush()
+
+	// The last entry is always written; the dropped entries are older ones.
+	mu.Lock()
+	defer mu.Unlock()
+	if len(written) == 0 || written[len(written)-1] != fmt.Sprint(n-1, payload) {
+		t.Fatalf("got %d entries written, want the last one among them", len(written))
+	}
+	var dropped []string
+	for _, r := range results.get() {
+		if r.Err == nil {
+			continue
+		}
+		if r.Err != logging.ErrOverflow {
+			t.Errorf("got error %v, want ErrOverflow", r.Err)
+		}
+		for _, ent := range r.Entries {
+			dropped = append(dropped, ent.GetTextPayload())
+		}
+	}
+	if len(written)+len(dropped) != n {
+		t.Errorf("got %d entries written and %d dropped, want %d in all", len(written), len(dropped), n)
+	}
+	if len(dropped) == 0 {
+		t.Error("got no dropped entries")
+	}
+	if got := strings.Count(spilled.String(), "\n"); got != len(dropped) {
+		t.Errorf("got %d spilled entries, want %d", got, len(dropped))
+	}
+}
+
+func TestEntryTransform(t *testing.T) {
+	var (
+		mu  sync.Mutex
+		got []*logpb.LogEntry
+	)
+	client, err := fakeClient("projects/test", func(e *logpb.WriteLogEntriesRequest) {
+		mu.Lock()
+		got = append(got, e.Entries...)
+		mu.Unlock()
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+
+	lg := client.Logger("transform",
+		logging.EntryTransform(func(e *logpb.LogEntry) *logpb.LogEntry {
+			if e.GetTextPayload() == "drop" {
+				return nil
+			}
+			e.Payload = &logpb.LogEntry_TextPayload{
+				TextPayload: strings.ReplaceAll(e.GetTextPayload(), "secret", "[REDACTED]"),
+			}
+			return e
+		}),
+		logging.EntryTransform(func(e *logpb.LogEntry) *logpb.LogEntry {
+			e.Labels = map[string]string{"scrubbed": "true"}
+			return e
+		}))
+	lg.Log(logging.Entry{Payload: "password is secret"})
+	lg.Log(logging.Entry{Payload: "drop"})
+	lg.Flush()
+	if err := lg.LogSync(context.Background(), logging.Entry{Payload: "drop"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := lg.LogSync(context.Background(), logging.Entry{Payload: "secret"}); err != nil {
+		t.Fatal(err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	var payloads []string
+	for _, e := range got {
+		payloads = append(payloads, e.GetTextPayload())
+		if e.Labels["scrubbed"] != "true" {
+			t.Errorf("%q: got labels %v", e.GetTextPayload(), e.Labels)
+		}
+	}
+	if want := []string{"password is [REDACTED]", "[REDACTED]"}; !cmp.Equal(payloads, want) {
+		t.Errorf("got payloads %q, want %q", payloads, want)
+	}
+}
+
 func TestRedirectOutputIngestion(t *testing.T) {
 	var hookCalled bool
 
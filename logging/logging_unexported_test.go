@@ -17,6 +17,7 @@
 package logging
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -29,10 +30,12 @@ import (
 	"github.com/golang/protobuf/proto"
 	durpb "github.com/golang/protobuf/ptypes/duration"
 	structpb "github.com/golang/protobuf/ptypes/struct"
+	"go.opentelemetry.io/otel/trace"
 	"google.golang.org/api/support/bundler"
 	mrpb "google.golang.org/genproto/googleapis/api/monitoredres"
 	logtypepb "google.golang.org/genproto/googleapis/logging/type"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 )
 
@@ -331,6 +334,73 @@ func TestFromHTTPRequest(t *testing.T) {
 	}
 }
 
+func TestTraceFromContext(t *testing.T) {
+	traceID, _ := trace.TraceIDFromHex("105445aa7843bc8bf206b12000100012")
+	spanID, _ := trace.SpanIDFromHex("000000000000004a")
+	spanCtx := trace.ContextWithSpanContext(context.Background(), trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    traceID,
+		SpanID:     spanID,
+		TraceFlags: trace.FlagsSampled,
+	}))
+	const wantTrace = "projects/P/traces/105445aa7843bc8bf206b12000100012"
+
+	for _, test := range []struct {
+		name string
+		ctx  context.Context
+		in   Entry
+		want Entry
+	}{
+		{
+			name: "no trace",
+			ctx:  context.Background(),
+		},
+		{
+			name: "OpenTelemetry span",
+			ctx:  spanCtx,
+			want: Entry{Trace: wantTrace, SpanID: "000000000000004a", TraceSampled: true},
+		},
+		{
+			name: "X-Cloud-Trace-Context metadata",
+			ctx: metadata.NewIncomingContext(context.Background(),
+				metadata.Pairs("x-cloud-trace-context", "105445aa7843bc8bf206b12000100012/000000000000004a;o=1")),
+			want: Entry{Trace: wantTrace, SpanID: "000000000000004a", TraceSampled: true},
+		},
+		{
+			name: "traceparent metadata",
+			ctx: metadata.NewIncomingContext(context.Background(),
+				metadata.Pairs("traceparent", "00-105445aa7843bc8bf206b12000100012-000000000000004a-01")),
+			want: Entry{Trace: wantTrace, SpanID: "000000000000004a"},
+		},
+		{
+			name: "entry trace kept",
+			ctx:  spanCtx,
+			in:   Entry{Trace: "projects/P/traces/T", SpanID: "S"},
+			want: Entry{Trace: "projects/P/traces/T", SpanID: "S"},
+		},
+	} {
+		e := test.in
+		setTraceFromContext(test.ctx, &e, "projects/P")
+		if e.Trace != test.want.Trace || e.SpanID != test.want.SpanID || e.TraceSampled != test.want.TraceSampled {
+			t.Errorf("%s: got trace %q, span %q, sampled %t; want %q, %q, %t", test.name,
+				e.Trace, e.SpanID, e.TraceSampled, test.want.Trace, test.want.SpanID, test.want.TraceSampled)
+		}
+	}
+
+	// The span of an HTTP request's context is preferred to its headers.
+	req := &http.Request{
+		Method: "GET",
+		URL:    &url.URL{Scheme: "http", Host: "example.com"},
+		Header: http.Header{"X-Cloud-Trace-Context": {"t3/1"}},
+	}
+	ent, err := ToLogEntry(Entry{HTTPRequest: &HTTPRequest{Request: req.WithContext(spanCtx)}}, "projects/P")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ent.Trace != wantTrace || ent.SpanId != "000000000000004a" || !ent.TraceSampled {
+		t.Errorf("got trace %q, span %q, sampled %t", ent.Trace, ent.SpanId, ent.TraceSampled)
+	}
+}
+
 func TestMonitoredResource(t *testing.T) {
 	for _, test := range []struct {
 		parent string
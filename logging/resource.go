@@ -25,7 +25,8 @@ import (
 
 // CommonResource sets the monitored resource associated with all log entries
 // written from a Logger. If not provided, the resource is automatically
-// detected based on the running environment (on GCE, GCR, GCF and GAE Standard only).
+// detected based on the running environment (on GCE, GKE, GAE, Cloud Run services
+// and jobs, and Cloud Functions only).
 // This value can be overridden per-entry by setting an Entry's Resource field.
 func CommonResource(r *mrpb.MonitoredResource) LoggerOption { return commonResource{r} }
 
@@ -48,6 +49,15 @@ func (r *resource) metadataProjectID() string {
 	return r.attrs.Metadata("project/project-id")
 }
 
+// projectID returns the project ID from the metadata server or, if it is not
+// available, from the GOOGLE_CLOUD_PROJECT environment variable.
+func (r *resource) projectID() string {
+	if projectID := r.metadataProjectID(); projectID != "" {
+		return projectID
+	}
+	return r.attrs.EnvVar("GOOGLE_CLOUD_PROJECT")
+}
+
 func (r *resource) metadataZone() string {
 	zone := r.attrs.Metadata("instance/zone")
 	if zone != "" {
@@ -79,10 +89,7 @@ func (r *resource) isAppEngine() bool {
 }
 
 func detectAppEngineResource() *mrpb.MonitoredResource {
-	projectID := detectedResource.metadataProjectID()
-	if projectID == "" {
-		projectID = detectedResource.attrs.EnvVar("GOOGLE_CLOUD_PROJECT")
-	}
+	projectID := detectedResource.projectID()
 	if projectID == "" {
 		return nil
 	}
@@ -110,12 +117,20 @@ func (r *resource) isCloudFunction() bool {
 }
 
 func detectCloudFunction() *mrpb.MonitoredResource {
-	projectID := detectedResource.metadataProjectID()
+	projectID := detectedResource.projectID()
 	if projectID == "" {
 		return nil
 	}
 	region := detectedResource.metadataRegion()
+	if region == "" {
+		// set by older runtimes
+		region = detectedResource.attrs.EnvVar("FUNCTION_REGION")
+	}
 	functionName := detectedResource.attrs.EnvVar("K_SERVICE")
+	if functionName == "" {
+		// set by older runtimes
+		functionName = detectedResource.attrs.EnvVar("FUNCTION_NAME")
+	}
 	return &mrpb.MonitoredResource{
 		Type: "cloud_function",
 		Labels: map[string]string{
@@ -135,7 +150,7 @@ func (r *resource) isCloudRun() bool {
 }
 
 func detectCloudRunResource() *mrpb.MonitoredResource {
-	projectID := detectedResource.metadataProjectID()
+	projectID := detectedResource.projectID()
 	if projectID == "" {
 		return nil
 	}
@@ -155,6 +170,29 @@ func detectCloudRunResource() *mrpb.MonitoredResource {
 	}
 }
 
+func (r *resource) isCloudRunJob() bool {
+	job := r.attrs.EnvVar("CLOUD_RUN_JOB")
+	execution := r.attrs.EnvVar("CLOUD_RUN_EXECUTION")
+	return job != "" && execution != ""
+}
+
+func detectCloudRunJobResource() *mrpb.MonitoredResource {
+	projectID := detectedResource.projectID()
+	if projectID == "" {
+		return nil
+	}
+	region := detectedResource.metadataRegion()
+	job := detectedResource.attrs.EnvVar("CLOUD_RUN_JOB")
+	return &mrpb.MonitoredResource{
+		Type: "cloud_run_job",
+		Labels: map[string]string{
+			"project_id": projectID,
+			"location":   region,
+			"job_name":   job,
+		},
+	}
+}
+
 func (r *resource) isKubernetesEngine() bool {
 	clusterName := r.attrs.Metadata("instance/attributes/cluster-name")
 	if clusterName == "" {
@@ -164,11 +202,15 @@ func (r *resource) isKubernetesEngine() bool {
 }
 
 func detectKubernetesResource() *mrpb.MonitoredResource {
-	projectID := detectedResource.metadataProjectID()
+	projectID := detectedResource.projectID()
 	if projectID == "" {
 		return nil
 	}
-	zone := detectedResource.metadataZone()
+	// regional clusters are located in a region, not in the zone of the node
+	location := detectedResource.attrs.Metadata("instance/attributes/cluster-location")
+	if location == "" {
+		location = detectedResource.metadataZone()
+	}
 	clusterName := detectedResource.attrs.Metadata("instance/attributes/cluster-name")
 	namespaceName := detectedResource.attrs.ReadAll("/var/run/secrets/kubernetes.io/serviceaccount/namespace")
 	if namespaceName == "" {
@@ -176,15 +218,19 @@ func detectKubernetesResource() *mrpb.MonitoredResource {
 		// the namespace via environment
 		namespaceName = detectedResource.attrs.EnvVar("NAMESPACE_NAME")
 	}
-	// note: if deployment customizes hostname, HOSTNAME envvar will have invalid content
-	podName := detectedResource.attrs.EnvVar("HOSTNAME")
+	// prefer the pod name exposed with the downward API; if deployment
+	// customizes hostname, HOSTNAME envvar will have invalid content
+	podName := detectedResource.attrs.EnvVar("POD_NAME")
+	if podName == "" {
+		podName = detectedResource.attrs.EnvVar("HOSTNAME")
+	}
 	// there is no way to derive container name from within container; use custom envvar if available
 	containerName := detectedResource.attrs.EnvVar("CONTAINER_NAME")
 	return &mrpb.MonitoredResource{
 		Type: "k8s_container",
 		Labels: map[string]string{
 			"cluster_name":   clusterName,
-			"location":       zone,
+			"location":       location,
 			"project_id":     projectID,
 			"pod_name":       podName,
 			"namespace_name": namespaceName,
@@ -201,7 +247,7 @@ func (r *resource) isComputeEngine() bool {
 }
 
 func detectComputeEngineResource() *mrpb.MonitoredResource {
-	projectID := detectedResource.metadataProjectID()
+	projectID := detectedResource.projectID()
 	if projectID == "" {
 		return nil
 	}
@@ -226,6 +272,9 @@ func detectResource() *mrpb.MonitoredResource {
 				detectedResource.pb = detectAppEngineResource()
 			case name == "Google Cloud Functions", detectedResource.isCloudFunction():
 				detectedResource.pb = detectCloudFunction()
+			// Cloud Run jobs have the same product name as services
+			case detectedResource.isCloudRunJob():
+				detectedResource.pb = detectCloudRunJobResource()
 			case name == "Google Cloud Run", detectedResource.isCloudRun():
 				detectedResource.pb = detectCloudRunResource()
 			// cannot use name validation for GKE and GCE because
@@ -41,6 +41,7 @@ const (
 	containerName       = "test-k8s-container-name"
 	namespaceName       = "test-k8s-namespace-name"
 	instanceID          = "test-instance-12345"
+	jobName             = "test-job"
 )
 
 // fakeResourceGetter mocks internal.ResourceAtttributesGetter interface to retrieve env vars and metadata
@@ -171,6 +172,52 @@ func TestResourceDetection(t *testing.T) {
 				},
 			},
 		},
+		{
+			name:     "detect Cloud Function resource on older runtimes",
+			envVars:  map[string]string{"FUNCTION_NAME": serviceName, "FUNCTION_REGION": regionID, "GOOGLE_CLOUD_PROJECT": projectID},
+			metaVars: map[string]string{"": there},
+			fsPaths:  map[string]string{"/sys/class/dmi/id/product_name": "Google Cloud Functions"},
+			want: &mrpb.MonitoredResource{
+				Type: "cloud_function",
+				Labels: map[string]string{
+					"project_id":    projectID,
+					"region":        regionID,
+					"function_name": serviceName,
+				},
+			},
+		},
+		{
+			name:     "detect Cloud Run job resource",
+			envVars:  map[string]string{"CLOUD_RUN_JOB": jobName, "CLOUD_RUN_EXECUTION": jobName + "-abcde"},
+			metaVars: map[string]string{"": there, "project/project-id": projectID, "instance/region": qualifiedRegionName},
+			fsPaths:  map[string]string{"/sys/class/dmi/id/product_name": "Google Cloud Run"},
+			want: &mrpb.MonitoredResource{
+				Type: "cloud_run_job",
+				Labels: map[string]string{
+					"project_id": projectID,
+					"location":   regionID,
+					"job_name":   jobName,
+				},
+			},
+		},
+		{
+			name:    "detect regional GKE resource",
+			envVars: map[string]string{"HOSTNAME": "custom-hostname", "POD_NAME": podName, "CONTAINER_NAME": containerName},
+			metaVars: map[string]string{"": there, "project/project-id": projectID, "instance/zone": qualifiedZoneName,
+				"instance/attributes/cluster-name": clusterName, "instance/attributes/cluster-location": regionID},
+			fsPaths: map[string]string{"/var/run/secrets/kubernetes.io/serviceaccount/namespace": namespaceName},
+			want: &mrpb.MonitoredResource{
+				Type: "k8s_container",
+				Labels: map[string]string{
+					"cluster_name":   clusterName,
+					"location":       regionID,
+					"project_id":     projectID,
+					"pod_name":       podName,
+					"namespace_name": namespaceName,
+					"container_name": containerName,
+				},
+			},
+		},
 		{
 			name:     "detect Compute Engine resource",
 			envVars:  map[string]string{},
@@ -0,0 +1,271 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build go1.21
+// +build go1.21
+
+package logging
+
+import (
+	"context"
+	"log/slog"
+	"runtime"
+
+	logpb "cloud.google.com/go/logging/apiv2/loggingpb"
+)
+
+// Attribute keys that a slog handler returned by Logger.SlogHandler maps to
+// entry fields, rather than to the payload, when they are not in a group.
+// They are the keys used for the same fields in structured logs written to
+// stdout.
+const (
+	slogLabelsKey       = "logging.googleapis.com/labels"
+	slogTraceKey        = "logging.googleapis.com/trace"
+	slogSpanIDKey       = "logging.googleapis.com/spanId"
+	slogTraceSampledKey = "logging.googleapis.com/trace_sampled"
+	slogHTTPRequestKey  = "httpRequest"
+)
+
+// SlogHandlerOptions are options for Logger.SlogHandler.
+type SlogHandlerOptions struct {
+	// Level is the minimum level of records that are logged. It defaults to
+	// slog.LevelInfo.
+	Level slog.Leveler
+
+	// AddSource sets the SourceLocation of each entry to the place where the
+	// record was logged.
+	AddSource bool
+
+	// Sync makes the handler write each entry with LogSync, so that Handle
+	// returns once the entry is written and reports any error. By default
+	// entries are buffered, as with Log.
+	Sync bool
+}
+
+// SlogHandler returns a slog.Handler that writes records to l. If opts is nil,
+// the default options are used.
+//
+// Each record becomes an entry with a JSON payload holding its message,
+// under the key "message", and its attributes. Groups become nested objects.
+// The level of the record is mapped to the entry's Severity: levels below
+// slog.LevelInfo are Debug, levels between slog.LevelInfo and slog.LevelWarn
+// are Notice, and levels above slog.LevelError are Critical.
+//
+// Some top-level attributes set entry fields instead of being added to the
+// payload: "logging.googleapis.com/labels", a group of strings, sets Labels;
+// "logging.googleapis.com/trace", "logging.googleapis.com/spanId" and
+// "logging.googleapis.com/trace_sampled" set Trace, SpanID and TraceSampled;
+// and "httpRequest", with a *HTTPRequest value, sets HTTPRequest. If there is
+// no trace attribute, the trace is taken from the context passed to the
+// slog.Logger method, as with LogSync.
+func (l *Logger) SlogHandler(opts *SlogHandlerOptions) slog.Handler {
+	h := &slogHandler{log: l.Log, parent: l.client.parent}
+	if opts != nil {
+		h.opts = *opts
+	}
+	if h.opts.Level == nil {
+		h.opts.Level = slog.LevelInfo
+	}
+	if h.opts.Sync {
+		h.logSync = l.LogSync
+	}
+	return h
+}
+
+// slogHandler is a slog.Handler that writes to a Logger.
+type slogHandler struct {
+	opts    SlogHandlerOptions
+	parent  string
+	log     func(Entry)
+	logSync func(context.Context, Entry) error // set if opts.Sync
+
+	// goas holds the groups and attributes added by WithGroup and WithAttrs,
+	// in order.
+	goas []groupOrAttrs
+}
+
+// groupOrAttrs is either a group name or a list of attributes.
+type groupOrAttrs struct {
+	group string
+	attrs []slog.Attr
+}
+
+func (h *slogHandler) Enabled(_ context.Context, level slog.Level) bool {
+	return level >= h.opts.Level.Level()
+}
+
+func (h *slogHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	return h.with(groupOrAttrs{group: name})
+}
+
+func (h *slogHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	if len(attrs) == 0 {
+		return h
+	}
+	return h.with(groupOrAttrs{attrs: attrs})
+}
+
+func (h *slogHandler) with(goa groupOrAttrs) *slogHandler {
+	h2 := *h
+	h2.goas = append(h.goas[:len(h.goas):len(h.goas)], goa)
+	return &h2
+}
+
+func (h *slogHandler) Handle(ctx context.Context, r slog.Record) error {
+	e := h.entry(r)
+	setTraceFromContext(ctx, &e, h.parent)
+	if h.logSync != nil {
+		return h.logSync(ctx, e)
+	}
+	h.log(e)
+	return nil
+}
+
+// entry converts a record to an entry.
+func (h *slogHandler) entry(r slog.Record) Entry {
+	e := Entry{
+		Timestamp: r.Time,
+		Severity:  slogLevelSeverity(r.Level),
+	}
+	payload := map[string]interface{}{"message": r.Message}
+	var group []string
+	for _, goa := range h.goas {
+		if goa.group != "" {
+			group = append(group, goa.group)
+			continue
+		}
+		for _, a := range goa.attrs {
+			addSlogAttr(&e, payload, group, a)
+		}
+	}
+	r.Attrs(func(a slog.Attr) bool {
+		addSlogAttr(&e, payload, group, a)
+		return true
+	})
+	e.Payload = payload
+
+	if h.opts.AddSource && r.PC != 0 {
+		frame, _ := runtime.CallersFrames([]uintptr{r.PC}).Next()
+		e.SourceLocation = &logpb.LogEntrySourceLocation{
+			File:     frame.File,
+			Line:     int64(frame.Line),
+			Function: frame.Function,
+		}
+	}
+	return e
+}
+
+// addSlogAttr adds a to the payload, within the given group, or sets the
+// entry field that it stands for.
+func addSlogAttr(e *Entry, payload map[string]interface{}, group []string, a slog.Attr) {
+	a.Value = a.Value.Resolve()
+	if a.Equal(slog.Attr{}) {
+		return
+	}
+	if len(group) == 0 && setSlogEntryField(e, a) {
+		return
+	}
+	if a.Value.Kind() == slog.KindGroup {
+		attrs := a.Value.Group()
+		if len(attrs) == 0 {
+			return
+		}
+		if a.Key != "" {
+			group = append(group[:len(group):len(group)], a.Key)
+		}
+		for _, ga := range attrs {
+			addSlogAttr(e, payload, group, ga)
+		}
+		return
+	}
+	m := payload
+	for _, g := range group {
+		sub, ok := m[g].(map[string]interface{})
+		if !ok {
+			sub = map[string]interface{}{}
+			m[g] = sub
+		}
+		m = sub
+	}
+	m[a.Key] = slogValue(a.Value)
+}
+
+// setSlogEntryField sets the entry field that a top-level attribute stands
+// for, if any, and reports whether it did.
+func setSlogEntryField(e *Entry, a slog.Attr) bool {
+	switch a.Key {
+	case slogLabelsKey:
+		if a.Value.Kind() != slog.KindGroup {
+			return false
+		}
+		if e.Labels == nil {
+			e.Labels = map[string]string{}
+		}
+		for _, la := range a.Value.Group() {
+			e.Labels[la.Key] = la.Value.Resolve().String()
+		}
+	case slogTraceKey:
+		e.Trace = a.Value.String()
+	case slogSpanIDKey:
+		e.SpanID = a.Value.String()
+	case slogTraceSampledKey:
+		if a.Value.Kind() != slog.KindBool {
+			return false
+		}
+		e.TraceSampled = a.Value.Bool()
+	case slogHTTPRequestKey:
+		req, ok := a.Value.Any().(*HTTPRequest)
+		if !ok {
+			return false
+		}
+		e.HTTPRequest = req
+	default:
+		return false
+	}
+	return true
+}
+
+// slogValue returns the JSON payload value of a resolved, non-group value.
+func slogValue(v slog.Value) interface{} {
+	switch v.Kind() {
+	case slog.KindDuration:
+		return v.Duration().String()
+	case slog.KindAny:
+		if err, ok := v.Any().(error); ok {
+			return err.Error()
+		}
+	}
+	return v.Any()
+}
+
+// slogLevelSeverity returns the Severity for a slog level.
+func slogLevelSeverity(level slog.Level) Severity {
+	switch {
+	case level < slog.LevelInfo:
+		return Debug
+	case level == slog.LevelInfo:
+		return Info
+	case level < slog.LevelWarn:
+		return Notice
+	case level < slog.LevelError:
+		return Warning
+	case level == slog.LevelError:
+		return Error
+	default:
+		return Critical
+	}
+}
@@ -0,0 +1,176 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build go1.21
+// +build go1.21
+
+package logging
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+	"testing"
+	"testing/slogtest"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/grpc/metadata"
+)
+
+// newTestSlogHandler returns a slog handler that appends its entries to es.
+func newTestSlogHandler(opts *SlogHandlerOptions, es *[]Entry) slog.Handler {
+	h := (&Logger{client: &Client{parent: "projects/P"}}).SlogHandler(opts).(*slogHandler)
+	h.log = func(e Entry) { *es = append(*es, e) }
+	return h
+}
+
+func TestSlogHandlerConformance(t *testing.T) {
+	var es []Entry
+	h := newTestSlogHandler(nil, &es)
+	results := func() []map[string]any {
+		var ms []map[string]any
+		for _, e := range es {
+			m := e.Payload.(map[string]interface{})
+			// slogtest expects the standard keys.
+			m[slog.MessageKey] = m["message"]
+			delete(m, "message")
+			m[slog.LevelKey] = e.Severity.String()
+			if !e.Timestamp.IsZero() {
+				m[slog.TimeKey] = e.Timestamp
+			}
+			ms = append(ms, m)
+		}
+		return ms
+	}
+	if err := slogtest.TestHandler(h, results); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestSlogHandlerEntry(t *testing.T) {
+	var es []Entry
+	h := newTestSlogHandler(nil, &es)
+	req := &HTTPRequest{Status: 200}
+	l := slog.New(h).With("logging.googleapis.com/trace", "projects/P/traces/T").WithGroup("g")
+	l.Warn("hello",
+		"n", 1,
+		"err", errors.New("oops"),
+		"d", time.Second,
+		slog.Group("s", "b", true),
+		slog.Group("empty"),
+		// Inside a group, special keys are ordinary attributes.
+		"logging.googleapis.com/spanId", "inner",
+	)
+	slog.New(h).Info("top",
+		slog.Group("logging.googleapis.com/labels", "a", "x", "b", 2),
+		"logging.googleapis.com/spanId", "S",
+		"logging.googleapis.com/trace_sampled", true,
+		"httpRequest", req,
+	)
+	if len(es) != 2 {
+		t.Fatalf("got %d entries, want 2", len(es))
+	}
+
+	e := es[0]
+	if e.Severity != Warning {
+		t.Errorf("got severity %v, want Warning", e.Severity)
+	}
+	if e.Trace != "projects/P/traces/T" {
+		t.Errorf("got trace %q", e.Trace)
+	}
+	if e.Timestamp.IsZero() {
+		t.Error("got zero timestamp")
+	}
+	wantPayload := map[string]interface{}{
+		"message": "hello",
+		"g": map[string]interface{}{
+			"n":                             int64(1),
+			"err":                           "oops",
+			"d":                             "1s",
+			"s":                             map[string]interface{}{"b": true},
+			"logging.googleapis.com/spanId": "inner",
+		},
+	}
+	if diff := cmp.Diff(wantPayload, e.Payload); diff != "" {
+		t.Errorf("payload: -want +got:\n%s", diff)
+	}
+
+	e = es[1]
+	if want := map[string]string{"a": "x", "b": "2"}; !cmp.Equal(e.Labels, want) {
+		t.Errorf("got labels %v, want %v", e.Labels, want)
+	}
+	if e.SpanID != "S" || !e.TraceSampled || e.HTTPRequest != req {
+		t.Errorf("got span %q, sampled %t, request %v", e.SpanID, e.TraceSampled, e.HTTPRequest)
+	}
+	if want := map[string]interface{}{"message": "top"}; !cmp.Equal(e.Payload, want) {
+		t.Errorf("got payload %v, want %v", e.Payload, want)
+	}
+}
+
+func TestSlogHandlerLevels(t *testing.T) {
+	var es []Entry
+	h := newTestSlogHandler(&SlogHandlerOptions{Level: slog.LevelDebug}, &es)
+	l := slog.New(h)
+	ctx := context.Background()
+	for _, level := range []slog.Level{
+		slog.LevelDebug, slog.LevelInfo, slog.LevelInfo + 2,
+		slog.LevelWarn, slog.LevelError, slog.LevelError + 4,
+	} {
+		l.Log(ctx, level, "msg")
+	}
+	var got []Severity
+	for _, e := range es {
+		got = append(got, e.Severity)
+	}
+	if want := []Severity{Debug, Info, Notice, Warning, Error, Critical}; !cmp.Equal(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+
+	if newTestSlogHandler(nil, &es).Enabled(ctx, slog.LevelDebug) {
+		t.Error("debug enabled by default")
+	}
+}
+
+func TestSlogHandlerSource(t *testing.T) {
+	var es []Entry
+	slog.New(newTestSlogHandler(&SlogHandlerOptions{AddSource: true}, &es)).Info("msg")
+	sl := es[0].SourceLocation
+	if sl == nil {
+		t.Fatal("got no source location")
+	}
+	if !strings.HasSuffix(sl.File, "slog_test.go") || sl.Line == 0 ||
+		!strings.HasSuffix(sl.Function, "TestSlogHandlerSource") {
+		t.Errorf("got source location %v", sl)
+	}
+}
+
+func TestSlogHandlerTraceFromContext(t *testing.T) {
+	var es []Entry
+	l := slog.New(newTestSlogHandler(nil, &es))
+	ctx := metadata.NewIncomingContext(context.Background(),
+		metadata.Pairs("x-cloud-trace-context", "105445aa7843bc8bf206b12000100012/1;o=1"))
+	l.InfoContext(ctx, "from context")
+	l.InfoContext(ctx, "from attribute", "logging.googleapis.com/trace", "projects/P/traces/T")
+	if got, want := es[0].Trace, "projects/P/traces/105445aa7843bc8bf206b12000100012"; got != want {
+		t.Errorf("got trace %q, want %q", got, want)
+	}
+	if es[0].SpanID != "1" || !es[0].TraceSampled {
+		t.Errorf("got span %q, sampled %t", es[0].SpanID, es[0].TraceSampled)
+	}
+	if got, want := es[1].Trace, "projects/P/traces/T"; got != want {
+		t.Errorf("got trace %q, want %q", got, want)
+	}
+}
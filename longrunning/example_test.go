@@ -70,6 +70,30 @@ func ExampleOperation_Wait() {
 	// 2009-11-10T23:00:00Z
 }
 
+func ExampleWait() {
+	// Complex computation, might take a long time.
+	op, err := bestMomentInHistory()
+	if err != nil {
+		// TODO: Handle err.
+	}
+	ts, err := Wait[timestamp.Timestamp, duration.Duration](context.TODO(), op, &WaitConfig[duration.Duration]{
+		Progress: func(meta *duration.Duration) {
+			d, err := ptypes.Duration(meta)
+			if err != nil {
+				// TODO: Handle err.
+			}
+			fmt.Println("estimated duration:", d)
+		},
+	})
+	if err != nil {
+		// TODO: Handle err.
+	}
+	fmt.Println(ptypes.TimestampString(ts))
+	// Output:
+	// estimated duration: 1h0m0s
+	// 2009-11-10T23:00:00Z
+}
+
 func ExampleOperation_Metadata() {
 	op, err := bestMomentInHistory()
 	if err != nil {
@@ -177,3 +177,83 @@ func (op *Operation) Cancel(ctx context.Context, opts ...gax.CallOption) error {
 func (op *Operation) Delete(ctx context.Context, opts ...gax.CallOption) error {
 	return op.c.DeleteOperation(ctx, &pb.DeleteOperationRequest{Name: op.Name()}, opts...)
 }
+
+// WaitConfig configures Wait.
+type WaitConfig[M any] struct {
+	// Backoff determines the intervals between polls. Zero Initial and Max
+	// select one second and DefaultWaitInterval.
+	Backoff gax.Backoff
+
+	// Progress, if not nil, is called with the metadata of the operation
+	// each time it is found to have changed, including after the first poll.
+	Progress func(*M)
+
+	// CallOptions are used for the GetOperation calls.
+	CallOptions []gax.CallOption
+}
+
+// Wait blocks until the operation is completed, and returns its response,
+// decoded into a T. The metadata of the operation is decoded into an M, and
+// passed to config.Progress as it changes. config may be nil to use the
+// defaults. The type parameters besides T and M are inferred:
+//
+//	resp, err := longrunning.Wait[pb.Response, pb.Metadata](ctx, op, &longrunning.WaitConfig[pb.Metadata]{
+//		Progress: func(m *pb.Metadata) { log.Printf("progress: %v", m) },
+//	})
+//
+// See documentation of Poll for error-handling information.
+func Wait[T, M any, PT interface {
+	*T
+	proto.Message
+}, PM interface {
+	*M
+	proto.Message
+}](ctx context.Context, op *Operation, config *WaitConfig[M]) (*T, error) {
+	var c WaitConfig[M]
+	if config != nil {
+		c = *config
+	}
+	bo := c.Backoff
+	if bo.Initial == 0 {
+		bo.Initial = 1 * time.Second
+	}
+	if bo.Max == 0 {
+		bo.Max = DefaultWaitInterval
+	}
+	if bo.Max < bo.Initial {
+		bo.Max = bo.Initial
+	}
+	return waitTyped[T, M, PT, PM](ctx, op, &bo, c.Progress, gax.Sleep, c.CallOptions...)
+}
+
+// waitTyped implements Wait, taking a sleeper argument for testing.
+func waitTyped[T, M any, PT interface {
+	*T
+	proto.Message
+}, PM interface {
+	*M
+	proto.Message
+}](ctx context.Context, op *Operation, bo *gax.Backoff, progress func(*M), sl sleeper, opts ...gax.CallOption) (*T, error) {
+	resp := new(T)
+	var lastMeta proto.Message
+	for {
+		err := op.Poll(ctx, PT(resp), opts...)
+		if meta := op.proto.GetMetadata(); progress != nil && meta != nil && (lastMeta == nil || !proto.Equal(meta, lastMeta)) {
+			m := new(M)
+			if err := op.Metadata(PM(m)); err != nil {
+				return nil, err
+			}
+			lastMeta = meta
+			progress(m)
+		}
+		if err != nil {
+			return nil, err
+		}
+		if op.Done() {
+			return resp, nil
+		}
+		if err := sl(ctx, bo.Pause()); err != nil {
+			return nil, err
+		}
+	}
+}
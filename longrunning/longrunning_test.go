@@ -226,3 +226,82 @@ func TestDeleteReturnsError(t *testing.T) {
 		t.Errorf("cancel, got error %s, want %s", got, want)
 	}
 }
+
+func TestWaitTyped(t *testing.T) {
+	anyOf := func(d time.Duration) *anypb.Any {
+		a, err := ptypes.MarshalAny(ptypes.DurationProto(d))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return a
+	}
+	s := &getterService{
+		results: []*pb.Operation{
+			{Name: "foo", Metadata: anyOf(1 * time.Second)},
+			{Name: "foo", Metadata: anyOf(1 * time.Second)},
+			{Name: "foo", Metadata: anyOf(2 * time.Second)},
+			{
+				Name:     "foo",
+				Done:     true,
+				Metadata: anyOf(3 * time.Second),
+				Result:   &pb.Operation_Response{Response: anyOf(42 * time.Second)},
+			},
+		},
+	}
+	op := &Operation{
+		c:     s,
+		proto: &pb.Operation{Name: "foo"},
+	}
+
+	var progress []time.Duration
+	bo := gax.Backoff{Initial: time.Second, Max: 3 * time.Second}
+	resp, err := waitTyped[duration.Duration, duration.Duration](context.Background(), op, &bo, func(m *duration.Duration) {
+		d, err := ptypes.Duration(m)
+		if err != nil {
+			t.Fatal(err)
+		}
+		progress = append(progress, d)
+	}, s.sleeper())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, err := ptypes.Duration(resp); err != nil || got != 42*time.Second {
+		t.Errorf("response: got %v, %v, want 42s", got, err)
+	}
+	// The unchanged metadata of the second poll is not reported.
+	want := []time.Duration{1 * time.Second, 2 * time.Second, 3 * time.Second}
+	if len(progress) != len(want) {
+		t.Fatalf("progress: got %v, want %v", progress, want)
+	}
+	for i := range want {
+		if progress[i] != want[i] {
+			t.Errorf("progress: got %v, want %v", progress, want)
+		}
+	}
+	if len(s.getTimes) != 4 {
+		t.Errorf("got %d polls, want 4", len(s.getTimes))
+	}
+}
+
+func TestWaitTypedError(t *testing.T) {
+	s := &getterService{
+		results: []*pb.Operation{
+			{
+				Name:   "foo",
+				Done:   true,
+				Result: &pb.Operation_Error{Error: &rpcstatus.Status{Code: int32(codes.NotFound), Message: "not found"}},
+			},
+		},
+	}
+	op := &Operation{
+		c:     s,
+		proto: &pb.Operation{Name: "foo"},
+	}
+	resp, err := Wait[duration.Duration, duration.Duration](context.Background(), op, nil)
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("got %v, want NotFound", err)
+	}
+	if resp != nil {
+		t.Errorf("got response %v, want nil", resp)
+	}
+}
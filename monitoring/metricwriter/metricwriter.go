@@ -0,0 +1,482 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package metricwriter writes custom metrics to Cloud Monitoring.
+//
+// A Writer accepts counter, gauge and histogram updates, aggregates them
+// locally, and writes the aggregated values of the series that were updated
+// once per period, in batched CreateTimeSeries calls. It writes each time
+// series at most once every MinPeriod, as Cloud Monitoring requires.
+//
+//	mc, err := monitoring.NewMetricClient(ctx)
+//	if err != nil {
+//		// TODO: Handle error.
+//	}
+//	defer mc.Close()
+//	w := metricwriter.NewWriter(mc, "my-project", metricwriter.Config{})
+//	defer w.Close()
+//
+//	requests := w.Counter("custom.googleapis.com/requests")
+//	latency := w.Histogram("custom.googleapis.com/latency_ms", []float64{10, 100, 1000})
+//	...
+//	requests.Add(map[string]string{"method": "GET"}, 1)
+//	latency.Record(map[string]string{"method": "GET"}, 42)
+package metricwriter // import "cloud.google.com/go/monitoring/metricwriter"
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	monitoring "cloud.google.com/go/monitoring/apiv3/v2"
+	"cloud.google.com/go/monitoring/apiv3/v2/monitoringpb"
+	"github.com/googleapis/gax-go/v2"
+	distributionpb "google.golang.org/genproto/googleapis/api/distribution"
+	metricpb "google.golang.org/genproto/googleapis/api/metric"
+	monitoredrespb "google.golang.org/genproto/googleapis/api/monitoredres"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	// DefaultPeriod is the default Config.Period.
+	DefaultPeriod = time.Minute
+
+	// MinPeriod is the shortest interval between two writes of the same time
+	// series that Cloud Monitoring accepts.
+	MinPeriod = 10 * time.Second
+
+	// maxBatchSize is the maximum number of time series in a
+	// CreateTimeSeries request.
+	maxBatchSize = 200
+)
+
+// Config configures a Writer. The zero value of each field selects its
+// default.
+type Config struct {
+	// Period is how often the updated time series are written. The default
+	// is DefaultPeriod. Shorter periods than MinPeriod are raised to
+	// MinPeriod.
+	Period time.Duration
+
+	// Resource is the monitored resource that the time series are written
+	// for. The default is the "global" resource of the Writer's project.
+	Resource *monitoredrespb.MonitoredResource
+
+	// OnError is the function to call if a background write fails, or an
+	// update is dropped because it does not match its metric. By default,
+	// errors are logged.
+	OnError func(err error)
+}
+
+// timeSeriesCreator is the part of the metric client used by Writer.
+type timeSeriesCreator interface {
+	CreateTimeSeries(context.Context, *monitoringpb.CreateTimeSeriesRequest, ...gax.CallOption) error
+}
+
+// A Writer aggregates metric updates and writes them to Cloud Monitoring.
+//
+// Counters and histograms are written as cumulative metrics, whose start time
+// is the time of their first update by the Writer; gauges are written as
+// gauge metrics. A write that fails is not retried, but the series is
+// written again after its next update.
+//
+// A Writer is safe to use concurrently. Call Close when you are done with it,
+// to write the last updates and stop the background writes.
+type Writer struct {
+	client   timeSeriesCreator
+	project  string
+	resource *monitoredrespb.MonitoredResource
+	onError  func(error)
+	now      func() time.Time
+	sleep    func(time.Duration)
+
+	mu     sync.Mutex
+	series map[string]*series
+
+	stop      chan struct{}
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+// series is the aggregated state of a time series.
+type series struct {
+	metricType string
+	labels     map[string]string
+	kind       metricpb.MetricDescriptor_MetricKind
+	valueType  metricpb.MetricDescriptor_ValueType
+	start      time.Time // When the series was first updated.
+	lastWrite  time.Time // When the series was last written.
+	dirty      bool      // Whether the series was updated since lastWrite.
+	writing    bool      // Whether a write of the series is in progress.
+	updates    int64     // The number of updates of the series.
+
+	count int64   // For counters.
+	value float64 // For gauges.
+	dist  *distribution
+}
+
+// distribution is the aggregated state of a histogram series.
+type distribution struct {
+	bounds       []float64
+	count        int64
+	mean         float64
+	sumOfSquares float64 // The sum of squared deviations from mean.
+	buckets      []int64
+}
+
+// NewWriter returns a Writer that writes to project with mc, and starts
+// writing in the background. The caller remains responsible for closing mc,
+// after closing the Writer.
+func NewWriter(mc *monitoring.MetricClient, project string, config Config) *Writer {
+	return newWriter(mc, project, config, true)
+}
+
+// newWriter returns a Writer, which writes in the background if background
+// is true.
+func newWriter(client timeSeriesCreator, project string, config Config, background bool) *Writer {
+	w := &Writer{
+		client:   client,
+		project:  project,
+		resource: config.Resource,
+		onError:  config.OnError,
+		now:      time.Now,
+		sleep:    time.Sleep,
+		series:   map[string]*series{},
+		stop:     make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+	if w.resource == nil {
+		w.resource = &monitoredrespb.MonitoredResource{
+			Type:   "global",
+			Labels: map[string]string{"project_id": project},
+		}
+	}
+	if w.onError == nil {
+		w.onError = func(err error) { log.Print(err) }
+	}
+	if !background {
+		close(w.done)
+		return w
+	}
+	period := DefaultPeriod
+	if config.Period > 0 {
+		period = config.Period
+	}
+	if period < MinPeriod {
+		period = MinPeriod
+	}
+	go w.writeLoop(period)
+	return w
+}
+
+func (w *Writer) writeLoop(period time.Duration) {
+	defer close(w.done)
+	t := time.NewTicker(period)
+	defer t.Stop()
+	for {
+		select {
+		case <-w.stop:
+			return
+		case <-t.C:
+			if _, err := w.flush(context.Background()); err != nil {
+				w.onError(err)
+			}
+		}
+	}
+}
+
+// Flush writes the series that were updated since they were last written,
+// except those that were written less than MinPeriod ago.
+func (w *Writer) Flush(ctx context.Context) error {
+	_, err := w.flush(ctx)
+	return err
+}
+
+// flush is like Flush, and also returns how long to wait until all the
+// updated series that it could not write can be written, or 0 if there are
+// none.
+func (w *Writer) flush(ctx context.Context) (wait time.Duration, err error) {
+	w.mu.Lock()
+	now := w.now()
+	var (
+		ss      []*series
+		updates []int64 // the updates of ss when their time series were taken
+		ts      []*monitoringpb.TimeSeries
+	)
+	for _, s := range w.series {
+		if !s.dirty || s.writing {
+			continue
+		}
+		if !s.lastWrite.IsZero() {
+			if d := s.lastWrite.Add(MinPeriod).Sub(now); d > 0 {
+				if d > wait {
+					wait = d
+				}
+				continue
+			}
+		}
+		ts = append(ts, s.timeSeries(w.resource, now))
+		ss = append(ss, s)
+		updates = append(updates, s.updates)
+		s.writing = true
+	}
+	w.mu.Unlock()
+
+	for len(ts) > 0 {
+		n := len(ts)
+		if n > maxBatchSize {
+			n = maxBatchSize
+		}
+		req := &monitoringpb.CreateTimeSeriesRequest{
+			Name:       "projects/" + w.project,
+			TimeSeries: ts[:n],
+		}
+		e := w.client.CreateTimeSeries(ctx, req)
+		if e != nil && err == nil {
+			err = fmt.Errorf("metricwriter: writing %d time series: %w", n, e)
+		}
+		// The series of a failed batch stay dirty, so that they are written
+		// by the next flush.
+		w.mu.Lock()
+		for i, s := range ss[:n] {
+			s.writing = false
+			if e == nil {
+				s.lastWrite = now
+				s.dirty = s.updates != updates[i]
+			}
+		}
+		w.mu.Unlock()
+		ss, updates, ts = ss[n:], updates[n:], ts[n:]
+	}
+	return wait, err
+}
+
+// Close writes the series that were updated since they were last written,
+// waiting up to MinPeriod for those that were written recently, and stops
+// the background writes. Close is idempotent; it returns nil after the first
+// call.
+func (w *Writer) Close() error {
+	var err error
+	w.closeOnce.Do(func() {
+		close(w.stop)
+		<-w.done
+		var wait time.Duration
+		wait, err = w.flush(context.Background())
+		if wait > 0 {
+			w.sleep(wait)
+			if _, e := w.flush(context.Background()); err == nil {
+				err = e
+			}
+		}
+	})
+	return err
+}
+
+// update applies f to the series of metricType with labels, creating it with
+// kind and valueType if needed, and marks it as updated. Updates that f or
+// the metric's kind and value type reject are dropped, and reported to
+// OnError.
+func (w *Writer) update(metricType string, labels map[string]string, kind metricpb.MetricDescriptor_MetricKind, valueType metricpb.MetricDescriptor_ValueType, f func(*series) error) {
+	key := seriesKey(metricType, labels)
+	err := func() error {
+		w.mu.Lock()
+		defer w.mu.Unlock()
+		s, ok := w.series[key]
+		if !ok {
+			l := make(map[string]string, len(labels))
+			for k, v := range labels {
+				l[k] = v
+			}
+			s = &series{metricType: metricType, labels: l, kind: kind, valueType: valueType, start: w.now()}
+			w.series[key] = s
+		} else if s.kind != kind || s.valueType != valueType {
+			return fmt.Errorf("%s is a %s %s metric, not a %s %s one", metricType, s.kind, s.valueType, kind, valueType)
+		}
+		if err := f(s); err != nil {
+			return err
+		}
+		s.dirty = true
+		s.updates++
+		return nil
+	}()
+	if err != nil {
+		w.onError(fmt.Errorf("metricwriter: dropping update: %w", err))
+	}
+}
+
+// seriesKey returns a key that identifies a time series.
+func seriesKey(metricType string, labels map[string]string) string {
+	keys := make([]string, 0, len(labels))
+	for k := range labels {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	var b strings.Builder
+	b.WriteString(metricType)
+	for _, k := range keys {
+		b.WriteByte(0)
+		b.WriteString(k)
+		b.WriteByte(0)
+		b.WriteString(labels[k])
+	}
+	return b.String()
+}
+
+// timeSeries returns the current value of s as a point ending at end.
+func (s *series) timeSeries(resource *monitoredrespb.MonitoredResource, end time.Time) *monitoringpb.TimeSeries {
+	interval := &monitoringpb.TimeInterval{EndTime: timestamppb.New(end)}
+	var value *monitoringpb.TypedValue
+	switch s.valueType {
+	case metricpb.MetricDescriptor_DOUBLE:
+		value = &monitoringpb.TypedValue{Value: &monitoringpb.TypedValue_DoubleValue{DoubleValue: s.value}}
+	case metricpb.MetricDescriptor_DISTRIBUTION:
+		value = &monitoringpb.TypedValue{Value: &monitoringpb.TypedValue_DistributionValue{DistributionValue: s.dist.proto()}}
+	default:
+		value = &monitoringpb.TypedValue{Value: &monitoringpb.TypedValue_Int64Value{Int64Value: s.count}}
+	}
+	if s.kind == metricpb.MetricDescriptor_CUMULATIVE {
+		// The start time of a cumulative point must be before its end time.
+		start := s.start
+		if !start.Before(end) {
+			start = end.Add(-time.Millisecond)
+		}
+		interval.StartTime = timestamppb.New(start)
+	}
+	return &monitoringpb.TimeSeries{
+		Metric:     &metricpb.Metric{Type: s.metricType, Labels: s.labels},
+		Resource:   resource,
+		MetricKind: s.kind,
+		ValueType:  s.valueType,
+		Points:     []*monitoringpb.Point{{Interval: interval, Value: value}},
+	}
+}
+
+func (d *distribution) add(v float64) {
+	d.count++
+	delta := v - d.mean
+	d.mean += delta / float64(d.count)
+	d.sumOfSquares += delta * (v - d.mean)
+	// Bucket i, for i > 0, holds the values in [bounds[i-1], bounds[i]).
+	d.buckets[sort.Search(len(d.bounds), func(i int) bool { return d.bounds[i] > v })]++
+}
+
+func (d *distribution) proto() *distributionpb.Distribution {
+	return &distributionpb.Distribution{
+		Count:                 d.count,
+		Mean:                  d.mean,
+		SumOfSquaredDeviation: d.sumOfSquares,
+		BucketOptions: &distributionpb.Distribution_BucketOptions{
+			Options: &distributionpb.Distribution_BucketOptions_ExplicitBuckets{
+				ExplicitBuckets: &distributionpb.Distribution_BucketOptions_Explicit{Bounds: d.bounds},
+			},
+		},
+		BucketCounts: append([]int64(nil), d.buckets...),
+	}
+}
+
+// A Counter is a cumulative INT64 metric.
+type Counter struct {
+	w          *Writer
+	metricType string
+}
+
+// Counter returns a Counter for the metric of type metricType, such as
+// "custom.googleapis.com/requests".
+func (w *Writer) Counter(metricType string) *Counter {
+	return &Counter{w: w, metricType: metricType}
+}
+
+// Add adds delta to the series of the counter with labels. It panics if
+// delta is negative.
+func (c *Counter) Add(labels map[string]string, delta int64) {
+	if delta < 0 {
+		panic(fmt.Sprintf("metricwriter: negative delta %d for counter %s", delta, c.metricType))
+	}
+	c.w.update(c.metricType, labels, metricpb.MetricDescriptor_CUMULATIVE, metricpb.MetricDescriptor_INT64, func(s *series) error {
+		s.count += delta
+		return nil
+	})
+}
+
+// A Gauge is a gauge DOUBLE metric.
+type Gauge struct {
+	w          *Writer
+	metricType string
+}
+
+// Gauge returns a Gauge for the metric of type metricType.
+func (w *Writer) Gauge(metricType string) *Gauge {
+	return &Gauge{w: w, metricType: metricType}
+}
+
+// Set sets the series of the gauge with labels to value. Only the last value
+// set in each period is written.
+func (g *Gauge) Set(labels map[string]string, value float64) {
+	g.w.update(g.metricType, labels, metricpb.MetricDescriptor_GAUGE, metricpb.MetricDescriptor_DOUBLE, func(s *series) error {
+		s.value = value
+		return nil
+	})
+}
+
+// A Histogram is a cumulative DISTRIBUTION metric with explicit buckets.
+type Histogram struct {
+	w          *Writer
+	metricType string
+	bounds     []float64
+}
+
+// Histogram returns a Histogram for the metric of type metricType, whose
+// buckets have the given bounds. The first bucket holds the values below
+// bounds[0], and the last the values from bounds[len(bounds)-1] up. It panics
+// if bounds is empty or not strictly increasing.
+func (w *Writer) Histogram(metricType string, bounds []float64) *Histogram {
+	if len(bounds) == 0 {
+		panic(fmt.Sprintf("metricwriter: no bucket bounds for histogram %s", metricType))
+	}
+	for i := 1; i < len(bounds); i++ {
+		if bounds[i] <= bounds[i-1] {
+			panic(fmt.Sprintf("metricwriter: bucket bounds for histogram %s are not strictly increasing", metricType))
+		}
+	}
+	return &Histogram{w: w, metricType: metricType, bounds: append([]float64(nil), bounds...)}
+}
+
+// Record adds value to the series of the histogram with labels.
+func (h *Histogram) Record(labels map[string]string, value float64) {
+	h.w.update(h.metricType, labels, metricpb.MetricDescriptor_CUMULATIVE, metricpb.MetricDescriptor_DISTRIBUTION, func(s *series) error {
+		if s.dist == nil {
+			s.dist = &distribution{bounds: h.bounds, buckets: make([]int64, len(h.bounds)+1)}
+		} else if !equalBounds(s.dist.bounds, h.bounds) {
+			return fmt.Errorf("histogram %s has bucket bounds %v, not %v", h.metricType, s.dist.bounds, h.bounds)
+		}
+		s.dist.add(value)
+		return nil
+	})
+}
+
+func equalBounds(a, b []float64) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
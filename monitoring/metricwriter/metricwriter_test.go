@@ -0,0 +1,242 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metricwriter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sync"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/monitoring/apiv3/v2/monitoringpb"
+	"github.com/googleapis/gax-go/v2"
+	metricpb "google.golang.org/genproto/googleapis/api/metric"
+)
+
+// fakeCreator records the CreateTimeSeries requests.
+type fakeCreator struct {
+	mu   sync.Mutex
+	reqs []*monitoringpb.CreateTimeSeriesRequest
+	err  error
+}
+
+func (f *fakeCreator) CreateTimeSeries(_ context.Context, req *monitoringpb.CreateTimeSeriesRequest, _ ...gax.CallOption) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.reqs = append(f.reqs, req)
+	return f.err
+}
+
+// written returns the time series written since the last call, by metric
+// type and "method" label.
+func (f *fakeCreator) written() map[string]*monitoringpb.TimeSeries {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	m := map[string]*monitoringpb.TimeSeries{}
+	for _, req := range f.reqs {
+		for _, ts := range req.TimeSeries {
+			m[ts.Metric.Type+"/"+ts.Metric.Labels["method"]] = ts
+		}
+	}
+	f.reqs = nil
+	return m
+}
+
+// newTestWriter returns a Writer without background writes, whose clock is
+// advanced by the returned function, and the errors that it reports.
+func newTestWriter(f *fakeCreator) (*Writer, func(time.Duration), *[]error) {
+	var errs []error
+	w := newWriter(f, "p", Config{OnError: func(err error) { errs = append(errs, err) }}, false)
+	now := time.Unix(1000, 0)
+	w.now = func() time.Time { return now }
+	advance := func(d time.Duration) { now = now.Add(d) }
+	w.sleep = advance
+	return w, advance, &errs
+}
+
+func TestWriter(t *testing.T) {
+	f := &fakeCreator{}
+	w, advance, errs := newTestWriter(f)
+	ctx := context.Background()
+	get := map[string]string{"method": "GET"}
+	put := map[string]string{"method": "PUT"}
+
+	requests := w.Counter("requests")
+	requests.Add(get, 1)
+	requests.Add(get, 2)
+	requests.Add(put, 5)
+	queue := w.Gauge("queue")
+	queue.Set(get, 3)
+	queue.Set(get, 4)
+	latency := w.Histogram("latency", []float64{10, 100})
+	for _, v := range []float64{1, 10, 50, 200} {
+		latency.Record(get, v)
+	}
+	advance(time.Minute)
+	if err := w.Flush(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	got := f.written()
+	if len(got) != 4 {
+		t.Fatalf("got %d time series, want 4", len(got))
+	}
+	if v := got["requests/GET"].Points[0].Value.GetInt64Value(); v != 3 {
+		t.Errorf("requests/GET: got %d, want 3", v)
+	}
+	if v := got["requests/PUT"].Points[0].Value.GetInt64Value(); v != 5 {
+		t.Errorf("requests/PUT: got %d, want 5", v)
+	}
+	ts := got["queue/GET"]
+	if v := ts.Points[0].Value.GetDoubleValue(); v != 4 {
+		t.Errorf("queue/GET: got %v, want 4", v)
+	}
+	if ts.MetricKind != metricpb.MetricDescriptor_GAUGE || ts.Points[0].Interval.StartTime != nil {
+		t.Errorf("queue/GET: got kind %v and start time %v, want a gauge without start time", ts.MetricKind, ts.Points[0].Interval.StartTime)
+	}
+	d := got["latency/GET"].Points[0].Value.GetDistributionValue()
+	if d.Count != 4 || d.Mean != 65.25 {
+		t.Errorf("latency/GET: got count %d and mean %v, want 4 and 65.25", d.Count, d.Mean)
+	}
+	// The squared deviations from 65.25 of 1, 10, 50 and 200.
+	if want := 4128.0625 + 3052.5625 + 232.5625 + 18157.5625; math.Abs(d.SumOfSquaredDeviation-want) > 1e-9 {
+		t.Errorf("latency/GET: got sum of squared deviations %v, want %v", d.SumOfSquaredDeviation, want)
+	}
+	if want := []int64{1, 2, 1}; fmt.Sprint(d.BucketCounts) != fmt.Sprint(want) {
+		t.Errorf("latency/GET: got bucket counts %v, want %v", d.BucketCounts, want)
+	}
+	start := got["requests/GET"].Points[0].Interval.StartTime.AsTime()
+	if want := time.Unix(1000, 0); !start.Equal(want) {
+		t.Errorf("got start time %v, want %v", start, want)
+	}
+
+	// Only the updated series are written, with their cumulative values.
+	requests.Add(get, 1)
+	advance(time.Minute)
+	if err := w.Flush(ctx); err != nil {
+		t.Fatal(err)
+	}
+	got = f.written()
+	if len(got) != 1 {
+		t.Fatalf("got %d time series, want 1", len(got))
+	}
+	if v := got["requests/GET"].Points[0].Value.GetInt64Value(); v != 4 {
+		t.Errorf("requests/GET: got %d, want 4", v)
+	}
+	if start2 := got["requests/GET"].Points[0].Interval.StartTime.AsTime(); !start2.Equal(start) {
+		t.Errorf("got start time %v, want %v", start2, start)
+	}
+	if len(*errs) != 0 {
+		t.Errorf("got errors %v", *errs)
+	}
+}
+
+func TestWriterMinPeriod(t *testing.T) {
+	f := &fakeCreator{}
+	w, advance, _ := newTestWriter(f)
+	ctx := context.Background()
+	c := w.Counter("c")
+
+	c.Add(nil, 1)
+	if err := w.Flush(ctx); err != nil {
+		t.Fatal(err)
+	}
+	c.Add(nil, 1)
+	advance(MinPeriod / 2)
+	if err := w.Flush(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if got := len(f.written()); got != 1 {
+		t.Fatalf("got %d time series, want 1", got)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	got := f.written()
+	if len(got) != 1 {
+		t.Fatalf("after Close: got %d time series, want 1", len(got))
+	}
+	if v := got["c/"].Points[0].Value.GetInt64Value(); v != 2 {
+		t.Errorf("after Close: got %d, want 2", v)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestWriterBatches(t *testing.T) {
+	f := &fakeCreator{}
+	w, _, _ := newTestWriter(f)
+	c := w.Counter("c")
+	for i := 0; i < 2*maxBatchSize+1; i++ {
+		c.Add(map[string]string{"method": fmt.Sprint(i)}, 1)
+	}
+	if err := w.Flush(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	var sizes []int
+	for _, req := range f.reqs {
+		sizes = append(sizes, len(req.TimeSeries))
+		if req.Name != "projects/p" {
+			t.Errorf("got name %q, want %q", req.Name, "projects/p")
+		}
+	}
+	if want := []int{maxBatchSize, maxBatchSize, 1}; fmt.Sprint(sizes) != fmt.Sprint(want) {
+		t.Errorf("got batch sizes %v, want %v", sizes, want)
+	}
+}
+
+func TestWriterErrors(t *testing.T) {
+	f := &fakeCreator{err: errors.New("quota")}
+	w, _, errs := newTestWriter(f)
+	w.Counter("m").Add(nil, 1)
+	w.Gauge("m").Set(nil, 1)
+	w.Histogram("h", []float64{1}).Record(nil, 1)
+	w.Histogram("h", []float64{2}).Record(nil, 1)
+	if len(*errs) != 2 {
+		t.Errorf("got errors %v, want 2 dropped updates", *errs)
+	}
+	if err := w.Flush(context.Background()); err == nil {
+		t.Error("got nil, want error")
+	}
+
+	// The series that failed to be written are written by the next flush,
+	// without waiting for MinPeriod.
+	f.written()
+	f.err = nil
+	if err := w.Flush(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if got := len(f.written()); got != 2 {
+		t.Errorf("got %d series written after the error, want 2", got)
+	}
+}
+
+func TestHistogramBadBounds(t *testing.T) {
+	w, _, _ := newTestWriter(&fakeCreator{})
+	for _, bounds := range [][]float64{nil, {1, 1}, {2, 1}} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("%v: got no panic", bounds)
+				}
+			}()
+			w.Histogram("h", bounds)
+		}()
+	}
+}
@@ -0,0 +1,203 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pubsub
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+// Compression is an algorithm used to compress the data of published messages.
+type Compression int
+
+const (
+	// CompressionNone disables compression. This is the default.
+	CompressionNone Compression = iota
+	// CompressionGzip compresses message data with gzip.
+	CompressionGzip
+	// CompressionZstd compresses message data with Zstandard.
+	CompressionZstd
+)
+
+const (
+	// compressionAttr is the message attribute recording the algorithm used
+	// to compress a message's data.
+	compressionAttr = "googclient_compression"
+
+	// maxDecompressedBytes is the largest size of the decompressed data of a
+	// message, that of the largest message which can be published. Larger
+	// data, as of a decompression bomb, is not decompressed.
+	maxDecompressedBytes = int(MaxPublishRequestBytes)
+)
+
+func (c Compression) String() string {
+	switch c {
+	case CompressionNone:
+		return "none"
+	case CompressionGzip:
+		return "gzip"
+	case CompressionZstd:
+		return "zstd"
+	}
+	return fmt.Sprintf("Compression(%d)", int(c))
+}
+
+func parseCompression(s string) (Compression, error) {
+	switch s {
+	case "gzip":
+		return CompressionGzip, nil
+	case "zstd":
+		return CompressionZstd, nil
+	}
+	return CompressionNone, fmt.Errorf("pubsub: unknown compression %q", s)
+}
+
+var errDecompressedSize = fmt.Errorf("pubsub: decompressed data is larger than %d bytes", maxDecompressedBytes)
+
+var (
+	zstdOnce    sync.Once
+	zstdEncoder *zstd.Encoder
+	zstdDecoder *zstd.Decoder
+	zstdErr     error
+)
+
+// zstdCodec returns the shared Zstandard encoder and decoder. Both are safe
+// for concurrent use with EncodeAll and DecodeAll.
+func zstdCodec() (*zstd.Encoder, *zstd.Decoder, error) {
+	zstdOnce.Do(func() {
+		zstdEncoder, zstdErr = zstd.NewWriter(nil)
+		if zstdErr != nil {
+			return
+		}
+		zstdDecoder, zstdErr = zstd.NewReader(nil, zstd.WithDecoderMaxMemory(uint64(maxDecompressedBytes)))
+	})
+	return zstdEncoder, zstdDecoder, zstdErr
+}
+
+func compress(c Compression, data []byte) ([]byte, error) {
+	switch c {
+	case CompressionGzip:
+		var buf bytes.Buffer
+		w := gzip.NewWriter(&buf)
+		if _, err := w.Write(data); err != nil {
+			return nil, err
+		}
+		if err := w.Close(); err != nil {
+			return nil, err
+		}
+		return buf.Bytes(), nil
+	case CompressionZstd:
+		enc, _, err := zstdCodec()
+		if err != nil {
+			return nil, err
+		}
+		return enc.EncodeAll(data, nil), nil
+	}
+	return nil, fmt.Errorf("pubsub: unsupported compression %v", c)
+}
+
+// decompress returns the decompressed data, or an error if it is larger than
+// maxDecompressedBytes.
+func decompress(c Compression, data []byte) ([]byte, error) {
+	switch c {
+	case CompressionGzip:
+		r, err := gzip.NewReader(bytes.NewReader(data))
+		if err != nil {
+			return nil, err
+		}
+		defer r.Close()
+		b, err := io.ReadAll(io.LimitReader(r, int64(maxDecompressedBytes)+1))
+		if err != nil {
+			return nil, err
+		}
+		if len(b) > maxDecompressedBytes {
+			return nil, errDecompressedSize
+		}
+		return b, nil
+	case CompressionZstd:
+		_, dec, err := zstdCodec()
+		if err != nil {
+			return nil, err
+		}
+		b, err := dec.DecodeAll(data, nil)
+		if err == zstd.ErrDecoderSizeExceeded {
+			return nil, errDecompressedSize
+		}
+		return b, err
+	}
+	return nil, fmt.Errorf("pubsub: unsupported compression %v", c)
+}
+
+// compressMessage returns a copy of msg with compressed data and the
+// compression attribute set, according to the topic's publish settings. msg
+// is returned unchanged if compression is disabled or msg is too small to be
+// compressed.
+func (t *Topic) compressMessage(msg *Message) (*Message, error) {
+	c := t.PublishSettings.Compression
+	threshold := t.PublishSettings.CompressionBytesThreshold
+	if threshold == 0 {
+		threshold = DefaultPublishSettings.CompressionBytesThreshold
+	}
+	if c == CompressionNone || len(msg.Data) < threshold {
+		return msg, nil
+	}
+	if _, ok := msg.Attributes[compressionAttr]; ok {
+		return nil, fmt.Errorf("pubsub: attribute %q is reserved for compression", compressionAttr)
+	}
+	data, err := compress(c, msg.Data)
+	if err != nil {
+		return nil, err
+	}
+	m := *msg
+	m.Data = data
+	m.Attributes = make(map[string]string, len(msg.Attributes)+1)
+	for k, v := range msg.Attributes {
+		m.Attributes[k] = v
+	}
+	m.Attributes[compressionAttr] = c.String()
+	return &m, nil
+}
+
+// decompressMessage replaces the data of a message published with compression
+// with its decompressed form and removes the compression attribute. Messages
+// that cannot be decompressed are left unchanged, so the attribute tells the
+// receiver that the data is still compressed.
+func decompressMessage(msg *Message) {
+	name, ok := msg.Attributes[compressionAttr]
+	if !ok {
+		return
+	}
+	c, err := parseCompression(name)
+	if err != nil {
+		return
+	}
+	data, err := decompress(c, msg.Data)
+	if err != nil {
+		return
+	}
+	msg.Data = data
+	attrs := make(map[string]string, len(msg.Attributes)-1)
+	for k, v := range msg.Attributes {
+		if k != compressionAttr {
+			attrs[k] = v
+		}
+	}
+	msg.Attributes = attrs
+}
@@ -0,0 +1,142 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pubsub
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/internal/testutil"
+)
+
+func TestPublishCompression(t *testing.T) {
+	ctx := context.Background()
+	large := bytes.Repeat([]byte(`{"key": "value"}`), 100)
+	for _, c := range []Compression{CompressionGzip, CompressionZstd} {
+		t.Run(c.String(), func(t *testing.T) {
+			client, srv := newFake(t)
+			defer client.Close()
+			defer srv.Close()
+
+			topic := mustCreateTopic(t, client, "t")
+			defer topic.Stop()
+			topic.PublishSettings.Compression = c
+			sub, err := client.CreateSubscription(ctx, "s", SubscriptionConfig{Topic: topic})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			attrs := map[string]string{"k": "v"}
+			id, err := topic.Publish(ctx, &Message{Data: large, Attributes: attrs}).Get(ctx)
+			if err != nil {
+				t.Fatal(err)
+			}
+			sent := srv.Message(id)
+			if got := sent.Attributes[compressionAttr]; got != c.String() {
+				t.Errorf("got compression attribute %q, want %q", got, c)
+			}
+			if len(sent.Data) >= len(large) {
+				t.Errorf("got %d bytes sent, want less than %d", len(sent.Data), len(large))
+			}
+			small := []byte("small")
+			id, err = topic.Publish(ctx, &Message{Data: small}).Get(ctx)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if sent := srv.Message(id); !bytes.Equal(sent.Data, small) || len(sent.Attributes) != 0 {
+				t.Errorf("small message was compressed: %+v", sent)
+			}
+
+			cctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+			defer cancel()
+			var mu sync.Mutex
+			got := make(map[string]*Message)
+			err = sub.Receive(cctx, func(_ context.Context, m *Message) {
+				m.Ack()
+				mu.Lock()
+				defer mu.Unlock()
+				got[fmt.Sprint(len(m.Data))] = m
+				if len(got) == 2 {
+					cancel()
+				}
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			m, ok := got[fmt.Sprint(len(large))]
+			if !ok {
+				t.Fatalf("did not receive decompressed message, got %v", got)
+			}
+			if !bytes.Equal(m.Data, large) {
+				t.Error("received data does not match published data")
+			}
+			if diff := testutil.Diff(m.Attributes, attrs); diff != "" {
+				t.Errorf("Attributes -got, +want:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestDecompressMessageInvalid(t *testing.T) {
+	m := &Message{
+		Data:       []byte("not compressed"),
+		Attributes: map[string]string{compressionAttr: "gzip"},
+	}
+	decompressMessage(m)
+	if string(m.Data) != "not compressed" || m.Attributes[compressionAttr] != "gzip" {
+		t.Errorf("invalid message was modified: %+v", m)
+	}
+}
+
+func TestDecompressLimit(t *testing.T) {
+	bomb := make([]byte, maxDecompressedBytes+1)
+	for _, c := range []Compression{CompressionGzip, CompressionZstd} {
+		data, err := compress(c, bomb)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := decompress(c, data); err != errDecompressedSize {
+			t.Errorf("%v: got %v, want errDecompressedSize", c, err)
+		}
+		m := &Message{Data: data, Attributes: map[string]string{compressionAttr: c.String()}}
+		decompressMessage(m)
+		if !bytes.Equal(m.Data, data) || m.Attributes[compressionAttr] != c.String() {
+			t.Errorf("%v: oversized message was modified", c)
+		}
+	}
+}
+
+func TestCompressionThresholdDefault(t *testing.T) {
+	topic := &Topic{PublishSettings: PublishSettings{Compression: CompressionGzip}}
+	small := &Message{Data: []byte("small")}
+	m, err := topic.compressMessage(small)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m != small {
+		t.Errorf("small message was compressed with the zero threshold: %+v", m)
+	}
+	topic.PublishSettings.CompressionBytesThreshold = -1
+	if m, err = topic.compressMessage(small); err != nil {
+		t.Fatal(err)
+	}
+	if m.Attributes[compressionAttr] != "gzip" {
+		t.Errorf("small message was not compressed with a negative threshold: %+v", m)
+	}
+}
@@ -0,0 +1,254 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pubsub
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+const (
+	// MinDeadLetterDeliveryAttempts is the minimum value of
+	// DeadLetterPolicy.MaxDeliveryAttempts accepted by the service.
+	MinDeadLetterDeliveryAttempts = 5
+
+	// MaxDeadLetterDeliveryAttempts is the maximum value of
+	// DeadLetterPolicy.MaxDeliveryAttempts accepted by the service.
+	MaxDeadLetterDeliveryAttempts = 100
+)
+
+// Attributes added by the service to messages forwarded to a dead letter topic.
+const (
+	deadLetterAttrPrefix              = "CloudPubSubDeadLetter"
+	deadLetterAttrDeliveryCount       = "CloudPubSubDeadLetterSourceDeliveryCount"
+	deadLetterAttrSubscription        = "CloudPubSubDeadLetterSourceSubscription"
+	deadLetterAttrSubscriptionProject = "CloudPubSubDeadLetterSourceSubscriptionProject"
+	deadLetterAttrPublishTime         = "CloudPubSubDeadLetterSourceTopicPublishTime"
+)
+
+// defaultRedriveIdleTimeout is used when RedriveSettings.IdleTimeout is unset.
+const defaultRedriveIdleTimeout = 10 * time.Second
+
+func (dlp *DeadLetterPolicy) validate() error {
+	if dlp.DeadLetterTopic == "" {
+		return errors.New("pubsub: DeadLetterPolicy.DeadLetterTopic must be set")
+	}
+	if n := dlp.MaxDeliveryAttempts; n != 0 && (n < MinDeadLetterDeliveryAttempts || n > MaxDeadLetterDeliveryAttempts) {
+		return fmt.Errorf("pubsub: DeadLetterPolicy.MaxDeliveryAttempts must be between %d and %d; got: %d",
+			MinDeadLetterDeliveryAttempts, MaxDeadLetterDeliveryAttempts, n)
+	}
+	return nil
+}
+
+// SetDeadLetterPolicy configures the subscription to forward messages that
+// could not be delivered after maxDeliveryAttempts attempts to deadLetterTopic.
+// If maxDeliveryAttempts is 0, the service default of 5 is used.
+//
+// The Pub/Sub service account of the project must be allowed to publish to
+// deadLetterTopic and to acknowledge messages on the subscription.
+func (s *Subscription) SetDeadLetterPolicy(ctx context.Context, deadLetterTopic *Topic, maxDeliveryAttempts int) (SubscriptionConfig, error) {
+	if deadLetterTopic == nil {
+		return SubscriptionConfig{}, errors.New("pubsub: require non-nil dead letter topic")
+	}
+	dlp := &DeadLetterPolicy{
+		DeadLetterTopic:     deadLetterTopic.String(),
+		MaxDeliveryAttempts: maxDeliveryAttempts,
+	}
+	if err := dlp.validate(); err != nil {
+		return SubscriptionConfig{}, err
+	}
+	return s.Update(ctx, SubscriptionConfigToUpdate{DeadLetterPolicy: dlp})
+}
+
+// RemoveDeadLetterPolicy removes dead lettering from the subscription.
+func (s *Subscription) RemoveDeadLetterPolicy(ctx context.Context) (SubscriptionConfig, error) {
+	return s.Update(ctx, SubscriptionConfigToUpdate{DeadLetterPolicy: &DeadLetterPolicy{}})
+}
+
+// DeadLetterInfo describes where a dead-lettered message came from. The service
+// attaches this information to messages as attributes when forwarding them to
+// a dead letter topic.
+type DeadLetterInfo struct {
+	// SourceSubscription is the fully qualified name of the subscription the
+	// message was dead-lettered from.
+	SourceSubscription string
+
+	// DeliveryAttempts is the number of delivery attempts made on the source
+	// subscription before the message was dead-lettered.
+	DeliveryAttempts int
+
+	// SourcePublishTime is the time the message was originally published to
+	// the source topic. It is zero if not reported.
+	SourcePublishTime time.Time
+}
+
+// DeadLetterInfoFromMessage extracts the dead letter metadata from a message
+// received on a dead letter topic. It reports false if msg was not forwarded
+// by a dead letter policy.
+func DeadLetterInfoFromMessage(msg *Message) (*DeadLetterInfo, bool) {
+	sub, ok := msg.Attributes[deadLetterAttrSubscription]
+	if !ok {
+		return nil, false
+	}
+	info := &DeadLetterInfo{SourceSubscription: sub}
+	if proj := msg.Attributes[deadLetterAttrSubscriptionProject]; proj != "" && !strings.HasPrefix(sub, "projects/") {
+		info.SourceSubscription = fmt.Sprintf("projects/%s/subscriptions/%s", proj, sub)
+	}
+	if n, err := strconv.Atoi(msg.Attributes[deadLetterAttrDeliveryCount]); err == nil {
+		info.DeliveryAttempts = n
+	}
+	if t, err := time.Parse(time.RFC3339Nano, msg.Attributes[deadLetterAttrPublishTime]); err == nil {
+		info.SourcePublishTime = t
+	}
+	return info, true
+}
+
+// RedriveSettings configure Subscription.Redrive.
+type RedriveSettings struct {
+	// MaxMessagesPerSecond limits the rate at which messages are republished.
+	// If less than or equal to zero, the rate is not limited.
+	MaxMessagesPerSecond float64
+
+	// MaxMessages is the maximum number of messages to redrive. If less than
+	// or equal to zero, messages are redriven until the subscription is idle.
+	MaxMessages int
+
+	// IdleTimeout stops the redrive once no message has been received or
+	// republished for this long. Messages being republished are waited for.
+	//
+	// Defaults to 10 seconds.
+	IdleTimeout time.Duration
+
+	// KeepDeadLetterAttributes retains the attributes added by the dead letter
+	// policy on republished messages. By default they are removed.
+	KeepDeadLetterAttributes bool
+}
+
+// Redrive receives messages from s, typically a subscription on a dead letter
+// topic, and republishes them to topic, usually the original source topic.
+// Each message is acknowledged once it has been republished and nacked if
+// republishing fails.
+//
+// Redrive returns the number of messages republished once the subscription
+// has been idle for settings.IdleTimeout, settings.MaxMessages have been
+// redriven or ctx is done. It returns the first publish error, if any, after
+// stopping.
+//
+// Ordering keys are preserved only if topic.EnableMessageOrdering is set.
+func (s *Subscription) Redrive(ctx context.Context, topic *Topic, settings RedriveSettings) (int, error) {
+	if topic == nil {
+		return 0, errors.New("pubsub: require non-nil Topic")
+	}
+	idle := settings.IdleTimeout
+	if idle <= 0 {
+		idle = defaultRedriveIdleTimeout
+	}
+	limit := rate.Inf
+	if settings.MaxMessagesPerSecond > 0 {
+		limit = rate.Limit(settings.MaxMessagesPerSecond)
+	}
+	limiter := rate.NewLimiter(limit, 1)
+
+	cctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		mu       sync.Mutex
+		firstErr error
+		inFlight int // messages being redriven, guarded by mu
+		redriven int64
+		started  int64
+	)
+	// The subscription is idle once no message has been in flight for idle.
+	idleTimer := time.AfterFunc(idle, func() {
+		mu.Lock()
+		defer mu.Unlock()
+		if inFlight == 0 {
+			cancel()
+		}
+	})
+	defer idleTimer.Stop()
+
+	err := s.Receive(cctx, func(rctx context.Context, msg *Message) {
+		mu.Lock()
+		inFlight++
+		mu.Unlock()
+		defer func() {
+			mu.Lock()
+			if inFlight--; inFlight == 0 {
+				idleTimer.Reset(idle)
+			}
+			mu.Unlock()
+		}()
+		if settings.MaxMessages > 0 && atomic.AddInt64(&started, 1) > int64(settings.MaxMessages) {
+			msg.Nack()
+			cancel()
+			return
+		}
+		if err := limiter.Wait(rctx); err != nil {
+			msg.Nack()
+			return
+		}
+		out := &Message{
+			Data:       msg.Data,
+			Attributes: redriveAttributes(msg.Attributes, settings.KeepDeadLetterAttributes),
+		}
+		if topic.EnableMessageOrdering {
+			out.OrderingKey = msg.OrderingKey
+		}
+		// Publish with ctx rather than rctx, so that stopping the redrive
+		// does not cancel the messages being republished.
+		if _, err := topic.Publish(ctx, out).Get(ctx); err != nil {
+			msg.Nack()
+			mu.Lock()
+			if firstErr == nil {
+				firstErr = err
+			}
+			mu.Unlock()
+			cancel()
+			return
+		}
+		msg.Ack()
+		if n := atomic.AddInt64(&redriven, 1); settings.MaxMessages > 0 && n >= int64(settings.MaxMessages) {
+			cancel()
+		}
+	})
+	if err == nil {
+		err = firstErr
+	}
+	return int(atomic.LoadInt64(&redriven)), err
+}
+
+// redriveAttributes returns the attributes for a republished message.
+func redriveAttributes(attrs map[string]string, keepDeadLetter bool) map[string]string {
+	if keepDeadLetter || len(attrs) == 0 {
+		return attrs
+	}
+	out := make(map[string]string, len(attrs))
+	for k, v := range attrs {
+		if !strings.HasPrefix(k, deadLetterAttrPrefix) {
+			out[k] = v
+		}
+	}
+	return out
+}
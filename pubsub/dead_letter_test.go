@@ -0,0 +1,207 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pubsub
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/internal/testutil"
+	pb "cloud.google.com/go/pubsub/apiv1/pubsubpb"
+	"cloud.google.com/go/pubsub/pstest"
+	"google.golang.org/api/option"
+	"google.golang.org/grpc"
+)
+
+func TestSetDeadLetterPolicy(t *testing.T) {
+	ctx := context.Background()
+	client, srv := newFake(t)
+	defer client.Close()
+	defer srv.Close()
+
+	topic := mustCreateTopic(t, client, "t")
+	dlq := mustCreateTopic(t, client, "dlq")
+	sub, err := client.CreateSubscription(ctx, "s", SubscriptionConfig{Topic: topic})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := sub.SetDeadLetterPolicy(ctx, dlq, 3); err == nil {
+		t.Error("SetDeadLetterPolicy with 3 attempts: got nil error, want error")
+	}
+	cfg, err := sub.SetDeadLetterPolicy(ctx, dlq, 7)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := &DeadLetterPolicy{DeadLetterTopic: dlq.String(), MaxDeliveryAttempts: 7}
+	if diff := testutil.Diff(cfg.DeadLetterPolicy, want); diff != "" {
+		t.Errorf("DeadLetterPolicy -got, +want:\n%s", diff)
+	}
+	cfg, err = sub.RemoveDeadLetterPolicy(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.DeadLetterPolicy != nil {
+		t.Errorf("got DeadLetterPolicy %+v, want nil", cfg.DeadLetterPolicy)
+	}
+}
+
+func TestDeadLetterInfoFromMessage(t *testing.T) {
+	if _, ok := DeadLetterInfoFromMessage(&Message{}); ok {
+		t.Error("got ok for message without dead letter attributes")
+	}
+	pt := time.Date(2023, 6, 1, 12, 0, 0, 0, time.UTC)
+	got, ok := DeadLetterInfoFromMessage(&Message{Attributes: map[string]string{
+		deadLetterAttrSubscription:        "s",
+		deadLetterAttrSubscriptionProject: "p",
+		deadLetterAttrDeliveryCount:       "6",
+		deadLetterAttrPublishTime:         pt.Format(time.RFC3339Nano),
+	}})
+	if !ok {
+		t.Fatal("got !ok, want ok")
+	}
+	want := &DeadLetterInfo{
+		SourceSubscription: "projects/p/subscriptions/s",
+		DeliveryAttempts:   6,
+		SourcePublishTime:  pt,
+	}
+	if diff := testutil.Diff(got, want); diff != "" {
+		t.Errorf("DeadLetterInfo -got, +want:\n%s", diff)
+	}
+}
+
+func TestRedrive(t *testing.T) {
+	ctx := context.Background()
+	client, srv := newFake(t)
+	defer client.Close()
+	defer srv.Close()
+
+	src := mustCreateTopic(t, client, "src")
+	dlq := mustCreateTopic(t, client, "dlq")
+	defer src.Stop()
+	defer dlq.Stop()
+	srcSub, err := client.CreateSubscription(ctx, "src-sub", SubscriptionConfig{Topic: src})
+	if err != nil {
+		t.Fatal(err)
+	}
+	dlqSub, err := client.CreateSubscription(ctx, "dlq-sub", SubscriptionConfig{Topic: dlq})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	const n = 5
+	for i := 0; i < n; i++ {
+		r := dlq.Publish(ctx, &Message{
+			Data: []byte("m"),
+			Attributes: map[string]string{
+				"keep":                     "v",
+				deadLetterAttrSubscription: srcSub.String(),
+			},
+		})
+		if _, err := r.Get(ctx); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	got, err := dlqSub.Redrive(ctx, src, RedriveSettings{
+		MaxMessagesPerSecond: 100,
+		IdleTimeout:          time.Second,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != n {
+		t.Errorf("Redrive: got %d messages, want %d", got, n)
+	}
+
+	var mu sync.Mutex
+	var received []*Message
+	cctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	err = srcSub.Receive(cctx, func(_ context.Context, m *Message) {
+		m.Ack()
+		mu.Lock()
+		defer mu.Unlock()
+		received = append(received, m)
+		if len(received) == n {
+			cancel()
+		}
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(received) != n {
+		t.Fatalf("received %d redriven messages, want %d", len(received), n)
+	}
+	for _, m := range received {
+		if diff := testutil.Diff(m.Attributes, map[string]string{"keep": "v"}); diff != "" {
+			t.Errorf("Attributes -got, +want:\n%s", diff)
+		}
+	}
+}
+
+// slowPublishReactor delays the publishes to topics whose name ends in topic.
+type slowPublishReactor struct {
+	topic string
+	delay time.Duration
+}
+
+func (r *slowPublishReactor) React(req interface{}) (bool, interface{}, error) {
+	if strings.HasSuffix(req.(*pb.PublishRequest).Topic, r.topic) {
+		time.Sleep(r.delay)
+	}
+	return false, nil, nil
+}
+
+func TestRedriveSlowPublish(t *testing.T) {
+	ctx := context.Background()
+	// Republishing takes longer than the idle timeout.
+	srv := pstest.NewServer(pstest.ServerReactorOption{
+		FuncName: "Publish",
+		Reactor:  &slowPublishReactor{topic: "/src", delay: 2 * time.Second},
+	})
+	defer srv.Close()
+	client, err := NewClient(ctx, projName,
+		option.WithEndpoint(srv.Addr),
+		option.WithoutAuthentication(),
+		option.WithGRPCDialOption(grpc.WithInsecure()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+
+	src := mustCreateTopic(t, client, "src")
+	dlq := mustCreateTopic(t, client, "dlq")
+	defer src.Stop()
+	defer dlq.Stop()
+	dlqSub, err := client.CreateSubscription(ctx, "dlq-sub", SubscriptionConfig{Topic: dlq})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := dlq.Publish(ctx, &Message{Data: []byte("m")}).Get(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := dlqSub.Redrive(ctx, src, RedriveSettings{IdleTimeout: 500 * time.Millisecond})
+	if err != nil {
+		t.Fatalf("Redrive: %v", err)
+	}
+	if got != 1 {
+		t.Errorf("Redrive: got %d messages, want 1", got)
+	}
+}
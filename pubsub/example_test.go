@@ -109,6 +109,35 @@ func ExampleClient_CreateSubscription() {
 	_ = sub // TODO: use the subscription.
 }
 
+func ExampleClient_CreateSubscription_cloudStorage() {
+	ctx := context.Background()
+	client, err := pubsub.NewClient(ctx, "project-id")
+	if err != nil {
+		// TODO: Handle error.
+	}
+	topic := client.Topic("topicName")
+
+	// Export messages as Avro files of at most 100 MB, starting a new file
+	// at least every 5 minutes.
+	csCfg, err := pubsub.NewCloudStorageConfigBuilder("bucket-name").
+		FilenamePrefix("log_events_").
+		AvroFormat(true).
+		MaxDuration(5 * time.Minute).
+		MaxBytes(100e6).
+		Build()
+	if err != nil {
+		// TODO: Handle error.
+	}
+	sub, err := client.CreateSubscription(ctx, "subName", pubsub.SubscriptionConfig{
+		Topic:              topic,
+		CloudStorageConfig: *csCfg,
+	})
+	if err != nil {
+		// TODO: Handle error.
+	}
+	_ = sub // TODO: Use the subscription
+}
+
 func ExampleClient_CreateSubscription_neverExpire() {
 	ctx := context.Background()
 	client, err := pubsub.NewClient(ctx, "project-id")
@@ -429,6 +458,44 @@ func ExampleSubscription_SeekToTime() {
 	}
 }
 
+func ExampleSubscription_ValidateSeekToTime() {
+	ctx := context.Background()
+	client, err := pubsub.NewClient(ctx, "project-id")
+	if err != nil {
+		// TODO: Handle error.
+	}
+	sub := client.Subscription("subName")
+	t := time.Now().Add(-time.Hour)
+	// Check that messages published since t can be replayed before seeking.
+	if err := sub.ValidateSeekToTime(ctx, t); err != nil {
+		// TODO: Handle error.
+	}
+	if err := sub.SeekToTime(ctx, t); err != nil {
+		// TODO: Handle error.
+	}
+}
+
+func ExampleTopic_Snapshots() {
+	ctx := context.Background()
+	client, err := pubsub.NewClient(ctx, "project-id")
+	if err != nil {
+		// TODO: Handle error.
+	}
+	topic := client.Topic("topicName")
+	// List all snapshots of the topic.
+	iter := topic.Snapshots(ctx)
+	for {
+		snap, err := iter.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			// TODO: Handle error.
+		}
+		_ = snap // TODO: use the snapshot.
+	}
+}
+
 func ExampleSnapshot_Delete() {
 	ctx := context.Background()
 	client, err := pubsub.NewClient(ctx, "project-id")
@@ -0,0 +1,211 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pubsub
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"cloud.google.com/go/internal/optional"
+)
+
+// Limits on Cloud Storage subscription files, as documented on
+// CloudStorageConfig.
+const (
+	MinCloudStorageMaxDuration = time.Minute
+	MaxCloudStorageMaxDuration = 10 * time.Minute
+	MinCloudStorageMaxBytes    = 1000
+	MaxCloudStorageMaxBytes    = 10 << 30 // 10 GiB
+)
+
+var (
+	// bigQueryTableRE matches "project:dataset.table" and
+	// "project.dataset.table". Project IDs may contain a domain prefix
+	// such as "example.com:project".
+	bigQueryTableRE = regexp.MustCompile(`^([a-z0-9.-]+:)?[a-z][a-z0-9-]*[:.][\w-]+\.[^.:/]+$`)
+
+	// bucketNameRE matches valid Cloud Storage bucket names.
+	bucketNameRE = regexp.MustCompile(`^[a-z0-9][a-z0-9._-]{1,220}[a-z0-9]$`)
+)
+
+// Validate reports whether bc is a valid configuration for a BigQuery
+// subscription. A zero BigQueryConfig is valid, and clears the configuration
+// when used in SubscriptionConfigToUpdate.
+func (bc *BigQueryConfig) Validate() error {
+	if bc == nil || *bc == (BigQueryConfig{}) {
+		return nil
+	}
+	return bc.validate()
+}
+
+func (bc *BigQueryConfig) validate() error {
+	if bc.Table == "" {
+		return errors.New("pubsub: BigQueryConfig.Table must be set")
+	}
+	if !bigQueryTableRE.MatchString(bc.Table) {
+		return fmt.Errorf("pubsub: invalid BigQuery table %q, want the form {projectId}:{datasetId}.{tableId}", bc.Table)
+	}
+	if bc.DropUnknownFields && !bc.UseTopicSchema {
+		return errors.New("pubsub: BigQueryConfig.DropUnknownFields requires UseTopicSchema")
+	}
+	return nil
+}
+
+// Validate reports whether cs is a valid configuration for a Cloud Storage
+// subscription. A zero CloudStorageConfig is valid, and clears the
+// configuration when used in SubscriptionConfigToUpdate.
+func (cs *CloudStorageConfig) Validate() error {
+	if cs == nil || *cs == (CloudStorageConfig{}) {
+		return nil
+	}
+	return cs.validate()
+}
+
+func (cs *CloudStorageConfig) validate() error {
+	if cs.Bucket == "" {
+		return errors.New("pubsub: CloudStorageConfig.Bucket must be set")
+	}
+	if strings.HasPrefix(cs.Bucket, "gs://") {
+		return fmt.Errorf("pubsub: bucket %q must not have the gs:// prefix", cs.Bucket)
+	}
+	if !bucketNameRE.MatchString(cs.Bucket) || strings.Contains(cs.Bucket, "..") {
+		return fmt.Errorf("pubsub: invalid bucket name %q", cs.Bucket)
+	}
+	if cs.MaxDuration != nil {
+		d := optional.ToDuration(cs.MaxDuration)
+		if d < MinCloudStorageMaxDuration || d > MaxCloudStorageMaxDuration {
+			return fmt.Errorf("pubsub: CloudStorageConfig.MaxDuration %v must be between %v and %v", d, MinCloudStorageMaxDuration, MaxCloudStorageMaxDuration)
+		}
+	}
+	if cs.MaxBytes != 0 && (cs.MaxBytes < MinCloudStorageMaxBytes || cs.MaxBytes > MaxCloudStorageMaxBytes) {
+		return fmt.Errorf("pubsub: CloudStorageConfig.MaxBytes %d must be between %d and %d", cs.MaxBytes, MinCloudStorageMaxBytes, MaxCloudStorageMaxBytes)
+	}
+	switch cs.OutputFormat.(type) {
+	case nil, *CloudStorageOutputFormatTextConfig, *CloudStorageOutputFormatAvroConfig:
+	default:
+		return fmt.Errorf("pubsub: unsupported CloudStorageConfig.OutputFormat %T", cs.OutputFormat)
+	}
+	return nil
+}
+
+// BigQueryConfigBuilder assembles a BigQueryConfig. Create one with
+// NewBigQueryConfigBuilder, then call Build to validate the result.
+type BigQueryConfigBuilder struct {
+	cfg BigQueryConfig
+}
+
+// NewBigQueryConfigBuilder returns a builder for a BigQuery subscription that
+// writes to table, of the form {projectId}:{datasetId}.{tableId}.
+func NewBigQueryConfigBuilder(table string) *BigQueryConfigBuilder {
+	return &BigQueryConfigBuilder{cfg: BigQueryConfig{Table: table}}
+}
+
+// UseTopicSchema writes messages using the columns of the topic's schema.
+func (b *BigQueryConfigBuilder) UseTopicSchema() *BigQueryConfigBuilder {
+	b.cfg.UseTopicSchema = true
+	return b
+}
+
+// WriteMetadata writes the subscription name, message ID, publish time,
+// attributes and ordering key to additional columns.
+func (b *BigQueryConfigBuilder) WriteMetadata() *BigQueryConfigBuilder {
+	b.cfg.WriteMetadata = true
+	return b
+}
+
+// DropUnknownFields drops the fields of the topic schema that are missing
+// from the table schema, instead of leaving such messages in the backlog.
+// It implies UseTopicSchema.
+func (b *BigQueryConfigBuilder) DropUnknownFields() *BigQueryConfigBuilder {
+	b.cfg.UseTopicSchema = true
+	b.cfg.DropUnknownFields = true
+	return b
+}
+
+// Build validates and returns the configuration.
+func (b *BigQueryConfigBuilder) Build() (*BigQueryConfig, error) {
+	cfg := b.cfg
+	if err := cfg.validate(); err != nil {
+		return nil, err
+	}
+	return &cfg, nil
+}
+
+// CloudStorageConfigBuilder assembles a CloudStorageConfig. Create one with
+// NewCloudStorageConfigBuilder, then call Build to validate the result.
+type CloudStorageConfigBuilder struct {
+	cfg CloudStorageConfig
+}
+
+// NewCloudStorageConfigBuilder returns a builder for a Cloud Storage
+// subscription that writes text files to bucket. The bucket name must not
+// have the gs:// prefix.
+func NewCloudStorageConfigBuilder(bucket string) *CloudStorageConfigBuilder {
+	return &CloudStorageConfigBuilder{cfg: CloudStorageConfig{Bucket: bucket}}
+}
+
+// FilenamePrefix sets the prefix of the files written to the bucket.
+func (b *CloudStorageConfigBuilder) FilenamePrefix(prefix string) *CloudStorageConfigBuilder {
+	b.cfg.FilenamePrefix = prefix
+	return b
+}
+
+// FilenameSuffix sets the suffix of the files written to the bucket.
+func (b *CloudStorageConfigBuilder) FilenameSuffix(suffix string) *CloudStorageConfigBuilder {
+	b.cfg.FilenameSuffix = suffix
+	return b
+}
+
+// TextFormat writes message data as raw text, separated by newlines. This is
+// the default.
+func (b *CloudStorageConfigBuilder) TextFormat() *CloudStorageConfigBuilder {
+	b.cfg.OutputFormat = &CloudStorageOutputFormatTextConfig{}
+	return b
+}
+
+// AvroFormat writes messages as Avro binary. If writeMetadata is true, the
+// subscription name, message ID, publish time, attributes and ordering key
+// are written as additional fields.
+func (b *CloudStorageConfigBuilder) AvroFormat(writeMetadata bool) *CloudStorageConfigBuilder {
+	b.cfg.OutputFormat = &CloudStorageOutputFormatAvroConfig{WriteMetadata: writeMetadata}
+	return b
+}
+
+// MaxDuration sets the maximum time before a new file is created. It must be
+// between MinCloudStorageMaxDuration and MaxCloudStorageMaxDuration, and may
+// not exceed the subscription's ack deadline.
+func (b *CloudStorageConfigBuilder) MaxDuration(d time.Duration) *CloudStorageConfigBuilder {
+	b.cfg.MaxDuration = d
+	return b
+}
+
+// MaxBytes sets the maximum size of a file before a new one is created. It
+// must be between MinCloudStorageMaxBytes and MaxCloudStorageMaxBytes.
+func (b *CloudStorageConfigBuilder) MaxBytes(n int64) *CloudStorageConfigBuilder {
+	b.cfg.MaxBytes = n
+	return b
+}
+
+// Build validates and returns the configuration.
+func (b *CloudStorageConfigBuilder) Build() (*CloudStorageConfig, error) {
+	cfg := b.cfg
+	if err := cfg.validate(); err != nil {
+		return nil, err
+	}
+	return &cfg, nil
+}
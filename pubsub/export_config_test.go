@@ -0,0 +1,137 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pubsub
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/internal/testutil"
+)
+
+func TestBigQueryConfigBuilder(t *testing.T) {
+	got, err := NewBigQueryConfigBuilder("some-project:some-dataset.some-table").
+		WriteMetadata().
+		DropUnknownFields().
+		Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := &BigQueryConfig{
+		Table:             "some-project:some-dataset.some-table",
+		UseTopicSchema:    true,
+		WriteMetadata:     true,
+		DropUnknownFields: true,
+	}
+	if diff := testutil.Diff(got, want); diff != "" {
+		t.Errorf("BigQueryConfig -got, +want:\n%s", diff)
+	}
+
+	for _, table := range []string{
+		"",
+		"some-table",
+		"projects/p/datasets/d/tables/t",
+		"gs://bucket",
+	} {
+		if _, err := NewBigQueryConfigBuilder(table).Build(); err == nil {
+			t.Errorf("table %q: got nil error, want error", table)
+		}
+	}
+	for _, table := range []string{
+		"p.d.t",
+		"example.com:p:d.t",
+		"my-project:my_dataset.my_table",
+	} {
+		if _, err := NewBigQueryConfigBuilder(table).Build(); err != nil {
+			t.Errorf("table %q: %v", table, err)
+		}
+	}
+}
+
+func TestBigQueryConfigValidate(t *testing.T) {
+	if err := (&BigQueryConfig{}).Validate(); err != nil {
+		t.Errorf("zero config: %v", err)
+	}
+	bc := &BigQueryConfig{Table: "p:d.t", DropUnknownFields: true}
+	if err := bc.Validate(); err == nil {
+		t.Error("DropUnknownFields without UseTopicSchema: got nil error")
+	}
+}
+
+func TestCloudStorageConfigBuilder(t *testing.T) {
+	got, err := NewCloudStorageConfigBuilder("some-bucket").
+		FilenamePrefix("prefix").
+		FilenameSuffix(".avro").
+		AvroFormat(true).
+		MaxDuration(2 * time.Minute).
+		MaxBytes(10000).
+		Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := &CloudStorageConfig{
+		Bucket:         "some-bucket",
+		FilenamePrefix: "prefix",
+		FilenameSuffix: ".avro",
+		OutputFormat:   &CloudStorageOutputFormatAvroConfig{WriteMetadata: true},
+		MaxDuration:    2 * time.Minute,
+		MaxBytes:       10000,
+	}
+	if diff := testutil.Diff(got, want); diff != "" {
+		t.Errorf("CloudStorageConfig -got, +want:\n%s", diff)
+	}
+
+	for _, tc := range []struct {
+		desc string
+		b    *CloudStorageConfigBuilder
+	}{
+		{"empty bucket", NewCloudStorageConfigBuilder("")},
+		{"gs prefix", NewCloudStorageConfigBuilder("gs://bucket")},
+		{"uppercase bucket", NewCloudStorageConfigBuilder("Bucket")},
+		{"short duration", NewCloudStorageConfigBuilder("bucket").MaxDuration(30 * time.Second)},
+		{"long duration", NewCloudStorageConfigBuilder("bucket").MaxDuration(time.Hour)},
+		{"small files", NewCloudStorageConfigBuilder("bucket").MaxBytes(10)},
+		{"large files", NewCloudStorageConfigBuilder("bucket").MaxBytes(11 << 30)},
+	} {
+		if _, err := tc.b.Build(); err == nil {
+			t.Errorf("%s: got nil error, want error", tc.desc)
+		}
+	}
+}
+
+func TestCreateSubscriptionWithBuiltConfigs(t *testing.T) {
+	ctx := context.Background()
+	client, srv := newFake(t)
+	defer client.Close()
+	defer srv.Close()
+
+	topic := mustCreateTopic(t, client, "t")
+	bq, err := NewBigQueryConfigBuilder("some-project:some-dataset.some-table").WriteMetadata().Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	sub, err := client.CreateSubscription(ctx, "bq", SubscriptionConfig{Topic: topic, BigQueryConfig: *bq})
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg, err := sub.Config(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.BigQueryConfig.Table != bq.Table || !cfg.BigQueryConfig.WriteMetadata {
+		t.Errorf("got BigQueryConfig %+v, want %+v", cfg.BigQueryConfig, bq)
+	}
+}
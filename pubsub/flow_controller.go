@@ -17,7 +17,9 @@ package pubsub
 import (
 	"context"
 	"errors"
+	"sync"
 	"sync/atomic"
+	"time"
 
 	"golang.org/x/sync/semaphore"
 )
@@ -42,6 +44,9 @@ type flowControllerPurpose int
 const (
 	flowControllerPurposeSubscription flowControllerPurpose = iota
 	flowControllerPurposeTopic
+	// flowControllerPurposeOrderingKey flow controllers limit the messages of a
+	// single ordering key and don't record stats.
+	flowControllerPurposeOrderingKey
 )
 
 // FlowControlSettings controls flow control for messages while publishing or subscribing.
@@ -83,6 +88,9 @@ type flowController struct {
 	bytesRemaining int64
 	limitBehavior  LimitExceededBehavior
 	purpose        flowControllerPurpose
+	// onBlocked, if non-nil, is called with the time acquire spent waiting
+	// whenever it could not allocate space immediately in FlowControlBlock mode.
+	onBlocked func(time.Duration)
 }
 
 // newFlowController creates a new flowController that ensures no more than
@@ -128,12 +136,16 @@ func (f *flowController) acquire(ctx context.Context, size int) error {
 	case FlowControlIgnore:
 		return nil
 	case FlowControlBlock:
-		if f.semCount != nil {
+		start := time.Now()
+		blocked := false
+		if f.semCount != nil && !f.semCount.TryAcquire(1) {
+			blocked = true
 			if err := f.semCount.Acquire(ctx, 1); err != nil {
 				return err
 			}
 		}
-		if f.semSize != nil {
+		if f.semSize != nil && !f.semSize.TryAcquire(f.bound(size)) {
+			blocked = true
 			if err := f.semSize.Acquire(ctx, f.bound(size)); err != nil {
 				if f.semCount != nil {
 					f.semCount.Release(1)
@@ -141,6 +153,9 @@ func (f *flowController) acquire(ctx context.Context, size int) error {
 				return err
 			}
 		}
+		if blocked && f.onBlocked != nil {
+			f.onBlocked(time.Since(start))
+		}
 	case FlowControlSignalError:
 		if f.semCount != nil {
 			if !f.semCount.TryAcquire(1) {
@@ -202,7 +217,10 @@ func (f *flowController) count() int {
 }
 
 func (f *flowController) recordOutstandingMessages(ctx context.Context, n int64) {
-	if f.purpose == flowControllerPurposeTopic {
+	switch f.purpose {
+	case flowControllerPurposeOrderingKey:
+		return
+	case flowControllerPurposeTopic:
 		recordStat(ctx, PublisherOutstandingMessages, n)
 		return
 	}
@@ -211,10 +229,85 @@ func (f *flowController) recordOutstandingMessages(ctx context.Context, n int64)
 }
 
 func (f *flowController) recordOutstandingBytes(ctx context.Context, n int64) {
-	if f.purpose == flowControllerPurposeTopic {
+	switch f.purpose {
+	case flowControllerPurposeOrderingKey:
+		return
+	case flowControllerPurposeTopic:
 		recordStat(ctx, PublisherOutstandingBytes, n)
 		return
 	}
 
 	recordStat(ctx, OutstandingBytes, n)
 }
+
+// keyFlowControllers enforces flow control separately for each ordering key.
+// Flow controllers are created when a key is first used and discarded once
+// it has no outstanding messages.
+type keyFlowControllers struct {
+	settings FlowControlSettings
+
+	mu sync.Mutex
+	m  map[string]*keyFlowController
+}
+
+type keyFlowController struct {
+	fc flowController
+	// Number of acquires in progress or not yet released. Guarded by
+	// keyFlowControllers.mu.
+	refs int
+}
+
+func newKeyFlowControllers(fc FlowControlSettings) keyFlowControllers {
+	return keyFlowControllers{
+		settings: fc,
+		m:        make(map[string]*keyFlowController),
+	}
+}
+
+// acquire allocates space for a message with the given ordering key. It is a
+// no-op for messages without an ordering key.
+func (k *keyFlowControllers) acquire(ctx context.Context, key string, size int) error {
+	if key == "" || k.settings.LimitExceededBehavior == FlowControlIgnore {
+		return nil
+	}
+	k.mu.Lock()
+	c, ok := k.m[key]
+	if !ok {
+		c = &keyFlowController{fc: newFlowController(k.settings)}
+		c.fc.purpose = flowControllerPurposeOrderingKey
+		k.m[key] = c
+	}
+	c.refs++
+	k.mu.Unlock()
+
+	if err := c.fc.acquire(ctx, size); err != nil {
+		k.unref(key, c)
+		return err
+	}
+	return nil
+}
+
+// release notes that one message of size bytes with the given ordering key is
+// no longer outstanding.
+func (k *keyFlowControllers) release(ctx context.Context, key string, size int) {
+	if key == "" || k.settings.LimitExceededBehavior == FlowControlIgnore {
+		return
+	}
+	k.mu.Lock()
+	c, ok := k.m[key]
+	k.mu.Unlock()
+	if !ok {
+		return
+	}
+	c.fc.release(ctx, size)
+	k.unref(key, c)
+}
+
+func (k *keyFlowControllers) unref(key string, c *keyFlowController) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	c.refs--
+	if c.refs == 0 {
+		delete(k.m, key)
+	}
+}
@@ -241,3 +241,37 @@ func TestFlowControllerUnboundedBytes(t *testing.T) {
 		t.Errorf("got nil, wanted %v", ErrFlowControllerMaxOutstandingMessages)
 	}
 }
+
+func TestFlowControllerOnBlocked(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	fc := newFlowController(fcSettings(1, 10, FlowControlBlock))
+	blocked := make(chan time.Duration, 1)
+	fc.onBlocked = func(d time.Duration) { blocked <- d }
+
+	if err := fc.acquire(ctx, 1); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case d := <-blocked:
+		t.Fatalf("onBlocked called with %v for an unblocked acquire", d)
+	default:
+	}
+
+	const wait = 50 * time.Millisecond
+	go func() {
+		time.Sleep(wait)
+		fc.release(ctx, 1)
+	}()
+	if err := fc.acquire(ctx, 1); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case d := <-blocked:
+		if d < wait/2 {
+			t.Errorf("got blocked for %v, want at least %v", d, wait/2)
+		}
+	default:
+		t.Fatal("onBlocked was not called for a blocked acquire")
+	}
+}
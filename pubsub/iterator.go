@@ -96,6 +96,10 @@ type messageIterator struct {
 	eoMu                      sync.RWMutex
 	enableExactlyOnceDelivery bool
 	sendNewAckDeadline        bool
+
+	// lastAckDeadline is the deadline last reported to
+	// pullOptions.onAckDeadlineChange. Only accessed by sender.
+	lastAckDeadline time.Duration
 }
 
 // newMessageIterator starts and returns a new messageIterator.
@@ -375,8 +379,11 @@ func (it *messageIterator) sender() {
 		sendNacks := false
 		sendModAcks := false
 		sendPing := false
+		recordLeases := false
+		var extended, expired int
 
 		dl := it.ackDeadline()
+		it.reportAckDeadline(dl)
 
 		select {
 		case <-it.failed:
@@ -394,7 +401,8 @@ func (it *messageIterator) sender() {
 
 		case <-it.kaTick:
 			it.mu.Lock()
-			it.handleKeepAlives()
+			extended, expired = it.handleKeepAlives()
+			recordLeases = true
 			sendModAcks = (len(it.pendingModAcks) > 0)
 
 			nextTick := dl - gracePeriod
@@ -444,6 +452,9 @@ func (it *messageIterator) sender() {
 		if sendModAcks {
 			it.sendModAck(modAcks, dl, true)
 		}
+		if recordLeases {
+			it.recordLeases(extended, expired, dl)
+		}
 		if sendPing {
 			it.pingStream()
 		}
@@ -451,10 +462,11 @@ func (it *messageIterator) sender() {
 }
 
 // handleKeepAlives modifies the pending request to include deadline extensions
-// for live messages. It also purges expired messages.
+// for live messages. It also purges expired messages. It returns the number of
+// messages to extend and the number of messages that expired.
 //
 // Called with the lock held.
-func (it *messageIterator) handleKeepAlives() {
+func (it *messageIterator) handleKeepAlives() (extended, expired int) {
 	now := time.Now()
 	for id, expiry := range it.keepAliveDeadlines {
 		if expiry.Before(now) {
@@ -463,12 +475,28 @@ func (it *messageIterator) handleKeepAlives() {
 			// statements with range clause", note 3, and stated explicitly at
 			// https://groups.google.com/forum/#!msg/golang-nuts/UciASUb03Js/pzSq5iVFAQAJ.
 			delete(it.keepAliveDeadlines, id)
+			expired++
 		} else {
 			// Use a success AckResult since we don't propagate ModAcks back to the user.
 			it.pendingModAcks[id] = newSuccessAckResult()
+			extended++
 		}
 	}
 	it.checkDrained()
+	return extended, expired
+}
+
+// recordLeases reports the result of handleKeepAlives to the stats measures
+// and the user's LeaseObserver.
+func (it *messageIterator) recordLeases(extended, expired int, deadline time.Duration) {
+	if extended > 0 {
+		recordStat(it.ctx, LeaseExtensionCount, int64(extended))
+	}
+	if expired > 0 {
+		recordStat(it.ctx, ExpiredLeaseCount, int64(expired))
+	}
+	it.po.leaseObserver.leaseExtended(extended, deadline)
+	it.po.leaseObserver.leaseExpired(expired)
 }
 
 // sendAck is used to confirm acknowledgement of a message. If exactly once delivery is
@@ -700,18 +728,32 @@ func splitRequestIDs(ids []string, maxBatchSize int) (prefix, remainder []string
 
 // The deadline to ack is derived from a percentile distribution based
 // on the time it takes to process messages. The percentile chosen is the 99%th
-// percentile - that is, processing times up to the 99%th longest processing
+// percentile by default - that is, processing times up to the 99%th longest processing
 // times should be safe. The highest 1% may expire. This number was chosen
 // as a way to cover most users' usecases without losing the value of
-// expiration.
+// expiration. It can be changed with ReceiveSettings.AckDeadlinePercentile.
 func (it *messageIterator) ackDeadline() time.Duration {
-	pt := time.Duration(it.ackTimeDist.Percentile(.99)) * time.Second
+	p := it.po.ackDeadlinePercentile
+	if p == 0 {
+		p = DefaultReceiveSettings.AckDeadlinePercentile
+	}
+	pt := time.Duration(it.ackTimeDist.Percentile(p)) * time.Second
 	it.eoMu.RLock()
 	enableExactlyOnce := it.enableExactlyOnceDelivery
 	it.eoMu.RUnlock()
 	return boundedDuration(pt, it.po.minExtensionPeriod, it.po.maxExtensionPeriod, enableExactlyOnce)
 }
 
+// reportAckDeadline calls the user's OnAckDeadlineChange callback if the ack
+// deadline differs from the one last reported.
+func (it *messageIterator) reportAckDeadline(dl time.Duration) {
+	if it.po.onAckDeadlineChange == nil || dl == it.lastAckDeadline {
+		return
+	}
+	it.lastAckDeadline = dl
+	it.po.onAckDeadlineChange(dl)
+}
+
 func boundedDuration(ackDeadline, minExtension, maxExtension time.Duration, exactlyOnce bool) time.Duration {
 	// If the user explicitly sets a maxExtensionPeriod, respect it.
 	if maxExtension > 0 {
@@ -824,3 +824,126 @@ func TestExactlyOnceProcessRequests(t *testing.T) {
 		}
 	})
 }
+
+func TestHandleKeepAlivesLeaseObserver(t *testing.T) {
+	c, _ := newFake(t)
+
+	var gotExtended, gotExpired int
+	var gotDeadline time.Duration
+	iter := newMessageIterator(c.subc, "some-sub", &pullOptions{
+		leaseObserver: &LeaseObserver{
+			LeaseExtended: func(n int, d time.Duration) {
+				gotExtended = n
+				gotDeadline = d
+			},
+			LeaseExpired: func(n int) { gotExpired = n },
+		},
+	})
+	defer func() {
+		// Drop the remaining leases so stop doesn't wait for them to be acked.
+		iter.mu.Lock()
+		iter.keepAliveDeadlines = map[string]time.Time{}
+		iter.mu.Unlock()
+		iter.stop()
+	}()
+
+	now := time.Now()
+	iter.mu.Lock()
+	iter.keepAliveDeadlines["live1"] = now.Add(time.Hour)
+	iter.keepAliveDeadlines["live2"] = now.Add(time.Hour)
+	iter.keepAliveDeadlines["expired"] = now.Add(-time.Second)
+	extended, expired := iter.handleKeepAlives()
+	_, stillLeased := iter.keepAliveDeadlines["expired"]
+	iter.mu.Unlock()
+	if extended != 2 || expired != 1 {
+		t.Errorf("handleKeepAlives() = %d, %d; want 2, 1", extended, expired)
+	}
+	if stillLeased {
+		t.Error("expired message is still leased")
+	}
+
+	iter.recordLeases(extended, expired, 30*time.Second)
+	if gotExtended != 2 || gotDeadline != 30*time.Second {
+		t.Errorf("LeaseExtended got (%d, %v), want (2, 30s)", gotExtended, gotDeadline)
+	}
+	if gotExpired != 1 {
+		t.Errorf("LeaseExpired got %d, want 1", gotExpired)
+	}
+}
+
+func TestAckDeadlinePercentile(t *testing.T) {
+	c, _ := newFake(t)
+
+	var changes []time.Duration
+	iter := newMessageIterator(c.subc, "some-sub", &pullOptions{
+		ackDeadlinePercentile: 0.5,
+		onAckDeadlineChange:   func(d time.Duration) { changes = append(changes, d) },
+	})
+	for _, s := range []int{20, 30, 40, 500} {
+		iter.ackTimeDist.Record(s)
+	}
+	want := 30 * time.Second
+	got := iter.ackDeadline()
+	if got != want {
+		t.Errorf("ackDeadline() = %v, want %v", got, want)
+	}
+
+	iter.reportAckDeadline(got)
+	iter.reportAckDeadline(got)
+	iter.reportAckDeadline(time.Minute)
+	if diff := testutil.Diff(changes, []time.Duration{want, time.Minute}); diff != "" {
+		t.Errorf("OnAckDeadlineChange calls -got, +want:\n%s", diff)
+	}
+}
+
+func TestReceiveAckDeadlineSettings(t *testing.T) {
+	ctx := context.Background()
+	client, srv := newFake(t)
+	defer client.Close()
+	defer srv.Close()
+
+	topic := mustCreateTopic(t, client, "t")
+	sub, err := client.CreateSubscription(ctx, "s", SubscriptionConfig{Topic: topic})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, rs := range []ReceiveSettings{
+		{FixedAckDeadline: time.Second},
+		{FixedAckDeadline: time.Hour},
+		{AckDeadlinePercentile: -0.5},
+		{AckDeadlinePercentile: 1.5},
+	} {
+		sub.ReceiveSettings = rs
+		if err := sub.Receive(ctx, func(context.Context, *Message) {}); err == nil {
+			t.Errorf("%+v: got nil error, want error", rs)
+		}
+	}
+
+	srv.Publish(topic.String(), []byte("m"), nil)
+	fixed := 42 * time.Second
+	var mu sync.Mutex
+	var got []time.Duration
+	sub.ReceiveSettings = ReceiveSettings{
+		NumGoroutines:    1,
+		FixedAckDeadline: fixed,
+		OnAckDeadlineChange: func(d time.Duration) {
+			mu.Lock()
+			got = append(got, d)
+			mu.Unlock()
+		},
+	}
+	cctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	err = sub.Receive(cctx, func(_ context.Context, m *Message) {
+		m.Ack()
+		cancel()
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if diff := testutil.Diff(got, []time.Duration{fixed}); diff != "" {
+		t.Errorf("OnAckDeadlineChange calls -got, +want:\n%s", diff)
+	}
+}
@@ -0,0 +1,63 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pubsub
+
+import "time"
+
+// LeaseObserver receives notifications about the leases Receive holds on
+// the messages it has delivered, to help diagnose messages that are
+// redelivered or processed slowly. Any of its functions may be nil.
+//
+// The functions are called synchronously from the goroutines that manage
+// leases and pull messages, so they should return quickly. Receive uses
+// ReceiveSettings.NumGoroutines streams, each of which reports separately.
+//
+// The same information is recorded in the LeaseExtensionCount,
+// ExpiredLeaseCount and FlowControlBlockingLatency measures.
+type LeaseObserver struct {
+	// LeaseExtended is called after the client extends the ack deadline of
+	// the messages outstanding on a stream. outstanding is the number of
+	// messages whose leases were extended, and deadline is the new ack
+	// deadline.
+	LeaseExtended func(outstanding int, deadline time.Duration)
+
+	// LeaseExpired is called with the number of messages whose leases are
+	// no longer extended because they were neither acked nor nacked within
+	// ReceiveSettings.MaxExtension. These messages will be redelivered.
+	LeaseExpired func(count int)
+
+	// FlowControlBlocked is called when Receive had to wait for outstanding
+	// messages to be acked or nacked before it could deliver another message,
+	// with the time it spent waiting.
+	FlowControlBlocked func(d time.Duration)
+}
+
+func (o *LeaseObserver) leaseExtended(outstanding int, deadline time.Duration) {
+	if o != nil && o.LeaseExtended != nil && outstanding > 0 {
+		o.LeaseExtended(outstanding, deadline)
+	}
+}
+
+func (o *LeaseObserver) leaseExpired(count int) {
+	if o != nil && o.LeaseExpired != nil && count > 0 {
+		o.LeaseExpired(count)
+	}
+}
+
+func (o *LeaseObserver) flowControlBlocked(d time.Duration) {
+	if o != nil && o.FlowControlBlocked != nil {
+		o.FlowControlBlocked(d)
+	}
+}
@@ -22,6 +22,7 @@ import (
 	"reflect"
 	"runtime"
 	"strings"
+	"sync"
 	"time"
 
 	"cloud.google.com/go/internal/detect"
@@ -51,12 +52,31 @@ type Client struct {
 	projectID string
 	pubc      *vkit.PublisherClient
 	subc      *vkit.SubscriberClient
+
+	// schemac fetches the schemas of topics which validate messages. It is
+	// made on first use, on the connection of pubc, and is guarded by
+	// schemaMu.
+	schemaMu sync.Mutex
+	schemac  *vkit.SchemaClient
+
+	enableTracePropagation bool
 }
 
 // ClientConfig has configurations for the client.
 type ClientConfig struct {
 	PublisherCallOptions  *vkit.PublisherCallOptions
 	SubscriberCallOptions *vkit.SubscriberCallOptions
+
+	// EnableTracePropagation propagates OpenTelemetry trace context from
+	// publishers to subscribers. When set, the W3C trace context of the ctx
+	// passed to Topic.Publish is stored in the message's attributes, and the
+	// ctx passed to the Subscription.Receive callback carries the span context
+	// of the publisher, so spans started from it are linked to the publishing
+	// span.
+	//
+	// The attributes are prefixed with "googclient_", for instance
+	// "googclient_traceparent", and count towards the message's size.
+	EnableTracePropagation bool
 }
 
 // mergePublisherCallOptions merges two PublisherCallOptions into one and the first argument has
@@ -183,11 +203,15 @@ func NewClientWithConfig(ctx context.Context, projectID string, config *ClientCo
 		return nil, err
 	}
 
-	return &Client{
+	c = &Client{
 		projectID: projectID,
 		pubc:      pubc,
 		subc:      subc,
-	}, nil
+	}
+	if config != nil {
+		c.enableTracePropagation = config.EnableTracePropagation
+	}
+	return c, nil
 }
 
 // Project returns the project ID or number for this instance of the client, which may have
@@ -204,6 +228,14 @@ func (c *Client) Project() string {
 func (c *Client) Close() error {
 	pubErr := c.pubc.Close()
 	subErr := c.subc.Close()
+	c.schemaMu.Lock()
+	if c.schemac != nil {
+		// The schema client shares the connection of the publisher client,
+		// which is closed already, so its error is of no interest.
+		c.schemac.Close()
+		c.schemac = nil
+	}
+	c.schemaMu.Unlock()
 	if pubErr != nil {
 		return fmt.Errorf("pubsub publisher closing error: %w", pubErr)
 	}
@@ -0,0 +1,124 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pubsub
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// batchItem is a message waiting to be delivered as part of a batch.
+type batchItem struct {
+	ctx  context.Context
+	msg  *Message
+	done chan struct{}
+}
+
+// ReceiveBatch is like Receive, but calls f with batches of up to
+// s.ReceiveSettings.BatchSize messages. A batch is delivered once it is full,
+// or once s.ReceiveSettings.BatchDelay has passed since its first message was
+// received.
+//
+// f returns one decision per message in the batch: the message at index i is
+// acknowledged if the i-th decision is true and nacked otherwise. Messages
+// without a decision, for instance because f returned a short or nil slice,
+// are nacked. Messages must not be acked or nacked directly by f.
+//
+// f is never called concurrently with itself. When message ordering is
+// enabled, a batch contains at most one message per ordering key, since
+// messages with the same key are only delivered after the previous one has
+// been handled.
+//
+// Each Subscription may have only one invocation of Receive or ReceiveBatch
+// active at a time.
+func (s *Subscription) ReceiveBatch(ctx context.Context, f func(context.Context, []*Message) []bool) error {
+	size := s.ReceiveSettings.BatchSize
+	if size <= 0 {
+		size = DefaultReceiveSettings.BatchSize
+	}
+	delay := s.ReceiveSettings.BatchDelay
+	if delay <= 0 {
+		delay = DefaultReceiveSettings.BatchDelay
+	}
+
+	items := make(chan *batchItem)
+	stop := make(chan struct{})
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		runBatcher(items, stop, size, delay, f)
+	}()
+
+	// Each message's callback blocks until its batch has been handled, so
+	// that flow control and Receive's shutdown wait on batched messages just
+	// as they do on individual ones.
+	err := s.Receive(ctx, func(ctx context.Context, msg *Message) {
+		it := &batchItem{ctx: ctx, msg: msg, done: make(chan struct{})}
+		items <- it
+		<-it.done
+	})
+	close(stop)
+	wg.Wait()
+	return err
+}
+
+// runBatcher collects items into batches and hands them to f until stop is
+// closed.
+func runBatcher(items <-chan *batchItem, stop <-chan struct{}, size int, delay time.Duration, f func(context.Context, []*Message) []bool) {
+	for {
+		var batch []*batchItem
+		select {
+		case it := <-items:
+			batch = append(batch, it)
+		case <-stop:
+			return
+		}
+		ctx := batch[0].ctx
+		timer := time.NewTimer(delay)
+	collect:
+		for len(batch) < size {
+			select {
+			case it := <-items:
+				batch = append(batch, it)
+			case <-timer.C:
+				break collect
+			case <-ctx.Done():
+				// Deliver what we have rather than holding messages
+				// while Receive shuts down.
+				break collect
+			}
+		}
+		timer.Stop()
+		deliverBatch(ctx, batch, f)
+	}
+}
+
+func deliverBatch(ctx context.Context, batch []*batchItem, f func(context.Context, []*Message) []bool) {
+	msgs := make([]*Message, len(batch))
+	for i, it := range batch {
+		msgs[i] = it.msg
+	}
+	decisions := f(ctx, msgs)
+	for i, it := range batch {
+		if i < len(decisions) && decisions[i] {
+			it.msg.Ack()
+		} else {
+			it.msg.Nack()
+		}
+		close(it.done)
+	}
+}
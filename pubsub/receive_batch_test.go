@@ -0,0 +1,73 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pubsub
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestReceiveBatch(t *testing.T) {
+	ctx := context.Background()
+	client, srv := newFake(t)
+	defer client.Close()
+	defer srv.Close()
+
+	topic := mustCreateTopic(t, client, "t")
+	sub, err := client.CreateSubscription(ctx, "s", SubscriptionConfig{Topic: topic})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	const n = 25
+	for i := 0; i < n; i++ {
+		srv.Publish(topic.String(), []byte(fmt.Sprint(i)), nil)
+	}
+
+	sub.ReceiveSettings.BatchSize = 10
+	sub.ReceiveSettings.BatchDelay = 50 * time.Millisecond
+	cctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	acked := make(map[string]bool)
+	nacked := false
+	err = sub.ReceiveBatch(cctx, func(_ context.Context, msgs []*Message) []bool {
+		if len(msgs) > 10 {
+			t.Errorf("got batch of %d messages, want at most 10", len(msgs))
+		}
+		decisions := make([]bool, len(msgs))
+		for i, m := range msgs {
+			// Nack the first message once to check it is redelivered.
+			if !nacked {
+				nacked = true
+				continue
+			}
+			decisions[i] = true
+			acked[string(m.Data)] = true
+		}
+		if len(acked) == n {
+			cancel()
+		}
+		return decisions
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(acked) != n {
+		t.Errorf("acked %d distinct messages, want %d", len(acked), n)
+	}
+}
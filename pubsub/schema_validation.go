@@ -0,0 +1,386 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pubsub
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"sync"
+	"time"
+
+	vkit "cloud.google.com/go/pubsub/apiv1"
+	pb "cloud.google.com/go/pubsub/apiv1/pubsubpb"
+	"google.golang.org/api/iterator"
+	"google.golang.org/api/option"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+const (
+	// defaultSchemaCacheExpiration is how long a topic's schema revisions are
+	// cached when SchemaValidationSettings.CacheExpiration is unset.
+	defaultSchemaCacheExpiration = 5 * time.Minute
+
+	// schemaFetchRetryInterval is how long after failing to fetch a topic's
+	// schema it is fetched again.
+	schemaFetchRetryInterval = 10 * time.Second
+)
+
+// ErrUnsupportedSchemaType is returned by a SchemaValidator for schemas of a
+// type it cannot validate against.
+var ErrUnsupportedSchemaType = errors.New("pubsub: schema type not supported by the validator")
+
+// SchemaValidator validates the data of an outgoing message on the client,
+// before it is sent to the server.
+type SchemaValidator interface {
+	// Validate returns a non-nil error if data, encoded with encoding, does
+	// not conform to the schema revision described by schema.
+	//
+	// If the validator does not support the type of schema, Validate returns
+	// an error wrapping ErrUnsupportedSchemaType. Publish then fails with
+	// that error, rather than with a *SchemaValidationError.
+	Validate(data []byte, encoding SchemaEncoding, schema *SchemaConfig) error
+}
+
+// SchemaValidationSettings configures client-side validation of published
+// messages against the schema attached to a topic.
+//
+// When enabled, the topic's schema settings and the schema revisions they
+// allow are fetched from the server and cached. A message is accepted if it
+// is valid against at least one of the allowed revisions. Messages that fail
+// validation are never sent; their PublishResult instead holds a
+// *SchemaValidationError.
+//
+// Publish may block while the schema is fetched for the first time. When the
+// cache expires, the schema is fetched again while messages are validated
+// against the revisions fetched before.
+//
+// If the schema cannot be fetched, messages are validated against the
+// revisions fetched before, if any, and are otherwise published without
+// validation, unless FailOnFetchError is set.
+//
+// How closely messages are checked against each revision depends on the
+// Validator; see NewProtoSchemaValidator.
+type SchemaValidationSettings struct {
+	// Validator checks message data against a single schema revision.
+	// It is required.
+	Validator SchemaValidator
+
+	// CacheExpiration is how long the topic's schema revisions are cached
+	// before being fetched again.
+	//
+	// Defaults to 5 minutes.
+	CacheExpiration time.Duration
+
+	// FailOnFetchError makes Publish fail with the error of fetching the
+	// schema when no revisions of it were fetched before, rather than
+	// publish the message without validation.
+	FailOnFetchError bool
+}
+
+// SchemaValidationError is returned in a PublishResult when a message does
+// not conform to any schema revision allowed by the topic.
+type SchemaValidationError struct {
+	// Schema is the name of the schema the message was validated against.
+	Schema string
+
+	// Errors holds the validation error for each allowed revision,
+	// keyed by revision ID.
+	Errors map[string]error
+}
+
+func (e *SchemaValidationError) Error() string {
+	ids := make([]string, 0, len(e.Errors))
+	for id := range e.Errors {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	msg := fmt.Sprintf("pubsub: message failed validation against schema %s", e.Schema)
+	for _, id := range ids {
+		msg += fmt.Sprintf("; revision %s: %v", id, e.Errors[id])
+	}
+	return msg
+}
+
+// topicSchemaCache holds the schema revisions a topic's messages are validated
+// against.
+type topicSchemaCache struct {
+	mu sync.Mutex
+	// refresh is closed when the fetch in progress, if any, ends.
+	refresh  chan struct{}
+	fetched  time.Time
+	retryAt  time.Time // after a failed fetch, when to fetch again
+	fetchErr error     // the error of the last fetch
+	schema   topicSchema
+}
+
+// topicSchema is the schema of a topic and the revisions it allows.
+type topicSchema struct {
+	name      string
+	encoding  SchemaEncoding
+	revisions []*SchemaConfig
+}
+
+// stale reports whether the schema should be fetched. c.mu must be held.
+func (c *topicSchemaCache) stale(exp time.Duration) bool {
+	now := time.Now()
+	return (c.fetched.IsZero() || now.Sub(c.fetched) > exp) && !now.Before(c.retryAt)
+}
+
+// validateSchema validates msg against the cached schema revisions of the
+// topic, refreshing the cache if needed. It returns nil if the topic has no
+// schema.
+func (t *Topic) validateSchema(ctx context.Context, msg *Message) error {
+	settings := t.PublishSettings.SchemaValidation
+	if settings.Validator == nil {
+		return errors.New("pubsub: SchemaValidationSettings.Validator must be set")
+	}
+	exp := settings.CacheExpiration
+	if exp <= 0 {
+		exp = defaultSchemaCacheExpiration
+	}
+
+	c := &t.schemaCache
+	c.mu.Lock()
+	for c.stale(exp) {
+		if c.refresh != nil {
+			if !c.fetched.IsZero() {
+				// Use the revisions fetched before while they are fetched
+				// again.
+				break
+			}
+			refresh := c.refresh
+			c.mu.Unlock()
+			select {
+			case <-refresh:
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+			c.mu.Lock()
+			continue
+		}
+		refresh := make(chan struct{})
+		c.refresh = refresh
+		c.mu.Unlock()
+		ts, err := t.fetchSchema(ctx)
+		c.mu.Lock()
+		c.refresh = nil
+		close(refresh)
+		c.fetchErr = err
+		if err != nil {
+			c.retryAt = time.Now().Add(schemaFetchRetryInterval)
+			break
+		}
+		c.schema, c.fetched = ts, time.Now()
+	}
+	if c.fetched.IsZero() && c.fetchErr != nil && settings.FailOnFetchError {
+		err := c.fetchErr
+		c.mu.Unlock()
+		return fmt.Errorf("pubsub: fetching schema for validation: %w", err)
+	}
+	ts := c.schema
+	c.mu.Unlock()
+
+	if ts.name == "" {
+		return nil
+	}
+	verr := &SchemaValidationError{Schema: ts.name, Errors: make(map[string]error)}
+	for _, rev := range ts.revisions {
+		err := settings.Validator.Validate(msg.Data, ts.encoding, rev)
+		if err == nil {
+			return nil
+		}
+		if errors.Is(err, ErrUnsupportedSchemaType) {
+			return err
+		}
+		verr.Errors[rev.RevisionID] = err
+	}
+	return verr
+}
+
+// fetchSchema fetches the topic's schema settings and the schema revisions
+// they allow.
+func (t *Topic) fetchSchema(ctx context.Context) (topicSchema, error) {
+	pbt, err := t.c.pubc.GetTopic(ctx, &pb.GetTopicRequest{Topic: t.name})
+	if err != nil {
+		return topicSchema{}, err
+	}
+	ss := protoToSchemaSettings(pbt.SchemaSettings)
+	if ss == nil || ss.Schema == "" {
+		return topicSchema{}, nil
+	}
+	sc, err := t.c.schemaClient(ctx)
+	if err != nil {
+		return topicSchema{}, err
+	}
+	var all []*SchemaConfig
+	it := sc.ListSchemaRevisions(ctx, &pb.ListSchemaRevisionsRequest{
+		Name: ss.Schema,
+		View: pb.SchemaView_FULL,
+	})
+	for {
+		s, err := it.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return topicSchema{}, err
+		}
+		all = append(all, protoToSchemaConfig(s))
+	}
+	revisions, err := allowedRevisions(all, ss.FirstRevisionID, ss.LastRevisionID)
+	if err != nil {
+		return topicSchema{}, err
+	}
+	return topicSchema{name: ss.Schema, encoding: ss.Encoding, revisions: revisions}, nil
+}
+
+// schemaClient returns the client's schema client, making it if needed.
+func (c *Client) schemaClient(ctx context.Context) (*vkit.SchemaClient, error) {
+	c.schemaMu.Lock()
+	defer c.schemaMu.Unlock()
+	if c.schemac == nil {
+		// Share the publisher's connection rather than dialing a new one.
+		sc, err := vkit.NewSchemaClient(ctx, option.WithGRPCConn(c.pubc.Connection()))
+		if err != nil {
+			return nil, err
+		}
+		c.schemac = sc
+	}
+	return c.schemac, nil
+}
+
+// allowedRevisions returns the revisions between first and last (inclusive)
+// in creation order. An empty first or last leaves that end of the range open.
+func allowedRevisions(all []*SchemaConfig, first, last string) ([]*SchemaConfig, error) {
+	sort.SliceStable(all, func(i, j int) bool {
+		return all[i].RevisionCreateTime.Before(all[j].RevisionCreateTime)
+	})
+	lo, hi := 0, len(all)-1
+	if first != "" {
+		lo = -1
+		for i, s := range all {
+			if s.RevisionID == first {
+				lo = i
+			}
+		}
+		if lo < 0 {
+			return nil, fmt.Errorf("first revision %q not found", first)
+		}
+	}
+	if last != "" {
+		hi = -1
+		for i, s := range all {
+			if s.RevisionID == last {
+				hi = i
+			}
+		}
+		if hi < 0 {
+			return nil, fmt.Errorf("last revision %q not found", last)
+		}
+	}
+	if lo > hi {
+		return nil, fmt.Errorf("no revisions between %q and %q", first, last)
+	}
+	return all[lo : hi+1], nil
+}
+
+// protoSchemaValidator validates messages against a compiled protocol buffer
+// message descriptor.
+type protoSchemaValidator struct {
+	md protoreflect.MessageDescriptor
+	re *regexp.Regexp
+}
+
+// NewProtoSchemaValidator returns a SchemaValidator for topics with protocol
+// buffer schemas. Messages are decoded into the message type described by md,
+// which must be the top-level message type declared by the schema.
+//
+// Both binary and JSON encodings are supported. Data that cannot be decoded,
+// contains unknown fields or is missing required fields is rejected.
+//
+// The validator does not compile the definitions of the topic's schema
+// revisions: data is checked against md alone, for every allowed revision,
+// and a revision is only checked to declare a message with the name of md.
+// Fields added or removed by a revision are not detected, so md must match
+// all the revisions the topic allows.
+//
+// Avro schemas are not supported. For topics with Avro schemas, Publish fails
+// with an error wrapping ErrUnsupportedSchemaType.
+func NewProtoSchemaValidator(md protoreflect.MessageDescriptor) SchemaValidator {
+	return &protoSchemaValidator{
+		md: md,
+		re: regexp.MustCompile(`\bmessage\s+` + regexp.QuoteMeta(string(md.Name())) + `\s*\{`),
+	}
+}
+
+func (v *protoSchemaValidator) Validate(data []byte, encoding SchemaEncoding, schema *SchemaConfig) error {
+	if schema.Type != SchemaProtocolBuffer {
+		return fmt.Errorf("%w: %s is not a protocol buffer schema", ErrUnsupportedSchemaType, schema.Name)
+	}
+	if !v.re.MatchString(schema.Definition) {
+		return fmt.Errorf("schema revision does not declare message %s", v.md.Name())
+	}
+	m := dynamicpb.NewMessage(v.md)
+	switch encoding {
+	case EncodingBinary:
+		if err := proto.Unmarshal(data, m); err != nil {
+			return err
+		}
+		return checkUnknownFields(m.ProtoReflect())
+	case EncodingJSON:
+		return protojson.Unmarshal(data, m)
+	default:
+		return fmt.Errorf("unsupported encoding %v", encoding)
+	}
+}
+
+// checkUnknownFields returns an error if m or any message nested within it
+// holds fields not present in its descriptor.
+func checkUnknownFields(m protoreflect.Message) error {
+	if len(m.GetUnknown()) > 0 {
+		return fmt.Errorf("unknown fields in %s", m.Descriptor().FullName())
+	}
+	var err error
+	m.Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		if fd.Message() == nil {
+			return true
+		}
+		switch {
+		case fd.IsList():
+			l := v.List()
+			for i := 0; i < l.Len() && err == nil; i++ {
+				err = checkUnknownFields(l.Get(i).Message())
+			}
+		case fd.IsMap():
+			if fd.MapValue().Message() == nil {
+				return true
+			}
+			v.Map().Range(func(_ protoreflect.MapKey, mv protoreflect.Value) bool {
+				err = checkUnknownFields(mv.Message())
+				return err == nil
+			})
+		default:
+			err = checkUnknownFields(v.Message())
+		}
+		return err == nil
+	})
+	return err
+}
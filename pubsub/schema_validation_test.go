@@ -0,0 +1,218 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pubsub
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/internal/testutil"
+	pb "cloud.google.com/go/pubsub/apiv1/pubsubpb"
+	"cloud.google.com/go/pubsub/pstest"
+	"google.golang.org/api/option"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+const testProtoSchemaDefinition = `syntax = "proto3";
+message SchemaSettings {
+  string schema = 1;
+  int32 encoding = 2;
+  string first_revision_id = 3;
+  string last_revision_id = 4;
+}`
+
+func TestPublishSchemaValidation(t *testing.T) {
+	ctx := context.Background()
+	client, srv := newFake(t)
+	defer client.Close()
+	defer srv.Close()
+
+	admin, err := NewSchemaClient(ctx, projName, option.WithEndpoint(srv.Addr), option.WithoutAuthentication(), option.WithGRPCDialOption(grpc.WithInsecure()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer admin.Close()
+	sc, err := admin.CreateSchema(ctx, "s", SchemaConfig{
+		Type:       SchemaProtocolBuffer,
+		Definition: testProtoSchemaDefinition,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	topic := mustCreateTopicWithConfig(t, client, "t", &TopicConfig{
+		SchemaSettings: &SchemaSettings{
+			Schema:   sc.Name,
+			Encoding: EncodingBinary,
+		},
+	})
+	defer topic.Stop()
+	topic.PublishSettings.SchemaValidation = &SchemaValidationSettings{
+		Validator: NewProtoSchemaValidator((&pb.SchemaSettings{}).ProtoReflect().Descriptor()),
+	}
+
+	valid, err := proto.Marshal(&pb.SchemaSettings{Schema: "x", FirstRevisionId: "y"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := topic.Publish(ctx, &Message{Data: valid}).Get(ctx); err != nil {
+		t.Errorf("Publish(valid): %v", err)
+	}
+
+	// Field number 9 is not declared by the schema.
+	invalid := append(valid, 0x48, 0x01)
+	_, err = topic.Publish(ctx, &Message{Data: invalid}).Get(ctx)
+	var verr *SchemaValidationError
+	if !errors.As(err, &verr) {
+		t.Fatalf("Publish(invalid): got %v, want *SchemaValidationError", err)
+	}
+	if verr.Schema != sc.Name {
+		t.Errorf("got schema %q, want %q", verr.Schema, sc.Name)
+	}
+	if _, ok := verr.Errors[sc.RevisionID]; !ok {
+		t.Errorf("missing error for revision %q: %v", sc.RevisionID, verr)
+	}
+}
+
+func TestPublishSchemaValidationAvro(t *testing.T) {
+	ctx := context.Background()
+	client, srv := newFake(t)
+	defer client.Close()
+	defer srv.Close()
+
+	admin, err := NewSchemaClient(ctx, projName, option.WithEndpoint(srv.Addr), option.WithoutAuthentication(), option.WithGRPCDialOption(grpc.WithInsecure()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer admin.Close()
+	sc, err := admin.CreateSchema(ctx, "s", SchemaConfig{
+		Type:       SchemaAvro,
+		Definition: `{"type": "record", "name": "R", "fields": []}`,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	topic := mustCreateTopicWithConfig(t, client, "t", &TopicConfig{
+		SchemaSettings: &SchemaSettings{
+			Schema:   sc.Name,
+			Encoding: EncodingJSON,
+		},
+	})
+	defer topic.Stop()
+	topic.PublishSettings.SchemaValidation = &SchemaValidationSettings{
+		Validator: NewProtoSchemaValidator((&pb.SchemaSettings{}).ProtoReflect().Descriptor()),
+	}
+
+	_, err = topic.Publish(ctx, &Message{Data: []byte(`{}`)}).Get(ctx)
+	if !errors.Is(err, ErrUnsupportedSchemaType) {
+		t.Errorf("Publish: got %v, want ErrUnsupportedSchemaType", err)
+	}
+}
+
+func TestPublishSchemaValidationFetchError(t *testing.T) {
+	ctx := context.Background()
+	srv := pstest.NewServer(pstest.WithErrorInjection("GetTopic", codes.PermissionDenied, "denied"))
+	defer srv.Close()
+	client, err := NewClient(ctx, projName,
+		option.WithEndpoint(srv.Addr),
+		option.WithoutAuthentication(),
+		option.WithGRPCDialOption(grpc.WithInsecure()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+
+	topic := mustCreateTopic(t, client, "t")
+	defer topic.Stop()
+	settings := &SchemaValidationSettings{
+		Validator: NewProtoSchemaValidator((&pb.SchemaSettings{}).ProtoReflect().Descriptor()),
+	}
+	topic.PublishSettings.SchemaValidation = settings
+
+	// The message is published without validation.
+	if _, err := topic.Publish(ctx, &Message{Data: []byte("x")}).Get(ctx); err != nil {
+		t.Errorf("Publish: %v", err)
+	}
+
+	settings.FailOnFetchError = true
+	_, err = topic.Publish(ctx, &Message{Data: []byte("x")}).Get(ctx)
+	if status.Code(err) != codes.PermissionDenied {
+		t.Errorf("Publish with FailOnFetchError: got %v, want PermissionDenied", err)
+	}
+}
+
+func TestProtoSchemaValidator(t *testing.T) {
+	v := NewProtoSchemaValidator((&pb.SchemaSettings{}).ProtoReflect().Descriptor())
+	schema := &SchemaConfig{Type: SchemaProtocolBuffer, Definition: testProtoSchemaDefinition}
+	for _, test := range []struct {
+		desc     string
+		data     string
+		encoding SchemaEncoding
+		schema   *SchemaConfig
+		wantErr  bool
+	}{
+		{"valid JSON", `{"schema": "x"}`, EncodingJSON, schema, false},
+		{"unknown JSON field", `{"bogus": 1}`, EncodingJSON, schema, true},
+		{"wrong JSON type", `{"schema": 1}`, EncodingJSON, schema, true},
+		{"avro schema", `{}`, EncodingJSON, &SchemaConfig{Type: SchemaAvro}, true},
+		{"other message", `{}`, EncodingJSON, &SchemaConfig{Type: SchemaProtocolBuffer, Definition: "message Other {}"}, true},
+		{"unspecified encoding", `{}`, EncodingUnspecified, schema, true},
+	} {
+		err := v.Validate([]byte(test.data), test.encoding, test.schema)
+		if gotErr := err != nil; gotErr != test.wantErr {
+			t.Errorf("%s: got error %v, want error: %t", test.desc, err, test.wantErr)
+		}
+	}
+}
+
+func TestAllowedRevisions(t *testing.T) {
+	now := time.Now()
+	all := []*SchemaConfig{
+		{RevisionID: "c", RevisionCreateTime: now.Add(2 * time.Second)},
+		{RevisionID: "a", RevisionCreateTime: now},
+		{RevisionID: "b", RevisionCreateTime: now.Add(time.Second)},
+	}
+	for _, test := range []struct {
+		first, last string
+		want        []string
+		wantErr     bool
+	}{
+		{"", "", []string{"a", "b", "c"}, false},
+		{"b", "", []string{"b", "c"}, false},
+		{"", "b", []string{"a", "b"}, false},
+		{"b", "b", []string{"b"}, false},
+		{"c", "a", nil, true},
+		{"z", "", nil, true},
+	} {
+		got, err := allowedRevisions(all, test.first, test.last)
+		if gotErr := err != nil; gotErr != test.wantErr {
+			t.Errorf("allowedRevisions(%q, %q): got error %v, want error: %t", test.first, test.last, err, test.wantErr)
+			continue
+		}
+		var ids []string
+		for _, s := range got {
+			ids = append(ids, s.RevisionID)
+		}
+		if diff := testutil.Diff(ids, test.want); diff != "" {
+			t.Errorf("allowedRevisions(%q, %q) -got, +want:\n%s", test.first, test.last, diff)
+		}
+	}
+}
@@ -16,11 +16,14 @@ package pubsub
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strings"
 	"time"
 
 	pb "cloud.google.com/go/pubsub/apiv1/pubsubpb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	fmpb "google.golang.org/protobuf/types/known/fieldmaskpb"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
@@ -43,6 +46,32 @@ func (s *Snapshot) ID() string {
 	return s.name[slash+1:]
 }
 
+// String returns the printable globally unique name for the snapshot.
+func (s *Snapshot) String() string {
+	return s.name
+}
+
+// Config fetches the current configuration for the snapshot.
+func (s *Snapshot) Config(ctx context.Context) (*SnapshotConfig, error) {
+	snap, err := s.c.subc.GetSnapshot(ctx, &pb.GetSnapshotRequest{Snapshot: s.name})
+	if err != nil {
+		return nil, err
+	}
+	return toSnapshotConfig(snap, s.c)
+}
+
+// Exists reports whether the snapshot exists on the server.
+func (s *Snapshot) Exists(ctx context.Context) (bool, error) {
+	_, err := s.c.subc.GetSnapshot(ctx, &pb.GetSnapshotRequest{Snapshot: s.name})
+	if err == nil {
+		return true, nil
+	}
+	if status.Code(err) == codes.NotFound {
+		return false, nil
+	}
+	return false, err
+}
+
 // SetLabels sets or replaces the labels on a given snapshot.
 func (s *Snapshot) SetLabels(ctx context.Context, label map[string]string) (*SnapshotConfig, error) {
 	sc, err := s.c.subc.UpdateSnapshot(ctx, &pb.UpdateSnapshotRequest{
@@ -103,6 +132,33 @@ func (snaps *SnapshotConfigIterator) Next() (*SnapshotConfig, error) {
 	return snaps.next()
 }
 
+// Snapshots returns an iterator which returns the snapshots of this topic.
+//
+// Snapshots are returned by name only; call Snapshot.Config to fetch their
+// configuration.
+func (t *Topic) Snapshots(ctx context.Context) *SnapshotIterator {
+	it := t.c.pubc.ListTopicSnapshots(ctx, &pb.ListTopicSnapshotsRequest{Topic: t.name})
+	return &SnapshotIterator{
+		c:    t.c,
+		next: it.Next,
+	}
+}
+
+// SnapshotIterator is an iterator that returns a series of snapshot references.
+type SnapshotIterator struct {
+	c    *Client
+	next func() (string, error)
+}
+
+// Next returns the next snapshot. If there are no more snapshots, iterator.Done will be returned.
+func (snaps *SnapshotIterator) Next() (*Snapshot, error) {
+	name, err := snaps.next()
+	if err != nil {
+		return nil, err
+	}
+	return &Snapshot{c: snaps.c, name: name}, nil
+}
+
 // Delete deletes a snapshot.
 func (s *Snapshot) Delete(ctx context.Context) error {
 	return s.c.subc.DeleteSnapshot(ctx, &pb.DeleteSnapshotRequest{Snapshot: s.name})
@@ -140,12 +196,19 @@ func (s *Subscription) SeekToTime(ctx context.Context, t time.Time) error {
 //	(b) Any messages published to the subscription's topic following
 //	    Snapshot returning without error.
 func (s *Subscription) CreateSnapshot(ctx context.Context, name string) (*SnapshotConfig, error) {
+	return s.CreateSnapshotWithLabels(ctx, name, nil)
+}
+
+// CreateSnapshotWithLabels is like CreateSnapshot, but also sets labels on
+// the created snapshot.
+func (s *Subscription) CreateSnapshotWithLabels(ctx context.Context, name string, labels map[string]string) (*SnapshotConfig, error) {
 	if name != "" {
 		name = fmt.Sprintf("projects/%s/snapshots/%s", strings.Split(s.name, "/")[1], name)
 	}
 	snap, err := s.c.subc.CreateSnapshot(ctx, &pb.CreateSnapshotRequest{
 		Name:         name,
 		Subscription: s.name,
+		Labels:       labels,
 	})
 	if err != nil {
 		return nil, err
@@ -165,6 +228,78 @@ func (s *Subscription) SeekToSnapshot(ctx context.Context, snap *Snapshot) error
 	return err
 }
 
+// defaultSubscriptionRetention is the message retention duration of
+// subscriptions that don't configure one.
+const defaultSubscriptionRetention = 7 * 24 * time.Hour
+
+// ValidateSeekToTime checks that seeking the subscription to t would replay
+// messages as expected, without seeking. It returns an error if t is in the
+// future, lies before the subscription's retention window, or if the
+// subscription discards acknowledged messages so they cannot be replayed.
+func (s *Subscription) ValidateSeekToTime(ctx context.Context, t time.Time) error {
+	cfg, err := s.Config(ctx)
+	if err != nil {
+		return err
+	}
+	return checkSeekToTime(cfg, t, time.Now())
+}
+
+// ValidateSeekToSnapshot checks that the subscription can be sought to snap,
+// without seeking. It returns an error if snap does not exist, has expired, or
+// is for a different topic than the subscription.
+func (s *Subscription) ValidateSeekToSnapshot(ctx context.Context, snap *Snapshot) error {
+	cfg, err := s.Config(ctx)
+	if err != nil {
+		return err
+	}
+	snapCfg, err := snap.Config(ctx)
+	if err != nil {
+		return err
+	}
+	return checkSeekToSnapshot(cfg, snapCfg, time.Now())
+}
+
+func checkSeekToTime(cfg SubscriptionConfig, t, now time.Time) error {
+	if t.IsZero() {
+		return errors.New("pubsub: seek time must be set")
+	}
+	if cfg.Detached {
+		return fmt.Errorf("pubsub: subscription %s is detached from its topic", cfg.name)
+	}
+	if t.After(now) {
+		return fmt.Errorf("pubsub: seek time %v is in the future", t)
+	}
+	retention := cfg.RetentionDuration
+	if retention == 0 {
+		retention = defaultSubscriptionRetention
+	}
+	if !cfg.RetainAckedMessages {
+		if cfg.TopicMessageRetentionDuration == 0 {
+			return fmt.Errorf("pubsub: subscription %s does not retain acknowledged messages, so they cannot be replayed; set RetainAckedMessages or the topic's RetentionDuration", cfg.name)
+		}
+		retention = cfg.TopicMessageRetentionDuration
+	} else if cfg.TopicMessageRetentionDuration > retention {
+		retention = cfg.TopicMessageRetentionDuration
+	}
+	if start := now.Add(-retention); t.Before(start) {
+		return fmt.Errorf("pubsub: seek time %v is before the retention window of subscription %s, which starts at %v", t, cfg.name, start)
+	}
+	return nil
+}
+
+func checkSeekToSnapshot(cfg SubscriptionConfig, snap *SnapshotConfig, now time.Time) error {
+	if cfg.Detached {
+		return fmt.Errorf("pubsub: subscription %s is detached from its topic", cfg.name)
+	}
+	if cfg.Topic == nil || snap.Topic == nil || cfg.Topic.String() != snap.Topic.String() {
+		return fmt.Errorf("pubsub: snapshot %s is for topic %v, but subscription %s is for topic %v", snap.name, snap.Topic, cfg.name, cfg.Topic)
+	}
+	if !snap.Expiration.IsZero() && !snap.Expiration.After(now) {
+		return fmt.Errorf("pubsub: snapshot %s expired at %v", snap.name, snap.Expiration)
+	}
+	return nil
+}
+
 func toSnapshotConfig(snap *pb.Snapshot, c *Client) (*SnapshotConfig, error) {
 	exp := snap.ExpireTime.AsTime()
 	return &SnapshotConfig{
@@ -0,0 +1,68 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pubsub
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCheckSeekToTime(t *testing.T) {
+	now := time.Date(2023, 6, 1, 12, 0, 0, 0, time.UTC)
+	for _, test := range []struct {
+		desc    string
+		cfg     SubscriptionConfig
+		t       time.Time
+		wantErr bool
+	}{
+		{"retained", SubscriptionConfig{RetainAckedMessages: true}, now.Add(-time.Hour), false},
+		{"zero time", SubscriptionConfig{RetainAckedMessages: true}, time.Time{}, true},
+		{"future", SubscriptionConfig{RetainAckedMessages: true}, now.Add(time.Hour), true},
+		{"acked messages dropped", SubscriptionConfig{}, now.Add(-time.Hour), true},
+		{"detached", SubscriptionConfig{RetainAckedMessages: true, Detached: true}, now.Add(-time.Hour), true},
+		{"before default retention", SubscriptionConfig{RetainAckedMessages: true}, now.Add(-8 * 24 * time.Hour), true},
+		{"before subscription retention", SubscriptionConfig{RetainAckedMessages: true, RetentionDuration: time.Hour}, now.Add(-2 * time.Hour), true},
+		{"topic retention", SubscriptionConfig{TopicMessageRetentionDuration: 3 * time.Hour}, now.Add(-2 * time.Hour), false},
+		{"topic retention exceeds subscription", SubscriptionConfig{RetainAckedMessages: true, RetentionDuration: time.Hour, TopicMessageRetentionDuration: 3 * time.Hour}, now.Add(-2 * time.Hour), false},
+	} {
+		err := checkSeekToTime(test.cfg, test.t, now)
+		if gotErr := err != nil; gotErr != test.wantErr {
+			t.Errorf("%s: got error %v, want error: %t", test.desc, err, test.wantErr)
+		}
+	}
+}
+
+func TestCheckSeekToSnapshot(t *testing.T) {
+	now := time.Date(2023, 6, 1, 12, 0, 0, 0, time.UTC)
+	c := &Client{projectID: "P"}
+	topic := c.Topic("t")
+	snap := &Snapshot{c: c, name: "projects/P/snapshots/s"}
+	for _, test := range []struct {
+		desc    string
+		cfg     SubscriptionConfig
+		snap    *SnapshotConfig
+		wantErr bool
+	}{
+		{"valid", SubscriptionConfig{Topic: topic}, &SnapshotConfig{Snapshot: snap, Topic: topic, Expiration: now.Add(time.Hour)}, false},
+		{"other topic", SubscriptionConfig{Topic: topic}, &SnapshotConfig{Snapshot: snap, Topic: c.Topic("other"), Expiration: now.Add(time.Hour)}, true},
+		{"expired", SubscriptionConfig{Topic: topic}, &SnapshotConfig{Snapshot: snap, Topic: topic, Expiration: now.Add(-time.Hour)}, true},
+		{"detached", SubscriptionConfig{Topic: topic, Detached: true}, &SnapshotConfig{Snapshot: snap, Topic: topic, Expiration: now.Add(time.Hour)}, true},
+	} {
+		err := checkSeekToSnapshot(test.cfg, test.snap, now)
+		if gotErr := err != nil; gotErr != test.wantErr {
+			t.Errorf("%s: got error %v, want error: %t", test.desc, err, test.wantErr)
+		}
+	}
+}
@@ -29,6 +29,7 @@ import (
 	pb "cloud.google.com/go/pubsub/apiv1/pubsubpb"
 	"cloud.google.com/go/pubsub/internal/scheduler"
 	gax "github.com/googleapis/gax-go/v2"
+	"go.opencensus.io/stats"
 	"golang.org/x/sync/errgroup"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -934,6 +935,45 @@ type ReceiveSettings struct {
 	// Synchronous to false.
 	// Synchronous mode does not work with exactly once delivery.
 	Synchronous bool
+
+	// BatchSize is the maximum number of messages passed at once to the
+	// function given to ReceiveBatch. If BatchSize is 0, it will be treated as
+	// if it were DefaultReceiveSettings.BatchSize.
+	//
+	// A batch can only be as large as MaxOutstandingMessages allows.
+	BatchSize int
+
+	// BatchDelay is the maximum time ReceiveBatch waits for a batch to fill
+	// before passing a smaller batch to its function. If BatchDelay is 0, it
+	// will be treated as if it were DefaultReceiveSettings.BatchDelay.
+	BatchDelay time.Duration
+
+	// AckDeadlinePercentile is the percentile of observed message processing
+	// times used to choose the deadline of each lease extension. Higher values
+	// extend leases for longer, at the cost of slower redelivery when a
+	// subscriber crashes. It must be between 0 and 1; if it is 0, it will be
+	// treated as if it were DefaultReceiveSettings.AckDeadlinePercentile.
+	// The chosen deadline is still bounded by MinExtensionPeriod and
+	// MaxExtensionPeriod.
+	AckDeadlinePercentile float64
+
+	// FixedAckDeadline disables the automatic tuning of the ack deadline if
+	// positive: every lease extension uses this deadline instead, and
+	// MinExtensionPeriod and MaxExtensionPeriod are ignored.
+	//
+	// FixedAckDeadline must be between 10s and 600s (inclusive).
+	FixedAckDeadline time.Duration
+
+	// OnAckDeadlineChange, if non-nil, is called with the deadline Receive
+	// uses for lease extensions when it is first chosen and whenever it
+	// changes afterwards. It is called separately for each of the
+	// NumGoroutines streams, and should return quickly.
+	OnAckDeadlineChange func(deadline time.Duration)
+
+	// LeaseObserver, if non-nil, is notified of ack deadline extensions,
+	// expired leases and time spent blocked by flow control while Receive
+	// is running.
+	LeaseObserver *LeaseObserver
 }
 
 // For synchronous receive, the time to wait if we are already processing
@@ -963,6 +1003,9 @@ var DefaultReceiveSettings = ReceiveSettings{
 	MaxOutstandingMessages: 1000,
 	MaxOutstandingBytes:    1e9, // 1G
 	NumGoroutines:          10,
+	BatchSize:              100,
+	BatchDelay:             100 * time.Millisecond,
+	AckDeadlinePercentile:  0.99,
 }
 
 // Delete deletes the subscription.
@@ -1265,6 +1308,20 @@ func (s *Subscription) Receive(ctx context.Context, f func(context.Context, *Mes
 	if minExtPeriod < 0 {
 		minExtPeriod = DefaultReceiveSettings.MinExtensionPeriod
 	}
+	if fixed := s.ReceiveSettings.FixedAckDeadline; fixed > 0 {
+		if fixed < minDurationPerLeaseExtension || fixed > maxDurationPerLeaseExtension {
+			return fmt.Errorf("pubsub: ReceiveSettings.FixedAckDeadline %v must be between %v and %v", fixed, minDurationPerLeaseExtension, maxDurationPerLeaseExtension)
+		}
+		// Pinning both bounds to the same value fixes every lease extension,
+		// as well as the stream's ack deadline.
+		minExtPeriod, maxExtPeriod = fixed, fixed
+	}
+	ackPercentile := s.ReceiveSettings.AckDeadlinePercentile
+	if ackPercentile == 0 {
+		ackPercentile = DefaultReceiveSettings.AckDeadlinePercentile
+	} else if ackPercentile < 0 || ackPercentile > 1 {
+		return fmt.Errorf("pubsub: ReceiveSettings.AckDeadlinePercentile %v must be between 0 and 1", ackPercentile)
+	}
 
 	var numGoroutines int
 	switch {
@@ -1285,12 +1342,20 @@ func (s *Subscription) Receive(ctx context.Context, f func(context.Context, *Mes
 		maxOutstandingMessages: maxCount,
 		maxOutstandingBytes:    maxBytes,
 		useLegacyFlowControl:   s.ReceiveSettings.UseLegacyFlowControl,
+		leaseObserver:          s.ReceiveSettings.LeaseObserver,
+		ackDeadlinePercentile:  ackPercentile,
+		onAckDeadlineChange:    s.ReceiveSettings.OnAckDeadlineChange,
 	}
 	fc := newSubscriptionFlowController(FlowControlSettings{
 		MaxOutstandingMessages: maxCount,
 		MaxOutstandingBytes:    maxBytes,
 		LimitExceededBehavior:  FlowControlBlock,
 	})
+	statsCtx := withSubscriptionKey(context.Background(), s.name)
+	fc.onBlocked = func(d time.Duration) {
+		stats.Record(statsCtx, FlowControlBlockingLatency.M(float64(d)/float64(time.Millisecond)))
+		po.leaseObserver.flowControlBlocked(d)
+	}
 
 	sched := scheduler.NewReceiveScheduler(maxCount)
 
@@ -1407,7 +1472,13 @@ func (s *Subscription) Receive(ctx context.Context, f func(context.Context, *Mes
 					// constructor level?
 					if err := sched.Add(key, msg, func(msg interface{}) {
 						defer wg.Done()
-						f(ctx2, msg.(*Message))
+						m := msg.(*Message)
+						decompressMessage(m)
+						fctx := ctx2
+						if s.c.enableTracePropagation {
+							fctx = extractTraceContext(fctx, m)
+						}
+						f(fctx, m)
 					}); err != nil {
 						wg.Done()
 						// If there are any errors with scheduling messages,
@@ -1467,4 +1538,7 @@ type pullOptions struct {
 	maxOutstandingMessages int
 	maxOutstandingBytes    int
 	useLegacyFlowControl   bool
+	leaseObserver          *LeaseObserver
+	ackDeadlinePercentile  float64 // the percentile of processing times used to choose ack deadlines
+	onAckDeadlineChange    func(time.Duration)
 }
@@ -20,6 +20,7 @@ import (
 	"fmt"
 	"log"
 	"runtime"
+	"sort"
 	"strings"
 	"sync"
 	"time"
@@ -79,9 +80,12 @@ type Topic struct {
 	scheduler *scheduler.PublishScheduler
 
 	flowController
+	keyFlowControllers keyFlowControllers
 
 	// EnableMessageOrdering enables delivery of ordered keys.
 	EnableMessageOrdering bool
+
+	schemaCache topicSchemaCache
 }
 
 // PublishSettings control the bundling of published messages.
@@ -117,6 +121,42 @@ type PublishSettings struct {
 
 	// FlowControlSettings defines publisher flow control settings.
 	FlowControlSettings FlowControlSettings
+
+	// Compression is the algorithm used to compress the data of published
+	// messages. Compressed messages carry a "googclient_compression"
+	// attribute naming the algorithm, and are decompressed transparently by
+	// Subscription.Receive. Subscribers using other clients must decompress
+	// such messages themselves.
+	//
+	// Defaults to CompressionNone.
+	Compression Compression
+
+	// CompressionBytesThreshold is the minimum size of a message's data for
+	// it to be compressed. Smaller messages are published uncompressed, since
+	// compression rarely pays off for them. A negative threshold compresses
+	// every message.
+	//
+	// Defaults to DefaultPublishSettings.CompressionBytesThreshold.
+	CompressionBytesThreshold int
+
+	// OrderingKeyFlowControlSettings defines flow control settings that are
+	// applied separately to the messages of each ordering key, in addition
+	// to FlowControlSettings. Messages without an ordering key are not
+	// affected. The zero value disables per-key flow control.
+	OrderingKeyFlowControlSettings FlowControlSettings
+
+	// OnOrderingKeyPaused, if non-nil, is called when publishing for an
+	// ordering key is paused because a message with that key failed to be
+	// published, with the error that caused it. Publishing for the key
+	// remains paused until Topic.ResumePublish is called.
+	//
+	// OnOrderingKeyPaused is called at most once each time a key is paused,
+	// from a goroutine that publishes messages, so it must not block.
+	OnOrderingKeyPaused func(orderingKey string, err error)
+
+	// SchemaValidation, if non-nil, enables validation of messages against
+	// the topic's schema on the client before they are published.
+	SchemaValidation *SchemaValidationSettings
 }
 
 // DefaultPublishSettings holds the default values for topics' PublishSettings.
@@ -125,6 +165,8 @@ var DefaultPublishSettings = PublishSettings{
 	CountThreshold: 100,
 	ByteThreshold:  1e6,
 	Timeout:        60 * time.Second,
+	// Below 240 bytes, compression overhead usually outweighs its gains.
+	CompressionBytesThreshold: 240,
 	// By default, limit the bundler to 10 times the max message size. The number 10 is
 	// chosen as a reasonable amount of messages in the worst case whilst still
 	// capping the number to a low enough value to not OOM users.
@@ -577,6 +619,23 @@ func (t *Topic) Publish(ctx context.Context, msg *Message) *PublishResult {
 		ipubsub.SetPublishResult(r, "", errTopicOrderingNotEnabled)
 		return r
 	}
+	if t.PublishSettings.SchemaValidation != nil {
+		if err := t.validateSchema(ctx, msg); err != nil {
+			ipubsub.SetPublishResult(r, "", err)
+			return r
+		}
+	}
+
+	if t.c.enableTracePropagation {
+		// Copy msg so the caller's attributes are left untouched.
+		m := *msg
+		m.Attributes = injectTraceContext(ctx, msg.Attributes)
+		msg = &m
+	}
+	if msg, err = t.compressMessage(msg); err != nil {
+		ipubsub.SetPublishResult(r, "", err)
+		return r
+	}
 
 	// Calculate the size of the encoded proto message by accounting
 	// for the length of an individual PubSubMessage and Data/Attributes field.
@@ -594,20 +653,41 @@ func (t *Topic) Publish(ctx context.Context, msg *Message) *PublishResult {
 		ipubsub.SetPublishResult(r, "", ErrTopicStopped)
 		return r
 	}
+	if msg.OrderingKey != "" && t.scheduler.IsPaused(msg.OrderingKey) {
+		ipubsub.SetPublishResult(r, "", ErrPublishingPaused{OrderingKey: msg.OrderingKey})
+		return r
+	}
 
 	if err := t.flowController.acquire(ctx, msgSize); err != nil {
-		t.scheduler.Pause(msg.OrderingKey)
+		t.pauseOrderingKey(msg.OrderingKey, err)
+		ipubsub.SetPublishResult(r, "", err)
+		return r
+	}
+	if err := t.keyFlowControllers.acquire(ctx, msg.OrderingKey, msgSize); err != nil {
+		t.flowController.release(ctx, msgSize)
+		t.pauseOrderingKey(msg.OrderingKey, err)
 		ipubsub.SetPublishResult(r, "", err)
 		return r
 	}
 	err = t.scheduler.Add(msg.OrderingKey, &bundledMessage{msg, r, msgSize}, msgSize)
 	if err != nil {
-		t.scheduler.Pause(msg.OrderingKey)
+		t.flowController.release(ctx, msgSize)
+		t.keyFlowControllers.release(ctx, msg.OrderingKey, msgSize)
+		t.pauseOrderingKey(msg.OrderingKey, err)
 		ipubsub.SetPublishResult(r, "", err)
 	}
 	return r
 }
 
+// pauseOrderingKey pauses publishing for orderingKey because of err and
+// notifies PublishSettings.OnOrderingKeyPaused if the key wasn't already
+// paused.
+func (t *Topic) pauseOrderingKey(orderingKey string, err error) {
+	if t.scheduler.Pause(orderingKey) && t.PublishSettings.OnOrderingKeyPaused != nil {
+		t.PublishSettings.OnOrderingKeyPaused(orderingKey, err)
+	}
+}
+
 // Stop sends all remaining published messages and stop goroutines created for handling
 // publishing. Returns once all outstanding messages have been sent or have
 // failed to be sent.
@@ -694,6 +774,7 @@ func (t *Topic) initBundler() {
 	}
 
 	t.flowController = newTopicFlowController(fcs)
+	t.keyFlowControllers = newKeyFlowControllers(t.PublishSettings.OrderingKeyFlowControlSettings)
 
 	bufferedByteLimit := DefaultPublishSettings.BufferedByteLimit
 	if t.PublishSettings.BufferedByteLimit > 0 {
@@ -753,7 +834,7 @@ func (t *Topic) publishMessageBundle(ctx context.Context, bms []*bundledMessage)
 	}
 	end := time.Now()
 	if err != nil {
-		t.scheduler.Pause(orderingKey)
+		t.pauseOrderingKey(orderingKey, err)
 		// Update context with error tag for OpenCensus,
 		// using same stats.Record() call as success case.
 		ctx, _ = tag.New(ctx, tag.Upsert(keyStatus, "ERROR"),
@@ -764,6 +845,7 @@ func (t *Topic) publishMessageBundle(ctx context.Context, bms []*bundledMessage)
 		PublishedMessages.M(int64(len(bms))))
 	for i, bm := range bms {
 		t.flowController.release(ctx, bm.size)
+		t.keyFlowControllers.release(ctx, orderingKey, bm.size)
 		if err != nil {
 			ipubsub.SetPublishResult(bm.res, "", err)
 		} else {
@@ -786,3 +868,19 @@ func (t *Topic) ResumePublish(orderingKey string) {
 
 	t.scheduler.Resume(orderingKey)
 }
+
+// PausedOrderingKeys returns the ordering keys for which publishing is
+// currently paused, in sorted order. Publishing for a key is paused after a
+// message with that key fails to be published, and can be resumed with
+// ResumePublish.
+func (t *Topic) PausedOrderingKeys() []string {
+	t.mu.RLock()
+	noop := t.scheduler == nil
+	t.mu.RUnlock()
+	if noop {
+		return nil
+	}
+	keys := t.scheduler.PausedKeys()
+	sort.Strings(keys)
+	return keys
+}
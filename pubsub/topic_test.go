@@ -559,6 +559,87 @@ func TestPublishFlowControl_SignalErrorOrderingKey(t *testing.T) {
 	}
 }
 
+func TestPublishOrderingKeyPaused(t *testing.T) {
+	ctx := context.Background()
+	c, srv := newFake(t)
+	defer c.Close()
+	defer srv.Close()
+
+	topic := mustCreateTopic(t, c, "some-topic")
+	defer topic.Stop()
+	topic.EnableMessageOrdering = true
+	topic.PublishSettings.FlowControlSettings = FlowControlSettings{
+		MaxOutstandingBytes:   10,
+		LimitExceededBehavior: FlowControlSignalError,
+	}
+	var mu sync.Mutex
+	var paused []string
+	var pauseErr error
+	topic.PublishSettings.OnOrderingKeyPaused = func(key string, err error) {
+		mu.Lock()
+		defer mu.Unlock()
+		paused = append(paused, key)
+		pauseErr = err
+	}
+
+	r := publishSingleMessageWithKey(ctx, topic, "AAAAAAAAAAA", "a")
+	if _, err := r.Get(ctx); err != ErrFlowControllerMaxOutstandingBytes {
+		t.Fatalf("Get() got: %v, want %v", err, ErrFlowControllerMaxOutstandingBytes)
+	}
+	r = publishSingleMessageWithKey(ctx, topic, "A", "a")
+	if _, err := r.Get(ctx); !errors.As(err, &ErrPublishingPaused{}) {
+		t.Fatalf("Get() got: %v, want ErrPublishingPaused", err)
+	}
+	mu.Lock()
+	if diff := testutil.Diff(paused, []string{"a"}); diff != "" {
+		t.Errorf("OnOrderingKeyPaused keys -got, +want:\n%s", diff)
+	}
+	if pauseErr != ErrFlowControllerMaxOutstandingBytes {
+		t.Errorf("OnOrderingKeyPaused got error %v, want %v", pauseErr, ErrFlowControllerMaxOutstandingBytes)
+	}
+	mu.Unlock()
+	if diff := testutil.Diff(topic.PausedOrderingKeys(), []string{"a"}); diff != "" {
+		t.Errorf("PausedOrderingKeys() -got, +want:\n%s", diff)
+	}
+
+	topic.ResumePublish("a")
+	if got := topic.PausedOrderingKeys(); len(got) != 0 {
+		t.Errorf("PausedOrderingKeys() after ResumePublish got %v, want none", got)
+	}
+	r = publishSingleMessageWithKey(ctx, topic, "A", "a")
+	if _, err := r.Get(ctx); err != nil {
+		t.Errorf("Get() after ResumePublish got: %v", err)
+	}
+}
+
+func TestPublishOrderingKeyFlowControl(t *testing.T) {
+	ctx := context.Background()
+	c, srv := newFake(t)
+	defer c.Close()
+	defer srv.Close()
+
+	topic := mustCreateTopic(t, c, "some-topic")
+	topic.EnableMessageOrdering = true
+	topic.PublishSettings.DelayThreshold = 5 * time.Second
+	topic.PublishSettings.CountThreshold = 10
+	topic.PublishSettings.OrderingKeyFlowControlSettings = FlowControlSettings{
+		MaxOutstandingMessages: 1,
+		LimitExceededBehavior:  FlowControlSignalError,
+	}
+
+	publishSingleMessageWithKey(ctx, topic, "A", "a")
+	r := publishSingleMessageWithKey(ctx, topic, "A", "a")
+	if _, err := r.Get(ctx); err != ErrFlowControllerMaxOutstandingMessages {
+		t.Fatalf("Get() got: %v, want %v", err, ErrFlowControllerMaxOutstandingMessages)
+	}
+	// Other keys have their own limits.
+	r = publishSingleMessageWithKey(ctx, topic, "B", "b")
+	topic.Stop()
+	if _, err := r.Get(ctx); err != nil {
+		t.Errorf("Get() for another key got: %v", err)
+	}
+}
+
 func TestPublishFlowControl_Block(t *testing.T) {
 	ctx := context.Background()
 	c, srv := newFake(t)
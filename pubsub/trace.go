@@ -100,6 +100,21 @@ var (
 	// PublisherOutstandingBytes is a measure of the number of bytes all outstanding publish messages held by the client take up.
 	// It is EXPERIMENTAL and subject to change or removal without notice.
 	PublisherOutstandingBytes = stats.Int64(statsPrefix+"publisher_outstanding_bytes", "Number of outstanding publish bytes", stats.UnitDimensionless)
+
+	// LeaseExtensionCount is a measure of the number of message leases extended by the client
+	// while the messages are being processed. It does not include the extensions sent on receipt.
+	// It is EXPERIMENTAL and subject to change or removal without notice.
+	LeaseExtensionCount = stats.Int64(statsPrefix+"lease_extension_count", "Number of message leases extended", stats.UnitDimensionless)
+
+	// ExpiredLeaseCount is a measure of the number of messages that were neither acked nor nacked
+	// before reaching ReceiveSettings.MaxExtension, and whose leases are no longer extended.
+	// It is EXPERIMENTAL and subject to change or removal without notice.
+	ExpiredLeaseCount = stats.Int64(statsPrefix+"expired_lease_count", "Number of message leases that expired", stats.UnitDimensionless)
+
+	// FlowControlBlockingLatency is a measure of the number of milliseconds Receive was blocked by
+	// flow control before it could deliver a message.
+	// It is EXPERIMENTAL and subject to change or removal without notice.
+	FlowControlBlockingLatency = stats.Float64(statsPrefix+"flow_control_blocking_latency", "The latency in milliseconds spent blocked by subscriber flow control", stats.UnitMilliseconds)
 )
 
 var (
@@ -162,6 +177,18 @@ var (
 	// PublisherOutstandingBytesView is the last value of OutstandingBytes
 	// It is EXPERIMENTAL and subject to change or removal without notice.
 	PublisherOutstandingBytesView *view.View
+
+	// LeaseExtensionCountView is a cumulative sum of LeaseExtensionCount.
+	// It is EXPERIMENTAL and subject to change or removal without notice.
+	LeaseExtensionCountView *view.View
+
+	// ExpiredLeaseCountView is a cumulative sum of ExpiredLeaseCount.
+	// It is EXPERIMENTAL and subject to change or removal without notice.
+	ExpiredLeaseCountView *view.View
+
+	// FlowControlBlockingLatencyView is a distribution of FlowControlBlockingLatency.
+	// It is EXPERIMENTAL and subject to change or removal without notice.
+	FlowControlBlockingLatencyView *view.View
 )
 
 func init() {
@@ -180,6 +207,9 @@ func init() {
 	StreamResponseCountView = createCountView(StreamResponseCount, keySubscription)
 	OutstandingMessagesView = createLastValueView(OutstandingMessages, keySubscription)
 	OutstandingBytesView = createLastValueView(OutstandingBytes, keySubscription)
+	LeaseExtensionCountView = createCountView(LeaseExtensionCount, keySubscription)
+	ExpiredLeaseCountView = createCountView(ExpiredLeaseCount, keySubscription)
+	FlowControlBlockingLatencyView = createDistView(FlowControlBlockingLatency, keySubscription)
 
 	DefaultPublishViews = []*view.View{
 		PublishedMessagesView,
@@ -200,6 +230,9 @@ func init() {
 		StreamResponseCountView,
 		OutstandingMessagesView,
 		OutstandingBytesView,
+		LeaseExtensionCountView,
+		ExpiredLeaseCountView,
+		FlowControlBlockingLatencyView,
 	}
 }
 
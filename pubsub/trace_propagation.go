@@ -0,0 +1,80 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pubsub
+
+import (
+	"context"
+	"strings"
+
+	"go.opentelemetry.io/otel/propagation"
+)
+
+// traceAttrPrefix is prepended to the W3C trace context keys when they are
+// stored as message attributes, so they don't collide with user attributes.
+// It matches the prefix used by the other Pub/Sub client libraries.
+const traceAttrPrefix = "googclient_"
+
+// tracePropagator injects and extracts W3C trace context.
+var tracePropagator = propagation.TraceContext{}
+
+// messageCarrier adapts message attributes to propagation.TextMapCarrier.
+type messageCarrier map[string]string
+
+var _ propagation.TextMapCarrier = messageCarrier(nil)
+
+func (c messageCarrier) Get(key string) string {
+	return c[traceAttrPrefix+key]
+}
+
+func (c messageCarrier) Set(key, value string) {
+	c[traceAttrPrefix+key] = value
+}
+
+func (c messageCarrier) Keys() []string {
+	var keys []string
+	for k := range c {
+		if strings.HasPrefix(k, traceAttrPrefix) {
+			keys = append(keys, strings.TrimPrefix(k, traceAttrPrefix))
+		}
+	}
+	return keys
+}
+
+// injectTraceContext returns a copy of attrs holding the trace context of
+// ctx. attrs is returned unchanged if ctx carries no valid span context.
+func injectTraceContext(ctx context.Context, attrs map[string]string) map[string]string {
+	c := make(messageCarrier)
+	tracePropagator.Inject(ctx, c)
+	if len(c) == 0 {
+		return attrs
+	}
+	out := make(map[string]string, len(attrs)+len(c))
+	for k, v := range attrs {
+		out[k] = v
+	}
+	for k, v := range c {
+		out[k] = v
+	}
+	return out
+}
+
+// extractTraceContext returns a copy of ctx carrying the remote span context
+// stored in the attributes of msg, if any.
+func extractTraceContext(ctx context.Context, msg *Message) context.Context {
+	if len(msg.Attributes) == 0 {
+		return ctx
+	}
+	return tracePropagator.Extract(ctx, messageCarrier(msg.Attributes))
+}
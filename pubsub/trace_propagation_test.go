@@ -0,0 +1,104 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pubsub
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/pubsub/pstest"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/api/option"
+	"google.golang.org/grpc"
+)
+
+func TestTracePropagation(t *testing.T) {
+	ctx := context.Background()
+	srv := pstest.NewServer()
+	defer srv.Close()
+	client, err := NewClientWithConfig(ctx, projName, &ClientConfig{EnableTracePropagation: true},
+		option.WithEndpoint(srv.Addr),
+		option.WithoutAuthentication(),
+		option.WithGRPCDialOption(grpc.WithInsecure()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+
+	topic := mustCreateTopic(t, client, "t")
+	defer topic.Stop()
+	sub, err := client.CreateSubscription(ctx, "s", SubscriptionConfig{Topic: topic})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sc := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    trace.TraceID{0x01, 0x02, 0x03},
+		SpanID:     trace.SpanID{0x04, 0x05},
+		TraceFlags: trace.FlagsSampled,
+	})
+	attrs := map[string]string{"k": "v"}
+	pctx := trace.ContextWithSpanContext(ctx, sc)
+	if _, err := topic.Publish(pctx, &Message{Data: []byte("m"), Attributes: attrs}).Get(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if len(attrs) != 1 {
+		t.Errorf("Publish modified the caller's attributes: %v", attrs)
+	}
+
+	cctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	var got trace.SpanContext
+	var gotAttrs map[string]string
+	err = sub.Receive(cctx, func(ctx context.Context, m *Message) {
+		m.Ack()
+		got = trace.SpanContextFromContext(ctx)
+		gotAttrs = m.Attributes
+		cancel()
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.TraceID() != sc.TraceID() || got.SpanID() != sc.SpanID() || !got.IsSampled() {
+		t.Errorf("got span context %v, want %v", got, sc)
+	}
+	if !got.IsRemote() {
+		t.Error("got local span context, want remote")
+	}
+	if gotAttrs["googclient_traceparent"] == "" {
+		t.Errorf("missing traceparent attribute: %v", gotAttrs)
+	}
+}
+
+func TestTracePropagationDisabled(t *testing.T) {
+	ctx := trace.ContextWithSpanContext(context.Background(), trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID: trace.TraceID{0x01},
+		SpanID:  trace.SpanID{0x01},
+	}))
+	client, srv := newFake(t)
+	defer client.Close()
+	defer srv.Close()
+
+	topic := mustCreateTopic(t, client, "t")
+	defer topic.Stop()
+	id, err := topic.Publish(ctx, &Message{Data: []byte("m")}).Get(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m := srv.Message(id); len(m.Attributes) != 0 {
+		t.Errorf("got attributes %v, want none", m.Attributes)
+	}
+}
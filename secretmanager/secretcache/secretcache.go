@@ -0,0 +1,293 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package secretcache caches the payloads of Secret Manager secret versions,
+// so that reading a secret on a hot path does not cost an RPC each time.
+//
+// A Client keeps each payload that it accesses for a TTL, and refreshes the
+// payloads that are in use in the background. When a secret is rotated, its
+// cached payloads can be dropped right away by passing the secret's Pub/Sub
+// notifications to HandleNotification:
+//
+//	sm, err := secretmanager.NewClient(ctx)
+//	if err != nil {
+//		// TODO: Handle error.
+//	}
+//	defer sm.Close()
+//	c := secretcache.NewClient(sm, secretcache.Config{})
+//	defer c.Close()
+//
+//	// Drop cached payloads when secrets are rotated or get new versions.
+//	go sub.Receive(ctx, func(ctx context.Context, m *pubsub.Message) {
+//		c.HandleNotification(m.Attributes)
+//		m.Ack()
+//	})
+//
+//	payload, err := c.Access(ctx, "projects/my-project/secrets/my-secret/versions/latest")
+//	if err != nil {
+//		// TODO: Handle error.
+//	}
+//	// TODO: Use payload.
+package secretcache // import "cloud.google.com/go/secretmanager/secretcache"
+
+import (
+	"context"
+	"fmt"
+	"hash/crc32"
+	"strings"
+	"sync"
+	"time"
+
+	secretmanager "cloud.google.com/go/secretmanager/apiv1"
+	"cloud.google.com/go/secretmanager/apiv1/secretmanagerpb"
+	"github.com/googleapis/gax-go/v2"
+)
+
+const (
+	// DefaultTTL is the default Config.TTL.
+	DefaultTTL = 5 * time.Minute
+
+	// DefaultRefreshInterval is the default Config.RefreshInterval.
+	DefaultRefreshInterval = time.Minute
+)
+
+// Config configures a Client. The zero value of each field selects its
+// default.
+type Config struct {
+	// TTL is how long a payload is used after it was accessed. A payload that
+	// could not be refreshed for longer than this is accessed again by the
+	// next call to Access. It is also how long a payload stays cached without
+	// being read. The default is DefaultTTL.
+	TTL time.Duration
+
+	// RefreshInterval is how often the cached payloads are accessed again in
+	// the background. The default is DefaultRefreshInterval. A negative
+	// value disables background refreshes.
+	RefreshInterval time.Duration
+}
+
+// accessor is the part of the Secret Manager client used by Client.
+type accessor interface {
+	AccessSecretVersion(context.Context, *secretmanagerpb.AccessSecretVersionRequest, ...gax.CallOption) (*secretmanagerpb.AccessSecretVersionResponse, error)
+}
+
+// A Client reads the payloads of secret versions through a cache.
+//
+// A Client is safe to use concurrently. Call Close when you are done with it,
+// to stop the background refreshes.
+type Client struct {
+	sm  accessor
+	ttl time.Duration
+	now func() time.Time
+
+	mu      sync.Mutex
+	entries map[string]*entry
+	// epoch is incremented by each invalidation, so that an access that
+	// started before it does not cache a payload that may be stale.
+	epoch uint64
+
+	stop chan struct{}
+	done chan struct{}
+}
+
+// entry is a cached payload.
+type entry struct {
+	payload  []byte
+	fetched  time.Time // When payload was accessed from Secret Manager.
+	lastRead time.Time // When payload was last returned by Access.
+}
+
+// NewClient returns a Client that accesses secret versions with sm. The
+// caller remains responsible for closing sm, after closing the Client.
+func NewClient(sm *secretmanager.Client, config Config) *Client {
+	return newClient(sm, config)
+}
+
+func newClient(sm accessor, config Config) *Client {
+	c := &Client{
+		sm:      sm,
+		ttl:     DefaultTTL,
+		now:     time.Now,
+		entries: map[string]*entry{},
+		stop:    make(chan struct{}),
+		done:    make(chan struct{}),
+	}
+	if config.TTL > 0 {
+		c.ttl = config.TTL
+	}
+	interval := DefaultRefreshInterval
+	if config.RefreshInterval != 0 {
+		interval = config.RefreshInterval
+	}
+	if interval < 0 {
+		close(c.done)
+		return c
+	}
+	go c.refreshLoop(interval)
+	return c
+}
+
+// Access returns the payload of the secret version with the given resource
+// name, in the format projects/*/secrets/*/versions/*. The version may be an
+// alias, such as "latest". The payload is read from the cache if it holds
+// one that is less than a TTL old, and accessed from Secret Manager
+// otherwise.
+//
+// The returned slice is shared by other callers, and must not be modified.
+func (c *Client) Access(ctx context.Context, name string) ([]byte, error) {
+	now := c.now()
+	c.mu.Lock()
+	if e, ok := c.entries[name]; ok && now.Sub(e.fetched) < c.ttl {
+		e.lastRead = now
+		payload := e.payload
+		c.mu.Unlock()
+		return payload, nil
+	}
+	c.mu.Unlock()
+
+	payload, err := c.fetch(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	c.mu.Lock()
+	if e, ok := c.entries[name]; ok {
+		e.lastRead = now
+	}
+	c.mu.Unlock()
+	return payload, nil
+}
+
+// fetch accesses the secret version from Secret Manager and caches its
+// payload, unless the cache was invalidated in the meantime.
+func (c *Client) fetch(ctx context.Context, name string) ([]byte, error) {
+	c.mu.Lock()
+	epoch := c.epoch
+	c.mu.Unlock()
+
+	resp, err := c.sm.AccessSecretVersion(ctx, &secretmanagerpb.AccessSecretVersionRequest{Name: name})
+	if err != nil {
+		return nil, err
+	}
+	payload := resp.GetPayload()
+	if payload.DataCrc32C != nil {
+		if got := crc32.Checksum(payload.GetData(), crc32.MakeTable(crc32.Castagnoli)); int64(got) != payload.GetDataCrc32C() {
+			return nil, fmt.Errorf("secretcache: payload of %s is corrupted: got CRC32C %d, want %d", name, got, payload.GetDataCrc32C())
+		}
+	}
+
+	now := c.now()
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.epoch == epoch {
+		e, ok := c.entries[name]
+		if !ok {
+			e = &entry{lastRead: now}
+			c.entries[name] = e
+		}
+		e.payload = payload.GetData()
+		e.fetched = now
+	}
+	return payload.GetData(), nil
+}
+
+// Invalidate drops the cached payloads of the secret or secret version with
+// the given resource name. A secret name, in the format projects/*/secrets/*,
+// drops the payloads of all of its versions.
+func (c *Client) Invalidate(name string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.epoch++
+	for n := range c.entries {
+		if n == name || strings.HasPrefix(n, name+"/versions/") {
+			delete(c.entries, n)
+		}
+	}
+}
+
+// HandleNotification drops the cached payloads of a secret when it changes,
+// given the attributes of a Pub/Sub message that Secret Manager published to
+// one of the secret's topics. Notifications that a secret was rotated or
+// deleted, or that one of its versions was added, enabled, disabled or
+// destroyed, drop
+// the payloads of all versions of the secret, including aliases such as
+// "latest". Other notifications are ignored.
+//
+// The secret is matched by name, so the names passed to Access should name
+// the project the way notifications do, which may be by project number
+// rather than by ID.
+func (c *Client) HandleNotification(attributes map[string]string) {
+	switch attributes["eventType"] {
+	case "SECRET_ROTATE", "SECRET_VERSION_ADD", "SECRET_VERSION_ENABLE",
+		"SECRET_VERSION_DISABLE", "SECRET_VERSION_DESTROY", "SECRET_DELETE":
+		if name := attributes["secretId"]; name != "" {
+			c.Invalidate(name)
+		}
+	}
+}
+
+// Close stops the background refreshes. It does not close the underlying
+// Secret Manager client.
+func (c *Client) Close() error {
+	select {
+	case <-c.stop:
+	default:
+		close(c.stop)
+	}
+	<-c.done
+	return nil
+}
+
+// refreshLoop refreshes the cache every interval until the Client is closed.
+func (c *Client) refreshLoop(interval time.Duration) {
+	defer close(c.done)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		<-c.stop
+		cancel()
+	}()
+	for {
+		select {
+		case <-c.stop:
+			return
+		case <-ticker.C:
+			c.refresh(ctx)
+		}
+	}
+}
+
+// refresh drops the payloads that have not been read for a TTL, and accesses
+// the others again. A payload that cannot be accessed is kept until it
+// expires.
+func (c *Client) refresh(ctx context.Context) {
+	now := c.now()
+	var names []string
+	c.mu.Lock()
+	for name, e := range c.entries {
+		if now.Sub(e.lastRead) >= c.ttl {
+			delete(c.entries, name)
+			continue
+		}
+		names = append(names, name)
+	}
+	c.mu.Unlock()
+	for _, name := range names {
+		if ctx.Err() != nil {
+			return
+		}
+		c.fetch(ctx, name)
+	}
+}
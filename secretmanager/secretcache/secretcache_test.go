@@ -0,0 +1,227 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package secretcache
+
+import (
+	"context"
+	"errors"
+	"hash/crc32"
+	"sync"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/secretmanager/apiv1/secretmanagerpb"
+	"github.com/googleapis/gax-go/v2"
+	"google.golang.org/protobuf/proto"
+)
+
+// fakeAccessor serves the payloads in data, and counts the accesses of each
+// name.
+type fakeAccessor struct {
+	mu     sync.Mutex
+	data   map[string]string
+	calls  map[string]int
+	badCRC bool
+}
+
+func newFakeAccessor() *fakeAccessor {
+	return &fakeAccessor{data: map[string]string{}, calls: map[string]int{}}
+}
+
+func (f *fakeAccessor) AccessSecretVersion(_ context.Context, req *secretmanagerpb.AccessSecretVersionRequest, _ ...gax.CallOption) (*secretmanagerpb.AccessSecretVersionResponse, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls[req.Name]++
+	d, ok := f.data[req.Name]
+	if !ok {
+		return nil, errors.New("not found")
+	}
+	crc := int64(crc32.Checksum([]byte(d), crc32.MakeTable(crc32.Castagnoli)))
+	if f.badCRC {
+		crc++
+	}
+	return &secretmanagerpb.AccessSecretVersionResponse{
+		Name:    req.Name,
+		Payload: &secretmanagerpb.SecretPayload{Data: []byte(d), DataCrc32C: proto.Int64(crc)},
+	}, nil
+}
+
+func (f *fakeAccessor) set(name, data string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.data[name] = data
+}
+
+func (f *fakeAccessor) numCalls(name string) int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.calls[name]
+}
+
+const (
+	latest = "projects/p/secrets/s/versions/latest"
+	other  = "projects/p/secrets/other/versions/1"
+)
+
+// newTestClient returns a client without background refreshes, whose clock
+// is advanced by the returned function.
+func newTestClient(f *fakeAccessor, ttl time.Duration) (*Client, func(time.Duration)) {
+	c := newClient(f, Config{TTL: ttl, RefreshInterval: -1})
+	now := time.Unix(1000, 0)
+	c.now = func() time.Time { return now }
+	return c, func(d time.Duration) { now = now.Add(d) }
+}
+
+func access(t *testing.T, c *Client, name string) string {
+	t.Helper()
+	b, err := c.Access(context.Background(), name)
+	if err != nil {
+		t.Fatalf("Access(%q): %v", name, err)
+	}
+	return string(b)
+}
+
+func TestAccessCaches(t *testing.T) {
+	f := newFakeAccessor()
+	f.set(latest, "v1")
+	c, advance := newTestClient(f, time.Minute)
+	defer c.Close()
+
+	for i := 0; i < 3; i++ {
+		if got := access(t, c, latest); got != "v1" {
+			t.Errorf("got %q, want %q", got, "v1")
+		}
+	}
+	if got := f.numCalls(latest); got != 1 {
+		t.Errorf("got %d accesses, want 1", got)
+	}
+
+	// Once the payload expires, it is accessed again.
+	f.set(latest, "v2")
+	advance(30 * time.Second)
+	if got := access(t, c, latest); got != "v1" {
+		t.Errorf("before TTL: got %q, want %q", got, "v1")
+	}
+	advance(30 * time.Second)
+	if got := access(t, c, latest); got != "v2" {
+		t.Errorf("after TTL: got %q, want %q", got, "v2")
+	}
+	if got := f.numCalls(latest); got != 2 {
+		t.Errorf("got %d accesses, want 2", got)
+	}
+}
+
+func TestAccessErrors(t *testing.T) {
+	f := newFakeAccessor()
+	c, _ := newTestClient(f, time.Minute)
+	defer c.Close()
+
+	if _, err := c.Access(context.Background(), latest); err == nil {
+		t.Error("missing secret: got nil, want error")
+	}
+	f.set(latest, "v1")
+	f.badCRC = true
+	if _, err := c.Access(context.Background(), latest); err == nil {
+		t.Error("bad checksum: got nil, want error")
+	}
+	f.badCRC = false
+	if got := access(t, c, latest); got != "v1" {
+		t.Errorf("got %q, want %q", got, "v1")
+	}
+}
+
+func TestHandleNotification(t *testing.T) {
+	f := newFakeAccessor()
+	f.set(latest, "v1")
+	f.set("projects/p/secrets/s/versions/1", "v1")
+	f.set(other, "o1")
+	c, _ := newTestClient(f, time.Hour)
+	defer c.Close()
+	for _, name := range []string{latest, "projects/p/secrets/s/versions/1", other} {
+		access(t, c, name)
+	}
+
+	// Unrelated events don't drop payloads.
+	c.HandleNotification(map[string]string{"eventType": "SECRET_UPDATE", "secretId": "projects/p/secrets/s"})
+	f.set(latest, "v2")
+	if got := access(t, c, latest); got != "v1" {
+		t.Errorf("after SECRET_UPDATE: got %q, want %q", got, "v1")
+	}
+
+	c.HandleNotification(map[string]string{"eventType": "SECRET_ROTATE", "secretId": "projects/p/secrets/s"})
+	if got := access(t, c, latest); got != "v2" {
+		t.Errorf("after SECRET_ROTATE: got %q, want %q", got, "v2")
+	}
+	for name, want := range map[string]int{latest: 2, "projects/p/secrets/s/versions/1": 2, other: 1} {
+		access(t, c, name)
+		if got := f.numCalls(name); got != want {
+			t.Errorf("%s: got %d accesses, want %d", name, got, want)
+		}
+	}
+}
+
+func TestRefresh(t *testing.T) {
+	f := newFakeAccessor()
+	f.set(latest, "v1")
+	f.set(other, "o1")
+	c, advance := newTestClient(f, time.Minute)
+	defer c.Close()
+	access(t, c, latest)
+	access(t, c, other)
+
+	// A refresh accesses the payloads that are in use again.
+	f.set(latest, "v2")
+	advance(30 * time.Second)
+	access(t, c, latest)
+	c.refresh(context.Background())
+	if got := access(t, c, latest); got != "v2" {
+		t.Errorf("after refresh: got %q, want %q", got, "v2")
+	}
+
+	// The payload that was not read for a TTL is dropped.
+	advance(30 * time.Second)
+	c.refresh(context.Background())
+	if got, want := f.numCalls(other), 2; got != want {
+		t.Errorf("got %d accesses, want %d", got, want)
+	}
+	c.mu.Lock()
+	_, ok := c.entries[other]
+	c.mu.Unlock()
+	if ok {
+		t.Errorf("%s is still cached, want it dropped", other)
+	}
+}
+
+func TestRefreshLoop(t *testing.T) {
+	f := newFakeAccessor()
+	f.set(latest, "v1")
+	c := newClient(f, Config{RefreshInterval: time.Millisecond})
+	access(t, c, latest)
+	f.set(latest, "v2")
+	deadline := time.Now().Add(10 * time.Second)
+	for access(t, c, latest) != "v2" {
+		if time.Now().After(deadline) {
+			t.Fatal("payload was not refreshed in the background")
+		}
+		time.Sleep(time.Millisecond)
+	}
+	if err := c.Close(); err != nil {
+		t.Fatal(err)
+	}
+	// Close is idempotent.
+	if err := c.Close(); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -14,8 +14,8 @@
 
 // Code generated by protoc-gen-go. DO NOT EDIT.
 // versions:
-// 	protoc-gen-go v1.31.0
-// 	protoc        v4.23.2
+// 	protoc-gen-go v1.32.0
+// 	protoc        v4.25.2
 // source: google/spanner/v1/commit_response.proto
 
 package spannerpb
@@ -14,8 +14,8 @@
 
 // Code generated by protoc-gen-go. DO NOT EDIT.
 // versions:
-// 	protoc-gen-go v1.31.0
-// 	protoc        v4.23.2
+// 	protoc-gen-go v1.32.0
+// 	protoc        v4.25.2
 // source: google/spanner/v1/keys.proto
 
 package spannerpb
@@ -132,12 +132,14 @@ type KeyRange struct {
 	// The start key must be provided. It can be either closed or open.
 	//
 	// Types that are assignable to StartKeyType:
+	//
 	//	*KeyRange_StartClosed
 	//	*KeyRange_StartOpen
 	StartKeyType isKeyRange_StartKeyType `protobuf_oneof:"start_key_type"`
 	// The end key must be provided. It can be either closed or open.
 	//
 	// Types that are assignable to EndKeyType:
+	//
 	//	*KeyRange_EndClosed
 	//	*KeyRange_EndOpen
 	EndKeyType isKeyRange_EndKeyType `protobuf_oneof:"end_key_type"`
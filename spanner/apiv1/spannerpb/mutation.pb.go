@@ -14,8 +14,8 @@
 
 // Code generated by protoc-gen-go. DO NOT EDIT.
 // versions:
-// 	protoc-gen-go v1.31.0
-// 	protoc        v4.23.2
+// 	protoc-gen-go v1.32.0
+// 	protoc        v4.25.2
 // source: google/spanner/v1/mutation.proto
 
 package spannerpb
@@ -48,6 +48,7 @@ type Mutation struct {
 	// Required. The operation to perform.
 	//
 	// Types that are assignable to Operation:
+	//
 	//	*Mutation_Insert
 	//	*Mutation_Update
 	//	*Mutation_InsertOrUpdate
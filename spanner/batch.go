@@ -150,14 +150,15 @@ func (t *BatchReadOnlyTransaction) PartitionReadUsingIndexWithOptions(ctx contex
 	}
 	// Prepare ReadRequest.
 	req := &sppb.ReadRequest{
-		Session:          sid,
-		Transaction:      ts,
-		Table:            table,
-		Index:            index,
-		Columns:          columns,
-		KeySet:           kset,
-		RequestOptions:   createRequestOptions(readOptions.Priority, readOptions.RequestTag, ""),
-		DataBoostEnabled: readOptions.DataBoostEnabled,
+		Session:             sid,
+		Transaction:         ts,
+		Table:               table,
+		Index:               index,
+		Columns:             columns,
+		KeySet:              kset,
+		RequestOptions:      createRequestOptions(readOptions.Priority, readOptions.RequestTag, ""),
+		DataBoostEnabled:    readOptions.DataBoostEnabled,
+		DirectedReadOptions: readOptions.DirectedReadOptions,
 	}
 	// Generate partitions.
 	for _, p := range resp.GetPartitions() {
@@ -213,14 +214,15 @@ func (t *BatchReadOnlyTransaction) partitionQuery(ctx context.Context, statement
 
 	// prepare ExecuteSqlRequest
 	r := &sppb.ExecuteSqlRequest{
-		Session:          sid,
-		Transaction:      ts,
-		Sql:              statement.SQL,
-		Params:           params,
-		ParamTypes:       paramTypes,
-		QueryOptions:     qOpts.Options,
-		RequestOptions:   createRequestOptions(qOpts.Priority, qOpts.RequestTag, ""),
-		DataBoostEnabled: qOpts.DataBoostEnabled,
+		Session:             sid,
+		Transaction:         ts,
+		Sql:                 statement.SQL,
+		Params:              params,
+		ParamTypes:          paramTypes,
+		QueryOptions:        qOpts.Options,
+		RequestOptions:      createRequestOptions(qOpts.Priority, qOpts.RequestTag, ""),
+		DataBoostEnabled:    qOpts.DataBoostEnabled,
+		DirectedReadOptions: qOpts.DirectedReadOptions,
 	}
 
 	// generate Partitions
@@ -310,16 +312,17 @@ func (t *BatchReadOnlyTransaction) Execute(ctx context.Context, p *Partition) *R
 	if p.rreq != nil {
 		rpc = func(ctx context.Context, resumeToken []byte) (streamingReceiver, error) {
 			client, err := client.StreamingRead(ctx, &sppb.ReadRequest{
-				Session:          p.rreq.Session,
-				Transaction:      p.rreq.Transaction,
-				Table:            p.rreq.Table,
-				Index:            p.rreq.Index,
-				Columns:          p.rreq.Columns,
-				KeySet:           p.rreq.KeySet,
-				PartitionToken:   p.pt,
-				RequestOptions:   p.rreq.RequestOptions,
-				ResumeToken:      resumeToken,
-				DataBoostEnabled: p.rreq.DataBoostEnabled,
+				Session:             p.rreq.Session,
+				Transaction:         p.rreq.Transaction,
+				Table:               p.rreq.Table,
+				Index:               p.rreq.Index,
+				Columns:             p.rreq.Columns,
+				KeySet:              p.rreq.KeySet,
+				PartitionToken:      p.pt,
+				RequestOptions:      p.rreq.RequestOptions,
+				ResumeToken:         resumeToken,
+				DataBoostEnabled:    p.rreq.DataBoostEnabled,
+				DirectedReadOptions: p.rreq.DirectedReadOptions,
 			})
 			if err != nil {
 				return client, err
@@ -335,16 +338,17 @@ func (t *BatchReadOnlyTransaction) Execute(ctx context.Context, p *Partition) *R
 	} else {
 		rpc = func(ctx context.Context, resumeToken []byte) (streamingReceiver, error) {
 			client, err := client.ExecuteStreamingSql(ctx, &sppb.ExecuteSqlRequest{
-				Session:          p.qreq.Session,
-				Transaction:      p.qreq.Transaction,
-				Sql:              p.qreq.Sql,
-				Params:           p.qreq.Params,
-				ParamTypes:       p.qreq.ParamTypes,
-				QueryOptions:     p.qreq.QueryOptions,
-				PartitionToken:   p.pt,
-				RequestOptions:   p.qreq.RequestOptions,
-				ResumeToken:      resumeToken,
-				DataBoostEnabled: p.qreq.DataBoostEnabled,
+				Session:             p.qreq.Session,
+				Transaction:         p.qreq.Transaction,
+				Sql:                 p.qreq.Sql,
+				Params:              p.qreq.Params,
+				ParamTypes:          p.qreq.ParamTypes,
+				QueryOptions:        p.qreq.QueryOptions,
+				PartitionToken:      p.pt,
+				RequestOptions:      p.qreq.RequestOptions,
+				ResumeToken:         resumeToken,
+				DataBoostEnabled:    p.qreq.DataBoostEnabled,
+				DirectedReadOptions: p.qreq.DirectedReadOptions,
 			})
 			if err != nil {
 				return client, err
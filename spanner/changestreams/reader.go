@@ -0,0 +1,477 @@
+/*
+Copyright 2023 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+/*
+Package changestreams reads the change records of Cloud Spanner change streams.
+
+A change stream is divided into partitions, which split and merge over time as
+the load on the database changes. A Reader queries every partition, starts the
+child partitions of a partition as they are reported, and passes the data
+change records it reads to a function:
+
+	r, err := changestreams.NewReader(client, "MyStream", changestreams.Config{
+	    StartTimestamp: start,
+	})
+	if err != nil {
+	    // TODO: Handle error.
+	}
+	err = r.Read(ctx, func(rec *changestreams.DataChangeRecord) error {
+	    // TODO: Use rec.
+	    return nil
+	})
+
+To resume reading after a restart, set Config.Checkpointer to a Checkpointer
+that stores the progress of each partition, for example in a Cloud Spanner
+table.
+
+See https://cloud.google.com/spanner/docs/change-streams for more about change
+streams.
+*/
+package changestreams // import "cloud.google.com/go/spanner/changestreams"
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sync"
+	"time"
+
+	"cloud.google.com/go/spanner"
+	"google.golang.org/api/iterator"
+)
+
+// defaultHeartbeatInterval is the heartbeat interval used if
+// Config.HeartbeatInterval is zero.
+const defaultHeartbeatInterval = 10 * time.Second
+
+// streamNameRE matches the names of change streams.
+var streamNameRE = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// Config configures a Reader.
+type Config struct {
+	// StartTimestamp is the commit time from which to read changes. It must be
+	// within the retention period of the change stream. If zero, changes are
+	// read from the time Read is called.
+	StartTimestamp time.Time
+
+	// EndTimestamp is the commit time up to which to read changes. If zero,
+	// changes are read until Read's context is done.
+	EndTimestamp time.Time
+
+	// HeartbeatInterval is how often a partition that has no changes reports
+	// its progress. It defaults to 10 seconds.
+	HeartbeatInterval time.Duration
+
+	// Checkpointer, if not nil, stores the progress of each partition, so that
+	// a later Read can resume where an earlier one stopped.
+	Checkpointer Checkpointer
+}
+
+// A Checkpointer stores the progress of the partitions of a change stream.
+// The Reader does not call its methods concurrently.
+type Checkpointer interface {
+	// Load returns the partitions saved by an earlier Read, or none if reading
+	// should start afresh from Config.StartTimestamp.
+	Load(ctx context.Context) ([]PartitionState, error)
+
+	// Save stores the state of a partition, replacing any earlier state with
+	// the same token. It is called whenever a partition is discovered, makes
+	// progress or finishes, so implementations may want to buffer writes.
+	Save(ctx context.Context, p PartitionState) error
+}
+
+// PartitionState is the progress of a Reader through a partition of a change
+// stream.
+type PartitionState struct {
+	// Token identifies the partition.
+	Token string
+
+	// ParentTokens identifies the partitions that this one was split or merged
+	// from. The partition is only read once its parents are finished.
+	ParentTokens []string
+
+	// Watermark is the commit time up to which the partition has been read.
+	// Reading resumes from the watermark, so records committed at exactly
+	// that time may be delivered again.
+	Watermark time.Time
+
+	// Finished reports whether the partition has been read to its end.
+	Finished bool
+}
+
+// A DataChangeRecord describes the changes made by a transaction to a table,
+// within one partition.
+type DataChangeRecord struct {
+	CommitTimestamp                      time.Time     `spanner:"commit_timestamp"`
+	RecordSequence                       string        `spanner:"record_sequence"`
+	ServerTransactionID                  string        `spanner:"server_transaction_id"`
+	IsLastRecordInTransactionInPartition bool          `spanner:"is_last_record_in_transaction_in_partition"`
+	TableName                            string        `spanner:"table_name"`
+	ColumnTypes                          []*ColumnType `spanner:"column_types"`
+	Mods                                 []*Mod        `spanner:"mods"`
+	// ModType is one of INSERT, UPDATE or DELETE.
+	ModType string `spanner:"mod_type"`
+	// ValueCaptureType is the value capture type of the change stream, for
+	// example OLD_AND_NEW_VALUES.
+	ValueCaptureType                string `spanner:"value_capture_type"`
+	NumberOfRecordsInTransaction    int64  `spanner:"number_of_records_in_transaction"`
+	NumberOfPartitionsInTransaction int64  `spanner:"number_of_partitions_in_transaction"`
+	TransactionTag                  string `spanner:"transaction_tag"`
+	IsSystemTransaction             bool   `spanner:"is_system_transaction"`
+
+	// PartitionToken identifies the partition that the record was read from.
+	PartitionToken string `spanner:"-"`
+}
+
+// A ColumnType describes a column of the table changed by a DataChangeRecord.
+type ColumnType struct {
+	Name string `spanner:"name"`
+	// Type is the column's type, in the form {"code": "STRING"}.
+	Type            spanner.NullJSON `spanner:"type"`
+	IsPrimaryKey    bool             `spanner:"is_primary_key"`
+	OrdinalPosition int64            `spanner:"ordinal_position"`
+}
+
+// A Mod is the change made to one row. Its values are JSON objects keyed by
+// column name.
+type Mod struct {
+	Keys      spanner.NullJSON `spanner:"keys"`
+	NewValues spanner.NullJSON `spanner:"new_values"`
+	OldValues spanner.NullJSON `spanner:"old_values"`
+}
+
+// changeRecord is one element of the ChangeRecord column returned by a change
+// stream query. Exactly one of its fields is set.
+type changeRecord struct {
+	DataChangeRecords      []*DataChangeRecord      `spanner:"data_change_record"`
+	HeartbeatRecords       []*heartbeatRecord       `spanner:"heartbeat_record"`
+	ChildPartitionsRecords []*childPartitionsRecord `spanner:"child_partitions_record"`
+}
+
+type heartbeatRecord struct {
+	Timestamp time.Time `spanner:"timestamp"`
+}
+
+type childPartitionsRecord struct {
+	StartTimestamp  time.Time         `spanner:"start_timestamp"`
+	RecordSequence  string            `spanner:"record_sequence"`
+	ChildPartitions []*childPartition `spanner:"child_partitions"`
+}
+
+type childPartition struct {
+	Token                 string   `spanner:"token"`
+	ParentPartitionTokens []string `spanner:"parent_partition_tokens"`
+}
+
+// rowIterator is the subset of *spanner.RowIterator used by a Reader.
+type rowIterator interface {
+	Next() (*spanner.Row, error)
+	Stop()
+}
+
+// A Reader reads the data change records of a change stream.
+type Reader struct {
+	streamName string
+	config     Config
+	query      func(ctx context.Context, stmt spanner.Statement) rowIterator
+}
+
+// NewReader returns a Reader for the change stream with the given name in the
+// database of client.
+func NewReader(client *spanner.Client, streamName string, config Config) (*Reader, error) {
+	r, err := newReader(streamName, config)
+	if err != nil {
+		return nil, err
+	}
+	r.query = func(ctx context.Context, stmt spanner.Statement) rowIterator {
+		// Change stream queries must use a strong single-use read-only
+		// transaction.
+		return client.Single().Query(ctx, stmt)
+	}
+	return r, nil
+}
+
+func newReader(streamName string, config Config) (*Reader, error) {
+	if !streamNameRE.MatchString(streamName) {
+		return nil, fmt.Errorf("changestreams: invalid change stream name %q", streamName)
+	}
+	if config.HeartbeatInterval < 0 {
+		return nil, fmt.Errorf("changestreams: negative heartbeat interval %v", config.HeartbeatInterval)
+	}
+	if config.HeartbeatInterval == 0 {
+		config.HeartbeatInterval = defaultHeartbeatInterval
+	}
+	if !config.EndTimestamp.IsZero() && config.EndTimestamp.Before(config.StartTimestamp) {
+		return nil, fmt.Errorf("changestreams: end timestamp %v is before start timestamp %v",
+			config.EndTimestamp, config.StartTimestamp)
+	}
+	return &Reader{streamName: streamName, config: config}, nil
+}
+
+// Read reads the change stream, calling f for each data change record.
+// Partitions are read concurrently, but f is called by one goroutine at a time.
+// The records of a partition are passed to f in the order of their commit
+// timestamps.
+//
+// Read returns when every partition has been read up to Config.EndTimestamp,
+// when f returns an error, or when reading a partition fails. In the last two
+// cases Read returns the error.
+func (r *Reader) Read(ctx context.Context, f func(*DataChangeRecord) error) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	rs := &readState{
+		r:          r,
+		f:          f,
+		ctx:        ctx,
+		cancel:     cancel,
+		partitions: map[string]*partition{},
+	}
+
+	var saved []PartitionState
+	if cp := r.config.Checkpointer; cp != nil {
+		var err error
+		if saved, err = cp.Load(ctx); err != nil {
+			return err
+		}
+	}
+	rs.mu.Lock()
+	if len(saved) == 0 {
+		// The root query, which has no partition token, returns the
+		// partitions that exist at the start timestamp.
+		start := r.config.StartTimestamp
+		if start.IsZero() {
+			start = time.Now()
+		}
+		rs.start(&partition{PartitionState: PartitionState{Watermark: start}})
+	} else {
+		for _, s := range saved {
+			rs.partitions[s.Token] = &partition{PartitionState: s}
+		}
+		rs.startReady()
+	}
+	rs.mu.Unlock()
+
+	rs.wg.Wait()
+	return rs.err
+}
+
+// partition is a partition known to a Read.
+type partition struct {
+	PartitionState
+	started bool
+}
+
+// readState is the state of a call to Reader.Read.
+type readState struct {
+	r      *Reader
+	f      func(*DataChangeRecord) error
+	ctx    context.Context
+	cancel func()
+	wg     sync.WaitGroup
+
+	fmu sync.Mutex // serializes calls to f
+
+	mu         sync.Mutex // guards the fields below and calls to the Checkpointer
+	partitions map[string]*partition
+	err        error
+}
+
+// start starts reading p in a new goroutine. rs.mu must be held.
+func (rs *readState) start(p *partition) {
+	p.started = true
+	rs.wg.Add(1)
+	go func() {
+		defer rs.wg.Done()
+		if err := rs.readPartition(p); err != nil {
+			rs.fail(err)
+			return
+		}
+		rs.finish(p)
+	}()
+}
+
+// startReady starts the partitions whose parents are all finished. rs.mu must
+// be held.
+func (rs *readState) startReady() {
+	for _, p := range rs.partitions {
+		if !p.started && !p.Finished && rs.ready(p) {
+			rs.start(p)
+		}
+	}
+}
+
+// ready reports whether the parents of p are finished. Parents unknown to the
+// Read are assumed to have been finished before it started. rs.mu must be held.
+func (rs *readState) ready(p *partition) bool {
+	for _, t := range p.ParentTokens {
+		if parent, ok := rs.partitions[t]; ok && !parent.Finished {
+			return false
+		}
+	}
+	return true
+}
+
+func (rs *readState) fail(err error) {
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+	if rs.err == nil {
+		rs.err = err
+		rs.cancel()
+	}
+}
+
+// finish records that p has been read to its end and starts any of its
+// children that are now ready.
+func (rs *readState) finish(p *partition) {
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+	p.Finished = true
+	if err := rs.save(p); err != nil {
+		rs.err = err
+		rs.cancel()
+		return
+	}
+	if rs.err == nil {
+		rs.startReady()
+	}
+}
+
+// save saves the state of p with the Checkpointer, if there is one. rs.mu must
+// be held.
+func (rs *readState) save(p *partition) error {
+	cp := rs.r.config.Checkpointer
+	if cp == nil || p.Token == "" {
+		return nil
+	}
+	if rs.err != nil {
+		return rs.err
+	}
+	return cp.Save(rs.ctx, p.PartitionState)
+}
+
+// advance moves the watermark of p forward to t.
+func (rs *readState) advance(p *partition, t time.Time) error {
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+	if !t.After(p.Watermark) {
+		return nil
+	}
+	p.Watermark = t
+	return rs.save(p)
+}
+
+// addChildren records the child partitions reported by p.
+func (rs *readState) addChildren(p *partition, cpr *childPartitionsRecord) error {
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+	for _, cp := range cpr.ChildPartitions {
+		// When partitions merge, each parent reports the same child.
+		if cp == nil || rs.partitions[cp.Token] != nil {
+			continue
+		}
+		child := &partition{PartitionState: PartitionState{
+			Token:        cp.Token,
+			ParentTokens: cp.ParentPartitionTokens,
+			Watermark:    cpr.StartTimestamp,
+		}}
+		rs.partitions[cp.Token] = child
+		if err := rs.save(child); err != nil {
+			return err
+		}
+		if rs.ready(child) {
+			rs.start(child)
+		}
+	}
+	return nil
+}
+
+func (rs *readState) statement(p *partition) spanner.Statement {
+	end := rs.r.config.EndTimestamp
+	return spanner.Statement{
+		SQL: fmt.Sprintf(`SELECT ChangeRecord FROM READ_%s(
+			start_timestamp => @startTimestamp,
+			end_timestamp => @endTimestamp,
+			partition_token => @partitionToken,
+			heartbeat_milliseconds => @heartbeatMilliseconds
+		)`, rs.r.streamName),
+		Params: map[string]interface{}{
+			"startTimestamp":        p.Watermark,
+			"endTimestamp":          spanner.NullTime{Time: end, Valid: !end.IsZero()},
+			"partitionToken":        spanner.NullString{StringVal: p.Token, Valid: p.Token != ""},
+			"heartbeatMilliseconds": rs.r.config.HeartbeatInterval.Milliseconds(),
+		},
+	}
+}
+
+// readPartition queries p until it ends, passing its data change records to f
+// and keeping track of its watermark and children.
+func (rs *readState) readPartition(p *partition) error {
+	iter := rs.r.query(rs.ctx, rs.statement(p))
+	defer iter.Stop()
+	for {
+		row, err := iter.Next()
+		if err == iterator.Done {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		// Decode leniently, so that fields added to change records in the
+		// future are ignored.
+		var rec struct {
+			ChangeRecord []*changeRecord `spanner:"ChangeRecord"`
+		}
+		if err := row.ToStructLenient(&rec); err != nil {
+			return err
+		}
+		for _, cr := range rec.ChangeRecord {
+			if cr == nil {
+				continue
+			}
+			for _, dcr := range cr.DataChangeRecords {
+				if dcr == nil {
+					continue
+				}
+				dcr.PartitionToken = p.Token
+				rs.fmu.Lock()
+				err := rs.f(dcr)
+				rs.fmu.Unlock()
+				if err != nil {
+					return err
+				}
+				if err := rs.advance(p, dcr.CommitTimestamp); err != nil {
+					return err
+				}
+			}
+			for _, hr := range cr.HeartbeatRecords {
+				if hr == nil {
+					continue
+				}
+				if err := rs.advance(p, hr.Timestamp); err != nil {
+					return err
+				}
+			}
+			for _, cpr := range cr.ChildPartitionsRecords {
+				if cpr == nil {
+					continue
+				}
+				if err := rs.addChildren(p, cpr); err != nil {
+					return err
+				}
+			}
+		}
+	}
+}
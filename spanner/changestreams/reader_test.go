@@ -0,0 +1,237 @@
+/*
+Copyright 2023 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package changestreams
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/spanner"
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/api/iterator"
+)
+
+var t0 = time.Date(2023, 5, 1, 12, 0, 0, 0, time.UTC)
+
+func at(sec int) time.Time { return t0.Add(time.Duration(sec) * time.Second) }
+
+// fakeStream answers change stream queries with the records of each partition.
+type fakeStream struct {
+	t       *testing.T
+	records map[string][]*changeRecord // keyed by partition token; "" is the root
+
+	mu      sync.Mutex
+	queries map[string]time.Time // start timestamps, keyed by partition token
+}
+
+func (s *fakeStream) query(ctx context.Context, stmt spanner.Statement) rowIterator {
+	token := stmt.Params["partitionToken"].(spanner.NullString).StringVal
+	s.mu.Lock()
+	s.queries[token] = stmt.Params["startTimestamp"].(time.Time)
+	s.mu.Unlock()
+	var rows []*spanner.Row
+	for _, cr := range s.records[token] {
+		row, err := spanner.NewRow([]string{"ChangeRecord"}, []interface{}{[]*changeRecord{cr}})
+		if err != nil {
+			s.t.Error(err)
+			break
+		}
+		rows = append(rows, row)
+	}
+	return &fakeIterator{rows: rows}
+}
+
+type fakeIterator struct{ rows []*spanner.Row }
+
+func (it *fakeIterator) Next() (*spanner.Row, error) {
+	if len(it.rows) == 0 {
+		return nil, iterator.Done
+	}
+	row := it.rows[0]
+	it.rows = it.rows[1:]
+	return row, nil
+}
+
+func (it *fakeIterator) Stop() {}
+
+// memCheckpointer keeps partition states in memory.
+type memCheckpointer struct {
+	states map[string]PartitionState
+}
+
+func (c *memCheckpointer) Load(context.Context) ([]PartitionState, error) {
+	var ps []PartitionState
+	for _, p := range c.states {
+		ps = append(ps, p)
+	}
+	return ps, nil
+}
+
+func (c *memCheckpointer) Save(_ context.Context, p PartitionState) error {
+	c.states[p.Token] = p
+	return nil
+}
+
+func dataRecord(sec int, table string) *changeRecord {
+	return &changeRecord{DataChangeRecords: []*DataChangeRecord{{
+		CommitTimestamp: at(sec),
+		TableName:       table,
+		ModType:         "INSERT",
+		Mods: []*Mod{{
+			Keys:      spanner.NullJSON{Value: map[string]interface{}{"id": "1"}, Valid: true},
+			NewValues: spanner.NullJSON{Value: map[string]interface{}{"name": table}, Valid: true},
+		}},
+	}}}
+}
+
+func childRecord(sec int, parents []string, tokens ...string) *changeRecord {
+	cpr := &childPartitionsRecord{StartTimestamp: at(sec)}
+	for _, t := range tokens {
+		cpr.ChildPartitions = append(cpr.ChildPartitions, &childPartition{Token: t, ParentPartitionTokens: parents})
+	}
+	return &changeRecord{ChildPartitionsRecords: []*childPartitionsRecord{cpr}}
+}
+
+func newFakeReader(t *testing.T, s *fakeStream, config Config) *Reader {
+	t.Helper()
+	r, err := newReader("Stream", config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.t = t
+	s.queries = map[string]time.Time{}
+	r.query = s.query
+	return r
+}
+
+func TestRead(t *testing.T) {
+	// The root has partitions A and B, which merge into C.
+	s := &fakeStream{records: map[string][]*changeRecord{
+		"": {childRecord(0, nil, "A", "B")},
+		"A": {
+			dataRecord(1, "A1"),
+			childRecord(5, []string{"A", "B"}, "C"),
+		},
+		"B": {
+			{HeartbeatRecords: []*heartbeatRecord{{Timestamp: at(2)}}},
+			dataRecord(3, "B1"),
+			childRecord(5, []string{"A", "B"}, "C"),
+		},
+		"C": {
+			dataRecord(6, "C1"),
+			{HeartbeatRecords: []*heartbeatRecord{{Timestamp: at(7)}}},
+		},
+	}}
+	cp := &memCheckpointer{states: map[string]PartitionState{}}
+	r := newFakeReader(t, s, Config{StartTimestamp: at(0), EndTimestamp: at(10), Checkpointer: cp})
+
+	var got []string
+	err := r.Read(context.Background(), func(rec *DataChangeRecord) error {
+		if rec.PartitionToken != rec.TableName[:1] {
+			t.Errorf("%s: got partition %q", rec.TableName, rec.PartitionToken)
+		}
+		if got, want := rec.Mods[0].NewValues.Value, map[string]interface{}{"name": rec.TableName}; !cmp.Equal(got, want) {
+			t.Errorf("%s: got new values %v, want %v", rec.TableName, got, want)
+		}
+		got = append(got, rec.TableName)
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// C is only read once both its parents are finished.
+	if len(got) != 3 || got[2] != "C1" {
+		t.Errorf("got records %v, want A1 and B1 before C1", got)
+	}
+	wantQueries := map[string]time.Time{"": at(0), "A": at(0), "B": at(0), "C": at(5)}
+	if !cmp.Equal(s.queries, wantQueries) {
+		t.Errorf("got queries %v, want %v", s.queries, wantQueries)
+	}
+	wantStates := map[string]PartitionState{
+		"A": {Token: "A", Watermark: at(1), Finished: true},
+		"B": {Token: "B", Watermark: at(3), Finished: true},
+		"C": {Token: "C", ParentTokens: []string{"A", "B"}, Watermark: at(7), Finished: true},
+	}
+	if diff := cmp.Diff(wantStates, cp.states); diff != "" {
+		t.Errorf("checkpoints: -want +got:\n%s", diff)
+	}
+}
+
+func TestReadResume(t *testing.T) {
+	s := &fakeStream{records: map[string][]*changeRecord{
+		"C": {dataRecord(6, "C1")},
+	}}
+	cp := &memCheckpointer{states: map[string]PartitionState{
+		"A": {Token: "A", Watermark: at(1), Finished: true},
+		"B": {Token: "B", Watermark: at(3), Finished: true},
+		"C": {Token: "C", ParentTokens: []string{"A", "B"}, Watermark: at(5)},
+	}}
+	r := newFakeReader(t, s, Config{Checkpointer: cp})
+	n := 0
+	if err := r.Read(context.Background(), func(*DataChangeRecord) error { n++; return nil }); err != nil {
+		t.Fatal(err)
+	}
+	if n != 1 {
+		t.Errorf("got %d records, want 1", n)
+	}
+	if want := map[string]time.Time{"C": at(5)}; !cmp.Equal(s.queries, want) {
+		t.Errorf("got queries %v, want %v", s.queries, want)
+	}
+	if !cp.states["C"].Finished {
+		t.Error("C not finished")
+	}
+}
+
+func TestReadError(t *testing.T) {
+	s := &fakeStream{records: map[string][]*changeRecord{
+		"":  {childRecord(0, nil, "A")},
+		"A": {dataRecord(1, "A1"), dataRecord(2, "A2")},
+	}}
+	r := newFakeReader(t, s, Config{StartTimestamp: at(0)})
+	wantErr := errors.New("stop")
+	n := 0
+	err := r.Read(context.Background(), func(*DataChangeRecord) error {
+		n++
+		return wantErr
+	})
+	if err != wantErr {
+		t.Errorf("got %v, want %v", err, wantErr)
+	}
+	if n != 1 {
+		t.Errorf("got %d calls, want 1", n)
+	}
+}
+
+func TestNewReaderErrors(t *testing.T) {
+	for _, test := range []struct {
+		name   string
+		config Config
+	}{
+		{"", Config{}},
+		{"My Stream", Config{}},
+		{"Stream; DROP TABLE T", Config{}},
+		{"Stream", Config{HeartbeatInterval: -time.Second}},
+		{"Stream", Config{StartTimestamp: at(1), EndTimestamp: at(0)}},
+	} {
+		if _, err := newReader(test.name, test.config); err == nil {
+			t.Errorf("%q, %+v: got nil, want error", test.name, test.config)
+		}
+	}
+}
@@ -19,6 +19,7 @@ package spanner
 import (
 	"context"
 	"fmt"
+	"io"
 	"log"
 	"os"
 	"regexp"
@@ -26,6 +27,8 @@ import (
 
 	"cloud.google.com/go/internal/trace"
 	sppb "cloud.google.com/go/spanner/apiv1/spannerpb"
+	"github.com/googleapis/gax-go/v2"
+	"google.golang.org/api/iterator"
 	"google.golang.org/api/option"
 	"google.golang.org/api/option/internaloption"
 	gtransport "google.golang.org/api/transport/grpc"
@@ -97,6 +100,8 @@ type Client struct {
 	ro                   ReadOptions
 	ao                   []ApplyOption
 	txo                  TransactionOptions
+	bwo                  BatchWriteOptions
+	dro                  *sppb.DirectedReadOptions
 	ct                   *commonTags
 	disableRouteToLeader bool
 }
@@ -138,6 +143,17 @@ type ClientConfig struct {
 	// TransactionOptions is the configuration for a transaction.
 	TransactionOptions TransactionOptions
 
+	// BatchWriteOptions is the configuration for a BatchWrite request.
+	BatchWriteOptions BatchWriteOptions
+
+	// DirectedReadOptions selects the replicas that serve the reads and
+	// queries of read-only transactions and single-use reads, by location
+	// and type, and whether they may fail over to other replicas when the
+	// selected replicas are unavailable. It is not used by read/write
+	// transactions. ReadOptions.DirectedReadOptions and
+	// QueryOptions.DirectedReadOptions override it for a single request.
+	DirectedReadOptions *sppb.DirectedReadOptions
+
 	// CallOptions is the configuration for providing custom retry settings that
 	// override the default values.
 	CallOptions *vkit.CallOptions
@@ -192,6 +208,35 @@ func contextWithOutgoingMetadata(ctx context.Context, md metadata.MD, disableRou
 	return metadata.NewOutgoingContext(ctx, md)
 }
 
+// LeaderRouting specifies whether the requests of a read-write transaction, a
+// partitioned DML statement or an Apply call are routed to the leader region
+// of the database. Routing them to the leader can reduce their latency in
+// multi-region databases.
+type LeaderRouting int
+
+const (
+	// LeaderRoutingDefault routes requests as set by
+	// ClientConfig.DisableRouteToLeader.
+	LeaderRoutingDefault LeaderRouting = iota
+	// LeaderRoutingEnabled routes requests to the leader region.
+	LeaderRoutingEnabled
+	// LeaderRoutingDisabled does not route requests to the leader region.
+	LeaderRoutingDisabled
+)
+
+// disableRouteToLeader reports whether requests should not be routed to the
+// leader, given the client's setting.
+func (lr LeaderRouting) disableRouteToLeader(clientDisabled bool) bool {
+	switch lr {
+	case LeaderRoutingEnabled:
+		return false
+	case LeaderRoutingDisabled:
+		return true
+	default:
+		return clientDisabled
+	}
+}
+
 // NewClient creates a client to a database. A valid database name has the
 // form projects/PROJECT_ID/instances/INSTANCE_ID/databases/DATABASE_ID. It uses
 // a default configuration.
@@ -281,6 +326,8 @@ func NewClientWithConfig(ctx context.Context, database string, config ClientConf
 		ro:                   config.ReadOptions,
 		ao:                   config.ApplyOptions,
 		txo:                  config.TransactionOptions,
+		bwo:                  config.BatchWriteOptions,
+		dro:                  config.DirectedReadOptions,
 		ct:                   getCommonTags(sc),
 		disableRouteToLeader: config.DisableRouteToLeader,
 	}
@@ -350,6 +397,8 @@ func (c *Client) Single() *ReadOnlyTransaction {
 	t.txReadOnly.txReadEnv = t
 	t.txReadOnly.qo = c.qo
 	t.txReadOnly.ro = c.ro
+	t.txReadOnly.qo.DirectedReadOptions = c.dro
+	t.txReadOnly.ro.DirectedReadOptions = c.dro
 	t.txReadOnly.disableRouteToLeader = true
 	t.txReadOnly.replaceSessionFunc = func(ctx context.Context) error {
 		if t.sh == nil {
@@ -388,6 +437,8 @@ func (c *Client) ReadOnlyTransaction() *ReadOnlyTransaction {
 	t.txReadOnly.txReadEnv = t
 	t.txReadOnly.qo = c.qo
 	t.txReadOnly.ro = c.ro
+	t.txReadOnly.qo.DirectedReadOptions = c.dro
+	t.txReadOnly.ro.DirectedReadOptions = c.dro
 	t.txReadOnly.disableRouteToLeader = true
 	t.ct = c.ct
 	return t
@@ -404,6 +455,9 @@ func (c *Client) ReadOnlyTransaction() *ReadOnlyTransaction {
 // You should call Close() after the txn is no longer needed on local
 // client, and call Cleanup() when the txn is finished for all clients, to free
 // the session.
+//
+// The session is created with ClientConfig.DatabaseRole; use a Client with
+// another role to run a batch read-only transaction with that role.
 func (c *Client) BatchReadOnlyTransaction(ctx context.Context, tb TimestampBound) (*BatchReadOnlyTransaction, error) {
 	var (
 		tx  transactionID
@@ -454,6 +508,8 @@ func (c *Client) BatchReadOnlyTransaction(ctx context.Context, tb TimestampBound
 	t.txReadOnly.txReadEnv = t
 	t.txReadOnly.qo = c.qo
 	t.txReadOnly.ro = c.ro
+	t.txReadOnly.qo.DirectedReadOptions = c.dro
+	t.txReadOnly.ro.DirectedReadOptions = c.dro
 	t.txReadOnly.disableRouteToLeader = true
 	t.ct = c.ct
 	return t, nil
@@ -484,6 +540,8 @@ func (c *Client) BatchReadOnlyTransactionFromID(tid BatchReadOnlyTransactionID)
 	t.txReadOnly.txReadEnv = t
 	t.txReadOnly.qo = c.qo
 	t.txReadOnly.ro = c.ro
+	t.txReadOnly.qo.DirectedReadOptions = c.dro
+	t.txReadOnly.ro.DirectedReadOptions = c.dro
 	t.txReadOnly.disableRouteToLeader = true
 	t.ct = c.ct
 	return t
@@ -546,6 +604,10 @@ func (c *Client) rwTransaction(ctx context.Context, f func(context.Context, *Rea
 		t       *ReadWriteTransaction
 		attempt = 0
 	)
+	sp, err := c.idleSessions.forRole(c.txo.merge(options).DatabaseRole)
+	if err != nil {
+		return resp, err
+	}
 	defer func() {
 		if sh != nil {
 			sh.recycle()
@@ -557,7 +619,7 @@ func (c *Client) rwTransaction(ctx context.Context, f func(context.Context, *Rea
 		)
 		if sh == nil || sh.getID() == "" || sh.getClient() == nil {
 			// Session handle hasn't been allocated or has been destroyed.
-			sh, err = c.idleSessions.take(ctx)
+			sh, err = sp.take(ctx)
 			if err != nil {
 				// If session retrieval fails, just fail the transaction.
 				return err
@@ -579,13 +641,13 @@ func (c *Client) rwTransaction(ctx context.Context, f func(context.Context, *Rea
 			t.txReadOnly.sh = sh
 		}
 		attempt++
-		t.txReadOnly.sp = c.idleSessions
+		t.txReadOnly.sp = sp
 		t.txReadOnly.txReadEnv = t
 		t.txReadOnly.qo = c.qo
 		t.txReadOnly.ro = c.ro
-		t.txReadOnly.disableRouteToLeader = c.disableRouteToLeader
 		t.wb = []*Mutation{}
 		t.txOpts = c.txo.merge(options)
+		t.txReadOnly.disableRouteToLeader = t.txOpts.LeaderRouting.disableRouteToLeader(c.disableRouteToLeader)
 		t.ct = c.ct
 
 		trace.TracePrintf(ctx, map[string]interface{}{"transactionSelector": t.getTransactionSelector().String()},
@@ -606,6 +668,10 @@ type applyOption struct {
 	transactionTag string
 	// priority is the RPC priority that is used for the commit operation.
 	priority sppb.RequestOptions_Priority
+	// leaderRouting specifies whether the requests are routed to the leader.
+	leaderRouting LeaderRouting
+	// databaseRole is the database role with which the mutations are applied.
+	databaseRole string
 }
 
 // An ApplyOption is an optional argument to Apply.
@@ -644,6 +710,23 @@ func Priority(priority sppb.RequestOptions_Priority) ApplyOption {
 	}
 }
 
+// ApplyLeaderRouting returns an ApplyOption that specifies whether the
+// requests of Apply are routed to the leader region, overriding
+// ClientConfig.DisableRouteToLeader.
+func ApplyLeaderRouting(lr LeaderRouting) ApplyOption {
+	return func(ao *applyOption) {
+		ao.leaderRouting = lr
+	}
+}
+
+// ApplyDatabaseRole returns an ApplyOption that applies the mutations with
+// the given database role instead of ClientConfig.DatabaseRole.
+func ApplyDatabaseRole(role string) ApplyOption {
+	return func(ao *applyOption) {
+		ao.databaseRole = role
+	}
+}
+
 // Apply applies a list of mutations atomically to the database.
 func (c *Client) Apply(ctx context.Context, ms []*Mutation, opts ...ApplyOption) (commitTimestamp time.Time, err error) {
 	ao := &applyOption{}
@@ -662,13 +745,237 @@ func (c *Client) Apply(ctx context.Context, ms []*Mutation, opts ...ApplyOption)
 	if !ao.atLeastOnce {
 		resp, err := c.ReadWriteTransactionWithOptions(ctx, func(ctx context.Context, t *ReadWriteTransaction) error {
 			return t.BufferWrite(ms)
-		}, TransactionOptions{CommitPriority: ao.priority, TransactionTag: ao.transactionTag})
+		}, TransactionOptions{CommitPriority: ao.priority, TransactionTag: ao.transactionTag, LeaderRouting: ao.leaderRouting, DatabaseRole: ao.databaseRole})
 		return resp.CommitTs, err
 	}
-	t := &writeOnlyTransaction{sp: c.idleSessions, commitPriority: ao.priority, transactionTag: ao.transactionTag, disableRouteToLeader: c.disableRouteToLeader}
+	sp, err := c.idleSessions.forRole(ao.databaseRole)
+	if err != nil {
+		return time.Time{}, err
+	}
+	t := &writeOnlyTransaction{sp: sp, commitPriority: ao.priority, transactionTag: ao.transactionTag, disableRouteToLeader: ao.leaderRouting.disableRouteToLeader(c.disableRouteToLeader)}
 	return t.applyAtLeastOnce(ctx, ms...)
 }
 
+// BatchWriteOptions provides options for a BatchWrite request.
+type BatchWriteOptions struct {
+	// Priority is the RPC priority to use for the request.
+	Priority sppb.RequestOptions_Priority
+
+	// TransactionTag is the transaction tag to use for the request.
+	TransactionTag string
+
+	// LeaderRouting specifies whether the request is routed to the leader
+	// region, overriding ClientConfig.DisableRouteToLeader.
+	LeaderRouting LeaderRouting
+
+	// DatabaseRole is the database role with which the mutation groups are
+	// applied, overriding ClientConfig.DatabaseRole.
+	DatabaseRole string
+}
+
+// merge combines two BatchWriteOptions such that the input parameter will
+// have higher order of precedence.
+func (bwo BatchWriteOptions) merge(opts BatchWriteOptions) BatchWriteOptions {
+	merged := bwo
+	if opts.Priority != sppb.RequestOptions_PRIORITY_UNSPECIFIED {
+		merged.Priority = opts.Priority
+	}
+	if opts.TransactionTag != "" {
+		merged.TransactionTag = opts.TransactionTag
+	}
+	if opts.LeaderRouting != LeaderRoutingDefault {
+		merged.LeaderRouting = opts.LeaderRouting
+	}
+	if opts.DatabaseRole != "" {
+		merged.DatabaseRole = opts.DatabaseRole
+	}
+	return merged
+}
+
+// BatchWriteResponseIterator is an iterator over the responses of a
+// BatchWrite request. Each response reports the status of one or more
+// mutation groups, identified by their indexes in the request.
+type BatchWriteResponseIterator struct {
+	ctx            context.Context
+	stream         sppb.Spanner_BatchWriteClient
+	err            error
+	dataReceived   bool
+	replaceSession func(ctx context.Context) error
+	rpc            func(ctx context.Context) (sppb.Spanner_BatchWriteClient, error)
+	release        func(error)
+	cancel         func()
+}
+
+// Next returns the next response. Its second return value is iterator.Done if
+// there are no more responses. Once Next returns Done, all subsequent calls
+// will return Done.
+//
+// A mutation group that failed to be applied is reported by a response with a
+// non-OK Status; Next only returns an error if the request itself fails.
+func (r *BatchWriteResponseIterator) Next() (*sppb.BatchWriteResponse, error) {
+	for {
+		// Stream finished or in error state.
+		if r.err != nil {
+			return nil, r.err
+		}
+
+		// RPC not made yet.
+		if r.stream == nil {
+			r.stream, r.err = r.rpc(r.ctx)
+			if r.err != nil {
+				r.err = ToSpannerError(r.err)
+			}
+			continue
+		}
+
+		var response *sppb.BatchWriteResponse
+		response, r.err = r.stream.Recv()
+		if r.err == nil {
+			r.dataReceived = true
+			return response, nil
+		}
+		if r.err == io.EOF {
+			r.err = iterator.Done
+			return nil, r.err
+		}
+		r.err = ToSpannerError(r.err)
+
+		// Retry the request on a new session if the session was not found,
+		// unless some mutation groups have already been applied.
+		if !r.dataReceived && r.replaceSession != nil && isSessionNotFoundError(r.err) {
+			r.err = r.replaceSession(r.ctx)
+			r.stream = nil
+		}
+	}
+}
+
+// Stop terminates the iteration. It should be called after you finish using
+// the iterator.
+func (r *BatchWriteResponseIterator) Stop() {
+	if r.stream != nil {
+		err := r.err
+		if err == iterator.Done {
+			err = nil
+		}
+		defer trace.EndSpan(r.ctx, err)
+	}
+	if r.cancel != nil {
+		r.cancel()
+		r.cancel = nil
+	}
+	if r.release != nil {
+		r.release(r.err)
+		r.release = nil
+	}
+	if r.err == nil {
+		r.err = spannerErrorf(codes.FailedPrecondition, "Next called after Stop")
+	}
+}
+
+// Do calls the provided function once in sequence for each response in the
+// iteration. If the function returns a non-nil error, Do immediately returns
+// that error.
+//
+// If there are no responses in the iterator, Do will return nil without
+// calling the provided function.
+//
+// Do always calls Stop on the iterator.
+func (r *BatchWriteResponseIterator) Do(f func(r *sppb.BatchWriteResponse) error) error {
+	defer r.Stop()
+	for {
+		resp, err := r.Next()
+		switch err {
+		case iterator.Done:
+			return nil
+		case nil:
+			if err = f(resp); err != nil {
+				return err
+			}
+		default:
+			return err
+		}
+	}
+}
+
+// BatchWrite applies a list of mutation groups in a collection of efficient
+// transactions. The mutations of each group are applied atomically, but the
+// groups are applied independently, in an unspecified order, so they must not
+// depend on each other. Some groups may be applied while others fail; the
+// status of each group is streamed back as the groups are applied.
+//
+// BatchWrite requests are not replay protected, meaning that a mutation group
+// may be applied more than once. Replays of non-idempotent mutations may have
+// undesirable effects, such as an insert failing with ALREADY_EXISTS, so the
+// mutation groups should be idempotent.
+func (c *Client) BatchWrite(ctx context.Context, mgs []*MutationGroup) *BatchWriteResponseIterator {
+	return c.BatchWriteWithOptions(ctx, mgs, BatchWriteOptions{})
+}
+
+// BatchWriteWithOptions is the same as BatchWrite, with options to customize
+// the request.
+func (c *Client) BatchWriteWithOptions(ctx context.Context, mgs []*MutationGroup, opts BatchWriteOptions) *BatchWriteResponseIterator {
+	ctx = trace.StartSpan(ctx, "cloud.google.com/go/spanner.BatchWrite")
+	var err error
+	defer func() { trace.EndSpan(ctx, err) }()
+
+	opts = c.bwo.merge(opts)
+	mgsPb, err := mutationGroupsProto(mgs)
+	if err != nil {
+		return &BatchWriteResponseIterator{err: err}
+	}
+	sp, err := c.idleSessions.forRole(opts.DatabaseRole)
+	if err != nil {
+		return &BatchWriteResponseIterator{err: err}
+	}
+	var sh *sessionHandle
+	sh, err = sp.take(ctx)
+	if err != nil {
+		return &BatchWriteResponseIterator{err: err}
+	}
+	disableRouteToLeader := opts.LeaderRouting.disableRouteToLeader(c.disableRouteToLeader)
+
+	rpc := func(ct context.Context) (sppb.Spanner_BatchWriteClient, error) {
+		var md metadata.MD
+		stream, err := sh.getClient().BatchWrite(contextWithOutgoingMetadata(ct, sh.getMetadata(), disableRouteToLeader), &sppb.BatchWriteRequest{
+			Session:        sh.getID(),
+			MutationGroups: mgsPb,
+			RequestOptions: createRequestOptions(opts.Priority, "", opts.TransactionTag),
+		}, gax.WithGRPCOptions(grpc.Header(&md)))
+
+		if getGFELatencyMetricsFlag() && md != nil && c.ct != nil {
+			if metricErr := createContextAndCaptureGFELatencyMetrics(ct, c.ct, md, "BatchWrite"); metricErr != nil {
+				trace.TracePrintf(ct, nil, "Error in recording GFE Latency. Try disabling and rerunning. Error: %v", metricErr)
+			}
+		}
+		return stream, err
+	}
+	replaceSession := func(ct context.Context) error {
+		sh.destroy()
+		var err error
+		sh, err = sp.take(ct)
+		return err
+	}
+	release := func(err error) {
+		if sh == nil {
+			return
+		}
+		if isSessionNotFoundError(err) {
+			sh.destroy()
+		}
+		sh.recycle()
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	ctx = trace.StartSpan(ctx, "cloud.google.com/go/spanner.BatchWriteResponseIterator")
+	return &BatchWriteResponseIterator{
+		ctx:            ctx,
+		rpc:            rpc,
+		replaceSession: replaceSession,
+		release:        release,
+		cancel:         cancel,
+	}
+}
+
 // logf logs the given message to the given logger, or the standard logger if
 // the given logger is nil.
 func logf(logger *log.Logger, format string, v ...interface{}) {
@@ -34,8 +34,10 @@ import (
 	"github.com/googleapis/gax-go/v2"
 	"google.golang.org/api/iterator"
 	"google.golang.org/api/option"
+	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/encoding/gzip"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 
 	vkit "cloud.google.com/go/spanner/apiv1"
@@ -451,6 +453,78 @@ func TestClient_Single_ReadOptions(t *testing.T) {
 	}
 }
 
+func TestClient_DirectedReadOptions(t *testing.T) {
+	t.Parallel()
+
+	clientDRO := &sppb.DirectedReadOptions{
+		Replicas: &sppb.DirectedReadOptions_IncludeReplicas_{IncludeReplicas: &sppb.DirectedReadOptions_IncludeReplicas{
+			ReplicaSelections: []*sppb.DirectedReadOptions_ReplicaSelection{
+				{Location: "us-east1", Type: sppb.DirectedReadOptions_ReplicaSelection_READ_ONLY},
+			},
+			AutoFailoverDisabled: true,
+		}},
+	}
+	requestDRO := &sppb.DirectedReadOptions{
+		Replicas: &sppb.DirectedReadOptions_ExcludeReplicas_{ExcludeReplicas: &sppb.DirectedReadOptions_ExcludeReplicas{
+			ReplicaSelections: []*sppb.DirectedReadOptions_ReplicaSelection{{Location: "us-west1"}},
+		}},
+	}
+	ctx := context.Background()
+	server, client, teardown := setupMockedTestServerWithConfig(t, ClientConfig{DirectedReadOptions: clientDRO})
+	defer teardown()
+	stmt := NewStatement(SelectSingerIDAlbumIDAlbumTitleFromAlbums)
+	columns := []string{"SingerId", "AlbumId", "AlbumTitle"}
+
+	for _, test := range []struct {
+		name string
+		run  func() *RowIterator
+		want *sppb.DirectedReadOptions
+	}{
+		{"single query", func() *RowIterator { return client.Single().Query(ctx, stmt) }, clientDRO},
+		{"single read", func() *RowIterator { return client.Single().Read(ctx, "Albums", KeySets(Key{"foo"}), columns) }, clientDRO},
+		{"read-only query", func() *RowIterator {
+			return client.ReadOnlyTransaction().QueryWithOptions(ctx, stmt, QueryOptions{DirectedReadOptions: requestDRO})
+		}, requestDRO},
+		{"read-only read", func() *RowIterator {
+			return client.ReadOnlyTransaction().ReadWithOptions(ctx, "Albums", KeySets(Key{"foo"}), columns, &ReadOptions{DirectedReadOptions: requestDRO})
+		}, requestDRO},
+	} {
+		iter := test.run()
+		if _, err := iter.Next(); err != nil {
+			t.Fatalf("%s: %v", test.name, err)
+		}
+		iter.Stop()
+		var got []*sppb.DirectedReadOptions
+		for _, req := range drainRequestsFromServer(server.TestSpanner) {
+			switch req := req.(type) {
+			case *sppb.ExecuteSqlRequest:
+				got = append(got, req.DirectedReadOptions)
+			case *sppb.ReadRequest:
+				got = append(got, req.DirectedReadOptions)
+			}
+		}
+		if len(got) != 1 || !testEqual(got[0], test.want) {
+			t.Errorf("%s: directed read options mismatch\nGot: %v\nWant: %v", test.name, got, test.want)
+		}
+	}
+
+	// Read/write transactions do not use the directed read options of the
+	// client.
+	if _, err := client.ReadWriteTransaction(ctx, func(ctx context.Context, tx *ReadWriteTransaction) error {
+		iter := tx.Query(ctx, stmt)
+		defer iter.Stop()
+		_, err := iter.Next()
+		return err
+	}); err != nil {
+		t.Fatal(err)
+	}
+	for _, req := range drainRequestsFromServer(server.TestSpanner) {
+		if req, ok := req.(*sppb.ExecuteSqlRequest); ok && req.DirectedReadOptions != nil {
+			t.Errorf("read/write transaction: got directed read options %v, want nil", req.DirectedReadOptions)
+		}
+	}
+}
+
 func TestClient_ReturnDatabaseName(t *testing.T) {
 	t.Parallel()
 
@@ -1756,6 +1830,124 @@ func TestClient_SessionContainsDatabaseRole(t *testing.T) {
 	}
 }
 
+func TestClient_DatabaseRoleOverride(t *testing.T) {
+	t.Parallel()
+	server, client, teardown := setupMockedTestServerWithConfig(t, ClientConfig{DatabaseRole: "test"})
+	defer teardown()
+	ctx := context.Background()
+
+	// commitRole returns the creator role of the session of the last commit.
+	commitRole := func() string {
+		t.Helper()
+		var session string
+		for _, req := range drainRequestsFromServer(server.TestSpanner) {
+			if commit, ok := req.(*sppb.CommitRequest); ok {
+				session = commit.Session
+			}
+		}
+		if session == "" {
+			t.Fatal("no CommitRequest")
+		}
+		resp, err := server.TestSpanner.GetSession(ctx, &sppb.GetSessionRequest{Name: session})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return resp.CreatorRole
+	}
+	ms := []*Mutation{Insert("foo", []string{"col1"}, []interface{}{"val1"})}
+
+	for _, role := range []string{"", "other"} {
+		want := role
+		if want == "" {
+			want = "test"
+		}
+		if _, err := client.ReadWriteTransactionWithOptions(ctx, func(ctx context.Context, tx *ReadWriteTransaction) error {
+			return tx.BufferWrite(ms)
+		}, TransactionOptions{DatabaseRole: role}); err != nil {
+			t.Fatal(err)
+		}
+		if got := commitRole(); got != want {
+			t.Errorf("ReadWriteTransaction with role %q: got session role %q, want %q", role, got, want)
+		}
+		if _, err := client.Apply(ctx, ms, ApplyAtLeastOnce(), ApplyDatabaseRole(role)); err != nil {
+			t.Fatal(err)
+		}
+		if got := commitRole(); got != want {
+			t.Errorf("Apply with role %q: got session role %q, want %q", role, got, want)
+		}
+	}
+	if got := len(client.idleSessions.rolePools); got != 1 {
+		t.Errorf("got %d role pools, want 1", got)
+	}
+
+	// queryRole returns the creator role of the session of the last query.
+	queryRole := func() string {
+		t.Helper()
+		var session string
+		for _, req := range drainRequestsFromServer(server.TestSpanner) {
+			if q, ok := req.(*sppb.ExecuteSqlRequest); ok {
+				session = q.Session
+			}
+		}
+		if session == "" {
+			t.Fatal("no ExecuteSqlRequest")
+		}
+		resp, err := server.TestSpanner.GetSession(ctx, &sppb.GetSessionRequest{Name: session})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return resp.CreatorRole
+	}
+	for _, role := range []string{"", "other"} {
+		want := role
+		if want == "" {
+			want = "test"
+		}
+		if err := client.Single().WithDatabaseRole(role).Query(ctx, NewStatement(SelectSingerIDAlbumIDAlbumTitleFromAlbums)).Do(func(*Row) error { return nil }); err != nil {
+			t.Fatal(err)
+		}
+		if got := queryRole(); got != want {
+			t.Errorf("Single with role %q: got session role %q, want %q", role, got, want)
+		}
+		tx := client.ReadOnlyTransaction().WithDatabaseRole(role)
+		err := tx.Query(ctx, NewStatement(SelectSingerIDAlbumIDAlbumTitleFromAlbums)).Do(func(*Row) error { return nil })
+		tx.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := queryRole(); got != want {
+			t.Errorf("ReadOnlyTransaction with role %q: got session role %q, want %q", role, got, want)
+		}
+	}
+
+	// The role pools are bounded in number and in sessions.
+	rp, err := client.idleSessions.forRole("other")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := rp.MaxOpened, client.idleSessions.MaxOpened/maxRolePools; got != want {
+		t.Errorf("role pool MaxOpened: got %d, want %d", got, want)
+	}
+	for i := 1; i < maxRolePools; i++ {
+		if _, err := client.idleSessions.forRole(fmt.Sprintf("role%d", i)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	err = client.Single().WithDatabaseRole("one-too-many").Query(ctx, NewStatement(SelectSingerIDAlbumIDAlbumTitleFromAlbums)).Do(func(*Row) error { return nil })
+	if err != errTooManyDatabaseRoles {
+		t.Errorf("got %v, want %v", err, errTooManyDatabaseRoles)
+	}
+
+	// Closing the client marks the session clients of the role pools closed.
+	client.Close()
+	rp.sc.mu.Lock()
+	closed := rp.sc.closed
+	rp.sc.mu.Unlock()
+	if !closed {
+		t.Error("role pool session client is not closed")
+	}
+}
+
 func TestClient_SessionNotFound(t *testing.T) {
 	// Ensure we always have at least one session in the pool.
 	sc := SessionPoolConfig{
@@ -2450,6 +2642,101 @@ func TestClient_ApplyAtLeastOnce(t *testing.T) {
 	}
 }
 
+func TestClient_BatchWrite(t *testing.T) {
+	t.Parallel()
+	server, client, teardown := setupMockedTestServerWithConfig(t, ClientConfig{
+		BatchWriteOptions: BatchWriteOptions{TransactionTag: "client-tag", Priority: sppb.RequestOptions_PRIORITY_LOW},
+	})
+	defer teardown()
+	mgs := []*MutationGroup{
+		{Mutations: []*Mutation{
+			Insert("Accounts", []string{"AccountId", "Nickname", "Balance"}, []interface{}{int64(1), "Foo", int64(50)}),
+			Insert("Accounts", []string{"AccountId", "Nickname", "Balance"}, []interface{}{int64(2), "Bar", int64(1)}),
+		}},
+		{Mutations: []*Mutation{
+			Insert("Accounts", []string{"AccountId", "Nickname", "Balance"}, []interface{}{int64(3), "Baz", int64(2)}),
+		}},
+	}
+	iter := client.BatchWriteWithOptions(context.Background(), mgs, BatchWriteOptions{TransactionTag: "tag"})
+	var indexes []int32
+	if err := iter.Do(func(r *sppb.BatchWriteResponse) error {
+		if r.Status.Code != int32(codes.OK) {
+			return fmt.Errorf("groups %v: got status %v", r.Indexes, r.Status)
+		}
+		indexes = append(indexes, r.Indexes...)
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if g, w := indexes, []int32{0, 1}; !testEqual(g, w) {
+		t.Fatalf("indexes mismatch\nGot: %v\nWant: %v", g, w)
+	}
+	requests := drainRequestsFromServer(server.TestSpanner)
+	if err := compareRequests([]interface{}{
+		&sppb.BatchCreateSessionsRequest{},
+		&sppb.BatchWriteRequest{},
+	}, requests); err != nil {
+		t.Fatal(err)
+	}
+	req := requests[1].(*sppb.BatchWriteRequest)
+	if g, w := len(req.MutationGroups), 2; g != w {
+		t.Fatalf("mutation group count mismatch\nGot: %v\nWant: %v", g, w)
+	}
+	if g, w := req.RequestOptions.TransactionTag, "tag"; g != w {
+		t.Errorf("transaction tag mismatch\nGot: %v\nWant: %v", g, w)
+	}
+	if g, w := req.RequestOptions.Priority, sppb.RequestOptions_PRIORITY_LOW; g != w {
+		t.Errorf("priority mismatch\nGot: %v\nWant: %v", g, w)
+	}
+}
+
+func TestClient_BatchWrite_SessionNotFound(t *testing.T) {
+	t.Parallel()
+	server, client, teardown := setupMockedTestServer(t)
+	defer teardown()
+	server.TestSpanner.PutExecutionTime(MethodBatchWrite,
+		SimulatedExecutionTime{Errors: []error{newSessionNotFoundError("projects/p/instances/i/databases/d/sessions/s")}},
+	)
+	mgs := []*MutationGroup{
+		{Mutations: []*Mutation{
+			Insert("Accounts", []string{"AccountId", "Nickname", "Balance"}, []interface{}{int64(1), "Foo", int64(50)}),
+		}},
+	}
+	n := 0
+	if err := client.BatchWrite(context.Background(), mgs).Do(func(r *sppb.BatchWriteResponse) error {
+		n++
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if n != 1 {
+		t.Fatalf("response count mismatch\nGot: %v\nWant: %v", n, 1)
+	}
+}
+
+func TestClient_BatchWrite_Error(t *testing.T) {
+	t.Parallel()
+	server, client, teardown := setupMockedTestServer(t)
+	defer teardown()
+	server.TestSpanner.PutExecutionTime(MethodBatchWrite,
+		SimulatedExecutionTime{Errors: []error{status.Error(codes.InvalidArgument, "Invalid mutation")}},
+	)
+	mgs := []*MutationGroup{
+		{Mutations: []*Mutation{
+			Insert("Accounts", []string{"AccountId", "Nickname", "Balance"}, []interface{}{int64(1), "Foo", int64(50)}),
+		}},
+	}
+	iter := client.BatchWrite(context.Background(), mgs)
+	defer iter.Stop()
+	_, err := iter.Next()
+	if g, w := ErrCode(err), codes.InvalidArgument; g != w {
+		t.Fatalf("error code mismatch\nGot: %v\nWant: %v", g, w)
+	}
+	if _, err := iter.Next(); ErrCode(err) != codes.InvalidArgument {
+		t.Fatalf("got %v after an error, want the same error", err)
+	}
+}
+
 func TestClient_ApplyAtLeastOnceReuseSession(t *testing.T) {
 	t.Parallel()
 	server, client, teardown := setupMockedTestServerWithConfig(t, ClientConfig{
@@ -4066,6 +4353,92 @@ func TestClient_Apply_Tagging(t *testing.T) {
 	checkCommitForExpectedRequestOptions(t, server.TestSpanner, sppb.RequestOptions{TransactionTag: "tx-tag"})
 }
 
+// leaderRoutingRecorder records whether the last call of each RPC method was
+// routed to the leader.
+type leaderRoutingRecorder struct {
+	mu     sync.Mutex
+	routed map[string]bool
+}
+
+func (r *leaderRoutingRecorder) intercept(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	md, _ := metadata.FromOutgoingContext(ctx)
+	r.mu.Lock()
+	r.routed[method[strings.LastIndex(method, "/")+1:]] = len(md.Get(routeToLeaderHeader)) > 0
+	r.mu.Unlock()
+	return invoker(ctx, method, req, reply, cc, opts...)
+}
+
+func (r *leaderRoutingRecorder) check(t *testing.T, name, method string, want bool) {
+	t.Helper()
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	got, ok := r.routed[method]
+	if !ok {
+		t.Errorf("%s: no %s call", name, method)
+	} else if got != want {
+		t.Errorf("%s: %s routed to leader: got %v, want %v", name, method, got, want)
+	}
+	delete(r.routed, method)
+}
+
+func TestClient_LeaderRouting(t *testing.T) {
+	t.Parallel()
+
+	for _, test := range []struct {
+		clientDisabled bool
+		lr             LeaderRouting
+		want           bool
+	}{
+		{false, LeaderRoutingDefault, true},
+		{false, LeaderRoutingDisabled, false},
+		{true, LeaderRoutingDefault, false},
+		{true, LeaderRoutingEnabled, true},
+	} {
+		rec := &leaderRoutingRecorder{routed: map[string]bool{}}
+		_, client, teardown := setupMockedTestServerWithConfigAndClientOptions(t,
+			ClientConfig{DisableRouteToLeader: test.clientDisabled},
+			[]option.ClientOption{option.WithGRPCDialOption(grpc.WithChainUnaryInterceptor(rec.intercept))})
+		ctx := context.Background()
+		ms := []*Mutation{Insert("foo", []string{"col1"}, []interface{}{"val1"})}
+		prefix := fmt.Sprintf("client disabled %v, %v", test.clientDisabled, test.lr)
+
+		if _, err := client.Apply(ctx, ms, ApplyLeaderRouting(test.lr)); err != nil {
+			t.Fatal(err)
+		}
+		rec.check(t, prefix+": Apply", "Commit", test.want)
+		if _, err := client.Apply(ctx, ms, ApplyAtLeastOnce(), ApplyLeaderRouting(test.lr)); err != nil {
+			t.Fatal(err)
+		}
+		rec.check(t, prefix+": ApplyAtLeastOnce", "Commit", test.want)
+		if _, err := client.ReadWriteTransactionWithOptions(ctx, func(ctx context.Context, tx *ReadWriteTransaction) error {
+			_, err := tx.Update(ctx, NewStatement(UpdateBarSetFoo))
+			return err
+		}, TransactionOptions{LeaderRouting: test.lr}); err != nil {
+			t.Fatal(err)
+		}
+		rec.check(t, prefix+": ReadWriteTransaction", "ExecuteSql", test.want)
+		rec.check(t, prefix+": ReadWriteTransaction", "Commit", test.want)
+		if _, err := client.ReadWriteTransaction(ctx, func(ctx context.Context, tx *ReadWriteTransaction) error {
+			opts := QueryOptions{LeaderRouting: test.lr}
+			if _, err := tx.UpdateWithOptions(ctx, NewStatement(UpdateBarSetFoo), opts); err != nil {
+				return err
+			}
+			rec.check(t, prefix+": UpdateWithOptions", "ExecuteSql", test.want)
+			_, err := tx.BatchUpdateWithOptions(ctx, []Statement{NewStatement(UpdateBarSetFoo)}, opts)
+			return err
+		}); err != nil {
+			t.Fatal(err)
+		}
+		rec.check(t, prefix+": BatchUpdateWithOptions", "ExecuteBatchDml", test.want)
+		rec.check(t, prefix+": ReadWriteTransaction", "Commit", !test.clientDisabled)
+		if _, err := client.PartitionedUpdateWithOptions(ctx, NewStatement(UpdateBarSetFoo), QueryOptions{LeaderRouting: test.lr}); err != nil {
+			t.Fatal(err)
+		}
+		rec.check(t, prefix+": PartitionedUpdate", "ExecuteSql", test.want)
+		teardown()
+	}
+}
+
 func TestClient_PartitionQuery_RequestOptions(t *testing.T) {
 	t.Parallel()
 
@@ -197,6 +197,13 @@ into that:
 	var s struct { Name string; Balance int64 }
 	err = row.ToStruct(&s)
 
+The generic functions ToStruct and All do the same, returning values of the
+struct type you name. All decodes every row of an iterator, working out which
+column goes into which field only once:
+
+	type Account struct { Name string; Balance int64 }
+	accounts, err := spanner.All[Account](iter)
+
 For Cloud Spanner columns that may contain NULL, use one of the NullXXX types,
 like NullString:
 
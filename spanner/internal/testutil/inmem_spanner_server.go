@@ -87,6 +87,7 @@ const (
 	MethodExecuteStreamingSql string = "EXECUTE_STREAMING_SQL"
 	MethodExecuteBatchDml     string = "EXECUTE_BATCH_DML"
 	MethodStreamingRead       string = "EXECUTE_STREAMING_READ"
+	MethodBatchWrite          string = "BATCH_WRITE"
 )
 
 // StatementResult represents a mocked result on the test server. The result is
@@ -690,10 +691,12 @@ func (s *inMemSpannerServer) CreateSession(ctx context.Context, req *spannerpb.C
 	sessionName := s.generateSessionNameLocked(req.Database)
 	ts := getCurrentTimestamp()
 	var creatorRole string
+	var multiplexed bool
 	if req.Session != nil {
 		creatorRole = req.Session.CreatorRole
+		multiplexed = req.Session.Multiplexed
 	}
-	session := &spannerpb.Session{Name: sessionName, CreateTime: ts, ApproximateLastUseTime: ts, CreatorRole: creatorRole}
+	session := &spannerpb.Session{Name: sessionName, CreateTime: ts, ApproximateLastUseTime: ts, CreatorRole: creatorRole, Multiplexed: multiplexed}
 	s.totalSessionsCreated++
 	s.sessions[sessionName] = session
 	return session, nil
@@ -1052,6 +1055,34 @@ func (s *inMemSpannerServer) Commit(ctx context.Context, req *spannerpb.CommitRe
 	return resp, nil
 }
 
+func (s *inMemSpannerServer) BatchWrite(req *spannerpb.BatchWriteRequest, stream spannerpb.Spanner_BatchWriteServer) error {
+	if err := s.simulateExecutionTime(MethodBatchWrite, req); err != nil {
+		return err
+	}
+	if req.Session == "" {
+		return gstatus.Error(codes.InvalidArgument, "Missing session name")
+	}
+	session, err := s.findSession(req.Session)
+	if err != nil {
+		return err
+	}
+	s.updateSessionLastUseTime(session.Name)
+	if len(req.MutationGroups) == 0 {
+		return gstatus.Error(codes.InvalidArgument, "No mutation groups in batch write request")
+	}
+	// Each mutation group is applied in a separate transaction.
+	for i := range req.MutationGroups {
+		if err := stream.Send(&spannerpb.BatchWriteResponse{
+			Indexes:         []int32{int32(i)},
+			Status:          &status.Status{Code: int32(codes.OK)},
+			CommitTimestamp: getCurrentTimestamp(),
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (s *inMemSpannerServer) Rollback(ctx context.Context, req *spannerpb.RollbackRequest) (*emptypb.Empty, error) {
 	s.mu.Lock()
 	if s.stopped {
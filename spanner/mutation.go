@@ -121,6 +121,14 @@ const (
 //	[]Date, []*Date, []NullDate - DATE ARRAY
 //	big.Rat, *big.Rat, NullNumeric - NUMERIC
 //	[]big.Rat, []*big.Rat, []NullNumeric - NUMERIC ARRAY
+//	*pb.Message - PROTO
+//	[]*pb.Message - PROTO ARRAY
+//	pb.Enum, *pb.Enum - ENUM
+//	[]pb.Enum, []*pb.Enum - ENUM ARRAY
+//
+// pb.Message and pb.Enum stand for any generated protocol buffer message and
+// enum type. Their values are sent with the fully qualified name of the proto
+// type, taken from its descriptor.
 //
 // To compare two Mutations for testing purposes, use reflect.DeepEqual.
 type Mutation struct {
@@ -141,6 +149,13 @@ type Mutation struct {
 	values []interface{}
 }
 
+// A MutationGroup is a list of Mutations that are committed atomically by
+// Client.BatchWrite.
+type MutationGroup struct {
+	// Mutations are the mutations in the group.
+	Mutations []*Mutation
+}
+
 // mapToMutationParams converts Go map into mutation parameters.
 func mapToMutationParams(in map[string]interface{}) ([]string, []interface{}) {
 	cols := []string{}
@@ -432,3 +447,18 @@ func mutationsProto(ms []*Mutation) ([]*sppb.Mutation, error) {
 	}
 	return l, nil
 }
+
+// mutationGroupsProto turns a spanner.MutationGroup array into a
+// sppb.BatchWriteRequest_MutationGroup array, in preparation to send a
+// BatchWrite RPC.
+func mutationGroupsProto(mgs []*MutationGroup) ([]*sppb.BatchWriteRequest_MutationGroup, error) {
+	gs := make([]*sppb.BatchWriteRequest_MutationGroup, 0, len(mgs))
+	for _, mg := range mgs {
+		ms, err := mutationsProto(mg.Mutations)
+		if err != nil {
+			return nil, err
+		}
+		gs = append(gs, &sppb.BatchWriteRequest_MutationGroup{Mutations: ms})
+	}
+	return gs, nil
+}
@@ -21,6 +21,7 @@ import (
 	"context"
 	"io"
 	"log"
+	"reflect"
 	"sync/atomic"
 	"time"
 
@@ -224,6 +225,42 @@ func (r *RowIterator) Do(f func(r *Row) error) error {
 	}
 }
 
+// All decodes the rows remaining in iter into values of the struct type T,
+// following the same rules as Row.ToStruct, and returns them. The mapping from
+// the columns to the fields of T is computed once for the whole iteration.
+//
+// All always calls Stop on the iterator.
+func All[T any](iter *RowIterator) ([]T, error) {
+	var zero T
+	t := reflect.TypeOf(zero)
+	if t == nil || t.Kind() != reflect.Struct {
+		iter.Stop()
+		return nil, errToStructArgType(&zero)
+	}
+	var (
+		vs []T
+		p  *rowPlan
+	)
+	err := iter.Do(func(r *Row) error {
+		if p == nil {
+			var err error
+			if p, err = cachedRowPlan(t, r.fields); err != nil {
+				return err
+			}
+		}
+		var v T
+		if err := p.decode(r, reflect.ValueOf(&v).Elem()); err != nil {
+			return err
+		}
+		vs = append(vs, v)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return vs, nil
+}
+
 // Stop terminates the iteration. It should be called after you finish using the
 // iterator.
 func (r *RowIterator) Stop() {
@@ -1782,3 +1782,39 @@ func createSession(client *vkit.Client) (*sppb.Session, error) {
 	}
 	return client.CreateSession(context.Background(), request)
 }
+
+func TestAll(t *testing.T) {
+	_, c, teardown := setupMockedTestServer(t)
+	defer teardown()
+	ctx := context.Background()
+
+	type album struct {
+		SingerID   int64 `spanner:"SingerId"`
+		AlbumID    int64 `spanner:"AlbumId"`
+		AlbumTitle string
+	}
+	got, err := All[album](c.Single().Query(ctx, NewStatement(SelectSingerIDAlbumIDAlbumTitleFromAlbums)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []album{
+		{1, 0, "Album title 0"},
+		{2, 11, "Album title 1"},
+		{3, 22, "Album title 2"},
+	}
+	if !testEqual(got, want) {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+
+	// Every column must have a field, as with Row.ToStruct.
+	type singer struct {
+		SingerID int64 `spanner:"SingerId"`
+	}
+	_, err = All[singer](c.Single().Query(ctx, NewStatement(SelectSingerIDAlbumIDAlbumTitleFromAlbums)))
+	if ErrCode(err) != codes.InvalidArgument {
+		t.Errorf("got %v, want InvalidArgument", err)
+	}
+	if _, err := All[*album](c.Single().Query(ctx, NewStatement(SelectSingerIDAlbumIDAlbumTitleFromAlbums))); ErrCode(err) != codes.InvalidArgument {
+		t.Errorf("got %v, want InvalidArgument", err)
+	}
+}
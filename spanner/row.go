@@ -19,6 +19,8 @@ package spanner
 import (
 	"fmt"
 	"reflect"
+	"strings"
+	"sync"
 
 	sppb "cloud.google.com/go/spanner/apiv1/spannerpb"
 	proto3 "github.com/golang/protobuf/ptypes/struct"
@@ -67,10 +69,18 @@ import (
 //	*[]*some_go_struct, *[]NullRow - STRUCT ARRAY
 //	*NullJSON - JSON
 //	*[]NullJSON - JSON ARRAY
+//	*pb.Message(not NULL), **pb.Message - PROTO
+//	*[]*pb.Message - PROTO ARRAY
+//	*pb.Enum(not NULL), **pb.Enum - ENUM
+//	*[]pb.Enum, *[]*pb.Enum - ENUM ARRAY
 //	*GenericColumnValue - any Cloud Spanner type
 //
 // For TIMESTAMP columns, the returned time.Time object will be in UTC.
 //
+// pb.Message and pb.Enum stand for any generated protocol buffer message and
+// enum type. A generated message can also be fetched from a BYTES column, and
+// a generated enum from an INT64 column.
+//
 // To fetch an array of BYTES, pass a *[][]byte. To fetch an array of (sub)rows, pass
 // a *[]spanner.NullRow or a *[]*some_go_struct where some_go_struct holds all
 // information of the subrow, see spanner.Row.ToStruct for the mapping between a
@@ -362,3 +372,92 @@ func (r *Row) ToStructLenient(p interface{}) error {
 		true,
 	)
 }
+
+// ToStruct decodes the row into a new value of the struct type T, following
+// the same rules as Row.ToStruct. The mapping from the row's columns to the
+// fields of T is computed once for each combination of T and column names, so
+// decoding many rows of the same shape avoids most of the reflection that
+// Row.ToStruct does per row.
+func ToStruct[T any](r *Row) (T, error) {
+	var v T
+	if t := reflect.TypeOf(v); t == nil || t.Kind() != reflect.Struct {
+		return v, errToStructArgType(&v)
+	}
+	p, err := cachedRowPlan(reflect.TypeOf(v), r.fields)
+	if err != nil {
+		return v, err
+	}
+	err = p.decode(r, reflect.ValueOf(&v).Elem())
+	return v, err
+}
+
+// rowPlan maps the columns of rows with the same column names to the fields
+// of a struct type.
+type rowPlan struct {
+	index [][]int // the field index of each column
+}
+
+type rowPlanKey struct {
+	t       reflect.Type
+	columns string // column names, separated by NUL bytes
+}
+
+var rowPlans sync.Map // rowPlanKey -> *rowPlan
+
+func cachedRowPlan(t reflect.Type, cols []*sppb.StructType_Field) (*rowPlan, error) {
+	var b strings.Builder
+	for _, f := range cols {
+		b.WriteString(f.Name)
+		b.WriteByte(0)
+	}
+	key := rowPlanKey{t, b.String()}
+	if p, ok := rowPlans.Load(key); ok {
+		return p.(*rowPlan), nil
+	}
+	p, err := newRowPlan(t, cols)
+	if err != nil {
+		return nil, err
+	}
+	rowPlans.Store(key, p)
+	return p, nil
+}
+
+// newRowPlan matches cols to the fields of the struct type t, reporting the
+// same errors as Row.ToStruct.
+func newRowPlan(t reflect.Type, cols []*sppb.StructType_Field) (*rowPlan, error) {
+	fields, err := fieldCache.Fields(t)
+	if err != nil {
+		return nil, ToSpannerError(err)
+	}
+	ty := &sppb.StructType{Fields: cols}
+	p := &rowPlan{index: make([][]int, len(cols))}
+	seen := map[string]bool{}
+	for i, f := range cols {
+		if f.Name == "" {
+			return nil, errUnnamedField(ty, i)
+		}
+		sf := fields.Match(f.Name)
+		if sf == nil {
+			return nil, errNoOrDupGoField(reflect.New(t).Interface(), f.Name)
+		}
+		if seen[f.Name] {
+			return nil, errDupSpannerField(f.Name, ty)
+		}
+		seen[f.Name] = true
+		p.index[i] = sf.Index
+	}
+	return p, nil
+}
+
+// decode decodes the columns of r into the fields of the struct v.
+func (p *rowPlan) decode(r *Row, v reflect.Value) error {
+	if len(r.vals) != len(r.fields) || len(r.fields) != len(p.index) {
+		return errFieldsMismatchVals(r)
+	}
+	for i, f := range r.fields {
+		if err := decodeValue(r.vals[i], f.Type, v.FieldByIndex(p.index[i]).Addr().Interface()); err != nil {
+			return errDecodeStructField(&sppb.StructType{Fields: r.fields}, f.Name, err)
+		}
+	}
+	return nil
+}
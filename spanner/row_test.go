@@ -1967,3 +1967,68 @@ func BenchmarkColumn(b *testing.B) {
 		}
 	}
 }
+
+func TestToStructGeneric(t *testing.T) {
+	type s struct {
+		F1 string
+		F2 NullInt64 `spanner:"col2"`
+	}
+	r := &Row{
+		[]*sppb.StructType_Field{
+			{Name: "F1", Type: stringType()},
+			{Name: "col2", Type: intType()},
+		},
+		[]*proto3.Value{
+			stringProto("v1"),
+			intProto(2),
+		},
+	}
+	for i := 0; i < 2; i++ { // the second time uses the cached plan
+		got, err := ToStruct[s](r)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want := (s{F1: "v1", F2: NullInt64{2, true}}); got != want {
+			t.Errorf("got %+v, want %+v", got, want)
+		}
+		var want s
+		if err := r.ToStruct(&want); err != nil {
+			t.Fatal(err)
+		}
+		if got != want {
+			t.Errorf("ToStruct[s] = %+v, Row.ToStruct = %+v", got, want)
+		}
+	}
+
+	for _, test := range []struct {
+		desc string
+		f    func() error
+		want error
+	}{
+		{
+			"not a struct",
+			func() error { _, err := ToStruct[int](r); return err },
+			errToStructArgType(new(int)),
+		},
+		{
+			"missing field",
+			func() error { _, err := ToStruct[struct{ F1 string }](r); return err },
+			errNoOrDupGoField(&struct{ F1 string }{}, "col2"),
+		},
+		{
+			"bad field type",
+			func() error {
+				_, err := ToStruct[struct {
+					F1   string
+					Col2 string
+				}](r)
+				return err
+			},
+			errDecodeStructField(&sppb.StructType{Fields: r.fields}, "col2", errTypeMismatch(sppb.TypeCode_INT64, sppb.TypeCode_TYPE_CODE_UNSPECIFIED, new(string))),
+		},
+	} {
+		if got := test.f(); !testEqual(got, test.want) {
+			t.Errorf("%s: got %v, want %v", test.desc, got, test.want)
+		}
+	}
+}
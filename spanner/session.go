@@ -79,7 +79,11 @@ func (sh *sessionHandle) recycle() {
 	sh.checkoutTime = time.Time{}
 	sh.stack = nil
 	sh.mu.Unlock()
-	s.recycle()
+	if !s.multiplexed {
+		// The multiplexed session is shared by all its handles and never
+		// returns to the idle list.
+		s.recycle()
+	}
 	if tracked != nil {
 		p.mu.Lock()
 		p.trackedSessionHandles.Remove(tracked)
@@ -155,6 +159,10 @@ func (sh *sessionHandle) destroy() {
 		p.trackedSessionHandles.Remove(tracked)
 		p.mu.Unlock()
 	}
+	if s.multiplexed {
+		s.pool.discardMultiplexedSession(s)
+		return
+	}
 	s.destroy(false)
 }
 
@@ -176,6 +184,11 @@ type session struct {
 	// logger is the logger configured for the Spanner client that created the
 	// session. If nil, logging will be directed to the standard logger.
 	logger *log.Logger
+	// multiplexed is true for the multiplexed session of a pool, which many
+	// transactions can use at the same time. It is set only once during
+	// session's creation. A multiplexed session is never in the idle list or
+	// the healthcheck queue.
+	multiplexed bool
 
 	// mu protects the following fields from concurrent access: both
 	// healthcheck workers and transactions can modify them.
@@ -432,6 +445,25 @@ type SessionPoolConfig struct {
 	// Defaults to false.
 	TrackSessionHandles bool
 
+	// MultiplexedSessions makes read-only transactions and single-use reads
+	// and queries use the multiplexed session of the pool instead of taking
+	// sessions from it. A multiplexed session can be used by any number of
+	// transactions at the same time, so the pool then only needs sessions
+	// for read/write transactions, partitioned DML and batch writes.
+	//
+	// The multiplexed session is created on first use, and is replaced once
+	// it is a week old. It is not health checked, and is not deleted when
+	// the client is closed, as Cloud Spanner does not allow that.
+	//
+	// Defaults to false.
+	MultiplexedSessions bool
+
+	// multiplexedSessionRefreshInterval is the age at which the multiplexed
+	// session is replaced.
+	//
+	// Defaults to 7 days.
+	multiplexedSessionRefreshInterval time.Duration
+
 	// healthCheckSampleInterval is how often the health checker samples live
 	// session (for use in maintaining session pool size).
 	//
@@ -552,6 +584,21 @@ type sessionPool struct {
 
 	// tagMap is a map of all tags that are associated with the emitted metrics.
 	tagMap *tag.Map
+
+	// rolePools holds the pools of sessions created with a database role other
+	// than that of sc, keyed by role. They are created on first use, up to
+	// maxRolePools of them.
+	rolePools map[string]*sessionPool
+
+	// multiplexedSession is the multiplexed session of the pool, if
+	// MultiplexedSessions is set and it has been created.
+	multiplexedSession *session
+	// multiplexedSessionCreated is closed when the ongoing creation of a
+	// multiplexed session finishes. It is nil if there is none.
+	multiplexedSessionCreated chan struct{}
+	// multiplexedSessionError is the error of the last failed creation of a
+	// multiplexed session.
+	multiplexedSessionError error
 }
 
 // newSessionPool creates a new session pool.
@@ -559,6 +606,9 @@ func newSessionPool(sc *sessionClient, config SessionPoolConfig) (*sessionPool,
 	if err := config.validate(); err != nil {
 		return nil, err
 	}
+	if config.multiplexedSessionRefreshInterval == 0 {
+		config.multiplexedSessionRefreshInterval = 7 * 24 * time.Hour
+	}
 	pool := &sessionPool{
 		sc:                sc,
 		valid:             true,
@@ -721,7 +771,16 @@ func (p *sessionPool) close(ctx context.Context) {
 		return
 	}
 	p.valid = false
+	rolePools := p.rolePools
+	p.rolePools = nil
+	// The multiplexed session cannot be deleted, and is left to expire.
+	p.multiplexedSession = nil
 	p.mu.Unlock()
+	for _, rp := range rolePools {
+		rp.close(ctx)
+		// The connections are shared with p, and are closed with them.
+		rp.sc.markClosed()
+	}
 	p.hc.close()
 	// destroy all the sessions
 	p.hc.mu.Lock()
@@ -741,6 +800,58 @@ func deleteSession(ctx context.Context, s *session, wg *sync.WaitGroup) {
 	s.destroyWithContext(ctx, false)
 }
 
+// maxRolePools is the maximum number of database roles, other than that of
+// the client, that a client can use.
+const maxRolePools = 8
+
+// errTooManyDatabaseRoles is the error for using more than maxRolePools
+// database roles.
+var errTooManyDatabaseRoles = spannerErrorf(codes.FailedPrecondition, "a client can use at most %d database roles other than its own", maxRolePools)
+
+// forRole returns the pool of sessions that are created with the given
+// database role. It returns p itself if role is empty or the role of p.
+//
+// Other pools share the connections of p, and do not open any sessions until
+// they are needed. There are at most maxRolePools of them, and together they
+// open at most MaxOpened sessions in addition to those of p: each opens at
+// most MaxOpened/maxRolePools sessions, with a MaxIdle of 0 and a single
+// health check worker.
+func (p *sessionPool) forRole(role string) (*sessionPool, error) {
+	if role == "" || role == p.sc.databaseRole {
+		return p, nil
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if !p.valid {
+		return nil, errInvalidSessionPool
+	}
+	if rp, ok := p.rolePools[role]; ok {
+		return rp, nil
+	}
+	if len(p.rolePools) >= maxRolePools {
+		return nil, errTooManyDatabaseRoles
+	}
+	config := p.SessionPoolConfig
+	config.MinOpened = 0
+	config.MaxIdle = 0
+	if config.MaxOpened > 0 {
+		config.MaxOpened /= maxRolePools
+		if config.MaxOpened == 0 {
+			config.MaxOpened = 1
+		}
+	}
+	config.HealthCheckWorkers = 1
+	rp, err := newSessionPool(p.sc.withDatabaseRole(role), config)
+	if err != nil {
+		return nil, err
+	}
+	if p.rolePools == nil {
+		p.rolePools = make(map[string]*sessionPool)
+	}
+	p.rolePools[role] = rp
+	return rp, nil
+}
+
 // errInvalidSessionPool is the error for using an invalid session pool.
 var errInvalidSessionPool = spannerErrorf(codes.InvalidArgument, "invalid session pool")
 
@@ -932,6 +1043,82 @@ func (p *sessionPool) take(ctx context.Context) (*sessionHandle, error) {
 	}
 }
 
+// takeMultiplexed returns a handle to the multiplexed session of the pool,
+// which it creates if there is none. It returns a session from the pool, like
+// take, if MultiplexedSessions is not set.
+func (p *sessionPool) takeMultiplexed(ctx context.Context) (*sessionHandle, error) {
+	if !p.MultiplexedSessions {
+		return p.take(ctx)
+	}
+	trace.TracePrintf(ctx, nil, "Acquiring the multiplexed session")
+	for {
+		p.mu.Lock()
+		if !p.valid {
+			p.mu.Unlock()
+			return nil, errInvalidSessionPool
+		}
+		s := p.multiplexedSession
+		if p.multiplexedSessionCreated == nil && (s == nil || time.Since(s.createTime) >= p.multiplexedSessionRefreshInterval) {
+			// An old multiplexed session remains in use until its
+			// replacement has been created.
+			p.createMultiplexedSessionLocked()
+		}
+		if s != nil {
+			p.mu.Unlock()
+			return p.newSessionHandle(s), nil
+		}
+		created := p.multiplexedSessionCreated
+		p.mu.Unlock()
+		select {
+		case <-ctx.Done():
+			trace.TracePrintf(ctx, nil, "Context done waiting for the multiplexed session")
+			p.recordStat(ctx, GetSessionTimeoutsCount, 1)
+			return nil, p.errGetSessionTimeout(ctx)
+		case <-created:
+			p.mu.Lock()
+			err := p.multiplexedSessionError
+			ok := p.multiplexedSession != nil
+			p.mu.Unlock()
+			if !ok && err != nil {
+				trace.TracePrintf(ctx, nil, "Error creating the multiplexed session: %v", err)
+				return nil, err
+			}
+		}
+	}
+}
+
+// createMultiplexedSessionLocked starts the creation of a new multiplexed
+// session in the background. The caller must hold p.mu.
+func (p *sessionPool) createMultiplexedSessionLocked() {
+	created := make(chan struct{})
+	p.multiplexedSessionCreated = created
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), p.sc.batchTimeout)
+		defer cancel()
+		s, err := p.sc.createMultiplexedSession(ctx)
+		p.mu.Lock()
+		defer p.mu.Unlock()
+		if err == nil && p.valid {
+			s.pool = p
+			p.multiplexedSession = s
+		}
+		p.multiplexedSessionError = err
+		p.multiplexedSessionCreated = nil
+		close(created)
+	}()
+}
+
+// discardMultiplexedSession stops the use of the multiplexed session s, which
+// Cloud Spanner no longer knows, so that the next transaction creates a new
+// one.
+func (p *sessionPool) discardMultiplexedSession(s *session) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.multiplexedSession == s {
+		p.multiplexedSession = nil
+	}
+}
+
 // recycle puts session s back to the session pool's idle list, it returns true
 // if the session pool successfully recycles session s.
 func (p *sessionPool) recycle(s *session) bool {
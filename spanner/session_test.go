@@ -649,6 +649,111 @@ func TestSessionDestroy(t *testing.T) {
 	}
 }
 
+// TestMultiplexedSession tests that read-only transactions use the
+// multiplexed session of the pool if MultiplexedSessions is set.
+func TestMultiplexedSession(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	server, client, teardown := setupMockedTestServerWithConfig(t,
+		ClientConfig{
+			SessionPoolConfig: SessionPoolConfig{
+				MinOpened:           0,
+				MultiplexedSessions: true,
+			},
+		})
+	defer teardown()
+	sp := client.idleSessions
+	stmt := NewStatement(SelectSingerIDAlbumIDAlbumTitleFromAlbums)
+	query := func(tx *ReadOnlyTransaction) {
+		iter := tx.Query(ctx, stmt)
+		defer iter.Stop()
+		if err := iter.Do(func(*Row) error { return nil }); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// multiplexedSessions returns the IDs of the multiplexed sessions that
+	// the requests since the last call were sent on.
+	multiplexedSessions := func() map[string]bool {
+		ids := map[string]bool{}
+		for _, req := range drainRequestsFromServer(server.TestSpanner) {
+			switch req := req.(type) {
+			case *sppb.BatchCreateSessionsRequest:
+				t.Errorf("got a BatchCreateSessions request, want none")
+			case *sppb.CreateSessionRequest:
+				if !req.Session.GetMultiplexed() {
+					t.Errorf("got a CreateSession request for a regular session, want a multiplexed session")
+				}
+			case *sppb.ExecuteSqlRequest:
+				ids[req.Session] = true
+			case *sppb.BeginTransactionRequest:
+				ids[req.Session] = true
+			}
+		}
+		return ids
+	}
+
+	query(client.Single())
+	ro := client.ReadOnlyTransaction()
+	query(ro)
+	query(ro)
+	ro.Close()
+	ids := multiplexedSessions()
+	if len(ids) != 1 {
+		t.Fatalf("got requests on sessions %v, want one multiplexed session", ids)
+	}
+	if got := server.TestSpanner.TotalSessionsCreated(); got != 1 {
+		t.Errorf("got %d sessions created, want 1", got)
+	}
+	sp.mu.Lock()
+	numOpened, numInUse := sp.numOpened, sp.numInUse
+	sp.mu.Unlock()
+	if numOpened != 0 || numInUse != 0 {
+		t.Errorf("got %d sessions opened and %d in use in the pool, want 0 and 0", numOpened, numInUse)
+	}
+
+	// A multiplexed session that is not found is replaced.
+	server.TestSpanner.PutExecutionTime(MethodExecuteStreamingSql,
+		SimulatedExecutionTime{Errors: []error{newSessionNotFoundError("projects/p/instances/i/databases/d/sessions/s")}})
+	query(client.Single())
+	if got := server.TestSpanner.TotalSessionsCreated(); got != 2 {
+		t.Errorf("got %d sessions created after session not found, want 2", got)
+	}
+	if newIDs := multiplexedSessions(); len(newIDs) != 2 {
+		t.Errorf("got requests on sessions %v after session not found, want the old and a new multiplexed session", newIDs)
+	}
+
+	// An old multiplexed session is replaced in the background, and is used
+	// until its replacement has been created.
+	sp.mu.Lock()
+	old := sp.multiplexedSession
+	sp.multiplexedSessionRefreshInterval = time.Nanosecond
+	sp.mu.Unlock()
+	query(client.Single())
+	waitFor(t, func() error {
+		sp.mu.Lock()
+		defer sp.mu.Unlock()
+		if sp.multiplexedSession == old {
+			return fmt.Errorf("multiplexed session not replaced")
+		}
+		return nil
+	})
+	multiplexedSessions()
+
+	// Read/write transactions still take sessions from the pool.
+	if _, err := client.ReadWriteTransaction(ctx, func(ctx context.Context, tx *ReadWriteTransaction) error {
+		_, err := tx.Update(ctx, NewStatement(UpdateBarSetFoo))
+		return err
+	}); err != nil {
+		t.Fatal(err)
+	}
+	sp.mu.Lock()
+	numOpened = sp.numOpened
+	sp.mu.Unlock()
+	if numOpened == 0 {
+		t.Error("got no sessions opened in the pool for a read/write transaction")
+	}
+}
+
 // TestHcHeap tests heap operation on top of hcHeap.
 func TestHcHeap(t *testing.T) {
 	in := []*session{
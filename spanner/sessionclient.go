@@ -118,6 +118,19 @@ func newSessionClient(connPool gtransport.ConnPool, database, userAgent string,
 	}
 }
 
+// withDatabaseRole returns a session client that creates sessions with the
+// given database role, using the connections of sc.
+func (sc *sessionClient) withDatabaseRole(role string) *sessionClient {
+	return newSessionClient(sc.connPool, sc.database, sc.userAgent, sc.sessionLabels, role, sc.disableRouteToLeader, sc.md, sc.batchTimeout, sc.logger, sc.callOptions)
+}
+
+// markClosed marks sc closed, without closing its connections.
+func (sc *sessionClient) markClosed() {
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+	sc.closed = true
+}
+
 func (sc *sessionClient) close() error {
 	sc.mu.Lock()
 	defer sc.mu.Unlock()
@@ -128,6 +141,16 @@ func (sc *sessionClient) close() error {
 // createSession creates one session for the database of the sessionClient. The
 // session is created using one synchronous RPC.
 func (sc *sessionClient) createSession(ctx context.Context) (*session, error) {
+	return sc.doCreateSession(ctx, false)
+}
+
+// createMultiplexedSession creates a multiplexed session for the database of
+// the sessionClient, which many transactions can use at the same time.
+func (sc *sessionClient) createMultiplexedSession(ctx context.Context) (*session, error) {
+	return sc.doCreateSession(ctx, true)
+}
+
+func (sc *sessionClient) doCreateSession(ctx context.Context, multiplexed bool) (*session, error) {
 	sc.mu.Lock()
 	if sc.closed {
 		sc.mu.Unlock()
@@ -142,7 +165,7 @@ func (sc *sessionClient) createSession(ctx context.Context) (*session, error) {
 	var md metadata.MD
 	sid, err := client.CreateSession(contextWithOutgoingMetadata(ctx, sc.md, sc.disableRouteToLeader), &sppb.CreateSessionRequest{
 		Database: sc.database,
-		Session:  &sppb.Session{Labels: sc.sessionLabels, CreatorRole: sc.databaseRole},
+		Session:  &sppb.Session{Labels: sc.sessionLabels, CreatorRole: sc.databaseRole, Multiplexed: multiplexed},
 	}, gax.WithGRPCOptions(grpc.Header(&md)))
 
 	if getGFELatencyMetricsFlag() && md != nil {
@@ -167,7 +190,7 @@ func (sc *sessionClient) createSession(ctx context.Context) (*session, error) {
 	if err != nil {
 		return nil, ToSpannerError(err)
 	}
-	return &session{valid: true, client: client, id: sid.Name, createTime: time.Now(), md: sc.md, logger: sc.logger}, nil
+	return &session{valid: true, client: client, id: sid.Name, createTime: time.Now(), md: sc.md, logger: sc.logger, multiplexed: multiplexed}, nil
 }
 
 // batchCreateSessions creates a batch of sessions for the database of the
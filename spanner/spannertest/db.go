@@ -99,6 +99,12 @@ type transaction struct {
 	d               *database
 	commitTimestamp time.Time // not set if readOnly
 	unlock          func()    // may be nil
+
+	// undo holds the rows of each table changed by DML in this transaction,
+	// as they were before the first change, so that Rollback can restore them.
+	// DML is applied to the tables immediately, and is not isolated from
+	// other transactions.
+	undo map[*table][]row
 }
 
 func (d *database) NewReadOnlyTransaction() *transaction {
@@ -153,6 +159,7 @@ func (tx *transaction) Commit() (time.Time, error) {
 	if tx.unlock != nil {
 		tx.unlock()
 	}
+	tx.undo = nil
 	return tx.commitTimestamp, nil
 }
 
@@ -160,7 +167,29 @@ func (tx *transaction) Rollback() {
 	if tx.unlock != nil {
 		tx.unlock()
 	}
-	// TODO: actually rollback
+	// Mutations are only applied on commit, so only DML needs to be undone.
+	for t, rows := range tx.undo {
+		t.mu.Lock()
+		t.rows = rows
+		t.mu.Unlock()
+	}
+	tx.undo = nil
+}
+
+// saveForUndo records the rows of t, if they have not been recorded yet, so
+// that Rollback can restore them. t.mu must be held.
+func (tx *transaction) saveForUndo(t *table) {
+	if _, ok := tx.undo[t]; ok {
+		return
+	}
+	if tx.undo == nil {
+		tx.undo = make(map[*table][]row)
+	}
+	rows := make([]row, len(t.rows))
+	for i, r := range t.rows {
+		rows[i] = r.copyAllData()
+	}
+	tx.undo[t] = rows
 }
 
 /*
@@ -1168,9 +1197,12 @@ func (r *keyRange) String() string {
 
 type keyRangeList []*keyRange
 
-// Execute runs a DML statement.
+// Execute runs a DML statement in a transaction.
 // It returns the number of affected rows.
-func (d *database) Execute(stmt spansql.DMLStmt, params queryParams) (int, error) { // TODO: return *status.Status instead?
+func (d *database) Execute(tx *transaction, stmt spansql.DMLStmt, params queryParams) (int, error) { // TODO: return *status.Status instead?
+	if err := tx.checkMutable(); err != nil {
+		return 0, err
+	}
 	switch stmt := stmt.(type) {
 	default:
 		return 0, status.Errorf(codes.Unimplemented, "unhandled DML statement type %T", stmt)
@@ -1182,6 +1214,7 @@ func (d *database) Execute(stmt spansql.DMLStmt, params queryParams) (int, error
 
 		t.mu.Lock()
 		defer t.mu.Unlock()
+		tx.saveForUndo(t)
 
 		n := 0
 		for i := 0; i < len(t.rows); {
@@ -1211,6 +1244,7 @@ func (d *database) Execute(stmt spansql.DMLStmt, params queryParams) (int, error
 
 		t.mu.Lock()
 		defer t.mu.Unlock()
+		tx.saveForUndo(t)
 
 		ec := evalContext{
 			cols:   t.cols,
@@ -1270,6 +1304,7 @@ func (d *database) Execute(stmt spansql.DMLStmt, params queryParams) (int, error
 
 		t.mu.Lock()
 		defer t.mu.Unlock()
+		tx.saveForUndo(t)
 
 		ec := evalContext{
 			cols:   t.cols,
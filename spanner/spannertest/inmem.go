@@ -41,6 +41,10 @@ variable and use the regular spanner.NewClient:
 	client, err := spanner.NewClient(ctx, db)
 	...
 
+Read-write transactions may use DML, including batch DML. DML statements are
+applied as soon as they are executed and undone if the transaction is rolled
+back; unlike in Cloud Spanner, transactions are not isolated from one another.
+
 The same server also supports database admin operations for use with
 the cloud.google.com/go/spanner/admin/database/apiv1 package. This only
 simulates the existence of a single database; its name is ignored.
@@ -522,33 +526,60 @@ func (s *server) ExecuteSql(ctx context.Context, req *spannerpb.ExecuteSqlReques
 		return s.resultSet(ri)
 	}
 
+	return s.executeDML(ctx, req.Session, req.Transaction, req.Sql, req.Params, req.ParamTypes)
+}
+
+// dmlTx returns the read-write transaction in which to execute a DML
+// statement, beginning one if the selector asks for it. The returned bool
+// reports whether a transaction was begun.
+func (s *server) dmlTx(ctx context.Context, session string, tsel *spannerpb.TransactionSelector) (*transaction, bool, error) {
 	var tid string
-	obj, ok := req.Transaction.Selector.(*spannerpb.TransactionSelector_Id)
-	if ok {
-		tid = string(obj.Id)
-	}
-	isTransactionBegin := false
-	if !ok {
-		if _, ok := req.Transaction.Selector.(*spannerpb.TransactionSelector_Begin); !ok {
-			return nil, fmt.Errorf("unsupported transaction type %T", req.Transaction.Selector)
-		}
-		isTransactionBegin = true
-		tx, err := s.BeginTransaction(ctx, &spannerpb.BeginTransactionRequest{
-			Session: req.Session,
+	begun := false
+	switch sel := tsel.GetSelector().(type) {
+	default:
+		return nil, false, fmt.Errorf("unsupported transaction type %T", sel)
+	case *spannerpb.TransactionSelector_Id:
+		tid = string(sel.Id)
+	case *spannerpb.TransactionSelector_Begin:
+		tr, err := s.BeginTransaction(ctx, &spannerpb.BeginTransactionRequest{
+			Session: session,
 		})
 		if err != nil {
-			return nil, err
+			return nil, false, err
 		}
-		tid = string(tx.Id)
+		tid = string(tr.Id)
+		begun = true
+	}
+
+	s.mu.Lock()
+	sess, ok := s.sessions[session]
+	s.mu.Unlock()
+	if !ok {
+		// TODO: what error does the real Spanner return?
+		return nil, false, status.Errorf(codes.NotFound, "unknown session %q", session)
+	}
+	sess.mu.Lock()
+	sess.lastUse = time.Now()
+	tx, ok := sess.transactions[tid]
+	sess.mu.Unlock()
+	if !ok {
+		return nil, false, fmt.Errorf("no transaction with id %q", tid)
 	}
+	return tx, begun, nil
+}
 
-	_ = tid // TODO: lookup an existing transaction by ID.
+// executeDML executes a DML statement in the transaction selected by tsel.
+func (s *server) executeDML(ctx context.Context, session string, tsel *spannerpb.TransactionSelector, sql string, p *structpb.Struct, types map[string]*spannerpb.Type) (*spannerpb.ResultSet, error) {
+	tx, begun, err := s.dmlTx(ctx, session, tsel)
+	if err != nil {
+		return nil, err
+	}
 
-	stmt, err := spansql.ParseDMLStmt(req.Sql)
+	stmt, err := spansql.ParseDMLStmt(sql)
 	if err != nil {
 		return nil, status.Errorf(codes.InvalidArgument, "bad DML: %v", err)
 	}
-	params, err := parseQueryParams(req.GetParams(), req.ParamTypes)
+	params, err := parseQueryParams(p, types)
 	if err != nil {
 		return nil, err
 	}
@@ -558,7 +589,7 @@ func (s *server) ExecuteSql(ctx context.Context, req *spannerpb.ExecuteSqlReques
 		s.logf("        ▹ %v", params)
 	}
 
-	n, err := s.db.Execute(stmt, params)
+	n, err := s.db.Execute(tx, stmt, params)
 	if err != nil {
 		return nil, err
 	}
@@ -567,13 +598,55 @@ func (s *server) ExecuteSql(ctx context.Context, req *spannerpb.ExecuteSqlReques
 			RowCount: &spannerpb.ResultSetStats_RowCountExact{RowCountExact: int64(n)},
 		},
 	}
-	if isTransactionBegin {
-		rs.Metadata = &spannerpb.ResultSetMetadata{Transaction: &spannerpb.Transaction{Id: []byte(tid)}}
+	if begun {
+		rs.Metadata = &spannerpb.ResultSetMetadata{Transaction: &spannerpb.Transaction{Id: []byte(tx.id)}}
 	}
 	return rs, nil
 }
 
+func (s *server) ExecuteBatchDml(ctx context.Context, req *spannerpb.ExecuteBatchDmlRequest) (*spannerpb.ExecuteBatchDmlResponse, error) {
+	if len(req.Statements) == 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "no statements in batch DML")
+	}
+	resp := &spannerpb.ExecuteBatchDmlResponse{}
+	tsel := req.Transaction
+	for _, stmt := range req.Statements {
+		rs, err := s.executeDML(ctx, req.Session, tsel, stmt.Sql, stmt.Params, stmt.ParamTypes)
+		if err != nil {
+			// Statements after a failing one are not executed, and the
+			// failure is reported in the response rather than as an error.
+			resp.Status = status.Convert(err).Proto()
+			return resp, nil
+		}
+		if tid := rs.GetMetadata().GetTransaction().GetId(); tid != nil {
+			// Run the rest of the batch in the transaction the first
+			// statement began.
+			tsel = &spannerpb.TransactionSelector{Selector: &spannerpb.TransactionSelector_Id{Id: tid}}
+		}
+		resp.ResultSets = append(resp.ResultSets, rs)
+	}
+	resp.Status = status.New(codes.OK, "").Proto()
+	return resp, nil
+}
+
 func (s *server) ExecuteStreamingSql(req *spannerpb.ExecuteSqlRequest, stream spannerpb.Spanner_ExecuteStreamingSqlServer) error {
+	// DML statements may also be sent this way, e.g. by ReadWriteTransaction.Query.
+	if _, err := spansql.ParseDMLStmt(req.Sql); err == nil {
+		rs, err := s.executeDML(stream.Context(), req.Session, req.Transaction, req.Sql, req.Params, req.ParamTypes)
+		if err != nil {
+			return err
+		}
+		rsm := rs.Metadata
+		if rsm == nil {
+			rsm = &spannerpb.ResultSetMetadata{}
+		}
+		rsm.RowType = &spannerpb.StructType{}
+		return stream.Send(&spannerpb.PartialResultSet{
+			Metadata: rsm,
+			Stats:    rs.Stats,
+		})
+	}
+
 	tx, cleanup, err := s.readTx(stream.Context(), req.Session, req.Transaction)
 	if err != nil {
 		return err
@@ -1503,6 +1503,103 @@ func TestIntegration_GeneratedColumns(t *testing.T) {
 	}
 }
 
+func TestIntegration_DMLTransactions(t *testing.T) {
+	client, adminClient, _, cleanup := makeClient(t)
+	defer cleanup()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	if err := dropTable(t, adminClient, "Items"); err != nil {
+		t.Fatal(err)
+	}
+	if err := updateDDL(t, adminClient,
+		`CREATE TABLE Items (
+			ID INT64 NOT NULL,
+			Name STRING(MAX),
+			Qty INT64,
+		) PRIMARY KEY (ID)`); err != nil {
+		t.Fatalf("Creating table: %v", err)
+	}
+	_, err := client.Apply(ctx, []*spanner.Mutation{
+		spanner.Insert("Items", []string{"ID", "Name", "Qty"}, []interface{}{1, "a", 1}),
+		spanner.Insert("Items", []string{"ID", "Name", "Qty"}, []interface{}{2, "b", 2}),
+	})
+	if err != nil {
+		t.Fatalf("Inserting sample data: %v", err)
+	}
+	readItems := func() [][]interface{} {
+		t.Helper()
+		return mustSlurpRows(t, client.Single().Query(ctx, spanner.NewStatement("SELECT ID, Name, Qty FROM Items ORDER BY ID")))
+	}
+
+	// Batch DML, and DML sent as a query.
+	_, err = client.ReadWriteTransaction(ctx, func(ctx context.Context, tx *spanner.ReadWriteTransaction) error {
+		counts, err := tx.BatchUpdate(ctx, []spanner.Statement{
+			spanner.NewStatement("UPDATE Items SET Qty = 10 WHERE ID = 1"),
+			spanner.NewStatement("DELETE FROM Items WHERE ID = 2"),
+		})
+		if err != nil {
+			return err
+		}
+		if want := []int64{1, 1}; !reflect.DeepEqual(counts, want) {
+			t.Errorf("Batch DML counts: got %v, want %v", counts, want)
+		}
+		iter := tx.Query(ctx, spanner.NewStatement(`INSERT INTO Items (ID, Name, Qty) VALUES (3, "c", 3)`))
+		defer iter.Stop()
+		if _, err := iter.Next(); err != iterator.Done {
+			return fmt.Errorf("DML query: got %v, want iterator.Done", err)
+		}
+		if iter.RowCount != 1 {
+			t.Errorf("DML query affected %d rows, want 1", iter.RowCount)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Running DML: %v", err)
+	}
+	want := [][]interface{}{
+		{int64(1), "a", int64(10)},
+		{int64(3), "c", int64(3)},
+	}
+	if got := readItems(); !reflect.DeepEqual(got, want) {
+		t.Errorf("After DML:\n got %v\nwant %v", got, want)
+	}
+
+	// DML is undone when the transaction is rolled back.
+	errRollback := fmt.Errorf("roll back")
+	_, err = client.ReadWriteTransaction(ctx, func(ctx context.Context, tx *spanner.ReadWriteTransaction) error {
+		if _, err := tx.Update(ctx, spanner.NewStatement("DELETE FROM Items WHERE ID = 1")); err != nil {
+			return err
+		}
+		return errRollback
+	})
+	if err != errRollback {
+		t.Fatalf("Rolled back transaction: got %v, want %v", err, errRollback)
+	}
+	if got := readItems(); !reflect.DeepEqual(got, want) {
+		t.Errorf("After rollback:\n got %v\nwant %v", got, want)
+	}
+
+	// Batch DML stops at the first failing statement.
+	_, err = client.ReadWriteTransaction(ctx, func(ctx context.Context, tx *spanner.ReadWriteTransaction) error {
+		counts, err := tx.BatchUpdate(ctx, []spanner.Statement{
+			spanner.NewStatement("UPDATE Items SET Qty = 0 WHERE ID = 3"),
+			spanner.NewStatement("UPDATE NoSuchTable SET Qty = 0 WHERE ID = 3"),
+		})
+		if err == nil {
+			t.Error("Batch DML with bad statement: got nil, want error")
+		}
+		if want := []int64{1}; !reflect.DeepEqual(counts, want) {
+			t.Errorf("Batch DML with bad statement counts: got %v, want %v", counts, want)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Running failing batch DML: %v", err)
+	}
+}
+
 func TestIntegration_Views(t *testing.T) {
 	_, adminClient, _, cleanup := makeClient(t)
 	defer cleanup()
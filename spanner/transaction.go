@@ -111,6 +111,21 @@ type TransactionOptions struct {
 	// the transaction lock mode is used to specify a concurrency mode for the
 	// read/query operations. It works for a read/write transaction only.
 	ReadLockMode sppb.TransactionOptions_ReadWrite_ReadLockMode
+
+	// LeaderRouting specifies whether the requests of a read/write transaction
+	// are routed to the leader region, overriding
+	// ClientConfig.DisableRouteToLeader.
+	LeaderRouting LeaderRouting
+
+	// DatabaseRole is the database role with which a read/write transaction
+	// is run, overriding ClientConfig.DatabaseRole. Sessions for the role are
+	// kept in a separate pool that shares the connections of the client. Use
+	// ReadOnlyTransaction.WithDatabaseRole for read-only transactions.
+	//
+	// A client can use up to 8 database roles other than its own. The pools
+	// of these roles together open at most SessionPoolConfig.MaxOpened
+	// sessions, split evenly between the roles.
+	DatabaseRole string
 }
 
 // merge combines two TransactionOptions that the input parameter will have higher
@@ -120,6 +135,8 @@ func (to TransactionOptions) merge(opts TransactionOptions) TransactionOptions {
 		CommitOptions:  to.CommitOptions.merge(opts.CommitOptions),
 		TransactionTag: to.TransactionTag,
 		CommitPriority: to.CommitPriority,
+		LeaderRouting:  to.LeaderRouting,
+		DatabaseRole:   to.DatabaseRole,
 	}
 	if opts.TransactionTag != "" {
 		merged.TransactionTag = opts.TransactionTag
@@ -130,6 +147,12 @@ func (to TransactionOptions) merge(opts TransactionOptions) TransactionOptions {
 	if opts.ReadLockMode != sppb.TransactionOptions_ReadWrite_READ_LOCK_MODE_UNSPECIFIED {
 		merged.ReadLockMode = opts.ReadLockMode
 	}
+	if opts.LeaderRouting != LeaderRoutingDefault {
+		merged.LeaderRouting = opts.LeaderRouting
+	}
+	if opts.DatabaseRole != "" {
+		merged.DatabaseRole = opts.DatabaseRole
+	}
 	return merged
 }
 
@@ -169,17 +192,24 @@ type ReadOptions struct {
 	// If this is for a partitioned read and DataBoostEnabled field is set to true, the request will be executed
 	// via Spanner independent compute resources. Setting this option for regular read operations has no effect.
 	DataBoostEnabled bool
+
+	// DirectedReadOptions selects the replicas that serve the read, by
+	// location and type, and whether the read may fail over to other
+	// replicas. It overrides ClientConfig.DirectedReadOptions, and may only
+	// be set for reads in read-only transactions and single-use reads.
+	DirectedReadOptions *sppb.DirectedReadOptions
 }
 
 // merge combines two ReadOptions that the input parameter will have higher
 // order of precedence.
 func (ro ReadOptions) merge(opts ReadOptions) ReadOptions {
 	merged := ReadOptions{
-		Index:            ro.Index,
-		Limit:            ro.Limit,
-		Priority:         ro.Priority,
-		RequestTag:       ro.RequestTag,
-		DataBoostEnabled: ro.DataBoostEnabled,
+		Index:               ro.Index,
+		Limit:               ro.Limit,
+		Priority:            ro.Priority,
+		RequestTag:          ro.RequestTag,
+		DataBoostEnabled:    ro.DataBoostEnabled,
+		DirectedReadOptions: ro.DirectedReadOptions,
 	}
 	if opts.Index != "" {
 		merged.Index = opts.Index
@@ -196,6 +226,9 @@ func (ro ReadOptions) merge(opts ReadOptions) ReadOptions {
 	if opts.DataBoostEnabled {
 		merged.DataBoostEnabled = opts.DataBoostEnabled
 	}
+	if opts.DirectedReadOptions != nil {
+		merged.DirectedReadOptions = opts.DirectedReadOptions
+	}
 	return merged
 }
 
@@ -227,6 +260,7 @@ func (t *txReadOnly) ReadWithOptions(ctx context.Context, table string, keys Key
 	prio := t.ro.Priority
 	requestTag := t.ro.RequestTag
 	dataBoostEnabled := t.ro.DataBoostEnabled
+	directedReadOptions := t.ro.DirectedReadOptions
 	if opts != nil {
 		index = opts.Index
 		if opts.Limit > 0 {
@@ -237,6 +271,9 @@ func (t *txReadOnly) ReadWithOptions(ctx context.Context, table string, keys Key
 		if opts.DataBoostEnabled {
 			dataBoostEnabled = opts.DataBoostEnabled
 		}
+		if opts.DirectedReadOptions != nil {
+			directedReadOptions = opts.DirectedReadOptions
+		}
 	}
 	var setTransactionID func(transactionID)
 	if _, ok := ts.Selector.(*sppb.TransactionSelector_Begin); ok {
@@ -250,16 +287,17 @@ func (t *txReadOnly) ReadWithOptions(ctx context.Context, table string, keys Key
 		func(ctx context.Context, resumeToken []byte) (streamingReceiver, error) {
 			client, err := client.StreamingRead(ctx,
 				&sppb.ReadRequest{
-					Session:          t.sh.getID(),
-					Transaction:      t.getTransactionSelector(),
-					Table:            table,
-					Index:            index,
-					Columns:          columns,
-					KeySet:           kset,
-					ResumeToken:      resumeToken,
-					Limit:            int64(limit),
-					RequestOptions:   createRequestOptions(prio, requestTag, t.txOpts.TransactionTag),
-					DataBoostEnabled: dataBoostEnabled,
+					Session:             t.sh.getID(),
+					Transaction:         t.getTransactionSelector(),
+					Table:               table,
+					Index:               index,
+					Columns:             columns,
+					KeySet:              kset,
+					ResumeToken:         resumeToken,
+					Limit:               int64(limit),
+					RequestOptions:      createRequestOptions(prio, requestTag, t.txOpts.TransactionTag),
+					DataBoostEnabled:    dataBoostEnabled,
+					DirectedReadOptions: directedReadOptions,
 				})
 			if err != nil {
 				if _, ok := t.getTransactionSelector().GetSelector().(*sppb.TransactionSelector_Begin); ok {
@@ -374,17 +412,30 @@ type QueryOptions struct {
 	// If this is for a partitioned query and DataBoostEnabled field is set to true, the request will be executed
 	// via Spanner independent compute resources. Setting this option for regular query operations has no effect.
 	DataBoostEnabled bool
+
+	// LeaderRouting specifies whether the requests of the statement are
+	// routed to the leader region, overriding the setting of its transaction
+	// and ClientConfig.DisableRouteToLeader.
+	LeaderRouting LeaderRouting
+
+	// DirectedReadOptions selects the replicas that serve the query, by
+	// location and type, and whether the query may fail over to other
+	// replicas. It overrides ClientConfig.DirectedReadOptions, and may only
+	// be set for queries in read-only transactions and single-use queries.
+	DirectedReadOptions *sppb.DirectedReadOptions
 }
 
 // merge combines two QueryOptions that the input parameter will have higher
 // order of precedence.
 func (qo QueryOptions) merge(opts QueryOptions) QueryOptions {
 	merged := QueryOptions{
-		Mode:             qo.Mode,
-		Options:          &sppb.ExecuteSqlRequest_QueryOptions{},
-		RequestTag:       qo.RequestTag,
-		Priority:         qo.Priority,
-		DataBoostEnabled: qo.DataBoostEnabled,
+		Mode:                qo.Mode,
+		Options:             &sppb.ExecuteSqlRequest_QueryOptions{},
+		RequestTag:          qo.RequestTag,
+		Priority:            qo.Priority,
+		DataBoostEnabled:    qo.DataBoostEnabled,
+		LeaderRouting:       qo.LeaderRouting,
+		DirectedReadOptions: qo.DirectedReadOptions,
 	}
 	if opts.Mode != nil {
 		merged.Mode = opts.Mode
@@ -398,6 +449,12 @@ func (qo QueryOptions) merge(opts QueryOptions) QueryOptions {
 	if opts.DataBoostEnabled {
 		merged.DataBoostEnabled = opts.DataBoostEnabled
 	}
+	if opts.LeaderRouting != LeaderRoutingDefault {
+		merged.LeaderRouting = opts.LeaderRouting
+	}
+	if opts.DirectedReadOptions != nil {
+		merged.DirectedReadOptions = opts.DirectedReadOptions
+	}
 	proto.Merge(merged.Options, qo.Options)
 	proto.Merge(merged.Options, opts.Options)
 	return merged
@@ -426,9 +483,10 @@ func createRequestOptions(prio sppb.RequestOptions_Priority, requestTag, transac
 func (t *txReadOnly) Query(ctx context.Context, statement Statement) *RowIterator {
 	mode := sppb.ExecuteSqlRequest_NORMAL
 	return t.query(ctx, statement, QueryOptions{
-		Mode:     &mode,
-		Options:  t.qo.Options,
-		Priority: t.qo.Priority,
+		Mode:                &mode,
+		Options:             t.qo.Options,
+		Priority:            t.qo.Priority,
+		DirectedReadOptions: t.qo.DirectedReadOptions,
 	})
 }
 
@@ -445,9 +503,10 @@ func (t *txReadOnly) QueryWithOptions(ctx context.Context, statement Statement,
 func (t *txReadOnly) QueryWithStats(ctx context.Context, statement Statement) *RowIterator {
 	mode := sppb.ExecuteSqlRequest_PROFILE
 	return t.query(ctx, statement, QueryOptions{
-		Mode:     &mode,
-		Options:  t.qo.Options,
-		Priority: t.qo.Priority,
+		Mode:                &mode,
+		Options:             t.qo.Options,
+		Priority:            t.qo.Priority,
+		DirectedReadOptions: t.qo.DirectedReadOptions,
 	})
 }
 
@@ -455,9 +514,10 @@ func (t *txReadOnly) QueryWithStats(ctx context.Context, statement Statement) *R
 func (t *txReadOnly) AnalyzeQuery(ctx context.Context, statement Statement) (*sppb.QueryPlan, error) {
 	mode := sppb.ExecuteSqlRequest_PLAN
 	iter := t.query(ctx, statement, QueryOptions{
-		Mode:     &mode,
-		Options:  t.qo.Options,
-		Priority: t.qo.Priority,
+		Mode:                &mode,
+		Options:             t.qo.Options,
+		Priority:            t.qo.Priority,
+		DirectedReadOptions: t.qo.DirectedReadOptions,
 	})
 	defer iter.Stop()
 	for {
@@ -490,7 +550,7 @@ func (t *txReadOnly) query(ctx context.Context, statement Statement, options Que
 	}
 	client := sh.getClient()
 	return streamWithReplaceSessionFunc(
-		contextWithOutgoingMetadata(ctx, sh.getMetadata(), t.disableRouteToLeader),
+		contextWithOutgoingMetadata(ctx, sh.getMetadata(), options.LeaderRouting.disableRouteToLeader(t.disableRouteToLeader)),
 		sh.session.logger,
 		func(ctx context.Context, resumeToken []byte) (streamingReceiver, error) {
 			req.ResumeToken = resumeToken
@@ -538,16 +598,17 @@ func (t *txReadOnly) prepareExecuteSQL(ctx context.Context, stmt Statement, opti
 		mode = *options.Mode
 	}
 	req := &sppb.ExecuteSqlRequest{
-		Session:          sid,
-		Transaction:      ts,
-		Sql:              stmt.SQL,
-		QueryMode:        mode,
-		Seqno:            atomic.AddInt64(&t.sequenceNumber, 1),
-		Params:           params,
-		ParamTypes:       paramTypes,
-		QueryOptions:     options.Options,
-		RequestOptions:   createRequestOptions(options.Priority, options.RequestTag, t.txOpts.TransactionTag),
-		DataBoostEnabled: options.DataBoostEnabled,
+		Session:             sid,
+		Transaction:         ts,
+		Sql:                 stmt.SQL,
+		QueryMode:           mode,
+		Seqno:               atomic.AddInt64(&t.sequenceNumber, 1),
+		Params:              params,
+		ParamTypes:          paramTypes,
+		QueryOptions:        options.Options,
+		RequestOptions:      createRequestOptions(options.Priority, options.RequestTag, t.txOpts.TransactionTag),
+		DataBoostEnabled:    options.DataBoostEnabled,
+		DirectedReadOptions: options.DirectedReadOptions,
 	}
 	return req, sh, nil
 }
@@ -617,6 +678,8 @@ type ReadOnlyTransaction struct {
 	rts time.Time
 	// tb is the read staleness bound specification for transactional reads.
 	tb TimestampBound
+	// roleErr is the error of WithDatabaseRole, returned by the first read.
+	roleErr error
 }
 
 // errTxInitTimeout returns error for timeout in waiting for initialization of
@@ -668,7 +731,7 @@ func (t *ReadOnlyTransaction) begin(ctx context.Context) error {
 	}()
 	// Retry the BeginTransaction call if a 'Session not found' is returned.
 	for {
-		sh, err = t.sp.take(ctx)
+		sh, err = t.sp.takeMultiplexed(ctx)
 		if err != nil {
 			return err
 		}
@@ -729,6 +792,12 @@ func (t *ReadOnlyTransaction) acquire(ctx context.Context) (*sessionHandle, *spp
 	if err := checkNestedTxn(ctx); err != nil {
 		return nil, nil, err
 	}
+	t.mu.Lock()
+	roleErr := t.roleErr
+	t.mu.Unlock()
+	if roleErr != nil {
+		return nil, nil, roleErr
+	}
 	if t.singleUse {
 		return t.acquireSingleUse(ctx)
 	}
@@ -753,7 +822,7 @@ func (t *ReadOnlyTransaction) acquireSingleUse(ctx context.Context) (*sessionHan
 				},
 			},
 		}
-		sh, err := t.sp.take(ctx)
+		sh, err := t.sp.takeMultiplexed(ctx)
 		if err != nil {
 			return nil, nil, err
 		}
@@ -928,6 +997,26 @@ func (t *ReadOnlyTransaction) WithTimestampBound(tb TimestampBound) *ReadOnlyTra
 	return t
 }
 
+// WithDatabaseRole specifies the database role with which the transaction is
+// run, instead of ClientConfig.DatabaseRole. Like WithTimestampBound, it only
+// takes effect before the first read or query. It has no effect on a
+// BatchReadOnlyTransaction.
+func (t *ReadOnlyTransaction) WithDatabaseRole(role string) *ReadOnlyTransaction {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.state == txNew && t.sh == nil && t.sp != nil {
+		sp, err := t.sp.forRole(role)
+		switch {
+		case err == errTooManyDatabaseRoles:
+			t.roleErr = err
+		case err == nil:
+			t.sp = sp
+		}
+		// An invalid pool is kept, so that the first read fails.
+	}
+	return t
+}
+
 // ReadWriteTransaction provides a locking read-write transaction.
 //
 // This type of transaction is the only way to write data into Cloud Spanner;
@@ -1061,7 +1150,7 @@ func (t *ReadWriteTransaction) update(ctx context.Context, stmt Statement, opts
 	}
 
 	var md metadata.MD
-	resultSet, err := sh.getClient().ExecuteSql(contextWithOutgoingMetadata(ctx, sh.getMetadata(), t.disableRouteToLeader), req, gax.WithGRPCOptions(grpc.Header(&md)))
+	resultSet, err := sh.getClient().ExecuteSql(contextWithOutgoingMetadata(ctx, sh.getMetadata(), opts.LeaderRouting.disableRouteToLeader(t.disableRouteToLeader)), req, gax.WithGRPCOptions(grpc.Header(&md)))
 
 	if getGFELatencyMetricsFlag() && md != nil && t.ct != nil {
 		if err := createContextAndCaptureGFELatencyMetrics(ctx, t.ct, md, "update"); err != nil {
@@ -1150,7 +1239,7 @@ func (t *ReadWriteTransaction) batchUpdateWithOptions(ctx context.Context, stmts
 	}
 
 	var md metadata.MD
-	resp, err := sh.getClient().ExecuteBatchDml(contextWithOutgoingMetadata(ctx, sh.getMetadata(), t.disableRouteToLeader), &sppb.ExecuteBatchDmlRequest{
+	resp, err := sh.getClient().ExecuteBatchDml(contextWithOutgoingMetadata(ctx, sh.getMetadata(), opts.LeaderRouting.disableRouteToLeader(t.disableRouteToLeader)), &sppb.ExecuteBatchDmlRequest{
 		Session:        sh.getID(),
 		Transaction:    ts,
 		Statements:     sppbStmts,
@@ -1599,7 +1688,12 @@ func NewReadWriteStmtBasedTransactionWithOptions(ctx context.Context, c *Client,
 		err error
 		t   *ReadWriteStmtBasedTransaction
 	)
-	sh, err = c.idleSessions.take(ctx)
+	txOpts := c.txo.merge(options)
+	sp, err := c.idleSessions.forRole(txOpts.DatabaseRole)
+	if err != nil {
+		return nil, err
+	}
+	sh, err = sp.take(ctx)
 	if err != nil {
 		// If session retrieval fails, just fail the transaction.
 		return nil, err
@@ -1610,11 +1704,12 @@ func NewReadWriteStmtBasedTransactionWithOptions(ctx context.Context, c *Client,
 		},
 	}
 	t.txReadOnly.sh = sh
+	t.txReadOnly.sp = sp
 	t.txReadOnly.txReadEnv = t
 	t.txReadOnly.qo = c.qo
 	t.txReadOnly.ro = c.ro
-	t.txReadOnly.disableRouteToLeader = c.disableRouteToLeader
-	t.txOpts = c.txo.merge(options)
+	t.txOpts = txOpts
+	t.txReadOnly.disableRouteToLeader = t.txOpts.LeaderRouting.disableRouteToLeader(c.disableRouteToLeader)
 	t.ct = c.ct
 
 	// always explicit begin the transactions
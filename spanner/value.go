@@ -36,6 +36,8 @@ import (
 	"github.com/golang/protobuf/proto"
 	proto3 "github.com/golang/protobuf/ptypes/struct"
 	"google.golang.org/grpc/codes"
+	protov2 "google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
 )
 
 const (
@@ -1946,6 +1948,21 @@ func decodeValue(v *proto3.Value, t *sppb.Type, ptr interface{}, opts ...decodeO
 			return decodedVal.DecodeSpanner(x)
 		}
 
+		// Check if the pointer is a generated protocol buffer message, or a
+		// pointer to a generated message or enum, or to a slice of them.
+		if m, ok := ptr.(protoreflect.ProtoMessage); ok && isProtoMessageType(reflect.TypeOf(ptr)) {
+			if reflect.ValueOf(ptr).IsNil() {
+				return errNilDst(ptr)
+			}
+			return decodeProtoMessage(v, t, m)
+		}
+		if vp := reflect.ValueOf(ptr); vp.Kind() == reflect.Ptr && protoType(vp.Type().Elem()) != nil {
+			if vp.IsNil() {
+				return errNilDst(ptr)
+			}
+			return decodeProtoValue(v, t, vp.Elem())
+		}
+
 		// Check if the pointer is a variant of a base type.
 		decodableType := getDecodableSpannerType(ptr, true)
 		if decodableType != spannerTypeUnknown {
@@ -3743,6 +3760,12 @@ func encodeValue(v interface{}) (*proto3.Value, *sppb.Type, error) {
 			return encodeValue(nv)
 		}
 
+		// Check if the value is a generated protocol buffer message or enum,
+		// a pointer to an enum, or a slice of them.
+		if pt := protoType(reflect.TypeOf(v)); pt != nil {
+			return encodeProtoValue(v, pt)
+		}
+
 		// Check if the value is a variant of a base type.
 		decodableType := getDecodableSpannerType(v, false)
 		if decodableType != spannerTypeUnknown && decodableType != spannerTypeInvalid {
@@ -4054,6 +4077,9 @@ func isSupportedMutationType(v interface{}) bool {
 		if _, ok := v.(Encoder); ok {
 			return true
 		}
+		if protoType(reflect.TypeOf(v)) != nil {
+			return true
+		}
 
 		decodableType := getDecodableSpannerType(v, false)
 		return decodableType != spannerTypeUnknown && decodableType != spannerTypeInvalid
@@ -4091,6 +4117,147 @@ func encodeArray(len int, at func(int) interface{}) (*proto3.Value, error) {
 	return listProto(vs...), nil
 }
 
+var (
+	typeOfProtoMessage = reflect.TypeOf((*protoreflect.ProtoMessage)(nil)).Elem()
+	typeOfProtoEnum    = reflect.TypeOf((*protoreflect.Enum)(nil)).Elem()
+)
+
+// isProtoMessageType returns true if t is a generated protocol buffer message
+// type, such as *pb.Singer.
+func isProtoMessageType(t reflect.Type) bool {
+	return t.Kind() == reflect.Ptr && t.Elem().Kind() == reflect.Struct && t.Implements(typeOfProtoMessage)
+}
+
+// isProtoEnumType returns true if t is a generated protocol buffer enum type,
+// such as pb.Genre.
+func isProtoEnumType(t reflect.Type) bool {
+	return t.Kind() == reflect.Int32 && t.Implements(typeOfProtoEnum)
+}
+
+// protoType returns the Spanner type of the Go type t if t is a generated
+// protocol buffer message, a generated enum, a pointer to an enum, or a slice
+// of those, and nil otherwise. The fully qualified name of the proto type is
+// taken from the descriptor of the generated type.
+func protoType(t reflect.Type) *sppb.Type {
+	switch {
+	case isProtoMessageType(t):
+		m := reflect.Zero(t).Interface().(protoreflect.ProtoMessage)
+		return &sppb.Type{Code: sppb.TypeCode_PROTO, ProtoTypeFqn: string(m.ProtoReflect().Descriptor().FullName())}
+	case isProtoEnumType(t):
+		e := reflect.Zero(t).Interface().(protoreflect.Enum)
+		return &sppb.Type{Code: sppb.TypeCode_ENUM, ProtoTypeFqn: string(e.Descriptor().FullName())}
+	case t.Kind() == reflect.Ptr && isProtoEnumType(t.Elem()):
+		return protoType(t.Elem())
+	case t.Kind() == reflect.Slice:
+		// Arrays of arrays are not supported.
+		if et := protoType(t.Elem()); et != nil && et.Code != sppb.TypeCode_ARRAY {
+			return listType(et)
+		}
+	}
+	return nil
+}
+
+// encodeProtoValue encodes v, whose Spanner type pt was returned by protoType.
+// A message is encoded as its serialized bytes and an enum as its number.
+func encodeProtoValue(v interface{}, pt *sppb.Type) (*proto3.Value, *sppb.Type, error) {
+	rv := reflect.ValueOf(v)
+	if (rv.Kind() == reflect.Ptr || rv.Kind() == reflect.Slice) && rv.IsNil() {
+		return nullProto(), pt, nil
+	}
+	switch pt.Code {
+	case sppb.TypeCode_PROTO:
+		b, err := protov2.Marshal(v.(protoreflect.ProtoMessage))
+		if err != nil {
+			return nil, nil, err
+		}
+		return bytesProto(b), pt, nil
+	case sppb.TypeCode_ENUM:
+		return intProto(reflect.Indirect(rv).Int()), pt, nil
+	}
+	pb, err := encodeArray(rv.Len(), func(i int) interface{} { return rv.Index(i).Interface() })
+	if err != nil {
+		return nil, nil, err
+	}
+	return pb, pt, nil
+}
+
+// decodeProtoMessage decodes v, a PROTO or BYTES value, into the generated
+// message m.
+func decodeProtoMessage(v *proto3.Value, t *sppb.Type, m protoreflect.ProtoMessage) error {
+	if t.Code != sppb.TypeCode_PROTO && t.Code != sppb.TypeCode_BYTES {
+		return errTypeMismatch(t.Code, sppb.TypeCode_TYPE_CODE_UNSPECIFIED, m)
+	}
+	if _, isNull := v.Kind.(*proto3.Value_NullValue); isNull {
+		return errDstNotForNull(m)
+	}
+	x, err := getStringValue(v)
+	if err != nil {
+		return err
+	}
+	b, err := base64.StdEncoding.DecodeString(x)
+	if err != nil {
+		return errBadEncoding(v, err)
+	}
+	if err := protov2.Unmarshal(b, m); err != nil {
+		return errBadEncoding(v, err)
+	}
+	return nil
+}
+
+// decodeProtoValue decodes v, a value of Spanner type t, into dst, whose type
+// is one that protoType returns a Spanner type for. A NULL value sets dst to
+// nil if dst is a pointer or a slice.
+func decodeProtoValue(v *proto3.Value, t *sppb.Type, dst reflect.Value) error {
+	if _, isNull := v.Kind.(*proto3.Value_NullValue); isNull {
+		if dst.Kind() != reflect.Ptr && dst.Kind() != reflect.Slice {
+			return errDstNotForNull(dst.Addr().Interface())
+		}
+		dst.Set(reflect.Zero(dst.Type()))
+		return nil
+	}
+	switch protoType(dst.Type()).Code {
+	case sppb.TypeCode_PROTO:
+		m := reflect.New(dst.Type().Elem())
+		if err := decodeProtoMessage(v, t, m.Interface().(protoreflect.ProtoMessage)); err != nil {
+			return err
+		}
+		dst.Set(m)
+	case sppb.TypeCode_ENUM:
+		if t.Code != sppb.TypeCode_ENUM && t.Code != sppb.TypeCode_INT64 {
+			return errTypeMismatch(t.Code, sppb.TypeCode_TYPE_CODE_UNSPECIFIED, dst.Addr().Interface())
+		}
+		x, err := getStringValue(v)
+		if err != nil {
+			return err
+		}
+		n, err := strconv.ParseInt(x, 10, 32)
+		if err != nil {
+			return errBadEncoding(v, err)
+		}
+		if dst.Kind() == reflect.Ptr {
+			dst.Set(reflect.New(dst.Type().Elem()))
+			dst = dst.Elem()
+		}
+		dst.SetInt(n)
+	case sppb.TypeCode_ARRAY:
+		if t.Code != sppb.TypeCode_ARRAY || t.ArrayElementType == nil {
+			return errTypeMismatch(t.Code, t.GetArrayElementType().GetCode(), dst.Addr().Interface())
+		}
+		x, err := getListValue(v)
+		if err != nil {
+			return err
+		}
+		s := reflect.MakeSlice(dst.Type(), len(x.Values), len(x.Values))
+		for i, ev := range x.Values {
+			if err := decodeProtoValue(ev, t.ArrayElementType, s.Index(i)); err != nil {
+				return err
+			}
+		}
+		dst.Set(s)
+	}
+	return nil
+}
+
 func spannerTagParser(t reflect.StructTag) (name string, keep bool, other interface{}, err error) {
 	if s := t.Get("spanner"); s != "" {
 		if s == "-" {
@@ -33,6 +33,7 @@ import (
 	proto3 "github.com/golang/protobuf/ptypes/struct"
 	structpb "github.com/golang/protobuf/ptypes/struct"
 	"github.com/google/go-cmp/cmp"
+	protov2 "google.golang.org/protobuf/proto"
 )
 
 var (
@@ -1887,6 +1888,90 @@ func TestDecodeValueErrors(t *testing.T) {
 	}
 }
 
+func TestEncodeDecodeProtoValue(t *testing.T) {
+	// Generated messages and enums of the Spanner API stand in for those of
+	// the application.
+	msg := &sppb.Type{Code: sppb.TypeCode_STRING}
+	msgBytes, err := protov2.Marshal(msg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	msgType := &sppb.Type{Code: sppb.TypeCode_PROTO, ProtoTypeFqn: "google.spanner.v1.Type"}
+	enumType := &sppb.Type{Code: sppb.TypeCode_ENUM, ProtoTypeFqn: "google.spanner.v1.TypeCode"}
+	enum := sppb.TypeCode_JSON
+	var nilMsg *sppb.Type
+	var nilEnum *sppb.TypeCode
+
+	for i, test := range []struct {
+		in     interface{}
+		want   *proto3.Value
+		wantT  *sppb.Type
+		decode interface{}
+	}{
+		{msg, bytesProto(msgBytes), msgType, &sppb.Type{}},
+		{msg, bytesProto(msgBytes), msgType, new(*sppb.Type)},
+		{nilMsg, nullProto(), msgType, new(*sppb.Type)},
+		{[]*sppb.Type{msg, nil}, listProto(bytesProto(msgBytes), nullProto()), listType(msgType), &[]*sppb.Type{}},
+		{[]*sppb.Type(nil), nullProto(), listType(msgType), &[]*sppb.Type{}},
+		{enum, intProto(int64(enum)), enumType, new(sppb.TypeCode)},
+		{&enum, intProto(int64(enum)), enumType, new(*sppb.TypeCode)},
+		{nilEnum, nullProto(), enumType, new(*sppb.TypeCode)},
+		{[]sppb.TypeCode{enum, sppb.TypeCode_BOOL}, listProto(intProto(int64(enum)), intProto(int64(sppb.TypeCode_BOOL))), listType(enumType), &[]sppb.TypeCode{}},
+		{[]*sppb.TypeCode{&enum, nil}, listProto(intProto(int64(enum)), nullProto()), listType(enumType), &[]*sppb.TypeCode{}},
+	} {
+		got, gotT, err := encodeValue(test.in)
+		if err != nil {
+			t.Fatalf("#%d: encodeValue(%v): %v", i, test.in, err)
+		}
+		if !testEqual(got, test.want) || !testEqual(gotT, test.wantT) {
+			t.Errorf("#%d: encodeValue(%v)\ngot:  %v, %v\nwant: %v, %v", i, test.in, got, gotT, test.want, test.wantT)
+		}
+		if !isSupportedMutationType(test.in) {
+			t.Errorf("#%d: %T is not a supported mutation type", i, test.in)
+		}
+		if err := decodeValue(got, gotT, test.decode); err != nil {
+			t.Fatalf("#%d: decodeValue(%v): %v", i, got, err)
+		}
+		decoded := reflect.ValueOf(test.decode).Elem().Interface()
+		if _, ok := test.decode.(*sppb.Type); ok {
+			decoded = test.decode
+		}
+		if !testEqual(decoded, test.in) {
+			t.Errorf("#%d: decodeValue(%v)\ngot:  %v\nwant: %v", i, got, decoded, test.in)
+		}
+	}
+
+	// Generated messages and enums can also be decoded from BYTES and INT64
+	// values.
+	gotMsg := &sppb.Type{}
+	if err := decodeValue(bytesProto(msgBytes), bytesType(), gotMsg); err != nil || !testEqual(gotMsg, msg) {
+		t.Errorf("decoding BYTES: got %v, %v, want %v", gotMsg, err, msg)
+	}
+	var gotEnum sppb.TypeCode
+	if err := decodeValue(intProto(int64(enum)), intType(), &gotEnum); err != nil || gotEnum != enum {
+		t.Errorf("decoding INT64: got %v, %v, want %v", gotEnum, err, enum)
+	}
+
+	for i, test := range []struct {
+		in  *proto3.Value
+		t   *sppb.Type
+		dst interface{}
+	}{
+		{nullProto(), msgType, &sppb.Type{}},
+		{nullProto(), enumType, new(sppb.TypeCode)},
+		{listProto(nullProto()), listType(enumType), &[]sppb.TypeCode{}},
+		{stringProto("abc"), stringType(), &sppb.Type{}},
+		{intProto(1), enumType, new(*sppb.Type)},
+		{stringProto("abc"), enumType, new(sppb.TypeCode)},
+		{bytesProto([]byte{0xff}), msgType, &sppb.Type{}},
+		{intProto(1), enumType, &[]sppb.TypeCode{}},
+	} {
+		if err := decodeValue(test.in, test.t, test.dst); err == nil {
+			t.Errorf("#%d: decodeValue(%v, %v) into %T: got nil, want error", i, test.in, test.t, test.dst)
+		}
+	}
+}
+
 func TestGetDecodableSpannerType(t *testing.T) {
 	type CustomString string
 	type CustomInt64 int64